@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clusterbackup"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/tar"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type backupClusterOptions struct {
+	clusterName string
+	kubeconfig  string
+	output      string
+}
+
+var bco = &backupClusterOptions{}
+
+var backupClusterCmd = &cobra.Command{
+	Use:          "cluster",
+	Short:        "Backup a management cluster",
+	Long:         "Backup the EKS Anywhere, Tinkerbell Hardware and CAPI objects of a management cluster and its workload clusters to a compressed archive, which can be used to move the workload clusters to a new management cluster with restore workloads",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         bco.backupCluster,
+}
+
+func init() {
+	backupCmd.AddCommand(backupClusterCmd)
+	backupClusterCmd.Flags().StringVarP(&bco.clusterName, "cluster-name", "n", "", "Name of the management cluster to backup")
+	backupClusterCmd.Flags().StringVar(&bco.kubeconfig, "kubeconfig", "", "Management cluster kubeconfig file. Defaults to the kubeconfig in the cluster folder")
+	backupClusterCmd.Flags().StringVarP(&bco.output, "output", "o", "", "Path of the backup archive. Defaults to <cluster-name>-backup-<timestamp>.tar.gz")
+
+	if err := backupClusterCmd.MarkFlagRequired("cluster-name"); err != nil {
+		logger.Fatal(err, "marking cluster-name as required")
+	}
+}
+
+func (o *backupClusterOptions) backupCluster(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	cluster := &types.Cluster{
+		Name:           o.clusterName,
+		KubeconfigFile: o.kubeconfig,
+	}
+	if cluster.KubeconfigFile == "" {
+		cluster.KubeconfigFile = kubeconfig.FromClusterName(o.clusterName)
+	}
+
+	output := o.output
+	if output == "" {
+		output = fmt.Sprintf("%s-backup-%s.tar.gz", o.clusterName, time.Now().Format("2006-01-02T15_04_05"))
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableBuilder().
+		WithKubectl().
+		WithClusterctl().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	kubeClient := deps.UnAuthKubeClient.KubeconfigClient(cluster.KubeconfigFile)
+	backuper := clusterbackup.NewBackuper(kubeClient, deps.Clusterctl, tar.NewGzipPackager(), version.Get().GitVersion)
+	if err := backuper.Backup(ctx, cluster, output); err != nil {
+		return fmt.Errorf("backing up cluster: %v", err)
+	}
+
+	logger.MarkSuccess("Cluster backup written", "archive", output)
+	return nil
+}
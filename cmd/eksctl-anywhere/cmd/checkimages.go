@@ -16,7 +16,8 @@ import (
 )
 
 type checkImagesOptions struct {
-	fileName string
+	fileName          string
+	imageVerification imageVerificationOptions
 }
 
 var cio = &checkImagesOptions{}
@@ -24,6 +25,7 @@ var cio = &checkImagesOptions{}
 func init() {
 	rootCmd.AddCommand(checkImagesCommand)
 	checkImagesCommand.Flags().StringVarP(&cio.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	applyImageVerificationFlags(checkImagesCommand.Flags(), &cio.imageVerification)
 	err := checkImagesCommand.MarkFlagRequired("filename")
 	if err != nil {
 		log.Fatalf("Error marking filename flag as required: %v", err)
@@ -33,7 +35,8 @@ func init() {
 var checkImagesCommand = &cobra.Command{
 	Use:   "check-images",
 	Short: "Check images used by EKS Anywhere do exist in the target registry",
-	Long:  "This command is used to check images used by EKS-Anywhere for cluster provisioning do exist in the target registry",
+	Long: `This command is used to check images used by EKS-Anywhere for cluster provisioning do exist in the target registry.
+When a public key is provided with --verify-images-key, the cosign signatures or attestations of the images are verified as well.`,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		cmd.Flags().VisitAll(func(flag *pflag.Flag) {
 			if err := viper.BindPFlag(flag.Name, flag); err != nil {
@@ -44,11 +47,11 @@ var checkImagesCommand = &cobra.Command{
 	},
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return checkImages(cmd.Context(), cio.fileName)
+		return checkImages(cmd.Context(), cio.fileName, cio.imageVerification)
 	},
 }
 
-func checkImages(context context.Context, clusterSpecPath string) error {
+func checkImages(context context.Context, clusterSpecPath string, imageVerification imageVerificationOptions) error {
 	images, err := getImages(clusterSpecPath, "")
 	if err != nil {
 		return err
@@ -59,9 +62,12 @@ func checkImages(context context.Context, clusterSpecPath string) error {
 		return err
 	}
 
+	registryMirror := registrymirror.FromCluster(clusterSpec.Cluster)
 	checkImageExistence := artifacts.CheckImageExistence{}
+	uris := make([]string, 0, len(images))
 	for _, image := range images {
-		myImageURI := registrymirror.FromCluster(clusterSpec.Cluster).ReplaceRegistry(image.URI)
+		uris = append(uris, image.URI)
+		myImageURI := registryMirror.ReplaceRegistry(image.URI)
 		checkImageExistence.ImageUri = myImageURI
 		if err = checkImageExistence.Run(context); err != nil {
 			fmt.Println(err.Error())
@@ -71,5 +77,5 @@ func checkImages(context context.Context, clusterSpecPath string) error {
 		}
 	}
 
-	return nil
+	return imageVerification.verifyFromRegistry(context, registryMirror, uris)
 }
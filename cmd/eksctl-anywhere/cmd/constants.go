@@ -3,6 +3,7 @@ package cmd
 const (
 	imagesTarFile               = "images.tar"
 	eksaToolsImageTarFile       = "tools-image.tar"
+	imagesOCILayoutDir          = "images-oci-layout"
 	cpWaitTimeoutFlag           = "control-plane-wait-timeout"
 	externalEtcdWaitTimeoutFlag = "external-etcd-wait-timeout"
 	perMachineWaitTimeoutFlag   = "per-machine-wait-timeout"
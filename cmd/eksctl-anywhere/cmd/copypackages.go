@@ -3,10 +3,8 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -14,8 +12,6 @@ import (
 	"gopkg.in/yaml.v2"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	helmRegistry "helm.sh/helm/v3/pkg/registry"
-	"oras.land/oras-go/v2"
-	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
@@ -28,7 +24,7 @@ import (
 var copyPackagesCmd = &cobra.Command{
 	Use:          "packages <destination-registry>",
 	Short:        "Copy curated package images and charts from source registries to a destination registry",
-	Long:         `Copy all the EKS Anywhere curated package images and helm charts from source registries to a destination registry. Registry credentials are fetched from docker config.`,
+	Long:         `Copy all the EKS Anywhere curated package images and helm charts from source registries to a destination registry. Registry credentials are fetched from docker config. Sources and destination can also be OCI image layout directories, prefixed with oci:, to move the packages across an air gap without a registry or a Docker daemon.`,
 	SilenceUsage: true,
 	RunE:         runCopyPackages,
 	Args: func(cmd *cobra.Command, args []string) error {
@@ -56,6 +52,8 @@ func init() {
 	copyPackagesCmd.Flags().BoolVar(&cpc.dstPlainHTTP, "dst-plain-http", false, "Whether or not to use plain http for destination registry")
 	copyPackagesCmd.Flags().BoolVar(&cpc.dstInsecure, "dst-insecure", false, "Skip TLS verification against the destination registry")
 	copyPackagesCmd.Flags().BoolVar(&cpc.dryRun, "dry-run", false, "Dry run will show what artifacts would be copied, but not actually copy them")
+	copyPackagesCmd.Flags().StringVar(&cpc.journal, "journal", "", "File recording the copy progress, so an interrupted copy resumes without transferring the copied content again (default copy-packages-<destination>.journal)")
+	copyPackagesCmd.Flags().IntVar(&cpc.concurrency, "concurrency", 5, "Maximum number of blobs transferred at the same time for each artifact")
 
 	// making oras client to use dockerconfig
 	if err := cs.Init(); err != nil {
@@ -79,6 +77,8 @@ type copyPackagesConfig struct {
 	dstPlainHTTP     bool
 	dstInsecure      bool
 	dryRun           bool
+	journal          string
+	concurrency      int
 }
 
 func runCopyPackages(_ *cobra.Command, args []string) error {
@@ -87,18 +87,109 @@ func runCopyPackages(_ *cobra.Command, args []string) error {
 		cpc.srcChartRegistry = cpc.srcImageRegistry
 	}
 	ctx := context.Background()
-	bundle, err := getPackageBundle(ctx, cpc.srcChartRegistry, cpc.kubeVersion)
+	copier, err := newPackageCopier(&cpc)
+	if err != nil {
+		return err
+	}
+	defer copier.close()
+
+	bundleArtifact := registry.NewArtifact(cpc.srcChartRegistry, curatedpackages.ImageRepositoryName, getPackageBundleTag(cpc.kubeVersion), "")
+	bundle, err := getPackageBundle(ctx, copier.chartClient, bundleArtifact)
 	if err != nil {
 		return fmt.Errorf("cannot fetch package bundle: %w", err)
 	}
-	if err := copyArtifacts(context.Background(), bundle); err != nil {
+
+	if err := copier.copyArtifacts(ctx, bundle); err != nil {
 		return err
 	}
 
 	// copy package bundle yaml after charts and images
-	tag := getPackageBundleTag(cpc.kubeVersion)
-	_, err = orasCopy(ctx, curatedpackages.ImageRepositoryName, cpc.srcChartRegistry, tag, cpc.destRegistry, tag)
-	return err
+	if err := copier.copy(ctx, copier.chartClient, bundleArtifact); err != nil {
+		return fmt.Errorf("cannot copy package bundle: %w", err)
+	}
+
+	if !cpc.dryRun {
+		logger.Info("Copy complete: " + copier.mirror.Stats().String())
+	}
+	return nil
+}
+
+// packageCopier copies the charts and images of the packages in a bundle to the destination registry.
+type packageCopier struct {
+	chartClient registry.StorageClient
+	imageClient registry.StorageClient
+	dstClient   registry.StorageClient
+	journal     *registry.Journal
+	mirror      *registry.Mirror
+	dryRun      bool
+}
+
+func newPackageCopier(c *copyPackagesConfig) (*packageCopier, error) {
+	chartClient, err := newCopyPackagesClient(c.srcChartRegistry, false, false)
+	if err != nil {
+		return nil, err
+	}
+	imageClient, err := newCopyPackagesClient(c.srcImageRegistry, false, false)
+	if err != nil {
+		return nil, err
+	}
+	dstClient, err := newCopyPackagesClient(c.destRegistry, c.dstInsecure, c.dstPlainHTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	journalPath := c.journal
+	if journalPath == "" {
+		journalPath = defaultCopyPackagesJournal(c.destRegistry)
+	}
+	if c.dryRun {
+		journalPath = ""
+	}
+	journal, err := registry.NewJournal(journalPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &packageCopier{
+		chartClient: chartClient,
+		imageClient: imageClient,
+		dstClient:   dstClient,
+		journal:     journal,
+		mirror:      registry.NewMirror(journal, c.concurrency),
+		dryRun:      c.dryRun,
+	}, nil
+}
+
+// defaultCopyPackagesJournal returns a journal file name for destination, so copies to different
+// destinations don't share their progress.
+func defaultCopyPackagesJournal(destination string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, destination)
+	return "copy-packages-" + name + ".journal"
+}
+
+// newCopyPackagesClient creates a storage client for a registry, which can include a project path,
+// or for an OCI layout prefixed with oci:.
+func newCopyPackagesClient(location string, insecure, plainHTTP bool) (registry.StorageClient, error) {
+	return registry.NewStorageClient(location, cs, insecure, plainHTTP)
+}
+
+func (c *packageCopier) close() {
+	if err := c.journal.Close(); err != nil {
+		logger.V(4).Info("Closing journal", "error", err)
+	}
+}
+
+func (c *packageCopier) copy(ctx context.Context, srcClient registry.StorageClient, image registry.Artifact) error {
+	logger.V(0).Info("Copying artifact", "from", image.VersionedImage(), "to", c.dstClient.Destination(image))
+	if c.dryRun {
+		return nil
+	}
+	return c.mirror.Copy(ctx, srcClient, c.dstClient, image)
 }
 
 func getTagsFromChartValues(chartValues map[string]any, res map[string]string) error {
@@ -130,57 +221,60 @@ func getPackageBundleTag(kubeVersion string) string {
 	return "v" + strings.Replace(kubeVersion, ".", "-", -1) + "-latest"
 }
 
-func getPackageBundle(ctx context.Context, registry, kubeVersion string) (*packagesv1.PackageBundle, error) {
-	repo, err := remote.NewRepository(registry + "/" + curatedpackages.ImageRepositoryName)
+func getPackageBundle(ctx context.Context, client registry.StorageClient, bundle registry.Artifact) (*packagesv1.PackageBundle, error) {
+	data, err := fetchFirstLayer(ctx, client, bundle, "")
 	if err != nil {
 		return nil, err
 	}
-	tag := getPackageBundleTag(kubeVersion)
-	_, data, err := oras.FetchBytes(ctx, repo, tag, oras.DefaultFetchBytesOptions)
+
+	b := packagesv1.PackageBundle{}
+	err = yaml.Unmarshal(data, &b)
 	if err != nil {
 		return nil, err
 	}
+	return &b, nil
+}
 
-	var mani ocispec.Manifest
-	if err := json.Unmarshal(data, &mani); err != nil {
-		return nil, fmt.Errorf("unmarshal manifest: %v", err)
-	}
-	if len(mani.Layers) < 1 {
-		return nil, fmt.Errorf("missing layer")
-	}
-
-	_, data, err = oras.FetchBytes(ctx, repo.Blobs(), string(mani.Layers[0].Digest), oras.DefaultFetchBytesOptions)
+// fetchFirstLayer fetches the first layer of an artifact with the given media type, or of any media type if empty.
+func fetchFirstLayer(ctx context.Context, client registry.StorageClient, artifact registry.Artifact, mediaType string) ([]byte, error) {
+	repo, err := client.GetStorage(ctx, artifact)
 	if err != nil {
 		return nil, err
 	}
-
-	bundle := packagesv1.PackageBundle{}
-	err = yaml.Unmarshal(data, &bundle)
+	_, data, err := client.FetchBytes(ctx, repo, artifact)
 	if err != nil {
 		return nil, err
 	}
-	return &bundle, nil
+
+	var mani ocispec.Manifest
+	if err := json.Unmarshal(data, &mani); err != nil {
+		return nil, fmt.Errorf("unmarshal manifest: %v", err)
+	}
+	for _, layer := range mani.Layers {
+		if mediaType == "" || layer.MediaType == mediaType {
+			return client.FetchBlob(ctx, repo, layer)
+		}
+	}
+	return nil, fmt.Errorf("missing layer")
 }
 
-func copyArtifacts(ctx context.Context, bundle *packagesv1.PackageBundle) error {
+func (c *packageCopier) copyArtifacts(ctx context.Context, bundle *packagesv1.PackageBundle) error {
 	for _, p := range bundle.Spec.Packages {
 		for _, v := range p.Source.Versions {
-			chartTag := v.Name
-			url := cpc.srcChartRegistry + "/" + p.Source.Repository
-			values, err := getChartValues(url + ":" + chartTag)
+			chart := registry.NewArtifact(cpc.srcChartRegistry, p.Source.Repository, v.Name, "")
+			values, err := getChartValues(ctx, c.chartClient, chart)
 			if err != nil {
-				return fmt.Errorf("cannot get chart values %s: %w", url+":"+chartTag, err)
+				return fmt.Errorf("cannot get chart values %s: %w", chart.VersionedImage(), err)
 			}
 
 			tags := make(map[string]string)
 			if err = getTagsFromChartValues(values, tags); err != nil {
 				return fmt.Errorf("cannot get tags from chart values: %w", err)
 			}
-			_, err = orasCopy(ctx, p.Source.Repository, cpc.srcChartRegistry, chartTag, cpc.destRegistry, chartTag)
-			if err != nil {
+			if err = c.copy(ctx, c.chartClient, chart); err != nil {
 				return fmt.Errorf("cannot copy chart to repo: %w", err)
 			}
-			if err := copyImages(ctx, v.Images, tags); err != nil {
+			if err := c.copyImages(ctx, v.Images, tags); err != nil {
 				return fmt.Errorf("cannot process images: %w", err)
 			}
 		}
@@ -188,69 +282,28 @@ func copyArtifacts(ctx context.Context, bundle *packagesv1.PackageBundle) error
 	return nil
 }
 
-func copyImages(ctx context.Context, images []packagesv1.VersionImages, tags map[string]string) error {
+func (c *packageCopier) copyImages(ctx context.Context, images []packagesv1.VersionImages, tags map[string]string) error {
 	for _, i := range images {
-		dstRef := i.Digest
+		image := registry.NewArtifact(cpc.srcImageRegistry, i.Repository, "", i.Digest)
 		if t, ok := tags[i.Digest]; ok {
 			logger.V(0).Info("Using tag as the reference for digest", "tag", t, "digest", i.Digest)
-			dstRef = t
+			image.Tag = t
 		}
-		_, err := orasCopy(ctx, i.Repository, cpc.srcImageRegistry, i.Digest, cpc.destRegistry, dstRef)
-		if err != nil {
+		if err := c.copy(ctx, c.imageClient, image); err != nil {
 			return fmt.Errorf("cannot copy image to repo: %w", err)
 		}
 	}
 	return nil
 }
 
-func getChartValues(chartURL string) (map[string]interface{}, error) {
-	helmClient, err := helmRegistry.NewClient()
+func getChartValues(ctx context.Context, client registry.StorageClient, chart registry.Artifact) (map[string]interface{}, error) {
+	data, err := fetchFirstLayer(ctx, client, chart, helmRegistry.ChartLayerMediaType)
 	if err != nil {
 		return nil, err
 	}
-	res, err := helmClient.Pull(chartURL)
+	c, err := loader.LoadArchive(bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
-	chart, _ := loader.LoadArchive(bytes.NewReader(res.Chart.Data))
-	return chart.Values, nil
-}
-
-func orasCopy(ctx context.Context, repo, srcRegistry, srcRef, dstRegistry, dstRef string) (ocispec.Descriptor, error) {
-	logger.V(0).Info("Copying artifact", "from", srcRegistry+"/"+repo, "to", dstRegistry+"/"+repo, "dstRef", dstRef)
-
-	if cpc.dryRun {
-		return ocispec.Descriptor{}, nil
-	}
-
-	src, err := remote.NewRepository(srcRegistry + "/" + repo)
-	if err != nil {
-		return ocispec.Descriptor{}, err
-	}
-
-	dst, err := remote.NewRepository(dstRegistry + "/" + repo)
-	if err != nil {
-		return ocispec.Descriptor{}, err
-	}
-	setUpDstRepo(dst, &cpc)
-
-	return oras.Copy(ctx, src, srcRef, dst, dstRef, oras.DefaultCopyOptions)
-}
-
-func setUpDstRepo(dst *remote.Repository, c *copyPackagesConfig) {
-	dst.PlainHTTP = c.dstPlainHTTP
-	dst.Client = &auth.Client{
-		Client: &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: c.dstInsecure},
-			},
-		},
-		Header: http.Header{
-			"User-Agent": {"oras-go"},
-		},
-		Cache: auth.DefaultCache,
-		Credential: func(ctx context.Context, registry string) (auth.Credential, error) {
-			return cs.Credential(registry)
-		},
-	}
+	return c.Values, nil
 }
@@ -1,11 +1,14 @@
 package cmd
 
 import (
-	"net/http"
+	"context"
 	"testing"
 
-	"oras.land/oras-go/v2/registry/remote"
-	"oras.land/oras-go/v2/registry/remote/auth"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/registry"
 )
 
 func TestGetTagsFromChartValues(t *testing.T) {
@@ -46,18 +49,48 @@ func TestGetPackageBundleTag(t *testing.T) {
 	}
 }
 
-func TestSetupDstRepo(t *testing.T) {
-	dst, err := remote.NewRepository("localhost:5000/hello-world")
+func TestDefaultCopyPackagesJournal(t *testing.T) {
+	tests := map[string]string{
+		"localhost:5000/curated":   "copy-packages-localhost_5000_curated.journal",
+		"oci:/tmp/packages-layout": "copy-packages-oci__tmp_packages-layout.journal",
+	}
+	for destination, want := range tests {
+		if got := defaultCopyPackagesJournal(destination); got != want {
+			t.Errorf("defaultCopyPackagesJournal(%s) = %s, want %s", destination, got, want)
+		}
+	}
+}
+
+func TestGetPackageBundleFromOCILayout(t *testing.T) {
+	ctx := context.Background()
+	client, err := newCopyPackagesClient(registry.OCILayoutPrefix+t.TempDir(), false, false)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
+	}
+	bundleArtifact := registry.NewArtifact("", curatedpackages.ImageRepositoryName, getPackageBundleTag("1.27"), "")
+	repo, err := client.GetStorage(ctx, bundleArtifact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer, err := oras.PushBytes(ctx, repo, "application/vnd.eks.package.bundle", []byte("spec:\n  packages:\n  - name: harbor\n"))
+	if err != nil {
+		t.Fatal(err)
 	}
-	cpc := &copyPackagesConfig{dstPlainHTTP: true, dstInsecure: true}
-	setUpDstRepo(dst, cpc)
-	if dst.PlainHTTP != true {
-		t.Errorf("Expect PlainHTTP to be true")
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1_RC4, "application/vnd.eks.package.bundle", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = repo.Tag(ctx, manifest, bundleArtifact.Tag); err != nil {
+		t.Fatal(err)
 	}
 
-	if dst.Client.(*auth.Client).Client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify != true {
-		t.Errorf("Expect InsecureSkipVerify to be true")
+	bundle, err := getPackageBundle(ctx, client, bundleArtifact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundle.Spec.Packages) != 1 || bundle.Spec.Packages[0].Name != "harbor" {
+		t.Errorf("Expected bundle with package harbor, got %v", bundle.Spec.Packages)
 	}
 }
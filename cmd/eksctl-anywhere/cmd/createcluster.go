@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -11,16 +12,21 @@ import (
 	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/aflag"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/awsiamauth"
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clustermanager"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/createvalidations"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+	"github.com/aws/eks-anywhere/pkg/workflow/checkpoint"
 	newManagement "github.com/aws/eks-anywhere/pkg/workflow/management"
 	"github.com/aws/eks-anywhere/pkg/workflows/management"
 	"github.com/aws/eks-anywhere/pkg/workflows/workload"
@@ -29,11 +35,13 @@ import (
 type createClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	imageVerificationOptions
 	forceClean            bool
 	skipIpCheck           bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	installPackages       string
+	resume                bool
 	skipValidations       []string
 	providerOptions       *dependencies.ProviderOptions
 }
@@ -60,13 +68,16 @@ var createClusterCmd = &cobra.Command{
 func init() {
 	createCmd.AddCommand(createClusterCmd)
 	applyClusterOptionFlags(createClusterCmd.Flags(), &cc.clusterOptions)
+	applyBootstrapKubeconfigFlag(createClusterCmd.Flags(), &cc.clusterOptions)
 	applyTimeoutFlags(createClusterCmd.Flags(), &cc.timeoutOptions)
+	applyImageVerificationFlags(createClusterCmd.Flags(), &cc.imageVerificationOptions)
 	applyTinkerbellHardwareFlag(createClusterCmd.Flags(), &cc.hardwareCSVPath)
 	aflag.String(aflag.TinkerbellBootstrapIP, &cc.tinkerbellBootstrapIP, createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	hideForceCleanup(createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
+	createClusterCmd.Flags().BoolVar(&cc.resume, "resume", false, fmt.Sprintf("Resume a previously failed create from its last completed task. Requires %s=true", features.UseNewWorkflowsEnvVar))
 	createClusterCmd.Flags().StringArrayVar(&cc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass create validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(createvalidations.SkippableValidations[:], ",")))
 	tinkerbellFlags(createClusterCmd.Flags(), cc.providerOptions.Tinkerbell.BMCOptions.RPC)
 
@@ -106,6 +117,10 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		return errors.New("please remove the --force-cleanup flag")
 	}
 
+	if cc.resume && !features.UseNewWorkflows().IsActive() {
+		return fmt.Errorf("--resume requires %s=true", features.UseNewWorkflowsEnvVar)
+	}
+
 	ctx := cmd.Context()
 
 	clusterConfigFileExist := validations.FileExists(cc.fileName)
@@ -128,16 +143,22 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		return errors.New("etcdEncryption is not supported during cluster creation")
 	}
 
-	docker := executables.BuildDockerExecutable()
-
-	if err := validations.CheckMinimumDockerVersion(ctx, docker); err != nil {
-		return fmt.Errorf("failed to validate docker: %v", err)
+	if err := cc.validateBootstrapKubeconfig(clusterConfig); err != nil {
+		return err
 	}
 
-	validations.CheckDockerAllocatedMemory(ctx, docker)
+	if cc.needsContainerRuntime() {
+		docker := executables.BuildDockerExecutable()
+
+		if err := validations.CheckMinimumContainerRuntimeVersion(ctx, docker.Runtime(), docker); err != nil {
+			return fmt.Errorf("failed to validate docker: %v", err)
+		}
+
+		validations.CheckDockerAllocatedMemory(ctx, docker)
+	}
 
 	kubeconfigPath := kubeconfig.FromClusterName(clusterConfig.Name)
-	if validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
+	if !cc.resume && validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
 		return fmt.Errorf(
 			"old cluster config file exists under %s, please use a different clusterName to proceed",
 			clusterConfig.Name,
@@ -153,6 +174,10 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		return err
 	}
 
+	if err := cc.verifyClusterImages(ctx, cc.fileName, cc.bundlesOverride, registrymirror.FromCluster(clusterSpec.Cluster)); err != nil {
+		return err
+	}
+
 	cliConfig := buildCliConfig(clusterSpec)
 	dirs, err := cc.directoriesToMount(clusterSpec, cliConfig, cc.installPackages)
 	if err != nil {
@@ -169,6 +194,13 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		return fmt.Errorf("failed to build cluster manager opts: %v", err)
 	}
 
+	// A resumed create has already claimed the control plane endpoint, so checking it isn't in
+	// use would always fail.
+	if cc.resume && !cc.skipIpCheck {
+		logger.Info("Skipping control plane IP check for resumed cluster creation")
+		cc.skipIpCheck = true
+	}
+
 	var skippedValidations map[string]bool
 	if len(cc.skipValidations) != 0 {
 		skippedValidations, err = validations.ValidateSkippableValidation(cc.skipValidations, createvalidations.SkippableValidations)
@@ -178,6 +210,7 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	}
 
 	factory := dependencies.ForSpec(clusterSpec).WithExecutableMountDirs(dirs...).
+		UseBootstrapKubeconfig(cc.bootstrapKubeconfig).
 		WithBootstrapper().
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
@@ -241,6 +274,11 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 			CreateBootstrapClusterOptions: deps.Provider,
 			Cluster:                       clustermanager.NewCreateClusterShim(clusterSpec, deps.Provider),
 			FS:                            deps.Writer,
+			Provider:                      deps.Provider,
+			Validator:                     createValidations,
+			PackageInstaller:              deps.PackageManager,
+			CheckpointStore:               workflowCheckpointStore(deps, clusterSpec),
+			Resume:                        cc.resume,
 		}
 		wflw.WithHookRegistrar(awsiamauth.NewHookRegistrar(deps.AwsIamAuth, clusterSpec))
 
@@ -288,3 +326,26 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	cleanup(deps, &err)
 	return err
 }
+
+// workflowCheckpointStore returns the store used to persist workflow checkpoints. Workload
+// clusters keep them in a Secret in their management cluster so a create can be resumed from any
+// machine with access to it, while management clusters keep them on disk as no cluster outlives
+// a failed create.
+func workflowCheckpointStore(deps *dependencies.Dependencies, clusterSpec *cluster.Spec) workflow.CheckpointStore {
+	if clusterSpec.Cluster.IsManaged() {
+		mgmt := getManagementCluster(clusterSpec)
+		return checkpoint.NewSecretStore(
+			deps.UnAuthKubeClient.KubeconfigClient(mgmt.KubeconfigFile),
+			fmt.Sprintf("%s-create-checkpoint", clusterSpec.Cluster.Name),
+			constants.EksaSystemNamespace,
+		)
+	}
+
+	return checkpoint.NewFileStore(workflowCheckpointFile(deps.Writer.Dir(), clusterSpec.Cluster.Name))
+}
+
+// workflowCheckpointFile returns the path of the file used to persist workflow checkpoints for
+// clusterName.
+func workflowCheckpointFile(dir, clusterName string) string {
+	return filepath.Join(dir, fmt.Sprintf("%s-workflow-checkpoint.yaml", clusterName))
+}
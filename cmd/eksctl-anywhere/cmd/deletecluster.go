@@ -61,6 +61,7 @@ func init() {
 	hideForceCleanup(deleteClusterCmd.Flags())
 	deleteClusterCmd.Flags().StringVar(&dc.managementKubeconfig, "kubeconfig", "", "kubeconfig file pointing to a management cluster")
 	deleteClusterCmd.Flags().StringVar(&dc.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	applyBootstrapKubeconfigFlag(deleteClusterCmd.Flags(), &dc.clusterOptions)
 	tinkerbellFlags(deleteClusterCmd.Flags(), dc.providerOptions.Tinkerbell.BMCOptions.RPC)
 }
 
@@ -85,6 +86,10 @@ func (dc *deleteClusterOptions) validate(ctx context.Context, args []string) err
 		return err
 	}
 
+	if err := dc.validateBootstrapKubeconfig(clusterConfig); err != nil {
+		return err
+	}
+
 	kubeconfigPath := getKubeconfigPath(clusterConfig.Name, dc.wConfig)
 	if err := kubeconfig.ValidateFilename(kubeconfigPath); err != nil {
 		return err
@@ -115,6 +120,7 @@ func (dc *deleteClusterOptions) deleteCluster(ctx context.Context) error {
 	}
 
 	deps, err := dependencies.ForSpec(clusterSpec).WithExecutableMountDirs(dirs...).
+		UseBootstrapKubeconfig(dc.bootstrapKubeconfig).
 		WithBootstrapper().
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, nil).
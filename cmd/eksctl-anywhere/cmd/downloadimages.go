@@ -45,6 +45,7 @@ func init() {
 	downloadImagesCmd.Flag("include-packages").Deprecated = "use copy packages command"
 	downloadImagesCmd.Flags().StringVarP(&downloadImagesRunner.bundlesOverride, "bundles-override", "", "", "Override default Bundles manifest (not recommended)")
 	downloadImagesCmd.Flags().BoolVar(&downloadImagesRunner.insecure, "insecure", false, "Flag to indicate skipping TLS verification while downloading helm charts")
+	downloadImagesCmd.Flags().BoolVar(&downloadImagesRunner.ociLayout, "oci-layout", false, "Store the images and charts in an OCI image layout pulled directly from the registries, without a Docker daemon")
 }
 
 var downloadImagesRunner = downloadImagesCommand{}
@@ -54,48 +55,61 @@ type downloadImagesCommand struct {
 	bundlesOverride string
 	includePackages bool
 	insecure        bool
+	ociLayout       bool
 }
 
 func (c downloadImagesCommand) Run(ctx context.Context) error {
-	factory := dependencies.NewFactory()
-	helmOpts := []helm.Opt{}
-	if c.insecure {
-		helmOpts = append(helmOpts, helm.WithInsecure())
-	}
-	deps, err := factory.
+	factory := dependencies.NewFactory().
 		WithFileReader().
 		WithManifestReader().
-		WithHelm(helmOpts...).
-		WithLogger().
-		Build(ctx)
+		WithLogger()
+	if !c.ociLayout {
+		helmOpts := []helm.Opt{}
+		if c.insecure {
+			helmOpts = append(helmOpts, helm.WithInsecure())
+		}
+		factory = factory.WithHelm(helmOpts...)
+	}
+	deps, err := factory.Build(ctx)
 	if err != nil {
 		return err
 	}
 	defer deps.Close(ctx)
 
-	dockerClient := executables.BuildDockerExecutable()
 	downloadFolder := "tmp-eks-a-artifacts-download"
-	imagesFile := filepath.Join(downloadFolder, imagesTarFile)
-	eksaToolsImageFile := filepath.Join(downloadFolder, eksaToolsImageTarFile)
-
-	downloadArtifacts := artifacts.Download{
-		Reader:     deps.ManifestReader,
-		FileReader: deps.FileReader,
-		BundlesImagesDownloader: docker.NewImageMover(
+	var imagesDownloader, eksaToolsImageDownloader artifacts.ImageMover
+	var chartDownloader artifacts.ChartDownloader
+	if c.ociLayout {
+		// The tools image and the charts are stored in the same layout, which deduplicates the layers shared by the images.
+		layoutDir := filepath.Join(downloadFolder, imagesOCILayoutDir)
+		imagesDownloader = newOCILayoutDownloader(layoutDir, c.insecure)
+		eksaToolsImageDownloader = imagesDownloader
+		chartDownloader = newOCILayoutChartDownloader(layoutDir, c.insecure)
+	} else {
+		dockerClient := executables.BuildDockerExecutable()
+		imagesDownloader = docker.NewImageMover(
 			docker.NewOriginalRegistrySource(dockerClient),
-			docker.NewDiskDestination(dockerClient, imagesFile),
-		),
-		EksaToolsImageDownloader: docker.NewImageMover(
+			docker.NewDiskDestination(dockerClient, filepath.Join(downloadFolder, imagesTarFile)),
+		)
+		eksaToolsImageDownloader = docker.NewImageMover(
 			docker.NewOriginalRegistrySource(dockerClient),
-			docker.NewDiskDestination(dockerClient, eksaToolsImageFile),
-		),
-		ChartDownloader:    helm.NewChartRegistryDownloader(deps.Helm, downloadFolder),
-		Version:            version.Get(),
-		TmpDowloadFolder:   downloadFolder,
-		DstFile:            c.outputFile,
-		Packager:           packagerForFile(c.outputFile),
-		ManifestDownloader: oras.NewBundleDownloader(deps.Logger, downloadFolder),
-		BundlesOverride:    c.bundlesOverride,
+			docker.NewDiskDestination(dockerClient, filepath.Join(downloadFolder, eksaToolsImageTarFile)),
+		)
+		chartDownloader = helm.NewChartRegistryDownloader(deps.Helm, downloadFolder)
+	}
+
+	downloadArtifacts := artifacts.Download{
+		Reader:                   deps.ManifestReader,
+		FileReader:               deps.FileReader,
+		BundlesImagesDownloader:  imagesDownloader,
+		EksaToolsImageDownloader: eksaToolsImageDownloader,
+		ChartDownloader:          chartDownloader,
+		Version:                  version.Get(),
+		TmpDowloadFolder:         downloadFolder,
+		DstFile:                  c.outputFile,
+		Packager:                 packagerForFile(c.outputFile),
+		ManifestDownloader:       oras.NewBundleDownloader(deps.Logger, downloadFolder),
+		BundlesOverride:          c.bundlesOverride,
 	}
 
 	return downloadArtifacts.Run(ctx)
@@ -16,7 +16,7 @@ import (
 const (
 	TinkerbellHardwareCSVFlagName        = "hardware-csv"
 	TinkerbellHardwareCSVFlagAlias       = "z"
-	TinkerbellHardwareCSVFlagDescription = "Path to a CSV file containing hardware data."
+	TinkerbellHardwareCSVFlagDescription = "Path to a CSV file or a JSON/YAML inventory file containing hardware data."
 	KubeconfigFile                       = "kubeconfig"
 
 	forceCleanupDeprecationMessageForUpgrade = `The flag --force-cleanup has been removed. For more information on how to troubleshoot existing bootstrap clusters, please refer to the documentation:
@@ -52,6 +52,10 @@ func applyClusterOptionFlags(flagSet *pflag.FlagSet, clusterOpt *clusterOptions)
 	flagSet.StringVar(&clusterOpt.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file")
 }
 
+func applyBootstrapKubeconfigFlag(flagSet *pflag.FlagSet, clusterOpt *clusterOptions) {
+	flagSet.StringVar(&clusterOpt.bootstrapKubeconfig, "bootstrap-kubeconfig", "", "Kubeconfig file of an existing cluster to use as bootstrap cluster instead of creating a kind cluster")
+}
+
 func applyTinkerbellHardwareFlag(flagSet *pflag.FlagSet, pathOut *string) {
 	flagSet.StringVarP(
 		pathOut,
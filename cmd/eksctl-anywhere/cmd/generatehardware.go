@@ -13,6 +13,8 @@ import (
 type hardwareOptions struct {
 	csvPath         string
 	outputPath      string
+	redfish         bool
+	redfishInsecure bool
 	providerOptions *dependencies.ProviderOptions
 }
 
@@ -27,9 +29,11 @@ var hOpts = &hardwareOptions{
 }
 
 var generateHardwareCmd = &cobra.Command{
-	Use:     "hardware",
-	Short:   "Generate hardware files",
-	Long:    `Generate Kubernetes hardware YAML manifests for each Hardware entry in the source.`,
+	Use:   "hardware",
+	Short: "Generate hardware files",
+	Long: `Generate Kubernetes hardware YAML manifests for each Hardware entry in the source.
+The source is a CSV file or a JSON/YAML inventory file. With --redfish, the MAC address, disk
+and hostname missing from the source are discovered through the Redfish API of each machine's BMC.`,
 	RunE:    hOpts.generateHardware,
 	PreRunE: bindFlagsToViper,
 }
@@ -47,6 +51,9 @@ func init() {
 		TinkerbellHardwareCSVFlagDescription,
 	)
 
+	fset.BoolVar(&hOpts.redfish, "redfish", false, "Discover the missing MAC address, disk and hostname of each machine through the Redfish API of its BMC.")
+	fset.BoolVar(&hOpts.redfishInsecure, "redfish-insecure", false, "Skip the verification of the BMC TLS certificates during Redfish discovery.")
+
 	if err := generateHardwareCmd.MarkFlagRequired(TinkerbellHardwareCSVFlagName); err != nil {
 		panic(err)
 	}
@@ -54,9 +61,20 @@ func init() {
 }
 
 func (hOpts *hardwareOptions) generateHardware(cmd *cobra.Command, args []string) error {
-	hardwareYaml, err := hardware.BuildHardwareYAML(hOpts.csvPath, hOpts.providerOptions.Tinkerbell.BMCOptions)
+	reader, err := hardware.NewNormalizedReaderFromFile(hOpts.csvPath, hOpts.providerOptions.Tinkerbell.BMCOptions)
+	if err != nil {
+		return fmt.Errorf("reading hardware: %v", err)
+	}
+
+	if hOpts.redfish {
+		redfishReader := hardware.NewRedfishReader(cmd.Context(), reader)
+		redfishReader.Insecure = hOpts.redfishInsecure
+		reader = hardware.NewNormalizer(redfishReader)
+	}
+
+	hardwareYaml, err := hardware.BuildHardwareYAMLFromReader(reader)
 	if err != nil {
-		return fmt.Errorf("building hardware yaml from csv: %v", err)
+		return fmt.Errorf("building hardware yaml: %v", err)
 	}
 
 	fh, err := hardware.CreateOrStdout(hOpts.outputPath)
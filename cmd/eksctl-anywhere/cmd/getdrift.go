@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+type getDriftOptions struct {
+	clusterOptions
+	kubeConfig   string
+	output       string
+	updateStatus bool
+}
+
+var gdo = &getDriftOptions{}
+
+var getDriftCmd = &cobra.Command{
+	Use:          "drift",
+	Short:        "Get drift between the cluster config in Git and the cluster",
+	Long:         "Compares the EKS Anywhere cluster config committed to the GitOps repository with the live objects in the management cluster and shows the fields that differ. Only fields set in Git are compared.",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if gdo.fileName == "" {
+			return fmt.Errorf("must specify the cluster config file with --filename")
+		}
+		return getDrift(cmd.Context(), gdo)
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getDriftCmd)
+	getDriftCmd.Flags().StringVarP(&gdo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	getDriftCmd.Flags().StringVar(&gdo.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	getDriftCmd.Flags().StringVarP(&gdo.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+	getDriftCmd.Flags().BoolVar(&gdo.updateStatus, "update-status", false, "Record the result in the FluxConfig status")
+}
+
+func getDrift(ctx context.Context, opts *getDriftOptions) error {
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", opts.output)
+	}
+
+	opts.managementKubeconfig = opts.kubeConfig
+	clusterSpec, err := newClusterSpec(opts.clusterOptions)
+	if err != nil {
+		return err
+	}
+	if clusterSpec.FluxConfig == nil {
+		return fmt.Errorf("cluster %s doesn't have a FluxConfig gitOpsRef", clusterSpec.Cluster.Name)
+	}
+
+	kubeConfig := opts.kubeConfig
+	if kubeConfig == "" && clusterSpec.ManagementCluster != nil {
+		kubeConfig = clusterSpec.ManagementCluster.KubeconfigFile
+	}
+	kubeConfig, err = kubeconfig.ResolveAndValidateFilename(kubeConfig, clusterSpec.Cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := kubernetes.NewRuntimeClientFromFileName(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("unable to initialize k8s client: %v", err)
+	}
+
+	cliConfig := buildCliConfig(clusterSpec)
+	deps, err := dependencies.ForSpec(clusterSpec).
+		WithCliConfig(cliConfig).
+		WithGit(clusterSpec.Cluster, clusterSpec.FluxConfig).
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	gitOpsFlux := flux.NewFlux(nil, nil, deps.Git, cliConfig)
+	drift, err := gitOpsFlux.DetectDrift(ctx, k8sClient, clusterSpec)
+	if err != nil {
+		return fmt.Errorf("detecting drift: %v", err)
+	}
+
+	if opts.updateStatus {
+		if err := flux.UpdateDriftStatus(ctx, k8sClient, clusterSpec.FluxConfig, drift); err != nil {
+			return err
+		}
+	}
+
+	var out string
+	if opts.output == outputJson {
+		out, err = serializeDriftToJson(drift)
+	} else {
+		out, err = serializeDriftToText(drift)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(out)
+	return nil
+}
+
+func serializeDriftToJson(drift *v1alpha1.GitOpsDriftStatus) (string, error) {
+	b, err := json.Marshal(drift)
+	if err != nil {
+		return "", fmt.Errorf("marshalling drift: %v", err)
+	}
+	return string(b), nil
+}
+
+func serializeDriftToText(drift *v1alpha1.GitOpsDriftStatus) (string, error) {
+	if !drift.Drifted {
+		return fmt.Sprintf("No drift detected at commit %s", drift.Commit), nil
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "Drift detected at commit %s\n\n", drift.Commit)
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tFIELD\tTYPE\tGIT\tLIVE")
+	for _, f := range drift.Fields {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", f.Kind, f.Name, f.Path, f.Type, f.Git, f.Live)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("flushing drift table: %v", err)
+	}
+
+	return buffer.String(), nil
+}
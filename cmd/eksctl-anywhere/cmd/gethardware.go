@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type getHardwareOptions struct {
+	kubeConfig string
+	fileName   string
+	output     string
+}
+
+var gho = &getHardwareOptions{}
+
+var getHardwareCmd = &cobra.Command{
+	Use:          "hardware",
+	Short:        "Get Tinkerbell hardware and capacity",
+	Long:         "Lists the Tinkerbell hardware of a management cluster with its state and owner. When a cluster config is provided, it also compares the free hardware matching each TinkerbellMachineConfig hardware selector with the hardware needed to create, scale and roll out the cluster.",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getHardware(cmd.Context(), gho)
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getHardwareCmd)
+	getHardwareCmd.Flags().StringVar(&gho.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	getHardwareCmd.Flags().StringVarP(&gho.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration to calculate the hardware capacity for")
+	getHardwareCmd.Flags().StringVarP(&gho.output, outputFlagName, "o", outputDefault, "Output format: text|json")
+}
+
+type hardwareReport struct {
+	Hardware []hardwareReportItem          `json:"hardware"`
+	Capacity []tinkerbell.HardwareCapacity `json:"capacity,omitempty"`
+}
+
+type hardwareReportItem struct {
+	Name         string                 `json:"name"`
+	State        hardware.HardwareState `json:"state"`
+	Cluster      string                 `json:"cluster,omitempty"`
+	MachineGroup string                 `json:"machineGroup,omitempty"`
+	Machine      string                 `json:"machine,omitempty"`
+	Labels       map[string]string      `json:"labels,omitempty"`
+}
+
+func getHardware(ctx context.Context, opts *getHardwareOptions) error {
+	if opts.output != outputText && opts.output != outputJson {
+		return fmt.Errorf("invalid output format [%s]", opts.output)
+	}
+
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(opts.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := kubernetes.NewRuntimeClientFromFileName(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("unable to initialize k8s client: %v", err)
+	}
+
+	statuses, err := hardware.ListHardwareStatus(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+
+	report := &hardwareReport{Hardware: make([]hardwareReportItem, 0, len(statuses))}
+	for _, s := range statuses {
+		report.Hardware = append(report.Hardware, hardwareReportItem{
+			Name:         s.Hardware.Name,
+			State:        s.State,
+			Cluster:      s.Cluster,
+			MachineGroup: s.MachineGroup,
+			Machine:      s.Machine,
+			Labels:       userLabels(s.Hardware.Labels),
+		})
+	}
+
+	if opts.fileName != "" {
+		if report.Capacity, err = hardwareCapacity(ctx, k8sClient, opts.fileName); err != nil {
+			return err
+		}
+	}
+
+	var out string
+	if opts.output == outputJson {
+		out, err = serializeHardwareReportToJson(report)
+	} else {
+		out, err = serializeHardwareReportToText(report, opts.fileName != "")
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(out)
+	return nil
+}
+
+func hardwareCapacity(ctx context.Context, k8sClient client.Client, fileName string) ([]tinkerbell.HardwareCapacity, error) {
+	config, err := cluster.ParseConfigFromFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if config.TinkerbellDatacenter == nil {
+		return nil, fmt.Errorf("cluster config %s is not a Tinkerbell cluster", fileName)
+	}
+
+	spec := tinkerbell.NewClusterSpec(&cluster.Spec{Config: config}, config.TinkerbellMachineConfigs, config.TinkerbellDatacenter)
+
+	reader := hardware.NewKubeReader(k8sClient)
+	if err := reader.LoadHardware(ctx); err != nil {
+		return nil, fmt.Errorf("loading hardware: %v", err)
+	}
+
+	current, err := tinkerbell.GetValidatableCAPI(ctx, k8sClient, config.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("reading current cluster: %v", err)
+	}
+
+	// A cluster without a KubeadmControlPlane hasn't been created yet.
+	var validatable tinkerbell.ValidatableCluster
+	if current.KubeadmControlPlane != nil {
+		validatable = current
+	}
+
+	return tinkerbell.CalculateHardwareCapacity(spec, reader.GetCatalogue(), validatable)
+}
+
+func serializeHardwareReportToJson(report *hardwareReport) (string, error) {
+	b, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("failed marshalling hardware report: %v", err)
+	}
+	return string(b), nil
+}
+
+func serializeHardwareReportToText(report *hardwareReport, includeCapacity bool) (string, error) {
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATE\tCLUSTER\tGROUP\tLABELS")
+	for _, h := range report.Hardware {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", h.Name, h.State, h.Cluster, h.MachineGroup, formatLabels(h.Labels))
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	if !includeCapacity {
+		return buffer.String(), nil
+	}
+
+	fmt.Fprintln(&buffer)
+	w = tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "SELECTOR\tMACHINE CONFIGS\tGROUPS\tFREE\tREQUIRED\tSURGE\tSHORTFALL")
+	for _, c := range report.Capacity {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\n",
+			formatLabels(c.Selector),
+			strings.Join(c.MachineConfigs, ","),
+			strings.Join(c.Groups, ","),
+			c.Free,
+			c.Required,
+			c.Surge,
+			c.Shortfall(),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+// userLabels removes the owner labels set by CAPT from hardware labels.
+func userLabels(labels map[string]string) map[string]string {
+	filtered := map[string]string{}
+	for k, v := range labels {
+		if strings.HasPrefix(k, "v1alpha1.tinkerbell.org/") {
+			continue
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
+
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/signature"
+)
+
+const (
+	verifyImagesKeyFlag    = "verify-images-key"
+	verifyImagesTypeFlag   = "verify-images-type"
+	verifyImagesReportFlag = "verify-images-report"
+
+	defaultImageVerificationReport = "image-verification-report.yaml"
+	registryMirrorDialTimeout      = 5 * time.Second
+)
+
+// imageVerificationOptions configure the optional verification of the cosign signatures or
+// attestations of the images referenced by a bundle.
+type imageVerificationOptions struct {
+	publicKeyPath    string
+	verificationType string
+	reportPath       string
+}
+
+func applyImageVerificationFlags(flagSet *pflag.FlagSet, o *imageVerificationOptions) {
+	flagSet.StringVar(&o.publicKeyPath, verifyImagesKeyFlag, "", "Path to the PEM encoded public key verifying the cosign signatures or attestations of the images. Images are not verified when empty")
+	flagSet.StringVar(&o.verificationType, verifyImagesTypeFlag, string(signature.CosignSignature), fmt.Sprintf("Cosign artifacts to verify for each image, %s or %s", signature.CosignSignature, signature.CosignAttestation))
+	flagSet.StringVar(&o.reportPath, verifyImagesReportFlag, defaultImageVerificationReport, "File to write the image verification report to")
+}
+
+func (o imageVerificationOptions) enabled() bool {
+	return o.publicKeyPath != ""
+}
+
+// verify verifies the images from sources and writes the report. It fails if any of the images
+// could not be verified.
+func (o imageVerificationOptions) verify(ctx context.Context, sources registry.SourceSelector, images []string) error {
+	if !o.enabled() {
+		return nil
+	}
+
+	publicKey, err := os.ReadFile(o.publicKeyPath)
+	if err != nil {
+		return fmt.Errorf("reading image verification public key: %v", err)
+	}
+	verifier, err := signature.NewImageVerifier(publicKey, signature.ImageVerificationType(o.verificationType), sources)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Verifying images", "type", o.verificationType, "images", len(images))
+	report := verifier.Verify(ctx, images...)
+
+	f, err := os.Create(o.reportPath)
+	if err != nil {
+		return fmt.Errorf("creating image verification report: %v", err)
+	}
+	defer f.Close()
+	if err = report.Write(f); err != nil {
+		return err
+	}
+	logger.Info("Image verification report written", "file", o.reportPath)
+
+	return report.Err()
+}
+
+// verifyFromRegistry verifies the images from the registry mirror when configured, and from
+// their original registries otherwise.
+func (o imageVerificationOptions) verifyFromRegistry(ctx context.Context, registryMirror *registrymirror.RegistryMirror, images []string) error {
+	if !o.enabled() {
+		return nil
+	}
+
+	insecure := false
+	registryMirror = registryMirror.Failover(registrymirror.Reachable(registryMirrorDialTimeout))
+	if registryMirror != nil {
+		insecure = registryMirror.InsecureSkipVerify
+		if registryMirror.Auth {
+			username, password, err := registryMirror.ReadCredentials()
+			if err != nil {
+				return err
+			}
+			cs.SetCredential(registryMirror.BaseRegistry, username, password)
+		}
+	}
+
+	mirrored := make([]string, 0, len(images))
+	for _, image := range images {
+		mirrored = append(mirrored, registryMirror.ReplaceRegistry(image))
+	}
+	return o.verify(ctx, registry.RegistrySources(registry.NewCache(), cs, insecure), mirrored)
+}
+
+// verifyClusterImages verifies the images of the bundle of the cluster in clusterSpecPath.
+func (o imageVerificationOptions) verifyClusterImages(ctx context.Context, clusterSpecPath, bundlesOverride string, registryMirror *registrymirror.RegistryMirror) error {
+	if !o.enabled() {
+		return nil
+	}
+
+	images, err := getImages(clusterSpecPath, bundlesOverride)
+	if err != nil {
+		return err
+	}
+	uris := make([]string, 0, len(images))
+	for _, image := range images {
+		uris = append(uris, image.VersionedImage())
+	}
+	return o.verifyFromRegistry(ctx, registryMirror, uris)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+func writeTestPublicKey(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "cosign.pub")
+	if err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImageVerificationWritesReport(t *testing.T) {
+	layout := registry.NewOCILayout(t.TempDir())
+	if err := layout.Init(); err != nil {
+		t.Fatal(err)
+	}
+	o := imageVerificationOptions{
+		publicKeyPath:    writeTestPublicKey(t),
+		verificationType: "signature",
+		reportPath:       filepath.Join(t.TempDir(), "report.yaml"),
+	}
+
+	err := o.verify(context.Background(), registry.StorageSource(layout), []string{"public.ecr.aws/eks-anywhere/kube-vip:v0.5.5"})
+	if err == nil || !strings.HasPrefix(err.Error(), "signature verification failed for 1 of 1 images") {
+		t.Errorf("Expected verification to fail, got %v", err)
+	}
+	report, err := os.ReadFile(o.reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(report), "image: public.ecr.aws/eks-anywhere/kube-vip:v0.5.5") {
+		t.Errorf("Expected the image in the report, got %s", report)
+	}
+}
+
+func TestImageVerificationDisabled(t *testing.T) {
+	if err := (imageVerificationOptions{}).verify(context.Background(), nil, []string{"image"}); err != nil {
+		t.Errorf("Expected no verification without a public key, got %v", err)
+	}
+}
+
+func TestLayoutImporterVerificationRequiresLayout(t *testing.T) {
+	importer := newLayoutImporter(t.TempDir(), "localhost:5000", registry.NewStaticCredentialStore("localhost:5000", "", ""), false, nil).
+		withImageVerification(imageVerificationOptions{publicKeyPath: "cosign.pub"})
+
+	err := importer.Move(context.Background(), "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5")
+	if err == nil || !strings.Contains(err.Error(), "requires images downloaded with --oci-layout") {
+		t.Errorf("Expected verification to require an OCI layout, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/helm"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/registry"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -25,7 +27,10 @@ var importImagesCmd = &cobra.Command{
 	Use:   "images",
 	Short: "Import images and charts to a registry from a tarball",
 	Long: `Import all the images and helm charts necessary for EKS Anywhere clusters into a registry.
-Use this command in conjunction with download images, passing it output tarball as input to this command.`,
+Use this command in conjunction with download images, passing it output tarball as input to this command.
+Images and charts downloaded in an OCI image layout are pushed directly to the registry, without a Docker daemon.
+When a public key is provided with --verify-images-key, the cosign signatures or attestations of the images
+in the OCI image layout are verified before importing them.`,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
@@ -51,16 +56,18 @@ func init() {
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.includePackages, "include-packages", false, "Flag to indicate inclusion of curated packages in imported images")
 	importImagesCmd.Flag("include-packages").Deprecated = "use copy packages command"
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.insecure, "insecure", false, "Flag to indicate skipping TLS verification while pushing helm charts and bundles")
+	applyImageVerificationFlags(importImagesCmd.Flags(), &importImagesCommand.imageVerification)
 }
 
 var importImagesCommand = ImportImagesCommand{}
 
 type ImportImagesCommand struct {
-	InputFile        string
-	RegistryEndpoint string
-	BundlesFile      string
-	includePackages  bool
-	insecure         bool
+	InputFile         string
+	RegistryEndpoint  string
+	BundlesFile       string
+	includePackages   bool
+	insecure          bool
+	imageVerification imageVerificationOptions
 }
 
 func (c ImportImagesCommand) Call(ctx context.Context) error {
@@ -83,26 +90,43 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 	}
 
 	artifactsFolder := "tmp-eks-a-artifacts"
-	dockerClient := executables.BuildDockerExecutable()
 	toolsImageFile := filepath.Join(artifactsFolder, eksaToolsImageTarFile)
+	imagesFile := filepath.Join(artifactsFolder, "images.tar")
+	layoutDir := filepath.Join(artifactsFolder, imagesOCILayoutDir)
+	host, _, _ := strings.Cut(c.RegistryEndpoint, "/")
+	credentials := registry.NewStaticCredentialStore(host, username, password)
 
 	// Import the eksa tools image into the registry first, so it can be used immediately
-	// after to build the helm executable
+	// after to build the helm executable when the artifacts don't include an OCI layout.
 	importToolsImage := artifacts.ImportToolsImage{
 		Bundles:            bundle,
 		InputFile:          c.InputFile,
 		TmpArtifactsFolder: artifactsFolder,
 		UnPackager:         packagerForFile(c.InputFile),
-		ImageMover: docker.NewImageMover(
-			docker.NewDiskSource(dockerClient, toolsImageFile),
-			docker.NewRegistryDestination(dockerClient, c.RegistryEndpoint),
-		),
+		ImageMover:         newLayoutImporter(layoutDir, c.RegistryEndpoint, credentials, c.insecure, c.dockerImageMover(toolsImageFile)),
 	}
 
 	if err = importToolsImage.Run(ctx); err != nil {
 		return err
 	}
 
+	layoutImporter := newLayoutImporter(layoutDir, c.RegistryEndpoint, credentials, c.insecure, c.dockerImageMover(imagesFile)).
+		withImageVerification(c.imageVerification)
+	importArtifacts := artifacts.Import{
+		Reader:             deps.ManifestReader,
+		Bundles:            bundle,
+		ImageMover:         layoutImporter,
+		ChartImporter:      layoutImporter,
+		TmpArtifactsFolder: artifactsFolder,
+		FileImporter:       oras.NewFileRegistryImporter(c.RegistryEndpoint, username, password, artifactsFolder),
+	}
+	ctx = context.WithValue(ctx, types.InsecureRegistry, c.insecure)
+
+	// Charts downloaded in an OCI layout are pushed like the images.
+	if layoutImporter.hasLayout() {
+		return importArtifacts.Run(ctx)
+	}
+
 	dirsToMount, err := cc.cloudStackDirectoriesToMount()
 	if err != nil {
 		return err
@@ -131,23 +155,24 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 	}
 	defer deps.Close(ctx)
 
-	imagesFile := filepath.Join(artifactsFolder, "images.tar")
-	importArtifacts := artifacts.Import{
-		Reader:  deps.ManifestReader,
-		Bundles: bundle,
-		ImageMover: docker.NewImageMover(
+	importArtifacts.ChartImporter = helm.NewChartRegistryImporter(
+		deps.Helm, artifactsFolder,
+		c.RegistryEndpoint,
+		username,
+		password,
+	)
+
+	return importArtifacts.Run(ctx)
+}
+
+// dockerImageMover returns a function building an image mover that loads the images from
+// the imagesFile tarball with Docker and pushes them to the registry.
+func (c ImportImagesCommand) dockerImageMover(imagesFile string) func() artifacts.ImageMover {
+	return func() artifacts.ImageMover {
+		dockerClient := executables.BuildDockerExecutable()
+		return docker.NewImageMover(
 			docker.NewDiskSource(dockerClient, imagesFile),
 			docker.NewRegistryDestination(dockerClient, c.RegistryEndpoint),
-		),
-		ChartImporter: helm.NewChartRegistryImporter(
-			deps.Helm, artifactsFolder,
-			c.RegistryEndpoint,
-			username,
-			password,
-		),
-		TmpArtifactsFolder: artifactsFolder,
-		FileImporter:       oras.NewFileRegistryImporter(c.RegistryEndpoint, username, password, artifactsFolder),
+		)
 	}
-
-	return importArtifacts.Run(context.WithValue(ctx, types.InsecureRegistry, c.insecure))
 }
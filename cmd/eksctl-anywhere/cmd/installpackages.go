@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -21,6 +22,7 @@ type installPackageOptions struct {
 	// existing cluster.
 	kubeConfig      string
 	bundlesOverride string
+	dryRun          bool
 }
 
 var ipo = &installPackageOptions{}
@@ -42,6 +44,8 @@ func init() {
 		"Target cluster for installation.")
 	installPackageCommand.Flags().StringVar(&ipo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	installPackageCommand.Flags().BoolVar(&ipo.dryRun, "dry-run", false,
+		"Print the packages that would be installed, including dependencies, without installing them")
 
 	if err := installPackageCommand.MarkFlagRequired("package-name"); err != nil {
 		log.Fatalf("marking package-name flag as required: %s", err)
@@ -105,8 +109,19 @@ func installPackages(ctx context.Context, args []string) error {
 		return err
 	}
 
+	plan, err := packages.PlanInstall(ctx, p, ipo.clusterName, kubeConfig)
+	if err != nil {
+		return err
+	}
+	if err = packages.DisplayInstallPlan(os.Stdout, plan); err != nil {
+		return err
+	}
+	if ipo.dryRun {
+		return nil
+	}
+
 	curatedpackages.PrintLicense()
-	err = packages.InstallPackage(ctx, p, ipo.packageName, ipo.clusterName, kubeConfig)
+	err = packages.InstallPackageWithDependencies(ctx, plan, ipo.packageName, ipo.clusterName, kubeConfig)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+// newOCILayoutDownloader copies images, with their cosign signatures and attestations, from their
+// original registries to the OCI layout in layoutDir.
+func newOCILayoutDownloader(layoutDir string, insecure bool) *registry.ImageMover {
+	return registry.NewImageMover(
+		registry.RegistrySources(registry.NewCache(), cs, insecure),
+		registry.NewOCILayout(layoutDir),
+	).WithSignatures()
+}
+
+// newOCILayoutChartDownloader copies helm charts, which are OCI artifacts, from their original registries
+// to the OCI layout in layoutDir.
+func newOCILayoutChartDownloader(layoutDir string, insecure bool) chartMover {
+	return chartMover{
+		mover: registry.NewImageMover(
+			registry.RegistrySources(registry.NewCache(), cs, insecure),
+			registry.NewOCILayout(layoutDir),
+		),
+	}
+}
+
+// chartMover downloads helm charts with an image mover.
+type chartMover struct {
+	mover artifacts.ImageMover
+}
+
+func (m chartMover) Download(ctx context.Context, charts ...string) error {
+	return m.mover.Move(ctx, charts...)
+}
+
+// layoutImporter imports images to a registry from the OCI layout in layoutDir when the
+// unpackaged artifacts contain one, and with the image mover built by fallback otherwise.
+// The layout is only checked when moving images, after the input file has been unpackaged.
+type layoutImporter struct {
+	layoutDir         string
+	registryEndpoint  string
+	credentials       *registry.CredentialStore
+	insecure          bool
+	fallback          func() artifacts.ImageMover
+	imageVerification imageVerificationOptions
+}
+
+// newLayoutImporter creates a layoutImporter that authenticates to the registry with credentials.
+// fallback is only called if there is no OCI layout, so it can build clients that need a Docker daemon.
+func newLayoutImporter(layoutDir, registryEndpoint string, credentials *registry.CredentialStore, insecure bool, fallback func() artifacts.ImageMover) *layoutImporter {
+	return &layoutImporter{
+		layoutDir:        layoutDir,
+		registryEndpoint: registryEndpoint,
+		credentials:      credentials,
+		insecure:         insecure,
+		fallback:         fallback,
+	}
+}
+
+// withImageVerification makes the importer verify the images in the OCI layout before importing them.
+func (i *layoutImporter) withImageVerification(o imageVerificationOptions) *layoutImporter {
+	i.imageVerification = o
+	return i
+}
+
+// hasLayout returns true if the unpackaged artifacts contain an OCI layout.
+func (i *layoutImporter) hasLayout() bool {
+	_, err := os.Stat(filepath.Join(i.layoutDir, ocispec.ImageLayoutFile))
+	return err == nil
+}
+
+func (i *layoutImporter) Move(ctx context.Context, images ...string) error {
+	if !i.hasLayout() {
+		if i.imageVerification.enabled() {
+			return errors.New("verifying images requires images downloaded with --oci-layout, which include their cosign signatures and attestations")
+		}
+		return i.fallback().Move(ctx, images...)
+	}
+
+	layout := registry.StorageSource(registry.NewOCILayout(i.layoutDir))
+	if err := i.imageVerification.verify(ctx, layout, images); err != nil {
+		return err
+	}
+
+	mover, err := i.layoutMover()
+	if err != nil {
+		return err
+	}
+	return mover.WithSignatures().Move(ctx, images...)
+}
+
+// Import pushes the helm charts in the OCI layout to the registry.
+func (i *layoutImporter) Import(ctx context.Context, charts ...string) error {
+	mover, err := i.layoutMover()
+	if err != nil {
+		return err
+	}
+	return mover.Move(ctx, charts...)
+}
+
+func (i *layoutImporter) layoutMover() (*registry.ImageMover, error) {
+	dst, err := registry.NewStorageClient(i.registryEndpoint, i.credentials, i.insecure, false)
+	if err != nil {
+		return nil, err
+	}
+	return registry.NewImageMover(registry.StorageSource(registry.NewOCILayout(i.layoutDir)), dst), nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+type fakeImageMover struct {
+	moved []string
+}
+
+func (m *fakeImageMover) Move(_ context.Context, images ...string) error {
+	m.moved = append(m.moved, images...)
+	return nil
+}
+
+func TestLayoutImporterFallbackWithoutLayout(t *testing.T) {
+	fallback := &fakeImageMover{}
+	importer := newLayoutImporter(t.TempDir(), "localhost:5000", registry.NewStaticCredentialStore("localhost:5000", "", ""), false, func() artifacts.ImageMover {
+		return fallback
+	})
+
+	if err := importer.Move(context.Background(), "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fallback.moved) != 1 {
+		t.Errorf("Expected the image to be moved with the fallback mover, got %v", fallback.moved)
+	}
+}
+
+func TestLayoutImporterImportsChartsFromLayout(t *testing.T) {
+	ctx := context.Background()
+	srcDir, dstDir := t.TempDir(), t.TempDir()
+	chart := "public.ecr.aws/eks-anywhere/cilium-chart:1.0.0"
+	artifact := registry.NewArtifactFromURI(chart)
+
+	src := registry.NewOCILayout(srcDir)
+	if err := src.Init(); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := src.GetStorage(ctx, artifact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer, err := oras.PushBytes(ctx, repo, "application/vnd.cncf.helm.chart.content.v1.tar+gzip", []byte("chart"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1_RC4, "application/vnd.cncf.helm.config.v1+json", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = repo.Tag(ctx, manifest, artifact.Tag); err != nil {
+		t.Fatal(err)
+	}
+
+	importer := newLayoutImporter(srcDir, registry.OCILayoutPrefix+dstDir, registry.NewStaticCredentialStore("", "", ""), false, func() artifacts.ImageMover {
+		t.Fatal("Expected the charts to be imported from the OCI layout")
+		return nil
+	})
+	if !importer.hasLayout() {
+		t.Fatal("Expected the artifacts to contain an OCI layout")
+	}
+	if err = importer.Import(ctx, chart); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := registry.NewOCILayout(dstDir)
+	if err := dst.Init(); err != nil {
+		t.Fatal(err)
+	}
+	dstRepo, err := dst.GetStorage(ctx, artifact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, err := dst.Resolve(ctx, dstRepo, artifact.VersionedImage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.Digest != manifest.Digest {
+		t.Errorf("Expected chart manifest %s in the destination, got %s", manifest.Digest, imported.Digest)
+	}
+}
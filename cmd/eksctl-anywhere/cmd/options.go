@@ -19,6 +19,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/manifests"
@@ -78,6 +79,7 @@ type clusterOptions struct {
 	fileName             string
 	bundlesOverride      string
 	managementKubeconfig string
+	bootstrapKubeconfig  string
 }
 
 func (c clusterOptions) mountDirs() []string {
@@ -89,6 +91,27 @@ func (c clusterOptions) mountDirs() []string {
 	return dirs
 }
 
+// validateBootstrapKubeconfig validates the existing cluster set with --bootstrap-kubeconfig
+// can bootstrap the cluster.
+func (c clusterOptions) validateBootstrapKubeconfig(clusterConfig *v1alpha1.Cluster) error {
+	if c.bootstrapKubeconfig == "" {
+		return nil
+	}
+	if !validations.FileExistsAndIsNotEmpty(c.bootstrapKubeconfig) {
+		return fmt.Errorf("the bootstrap kubeconfig file %s does not exist or is empty", c.bootstrapKubeconfig)
+	}
+	if clusterConfig.Spec.DatacenterRef.Kind == v1alpha1.DockerDatacenterKind {
+		return errors.New("an existing bootstrap cluster is not supported for the docker provider")
+	}
+	return nil
+}
+
+// needsContainerRuntime returns true if the command runs containers in the host, either the
+// tools container or the kind bootstrap cluster.
+func (c clusterOptions) needsContainerRuntime() bool {
+	return c.bootstrapKubeconfig == "" || executables.ExecutablesInDocker()
+}
+
 func readClusterSpec(clusterConfigPath string, cliVersion version.Info, opts ...cluster.FileSpecBuilderOpt) (*cluster.Spec, error) {
 	b := cluster.NewFileSpecBuilder(
 		files.NewReader(files.WithEKSAUserAgent("cli", cliVersion.GitVersion)),
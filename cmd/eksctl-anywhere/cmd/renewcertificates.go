@@ -17,6 +17,8 @@ import (
 type renewCertificatesOptions struct {
 	configFile string
 	component  string
+	mode       string
+	image      string
 }
 
 var rc = &renewCertificatesOptions{}
@@ -35,6 +37,9 @@ func init() {
 	renewCertificatesCmd.Flags().StringVarP(&rc.configFile, "config", "f", "", "Config file containing node and SSH information")
 	renewCertificatesCmd.Flags().StringVarP(&rc.component, "component", "c", "", fmt.Sprintf("Component to renew certificates for (%s or %s). If not specified, renews both.", constants.EtcdComponent, constants.ControlPlaneComponent))
 
+	renewCertificatesCmd.Flags().StringVar(&rc.mode, "mode", "", fmt.Sprintf("How to access the control plane nodes (%s or %s). Overrides the mode in the config file, defaults to %s.", certificates.RenewalModeSSH, certificates.RenewalModePod, certificates.RenewalModeSSH))
+	renewCertificatesCmd.Flags().StringVar(&rc.image, "image", "", "Image used for the renewal pods in pod mode. Defaults to the in-place upgrader image of the cluster.")
+
 	if err := renewCertificatesCmd.MarkFlagRequired("config"); err != nil {
 		logger.Fatal(err, "marking config as required")
 	}
@@ -48,6 +53,10 @@ func (rc *renewCertificatesOptions) renewCertificates(cmd *cobra.Command, _ []st
 		return err
 	}
 
+	if rc.mode != "" {
+		cfg.Mode = certificates.RenewalMode(rc.mode)
+	}
+
 	deps, err := dependencies.NewFactory().
 		WithExecutableBuilder().
 		WithKubectl().
@@ -79,7 +88,21 @@ func (rc *renewCertificatesOptions) renewCertificates(cmd *cobra.Command, _ []st
 		os = string(certificates.OSTypeLinux)
 	}
 
-	renewer, err := certificates.NewRenewer(kubeClient, os, cfg)
+	opts := []certificates.RenewerOpt{
+		certificates.WithTracker(certificates.NewTracker(kubeClient, cfg.ClusterName, rc.component, cfg.Mode)),
+	}
+
+	if cfg.UsePods() {
+		image := rc.image
+		if image == "" {
+			if image, err = certificates.UpgraderImage(ctx, kubeClient, cfg.ClusterName); err != nil {
+				return fmt.Errorf("getting image for renewal pods, use --image to set it: %v", err)
+			}
+		}
+		opts = append(opts, certificates.WithControlPlaneRunner(certificates.NewPodRunner(kubeClient, deps.Kubectl, kubeCfgPath, image)))
+	}
+
+	renewer, err := certificates.NewRenewer(kubeClient, os, cfg, opts...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clusterbackup"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/tar"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type restoreWorkloadsOptions struct {
+	clusterName string
+	kubeconfig  string
+	archive     string
+}
+
+var rwo = &restoreWorkloadsOptions{}
+
+var restoreWorkloadsCmd = &cobra.Command{
+	Use:   "workloads",
+	Short: "Move the workload clusters of a management cluster backup to a new management cluster",
+	Long: "Make a new management cluster take over the existing workload clusters saved in a management cluster backup. " +
+		"The management cluster itself is not restored, it needs to be created beforehand",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE:         rwo.restoreWorkloads,
+}
+
+func init() {
+	restoreCmd.AddCommand(restoreWorkloadsCmd)
+	restoreWorkloadsCmd.Flags().StringVarP(&rwo.clusterName, "cluster-name", "n", "", "Name of the new management cluster")
+	restoreWorkloadsCmd.Flags().StringVar(&rwo.kubeconfig, "kubeconfig", "", "New management cluster kubeconfig file. Defaults to the kubeconfig in the cluster folder")
+	restoreWorkloadsCmd.Flags().StringVarP(&rwo.archive, "from", "f", "", "Backup archive created with backup cluster")
+
+	for _, flag := range []string{"cluster-name", "from"} {
+		if err := restoreWorkloadsCmd.MarkFlagRequired(flag); err != nil {
+			logger.Fatal(err, fmt.Sprintf("marking %s as required", flag))
+		}
+	}
+}
+
+func (o *restoreWorkloadsOptions) restoreWorkloads(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+
+	target := &types.Cluster{
+		Name:           o.clusterName,
+		KubeconfigFile: o.kubeconfig,
+	}
+	if target.KubeconfigFile == "" {
+		target.KubeconfigFile = kubeconfig.FromClusterName(o.clusterName)
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableBuilder().
+		WithKubectl().
+		WithClusterctl().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	kubeClient := deps.UnAuthKubeClient.KubeconfigClient(target.KubeconfigFile)
+	restorer := clusterbackup.NewRestorer(kubeClient, deps.Clusterctl, tar.NewGzipPackager())
+	metadata, err := restorer.RestoreWorkloads(ctx, o.archive, target)
+	if err != nil {
+		return fmt.Errorf("restoring workload clusters: %v", err)
+	}
+
+	logger.MarkSuccess("Workload clusters moved to the new management cluster", "backup", metadata.ClusterName, "clusters", metadata.WorkloadClusters)
+	return nil
+}
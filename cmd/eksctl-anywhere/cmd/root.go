@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
@@ -32,18 +34,117 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the log level verbosity")
+	rootCmd.PersistentFlags().String(eventsOutputFlag, "", "Emit machine readable task lifecycle events as JSON lines to a file path, or to stdout with -. When events are written to stdout, the console logs are written to stderr")
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		log.Fatalf("failed to bind flags for root: %v", err)
 	}
 }
 
 func rootPersistentPreRun(cmd *cobra.Command, args []string) {
-	if err := initLogger(); err != nil {
+	consoleOutput, err := initEventSink(cmd)
+	if err != nil {
 		log.Fatal(err)
 	}
+
+	if err := initLogger(consoleOutput); err != nil {
+		log.Fatal(err)
+	}
+}
+
+const (
+	eventsOutputFlag = "events-output"
+	// stdoutEventsOutput is the events-output value to write events to stdout.
+	stdoutEventsOutput = "-"
+)
+
+// eventSink is the events.Sink configured by initEventSink. It's kept outside the command context
+// so Execute can close it even when the command fails.
+var eventSink *events.JSONLinesSink
+
+// initEventSink configures the events.Sink used by task and workflow runners in the command
+// context according to the events-output flag. It returns the stream the console logs must be
+// written to so they don't get mixed with the events: stdout, or stderr when events go to stdout.
+func initEventSink(cmd *cobra.Command) (*os.File, error) {
+	output := viper.GetString(eventsOutputFlag)
+	if output == "" {
+		return os.Stdout, nil
+	}
+
+	var sink *events.JSONLinesSink
+	consoleOutput := os.Stdout
+	if output == stdoutEventsOutput || sharesFile(output, os.Stdout) {
+		sink = events.NewJSONLinesSink(os.Stdout)
+		consoleOutput = os.Stderr
+	} else {
+		var err error
+		if sink, err = events.NewFileSink(output); err != nil {
+			return nil, fmt.Errorf("root cmd: %v", err)
+		}
+	}
+
+	// Events are consumed by machines so they can't share an output with the human readable logs,
+	// like when stdout and stderr are redirected to the same file.
+	if mixesWithConsole(output, consoleOutput) {
+		_ = sink.Close()
+		return nil, fmt.Errorf("root cmd: %s shares its output with the console logs, write events to a separate file or file descriptor", eventsOutputFlag)
+	}
+
+	eventSink = sink
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cmd.SetContext(events.WithSink(ctx, sink))
+
+	return consoleOutput, nil
+}
+
+// sharesFile checks if path refers to the same file as f.
+func sharesFile(path string, f *os.File) bool {
+	pathInfo, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	fInfo, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return os.SameFile(pathInfo, fInfo)
+}
+
+// mixesWithConsole checks if the events output is the same file as the console logs output.
+// Terminals are not considered mixed, since they are read by humans anyway.
+func mixesWithConsole(output string, console *os.File) bool {
+	var outputInfo os.FileInfo
+	var err error
+	if output == stdoutEventsOutput {
+		outputInfo, err = os.Stdout.Stat()
+	} else {
+		outputInfo, err = os.Stat(output)
+	}
+	if err != nil {
+		return false
+	}
+	consoleInfo, err := console.Stat()
+	if err != nil {
+		return false
+	}
+	return os.SameFile(outputInfo, consoleInfo) && outputInfo.Mode()&os.ModeCharDevice == 0
+}
+
+// closeEventSink closes the events.Sink configured by initEventSink, if any.
+func closeEventSink() {
+	if eventSink == nil {
+		return
+	}
+
+	if err := eventSink.Close(); err != nil {
+		fmt.Printf("Failed to close events output: %s", err)
+	}
+	eventSink = nil
 }
 
-func initLogger() error {
+func initLogger(consoleOutput io.Writer) error {
 	logsFolder := filepath.Join(".", "eksa-cli-logs")
 	err := os.MkdirAll(logsFolder, 0o750)
 	if err != nil {
@@ -54,6 +155,7 @@ func initLogger() error {
 	if err = logger.Init(logger.Options{
 		Level:          viper.GetInt("verbosity"),
 		OutputFilePath: outputFilePath,
+		ConsoleOutput:  consoleOutput,
 	}); err != nil {
 		return fmt.Errorf("root cmd: %v", err)
 	}
@@ -62,6 +164,8 @@ func initLogger() error {
 }
 
 func Execute() error {
+	// PersistentPostRun is skipped when a command fails so the event sink is closed here instead.
+	defer closeEventSink()
 	return rootCmd.ExecuteContext(context.Background())
 }
 
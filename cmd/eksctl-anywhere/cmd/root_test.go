@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere/pkg/events"
+)
+
+func TestInitEventSinkFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	viper.Set(eventsOutputFlag, path)
+	t.Cleanup(func() {
+		viper.Set(eventsOutputFlag, "")
+		closeEventSink()
+	})
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if _, err := initEventSink(cmd); err != nil {
+		t.Fatalf("initEventSink() error = %v, want nil", err)
+	}
+
+	events.Emit(cmd.Context(), events.Event{Type: events.TaskStarted})
+	closeEventSink()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(content) == 0 {
+		t.Fatal("events file is empty, want one event")
+	}
+}
+
+// redirectStdStreams points stdout and stderr to the given files for the duration of the test.
+func redirectStdStreams(t *testing.T, stdout, stderr *os.File) {
+	t.Helper()
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	t.Cleanup(func() {
+		os.Stdout, os.Stderr = origStdout, origStderr
+		viper.Set(eventsOutputFlag, "")
+		closeEventSink()
+	})
+}
+
+func createFile(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Create(filepath.Join(t.TempDir(), name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestInitEventSinkStdout(t *testing.T) {
+	for _, output := range []string{"-", "stdout file"} {
+		t.Run(output, func(t *testing.T) {
+			stdout, stderr := createFile(t, "stdout"), createFile(t, "stderr")
+			redirectStdStreams(t, stdout, stderr)
+			if output == "-" {
+				viper.Set(eventsOutputFlag, output)
+			} else {
+				viper.Set(eventsOutputFlag, stdout.Name())
+			}
+
+			cmd := &cobra.Command{}
+			cmd.SetContext(context.Background())
+			consoleOutput, err := initEventSink(cmd)
+			if err != nil {
+				t.Fatalf("initEventSink() error = %v, want nil", err)
+			}
+			if consoleOutput != stderr {
+				t.Fatalf("initEventSink() console output = %s, want stderr", consoleOutput.Name())
+			}
+
+			events.Emit(cmd.Context(), events.Event{Type: events.TaskStarted})
+			content, err := os.ReadFile(stdout.Name())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(content) == 0 {
+				t.Fatal("stdout is empty, want one event")
+			}
+		})
+	}
+}
+
+func TestInitEventSinkSharedWithConsole(t *testing.T) {
+	out := createFile(t, "out")
+	redirectStdStreams(t, out, out)
+	viper.Set(eventsOutputFlag, "-")
+
+	if _, err := initEventSink(&cobra.Command{}); err == nil {
+		t.Fatal("initEventSink() error = nil, want error")
+	}
+	if eventSink != nil {
+		t.Fatal("initEventSink() configured a sink sharing the console logs output")
+	}
+}
+
+func TestInitEventSinkStderr(t *testing.T) {
+	stdout, stderr := createFile(t, "stdout"), createFile(t, "stderr")
+	redirectStdStreams(t, stdout, stderr)
+	viper.Set(eventsOutputFlag, stderr.Name())
+
+	consoleOutput, err := initEventSink(&cobra.Command{})
+	if err != nil {
+		t.Fatalf("initEventSink() error = %v, want nil", err)
+	}
+	if consoleOutput != stdout {
+		t.Fatalf("initEventSink() console output = %s, want stdout", consoleOutput.Name())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
@@ -24,12 +26,16 @@ import (
 type upgradeClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	imageVerificationOptions
 	wConfig               string
 	forceClean            bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	skipValidations       []string
 	providerOptions       *dependencies.ProviderOptions
+	gitOpsWaitForMerge    bool
+	gitOpsMergeTimeout    time.Duration
+	gitOpsPackages        string
 }
 
 var uc = &upgradeClusterOptions{
@@ -65,12 +71,17 @@ var upgradeClusterCmd = &cobra.Command{
 func init() {
 	upgradeCmd.AddCommand(upgradeClusterCmd)
 	applyClusterOptionFlags(upgradeClusterCmd.Flags(), &uc.clusterOptions)
+	applyBootstrapKubeconfigFlag(upgradeClusterCmd.Flags(), &uc.clusterOptions)
 	applyTimeoutFlags(upgradeClusterCmd.Flags(), &uc.timeoutOptions)
+	applyImageVerificationFlags(upgradeClusterCmd.Flags(), &uc.imageVerificationOptions)
 	applyTinkerbellHardwareFlag(upgradeClusterCmd.Flags(), &uc.hardwareCSVPath)
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	hideForceCleanup(upgradeClusterCmd.Flags())
 	upgradeClusterCmd.Flags().StringArrayVar(&uc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass upgrade validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(upgradevalidations.SkippableValidations[:], ",")))
+	upgradeClusterCmd.Flags().BoolVar(&uc.gitOpsWaitForMerge, "gitops-wait-for-merge", false, "Wait for the GitOps pull request with the cluster config changes to be merged and upgrade the cluster. Without it the upgrade stops after opening the pull request. Only used when the FluxConfig has pullRequest set")
+	upgradeClusterCmd.Flags().DurationVar(&uc.gitOpsMergeTimeout, "gitops-merge-timeout", time.Hour, "Maximum time to wait for the GitOps pull request to be merged")
+	upgradeClusterCmd.Flags().StringVar(&uc.gitOpsPackages, "gitops-packages", "", "File with curated packages custom resources to commit to the GitOps repository with the cluster config changes")
 	aflag.MarkRequired(createClusterCmd.Flags(), aflag.ClusterConfig.Name)
 	tinkerbellFlags(upgradeClusterCmd.Flags(), uc.providerOptions.Tinkerbell.BMCOptions.RPC)
 }
@@ -106,6 +117,10 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 		return err
 	}
 
+	if err := uc.validateBootstrapKubeconfig(clusterConfig); err != nil {
+		return err
+	}
+
 	if _, err := uc.commonValidations(ctx); err != nil {
 		return fmt.Errorf("common validations failed due to: %v", err)
 	}
@@ -122,7 +137,18 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 		return err
 	}
 
+	if err := uc.verifyClusterImages(ctx, uc.fileName, uc.bundlesOverride, registrymirror.FromCluster(clusterSpec.Cluster)); err != nil {
+		return err
+	}
+
+	if uc.gitOpsPackages != "" && clusterSpec.FluxConfig == nil {
+		return fmt.Errorf("--gitops-packages requires a FluxConfig gitOpsRef in the cluster config")
+	}
+
 	cliConfig := buildCliConfig(clusterSpec)
+	cliConfig.GitOpsWaitForMerge = uc.gitOpsWaitForMerge
+	cliConfig.GitOpsMergeTimeout = uc.gitOpsMergeTimeout
+	cliConfig.GitOpsPackagesFile = uc.gitOpsPackages
 	dirs, err := uc.directoriesToMount(clusterSpec, cliConfig)
 	if err != nil {
 		return err
@@ -147,6 +173,7 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 	}
 
 	factory := dependencies.ForSpec(clusterSpec).WithExecutableMountDirs(dirs...).
+		UseBootstrapKubeconfig(uc.bootstrapKubeconfig).
 		WithBootstrapper().
 		WithCliConfig(cliConfig).
 		WithClusterManager(clusterSpec.Cluster, clusterManagerTimeoutOpts).
@@ -6,15 +6,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"text/tabwriter"
 
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/eksd"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan"
+	"github.com/aws/eks-anywhere/pkg/workflows/interfaces"
 )
 
 const (
@@ -99,7 +111,12 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 	componentChangeDiffs.Append(cilium.ChangeDiff(currentSpec, newClusterSpec))
 	componentChangeDiffs.Append(eksd.ChangeDiff(currentSpec, newClusterSpec))
 
-	serializedDiff, err := serialize(componentChangeDiffs, output)
+	rolloutPlan, err := getRolloutPlan(ctx, deps.UnAuthKubeClient, deps.Provider, managementCluster, currentSpec, newClusterSpec)
+	if err != nil {
+		return err
+	}
+
+	serializedDiff, err := serialize(&upgradePlan{ChangeDiff: componentChangeDiffs, Rollout: rolloutPlan}, output)
 	if err != nil {
 		return err
 	}
@@ -109,41 +126,184 @@ func (uc *upgradeClusterOptions) upgradePlanCluster(ctx context.Context) error {
 	return nil
 }
 
-func serialize(componentChangeDiffs *types.ChangeDiff, outputFormat string) (string, error) {
+// upgradePlan is the output of the upgrade plan cluster command.
+type upgradePlan struct {
+	*types.ChangeDiff
+
+	// Rollout describes how the cluster machines would be upgraded.
+	Rollout *upgradeplan.Plan `json:"rollout,omitempty"`
+}
+
+func getRolloutPlan(ctx context.Context, clientFactory interfaces.ClientFactory, provider providers.Provider, managementCluster *types.Cluster, currentSpec, spec *cluster.Spec) (*upgradeplan.Plan, error) {
+	client, err := clientFactory.BuildClientFromKubeconfig(managementCluster.KubeconfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := client.List(ctx, machines, kubernetes.ListOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return nil, fmt.Errorf("listing machines: %v", err)
+	}
+
+	var clusterMachines []clusterv1.Machine
+	for _, m := range machines.Items {
+		if m.Labels[clusterv1.ClusterNameLabel] == spec.Cluster.Name {
+			clusterMachines = append(clusterMachines, m)
+		}
+	}
+
+	current, err := getCurrentRolloutObjects(ctx, client, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// The eks-a objects of the cluster live in the management cluster.
+	workloadCluster := &types.Cluster{
+		Name:           spec.Cluster.Name,
+		KubeconfigFile: managementCluster.KubeconfigFile,
+	}
+
+	var desired *upgradeplan.Objects
+	controlPlaneSpec, workersSpec, err := provider.GenerateCAPISpecForUpgrade(ctx, managementCluster, workloadCluster, currentSpec, spec)
+	if err != nil {
+		logger.Info("Warning: unable to generate the desired CAPI objects, machine rollout only accounts for Kubernetes version changes", "error", err)
+	} else if desired, err = upgradeplan.ParseObjects(controlPlaneSpec, workersSpec); err != nil {
+		return nil, err
+	}
+
+	return upgradeplan.Build(spec, clusterMachines, current, desired), nil
+}
+
+// getCurrentRolloutObjects retrieves the CAPI objects defining the machine groups of spec's
+// cluster. Objects that don't exist yet, such as new worker node groups, are omitted.
+func getCurrentRolloutObjects(ctx context.Context, client kubernetes.Client, spec *cluster.Spec) (*upgradeplan.Objects, error) {
+	objs := &upgradeplan.Objects{MachineDeployments: map[string]*clusterv1.MachineDeployment{}}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if found, err := getIfExists(ctx, client, clusterapi.KubeadmControlPlaneName(spec.Cluster), kcp); err != nil {
+		return nil, err
+	} else if found {
+		objs.KubeadmControlPlane = kcp
+	}
+
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcd := &etcdv1.EtcdadmCluster{}
+		if found, err := getIfExists(ctx, client, clusterapi.EtcdClusterName(spec.Cluster.Name), etcd); err != nil {
+			return nil, err
+		} else if found {
+			objs.EtcdadmCluster = etcd
+		}
+	}
+
+	for _, wng := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		name := clusterapi.MachineDeploymentName(spec.Cluster, wng)
+		md := &clusterv1.MachineDeployment{}
+		if found, err := getIfExists(ctx, client, name, md); err != nil {
+			return nil, err
+		} else if found {
+			objs.MachineDeployments[name] = md
+		}
+	}
+
+	return objs, nil
+}
+
+func getIfExists(ctx context.Context, client kubernetes.Client, name string, obj kubernetes.Object) (bool, error) {
+	err := client.Get(ctx, name, constants.EksaSystemNamespace, obj)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting %s: %v", name, err)
+	}
+	return true, nil
+}
+
+func serialize(plan *upgradePlan, outputFormat string) (string, error) {
 	switch outputFormat {
 	case outputText:
-		return serializeToText(componentChangeDiffs)
+		return serializeToText(plan)
 	case outputJson:
-		return serializeToJson(componentChangeDiffs)
+		return serializeToJson(plan)
 	default:
 		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
 	}
 }
 
-func serializeToText(componentChangeDiffs *types.ChangeDiff) (string, error) {
-	if componentChangeDiffs == nil || (componentChangeDiffs != nil && len(componentChangeDiffs.ComponentReports) == 0) {
+func serializeToText(plan *upgradePlan) (string, error) {
+	componentChangeDiffs := plan.ChangeDiff
+	componentsChanged := componentChangeDiffs != nil && len(componentChangeDiffs.ComponentReports) > 0
+	rolloutChanged := plan.Rollout != nil && plan.Rollout.Changed()
+	if !componentsChanged && !rolloutChanged {
 		return "All the components are up to date with the latest versions", nil
 	}
 
 	buffer := bytes.Buffer{}
-	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
-	fmt.Fprintln(w, "NAME\tCURRENT VERSION\tNEXT VERSION")
-	for i := range componentChangeDiffs.ComponentReports {
-		fmt.Fprintf(w, "%s\t%s\t%s\n", componentChangeDiffs.ComponentReports[i].ComponentName, componentChangeDiffs.ComponentReports[i].OldVersion, componentChangeDiffs.ComponentReports[i].NewVersion)
+	if componentsChanged {
+		w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, "NAME\tCURRENT VERSION\tNEXT VERSION")
+		for i := range componentChangeDiffs.ComponentReports {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", componentChangeDiffs.ComponentReports[i].ComponentName, componentChangeDiffs.ComponentReports[i].OldVersion, componentChangeDiffs.ComponentReports[i].NewVersion)
+		}
+		if err := w.Flush(); err != nil {
+			return "", fmt.Errorf("failed flushing table writer: %v", err)
+		}
+	} else {
+		buffer.WriteString("All the components are up to date with the latest versions, but the machines need to be rolled out\n")
 	}
-	if err := w.Flush(); err != nil {
-		return "", fmt.Errorf("failed flushing table writer: %v", err)
+
+	if rolloutChanged {
+		if err := writeRolloutPlan(&buffer, plan.Rollout); err != nil {
+			return "", err
+		}
 	}
 
 	return buffer.String(), nil
 }
 
-func serializeToJson(componentChangeDiffs *types.ChangeDiff) (string, error) {
-	if componentChangeDiffs == nil {
-		componentChangeDiffs = &types.ChangeDiff{ComponentReports: []types.ComponentChangeDiff{}}
+func writeRolloutPlan(buffer *bytes.Buffer, plan *upgradeplan.Plan) error {
+	fmt.Fprintf(buffer, "\nMachine rollout (up to %d additional machines required)\n", plan.MaxAdditionalMachines)
+
+	w := tabwriter.NewWriter(buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tSTEP\tNEW MACHINES\tREPLACED\tIN PLACE\tUNAVAILABLE")
+	for _, group := range plan.Groups {
+		for i, step := range group.Steps {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%d\n",
+				group.Name,
+				i+1,
+				step.NewMachines,
+				strings.Join(step.ReplacedMachines, ","),
+				strings.Join(step.InPlaceMachines, ","),
+				step.Unavailable,
+			)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	buffer.WriteString("\n")
+	w = tabwriter.NewWriter(buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tSTRATEGY\tMAX SURGE\tMAX UNAVAILABLE\tADDITIONAL MACHINES")
+	for _, group := range plan.Groups {
+		if len(group.Steps) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", group.Name, group.Strategy, group.MaxSurge, group.MaxUnavailable, group.AdditionalMachines)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	return nil
+}
+
+func serializeToJson(plan *upgradePlan) (string, error) {
+	if plan.ChangeDiff == nil {
+		plan.ChangeDiff = &types.ChangeDiff{ComponentReports: []types.ComponentChangeDiff{}}
 	}
 
-	jsonDiff, err := json.Marshal(componentChangeDiffs)
+	jsonDiff, err := json.Marshal(plan)
 	if err != nil {
 		return "", fmt.Errorf("failed serializing the components diff to json: %v", err)
 	}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan"
+)
+
+func TestSerializeToTextUpToDate(t *testing.T) {
+	plan := &upgradePlan{
+		ChangeDiff: &types.ChangeDiff{},
+		Rollout:    &upgradeplan.Plan{Groups: []upgradeplan.GroupPlan{{Name: "cluster-md-0", Machines: 3}}},
+	}
+
+	got, err := serializeToText(plan)
+	if err != nil {
+		t.Fatalf("serializeToText() error = %v, want nil", err)
+	}
+	if got != "All the components are up to date with the latest versions" {
+		t.Fatalf("serializeToText() = %q, want up to date", got)
+	}
+}
+
+func TestSerializeToTextOnlyRollout(t *testing.T) {
+	plan := &upgradePlan{
+		ChangeDiff: &types.ChangeDiff{},
+		Rollout: &upgradeplan.Plan{
+			MaxAdditionalMachines: 1,
+			Groups: []upgradeplan.GroupPlan{
+				{
+					Name:               "cluster-md-0",
+					Machines:           1,
+					MaxSurge:           1,
+					AdditionalMachines: 1,
+					Steps:              []upgradeplan.Step{{NewMachines: 1, ReplacedMachines: []string{"cluster-md-0-a"}}},
+				},
+			},
+		},
+	}
+
+	got, err := serializeToText(plan)
+	if err != nil {
+		t.Fatalf("serializeToText() error = %v, want nil", err)
+	}
+	if !strings.Contains(got, "the machines need to be rolled out") {
+		t.Errorf("serializeToText() = %q, want it to report the rollout is needed", got)
+	}
+	if !strings.Contains(got, "Machine rollout (up to 1 additional machines required)") || !strings.Contains(got, "cluster-md-0-a") {
+		t.Errorf("serializeToText() = %q, want the rollout plan", got)
+	}
+}
+
+func TestSerializeToTextComponentsAndRollout(t *testing.T) {
+	plan := &upgradePlan{
+		ChangeDiff: &types.ChangeDiff{
+			ComponentReports: []types.ComponentChangeDiff{{ComponentName: "EKS Distro", OldVersion: "v1.29.1-eks-1-29-1", NewVersion: "v1.30.1-eks-1-30-1"}},
+		},
+		Rollout: &upgradeplan.Plan{
+			Groups: []upgradeplan.GroupPlan{
+				{Name: "cluster", Machines: 1, Steps: []upgradeplan.Step{{NewMachines: 1, ReplacedMachines: []string{"cluster-a"}}}},
+			},
+		},
+	}
+
+	got, err := serializeToText(plan)
+	if err != nil {
+		t.Fatalf("serializeToText() error = %v, want nil", err)
+	}
+	if !strings.Contains(got, "EKS Distro") || !strings.Contains(got, "cluster-a") {
+		t.Errorf("serializeToText() = %q, want the component changes and the rollout plan", got)
+	}
+	if strings.Contains(got, "up to date") {
+		t.Errorf("serializeToText() = %q, want no up to date message", got)
+	}
+}
@@ -90,7 +90,7 @@ func (uc *upgradeClusterOptions) upgradePlanManagementComponents(ctx context.Con
 		return err
 	}
 
-	serializedDiff, err := serialize(componentChangeDiffs, output)
+	serializedDiff, err := serialize(&upgradePlan{ChangeDiff: componentChangeDiffs}, output)
 	if err != nil {
 		return err
 	}
@@ -11,7 +11,7 @@ import (
 
 func commonValidation(ctx context.Context, clusterConfigFile string) (*v1alpha1.Cluster, error) {
 	docker := executables.BuildDockerExecutable()
-	err := validations.CheckMinimumDockerVersion(ctx, docker)
+	err := validations.CheckMinimumContainerRuntimeVersion(ctx, docker.Runtime(), docker)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate docker: %v", err)
 	}
@@ -11,6 +11,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -39,6 +40,10 @@ import (
 
 const (
 	defaultRequeueTime = time.Minute
+	// certificateRotationRequeueTime is how often clusters with expiring certificates are checked.
+	certificateRotationRequeueTime = 10 * time.Minute
+	// etcdSnapshotRequeueTime is how often the status of the scheduled etcd snapshots is refreshed.
+	etcdSnapshotRequeueTime = 10 * time.Minute
 	// ClusterFinalizerName is the finalizer added to clusters to handle deletion.
 	ClusterFinalizerName = "clusters.anywhere.eks.amazonaws.com/finalizer"
 	releaseV022          = "v0.22.0"
@@ -53,6 +58,9 @@ type ClusterReconciler struct {
 	packagesClient             PackagesClient
 	machineHealthCheck         MachineHealthCheckReconciler
 	vSpherefailureDomainMover  FailureDomainApplier
+	eventRecorder              record.EventRecorder
+	etcdSnapshot               EtcdSnapshotReconciler
+	providerWatches            []client.Object
 }
 
 // PackagesClient handles curated packages operations from within the cluster
@@ -79,6 +87,11 @@ type MachineHealthCheckReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
 }
 
+// EtcdSnapshotReconciler manages the scheduled etcd snapshots for an eks-a cluster.
+type EtcdSnapshotReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
 // ClusterValidator runs cluster level preflight validations before it goes to provider reconciler.
 type ClusterValidator interface {
 	ValidateManagementClusterName(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
@@ -87,6 +100,29 @@ type ClusterValidator interface {
 // ClusterReconcilerOption allows to configure the ClusterReconciler.
 type ClusterReconcilerOption func(*ClusterReconciler)
 
+// WithEventRecorder configures the recorder used to emit Kubernetes events for the Cluster objects.
+func WithEventRecorder(recorder record.EventRecorder) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.eventRecorder = recorder
+	}
+}
+
+// WithEtcdSnapshotReconciler configures the reconciler used to manage the scheduled etcd snapshots.
+// Without it, the cluster etcdSnapshot configuration is ignored.
+func WithEtcdSnapshotReconciler(etcdSnapshot EtcdSnapshotReconciler) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.etcdSnapshot = etcdSnapshot
+	}
+}
+
+// WithProviderWatches configures additional provider objects the reconciler watches to reconcile
+// the clusters referencing them, for the providers wired through the provider registry.
+func WithProviderWatches(objs ...client.Object) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.providerWatches = append(r.providerWatches, objs...)
+	}
+}
+
 // SpecBuilder builds a cluster specification from an EKS Anywhere Cluster object.
 type SpecBuilder interface {
 	BuildSpec(ctx context.Context, eksaCluster *anywherev1.Cluster) (*c.Spec, error)
@@ -174,6 +210,7 @@ func NewClusterReconciler(client client.Client, registry ProviderClusterReconcil
 		packagesClient:             pkgs,
 		machineHealthCheck:         machineHealthCheck,
 		vSpherefailureDomainMover:  failuredomainmover,
+		eventRecorder:              &record.FakeRecorder{},
 	}
 
 	for _, opt := range opts {
@@ -187,7 +224,7 @@ func NewClusterReconciler(client client.Client, registry ProviderClusterReconcil
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger) error {
 	childObjectHandler := handlers.ChildObjectToClusters(log)
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&anywherev1.Cluster{}).
 		Watches(
 			&anywherev1.OIDCConfig{},
@@ -229,10 +266,6 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 			&anywherev1.TinkerbellMachineConfig{},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
 		).
-		Watches(
-			&anywherev1.DockerDatacenterConfig{},
-			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
-		).
 		Watches(
 			&anywherev1.CloudStackDatacenterConfig{},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
@@ -248,8 +281,13 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 		Watches(
 			&anywherev1.NutanixMachineConfig{},
 			handler.EnqueueRequestsFromMapFunc(childObjectHandler),
-		).
-		Complete(r)
+		)
+
+	for _, obj := range r.providerWatches {
+		b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(childObjectHandler))
+	}
+
+	return b.Complete(r)
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch;update
@@ -330,6 +368,16 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && conditions.IsFalse(cluster, anywherev1.ReadyCondition) {
 			result = ctrl.Result{RequeueAfter: 10 * time.Second}
 		}
+
+		// Keep checking expiring certificates so renewal can start as soon as the maintenance window opens.
+		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && conditions.IsFalse(cluster, anywherev1.CertificatesReadyCondition) {
+			result = ctrl.Result{RequeueAfter: certificateRotationRequeueTime}
+		}
+
+		// Snapshot jobs run in the workload cluster and don't trigger reconciliations, so refresh their status periodically.
+		if reterr == nil && !result.Requeue && result.RequeueAfter <= 0 && cluster.Spec.EtcdSnapshot != nil {
+			result = ctrl.Result{RequeueAfter: etcdSnapshotRequeueTime}
+		}
 	}()
 
 	if !cluster.DeletionTimestamp.IsZero() {
@@ -487,9 +535,39 @@ func (r *ClusterReconciler) preClusterProviderReconcile(ctx context.Context, log
 		}
 	}
 
+	if err := setRegistryMirrorFallbacksCredentialsEnv(ctx, r.client, cluster); err != nil {
+		return controller.Result{}, err
+	}
+
 	return controller.Result{}, nil
 }
 
+// setRegistryMirrorFallbacksCredentialsEnv loads the credentials of the authenticated fallback registry
+// mirrors from the registry-credentials secret into the env vars read when configuring containerd.
+func setRegistryMirrorFallbacksCredentialsEnv(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	if cluster.Spec.RegistryMirrorConfiguration == nil {
+		return nil
+	}
+
+	for i, mirror := range cluster.Spec.RegistryMirrorConfiguration.Mirrors {
+		if !mirror.Authenticate {
+			continue
+		}
+
+		index := i + 1
+		rUsername, rPassword, err := config.ReadMirrorCredentialsFromSecret(ctx, client, index)
+		if err != nil {
+			return err
+		}
+
+		if err := config.SetMirrorCredentialsEnv(index, rUsername, rPassword); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *ClusterReconciler) postClusterProviderReconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
 	if cluster.HasAWSIamConfig() {
 		if result, err := r.awsIamAuth.Reconcile(ctx, log, cluster); err != nil {
@@ -503,6 +581,18 @@ func (r *ClusterReconciler) postClusterProviderReconcile(ctx context.Context, lo
 		return controller.Result{}, err
 	}
 
+	if r.etcdSnapshot != nil {
+		if result, err := r.etcdSnapshot.Reconcile(ctx, log, cluster); err != nil {
+			return controller.Result{}, err
+		} else if result.Return() {
+			return result, nil
+		}
+	}
+
+	if err := clusters.ReconcileCertificateRotation(ctx, log, r.client, r.eventRecorder, cluster, time.Now()); err != nil {
+		return controller.Result{}, errors.Wrap(err, "reconciling certificate rotation")
+	}
+
 	return controller.Result{}, nil
 }
 
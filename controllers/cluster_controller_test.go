@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -1162,6 +1163,68 @@ func TestClusterReconcilerReconcileSelfManagedClusterRegAuthFailNoSecret(t *test
 	g.Expect(err).To(MatchError(ContainSubstring("fetching registry auth secret")))
 }
 
+func TestClusterReconcilerReconcileSelfManagedClusterRegAuthFallbackMirrors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+	t.Setenv("REGISTRY_USERNAME_1", "")
+	t.Setenv("REGISTRY_PASSWORD_1", "")
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube132,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			RegistryMirrorConfiguration: &anywherev1.RegistryMirrorConfiguration{
+				Endpoint: "1.2.3.4",
+				Mirrors: []anywherev1.RegistryMirrorEndpoint{
+					{Endpoint: "1.2.3.5", Authenticate: true},
+					{Endpoint: "1.2.3.6", Authenticate: true},
+				},
+			},
+			EksaVersion: &version,
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+	registrySecret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry-credentials",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"username":   []byte("primary"),
+			"password":   []byte("primarypass"),
+			"username_1": []byte("fallback"),
+			"password_1": []byte("fallbackpass"),
+		},
+	}
+
+	controller := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(controller)
+	iam := mocks.NewMockAWSIamConfigReconciler(controller)
+	clusterValidator := mocks.NewMockClusterValidator(controller)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(controller)
+
+	registry := newRegistryMock(providerReconciler)
+	eksaRelease := test.EKSARelease()
+	bundles := createBundle()
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, eksaRelease, bundles, registrySecret).Build()
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, nil, mhcReconciler, nil)
+	_, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).To(MatchError(ContainSubstring("registry auth secret has no credentials for registry mirror 2")))
+	g.Expect(os.Getenv("REGISTRY_USERNAME_1")).To(Equal("fallback"))
+	g.Expect(os.Getenv("REGISTRY_PASSWORD_1")).To(Equal("fallbackpass"))
+}
+
 func TestClusterReconcilerDeleteExistingCAPIClusterSuccess(t *testing.T) {
 	secret := createSecret()
 	managementCluster := vsphereCluster()
@@ -2,15 +2,16 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
-	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	awsiamconfigreconciler "github.com/aws/eks-anywhere/pkg/awsiamauth/reconciler"
 	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
 	mhcreconciler "github.com/aws/eks-anywhere/pkg/clusterapi/machinehealthcheck/reconciler"
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	etcdsnapshotreconciler "github.com/aws/eks-anywhere/pkg/etcdsnapshot/reconciler"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
 	"github.com/aws/eks-anywhere/pkg/helm"
@@ -26,13 +28,8 @@ import (
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
-	cloudstackreconciler "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
-	dockerreconciler "github.com/aws/eks-anywhere/pkg/providers/docker/reconciler"
-	nutanixreconciler "github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
 	snowreconciler "github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
-	tinkerbellreconciler "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
-	vspherereconciler "github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
 )
 
 type Manager = manager.Manager
@@ -45,16 +42,13 @@ type Factory struct {
 	reconcilers                  Reconcilers
 	tracker                      *remote.ClusterCacheTracker
 	registry                     *clusters.ProviderClusterReconcilerRegistry
-	dockerClusterReconciler      *dockerreconciler.Reconciler
-	vsphereClusterReconciler     *vspherereconciler.Reconciler
-	tinkerbellClusterReconciler  *tinkerbellreconciler.Reconciler
-	snowClusterReconciler        *snowreconciler.Reconciler
-	cloudstackClusterReconciler  *cloudstackreconciler.Reconciler
-	nutanixClusterReconciler     *nutanixreconciler.Reconciler
+	registeredClusterReconcilers map[string]clusters.ProviderClusterReconciler
+	registeredControllerWatches  []client.Object
 	cniReconciler                *cnireconciler.Reconciler
 	ipValidator                  *clusters.IPValidator
 	awsIamConfigReconciler       *awsiamconfigreconciler.Reconciler
 	machineHealthCheckReconciler *mhcreconciler.Reconciler
+	etcdSnapshotReconciler       *etcdsnapshotreconciler.Reconciler
 	logger                       logr.Logger
 	deps                         *dependencies.Dependencies
 	packageControllerClient      *curatedpackages.PackageControllerClient
@@ -67,6 +61,7 @@ type Reconcilers struct {
 	ClusterReconciler                  *ClusterReconciler
 	DockerDatacenterReconciler         *DockerDatacenterReconciler
 	VSphereDatacenterReconciler        *VSphereDatacenterReconciler
+	VSphereIPPoolReconciler            *VSphereIPPoolReconciler
 	SnowMachineConfigReconciler        *SnowMachineConfigReconciler
 	TinkerbellDatacenterReconciler     *TinkerbellDatacenterReconciler
 	CloudStackDatacenterReconciler     *CloudStackDatacenterReconciler
@@ -108,6 +103,13 @@ func (f *Factory) Build(ctx context.Context) (*Reconcilers, error) {
 	return &f.reconcilers, nil
 }
 
+// WithProviderRegistry configures the registry the factory resolves registered provider
+// cluster reconcilers from.
+func (f *Factory) WithProviderRegistry(registry *dependencies.ProviderRegistry) *Factory {
+	f.dependencyFactory.WithProviderRegistry(registry)
+	return f
+}
+
 // Close cleans up any open resources from the created dependencies.
 func (f *Factory) Close(ctx context.Context) error {
 	return f.deps.Close(ctx)
@@ -120,7 +122,8 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 		WithProviderClusterReconcilerRegistry(capiProviders).
 		withAWSIamConfigReconciler().
 		withPackageControllerClient().
-		withMachineHealthCheckReconciler()
+		withMachineHealthCheckReconciler().
+		withEtcdSnapshotReconciler()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.reconcilers.ClusterReconciler != nil {
@@ -135,7 +138,10 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 			f.packageControllerClient,
 			f.machineHealthCheckReconciler,
 			NewFailureDomainMover(f.manager.GetClient()),
-			opts...,
+			append([]ClusterReconcilerOption{
+				WithEtcdSnapshotReconciler(f.etcdSnapshotReconciler),
+				WithProviderWatches(f.registeredControllerWatches...),
+			}, opts...)...,
 		)
 
 		return nil
@@ -248,30 +254,6 @@ func (f *Factory) WithNutanixDatacenterReconciler() *Factory {
 	return f
 }
 
-// withNutanixClusterReconciler adds the NutanixClusterReconciler to the controller factory.
-func (f *Factory) withNutanixClusterReconciler() *Factory {
-	f.dependencyFactory.WithNutanixDefaulter().WithNutanixValidator()
-	f.withTracker().withCNIReconciler(f.getProviderNamespace(constants.NutanixProviderName)).withIPValidator()
-	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
-		if f.nutanixClusterReconciler != nil {
-			return nil
-		}
-
-		f.nutanixClusterReconciler = nutanixreconciler.New(
-			f.manager.GetClient(),
-			f.deps.NutanixValidator,
-			f.cniReconciler,
-			f.tracker,
-			f.ipValidator,
-		)
-		f.registryBuilder.Add(anywherev1.NutanixDatacenterKind, f.nutanixClusterReconciler)
-
-		return nil
-	})
-
-	return f
-}
-
 func (f *Factory) withTracker() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.tracker != nil {
@@ -297,15 +279,6 @@ func (f *Factory) withTracker() *Factory {
 	return f
 }
 
-const (
-	dockerProviderName     = "docker"
-	snowProviderName       = "snow"
-	vSphereProviderName    = "vsphere"
-	tinkerbellProviderName = "tinkerbell"
-	cloudstackProviderName = "cloudstack"
-	nutanixProviderName    = "nutanix"
-)
-
 func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterctlv1.Provider) *Factory {
 	f.registryBuilder = clusters.NewProviderClusterReconcilerRegistryBuilder()
 
@@ -314,22 +287,12 @@ func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterc
 			continue
 		}
 
-		switch p.ProviderName {
-		case dockerProviderName:
-			f.withDockerClusterReconciler()
-		case snowProviderName:
-			f.withSnowClusterReconciler()
-		case vSphereProviderName:
-			f.withVSphereClusterReconciler()
-		case tinkerbellProviderName:
-			f.withTinkerbellClusterReconciler()
-		case cloudstackProviderName:
-			f.withCloudStackClusterReconciler()
-		case nutanixProviderName:
-			f.withNutanixClusterReconciler()
-		default:
+		registration, ok := f.dependencyFactory.ProviderRegistry().GetByCAPIProviderName(p.ProviderName)
+		if !ok || registration.NewClusterReconciler == nil {
 			f.logger.Info("Found unknown CAPI provider, ignoring", "providerName", p.ProviderName)
+			continue
 		}
+		f.withRegisteredClusterReconciler(registration)
 	}
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -345,110 +308,35 @@ func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterc
 	return f
 }
 
-func (f *Factory) withDockerClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.DockerProviderName)).withTracker()
-	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
-		if f.dockerClusterReconciler != nil {
-			return nil
-		}
-
-		f.dockerClusterReconciler = dockerreconciler.New(
-			f.manager.GetClient(),
-			f.cniReconciler,
-			f.tracker,
-		)
-		f.registryBuilder.Add(anywherev1.DockerDatacenterKind, f.dockerClusterReconciler)
-
-		return nil
-	})
-
-	return f
-}
-
-func (f *Factory) withVSphereClusterReconciler() *Factory {
-	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator()
-	f.withTracker().withCNIReconciler(f.getProviderNamespace(constants.VSphereProviderName)).withIPValidator()
-	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
-		if f.vsphereClusterReconciler != nil {
-			return nil
-		}
-
-		f.vsphereClusterReconciler = vspherereconciler.New(
-			f.manager.GetClient(),
-			f.deps.VSphereValidator,
-			f.deps.VSphereDefaulter,
-			f.cniReconciler,
-			f.tracker,
-			f.ipValidator,
-		)
-		f.registryBuilder.Add(anywherev1.VSphereDatacenterKind, f.vsphereClusterReconciler)
-
-		return nil
-	})
-
-	return f
-}
-
-func (f *Factory) withSnowClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.SnowProviderName)).withTracker().withIPValidator()
-
+// withRegisteredClusterReconciler adds the cluster reconciler of a provider registered
+// in the dependency factory provider registry to the controller factory.
+func (f *Factory) withRegisteredClusterReconciler(registration dependencies.ProviderRegistration) *Factory {
+	if registration.WithReconcilerDependencies != nil {
+		registration.WithReconcilerDependencies(f.dependencyFactory)
+	}
+	f.withTracker().withCNIReconciler(registration.CAPINamespace).withIPValidator()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
-		if f.snowClusterReconciler != nil {
+		if _, ok := f.registeredClusterReconcilers[registration.DatacenterKind]; ok {
 			return nil
 		}
 
-		f.snowClusterReconciler = snowreconciler.New(
-			f.manager.GetClient(),
-			f.cniReconciler,
-			f.tracker,
-			f.ipValidator,
-		)
-		f.registryBuilder.Add(anywherev1.SnowDatacenterKind, f.snowClusterReconciler)
-
-		return nil
-	})
-
-	return f
-}
-
-func (f *Factory) withTinkerbellClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.TinkerbellProviderName)).withTracker().withIPValidator()
-
-	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
-		if f.tinkerbellClusterReconciler != nil {
-			return nil
+		r, err := registration.NewClusterReconciler(ctx, dependencies.ProviderReconcilerDependencies{
+			Client:        f.manager.GetClient(),
+			CNIReconciler: f.cniReconciler,
+			Tracker:       f.tracker,
+			IPValidator:   f.ipValidator,
+			Dependencies:  f.deps,
+		})
+		if err != nil {
+			return fmt.Errorf("building cluster reconciler for datacenter kind %s: %v", registration.DatacenterKind, err)
 		}
 
-		f.tinkerbellClusterReconciler = tinkerbellreconciler.New(
-			f.manager.GetClient(),
-			f.cniReconciler,
-			f.tracker,
-			f.ipValidator,
-		)
-		f.registryBuilder.Add(anywherev1.TinkerbellDatacenterKind, f.tinkerbellClusterReconciler)
-
-		return nil
-	})
-
-	return f
-}
-
-func (f *Factory) withCloudStackClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.CloudStackProviderName)).withTracker().withIPValidator().withCloudStackValidatorRegistry()
-
-	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
-		if f.cloudstackClusterReconciler != nil {
-			return nil
+		if f.registeredClusterReconcilers == nil {
+			f.registeredClusterReconcilers = map[string]clusters.ProviderClusterReconciler{}
 		}
-
-		f.cloudstackClusterReconciler = cloudstackreconciler.New(
-			f.manager.GetClient(),
-			f.ipValidator,
-			f.cniReconciler,
-			f.tracker,
-			f.cloudStackValidatorRegistry,
-		)
-		f.registryBuilder.Add(anywherev1.CloudStackDatacenterKind, f.cloudstackClusterReconciler)
+		f.registeredClusterReconcilers[registration.DatacenterKind] = r
+		f.registryBuilder.Add(registration.DatacenterKind, r)
+		f.registeredControllerWatches = append(f.registeredControllerWatches, registration.ControllerWatches...)
 
 		return nil
 	})
@@ -594,6 +482,25 @@ func (f *Factory) withMachineHealthCheckReconciler() *Factory {
 	return f
 }
 
+func (f *Factory) withEtcdSnapshotReconciler() *Factory {
+	f.withTracker()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.etcdSnapshotReconciler != nil {
+			return nil
+		}
+
+		f.etcdSnapshotReconciler = etcdsnapshotreconciler.New(
+			f.manager.GetClient(),
+			f.tracker,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
 // WithKubeadmControlPlaneReconciler builds the KubeadmControlPlane reconciler.
 func (f *Factory) WithKubeadmControlPlaneReconciler() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -612,6 +519,24 @@ func (f *Factory) WithKubeadmControlPlaneReconciler() *Factory {
 	return f
 }
 
+// WithVSphereIPPoolReconciler builds the VSphereIPPool reconciler.
+func (f *Factory) WithVSphereIPPoolReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.VSphereIPPoolReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.VSphereIPPoolReconciler = NewVSphereIPPoolReconciler(
+			f.manager.GetClient(),
+			f.manager.GetAPIReader(),
+		)
+
+		return nil
+	})
+
+	return f
+}
+
 // WithMachineDeploymentReconciler builds the MachineDeployment reconciler.
 func (f *Factory) WithMachineDeploymentReconciler() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -684,24 +609,3 @@ func (f *Factory) WithNodeUpgradeReconciler() *Factory {
 
 	return f
 }
-
-func (f *Factory) getProviderNamespace(providerName string) string {
-	var providerNamespace string
-	switch providerName {
-	case snowProviderName:
-		providerNamespace = constants.CapasSystemNamespace
-	case vSphereProviderName:
-		providerNamespace = constants.CapvSystemNamespace
-	case tinkerbellProviderName:
-		providerNamespace = constants.CaptSystemNamespace
-	case cloudstackProviderName:
-		providerNamespace = constants.CapcSystemNamespace
-	case nutanixProviderName:
-		providerNamespace = constants.CapxSystemNamespace
-	case dockerProviderName:
-		providerNamespace = constants.CapdSystemNamespace
-	default:
-		f.logger.Info("Found unknown CAPI provider, ignoring", "providerName", providerName)
-	}
-	return providerNamespace
-}
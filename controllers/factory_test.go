@@ -2,6 +2,7 @@ package controllers_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -10,6 +11,9 @@ import (
 
 	"github.com/aws/eks-anywhere/controllers"
 	"github.com/aws/eks-anywhere/controllers/mocks"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/providers"
 )
 
 func TestFactoryBuildAllVSphereReconciler(t *testing.T) {
@@ -272,6 +276,26 @@ func TestFactoryWithMachineDeploymentReconciler(t *testing.T) {
 	g.Expect(reconcilers.MachineDeploymentReconciler).NotTo(BeNil())
 }
 
+func TestFactoryWithVSphereIPPoolReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetAPIReader().AnyTimes()
+
+	f := controllers.NewFactory(logger, manager).
+		WithVSphereIPPoolReconciler()
+
+	// testing idempotence
+	f.WithVSphereIPPoolReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.VSphereIPPoolReconciler).NotTo(BeNil())
+}
+
 func TestFactoryWithNodeUpgradeReconciler(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -331,3 +355,83 @@ func TestFactoryWithMachineDeploymentUpgradeReconciler(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(reconcilers.MachineDeploymentUpgradeReconciler).NotTo(BeNil())
 }
+
+func TestFactoryBuildClusterReconcilerWithRegisteredProvider(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	built := 0
+	registry := dependencies.NewProviderRegistry()
+	g.Expect(registry.Register(dependencies.ProviderRegistration{
+		DatacenterKind:   "OpenStackDatacenterConfig",
+		CAPIProviderName: "openstack",
+		CAPINamespace:    "capo-system",
+		NewProvider: func(context.Context, *dependencies.Dependencies, dependencies.ProviderConfig) (providers.Provider, error) {
+			return nil, nil
+		},
+		NewClusterReconciler: func(_ context.Context, deps dependencies.ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			built++
+			g.Expect(deps.CNIReconciler).NotTo(BeNil())
+			g.Expect(deps.Dependencies).NotTo(BeNil())
+			return mocks.NewMockProviderClusterReconciler(ctrl), nil
+		},
+	})).To(Succeed())
+
+	capiProviders := []clusterctlv1.Provider{
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "openstack",
+		},
+	}
+
+	f := controllers.NewFactory(logger, manager).
+		WithProviderRegistry(registry).
+		WithClusterReconciler(capiProviders)
+
+	// testing idempotence
+	f.WithClusterReconciler(capiProviders)
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.ClusterReconciler).NotTo(BeNil())
+	g.Expect(built).To(Equal(1))
+}
+
+func TestFactoryBuildClusterReconcilerWithRegisteredProviderError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	manager.EXPECT().GetScheme().AnyTimes()
+
+	registry := dependencies.NewProviderRegistry()
+	g.Expect(registry.Register(dependencies.ProviderRegistration{
+		DatacenterKind:   "OpenStackDatacenterConfig",
+		CAPIProviderName: "openstack",
+		CAPINamespace:    "capo-system",
+		NewProvider: func(context.Context, *dependencies.Dependencies, dependencies.ProviderConfig) (providers.Provider, error) {
+			return nil, nil
+		},
+		NewClusterReconciler: func(context.Context, dependencies.ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			return nil, errors.New("missing credentials")
+		},
+	})).To(Succeed())
+
+	_, err := controllers.NewFactory(logger, manager).
+		WithProviderRegistry(registry).
+		WithClusterReconciler([]clusterctlv1.Provider{
+			{
+				Type:         string(clusterctlv1.InfrastructureProviderType),
+				ProviderName: "openstack",
+			},
+		}).
+		Build(ctx)
+	g.Expect(err).To(MatchError("building cluster reconciler for datacenter kind OpenStackDatacenterConfig: missing credentials"))
+}
@@ -232,6 +232,44 @@ func (mr *MockMachineHealthCheckReconcilerMockRecorder) Reconcile(ctx, logger, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockMachineHealthCheckReconciler)(nil).Reconcile), ctx, logger, cluster)
 }
 
+// MockEtcdSnapshotReconciler is a mock of EtcdSnapshotReconciler interface.
+type MockEtcdSnapshotReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEtcdSnapshotReconcilerMockRecorder
+}
+
+// MockEtcdSnapshotReconcilerMockRecorder is the mock recorder for MockEtcdSnapshotReconciler.
+type MockEtcdSnapshotReconcilerMockRecorder struct {
+	mock *MockEtcdSnapshotReconciler
+}
+
+// NewMockEtcdSnapshotReconciler creates a new mock instance.
+func NewMockEtcdSnapshotReconciler(ctrl *gomock.Controller) *MockEtcdSnapshotReconciler {
+	mock := &MockEtcdSnapshotReconciler{ctrl: ctrl}
+	mock.recorder = &MockEtcdSnapshotReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEtcdSnapshotReconciler) EXPECT() *MockEtcdSnapshotReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockEtcdSnapshotReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockEtcdSnapshotReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockEtcdSnapshotReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
 // MockClusterValidator is a mock of ClusterValidator interface.
 type MockClusterValidator struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,390 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// vsphereIPPoolClaimFinalizer is the finalizer added to the IPAddressClaims fulfilled from a
+	// VSphereIPPool, so their address is released before they are deleted.
+	vsphereIPPoolClaimFinalizer = "vsphereippools.anywhere.eks.amazonaws.com/ipaddressclaim"
+)
+
+// VSphereIPPoolReconciler allocates addresses from a VSphereIPPool to the CAPI IPAddressClaims
+// referencing it and records the allocations in the pool status.
+//
+// CAPV creates the claims in the eksa-system namespace, next to the machines, with a local
+// reference to the pool, while the pool lives in the namespace of its EKS-A Cluster. The pool of
+// a claim in eksa-system is resolved in the namespace of the EKS-A Cluster the claim belongs to.
+type VSphereIPPoolReconciler struct {
+	// client reads from a cache and is not a fully direct client.
+	client client.Client
+	// uncachedClient reads directly from the API server, so addresses are never allocated
+	// from a stale list of IPAddresses.
+	uncachedClient client.Reader
+	log            logr.Logger
+}
+
+// NewVSphereIPPoolReconciler returns a new instance of VSphereIPPoolReconciler.
+func NewVSphereIPPoolReconciler(client client.Client, uncachedClient client.Reader) *VSphereIPPoolReconciler {
+	return &VSphereIPPoolReconciler{
+		client:         client,
+		uncachedClient: uncachedClient,
+		log:            ctrl.Log.WithName("VSphereIPPoolController"),
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VSphereIPPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&anywherev1.VSphereIPPool{}).
+		Watches(
+			&ipamv1.IPAddressClaim{},
+			handler.EnqueueRequestsFromMapFunc(r.vsphereIPPoolForClaim),
+		).
+		Complete(r)
+}
+
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=vsphereippools,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=vsphereippools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch;create;delete
+
+// Reconcile allocates addresses to the pending claims of a VSphereIPPool, releases the addresses
+// of the deleted claims and updates the pool status.
+func (r *VSphereIPPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("VSphereIPPool", req.NamespacedName)
+
+	pool := &anywherev1.VSphereIPPool{}
+	if err := r.client.Get(ctx, req.NamespacedName, pool); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The claims of a deleted pool can't get new addresses, but their addresses
+		// still need to be released.
+		pool = nil
+	}
+
+	clusterNamespaces, err := r.clusterNamespaces(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	claims, err := r.claimsForPool(ctx, req.NamespacedName, clusterNamespaces)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	addresses, err := r.addressesForPool(ctx, req.NamespacedName, clusterNamespaces)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var errs []error
+	for i := range claims {
+		claim := &claims[i]
+		if pool == nil && claim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.reconcileClaim(ctx, log, pool, claim, addresses); err != nil {
+			errs = append(errs, fmt.Errorf("reconciling IPAddressClaim %s: %v", claim.Name, err))
+		}
+	}
+
+	if pool != nil {
+		if err := r.updateStatus(ctx, pool, addresses); err != nil {
+			errs = append(errs, fmt.Errorf("updating VSphereIPPool status: %v", err))
+		}
+	}
+
+	return ctrl.Result{}, kerrors.NewAggregate(errs)
+}
+
+// reconcileClaim allocates an address to claim or releases it if the claim is being deleted.
+// addresses holds the addresses allocated from the pool by claim name and is kept up to date.
+func (r *VSphereIPPoolReconciler) reconcileClaim(ctx context.Context, log logr.Logger, pool *anywherev1.VSphereIPPool, claim *ipamv1.IPAddressClaim, addresses map[string]*ipamv1.IPAddress) (reterr error) {
+	paused, err := r.claimPaused(ctx, claim)
+	if err != nil {
+		return err
+	}
+	if paused {
+		log.Info("IPAddressClaim is paused, skipping", "claim", claim.Name)
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(claim, r.client)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, claim); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, fmt.Errorf("patching IPAddressClaim: %v", err)})
+		}
+	}()
+
+	if !claim.DeletionTimestamp.IsZero() {
+		if address, ok := addresses[claim.Name]; ok {
+			if err := r.client.Delete(ctx, address); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("releasing address %s: %v", address.Spec.Address, err)
+			}
+			log.Info("Released address", "claim", claim.Name, "address", address.Spec.Address)
+			delete(addresses, claim.Name)
+		}
+		controllerutil.RemoveFinalizer(claim, vsphereIPPoolClaimFinalizer)
+		return nil
+	}
+
+	controllerutil.AddFinalizer(claim, vsphereIPPoolClaimFinalizer)
+
+	address, ok := addresses[claim.Name]
+	if !ok {
+		if address, err = r.allocate(ctx, pool, claim, addresses); err != nil {
+			return err
+		}
+		log.Info("Allocated address", "claim", claim.Name, "address", address.Spec.Address)
+	}
+	claim.Status.AddressRef = corev1.LocalObjectReference{Name: address.Name}
+
+	return nil
+}
+
+// allocate creates an IPAddress for claim with the lowest free address of the pool.
+func (r *VSphereIPPoolReconciler) allocate(ctx context.Context, pool *anywherev1.VSphereIPPool, claim *ipamv1.IPAddressClaim, addresses map[string]*ipamv1.IPAddress) (*ipamv1.IPAddress, error) {
+	allocated := make(map[string]struct{}, len(addresses))
+	for _, address := range addresses {
+		allocated[address.Spec.Address] = struct{}{}
+	}
+
+	free, err := pool.FreeAddress(allocated)
+	if err != nil {
+		return nil, err
+	}
+
+	address := &ipamv1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(claim, ipamv1.GroupVersion.WithKind("IPAddressClaim")),
+			},
+		},
+		Spec: ipamv1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claim.Name},
+			PoolRef:  claim.Spec.PoolRef,
+			Address:  free,
+			Prefix:   pool.Spec.Prefix,
+			Gateway:  pool.Spec.Gateway,
+		},
+	}
+	if clusterName, ok := claim.Labels[clusterv1.ClusterNameLabel]; ok {
+		address.Labels = map[string]string{clusterv1.ClusterNameLabel: clusterName}
+	}
+
+	if err := r.client.Create(ctx, address); err != nil {
+		return nil, fmt.Errorf("creating IPAddress: %v", err)
+	}
+	addresses[claim.Name] = address
+
+	return address, nil
+}
+
+// claimPaused returns true if the claim or its cluster are paused, like during a clusterctl move.
+func (r *VSphereIPPoolReconciler) claimPaused(ctx context.Context, claim *ipamv1.IPAddressClaim) (bool, error) {
+	if annotations.HasPaused(claim) {
+		return true, nil
+	}
+
+	clusterName := claimClusterName(claim)
+	if clusterName == "" {
+		return false, nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: claim.Namespace, Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting cluster %s: %v", clusterName, err)
+	}
+
+	return cluster.Spec.Paused, nil
+}
+
+func (r *VSphereIPPoolReconciler) updateStatus(ctx context.Context, pool *anywherev1.VSphereIPPool, addresses map[string]*ipamv1.IPAddress) error {
+	patchHelper, err := patch.NewHelper(pool, r.client)
+	if err != nil {
+		return err
+	}
+
+	// An invalid pool can't allocate any address, it's rejected by the webhook.
+	total, _ := pool.Size()
+	free := total
+	var allocations []anywherev1.VSphereIPPoolAllocation
+	for _, address := range addresses {
+		allocations = append(allocations, anywherev1.VSphereIPPoolAllocation{
+			Address: address.Spec.Address,
+			Claim:   address.Spec.ClaimRef.Name,
+		})
+		if ok, _ := pool.Contains(address.Spec.Address); ok {
+			free--
+		}
+	}
+	sort.Slice(allocations, func(i, j int) bool {
+		return addressLess(allocations[i].Address, allocations[j].Address)
+	})
+
+	pool.Status = anywherev1.VSphereIPPoolStatus{
+		Allocations: allocations,
+		Total:       total,
+		Free:        free,
+	}
+
+	return patchHelper.Patch(ctx, pool)
+}
+
+// claimsForPool returns the IPAddressClaims of the pool, sorted by name.
+func (r *VSphereIPPoolReconciler) claimsForPool(ctx context.Context, pool types.NamespacedName, clusterNamespaces map[string]string) ([]ipamv1.IPAddressClaim, error) {
+	var claims []ipamv1.IPAddressClaim
+	for _, namespace := range claimNamespaces(pool) {
+		claimList := &ipamv1.IPAddressClaimList{}
+		if err := r.client.List(ctx, claimList, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("listing IPAddressClaims: %v", err)
+		}
+
+		for _, claim := range claimList.Items {
+			if isVSphereIPPoolRef(claim.Spec.PoolRef, pool.Name) &&
+				poolNamespace(claim.Namespace, claimClusterName(&claim), clusterNamespaces) == pool.Namespace {
+				claims = append(claims, claim)
+			}
+		}
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return claims[i].Name < claims[j].Name
+	})
+
+	return claims, nil
+}
+
+// addressesForPool returns the IPAddresses allocated from the pool by claim name.
+func (r *VSphereIPPoolReconciler) addressesForPool(ctx context.Context, pool types.NamespacedName, clusterNamespaces map[string]string) (map[string]*ipamv1.IPAddress, error) {
+	addresses := map[string]*ipamv1.IPAddress{}
+	for _, namespace := range claimNamespaces(pool) {
+		addressList := &ipamv1.IPAddressList{}
+		if err := r.uncachedClient.List(ctx, addressList, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("listing IPAddresses: %v", err)
+		}
+
+		for i := range addressList.Items {
+			address := &addressList.Items[i]
+			if isVSphereIPPoolRef(address.Spec.PoolRef, pool.Name) &&
+				poolNamespace(address.Namespace, address.Labels[clusterv1.ClusterNameLabel], clusterNamespaces) == pool.Namespace {
+				addresses[address.Spec.ClaimRef.Name] = address
+			}
+		}
+	}
+
+	return addresses, nil
+}
+
+// clusterNamespaces returns the namespace of each EKS-A Cluster by name. The CAPI objects of all
+// the clusters are in eksa-system, so cluster names are unique across namespaces.
+func (r *VSphereIPPoolReconciler) clusterNamespaces(ctx context.Context) (map[string]string, error) {
+	clusterList := &anywherev1.ClusterList{}
+	if err := r.client.List(ctx, clusterList); err != nil {
+		return nil, fmt.Errorf("listing EKS-A Clusters: %v", err)
+	}
+
+	namespaces := make(map[string]string, len(clusterList.Items))
+	for _, cluster := range clusterList.Items {
+		namespaces[cluster.Name] = cluster.Namespace
+	}
+
+	return namespaces, nil
+}
+
+func (r *VSphereIPPoolReconciler) vsphereIPPoolForClaim(ctx context.Context, o client.Object) []reconcile.Request {
+	claim, ok := o.(*ipamv1.IPAddressClaim)
+	if !ok || !isVSphereIPPoolRef(claim.Spec.PoolRef, claim.Spec.PoolRef.Name) {
+		return nil
+	}
+
+	clusterNamespaces, err := r.clusterNamespaces(ctx)
+	if err != nil {
+		r.log.Error(err, "Failed resolving VSphereIPPool namespace for IPAddressClaim", "claim", claim.Name)
+		return nil
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: poolNamespace(claim.Namespace, claimClusterName(claim), clusterNamespaces),
+			Name:      claim.Spec.PoolRef.Name,
+		},
+	}}
+}
+
+// claimNamespaces returns the namespaces where the claims of the pool can be.
+func claimNamespaces(pool types.NamespacedName) []string {
+	if pool.Namespace == constants.EksaSystemNamespace {
+		return []string{pool.Namespace}
+	}
+	return []string{pool.Namespace, constants.EksaSystemNamespace}
+}
+
+// poolNamespace returns the namespace of the pool referenced by a claim, or its address, in
+// namespace for the cluster clusterName. Claims in eksa-system reference the pool in the
+// namespace of their EKS-A Cluster, any other claim references a pool in its own namespace.
+func poolNamespace(namespace, clusterName string, clusterNamespaces map[string]string) string {
+	if namespace != constants.EksaSystemNamespace {
+		return namespace
+	}
+	if clusterNamespace, ok := clusterNamespaces[clusterName]; ok {
+		return clusterNamespace
+	}
+	return namespace
+}
+
+func claimClusterName(claim *ipamv1.IPAddressClaim) string {
+	if claim.Spec.ClusterName != "" {
+		return claim.Spec.ClusterName
+	}
+	return claim.Labels[clusterv1.ClusterNameLabel]
+}
+
+func isVSphereIPPoolRef(ref corev1.TypedLocalObjectReference, name string) bool {
+	return ref.APIGroup != nil && *ref.APIGroup == anywherev1.GroupVersion.Group &&
+		ref.Kind == anywherev1.VSphereIPPoolKind && ref.Name == name
+}
+
+func addressLess(a, b string) bool {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return addrA.Less(addrB)
+}
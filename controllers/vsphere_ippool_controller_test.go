@@ -0,0 +1,324 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const vsphereIPPoolClaimFinalizer = "vsphereippools.anywhere.eks.amazonaws.com/ipaddressclaim"
+
+func TestVSphereIPPoolSetupWithManager(t *testing.T) {
+	client := env.Client()
+	r := controllers.NewVSphereIPPoolReconciler(client, client)
+
+	g := NewWithT(t)
+	g.Expect(r.SetupWithManager(env.Manager())).To(Succeed())
+}
+
+func TestVSphereIPPoolReconcileAllocatesAddresses(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := vsphereIPPool()
+	claimA := ipAddressClaim("claim-a", pool.Name)
+	claimB := ipAddressClaim("claim-b", pool.Name)
+	otherPoolClaim := ipAddressClaim("claim-c", "other-pool")
+
+	c := vsphereIPPoolClient(pool, claimA, claimB, otherPoolClaim, capiClusterForIPPool(false))
+	r := controllers.NewVSphereIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectAddressAllocated(ctx, g, c, "claim-a", "10.0.0.10")
+	expectAddressAllocated(ctx, g, c, "claim-b", "10.0.0.11")
+	expectNoAddress(ctx, g, c, "claim-c")
+
+	address, err := getIPAddress(ctx, c, "claim-a")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(address.Spec.Prefix).To(Equal(24))
+	g.Expect(address.Spec.Gateway).To(Equal("10.0.0.1"))
+	g.Expect(address.Spec.PoolRef).To(Equal(claimA.Spec.PoolRef))
+	g.Expect(address.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, "my-cluster"))
+	g.Expect(address.OwnerReferences).To(HaveLen(1))
+	g.Expect(address.OwnerReferences[0].Name).To(Equal("claim-a"))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status).To(Equal(anywherev1.VSphereIPPoolStatus{
+		Allocations: []anywherev1.VSphereIPPoolAllocation{
+			{Address: "10.0.0.10", Claim: "claim-a"},
+			{Address: "10.0.0.11", Claim: "claim-b"},
+		},
+		Total: 3,
+		Free:  1,
+	}))
+}
+
+func TestVSphereIPPoolReconcileKeepsExistingAllocations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := vsphereIPPool()
+	claimA := ipAddressClaim("claim-a", pool.Name)
+	claimB := ipAddressClaim("claim-b", pool.Name)
+	existing := ipAddress(claimB, "10.0.0.10")
+
+	c := vsphereIPPoolClient(pool, claimA, claimB, existing)
+	r := controllers.NewVSphereIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectAddressAllocated(ctx, g, c, "claim-a", "10.0.0.11")
+	expectAddressAllocated(ctx, g, c, "claim-b", "10.0.0.10")
+}
+
+func TestVSphereIPPoolReconcileReleasesDeletedClaims(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := vsphereIPPool()
+	claim := ipAddressClaim("claim-a", pool.Name)
+	claim.Finalizers = []string{vsphereIPPoolClaimFinalizer}
+	claim.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	address := ipAddress(claim, "10.0.0.10")
+
+	c := vsphereIPPoolClient(pool, claim, address)
+	r := controllers.NewVSphereIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectNoAddress(ctx, g, c, "claim-a")
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), &ipamv1.IPAddressClaim{})).To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status.Allocations).To(BeEmpty())
+	g.Expect(pool.Status.Free).To(Equal(3))
+}
+
+func TestVSphereIPPoolReconcilePoolNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := vsphereIPPool()
+	deleted := ipAddressClaim("claim-a", pool.Name)
+	deleted.Finalizers = []string{vsphereIPPoolClaimFinalizer}
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	pending := ipAddressClaim("claim-b", pool.Name)
+
+	c := vsphereIPPoolClient(deleted, ipAddress(deleted, "10.0.0.10"), pending)
+	r := controllers.NewVSphereIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectNoAddress(ctx, g, c, "claim-a")
+	expectNoAddress(ctx, g, c, "claim-b")
+}
+
+func TestVSphereIPPoolReconcilePoolExhausted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := vsphereIPPool()
+	pool.Spec.Addresses = []string{"10.0.0.10"}
+	claimA := ipAddressClaim("claim-a", pool.Name)
+	claimB := ipAddressClaim("claim-b", pool.Name)
+
+	c := vsphereIPPoolClient(pool, claimA, claimB)
+	r := controllers.NewVSphereIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).To(MatchError(ContainSubstring("reconciling IPAddressClaim claim-b: VSphereIPPool pool has no free addresses")))
+
+	expectAddressAllocated(ctx, g, c, "claim-a", "10.0.0.10")
+	expectNoAddress(ctx, g, c, "claim-b")
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status.Total).To(Equal(1))
+	g.Expect(pool.Status.Free).To(Equal(0))
+}
+
+func TestVSphereIPPoolReconcilePausedCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := vsphereIPPool()
+	claim := ipAddressClaim("claim-a", pool.Name)
+
+	c := vsphereIPPoolClient(pool, claim, capiClusterForIPPool(true))
+	r := controllers.NewVSphereIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectNoAddress(ctx, g, c, "claim-a")
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(claim), claim)).To(Succeed())
+	g.Expect(claim.Finalizers).To(BeEmpty())
+}
+
+func TestVSphereIPPoolReconcilePoolInClusterNamespace(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	pool := vsphereIPPool()
+	pool.Namespace = "default"
+	claim := ipAddressClaim("claim-a", pool.Name)
+	// A pool with the same name in another namespace belongs to another cluster.
+	otherClusterClaim := ipAddressClaim("claim-b", pool.Name)
+	otherClusterClaim.Labels[clusterv1.ClusterNameLabel] = "other-cluster"
+	otherCluster := eksaClusterForIPPool("other-cluster", "other")
+
+	c := vsphereIPPoolClient(pool, claim, otherClusterClaim, eksaClusterForIPPool("my-cluster", pool.Namespace), otherCluster, capiClusterForIPPool(false))
+	r := controllers.NewVSphereIPPoolReconciler(c, c)
+	_, err := r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	expectAddressAllocated(ctx, g, c, "claim-a", "10.0.0.10")
+	expectNoAddress(ctx, g, c, "claim-b")
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status.Allocations).To(Equal([]anywherev1.VSphereIPPoolAllocation{
+		{Address: "10.0.0.10", Claim: "claim-a"},
+	}))
+	g.Expect(pool.Status.Free).To(Equal(2))
+
+	// Reconciling again keeps the allocation of the claim in eksa-system.
+	_, err = r.Reconcile(ctx, vsphereIPPoolRequest(pool))
+	g.Expect(err).NotTo(HaveOccurred())
+	expectAddressAllocated(ctx, g, c, "claim-a", "10.0.0.10")
+}
+
+func vsphereIPPoolClient(objs ...client.Object) client.Client {
+	runtimeObjs := make([]runtime.Object, 0, len(objs))
+	for _, o := range objs {
+		runtimeObjs = append(runtimeObjs, o)
+	}
+	return fake.NewClientBuilder().
+		WithRuntimeObjects(runtimeObjs...).
+		WithStatusSubresource(&anywherev1.VSphereIPPool{}, &ipamv1.IPAddressClaim{}).
+		Build()
+}
+
+func expectAddressAllocated(ctx context.Context, g Gomega, c client.Client, claimName, want string) {
+	address, err := getIPAddress(ctx, c, claimName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(address.Spec.Address).To(Equal(want))
+	g.Expect(address.Spec.ClaimRef.Name).To(Equal(claimName))
+
+	claim := &ipamv1.IPAddressClaim{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: claimName, Namespace: constants.EksaSystemNamespace}, claim)).To(Succeed())
+	g.Expect(claim.Status.AddressRef.Name).To(Equal(address.Name))
+	g.Expect(claim.Finalizers).To(ContainElement(vsphereIPPoolClaimFinalizer))
+}
+
+func expectNoAddress(ctx context.Context, g Gomega, c client.Client, claimName string) {
+	_, err := getIPAddress(ctx, c, claimName)
+	g.Expect(err).To(MatchError(apierrors.IsNotFound, "IsNotFound"))
+}
+
+func getIPAddress(ctx context.Context, c client.Client, claimName string) (*ipamv1.IPAddress, error) {
+	address := &ipamv1.IPAddress{}
+	err := c.Get(ctx, types.NamespacedName{Name: claimName, Namespace: constants.EksaSystemNamespace}, address)
+	return address, err
+}
+
+func vsphereIPPoolRequest(pool *anywherev1.VSphereIPPool) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace},
+	}
+}
+
+func vsphereIPPool() *anywherev1.VSphereIPPool {
+	return &anywherev1.VSphereIPPool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.VSphereIPPoolKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.VSphereIPPoolSpec{
+			Addresses: []string{"10.0.0.10-10.0.0.12"},
+			Prefix:    24,
+			Gateway:   "10.0.0.1",
+		},
+	}
+}
+
+func ipAddressClaim(name, pool string) *ipamv1.IPAddressClaim {
+	return &ipamv1.IPAddressClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "IPAddressClaim",
+			APIVersion: ipamv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+		},
+		Spec: ipamv1.IPAddressClaimSpec{
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: ptr.String(anywherev1.GroupVersion.Group),
+				Kind:     anywherev1.VSphereIPPoolKind,
+				Name:     pool,
+			},
+		},
+	}
+}
+
+func ipAddress(claim *ipamv1.IPAddressClaim, address string) *ipamv1.IPAddress {
+	return &ipamv1.IPAddress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "IPAddress",
+			APIVersion: ipamv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+		},
+		Spec: ipamv1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claim.Name},
+			PoolRef:  claim.Spec.PoolRef,
+			Address:  address,
+			Prefix:   24,
+			Gateway:  "10.0.0.1",
+		},
+	}
+}
+
+func capiClusterForIPPool(paused bool) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: clusterv1.ClusterSpec{
+			Paused: paused,
+		},
+	}
+}
+
+func eksaClusterForIPPool(name, namespace string) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
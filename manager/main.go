@@ -5,6 +5,7 @@ import (
 	"flag"
 	"os"
 
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	eksdv1alpha1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	"github.com/go-logr/logr"
@@ -25,6 +26,7 @@ import (
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -37,6 +39,7 @@ import (
 	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/features"
 	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -52,6 +55,7 @@ func init() {
 	utilruntime.Must(releasev1.AddToScheme(scheme))
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
 	utilruntime.Must(clusterctlv1.AddToScheme(scheme))
+	utilruntime.Must(ipamv1.AddToScheme(scheme))
 	utilruntime.Must(controlplanev1.AddToScheme(scheme))
 	utilruntime.Must(vspherev1.AddToScheme(scheme))
 	utilruntime.Must(cloudstackv1.AddToScheme(scheme))
@@ -65,6 +69,7 @@ func init() {
 	utilruntime.Must(tinkv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(rufiov1alpha1.AddToScheme(scheme))
 	utilruntime.Must(nutanixv1.AddToScheme(scheme))
+	utilruntime.Must(packagesv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -177,8 +182,10 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 	factory := controllers.NewFactory(ctrl.Log, mgr).
 		WithClusterReconciler(
 			providers,
+			controllers.WithEventRecorder(mgr.GetEventRecorderFor("eksa-cluster-controller")),
 		).
 		WithVSphereDatacenterReconciler().
+		WithVSphereIPPoolReconciler().
 		WithSnowMachineConfigReconciler().
 		WithNutanixDatacenterReconciler().
 		WithCloudStackDatacenterReconciler().
@@ -207,6 +214,12 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		failed = true
 	}
 
+	setupLog.Info("Setting up vsphereippool controller")
+	if err := (reconcilers.VSphereIPPoolReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", anywherev1.VSphereIPPoolKind)
+		failed = true
+	}
+
 	setupLog.Info("Setting up snowmachineconfig controller")
 	if err := (reconcilers.SnowMachineConfigReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", anywherev1.SnowMachineConfigKind)
@@ -294,6 +307,10 @@ func setupCoreWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.AWSIamConfigKind)
 		os.Exit(1)
 	}
+	if err := curatedpackages.NewPackageConfigValidator(mgr.GetAPIReader()).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, packagesv1.PackageKind)
+		os.Exit(1)
+	}
 }
 
 func setupVSphereWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
@@ -305,6 +322,10 @@ func setupVSphereWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
 		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereMachineConfigKind)
 		os.Exit(1)
 	}
+	if err := (&anywherev1.VSphereIPPool{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", WEBHOOK, anywherev1.VSphereIPPoolKind)
+		os.Exit(1)
+	}
 }
 
 func setupCloudstackWebhooks(setupLog logr.Logger, mgr ctrl.Manager) {
@@ -0,0 +1,128 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// CertificateRenewalKind stores the Kind for CertificateRenewal.
+	CertificateRenewalKind = "CertificateRenewal"
+
+	// CertificatesRenewed reports whether the certificates on a node have been renewed.
+	CertificatesRenewed ConditionType = "CertificatesRenewed"
+
+	// EtcdClientCertificatesTransferred reports whether the renewed external etcd client certificates
+	// have been copied to a control plane node.
+	EtcdClientCertificatesTransferred ConditionType = "EtcdClientCertificatesTransferred"
+
+	// CertificateRenewalNodeInProgressReason reports that the renewal step is running on a node.
+	CertificateRenewalNodeInProgressReason = "InProgress"
+
+	// CertificateRenewalNodeFailedReason reports that the renewal step failed on a node.
+	CertificateRenewalNodeFailedReason = "Failed"
+)
+
+// CertificateRenewalSpec defines the desired state of CertificateRenewal.
+type CertificateRenewalSpec struct {
+	// ClusterName is the name of the cluster whose certificates are renewed.
+	ClusterName string `json:"clusterName"`
+
+	// Component limits the renewal to either "etcd" or "control-plane".
+	// If empty, both are renewed.
+	// +optional
+	Component string `json:"component,omitempty"`
+
+	// Mode is the way the renewer accesses the control plane nodes, either "ssh" or "pod".
+	// +optional
+	Mode string `json:"mode,omitempty"`
+}
+
+// CertificateRenewalNodeStatus defines the renewal progress for a single node.
+type CertificateRenewalNodeStatus struct {
+	// Name identifies the node, as provided in the renewal configuration.
+	Name string `json:"name"`
+
+	// Role is either "etcd" or "control-plane".
+	Role string `json:"role"`
+
+	// Conditions defines the state of each renewal step on the node.
+	// +optional
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+// CertificateRenewalStatus defines the observed state of CertificateRenewal.
+type CertificateRenewalStatus struct {
+	// Nodes contains the renewal progress for each node.
+	// +optional
+	Nodes []CertificateRenewalNodeStatus `json:"nodes,omitempty"`
+
+	// Completed denotes that the renewal has finished for all nodes.
+	// +optional
+	Completed bool `json:"completed"`
+
+	// FailureMessage describes the error that stopped the renewal, if any.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=certificaterenewals,scope=Namespaced,singular=certificaterenewal
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
+//+kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="Node access mode"
+//+kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.completed",description="Denotes whether the renewal has finished or not"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of the certificate renewal"
+
+// CertificateRenewal is the Schema for the certificaterenewals API.
+// It records the progress of a certificate renewal run. The status is written by the renewer
+// together with the rest of the object, so it doesn't use a status subresource.
+type CertificateRenewal struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateRenewalSpec   `json:"spec,omitempty"`
+	Status CertificateRenewalStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CertificateRenewalList contains a list of CertificateRenewal.
+type CertificateRenewalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CertificateRenewal `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CertificateRenewal{}, &CertificateRenewalList{})
+}
+
+// Node returns the status for the named node, adding it if it doesn't exist yet.
+func (c *CertificateRenewal) Node(name, role string) *CertificateRenewalNodeStatus {
+	for i := range c.Status.Nodes {
+		if c.Status.Nodes[i].Name == name && c.Status.Nodes[i].Role == role {
+			return &c.Status.Nodes[i]
+		}
+	}
+
+	c.Status.Nodes = append(c.Status.Nodes, CertificateRenewalNodeStatus{Name: name, Role: role})
+	return &c.Status.Nodes[len(c.Status.Nodes)-1]
+}
+
+// SetCondition adds or replaces the condition with the same type, keeping the last
+// transition time when the status doesn't change.
+func (n *CertificateRenewalNodeStatus) SetCondition(condition *Condition) {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	for i := range n.Conditions {
+		if n.Conditions[i].Type != condition.Type {
+			continue
+		}
+		if n.Conditions[i].Status == condition.Status {
+			condition.LastTransitionTime = n.Conditions[i].LastTransitionTime
+		}
+		n.Conditions[i] = *condition
+		return
+	}
+
+	n.Conditions = append(n.Conditions, *condition)
+}
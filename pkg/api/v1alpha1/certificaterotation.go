@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const maintenanceWindowStartLayout = "15:04"
+
+// Equal checks if two CertificateRotation policies are equal.
+func (n *CertificateRotation) Equal(o *CertificateRotation) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.RenewBeforeDays == o.RenewBeforeDays && n.MaintenanceWindow.Equal(o.MaintenanceWindow)
+}
+
+// Equal checks if two MaintenanceWindows are equal.
+func (n *MaintenanceWindow) Equal(o *MaintenanceWindow) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Start == o.Start && n.Duration == o.Duration && SliceEqual(n.Days, o.Days)
+}
+
+// Contains returns true if t falls inside the maintenance window. A nil window is always open.
+func (n *MaintenanceWindow) Contains(t time.Time) bool {
+	if n == nil {
+		return true
+	}
+
+	start, err := time.Parse(maintenanceWindowStartLayout, n.Start)
+	if err != nil {
+		return false
+	}
+
+	t = t.UTC()
+	// The window could have opened the day before and still be open, so check both.
+	for _, day := range []time.Time{t.AddDate(0, 0, -1), t} {
+		opens := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		if !n.appliesTo(opens.Weekday()) {
+			continue
+		}
+		if !t.Before(opens) && t.Before(opens.Add(n.Duration.Duration)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n *MaintenanceWindow) appliesTo(weekday time.Weekday) bool {
+	if len(n.Days) == 0 {
+		return true
+	}
+	for _, d := range n.Days {
+		if strings.EqualFold(d, weekday.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateCertificateRotation(clusterConfig *Cluster) error {
+	policy := clusterConfig.Spec.CertificateRotation
+	if policy == nil {
+		return nil
+	}
+
+	if policy.RenewBeforeDays <= 0 {
+		return errors.New("certificateRotation.renewBeforeDays must be greater than 0")
+	}
+
+	if w := policy.MaintenanceWindow; w != nil {
+		if _, err := time.Parse(maintenanceWindowStartLayout, w.Start); err != nil {
+			return errors.Errorf("certificateRotation.maintenanceWindow.start %q is invalid, it must follow the HH:MM format", w.Start)
+		}
+		if w.Duration.Duration <= 0 {
+			return errors.New("certificateRotation.maintenanceWindow.duration must be greater than 0")
+		}
+		if w.Duration.Duration > 24*time.Hour {
+			return errors.New("certificateRotation.maintenanceWindow.duration cannot be longer than 24h")
+		}
+		for _, d := range w.Days {
+			if !isWeekday(d) {
+				return errors.Errorf("certificateRotation.maintenanceWindow.days contains invalid day %q", d)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isWeekday(day string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(day, d.String()) {
+			return true
+		}
+	}
+	return false
+}
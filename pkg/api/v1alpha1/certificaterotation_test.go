@@ -0,0 +1,178 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	saturdayNight := &MaintenanceWindow{
+		Days:     []string{"saturday"},
+		Start:    "23:00",
+		Duration: metav1.Duration{Duration: 3 * time.Hour},
+	}
+
+	tests := []struct {
+		name   string
+		window *MaintenanceWindow
+		time   time.Time
+		want   bool
+	}{
+		{
+			name:   "nil window",
+			window: nil,
+			time:   time.Date(2024, time.June, 3, 12, 0, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "inside window",
+			window: saturdayNight,
+			time:   time.Date(2024, time.June, 1, 23, 30, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "window spanning midnight",
+			window: saturdayNight,
+			time:   time.Date(2024, time.June, 2, 1, 0, 0, 0, time.UTC),
+			want:   true,
+		},
+		{
+			name:   "window closed",
+			window: saturdayNight,
+			time:   time.Date(2024, time.June, 2, 2, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "before window opens",
+			window: saturdayNight,
+			time:   time.Date(2024, time.June, 1, 22, 59, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "non UTC time",
+			window: saturdayNight,
+			time:   time.Date(2024, time.June, 2, 1, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			want:   true,
+		},
+		{
+			name: "every day",
+			window: &MaintenanceWindow{
+				Start:    "08:00",
+				Duration: metav1.Duration{Duration: time.Hour},
+			},
+			time: time.Date(2024, time.June, 4, 8, 15, 0, 0, time.UTC),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tt.window.Contains(tt.time)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateCertificateRotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *CertificateRotation
+		wantErr string
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+		},
+		{
+			name: "valid policy",
+			policy: &CertificateRotation{
+				RenewBeforeDays: 30,
+				MaintenanceWindow: &MaintenanceWindow{
+					Days:     []string{"Sunday", "wednesday"},
+					Start:    "01:30",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+				},
+			},
+		},
+		{
+			name:    "invalid renewBeforeDays",
+			policy:  &CertificateRotation{},
+			wantErr: "renewBeforeDays must be greater than 0",
+		},
+		{
+			name: "invalid start",
+			policy: &CertificateRotation{
+				RenewBeforeDays: 30,
+				MaintenanceWindow: &MaintenanceWindow{
+					Start:    "25:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr: "it must follow the HH:MM format",
+		},
+		{
+			name: "missing duration",
+			policy: &CertificateRotation{
+				RenewBeforeDays:   30,
+				MaintenanceWindow: &MaintenanceWindow{Start: "01:00"},
+			},
+			wantErr: "duration must be greater than 0",
+		},
+		{
+			name: "duration too long",
+			policy: &CertificateRotation{
+				RenewBeforeDays: 30,
+				MaintenanceWindow: &MaintenanceWindow{
+					Start:    "01:00",
+					Duration: metav1.Duration{Duration: 25 * time.Hour},
+				},
+			},
+			wantErr: "cannot be longer than 24h",
+		},
+		{
+			name: "invalid day",
+			policy: &CertificateRotation{
+				RenewBeforeDays: 30,
+				MaintenanceWindow: &MaintenanceWindow{
+					Days:     []string{"Funday"},
+					Start:    "01:00",
+					Duration: metav1.Duration{Duration: time.Hour},
+				},
+			},
+			wantErr: "invalid day \"Funday\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{CertificateRotation: tt.policy}}
+			err := validateCertificateRotation(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestCertificateRotationEqual(t *testing.T) {
+	g := NewWithT(t)
+	policy := &CertificateRotation{
+		RenewBeforeDays: 30,
+		MaintenanceWindow: &MaintenanceWindow{
+			Days:     []string{"Sunday"},
+			Start:    "01:30",
+			Duration: metav1.Duration{Duration: time.Hour},
+		},
+	}
+
+	other := policy.DeepCopy()
+	g.Expect(policy.Equal(other)).To(BeTrue())
+
+	other.MaintenanceWindow.Days = []string{"Monday"}
+	g.Expect(policy.Equal(other)).To(BeFalse())
+	g.Expect(policy.Equal(nil)).To(BeFalse())
+}
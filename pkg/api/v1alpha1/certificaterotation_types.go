@@ -0,0 +1,26 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// CertificateRotation defines the policy used by the cluster controller to automatically
+// renew the control plane certificates before they expire.
+type CertificateRotation struct {
+	// RenewBeforeDays defines how many days before expiration the certificates are renewed.
+	RenewBeforeDays int `json:"renewBeforeDays"`
+	// MaintenanceWindow restricts when an automatic renewal can be started.
+	// If not set, a renewal can start at any time.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring time window in UTC.
+type MaintenanceWindow struct {
+	// Days defines the days of the week the window applies to, e.g. "Saturday".
+	// If empty, the window applies to every day.
+	// +optional
+	Days []string `json:"days,omitempty"`
+	// Start defines the time of the day the window opens, in the "HH:MM" format.
+	Start string `json:"start"`
+	// Duration defines how long the window stays open.
+	Duration metav1.Duration `json:"duration"`
+}
@@ -196,6 +196,8 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateControlPlaneAPIServerOIDCExtraArgs,
 	validateControlPlaneKubeletConfiguration,
 	validateWorkerNodeKubeletConfiguration,
+	validateCertificateRotation,
+	validateEtcdSnapshot,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -899,19 +901,41 @@ func validateProxyData(proxy string) error {
 }
 
 func validateMirrorConfig(clusterConfig *Cluster) error {
-	if clusterConfig.Spec.RegistryMirrorConfiguration == nil {
+	mirrorConfig := clusterConfig.Spec.RegistryMirrorConfiguration
+	if mirrorConfig == nil {
 		return nil
 	}
-	if clusterConfig.Spec.RegistryMirrorConfiguration.Endpoint == "" {
+	if mirrorConfig.Endpoint == "" {
 		return errors.New("no value set for RegistryMirrorConfiguration.Endpoint")
 	}
+	if err := validateMirrorEndpoint(mirrorConfig.Port, mirrorConfig.OCINamespaces); err != nil {
+		return err
+	}
+
+	endpoints := map[string]struct{}{net.JoinHostPort(mirrorConfig.Endpoint, mirrorConfig.Port): {}}
+	for i, mirror := range mirrorConfig.Mirrors {
+		if mirror.Endpoint == "" {
+			return fmt.Errorf("no value set for RegistryMirrorConfiguration.Mirrors[%d].Endpoint", i)
+		}
+		if err := validateMirrorEndpoint(mirror.Port, mirror.OCINamespaces); err != nil {
+			return fmt.Errorf("registry mirror %s: %v", mirror.Endpoint, err)
+		}
+		endpoint := net.JoinHostPort(mirror.Endpoint, mirror.Port)
+		if _, ok := endpoints[endpoint]; ok {
+			return fmt.Errorf("registry mirror %s is configured more than once", endpoint)
+		}
+		endpoints[endpoint] = struct{}{}
+	}
+
+	return nil
+}
 
-	if !networkutils.IsPortValid(clusterConfig.Spec.RegistryMirrorConfiguration.Port) {
-		return fmt.Errorf("registry mirror port %s is invalid, please provide a valid port", clusterConfig.Spec.RegistryMirrorConfiguration.Port)
+func validateMirrorEndpoint(port string, ociNamespaces []OCINamespace) error {
+	if !networkutils.IsPortValid(port) {
+		return fmt.Errorf("registry mirror port %s is invalid, please provide a valid port", port)
 	}
 
 	mirrorCount := 0
-	ociNamespaces := clusterConfig.Spec.RegistryMirrorConfiguration.OCINamespaces
 	for _, ociNamespace := range ociNamespaces {
 		if ociNamespace.Registry == "" {
 			return errors.New("registry can't be set to empty in OCINamespaces")
@@ -3273,6 +3273,83 @@ func TestValidateMirrorConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "valid additional mirrors",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []RegistryMirrorEndpoint{
+							{
+								Endpoint: "1.2.3.5",
+								Port:     "443",
+								OCINamespaces: []OCINamespace{
+									{
+										Registry:  "public.ecr.aws",
+										Namespace: "eks-anywhere",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "additional mirror endpoint not specified",
+			wantErr: "no value set for RegistryMirrorConfiguration.Mirrors[0].Endpoint",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []RegistryMirrorEndpoint{
+							{
+								Port: "443",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "additional mirror invalid port",
+			wantErr: "registry mirror 1.2.3.5: registry mirror port 0 is invalid",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []RegistryMirrorEndpoint{
+							{
+								Endpoint: "1.2.3.5",
+								Port:     "0",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "duplicate mirror",
+			wantErr: "registry mirror 1.2.3.4:443 is configured more than once",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					RegistryMirrorConfiguration: &RegistryMirrorConfiguration{
+						Endpoint: "1.2.3.4",
+						Port:     "443",
+						Mirrors: []RegistryMirrorEndpoint{
+							{
+								Endpoint: "1.2.3.4",
+								Port:     "443",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:    "insecureSkipVerify on nutanix provider",
 			wantErr: "",
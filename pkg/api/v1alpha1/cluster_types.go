@@ -92,6 +92,12 @@ type ClusterSpec struct {
 	MachineHealthCheck *MachineHealthCheck `json:"machineHealthCheck,omitempty"`
 	EtcdEncryption     *[]EtcdEncryption   `json:"etcdEncryption,omitempty"`
 	LicenseToken       string              `json:"licenseToken,omitempty"`
+	// CertificateRotation enables the automatic renewal of the control plane certificates.
+	// +optional
+	CertificateRotation *CertificateRotation `json:"certificateRotation,omitempty"`
+	// EtcdSnapshot enables scheduled etcd snapshots.
+	// +optional
+	EtcdSnapshot *EtcdSnapshotConfiguration `json:"etcdSnapshot,omitempty"`
 }
 
 // EksaVersion is the semver identifying the release of eks-a used to populate the cluster components.
@@ -193,6 +199,12 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if n.Spec.LicenseToken != o.Spec.LicenseToken {
 		return false
 	}
+	if !n.Spec.CertificateRotation.Equal(o.Spec.CertificateRotation) {
+		return false
+	}
+	if !n.Spec.EtcdSnapshot.Equal(o.Spec.EtcdSnapshot) {
+		return false
+	}
 
 	return true
 }
@@ -246,6 +258,41 @@ type RegistryMirrorConfiguration struct {
 	// InsecureSkipVerify skips the registry certificate verification.
 	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// Mirrors defines additional registry mirrors, in order of preference, used when the
+	// registry mirror at Endpoint is unreachable.
+	// +optional
+	Mirrors []RegistryMirrorEndpoint `json:"mirrors,omitempty"`
+}
+
+// RegistryMirrorEndpoint defines the settings of an additional registry mirror.
+type RegistryMirrorEndpoint struct {
+	// Endpoint defines the registry mirror endpoint to use for pulling images
+	Endpoint string `json:"endpoint"`
+
+	// Port defines the port exposed for registry mirror endpoint
+	Port string `json:"port,omitempty"`
+
+	// OCINamespaces defines the mapping from an upstream registry to a local namespace where upstream
+	// artifacts are placed into
+	OCINamespaces []OCINamespace `json:"ociNamespaces,omitempty"`
+
+	// CACertContent defines the contents registry mirror CA certificate
+	CACertContent string `json:"caCertContent,omitempty"`
+
+	// Authenticate defines if registry requires authentication
+	Authenticate bool `json:"authenticate,omitempty"`
+
+	// InsecureSkipVerify skips the registry certificate verification.
+	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// Equal checks if two RegistryMirrorEndpoints are equal.
+func (n RegistryMirrorEndpoint) Equal(o RegistryMirrorEndpoint) bool {
+	return n.Endpoint == o.Endpoint && n.Port == o.Port && n.CACertContent == o.CACertContent &&
+		n.InsecureSkipVerify == o.InsecureSkipVerify && n.Authenticate == o.Authenticate &&
+		OCINamespacesSliceEqual(n.OCINamespaces, o.OCINamespaces)
 }
 
 // OCINamespace represents an entity in a local reigstry to group related images.
@@ -265,7 +312,20 @@ func (n *RegistryMirrorConfiguration) Equal(o *RegistryMirrorConfiguration) bool
 	}
 	return n.Endpoint == o.Endpoint && n.Port == o.Port && n.CACertContent == o.CACertContent &&
 		n.InsecureSkipVerify == o.InsecureSkipVerify && n.Authenticate == o.Authenticate &&
-		OCINamespacesSliceEqual(n.OCINamespaces, o.OCINamespaces)
+		OCINamespacesSliceEqual(n.OCINamespaces, o.OCINamespaces) && registryMirrorEndpointsEqual(n.Mirrors, o.Mirrors)
+}
+
+// registryMirrorEndpointsEqual checks the equality of two ordered lists of mirrors.
+func registryMirrorEndpointsEqual(a, b []RegistryMirrorEndpoint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
 }
 
 // OCINamespacesSliceEqual is used to check equality of the OCINamespaces fields of two RegistryMirrorConfiguration.
@@ -1042,6 +1102,10 @@ type ClusterStatus struct {
 
 	// ObservedGeneration is the latest generation observed by the controller.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// EtcdSnapshot reports the state of the scheduled etcd snapshots.
+	// +optional
+	EtcdSnapshot *EtcdSnapshotStatus `json:"etcdSnapshot,omitempty"`
 }
 
 type EksdReleaseRef struct {
@@ -1596,6 +1660,8 @@ func (c *Cluster) ConvertConfigToConfigGenerateStruct() *ClusterGenerate {
 			MachineHealthCheck:            c.Spec.MachineHealthCheck,
 			EtcdEncryption:                c.Spec.EtcdEncryption,
 			LicenseToken:                  c.Spec.LicenseToken,
+			CertificateRotation:           c.Spec.CertificateRotation,
+			EtcdSnapshot:                  c.Spec.EtcdSnapshot,
 		},
 	}
 
@@ -1948,6 +1948,38 @@ func TestRegistryMirrorConfigurationEqual(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			testName: "both exist, mirrors same",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint: "1.2.3.4",
+				Mirrors:  []v1alpha1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5", Port: "443"}},
+			},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint: "1.2.3.4",
+				Mirrors:  []v1alpha1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5", Port: "443"}},
+			},
+			want: true,
+		},
+		{
+			testName: "both exist, mirrors order diff",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Mirrors: []v1alpha1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5"}, {Endpoint: "1.2.3.6"}},
+			},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Mirrors: []v1alpha1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.6"}, {Endpoint: "1.2.3.5"}},
+			},
+			want: false,
+		},
+		{
+			testName: "both exist, mirror ca diff",
+			cluster1Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Mirrors: []v1alpha1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5", CACertContent: "ca1"}},
+			},
+			cluster2Regi: &v1alpha1.RegistryMirrorConfiguration{
+				Mirrors: []v1alpha1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5", CACertContent: "ca2"}},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.testName, func(t *testing.T) {
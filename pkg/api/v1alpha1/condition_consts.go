@@ -78,3 +78,32 @@ const (
 	// create a cluster.
 	SkipUpgradesForDefaultCNIConfiguredReason = "SkipUpgradesForDefaultCNIConfigured"
 )
+
+const (
+	// CertificatesReadyCondition reports the status of the control plane and external etcd certificates
+	// when automatic certificate rotation is enabled.
+	CertificatesReadyCondition ConditionType = "CertificatesReady"
+
+	// CertificatesExpiringSoonReason reports that some certificates expire within the configured threshold.
+	CertificatesExpiringSoonReason = "CertificatesExpiringSoon"
+
+	// CertificateRenewalInProgressReason reports that a certificate renewal has been triggered and is still running.
+	CertificateRenewalInProgressReason = "CertificateRenewalInProgress"
+
+	// CertificateRenewalFailedReason reports that a certificate renewal could not be completed.
+	CertificateRenewalFailedReason = "CertificateRenewalFailed"
+)
+
+const (
+	// EtcdSnapshotsReadyCondition reports the status of the scheduled etcd snapshots.
+	EtcdSnapshotsReadyCondition ConditionType = "EtcdSnapshotsReady"
+
+	// EtcdSnapshotPendingReason reports that the snapshot job is deployed but no snapshot has finished yet.
+	EtcdSnapshotPendingReason = "EtcdSnapshotPending"
+
+	// EtcdSnapshotFailedReason reports that the last snapshot job failed.
+	EtcdSnapshotFailedReason = "EtcdSnapshotFailed"
+
+	// EtcdSnapshotDeploymentFailedReason reports that the snapshot job could not be deployed to the cluster.
+	EtcdSnapshotDeploymentFailedReason = "EtcdSnapshotDeploymentFailed"
+)
@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultEtcdSnapshotRetention is the number of etcd snapshots kept when no retention is set.
+const DefaultEtcdSnapshotRetention = 7
+
+var (
+	cronFieldRegex  = regexp.MustCompile(`^[0-9A-Za-z*/,?-]+$`)
+	cronDescriptors = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+)
+
+// Equal checks if two EtcdSnapshotConfigurations are equal.
+func (n *EtcdSnapshotConfiguration) Equal(o *EtcdSnapshotConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return n.Schedule == o.Schedule && n.Retention == o.Retention && n.Destination.Equal(&o.Destination)
+}
+
+// Equal checks if two EtcdSnapshotDestinations are equal.
+func (n *EtcdSnapshotDestination) Equal(o *EtcdSnapshotDestination) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return equalPtr(n.Local, o.Local) && equalPtr(n.PersistentVolume, o.PersistentVolume) && equalPtr(n.S3, o.S3)
+}
+
+func equalPtr[T comparable](n, o *T) bool {
+	if n == nil || o == nil {
+		return n == o
+	}
+	return *n == *o
+}
+
+// RetentionOrDefault returns the configured retention or DefaultEtcdSnapshotRetention if not set.
+func (n *EtcdSnapshotConfiguration) RetentionOrDefault() int {
+	if n.Retention == 0 {
+		return DefaultEtcdSnapshotRetention
+	}
+	return n.Retention
+}
+
+func validateEtcdSnapshot(clusterConfig *Cluster) error {
+	config := clusterConfig.Spec.EtcdSnapshot
+	if config == nil {
+		return nil
+	}
+
+	if err := validateCronSchedule(config.Schedule); err != nil {
+		return errors.Wrap(err, "etcdSnapshot.schedule")
+	}
+
+	if config.Retention < 0 {
+		return errors.New("etcdSnapshot.retention cannot be negative")
+	}
+
+	destinations := 0
+	if d := config.Destination.Local; d != nil {
+		destinations++
+		if !filepath.IsAbs(d.Path) || filepath.Clean(d.Path) == "/" {
+			return errors.Errorf("etcdSnapshot.destination.local.path %q must be an absolute path other than /", d.Path)
+		}
+	}
+	if d := config.Destination.PersistentVolume; d != nil {
+		destinations++
+		if d.ClaimName == "" {
+			return errors.New("etcdSnapshot.destination.persistentVolume.claimName is required")
+		}
+	}
+	if d := config.Destination.S3; d != nil {
+		destinations++
+		if d.Bucket == "" {
+			return errors.New("etcdSnapshot.destination.s3.bucket is required")
+		}
+		if d.CredentialsSecretName == "" {
+			return errors.New("etcdSnapshot.destination.s3.credentialsSecretName is required")
+		}
+		if d.Image == "" {
+			return errors.New("etcdSnapshot.destination.s3.image is required")
+		}
+		if d.Endpoint != "" && !strings.HasPrefix(d.Endpoint, "http://") && !strings.HasPrefix(d.Endpoint, "https://") {
+			return errors.Errorf("etcdSnapshot.destination.s3.endpoint %q must start with http:// or https://", d.Endpoint)
+		}
+	}
+
+	if destinations != 1 {
+		return errors.New("etcdSnapshot.destination must set exactly one of local, persistentVolume or s3")
+	}
+
+	return nil
+}
+
+// validateCronSchedule checks the format of a schedule. The fields are fully validated by the
+// API server when the CronJob is created.
+func validateCronSchedule(schedule string) error {
+	if strings.HasPrefix(schedule, "@") {
+		for _, d := range cronDescriptors {
+			if schedule == d {
+				return nil
+			}
+		}
+		return errors.Errorf("%q is not a valid descriptor", schedule)
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return errors.Errorf("%q must have 5 fields", schedule)
+	}
+	for _, f := range fields {
+		if !cronFieldRegex.MatchString(f) {
+			return errors.Errorf("%q contains invalid field %q", schedule, f)
+		}
+	}
+
+	return nil
+}
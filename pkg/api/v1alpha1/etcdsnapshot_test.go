@@ -0,0 +1,207 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateEtcdSnapshot(t *testing.T) {
+	s3 := func() *S3EtcdSnapshotDestination {
+		return &S3EtcdSnapshotDestination{
+			Endpoint:              "https://minio.local:9000",
+			Bucket:                "snapshots",
+			CredentialsSecretName: "minio-credentials",
+			Image:                 "amazon/aws-cli:2.15.0",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		config  *EtcdSnapshotConfiguration
+		wantErr string
+	}{
+		{
+			name:   "no config",
+			config: nil,
+		},
+		{
+			name: "valid local",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "0 */6 * * *",
+				Retention:   3,
+				Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "/var/lib/etcd-snapshots"}},
+			},
+		},
+		{
+			name: "valid persistent volume",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "@daily",
+				Destination: EtcdSnapshotDestination{PersistentVolume: &PersistentVolumeEtcdSnapshotDestination{ClaimName: "etcd-snapshots"}},
+			},
+		},
+		{
+			name: "valid s3",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "30 2 * * MON-FRI",
+				Destination: EtcdSnapshotDestination{S3: s3()},
+			},
+		},
+		{
+			name: "invalid schedule",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "every day",
+				Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "/snapshots"}},
+			},
+			wantErr: "etcdSnapshot.schedule: \"every day\" must have 5 fields",
+		},
+		{
+			name: "invalid schedule field",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "0 0 * * $",
+				Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "/snapshots"}},
+			},
+			wantErr: "contains invalid field \"$\"",
+		},
+		{
+			name: "invalid descriptor",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "@sometimes",
+				Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "/snapshots"}},
+			},
+			wantErr: "is not a valid descriptor",
+		},
+		{
+			name: "negative retention",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "@daily",
+				Retention:   -1,
+				Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "/snapshots"}},
+			},
+			wantErr: "retention cannot be negative",
+		},
+		{
+			name: "no destination",
+			config: &EtcdSnapshotConfiguration{
+				Schedule: "@daily",
+			},
+			wantErr: "must set exactly one of local, persistentVolume or s3",
+		},
+		{
+			name: "multiple destinations",
+			config: &EtcdSnapshotConfiguration{
+				Schedule: "@daily",
+				Destination: EtcdSnapshotDestination{
+					Local: &LocalEtcdSnapshotDestination{Path: "/snapshots"},
+					S3:    s3(),
+				},
+			},
+			wantErr: "must set exactly one of local, persistentVolume or s3",
+		},
+		{
+			name: "relative local path",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "@daily",
+				Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "snapshots"}},
+			},
+			wantErr: "must be an absolute path other than /",
+		},
+		{
+			name: "root local path",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "@daily",
+				Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "/"}},
+			},
+			wantErr: "must be an absolute path other than /",
+		},
+		{
+			name: "missing claim name",
+			config: &EtcdSnapshotConfiguration{
+				Schedule:    "@daily",
+				Destination: EtcdSnapshotDestination{PersistentVolume: &PersistentVolumeEtcdSnapshotDestination{}},
+			},
+			wantErr: "claimName is required",
+		},
+		{
+			name: "missing bucket",
+			config: &EtcdSnapshotConfiguration{
+				Schedule: "@daily",
+				Destination: EtcdSnapshotDestination{S3: func() *S3EtcdSnapshotDestination {
+					d := s3()
+					d.Bucket = ""
+					return d
+				}()},
+			},
+			wantErr: "s3.bucket is required",
+		},
+		{
+			name: "missing credentials",
+			config: &EtcdSnapshotConfiguration{
+				Schedule: "@daily",
+				Destination: EtcdSnapshotDestination{S3: func() *S3EtcdSnapshotDestination {
+					d := s3()
+					d.CredentialsSecretName = ""
+					return d
+				}()},
+			},
+			wantErr: "s3.credentialsSecretName is required",
+		},
+		{
+			name: "missing image",
+			config: &EtcdSnapshotConfiguration{
+				Schedule: "@daily",
+				Destination: EtcdSnapshotDestination{S3: func() *S3EtcdSnapshotDestination {
+					d := s3()
+					d.Image = ""
+					return d
+				}()},
+			},
+			wantErr: "s3.image is required",
+		},
+		{
+			name: "invalid endpoint",
+			config: &EtcdSnapshotConfiguration{
+				Schedule: "@daily",
+				Destination: EtcdSnapshotDestination{S3: func() *S3EtcdSnapshotDestination {
+					d := s3()
+					d.Endpoint = "minio.local:9000"
+					return d
+				}()},
+			},
+			wantErr: "must start with http:// or https://",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{EtcdSnapshot: tt.config}}
+			err := validateEtcdSnapshot(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestEtcdSnapshotConfigurationEqual(t *testing.T) {
+	g := NewWithT(t)
+	config := &EtcdSnapshotConfiguration{
+		Schedule:    "@daily",
+		Destination: EtcdSnapshotDestination{Local: &LocalEtcdSnapshotDestination{Path: "/snapshots"}},
+	}
+
+	other := config.DeepCopy()
+	g.Expect(config.Equal(other)).To(BeTrue())
+
+	other.Destination.Local.Path = "/other"
+	g.Expect(config.Equal(other)).To(BeFalse())
+	g.Expect(config.Equal(nil)).To(BeFalse())
+}
+
+func TestEtcdSnapshotConfigurationRetentionOrDefault(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect((&EtcdSnapshotConfiguration{}).RetentionOrDefault()).To(Equal(DefaultEtcdSnapshotRetention))
+	g.Expect((&EtcdSnapshotConfiguration{Retention: 2}).RetentionOrDefault()).To(Equal(2))
+}
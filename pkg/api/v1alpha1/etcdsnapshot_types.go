@@ -0,0 +1,75 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// EtcdSnapshotConfiguration defines the scheduled etcd snapshots taken by the cluster controller.
+// It works for both stacked and external etcd.
+type EtcdSnapshotConfiguration struct {
+	// Schedule defines when snapshots are taken, in the Cron format, e.g. "0 */6 * * *".
+	Schedule string `json:"schedule"`
+	// Retention defines how many snapshots are kept in the destination. The oldest ones are
+	// deleted after each snapshot. Defaults to 7.
+	// +optional
+	Retention int `json:"retention,omitempty"`
+	// Destination defines where snapshots are stored. Exactly one destination must be set.
+	Destination EtcdSnapshotDestination `json:"destination"`
+}
+
+// EtcdSnapshotDestination defines where etcd snapshots are stored.
+type EtcdSnapshotDestination struct {
+	// Local stores snapshots in a directory of the control plane node that takes them.
+	// Snapshots are lost when the node is replaced, like on every control plane rollout.
+	// +optional
+	Local *LocalEtcdSnapshotDestination `json:"local,omitempty"`
+	// PersistentVolume stores snapshots in the volume bound to an existing PersistentVolumeClaim.
+	// +optional
+	PersistentVolume *PersistentVolumeEtcdSnapshotDestination `json:"persistentVolume,omitempty"`
+	// S3 uploads snapshots to an S3-compatible bucket, e.g. AWS S3 or MinIO.
+	// +optional
+	S3 *S3EtcdSnapshotDestination `json:"s3,omitempty"`
+}
+
+// LocalEtcdSnapshotDestination defines a directory in the control plane nodes.
+type LocalEtcdSnapshotDestination struct {
+	// Path is the absolute path of the directory in the node.
+	Path string `json:"path"`
+}
+
+// PersistentVolumeEtcdSnapshotDestination defines a PersistentVolumeClaim in the cluster.
+type PersistentVolumeEtcdSnapshotDestination struct {
+	// ClaimName is the name of a PersistentVolumeClaim in the eksa-system namespace of the cluster.
+	ClaimName string `json:"claimName"`
+}
+
+// S3EtcdSnapshotDestination defines an S3-compatible bucket.
+type S3EtcdSnapshotDestination struct {
+	// Endpoint is the URL of the S3-compatible API. If not set, AWS S3 is used.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+	// Prefix is prepended to the snapshot object names.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Region is the region of the bucket.
+	// +optional
+	Region string `json:"region,omitempty"`
+	// CredentialsSecretName is the name of a Secret in the eksa-system namespace of the management
+	// cluster with the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+	// InsecureSkipVerify disables the TLS verification of the endpoint.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// Image is the container image used to upload snapshots. It must provide sh and the aws CLI.
+	Image string `json:"image"`
+}
+
+// EtcdSnapshotStatus reports the state of the scheduled etcd snapshots.
+type EtcdSnapshotStatus struct {
+	// LastScheduleTime is the last time a snapshot was started.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessTime is the last time a snapshot was stored successfully.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+}
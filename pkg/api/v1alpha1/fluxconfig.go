@@ -16,11 +16,17 @@ const (
 )
 
 func validateFluxConfig(config *FluxConfig) error {
-	if config.Spec.Git != nil && config.Spec.Github != nil {
+	providers := 0
+	for _, set := range []bool{config.Spec.Git != nil, config.Spec.Github != nil, config.Spec.Gitlab != nil, config.Spec.Gitea != nil} {
+		if set {
+			providers++
+		}
+	}
+	if providers > 1 {
 		return errors.New("must specify only one provider")
 	}
-	if config.Spec.Git == nil && config.Spec.Github == nil {
-		return errors.New("must specify a provider. Valid options are git and github")
+	if providers == 0 {
+		return errors.New("must specify a provider. Valid options are git, github, gitlab and gitea")
 	}
 	if config.Spec.Github != nil {
 		err := validateGithubProviderConfig(*config.Spec.Github)
@@ -34,6 +40,16 @@ func validateFluxConfig(config *FluxConfig) error {
 			return err
 		}
 	}
+	if config.Spec.Gitlab != nil {
+		if err := validateGitlabProviderConfig(*config.Spec.Gitlab); err != nil {
+			return err
+		}
+	}
+	if config.Spec.Gitea != nil {
+		if err := validateGiteaProviderConfig(*config.Spec.Gitea); err != nil {
+			return err
+		}
+	}
 
 	if len(config.Spec.Branch) > 0 {
 		err := validateGitBranchName(config.Spec.Branch)
@@ -42,6 +58,27 @@ func validateFluxConfig(config *FluxConfig) error {
 		}
 	}
 
+	if config.Spec.PullRequest != nil {
+		if err := validatePullRequestConfig(config.Spec); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validatePullRequestConfig(spec FluxConfigSpec) error {
+	if spec.Github == nil {
+		return errors.New("pullRequest is only supported for the github provider")
+	}
+
+	if len(spec.PullRequest.BranchPrefix) > 0 {
+		// The prefix is followed by the cluster name, so check it as the start of a branch name.
+		if err := validateGitBranchName(spec.PullRequest.BranchPrefix + "x"); err != nil {
+			return fmt.Errorf("pullRequest branchPrefix %s is not a valid git branch name prefix", spec.PullRequest.BranchPrefix)
+		}
+	}
+
 	return nil
 }
 
@@ -74,6 +111,57 @@ func validateGithubProviderConfig(config GithubProviderConfig) error {
 	return nil
 }
 
+func validateGitlabProviderConfig(config GitlabProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in gitlabProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	if len(config.BaseURL) > 0 {
+		if err := validateProviderBaseURL(config.BaseURL); err != nil {
+			return fmt.Errorf("invalid 'baseURL' in gitlabProviderConfig: %v", err)
+		}
+	}
+	return nil
+}
+
+func validateGiteaProviderConfig(config GiteaProviderConfig) error {
+	if len(config.Owner) <= 0 {
+		return errors.New("'owner' is not set or empty in giteaProviderConfig; owner is a required field")
+	}
+	if len(config.Repository) <= 0 {
+		return errors.New("'repository' is not set or empty in giteaProviderConfig; repository is a required field")
+	}
+	if err := validateGitRepoName(config.Repository); err != nil {
+		return err
+	}
+	if len(config.BaseURL) <= 0 {
+		return errors.New("'baseURL' is not set or empty in giteaProviderConfig; baseURL is a required field")
+	}
+	if err := validateProviderBaseURL(config.BaseURL); err != nil {
+		return fmt.Errorf("invalid 'baseURL' in giteaProviderConfig: %v", err)
+	}
+	return nil
+}
+
+func validateProviderBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("unable to parse url: %v", err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("url scheme must be https or http, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url %s has no host", baseURL)
+	}
+	return nil
+}
+
 func validateRepositoryUrl(repositoryUrl string) error {
 	url, err := url.Parse(repositoryUrl)
 	if err != nil {
@@ -105,4 +193,8 @@ func setFluxConfigDefaults(flux *FluxConfig) {
 	if len(c.Branch) == 0 {
 		c.Branch = FluxDefaultBranch
 	}
+
+	if c.PullRequest != nil && len(c.PullRequest.BranchPrefix) == 0 {
+		c.PullRequest.BranchPrefix = FluxDefaultPullRequestBranchPrefix
+	}
 }
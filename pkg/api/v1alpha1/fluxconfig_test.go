@@ -188,6 +188,226 @@ func TestValidateFluxConfig(t *testing.T) {
 			gitProvider: true,
 			error:       nil,
 		},
+		{
+			testName: "valid fluxconfig gitlab",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "platform/clusters",
+						Repository: "flux-fleet",
+						BaseURL:    "https://gitlab.example.com",
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "gitlab empty owner",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'owner' is not set or empty in gitlabProviderConfig; owner is a required field"),
+		},
+		{
+			testName: "gitlab invalid base url",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitlab: &GitlabProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						BaseURL:    "ssh://gitlab.example.com",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("invalid 'baseURL' in gitlabProviderConfig: url scheme must be https or http, got \"ssh\""),
+		},
+		{
+			testName: "valid fluxconfig gitea",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitea: &GiteaProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						Personal:   true,
+						BaseURL:    "https://gitea.example.com",
+					},
+				},
+			},
+			wantErr: false,
+			error:   nil,
+		},
+		{
+			testName: "gitea empty base url",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitea: &GiteaProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("'baseURL' is not set or empty in giteaProviderConfig; baseURL is a required field"),
+		},
+		{
+			testName: "multiple providers",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					Gitea: &GiteaProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						BaseURL:    "https://gitea.example.com",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("must specify only one provider"),
+		},
+		{
+			testName: "valid pull request github",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					PullRequest: &PullRequestConfig{
+						BranchPrefix: "eksa/",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			testName: "pull request not github",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Gitea: &GiteaProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+						BaseURL:    "https://gitea.example.com",
+					},
+					PullRequest: &PullRequestConfig{},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("pullRequest is only supported for the github provider"),
+		},
+		{
+			testName: "pull request invalid branch prefix",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{
+					Github: &GithubProviderConfig{
+						Owner:      "janedoe",
+						Repository: "flux-fleet",
+					},
+					PullRequest: &PullRequestConfig{
+						BranchPrefix: "eksa//changes/",
+					},
+				},
+			},
+			wantErr: true,
+			error:   errors.New("pullRequest branchPrefix eksa//changes/ is not a valid git branch name prefix"),
+		},
+		{
+			testName: "no provider",
+			fluxConfig: &FluxConfig{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       FluxConfigKind,
+					APIVersion: SchemeBuilder.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-flux",
+					Namespace: "default",
+				},
+				Spec: FluxConfigSpec{},
+			},
+			wantErr: true,
+			error:   errors.New("must specify a provider. Valid options are git, github, gitlab and gitea"),
+		},
 	}
 
 	for _, tt := range tests {
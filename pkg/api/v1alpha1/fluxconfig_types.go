@@ -35,6 +35,22 @@ type FluxConfigSpec struct {
 
 	// Used to specify Git provider that will be used to host the git files
 	Git *GitProviderConfig `json:"git,omitempty"`
+
+	// Used to specify GitLab provider to host the Git repo and host the git files
+	Gitlab *GitlabProviderConfig `json:"gitlab,omitempty"`
+
+	// Used to specify Gitea provider to host the Git repo and host the git files
+	Gitea *GiteaProviderConfig `json:"gitea,omitempty"`
+
+	// PullRequest, when set, makes the CLI propose cluster config changes through a pull request
+	// against Branch instead of pushing them to Branch directly. Only supported for the github provider.
+	PullRequest *PullRequestConfig `json:"pullRequest,omitempty"`
+}
+
+// PullRequestConfig defines how the CLI opens pull requests with cluster config changes.
+type PullRequestConfig struct {
+	// BranchPrefix is prepended to the name of the branches created for pull requests. Defaults to eksa/.
+	BranchPrefix string `json:"branchPrefix,omitempty"`
 }
 
 type GithubProviderConfig struct {
@@ -48,6 +64,42 @@ type GithubProviderConfig struct {
 	Personal bool `json:"personal,omitempty"`
 }
 
+// GitlabProviderConfig defines a repository hosted in GitLab.com or a self-managed GitLab instance.
+type GitlabProviderConfig struct {
+	// Owner is the user or group path of the repository. Subgroups are separated by '/'.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// if true, the owner is assumed to be a GitLab user; otherwise a group.
+	Personal bool `json:"personal,omitempty"`
+
+	// BaseURL of the GitLab instance. Defaults to https://gitlab.com.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// CACertContent is the PEM encoded CA bundle used to verify the GitLab instance certificate.
+	CACertContent string `json:"caCertContent,omitempty"`
+}
+
+// GiteaProviderConfig defines a repository hosted in a Gitea instance.
+type GiteaProviderConfig struct {
+	// Owner is the user or organization name of the repository.
+	Owner string `json:"owner"`
+
+	// Repository name.
+	Repository string `json:"repository"`
+
+	// if true, the owner is assumed to be a Gitea user; otherwise an org.
+	Personal bool `json:"personal,omitempty"`
+
+	// BaseURL of the Gitea instance.
+	BaseURL string `json:"baseURL"`
+
+	// CACertContent is the PEM encoded CA bundle used to verify the Gitea instance certificate.
+	CACertContent string `json:"caCertContent,omitempty"`
+}
+
 type GitProviderConfig struct {
 	// Repository URL for the repository to be used with flux. Can be either an SSH or HTTPS url.
 	RepositoryUrl string `json:"repositoryUrl"`
@@ -57,7 +109,65 @@ type GitProviderConfig struct {
 }
 
 // FluxConfigStatus defines the observed state of FluxConfig.
-type FluxConfigStatus struct{}
+type FluxConfigStatus struct {
+	// Drift is the result of the last comparison between the cluster configuration committed to
+	// the Git repository and the live objects in the cluster.
+	// +optional
+	Drift *GitOpsDriftStatus `json:"drift,omitempty"`
+}
+
+// GitOpsDriftStatus reports the differences between the cluster configuration at the Git HEAD and
+// the live cluster objects.
+type GitOpsDriftStatus struct {
+	// LastCheckTime is the time the comparison was made.
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+
+	// Commit is the Git commit the live objects were compared with.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// Drifted is true when at least one field differs between Git and the cluster.
+	Drifted bool `json:"drifted"`
+
+	// Fields lists the field-level differences.
+	// +optional
+	Fields []FieldDrift `json:"fields,omitempty"`
+}
+
+// DriftType describes how a field differs between Git and the cluster.
+type DriftType string
+
+const (
+	// DriftTypeModified means the field has a different value in the cluster.
+	DriftTypeModified DriftType = "Modified"
+	// DriftTypeMissing means the field or object is in Git but not in the cluster.
+	DriftTypeMissing DriftType = "Missing"
+)
+
+// FieldDrift is a single difference between an object in Git and the same object in the cluster.
+type FieldDrift struct {
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Path of the field in the object, like spec.controlPlaneConfiguration.count.
+	// Empty when the whole object is missing.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Type of difference.
+	Type DriftType `json:"type"`
+
+	// Git is the JSON encoded value in the Git repository.
+	// +optional
+	Git string `json:"git,omitempty"`
+
+	// Live is the JSON encoded value in the cluster.
+	// +optional
+	Live string `json:"live,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -97,7 +207,7 @@ func (e *FluxConfigSpec) Equal(n *FluxConfigSpec) bool {
 	if e.ClusterConfigPath != n.ClusterConfigPath {
 		return false
 	}
-	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github)
+	return e.Git.Equal(n.Git) && e.Github.Equal(n.Github) && e.Gitlab.Equal(n.Gitlab) && e.Gitea.Equal(n.Gitea)
 }
 
 func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
@@ -110,6 +220,28 @@ func (e *GithubProviderConfig) Equal(n *GithubProviderConfig) bool {
 	return *e == *n
 }
 
+// Equal compares two GitlabProviderConfigs.
+func (e *GitlabProviderConfig) Equal(n *GitlabProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
+// Equal compares two GiteaProviderConfigs.
+func (e *GiteaProviderConfig) Equal(n *GiteaProviderConfig) bool {
+	if e == n {
+		return true
+	}
+	if e == nil || n == nil {
+		return false
+	}
+	return *e == *n
+}
+
 func (e *GitProviderConfig) Equal(n *GitProviderConfig) bool {
 	if e == n {
 		return true
@@ -10,6 +10,9 @@ const (
 	GitOpsConfigKind     = "GitOpsConfig"
 	FluxDefaultNamespace = "flux-system"
 	FluxDefaultBranch    = "main"
+
+	// FluxDefaultPullRequestBranchPrefix is the default prefix of the branches created for pull requests.
+	FluxDefaultPullRequestBranchPrefix = "eksa/"
 )
 
 func validateGitOpsConfig(config *GitOpsConfig) error {
@@ -124,6 +124,11 @@ func (v *VSphereDatacenterConfig) SetDefaults() {
 	}
 }
 
+// NetworkPath returns the full path of a network in the datacenter, like /<datacenter>/network/<name>.
+func (v *VSphereDatacenterConfig) NetworkPath(network string) string {
+	return generateFullVCenterPath(networkFolderType, network, v.Spec.Datacenter)
+}
+
 func (v *VSphereDatacenterConfig) Validate() error {
 	if len(v.Spec.Server) <= 0 {
 		return errors.New("VSphereDatacenterConfig server is not set or is empty")
@@ -0,0 +1,221 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+const (
+	// VSphereIPPoolKind is the object kind name for VSphereIPPool.
+	VSphereIPPoolKind = "VSphereIPPool"
+)
+
+// vsphereIPRange is an inclusive range of IPv4 addresses.
+type vsphereIPRange struct {
+	first, last uint32
+}
+
+func (r vsphereIPRange) contains(address uint32) bool {
+	return address >= r.first && address <= r.last
+}
+
+// vsphereIPPoolAddresses holds the parsed addresses of a VSphereIPPool.
+type vsphereIPPoolAddresses struct {
+	// ranges are sorted and don't overlap.
+	ranges []vsphereIPRange
+	// reserved are the addresses in the ranges that are never allocated: the gateway and
+	// the network and broadcast addresses of the subnet.
+	reserved map[uint32]struct{}
+}
+
+func (a *vsphereIPPoolAddresses) allocatable(address uint32) bool {
+	if _, ok := a.reserved[address]; ok {
+		return false
+	}
+	for _, r := range a.ranges {
+		if r.contains(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the number of addresses the pool can allocate.
+func (p *VSphereIPPool) Size() (int, error) {
+	addresses, err := p.parseAddresses()
+	if err != nil {
+		return 0, err
+	}
+
+	size := 0
+	for _, r := range addresses.ranges {
+		size += int(r.last-r.first) + 1
+	}
+	for address := range addresses.reserved {
+		for _, r := range addresses.ranges {
+			if r.contains(address) {
+				size--
+			}
+		}
+	}
+	return size, nil
+}
+
+// Contains returns true if the pool can allocate address.
+func (p *VSphereIPPool) Contains(address string) (bool, error) {
+	addresses, err := p.parseAddresses()
+	if err != nil {
+		return false, err
+	}
+	ip, err := parseIPv4(address)
+	if err != nil {
+		return false, nil
+	}
+	return addresses.allocatable(ip), nil
+}
+
+// FreeAddress returns the lowest address of the pool that isn't in allocated.
+func (p *VSphereIPPool) FreeAddress(allocated map[string]struct{}) (string, error) {
+	addresses, err := p.parseAddresses()
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range addresses.ranges {
+		for ip := r.first; ; ip++ {
+			if _, ok := addresses.reserved[ip]; !ok {
+				address := uint32ToIPv4(ip).String()
+				if _, ok := allocated[address]; !ok {
+					return address, nil
+				}
+			}
+			if ip == r.last {
+				break
+			}
+		}
+	}
+
+	return "", fmt.Errorf("VSphereIPPool %s has no free addresses", p.Name)
+}
+
+func validateVSphereIPPool(pool *VSphereIPPool) error {
+	if _, err := pool.parseAddresses(); err != nil {
+		return err
+	}
+	for i, nameserver := range pool.Spec.Nameservers {
+		if _, err := netip.ParseAddr(nameserver); err != nil {
+			return fmt.Errorf("VSphereIPPool %s nameservers[%d] %s is invalid", pool.Name, i, nameserver)
+		}
+	}
+	return nil
+}
+
+func (p *VSphereIPPool) parseAddresses() (*vsphereIPPoolAddresses, error) {
+	if p.Spec.Prefix <= 0 || p.Spec.Prefix > 32 {
+		return nil, fmt.Errorf("VSphereIPPool %s prefix %d must be between 1 and 32", p.Name, p.Spec.Prefix)
+	}
+	if len(p.Spec.Gateway) == 0 {
+		return nil, fmt.Errorf("VSphereIPPool %s gateway can not be empty", p.Name)
+	}
+	gateway, err := parseIPv4(p.Spec.Gateway)
+	if err != nil {
+		return nil, fmt.Errorf("VSphereIPPool %s gateway is invalid: %v", p.Name, err)
+	}
+	if len(p.Spec.Addresses) == 0 {
+		return nil, fmt.Errorf("VSphereIPPool %s addresses can not be empty", p.Name)
+	}
+
+	mask := uint32(math.MaxUint32) << (32 - p.Spec.Prefix)
+	subnet := vsphereIPRange{first: gateway & mask, last: gateway | ^mask}
+	addresses := &vsphereIPPoolAddresses{
+		ranges:   make([]vsphereIPRange, 0, len(p.Spec.Addresses)),
+		reserved: map[uint32]struct{}{gateway: {}},
+	}
+	if p.Spec.Prefix <= 30 {
+		addresses.reserved[subnet.first] = struct{}{}
+		addresses.reserved[subnet.last] = struct{}{}
+	}
+
+	for i, address := range p.Spec.Addresses {
+		r, err := parseVSphereIPRange(address)
+		if err != nil {
+			return nil, fmt.Errorf("VSphereIPPool %s addresses[%d] %s is invalid: %v", p.Name, i, address, err)
+		}
+		if !subnet.contains(r.first) || !subnet.contains(r.last) {
+			return nil, fmt.Errorf("VSphereIPPool %s addresses[%d] %s should be within the subnet of the gateway %s/%d", p.Name, i, address, p.Spec.Gateway, p.Spec.Prefix)
+		}
+		addresses.ranges = append(addresses.ranges, r)
+	}
+
+	sort.Slice(addresses.ranges, func(i, j int) bool {
+		return addresses.ranges[i].first < addresses.ranges[j].first
+	})
+	for i := 1; i < len(addresses.ranges); i++ {
+		if addresses.ranges[i].first <= addresses.ranges[i-1].last {
+			return nil, fmt.Errorf("VSphereIPPool %s addresses overlap at %s", p.Name, uint32ToIPv4(addresses.ranges[i].first))
+		}
+	}
+
+	return addresses, nil
+}
+
+// parseVSphereIPRange parses a single address, a range like 10.0.0.10-10.0.0.20
+// or a CIDR like 10.0.0.16/28.
+func parseVSphereIPRange(address string) (vsphereIPRange, error) {
+	if strings.Contains(address, "/") {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(address))
+		if err != nil {
+			return vsphereIPRange{}, err
+		}
+		if !prefix.Addr().Is4() {
+			return vsphereIPRange{}, errors.New("only IPv4 addresses are supported")
+		}
+		first := ipv4ToUint32(prefix.Masked().Addr())
+		return vsphereIPRange{first: first, last: first | uint32(math.MaxUint32)>>prefix.Bits()}, nil
+	}
+
+	if start, end, ok := strings.Cut(address, "-"); ok {
+		first, err := parseIPv4(start)
+		if err != nil {
+			return vsphereIPRange{}, err
+		}
+		last, err := parseIPv4(end)
+		if err != nil {
+			return vsphereIPRange{}, err
+		}
+		if first > last {
+			return vsphereIPRange{}, errors.New("range start should not be greater than range end")
+		}
+		return vsphereIPRange{first: first, last: last}, nil
+	}
+
+	ip, err := parseIPv4(address)
+	if err != nil {
+		return vsphereIPRange{}, err
+	}
+	return vsphereIPRange{first: ip, last: ip}, nil
+}
+
+func parseIPv4(address string) (uint32, error) {
+	ip, err := netip.ParseAddr(strings.TrimSpace(address))
+	if err != nil {
+		return 0, err
+	}
+	if !ip.Is4() {
+		return 0, errors.New("only IPv4 addresses are supported")
+	}
+	return ipv4ToUint32(ip), nil
+}
+
+func ipv4ToUint32(ip netip.Addr) uint32 {
+	b := ip.As4()
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+func uint32ToIPv4(ip uint32) netip.Addr {
+	return netip.AddrFrom4([4]byte{byte(ip >> 24), byte(ip >> 16), byte(ip >> 8), byte(ip)})
+}
@@ -0,0 +1,215 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func vsphereIPPool() v1alpha1.VSphereIPPool {
+	return v1alpha1.VSphereIPPool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       v1alpha1.VSphereIPPoolKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ippool",
+			Namespace: "test-namespace",
+		},
+		Spec: v1alpha1.VSphereIPPoolSpec{
+			Addresses:   []string{"192.168.1.10-192.168.1.12", "192.168.1.20", "192.168.1.0/30"},
+			Prefix:      24,
+			Gateway:     "192.168.1.1",
+			Nameservers: []string{"192.168.1.254", "8.8.8.8"},
+		},
+	}
+}
+
+func TestVSphereIPPoolConvertConfigToConfigGenerateStruct(t *testing.T) {
+	g := NewWithT(t)
+	p := vsphereIPPool()
+	p.Namespace = ""
+
+	want := &v1alpha1.VSphereIPPoolGenerate{
+		TypeMeta: p.TypeMeta,
+		ObjectMeta: v1alpha1.ObjectMeta{
+			Name:      "ippool",
+			Namespace: "default",
+		},
+		Spec: p.Spec,
+	}
+
+	g.Expect(p.ConvertConfigToConfigGenerateStruct()).To(Equal(want))
+}
+
+func TestVSphereIPPoolValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(p *v1alpha1.VSphereIPPool)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(p *v1alpha1.VSphereIPPool) {},
+		},
+		{
+			name:    "prefix not set",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Prefix = 0 },
+			wantErr: "VSphereIPPool ippool prefix 0 must be between 1 and 32",
+		},
+		{
+			name:    "prefix too long",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Prefix = 33 },
+			wantErr: "VSphereIPPool ippool prefix 33 must be between 1 and 32",
+		},
+		{
+			name:    "gateway not set",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Gateway = "" },
+			wantErr: "VSphereIPPool ippool gateway can not be empty",
+		},
+		{
+			name:    "invalid gateway",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Gateway = "gateway" },
+			wantErr: "VSphereIPPool ippool gateway is invalid",
+		},
+		{
+			name:    "ipv6 gateway",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Gateway = "fd00::1" },
+			wantErr: "only IPv4 addresses are supported",
+		},
+		{
+			name:    "addresses not set",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Addresses = nil },
+			wantErr: "VSphereIPPool ippool addresses can not be empty",
+		},
+		{
+			name:    "invalid address",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Addresses = []string{"address"} },
+			wantErr: "VSphereIPPool ippool addresses[0] address is invalid",
+		},
+		{
+			name:    "invalid range",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Addresses = []string{"192.168.1.20-192.168.1.10"} },
+			wantErr: "range start should not be greater than range end",
+		},
+		{
+			name:    "invalid cidr",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Addresses = []string{"192.168.1.0/33"} },
+			wantErr: "VSphereIPPool ippool addresses[0] 192.168.1.0/33 is invalid",
+		},
+		{
+			name:    "address out of subnet",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Addresses = []string{"192.168.1.250-192.168.2.5"} },
+			wantErr: "addresses[0] 192.168.1.250-192.168.2.5 should be within the subnet of the gateway 192.168.1.1/24",
+		},
+		{
+			name: "overlapping addresses",
+			modify: func(p *v1alpha1.VSphereIPPool) {
+				p.Spec.Addresses = []string{"192.168.1.16/28", "192.168.1.20-192.168.1.40"}
+			},
+			wantErr: "VSphereIPPool ippool addresses overlap at 192.168.1.20",
+		},
+		{
+			name:    "invalid nameserver",
+			modify:  func(p *v1alpha1.VSphereIPPool) { p.Spec.Nameservers = []string{"dns"} },
+			wantErr: "VSphereIPPool ippool nameservers[0] dns is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := vsphereIPPool()
+			tt.modify(&p)
+			err := p.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestVSphereIPPoolSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		prefix    int
+		want      int
+	}{
+		{
+			name:      "ranges and single addresses",
+			addresses: []string{"192.168.1.10-192.168.1.12", "192.168.1.20"},
+			prefix:    24,
+			want:      4,
+		},
+		{
+			name:      "cidr excludes network, broadcast and gateway",
+			addresses: []string{"192.168.1.0/24"},
+			prefix:    24,
+			want:      253,
+		},
+		{
+			name:      "range including gateway",
+			addresses: []string{"192.168.1.0-192.168.1.5"},
+			prefix:    24,
+			want:      4,
+		},
+		{
+			name:      "point to point subnet",
+			addresses: []string{"192.168.1.0/31"},
+			prefix:    31,
+			want:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := vsphereIPPool()
+			p.Spec.Addresses = tt.addresses
+			p.Spec.Prefix = tt.prefix
+			g.Expect(p.Size()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestVSphereIPPoolSizeInvalid(t *testing.T) {
+	g := NewWithT(t)
+	p := vsphereIPPool()
+	p.Spec.Gateway = ""
+	_, err := p.Size()
+	g.Expect(err).To(MatchError(ContainSubstring("gateway can not be empty")))
+}
+
+func TestVSphereIPPoolContains(t *testing.T) {
+	g := NewWithT(t)
+	p := vsphereIPPool()
+	g.Expect(p.Contains("192.168.1.11")).To(BeTrue())
+	g.Expect(p.Contains("192.168.1.2")).To(BeTrue())
+	g.Expect(p.Contains("192.168.1.1")).To(BeFalse(), "gateway can't be allocated")
+	g.Expect(p.Contains("192.168.1.0")).To(BeFalse(), "network address can't be allocated")
+	g.Expect(p.Contains("192.168.1.13")).To(BeFalse())
+	g.Expect(p.Contains("address")).To(BeFalse())
+}
+
+func TestVSphereIPPoolFreeAddress(t *testing.T) {
+	g := NewWithT(t)
+	p := vsphereIPPool()
+	allocated := map[string]struct{}{}
+	var got []string
+	for i := 0; i < 6; i++ {
+		address, err := p.FreeAddress(allocated)
+		g.Expect(err).NotTo(HaveOccurred())
+		allocated[address] = struct{}{}
+		got = append(got, address)
+	}
+	g.Expect(got).To(Equal([]string{
+		"192.168.1.2", "192.168.1.3", "192.168.1.10", "192.168.1.11", "192.168.1.12", "192.168.1.20",
+	}))
+
+	_, err := p.FreeAddress(allocated)
+	g.Expect(err).To(MatchError("VSphereIPPool ippool has no free addresses"))
+}
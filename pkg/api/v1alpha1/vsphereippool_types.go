@@ -0,0 +1,104 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VSphereIPPoolSpec defines the desired state of VSphereIPPool.
+type VSphereIPPoolSpec struct {
+	// Addresses are the IPv4 addresses allocated to the machines. Each entry is a single address,
+	// a range like 10.0.0.10-10.0.0.20 or a CIDR like 10.0.0.16/28.
+	Addresses []string `json:"addresses"`
+
+	// Prefix is the length of the network prefix of the addresses, like 24.
+	Prefix int `json:"prefix"`
+
+	// Gateway is the default gateway of the network the addresses belong to.
+	Gateway string `json:"gateway"`
+
+	// Nameservers are the DNS servers configured in the machines.
+	Nameservers []string `json:"nameservers,omitempty"`
+}
+
+// VSphereIPPoolStatus defines the observed state of VSphereIPPool.
+type VSphereIPPoolStatus struct {
+	// Allocations are the addresses allocated to machines, sorted by address.
+	Allocations []VSphereIPPoolAllocation `json:"allocations,omitempty"`
+
+	// Total is the number of addresses in the pool.
+	Total int `json:"total,omitempty"`
+
+	// Free is the number of addresses in the pool not allocated yet.
+	Free int `json:"free,omitempty"`
+}
+
+// VSphereIPPoolAllocation is an address allocated from a VSphereIPPool.
+type VSphereIPPoolAllocation struct {
+	// Address is the allocated address.
+	Address string `json:"address"`
+
+	// Claim is the name of the CAPI IPAddressClaim the address is allocated to.
+	Claim string `json:"claim"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total"
+//+kubebuilder:printcolumn:name="Free",type="integer",JSONPath=".status.free"
+
+// VSphereIPPool is the Schema for the VSphereIPPools API.
+// It allocates static IP addresses to vSphere machines through CAPI IPAddressClaims.
+type VSphereIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VSphereIPPoolSpec   `json:"spec,omitempty"`
+	Status VSphereIPPoolStatus `json:"status,omitempty"`
+}
+
+// Validate validates the fields in a VSphereIPPool object.
+func (p *VSphereIPPool) Validate() error {
+	return validateVSphereIPPool(p)
+}
+
+// ConvertConfigToConfigGenerateStruct converts a VSphereIPPool to VSphereIPPoolGenerate object.
+func (p *VSphereIPPool) ConvertConfigToConfigGenerateStruct() *VSphereIPPoolGenerate {
+	namespace := defaultEksaNamespace
+	if p.Namespace != "" {
+		namespace = p.Namespace
+	}
+	config := &VSphereIPPoolGenerate{
+		TypeMeta: p.TypeMeta,
+		ObjectMeta: ObjectMeta{
+			Name:        p.Name,
+			Annotations: p.Annotations,
+			Namespace:   namespace,
+		},
+		Spec: p.Spec,
+	}
+
+	return config
+}
+
+// +kubebuilder:object:generate=false
+
+// VSphereIPPoolGenerate is same as VSphereIPPool except stripped down for generation of yaml file during generate clusterconfig.
+type VSphereIPPoolGenerate struct {
+	metav1.TypeMeta `json:",inline"`
+	ObjectMeta      `json:"metadata,omitempty"`
+
+	Spec VSphereIPPoolSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VSphereIPPoolList contains a list of VSphereIPPool.
+type VSphereIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VSphereIPPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VSphereIPPool{}, &VSphereIPPoolList{})
+}
@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var vsphereippoollog = logf.Log.WithName("vsphereippool-resource")
+
+// SetupWebhookWithManager sets up the webhook manager for VSphereIPPool.
+func (r *VSphereIPPool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-anywhere-eks-amazonaws-com-v1alpha1-vsphereippool,mutating=false,failurePolicy=fail,sideEffects=None,groups=anywhere.eks.amazonaws.com,resources=vsphereippools,verbs=create;update,versions=v1alpha1,name=validation.vsphereippool.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.CustomValidator = &VSphereIPPool{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (r *VSphereIPPool) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*VSphereIPPool)
+	if !ok {
+		return nil, fmt.Errorf("expected a VSphereIPPool but got %T", obj)
+	}
+
+	vsphereippoollog.Info("validate create", "name", pool.Name)
+
+	return nil, pool.Validate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (r *VSphereIPPool) ValidateUpdate(_ context.Context, old, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*VSphereIPPool)
+	if !ok {
+		return nil, fmt.Errorf("expected a VSphereIPPool but got %T", obj)
+	}
+
+	vsphereippoollog.Info("validate update", "name", pool.Name)
+
+	oldPool, ok := old.(*VSphereIPPool)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a VSphereIPPool but got a %T", old))
+	}
+
+	if err := pool.Validate(); err != nil {
+		return nil, err
+	}
+
+	if allErrs := validateVSphereIPPoolAllocationsKept(pool, oldPool); len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind(VSphereIPPoolKind).GroupKind(), pool.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (r *VSphereIPPool) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*VSphereIPPool)
+	if !ok {
+		return nil, fmt.Errorf("expected a VSphereIPPool but got %T", obj)
+	}
+
+	vsphereippoollog.Info("validate delete", "name", pool.Name)
+
+	return nil, nil
+}
+
+// validateVSphereIPPoolAllocationsKept makes sure the addresses already allocated to machines
+// stay in the pool and keep their network settings.
+func validateVSphereIPPoolAllocationsKept(new, old *VSphereIPPool) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(old.Status.Allocations) == 0 {
+		return allErrs
+	}
+
+	specPath := field.NewPath("spec")
+	if new.Spec.Prefix != old.Spec.Prefix {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("prefix"), "field is immutable while addresses are allocated"))
+	}
+	if new.Spec.Gateway != old.Spec.Gateway {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("gateway"), "field is immutable while addresses are allocated"))
+	}
+
+	for _, allocation := range old.Status.Allocations {
+		if ok, _ := new.Contains(allocation.Address); !ok {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(specPath.Child("addresses"), fmt.Sprintf("address %s is allocated to %s and can't be removed", allocation.Address, allocation.Claim)),
+			)
+		}
+	}
+
+	return allErrs
+}
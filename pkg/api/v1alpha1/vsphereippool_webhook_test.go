@@ -0,0 +1,99 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+func TestVSphereIPPoolValidateCreate(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	g.Expect(new.ValidateCreate(ctx, &new)).Error().To(Succeed())
+}
+
+func TestVSphereIPPoolValidateCreateInvalid(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	new.Spec.Addresses = []string{"invalid"}
+	g.Expect(new.ValidateCreate(ctx, &new)).Error().To(MatchError(ContainSubstring("VSphereIPPool ippool addresses[0] invalid is invalid")))
+}
+
+func TestVSphereIPPoolValidateCreateInvalidObjectType(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	g.Expect(new.ValidateCreate(ctx, &v1alpha1.VSphereDatacenterConfig{})).Error().To(MatchError(ContainSubstring("expected a VSphereIPPool but got *v1alpha1.VSphereDatacenterConfig")))
+}
+
+func TestVSphereIPPoolValidateUpdate(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := new.DeepCopy()
+	old.Status.Allocations = []v1alpha1.VSphereIPPoolAllocation{{Address: "192.168.1.11", Claim: "claim-1"}}
+	new.Spec.Addresses = []string{"192.168.1.11-192.168.1.50"}
+	new.Spec.Nameservers = []string{"1.1.1.1"}
+	g.Expect(new.ValidateUpdate(ctx, old, &new)).Error().To(Succeed())
+}
+
+func TestVSphereIPPoolValidateUpdateInvalid(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := new.DeepCopy()
+	new.Spec.Prefix = 0
+	g.Expect(new.ValidateUpdate(ctx, old, &new)).Error().To(MatchError(ContainSubstring("VSphereIPPool ippool prefix 0 must be between 1 and 32")))
+}
+
+func TestVSphereIPPoolValidateUpdateInvalidObjectType(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := &v1alpha1.VSphereDatacenterConfig{}
+	g.Expect(new.ValidateUpdate(ctx, old, &new)).Error().To(MatchError(ContainSubstring("expected a VSphereIPPool but got a *v1alpha1.VSphereDatacenterConfig")))
+}
+
+func TestVSphereIPPoolValidateUpdateAllocatedAddressRemoved(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := new.DeepCopy()
+	old.Status.Allocations = []v1alpha1.VSphereIPPoolAllocation{{Address: "192.168.1.20", Claim: "claim-1"}}
+	new.Spec.Addresses = []string{"192.168.1.10-192.168.1.12"}
+	g.Expect(new.ValidateUpdate(ctx, old, &new)).Error().To(MatchError(ContainSubstring("address 192.168.1.20 is allocated to claim-1 and can't be removed")))
+}
+
+func TestVSphereIPPoolValidateUpdateGatewayWithAllocations(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := new.DeepCopy()
+	old.Status.Allocations = []v1alpha1.VSphereIPPoolAllocation{{Address: "192.168.1.20", Claim: "claim-1"}}
+	new.Spec.Gateway = "192.168.1.126"
+	new.Spec.Prefix = 25
+	err := func() error { _, err := new.ValidateUpdate(ctx, old, &new); return err }()
+	g.Expect(err).To(MatchError(ContainSubstring("spec.prefix: Forbidden: field is immutable while addresses are allocated")))
+	g.Expect(err).To(MatchError(ContainSubstring("spec.gateway: Forbidden: field is immutable while addresses are allocated")))
+}
+
+func TestVSphereIPPoolValidateUpdateGatewayWithoutAllocations(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	old := new.DeepCopy()
+	new.Spec.Gateway = "192.168.1.254"
+	g.Expect(new.ValidateUpdate(ctx, old, &new)).Error().To(Succeed())
+}
+
+func TestVSphereIPPoolValidateDelete(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+	new := vsphereIPPool()
+	g.Expect(new.ValidateDelete(ctx, &new)).Error().To(Succeed())
+}
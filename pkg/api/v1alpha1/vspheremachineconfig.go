@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"math"
+	"path/filepath"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -14,6 +16,15 @@ const (
 	DefaultVSphereNumCPUs    = 2
 	DefaultVSphereMemoryMiB  = 8192
 	DefaultVSphereOSFamily   = Bottlerocket
+
+	// DefaultVSphereIPPoolAPIGroup is the API group of the VSphereIPPool.
+	DefaultVSphereIPPoolAPIGroup = "anywhere.eks.amazonaws.com"
+	// DefaultVSphereIPPoolKind is the kind of the VSphereIPPool.
+	DefaultVSphereIPPoolKind = VSphereIPPoolKind
+
+	// maxVSphereAdditionalDisks is the number of disks the SCSI controller of the boot disk
+	// has room for, besides the boot disk.
+	maxVSphereAdditionalDisks = 14
 )
 
 // Used for generating yaml for generate clusterconfig command.
@@ -75,6 +86,15 @@ func setVSphereMachineConfigDefaults(machineConfig *VSphereMachineConfig) {
 		logger.Info("Warning: OS family not specified in machine config specification. Defaulting to Bottlerocket.")
 		machineConfig.Spec.OSFamily = Bottlerocket
 	}
+
+	for _, pool := range machineConfig.IPPoolRefs() {
+		if pool.APIGroup == "" {
+			pool.APIGroup = DefaultVSphereIPPoolAPIGroup
+		}
+		if pool.Kind == "" {
+			pool.Kind = DefaultVSphereIPPoolKind
+		}
+	}
 }
 
 func validateVSphereMachineConfig(config *VSphereMachineConfig) error {
@@ -93,10 +113,79 @@ func validateVSphereMachineConfig(config *VSphereMachineConfig) error {
 	if err := validateHostOSConfig(config.Spec.HostOSConfiguration, config.Spec.OSFamily); err != nil {
 		return fmt.Errorf("HostOSConfiguration is invalid for VSphereMachineConfig %s: %v", config.Name, err)
 	}
+	if config.Spec.IPPool != nil && config.Spec.IPPool.Name == "" {
+		return fmt.Errorf("VSphereMachineConfig %s ipPool name is not set or is empty", config.Name)
+	}
+	if err := validateVSphereMachineConfigAdditionalNetworks(config); err != nil {
+		return err
+	}
+	if err := validateVSphereMachineConfigAdditionalDisks(config); err != nil {
+		return err
+	}
+	if err := validateVSphereMachineConfigPCIDevices(config); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+func validateVSphereMachineConfigAdditionalNetworks(config *VSphereMachineConfig) error {
+	names := map[string]struct{}{}
+	for _, network := range config.Spec.AdditionalNetworks {
+		if network.Name == "" {
+			return fmt.Errorf("VSphereMachineConfig %s additional network name is not set or is empty", config.Name)
+		}
+		if _, ok := names[network.Name]; ok {
+			return fmt.Errorf("VSphereMachineConfig %s additional network %s is duplicated", config.Name, network.Name)
+		}
+		names[network.Name] = struct{}{}
+		if network.IPPool != nil && network.IPPool.Name == "" {
+			return fmt.Errorf("VSphereMachineConfig %s additional network %s ipPool name is not set or is empty", config.Name, network.Name)
+		}
+	}
+	return nil
+}
+
+func validateVSphereMachineConfigAdditionalDisks(config *VSphereMachineConfig) error {
+	if len(config.Spec.AdditionalDisks) > maxVSphereAdditionalDisks {
+		return fmt.Errorf("VSphereMachineConfig %s can't have more than %d additional disks", config.Name, maxVSphereAdditionalDisks)
+	}
+	// CAPV resizes the template disks after the boot disk, and the second disk of the
+	// Bottlerocket templates is the data disk of the OS.
+	if len(config.Spec.AdditionalDisks) > 0 && config.Spec.OSFamily == Bottlerocket {
+		return fmt.Errorf("VSphereMachineConfig %s additional disks are not supported for osFamily %s", config.Name, Bottlerocket)
+	}
+	mountPaths := map[string]struct{}{}
+	for i, disk := range config.Spec.AdditionalDisks {
+		if disk.SizeGiB <= 0 {
+			return fmt.Errorf("VSphereMachineConfig %s additional disk %d sizeGiB must be greater than 0", config.Name, i)
+		}
+		if disk.MountPath == "" {
+			continue
+		}
+		if !filepath.IsAbs(disk.MountPath) || filepath.Clean(disk.MountPath) == "/" {
+			return fmt.Errorf("VSphereMachineConfig %s additional disk %d mountPath %s must be an absolute path other than /", config.Name, i, disk.MountPath)
+		}
+		if _, ok := mountPaths[filepath.Clean(disk.MountPath)]; ok {
+			return fmt.Errorf("VSphereMachineConfig %s additional disk %d mountPath %s is duplicated", config.Name, i, disk.MountPath)
+		}
+		mountPaths[filepath.Clean(disk.MountPath)] = struct{}{}
+	}
+	return nil
+}
+
+func validateVSphereMachineConfigPCIDevices(config *VSphereMachineConfig) error {
+	for i, device := range config.Spec.PCIDevices {
+		if device.DeviceID <= 0 || device.DeviceID > math.MaxUint16 {
+			return fmt.Errorf("VSphereMachineConfig %s pci device %d deviceId %d must be between 1 and %d", config.Name, i, device.DeviceID, math.MaxUint16)
+		}
+		if device.VendorID <= 0 || device.VendorID > math.MaxUint16 {
+			return fmt.Errorf("VSphereMachineConfig %s pci device %d vendorId %d must be between 1 and %d", config.Name, i, device.VendorID, math.MaxUint16)
+		}
+	}
+	return nil
+}
+
 func validateVSphereMachineConfigHasTemplate(config *VSphereMachineConfig) error {
 	if config.Spec.Template == "" {
 		return fmt.Errorf("template field is required")
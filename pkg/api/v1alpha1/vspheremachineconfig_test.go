@@ -359,3 +359,221 @@ func TestVSphereMachineConfigSetDefaultUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestVSphereMachineConfigValidateAdditionalNetworksAndDisks(t *testing.T) {
+	tests := []struct {
+		name     string
+		osFamily OSFamily
+		ipPool   *VSphereIPPoolReference
+		networks []VSphereMachineNetwork
+		disks    []VSphereMachineDisk
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			osFamily: Ubuntu,
+			networks: []VSphereMachineNetwork{
+				{Name: "storage"},
+				{Name: "database", IPPool: &VSphereIPPoolReference{Name: "pool"}},
+			},
+			disks: []VSphereMachineDisk{
+				{SizeGiB: 10, MountPath: "/var/lib/data"},
+				{SizeGiB: 10},
+			},
+		},
+		{
+			name:     "primary ip pool",
+			osFamily: Ubuntu,
+			ipPool:   &VSphereIPPoolReference{Name: "pool"},
+		},
+		{
+			name:     "empty primary ip pool name",
+			osFamily: Ubuntu,
+			ipPool:   &VSphereIPPoolReference{},
+			wantErr:  "VSphereMachineConfig test ipPool name is not set or is empty",
+		},
+		{
+			name:     "bottlerocket without mount path",
+			osFamily: Bottlerocket,
+			disks:    []VSphereMachineDisk{{SizeGiB: 10}},
+			wantErr:  "VSphereMachineConfig test additional disks are not supported for osFamily bottlerocket",
+		},
+		{
+			name:     "empty network name",
+			osFamily: Ubuntu,
+			networks: []VSphereMachineNetwork{{}},
+			wantErr:  "additional network name is not set or is empty",
+		},
+		{
+			name:     "duplicated network",
+			osFamily: Ubuntu,
+			networks: []VSphereMachineNetwork{{Name: "storage"}, {Name: "storage"}},
+			wantErr:  "additional network storage is duplicated",
+		},
+		{
+			name:     "empty ip pool name",
+			osFamily: Ubuntu,
+			networks: []VSphereMachineNetwork{{Name: "storage", IPPool: &VSphereIPPoolReference{}}},
+			wantErr:  "additional network storage ipPool name is not set or is empty",
+		},
+		{
+			name:     "disk without size",
+			osFamily: Ubuntu,
+			disks:    []VSphereMachineDisk{{MountPath: "/data"}},
+			wantErr:  "additional disk 0 sizeGiB must be greater than 0",
+		},
+		{
+			name:     "bottlerocket with mount path",
+			osFamily: Bottlerocket,
+			disks:    []VSphereMachineDisk{{SizeGiB: 10, MountPath: "/data"}},
+			wantErr:  "additional disks are not supported for osFamily bottlerocket",
+		},
+		{
+			name:     "relative mount path",
+			osFamily: Ubuntu,
+			disks:    []VSphereMachineDisk{{SizeGiB: 10, MountPath: "data"}},
+			wantErr:  "mountPath data must be an absolute path other than /",
+		},
+		{
+			name:     "root mount path",
+			osFamily: Ubuntu,
+			disks:    []VSphereMachineDisk{{SizeGiB: 10, MountPath: "/"}},
+			wantErr:  "mountPath / must be an absolute path other than /",
+		},
+		{
+			name:     "duplicated mount path",
+			osFamily: Ubuntu,
+			disks:    []VSphereMachineDisk{{SizeGiB: 10, MountPath: "/data"}, {SizeGiB: 10, MountPath: "/data/"}},
+			wantErr:  "additional disk 1 mountPath /data/ is duplicated",
+		},
+		{
+			name:     "too many disks",
+			osFamily: Ubuntu,
+			disks:    make([]VSphereMachineDisk, 15),
+			wantErr:  "can't have more than 14 additional disks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:          64,
+					DiskGiB:            100,
+					NumCPUs:            3,
+					Template:           "templateA",
+					ResourcePool:       "poolA",
+					Datastore:          "ds-aaa",
+					OSFamily:           tt.osFamily,
+					IPPool:             tt.ipPool,
+					AdditionalNetworks: tt.networks,
+					AdditionalDisks:    tt.disks,
+					Users: []UserConfiguration{
+						{
+							Name:              "ec2-user",
+							SshAuthorizedKeys: []string{"ssh_rsa"},
+						},
+					},
+				},
+			}
+			err := config.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestVSphereMachineConfigValidatePCIDevices(t *testing.T) {
+	tests := []struct {
+		name    string
+		devices []VSpherePCIDevice
+		wantErr string
+	}{
+		{
+			name: "valid",
+			devices: []VSpherePCIDevice{
+				{DeviceID: 8373, VendorID: 4318},
+				{DeviceID: 8373, VendorID: 4318},
+			},
+		},
+		{
+			name:    "missing device id",
+			devices: []VSpherePCIDevice{{VendorID: 4318}},
+			wantErr: "pci device 0 deviceId 0 must be between 1 and 65535",
+		},
+		{
+			name:    "device id out of range",
+			devices: []VSpherePCIDevice{{DeviceID: 65536, VendorID: 4318}},
+			wantErr: "pci device 0 deviceId 65536 must be between 1 and 65535",
+		},
+		{
+			name:    "negative vendor id",
+			devices: []VSpherePCIDevice{{DeviceID: 8373, VendorID: -1}},
+			wantErr: "pci device 0 vendorId -1 must be between 1 and 65535",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			config := &VSphereMachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: VSphereMachineConfigSpec{
+					MemoryMiB:    64,
+					DiskGiB:      100,
+					NumCPUs:      3,
+					Template:     "templateA",
+					ResourcePool: "poolA",
+					Datastore:    "ds-aaa",
+					OSFamily:     Ubuntu,
+					PCIDevices:   tt.devices,
+					Users: []UserConfiguration{
+						{
+							Name:              "ec2-user",
+							SshAuthorizedKeys: []string{"ssh_rsa"},
+						},
+					},
+				},
+			}
+			err := config.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+func TestVSphereMachineConfigSetDefaultsIPPool(t *testing.T) {
+	g := NewWithT(t)
+	config := &VSphereMachineConfig{
+		Spec: VSphereMachineConfigSpec{
+			IPPool: &VSphereIPPoolReference{Name: "primary"},
+			AdditionalNetworks: []VSphereMachineNetwork{
+				{Name: "storage"},
+				{Name: "database", IPPool: &VSphereIPPoolReference{Name: "pool"}},
+				{Name: "backup", IPPool: &VSphereIPPoolReference{APIGroup: "ipam.example.com", Kind: "Pool", Name: "pool"}},
+			},
+		},
+	}
+	config.SetDefaults()
+	g.Expect(config.Spec.IPPool).To(Equal(&VSphereIPPoolReference{
+		APIGroup: "anywhere.eks.amazonaws.com",
+		Kind:     "VSphereIPPool",
+		Name:     "primary",
+	}))
+	g.Expect(config.Spec.AdditionalNetworks[0].IPPool).To(BeNil())
+	g.Expect(config.Spec.AdditionalNetworks[1].IPPool).To(Equal(&VSphereIPPoolReference{
+		APIGroup: "anywhere.eks.amazonaws.com",
+		Kind:     "VSphereIPPool",
+		Name:     "pool",
+	}))
+	g.Expect(config.Spec.AdditionalNetworks[2].IPPool).To(Equal(&VSphereIPPoolReference{
+		APIGroup: "ipam.example.com",
+		Kind:     "Pool",
+		Name:     "pool",
+	}))
+}
@@ -28,6 +28,55 @@ type VSphereMachineConfigSpec struct {
 	TagIDs              []string             `json:"tags,omitempty"`
 	CloneMode           CloneMode            `json:"cloneMode,omitempty"`
 	HostOSConfiguration *HostOSConfiguration `json:"hostOSConfiguration,omitempty"`
+	// IPPool is the pool the static IP address of the primary network interface is allocated from.
+	// The interface uses DHCP when not set.
+	IPPool *VSphereIPPoolReference `json:"ipPool,omitempty"`
+	// AdditionalNetworks are network interfaces attached to the machines after the primary one,
+	// which is connected to the datacenter or failure domain network.
+	AdditionalNetworks []VSphereMachineNetwork `json:"additionalNetworks,omitempty"`
+	// AdditionalDisks are the data disks of the machines after the boot disk. CAPV resizes the
+	// template disks after the boot disk to them, the template needs a disk for each one.
+	AdditionalDisks []VSphereMachineDisk `json:"additionalDisks,omitempty"`
+	// PCIDevices are host PCI devices, like GPUs, passed through to the machines.
+	// They require the devices to have passthrough enabled in the ESXi hosts.
+	PCIDevices []VSpherePCIDevice `json:"pciDevices,omitempty"`
+}
+
+// VSphereMachineNetwork is an additional network interface of a vSphere machine.
+type VSphereMachineNetwork struct {
+	// Name is the vSphere network the interface is connected to. It can be the network name
+	// or its full path, like /<datacenter>/network/<name>.
+	Name string `json:"name"`
+	// IPPool is the pool the static IP address of the interface is allocated from.
+	// The interface uses DHCP when not set.
+	IPPool *VSphereIPPoolReference `json:"ipPool,omitempty"`
+}
+
+// VSphereIPPoolReference references a CAPI IPAM pool in the namespace of the cluster machines.
+type VSphereIPPoolReference struct {
+	// APIGroup is the API group of the pool. Defaults to anywhere.eks.amazonaws.com.
+	APIGroup string `json:"apiGroup,omitempty"`
+	// Kind is the kind of the pool. Defaults to VSphereIPPool.
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the pool.
+	Name string `json:"name"`
+}
+
+// VSphereMachineDisk is an additional data disk of a vSphere machine.
+type VSphereMachineDisk struct {
+	// SizeGiB is the size of the disk.
+	SizeGiB int `json:"sizeGiB"`
+	// MountPath is the path the disk is formatted with ext4 and mounted at, by filesystem label.
+	// The disk is left unformatted when not set.
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// VSpherePCIDevice identifies a PCI device passed through to a vSphere machine.
+type VSpherePCIDevice struct {
+	// DeviceID is the device ID of the PCI device, in decimal.
+	DeviceID int32 `json:"deviceId"`
+	// VendorID is the vendor ID of the PCI device, in decimal.
+	VendorID int32 `json:"vendorId"`
 }
 
 // ResourcePaths returns a map of vSphere resource paths defined in the VSphereMachineConfig.
@@ -42,6 +91,26 @@ func (c *VSphereMachineConfig) ResourcePaths() map[string]string {
 	}
 }
 
+// IPPoolRefs returns the references to the pools the static IP addresses of the machine
+// network interfaces are allocated from, the primary interface first.
+func (c *VSphereMachineConfig) IPPoolRefs() []*VSphereIPPoolReference {
+	var refs []*VSphereIPPoolReference
+	if c.Spec.IPPool != nil {
+		refs = append(refs, c.Spec.IPPool)
+	}
+	for _, network := range c.Spec.AdditionalNetworks {
+		if network.IPPool != nil {
+			refs = append(refs, network.IPPool)
+		}
+	}
+	return refs
+}
+
+// IsVSphereIPPool returns true if the reference points to a VSphereIPPool, explicitly or by default.
+func (r *VSphereIPPoolReference) IsVSphereIPPool() bool {
+	return (r.APIGroup == "" || r.APIGroup == DefaultVSphereIPPoolAPIGroup) && (r.Kind == "" || r.Kind == VSphereIPPoolKind)
+}
+
 func (c *VSphereMachineConfig) PauseReconcile() {
 	c.Annotations[pausedAnnotation] = "true"
 }
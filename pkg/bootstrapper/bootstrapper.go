@@ -59,6 +59,11 @@ func (b *Bootstrapper) CreateBootstrapCluster(ctx context.Context, clusterSpec *
 	return c, nil
 }
 
+// BootstrapClusterExists checks if the kind cluster backing a bootstrap cluster still exists.
+func (b *Bootstrapper) BootstrapClusterExists(ctx context.Context, cluster *types.Cluster) (bool, error) {
+	return b.clusterClient.KindClusterExists(ctx, cluster.Name)
+}
+
 func (b *Bootstrapper) DeleteBootstrapCluster(ctx context.Context, cluster *types.Cluster, operationType constants.Operation, isForceCleanup bool) error {
 	clusterExists, err := b.clusterClient.KindClusterExists(ctx, cluster.Name)
 	if err != nil {
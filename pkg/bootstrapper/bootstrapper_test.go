@@ -66,6 +66,22 @@ func TestBootstrapperCreateBootstrapClusterFailureOnCreateNamespaceIfNotPresentF
 	}
 }
 
+func TestBootstrapperBootstrapClusterExists(t *testing.T) {
+	cluster := &types.Cluster{
+		Name:           "cluster-name",
+		KubeconfigFile: "c.kubeconfig",
+	}
+
+	ctx := context.Background()
+	b, client := newBootstrapper(t)
+	client.EXPECT().KindClusterExists(ctx, cluster.Name).Return(true, nil)
+
+	exists, err := b.BootstrapClusterExists(ctx, cluster)
+	if err != nil || !exists {
+		t.Fatalf("Bootstrapper.BootstrapClusterExists() = %t, %v, want true, nil", exists, err)
+	}
+}
+
 func TestBootstrapperDeleteBootstrapClusterNoBootstrap(t *testing.T) {
 	cluster := &types.Cluster{
 		Name:           "cluster-name",
@@ -0,0 +1,445 @@
+package bootstrapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	// ExistingClusterNamespace is the namespace an existing bootstrap cluster keeps its lease in.
+	ExistingClusterNamespace = "eksa-bootstrap"
+	// ExistingClusterLease is the name of the ConfigMap recording which cluster is being
+	// bootstrapped in an existing cluster and the namespaces created for it.
+	ExistingClusterLease = "eksa-bootstrap-lease"
+
+	leaseClusterKey           = "cluster"
+	leaseCreatedNamespacesKey = "createdNamespaces"
+
+	capiProvidersResourceType = "providers.clusterctl.cluster.x-k8s.io"
+	capiClustersResourceType  = "clusters.cluster.x-k8s.io"
+	eksaClustersResourceType  = "clusters.anywhere.eks.amazonaws.com"
+	// capiClusterScopedResourceTypes are the cluster scoped resources clusterctl installs
+	// along with the providers and cert-manager.
+	capiClusterScopedResourceTypes = "customresourcedefinitions,validatingwebhookconfigurations,mutatingwebhookconfigurations,clusterroles,clusterrolebindings"
+)
+
+// eksaClusterScopedObjects are the cluster scoped objects, other than the CRDs and the namespace, the
+// EKS Anywhere components install. They aren't labeled, so they're deleted by name.
+var eksaClusterScopedObjects = []clusterScopedObject{
+	{resourceType: "clusterroles", name: "eksa-manager-role"},
+	{resourceType: "clusterrolebindings", name: "eksa-manager-rolebinding"},
+	{resourceType: "mutatingwebhookconfigurations", name: "eksa-mutating-webhook-configuration"},
+	{resourceType: "validatingwebhookconfigurations", name: "eksa-validating-webhook-configuration"},
+}
+
+type clusterScopedObject struct {
+	resourceType, name string
+}
+
+// bootstrapNamespaces are the namespaces installing the EKS Anywhere and Cluster API components creates
+// that aren't owned by a Cluster API provider.
+var bootstrapNamespaces = []string{constants.EksaSystemNamespace, constants.CertManagerNamespace}
+
+// ExistingClusterClient is the Kubernetes client the ExistingCluster bootstrapper works with.
+type ExistingClusterClient interface {
+	Get(ctx context.Context, resourceType, kubeconfig string, obj runtime.Object, opts ...kubernetes.KubectlGetOption) error
+	Create(ctx context.Context, kubeconfig string, obj runtime.Object) error
+	Delete(ctx context.Context, resourceType, kubeconfig string, opts ...kubernetes.KubectlDeleteOption) error
+	CreateNamespaceIfNotPresent(ctx context.Context, kubeconfig string, namespace string) error
+	DeleteNamespace(ctx context.Context, kubeconfig string, namespace string) error
+	DeleteClusterObject(ctx context.Context, resourceType, name, kubeconfig string) error
+	ValidateClustersCRD(ctx context.Context, cluster *types.Cluster) error
+	GetClusters(ctx context.Context, cluster *types.Cluster) ([]types.CAPICluster, error)
+}
+
+// ExistingCluster bootstraps clusters in an existing, long lived, Kubernetes cluster instead of
+// creating a kind cluster. Since CAPI and the EKS Anywhere namespaces are cluster wide, it holds a
+// lease in the existing cluster so only one cluster is bootstrapped at a time.
+// An existing Cluster API or EKS Anywhere installation can't be reused: it only bootstraps in clusters
+// that run neither, so everything found after bootstrap was installed by it and can be removed.
+type ExistingCluster struct {
+	kubeconfig string
+	client     ExistingClusterClient
+	writer     filewriter.FileWriter
+}
+
+// NewExistingCluster constructs an ExistingCluster bootstrapper for the cluster kubeconfig points to.
+func NewExistingCluster(kubeconfig string, client ExistingClusterClient, writer filewriter.FileWriter) *ExistingCluster {
+	return &ExistingCluster{
+		kubeconfig: kubeconfig,
+		client:     client,
+		writer:     writer,
+	}
+}
+
+// CreateBootstrapCluster takes the lease of the existing cluster and sets up the namespaces used
+// during bootstrap. The bootstrap options only apply to kind clusters and are ignored.
+func (e *ExistingCluster) CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.Spec, opts ...BootstrapClusterOption) (*types.Cluster, error) {
+	if len(opts) > 0 {
+		logger.V(4).Info("Ignoring kind bootstrap cluster options for existing bootstrap cluster")
+	}
+
+	kubeconfigFile, err := e.writeKubeconfig(clusterSpec.Cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: kubeconfigFile,
+	}
+
+	if err = e.client.CreateNamespaceIfNotPresent(ctx, c.KubeconfigFile, ExistingClusterNamespace); err != nil {
+		return nil, fmt.Errorf("creating bootstrap cluster: %v", err)
+	}
+
+	lease, err := e.getLease(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("creating bootstrap cluster: %v", err)
+	}
+
+	if lease == nil {
+		if err = e.validateNoComponents(ctx, c); err != nil {
+			return nil, fmt.Errorf("creating bootstrap cluster: %v", err)
+		}
+		if lease, err = e.takeLease(ctx, c); err != nil {
+			return nil, fmt.Errorf("creating bootstrap cluster: %v", err)
+		}
+	} else if owner := lease.Data[leaseClusterKey]; owner != c.Name {
+		return nil, fmt.Errorf("creating bootstrap cluster: existing cluster is bootstrapping cluster %s, delete ConfigMap %s/%s once it's done", owner, ExistingClusterNamespace, ExistingClusterLease)
+	} else {
+		logger.V(4).Info("Reusing existing bootstrap cluster lease", "cluster", c.Name)
+	}
+
+	if err = e.client.CreateNamespaceIfNotPresent(ctx, c.KubeconfigFile, constants.EksaSystemNamespace); err != nil {
+		return nil, fmt.Errorf("creating bootstrap cluster: %v", err)
+	}
+
+	return c, nil
+}
+
+// BootstrapClusterExists checks the existing cluster is still bootstrapping cluster, that is, it
+// still holds a lease owned by cluster.
+func (e *ExistingCluster) BootstrapClusterExists(ctx context.Context, cluster *types.Cluster) (bool, error) {
+	if cluster.KubeconfigFile == "" {
+		kubeconfigFile, err := e.writeKubeconfig(cluster.Name)
+		if err != nil {
+			return false, err
+		}
+		cluster.KubeconfigFile = kubeconfigFile
+	}
+
+	lease, err := e.getLease(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+
+	return lease != nil && lease.Data[leaseClusterKey] == cluster.Name, nil
+}
+
+// DeleteBootstrapCluster deletes the Cluster API, cert-manager and EKS Anywhere components and the namespaces
+// created during bootstrap, and releases the lease of the existing cluster. The existing cluster is kept.
+func (e *ExistingCluster) DeleteBootstrapCluster(ctx context.Context, cluster *types.Cluster, operationType constants.Operation, isForceCleanup bool) error {
+	if cluster.KubeconfigFile == "" {
+		kubeconfigFile, err := e.writeKubeconfig(cluster.Name)
+		if err != nil {
+			return fmt.Errorf("deleting bootstrap cluster: %v", err)
+		}
+		cluster.KubeconfigFile = kubeconfigFile
+	}
+
+	lease, err := e.getLease(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("deleting bootstrap cluster: %v", err)
+	}
+	if lease == nil || lease.Data[leaseClusterKey] != cluster.Name {
+		logger.V(4).Info("Skipping delete bootstrap cluster, existing cluster isn't bootstrapping this cluster")
+		return nil
+	}
+
+	mgmtCluster, err := e.managementInCluster(ctx, cluster)
+	if err != nil {
+		return fmt.Errorf("deleting bootstrap cluster: %v", err)
+	}
+	if mgmtCluster != nil && !isForceCleanup && (operationType == constants.Upgrade || mgmtCluster.Status.Phase == "Provisioned") {
+		return errors.New("error deleting bootstrap cluster: management cluster in bootstrap cluster")
+	}
+
+	if err = e.deleteCAPI(ctx, cluster, strings.Fields(lease.Data[leaseCreatedNamespacesKey])); err != nil {
+		return fmt.Errorf("deleting bootstrap cluster: %v", err)
+	}
+
+	if err = e.deleteEksaComponents(ctx, cluster); err != nil {
+		return fmt.Errorf("deleting bootstrap cluster: %v", err)
+	}
+
+	if err = e.client.Delete(ctx, "configmap", cluster.KubeconfigFile, &kubernetes.KubectlDeleteOptions{
+		Name:      ExistingClusterLease,
+		Namespace: ExistingClusterNamespace,
+	}); err != nil {
+		return fmt.Errorf("deleting bootstrap cluster: releasing lease: %v", err)
+	}
+
+	if err = e.client.DeleteNamespace(ctx, cluster.KubeconfigFile, ExistingClusterNamespace); err != nil {
+		return fmt.Errorf("deleting bootstrap cluster: %v", err)
+	}
+
+	return nil
+}
+
+// writeKubeconfig copies the kubeconfig of the existing cluster to the cluster folder, so it's
+// available to the executables running in the tools container.
+func (e *ExistingCluster) writeKubeconfig(clusterName string) (string, error) {
+	content, err := os.ReadFile(e.kubeconfig)
+	if err != nil {
+		return "", fmt.Errorf("reading existing bootstrap cluster kubeconfig: %v", err)
+	}
+	path, err := e.writer.Write(fmt.Sprintf("%s.bootstrap.kubeconfig", clusterName), content, filewriter.PersistentFile, filewriter.Permission0600)
+	if err != nil {
+		return "", fmt.Errorf("writing existing bootstrap cluster kubeconfig: %v", err)
+	}
+	return path, nil
+}
+
+func (e *ExistingCluster) getLease(ctx context.Context, cluster *types.Cluster) (*corev1.ConfigMap, error) {
+	lease := &corev1.ConfigMap{}
+	err := e.client.Get(ctx, "configmap", cluster.KubeconfigFile, lease, &kubernetes.KubectlGetOptions{
+		Name:      ExistingClusterLease,
+		Namespace: ExistingClusterNamespace,
+	})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting bootstrap lease: %v", err)
+	}
+	return lease, nil
+}
+
+// takeLease records the cluster being bootstrapped along with the namespaces that don't exist yet
+// and will be created for it. The namespaces of the Cluster API providers are found in the clusterctl
+// inventory when the bootstrap cluster is deleted.
+func (e *ExistingCluster) takeLease(ctx context.Context, cluster *types.Cluster) (*corev1.ConfigMap, error) {
+	var created []string
+	for _, namespace := range bootstrapNamespaces {
+		err := e.client.Get(ctx, "namespace", cluster.KubeconfigFile, &corev1.Namespace{}, &kubernetes.KubectlGetOptions{
+			Name:          namespace,
+			ClusterScoped: ptr.Bool(true),
+		})
+		if apierrors.IsNotFound(err) {
+			created = append(created, namespace)
+		} else if err != nil {
+			return nil, fmt.Errorf("getting namespace %s: %v", namespace, err)
+		}
+	}
+
+	lease := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ExistingClusterLease,
+			Namespace: ExistingClusterNamespace,
+		},
+		Data: map[string]string{
+			leaseClusterKey:           cluster.Name,
+			leaseCreatedNamespacesKey: strings.Join(created, " "),
+		},
+	}
+	if err := e.client.Create(ctx, cluster.KubeconfigFile, lease); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, errors.New("existing cluster is bootstrapping another cluster")
+		}
+		return nil, fmt.Errorf("taking bootstrap lease: %v", err)
+	}
+
+	return lease, nil
+}
+
+// managementInCluster returns the CAPI cluster for cluster, if present in the existing cluster.
+// Other CAPI clusters the existing cluster might manage are ignored.
+func (e *ExistingCluster) managementInCluster(ctx context.Context, cluster *types.Cluster) (*types.CAPICluster, error) {
+	if err := e.client.ValidateClustersCRD(ctx, cluster); err != nil {
+		return nil, nil
+	}
+	clusters, err := e.client.GetClusters(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range clusters {
+		if c.Metadata.Name == cluster.Name {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// validateNoComponents checks the existing cluster runs neither Cluster API nor EKS Anywhere, since the
+// components installed during bootstrap would replace the existing ones and be deleted with the bootstrap cluster.
+func (e *ExistingCluster) validateNoComponents(ctx context.Context, cluster *types.Cluster) error {
+	providers, err := e.capiProviders(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	if len(providers) > 0 {
+		installed := make([]string, 0, len(providers))
+		for _, p := range providers {
+			installed = append(installed, fmt.Sprintf("%s %s in namespace %s", p.ProviderName, p.Version, p.Namespace))
+		}
+		return fmt.Errorf("existing cluster already runs Cluster API providers (%s), use a cluster without Cluster API", strings.Join(installed, ", "))
+	}
+
+	exists, err := e.crdExists(ctx, cluster, capiClustersResourceType)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("existing cluster already has the Cluster API CRDs (%s), use a cluster without Cluster API", capiClustersResourceType)
+	}
+
+	exists, err = e.crdExists(ctx, cluster, eksaClustersResourceType)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("existing cluster already has the EKS Anywhere CRDs (%s), use a cluster without EKS Anywhere", eksaClustersResourceType)
+	}
+
+	return nil
+}
+
+// capiProviders returns the Cluster API providers recorded in the clusterctl inventory of the existing cluster.
+func (e *ExistingCluster) capiProviders(ctx context.Context, cluster *types.Cluster) ([]clusterctlv1.Provider, error) {
+	exists, err := e.crdExists(ctx, cluster, capiProvidersResourceType)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	providers := &clusterctlv1.ProviderList{}
+	err = e.client.Get(ctx, capiProvidersResourceType, cluster.KubeconfigFile, providers)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting Cluster API providers: %v", err)
+	}
+
+	return providers.Items, nil
+}
+
+func (e *ExistingCluster) crdExists(ctx context.Context, cluster *types.Cluster, name string) (bool, error) {
+	err := e.client.Get(ctx, "customresourcedefinition", cluster.KubeconfigFile, &metav1.PartialObjectMetadata{}, &kubernetes.KubectlGetOptions{
+		Name:          name,
+		ClusterScoped: ptr.Bool(true),
+	})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting CRD %s: %v", name, err)
+	}
+	return true, nil
+}
+
+// deleteCAPI deletes the namespaces of the Cluster API providers, the namespaces created for the
+// bootstrap and the cluster scoped resources clusterctl installed with them.
+func (e *ExistingCluster) deleteCAPI(ctx context.Context, cluster *types.Cluster, createdNamespaces []string) error {
+	providers, err := e.capiProviders(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	namespaces := append([]string{}, createdNamespaces...)
+	for _, p := range providers {
+		if !slices.Contains(namespaces, p.Namespace) {
+			namespaces = append(namespaces, p.Namespace)
+		}
+	}
+
+	for _, namespace := range namespaces {
+		logger.V(4).Info("Deleting bootstrap namespace", "namespace", namespace)
+		if err = e.client.DeleteNamespace(ctx, cluster.KubeconfigFile, namespace); err != nil {
+			return err
+		}
+	}
+
+	if len(providers) > 0 {
+		logger.V(4).Info("Deleting Cluster API cluster scoped resources")
+		if err = e.client.Delete(ctx, capiClusterScopedResourceTypes, cluster.KubeconfigFile, &kubernetes.KubectlDeleteOptions{
+			HasLabels: map[string]string{clusterctlv1.ClusterctlLabel: ""},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if slices.Contains(createdNamespaces, constants.CertManagerNamespace) {
+		logger.V(4).Info("Deleting cert-manager cluster scoped resources")
+		if err = e.client.Delete(ctx, capiClusterScopedResourceTypes, cluster.KubeconfigFile, &kubernetes.KubectlDeleteOptions{
+			HasLabels: map[string]string{clusterctlv1.ClusterctlCoreLabel: clusterctlv1.ClusterctlCoreLabelCertManagerValue},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteEksaComponents deletes the cluster scoped objects the EKS Anywhere components installed: the CRDs
+// of its API group, its cluster roles and its webhook configurations. The rest is deleted with eksa-system.
+func (e *ExistingCluster) deleteEksaComponents(ctx context.Context, cluster *types.Cluster) error {
+	crds := &metav1.PartialObjectMetadataList{}
+	if err := e.client.Get(ctx, "customresourcedefinitions", cluster.KubeconfigFile, crds, &kubernetes.KubectlGetOptions{
+		ClusterScoped: ptr.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("getting CRDs: %v", err)
+	}
+
+	var objects []clusterScopedObject
+	for _, crd := range crds.Items {
+		// CRD names are the plural of the resource followed by its API group.
+		if strings.HasSuffix(crd.Name, "."+v1alpha1.GroupVersion.Group) {
+			objects = append(objects, clusterScopedObject{resourceType: "customresourcedefinitions", name: crd.Name})
+		}
+	}
+	objects = append(objects, eksaClusterScopedObjects...)
+
+	logger.V(4).Info("Deleting EKS Anywhere cluster scoped resources")
+	for _, o := range objects {
+		err := e.client.Get(ctx, o.resourceType, cluster.KubeconfigFile, &metav1.PartialObjectMetadata{}, &kubernetes.KubectlGetOptions{
+			Name:          o.name,
+			ClusterScoped: ptr.Bool(true),
+		})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("getting %s %s: %v", o.resourceType, o.name, err)
+		}
+
+		if err = e.client.DeleteClusterObject(ctx, o.resourceType, o.name, cluster.KubeconfigFile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,508 @@
+package bootstrapper_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper/mocks"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type existingClusterTest struct {
+	*WithT
+	ctx            context.Context
+	b              *bootstrapper.ExistingCluster
+	client         *mocks.MockExistingClusterClient
+	clusterSpec    *cluster.Spec
+	kubeconfigFile string
+}
+
+func newExistingClusterTest(t *testing.T) *existingClusterTest {
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockExistingClusterClient(ctrl)
+	dir, writer := test.NewWriter(t)
+	kubeconfig := filepath.Join(dir, "existing.kubeconfig")
+	if err := os.WriteFile(kubeconfig, []byte("kubeconfig content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return &existingClusterTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		b:      bootstrapper.NewExistingCluster(kubeconfig, client, writer),
+		client: client,
+		clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "cluster-name"
+		}),
+		kubeconfigFile: filepath.Join(dir, "cluster-name.bootstrap.kubeconfig"),
+	}
+}
+
+func (tt *existingClusterTest) cluster() *types.Cluster {
+	return &types.Cluster{
+		Name:           tt.clusterSpec.Cluster.Name,
+		KubeconfigFile: tt.kubeconfigFile,
+	}
+}
+
+func (tt *existingClusterTest) expectGetLease(owner, createdNamespaces string) {
+	tt.client.EXPECT().Get(tt.ctx, "configmap", tt.kubeconfigFile, &corev1.ConfigMap{}, &kubernetes.KubectlGetOptions{
+		Name:      bootstrapper.ExistingClusterLease,
+		Namespace: bootstrapper.ExistingClusterNamespace,
+	}).DoAndReturn(func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+		if owner == "" {
+			return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, bootstrapper.ExistingClusterLease)
+		}
+		obj.(*corev1.ConfigMap).Data = map[string]string{
+			"cluster":           owner,
+			"createdNamespaces": createdNamespaces,
+		}
+		return nil
+	})
+}
+
+func (tt *existingClusterTest) expectGetEksaNamespace(err error) {
+	tt.client.EXPECT().Get(tt.ctx, "namespace", tt.kubeconfigFile, &corev1.Namespace{}, gomock.Any()).Return(err)
+}
+
+func (tt *existingClusterTest) expectCRD(name string, exists bool) {
+	var err error
+	if !exists {
+		err = apierrors.NewNotFound(schema.GroupResource{Resource: "customresourcedefinition"}, name)
+	}
+	tt.client.EXPECT().Get(tt.ctx, "customresourcedefinition", tt.kubeconfigFile, &metav1.PartialObjectMetadata{}, &kubernetes.KubectlGetOptions{
+		Name:          name,
+		ClusterScoped: ptr.Bool(true),
+	}).Return(err)
+}
+
+func (tt *existingClusterTest) expectNoComponents() {
+	tt.expectCRD("providers.clusterctl.cluster.x-k8s.io", false)
+	tt.expectCRD("clusters.cluster.x-k8s.io", false)
+	tt.expectCRD("clusters.anywhere.eks.amazonaws.com", false)
+}
+
+// expectDeleteEksaComponents expects the EKS Anywhere cluster scoped objects in objs to be deleted,
+// along with the CRDs of the EKS Anywhere API group in crds.
+func (tt *existingClusterTest) expectDeleteEksaComponents(crds []string, objs ...*unstructured.Unstructured) {
+	tt.client.EXPECT().Get(tt.ctx, "customresourcedefinitions", tt.kubeconfigFile, &metav1.PartialObjectMetadataList{}, &kubernetes.KubectlGetOptions{
+		ClusterScoped: ptr.Bool(true),
+	}).DoAndReturn(func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+		for _, name := range crds {
+			obj.(*metav1.PartialObjectMetadataList).Items = append(obj.(*metav1.PartialObjectMetadataList).Items, metav1.PartialObjectMetadata{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+			})
+		}
+		return nil
+	})
+
+	installed := map[string]bool{}
+	for _, o := range objs {
+		resourceType := strings.ToLower(o.GetKind()) + "s"
+		installed[resourceType+"/"+o.GetName()] = true
+		if resourceType == "customresourcedefinitions" {
+			continue
+		}
+		tt.client.EXPECT().Get(tt.ctx, resourceType, tt.kubeconfigFile, &metav1.PartialObjectMetadata{}, &kubernetes.KubectlGetOptions{
+			Name:          o.GetName(),
+			ClusterScoped: ptr.Bool(true),
+		})
+		tt.client.EXPECT().DeleteClusterObject(tt.ctx, resourceType, o.GetName(), tt.kubeconfigFile)
+	}
+	for _, name := range crds {
+		if !strings.HasSuffix(name, ".anywhere.eks.amazonaws.com") {
+			continue
+		}
+		tt.client.EXPECT().Get(tt.ctx, "customresourcedefinitions", tt.kubeconfigFile, &metav1.PartialObjectMetadata{}, &kubernetes.KubectlGetOptions{
+			Name:          name,
+			ClusterScoped: ptr.Bool(true),
+		})
+		tt.client.EXPECT().DeleteClusterObject(tt.ctx, "customresourcedefinitions", name, tt.kubeconfigFile)
+	}
+
+	for _, o := range []string{
+		"clusterroles/eksa-manager-role",
+		"clusterrolebindings/eksa-manager-rolebinding",
+		"mutatingwebhookconfigurations/eksa-mutating-webhook-configuration",
+		"validatingwebhookconfigurations/eksa-validating-webhook-configuration",
+	} {
+		if installed[o] {
+			continue
+		}
+		resourceType, name, _ := strings.Cut(o, "/")
+		tt.client.EXPECT().Get(tt.ctx, resourceType, tt.kubeconfigFile, &metav1.PartialObjectMetadata{}, &kubernetes.KubectlGetOptions{
+			Name:          name,
+			ClusterScoped: ptr.Bool(true),
+		}).Return(apierrors.NewNotFound(schema.GroupResource{Resource: resourceType}, name))
+	}
+}
+
+func (tt *existingClusterTest) expectReleaseLease() {
+	tt.client.EXPECT().Delete(tt.ctx, "configmap", tt.kubeconfigFile, &kubernetes.KubectlDeleteOptions{
+		Name:      bootstrapper.ExistingClusterLease,
+		Namespace: bootstrapper.ExistingClusterNamespace,
+	})
+	tt.client.EXPECT().DeleteNamespace(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+}
+
+// eksaComponentsClusterScopedObjects returns the cluster scoped objects of the EKS Anywhere components manifest,
+// except for its namespace.
+func eksaComponentsClusterScopedObjects(t *testing.T) (crds []string, objs []*unstructured.Unstructured) {
+	f, err := os.Open("../../config/manifest/eksa-components.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	decoder := apiyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		o := &unstructured.Unstructured{}
+		if err := decoder.Decode(&o.Object); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if o.Object == nil || o.GetNamespace() != "" || o.GetKind() == "Namespace" {
+			continue
+		}
+		if o.GetKind() == "CustomResourceDefinition" {
+			crds = append(crds, o.GetName())
+		}
+		objs = append(objs, o)
+	}
+
+	return crds, objs
+}
+
+func (tt *existingClusterTest) expectGetCAPIProviders(providers ...clusterctlv1.Provider) {
+	tt.expectCRD("providers.clusterctl.cluster.x-k8s.io", true)
+	tt.client.EXPECT().Get(tt.ctx, "providers.clusterctl.cluster.x-k8s.io", tt.kubeconfigFile, &clusterctlv1.ProviderList{}).DoAndReturn(
+		func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+			obj.(*clusterctlv1.ProviderList).Items = providers
+			return nil
+		},
+	)
+}
+
+func capiProvider(name, version, namespace string) clusterctlv1.Provider {
+	return clusterctlv1.Provider{
+		ObjectMeta:   metav1.ObjectMeta{Name: name, Namespace: namespace},
+		ProviderName: name,
+		Version:      version,
+	}
+}
+
+func notFound() error {
+	return apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, constants.EksaSystemNamespace)
+}
+
+func TestExistingClusterCreateBootstrapClusterSuccess(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("", "")
+	tt.expectNoComponents()
+	tt.expectGetEksaNamespace(notFound())
+	tt.expectGetEksaNamespace(notFound())
+	tt.client.EXPECT().Create(tt.ctx, tt.kubeconfigFile, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, obj runtime.Object) error {
+		lease := obj.(*corev1.ConfigMap)
+		tt.Expect(lease.Name).To(Equal(bootstrapper.ExistingClusterLease))
+		tt.Expect(lease.Namespace).To(Equal(bootstrapper.ExistingClusterNamespace))
+		tt.Expect(lease.Data).To(Equal(map[string]string{
+			"cluster":           "cluster-name",
+			"createdNamespaces": "eksa-system cert-manager",
+		}))
+		return nil
+	})
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, constants.EksaSystemNamespace)
+
+	got, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec, bootstrapper.WithExtraDockerMounts())
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(got).To(Equal(tt.cluster()))
+	tt.Expect(os.ReadFile(tt.kubeconfigFile)).To(BeEquivalentTo("kubeconfig content"))
+}
+
+func TestExistingClusterCreateBootstrapClusterEksaNamespaceExists(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("", "")
+	tt.expectNoComponents()
+	tt.expectGetEksaNamespace(nil)
+	tt.expectGetEksaNamespace(nil)
+	tt.client.EXPECT().Create(tt.ctx, tt.kubeconfigFile, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, obj runtime.Object) error {
+		tt.Expect(obj.(*corev1.ConfigMap).Data).To(HaveKeyWithValue("createdNamespaces", ""))
+		return nil
+	})
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, constants.EksaSystemNamespace)
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestExistingClusterCreateBootstrapClusterResume(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("cluster-name", constants.EksaSystemNamespace)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, constants.EksaSystemNamespace)
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestExistingClusterCreateBootstrapClusterLeaseTaken(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("other-cluster", "")
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("existing cluster is bootstrapping cluster other-cluster")))
+}
+
+func TestExistingClusterCreateBootstrapClusterLeaseRace(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("", "")
+	tt.expectNoComponents()
+	tt.expectGetEksaNamespace(nil)
+	tt.expectGetEksaNamespace(nil)
+	tt.client.EXPECT().Create(tt.ctx, tt.kubeconfigFile, gomock.Any()).Return(
+		apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, bootstrapper.ExistingClusterLease),
+	)
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("existing cluster is bootstrapping another cluster")))
+}
+
+func TestExistingClusterCreateBootstrapClusterCAPIProvidersInstalled(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("", "")
+	tt.expectGetCAPIProviders(
+		capiProvider("cluster-api", "v1.8.3", "capi-system"),
+		capiProvider("vsphere", "v1.11.0", "capv-system"),
+	)
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError("creating bootstrap cluster: existing cluster already runs Cluster API providers " +
+		"(cluster-api v1.8.3 in namespace capi-system, vsphere v1.11.0 in namespace capv-system), use a cluster without Cluster API"))
+}
+
+func TestExistingClusterCreateBootstrapClusterCAPICRDsInstalled(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("", "")
+	tt.expectCRD("providers.clusterctl.cluster.x-k8s.io", false)
+	tt.expectCRD("clusters.cluster.x-k8s.io", true)
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("existing cluster already has the Cluster API CRDs (clusters.cluster.x-k8s.io)")))
+}
+
+func TestExistingClusterCreateBootstrapClusterEksaCRDsInstalled(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("", "")
+	tt.expectCRD("providers.clusterctl.cluster.x-k8s.io", false)
+	tt.expectCRD("clusters.cluster.x-k8s.io", false)
+	tt.expectCRD("clusters.anywhere.eks.amazonaws.com", true)
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError("creating bootstrap cluster: existing cluster already has the EKS Anywhere CRDs " +
+		"(clusters.anywhere.eks.amazonaws.com), use a cluster without EKS Anywhere"))
+}
+
+func TestExistingClusterCreateBootstrapClusterCAPIProvidersError(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace)
+	tt.expectGetLease("", "")
+	tt.client.EXPECT().Get(tt.ctx, "customresourcedefinition", tt.kubeconfigFile, gomock.Any(), gomock.Any()).Return(errors.New("forbidden"))
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError("creating bootstrap cluster: getting CRD providers.clusterctl.cluster.x-k8s.io: forbidden"))
+}
+
+func TestExistingClusterCreateBootstrapClusterMissingKubeconfig(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	_, writer := test.NewWriter(t)
+	b := bootstrapper.NewExistingCluster("missing.kubeconfig", tt.client, writer)
+
+	_, err := b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading existing bootstrap cluster kubeconfig")))
+}
+
+func TestExistingClusterCreateBootstrapClusterNamespaceError(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.client.EXPECT().CreateNamespaceIfNotPresent(tt.ctx, tt.kubeconfigFile, bootstrapper.ExistingClusterNamespace).Return(errors.New("forbidden"))
+
+	_, err := tt.b.CreateBootstrapCluster(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("forbidden")))
+}
+
+func TestExistingClusterBootstrapClusterExists(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.expectGetLease("cluster-name", "")
+
+	tt.Expect(tt.b.BootstrapClusterExists(tt.ctx, &types.Cluster{Name: "cluster-name"})).To(BeTrue())
+}
+
+func TestExistingClusterBootstrapClusterExistsLeaseReleased(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.expectGetLease("", "")
+
+	tt.Expect(tt.b.BootstrapClusterExists(tt.ctx, tt.cluster())).To(BeFalse())
+}
+
+func TestExistingClusterBootstrapClusterExistsOtherOwner(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.expectGetLease("other-cluster", "")
+
+	tt.Expect(tt.b.BootstrapClusterExists(tt.ctx, tt.cluster())).To(BeFalse())
+}
+
+func TestExistingClusterDeleteBootstrapClusterSuccess(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	c := tt.cluster()
+	tt.expectGetLease("cluster-name", "eksa-system cert-manager")
+	tt.client.EXPECT().ValidateClustersCRD(tt.ctx, c)
+	tt.client.EXPECT().GetClusters(tt.ctx, c).Return([]types.CAPICluster{
+		{Metadata: types.Metadata{Name: "other-cluster"}, Status: types.ClusterStatus{Phase: "Provisioned"}},
+	}, nil)
+	tt.expectGetCAPIProviders(
+		capiProvider("cluster-api", "v1.8.3", "capi-system"),
+		capiProvider("kubeadm", "v1.8.3", "capi-kubeadm-bootstrap-system"),
+		capiProvider("vsphere", "v1.11.0", "capv-system"),
+	)
+	for _, namespace := range []string{"eksa-system", "cert-manager", "capi-system", "capi-kubeadm-bootstrap-system", "capv-system"} {
+		tt.client.EXPECT().DeleteNamespace(tt.ctx, tt.kubeconfigFile, namespace)
+	}
+	tt.client.EXPECT().Delete(tt.ctx, "customresourcedefinitions,validatingwebhookconfigurations,mutatingwebhookconfigurations,clusterroles,clusterrolebindings", tt.kubeconfigFile, &kubernetes.KubectlDeleteOptions{
+		HasLabels: map[string]string{"clusterctl.cluster.x-k8s.io": ""},
+	})
+	tt.client.EXPECT().Delete(tt.ctx, "customresourcedefinitions,validatingwebhookconfigurations,mutatingwebhookconfigurations,clusterroles,clusterrolebindings", tt.kubeconfigFile, &kubernetes.KubectlDeleteOptions{
+		HasLabels: map[string]string{"clusterctl.cluster.x-k8s.io/core": "cert-manager"},
+	})
+	crds, objs := eksaComponentsClusterScopedObjects(t)
+	tt.Expect(objs).To(ContainElement(HaveField("Object", HaveKeyWithValue("kind", "ValidatingWebhookConfiguration"))))
+	tt.expectDeleteEksaComponents(append(crds, "widgets.example.com"), objs...)
+	tt.expectReleaseLease()
+
+	tt.Expect(tt.b.DeleteBootstrapCluster(tt.ctx, c, constants.Create, false)).To(Succeed())
+}
+
+func TestExistingClusterDeleteBootstrapClusterWritesKubeconfig(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	c := &types.Cluster{Name: "cluster-name"}
+	tt.expectGetLease("", "")
+
+	tt.Expect(tt.b.DeleteBootstrapCluster(tt.ctx, c, constants.Delete, false)).To(Succeed())
+	tt.Expect(c.KubeconfigFile).To(Equal(tt.kubeconfigFile))
+}
+
+func TestExistingClusterDeleteBootstrapClusterOtherOwner(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	tt.expectGetLease("other-cluster", constants.EksaSystemNamespace)
+
+	tt.Expect(tt.b.DeleteBootstrapCluster(tt.ctx, tt.cluster(), constants.Create, false)).To(Succeed())
+}
+
+func TestExistingClusterDeleteBootstrapClusterManagementInCluster(t *testing.T) {
+	tests := []struct {
+		name          string
+		phase         string
+		operationType constants.Operation
+		force         bool
+		wantErr       bool
+	}{
+		{name: "provisioned", phase: "Provisioned", operationType: constants.Create, wantErr: true},
+		{name: "upgrade", phase: "Provisioning", operationType: constants.Upgrade, wantErr: true},
+		{name: "force cleanup", phase: "Provisioned", operationType: constants.Create, force: true},
+		{name: "not provisioned", phase: "Provisioning", operationType: constants.Create},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newExistingClusterTest(t)
+			c := tt.cluster()
+			tt.expectGetLease("cluster-name", "")
+			tt.client.EXPECT().ValidateClustersCRD(tt.ctx, c)
+			tt.client.EXPECT().GetClusters(tt.ctx, c).Return([]types.CAPICluster{
+				{Metadata: types.Metadata{Name: "cluster-name"}, Status: types.ClusterStatus{Phase: tc.phase}},
+			}, nil)
+			if !tc.wantErr {
+				tt.expectCRD("providers.clusterctl.cluster.x-k8s.io", false)
+				tt.expectDeleteEksaComponents(nil)
+				tt.expectReleaseLease()
+			}
+
+			err := tt.b.DeleteBootstrapCluster(tt.ctx, c, tc.operationType, tc.force)
+			if tc.wantErr {
+				tt.Expect(err).To(MatchError("error deleting bootstrap cluster: management cluster in bootstrap cluster"))
+			} else {
+				tt.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestExistingClusterDeleteBootstrapClusterNoCAPI(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	c := tt.cluster()
+	tt.expectGetLease("cluster-name", "")
+	tt.client.EXPECT().ValidateClustersCRD(tt.ctx, c).Return(errors.New("crd not found"))
+	tt.expectCRD("providers.clusterctl.cluster.x-k8s.io", false)
+	tt.expectDeleteEksaComponents([]string{"clusters.anywhere.eks.amazonaws.com"})
+	tt.client.EXPECT().Delete(tt.ctx, "configmap", tt.kubeconfigFile, gomock.Any()).Return(errors.New("forbidden"))
+
+	tt.Expect(tt.b.DeleteBootstrapCluster(tt.ctx, c, constants.Delete, false)).To(MatchError(ContainSubstring("releasing lease: forbidden")))
+}
+
+func TestExistingClusterDeleteBootstrapClusterDeleteCAPIError(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	c := tt.cluster()
+	tt.expectGetLease("cluster-name", "")
+	tt.client.EXPECT().ValidateClustersCRD(tt.ctx, c).Return(errors.New("crd not found"))
+	tt.expectGetCAPIProviders(capiProvider("cluster-api", "v1.8.3", "capi-system"))
+	tt.client.EXPECT().DeleteNamespace(tt.ctx, tt.kubeconfigFile, "capi-system").Return(errors.New("forbidden"))
+
+	tt.Expect(tt.b.DeleteBootstrapCluster(tt.ctx, c, constants.Delete, false)).To(MatchError("deleting bootstrap cluster: forbidden"))
+}
+
+func TestExistingClusterDeleteBootstrapClusterDeleteEksaComponentsError(t *testing.T) {
+	tt := newExistingClusterTest(t)
+	c := tt.cluster()
+	tt.expectGetLease("cluster-name", "")
+	tt.client.EXPECT().ValidateClustersCRD(tt.ctx, c).Return(errors.New("crd not found"))
+	tt.expectCRD("providers.clusterctl.cluster.x-k8s.io", false)
+	tt.client.EXPECT().Get(tt.ctx, "customresourcedefinitions", tt.kubeconfigFile, &metav1.PartialObjectMetadataList{}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, _ string, obj runtime.Object, _ ...kubernetes.KubectlGetOption) error {
+			obj.(*metav1.PartialObjectMetadataList).Items = []metav1.PartialObjectMetadata{
+				{ObjectMeta: metav1.ObjectMeta{Name: "clusters.anywhere.eks.amazonaws.com"}},
+			}
+			return nil
+		},
+	)
+	tt.client.EXPECT().Get(tt.ctx, "customresourcedefinitions", tt.kubeconfigFile, &metav1.PartialObjectMetadata{}, gomock.Any())
+	tt.client.EXPECT().DeleteClusterObject(tt.ctx, "customresourcedefinitions", "clusters.anywhere.eks.amazonaws.com", tt.kubeconfigFile).Return(errors.New("forbidden"))
+
+	tt.Expect(tt.b.DeleteBootstrapCluster(tt.ctx, c, constants.Delete, false)).To(MatchError("deleting bootstrap cluster: forbidden"))
+}
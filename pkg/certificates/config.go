@@ -31,12 +31,29 @@ type NodeConfig struct {
 	SSH   SSHConfig `yaml:"ssh"`
 }
 
+// RenewalMode defines how the renewer accesses the control plane nodes.
+type RenewalMode string
+
+const (
+	// RenewalModeSSH runs the renewal commands over SSH.
+	RenewalModeSSH RenewalMode = "ssh"
+	// RenewalModePod runs the renewal commands in privileged pods scheduled on the control plane nodes.
+	// External etcd nodes are not part of the cluster, so they are still accessed over SSH.
+	RenewalModePod RenewalMode = "pod"
+)
+
 // RenewalConfig defines the configuration for certificate renewal operations.
 type RenewalConfig struct {
-	ClusterName  string     `yaml:"clusterName"`
-	OS           string     `yaml:"os"`
-	ControlPlane NodeConfig `yaml:"controlPlane"`
-	Etcd         NodeConfig `yaml:"etcd"`
+	ClusterName  string      `yaml:"clusterName"`
+	OS           string      `yaml:"os"`
+	Mode         RenewalMode `yaml:"mode"`
+	ControlPlane NodeConfig  `yaml:"controlPlane"`
+	Etcd         NodeConfig  `yaml:"etcd"`
+}
+
+// UsePods returns true if the control plane nodes are accessed through privileged pods.
+func (c *RenewalConfig) UsePods() bool {
+	return c.Mode == RenewalModePod
 }
 
 // ParseConfig reads and parses a certificate renewal configuration file.
@@ -76,7 +93,15 @@ func ValidateConfig(config *RenewalConfig, component string) error {
 		return fmt.Errorf("unsupported os %q", config.OS)
 	}
 
-	if err := validateNodeConfig(&config.ControlPlane); err != nil {
+	if config.Mode != "" && config.Mode != RenewalModeSSH && config.Mode != RenewalModePod {
+		return fmt.Errorf("unsupported mode %q, must be either %q or %q", config.Mode, RenewalModeSSH, RenewalModePod)
+	}
+
+	if config.UsePods() {
+		if len(config.ControlPlane.Nodes) == 0 {
+			return fmt.Errorf("validating control plane config: nodes list cannot be empty")
+		}
+	} else if err := validateNodeConfig(&config.ControlPlane); err != nil {
 		return fmt.Errorf("validating control plane config: %w", err)
 	}
 
@@ -110,6 +110,49 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "pod mode without ssh config",
+			config: &RenewalConfig{
+				ClusterName: "test-cluster",
+				OS:          "bottlerocket",
+				Mode:        RenewalModePod,
+				ControlPlane: NodeConfig{
+					Nodes: []string{"192.168.1.10"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "pod mode with external etcd missing ssh config",
+			config: &RenewalConfig{
+				ClusterName: "test-cluster",
+				OS:          "ubuntu",
+				Mode:        RenewalModePod,
+				ControlPlane: NodeConfig{
+					Nodes: []string{"192.168.1.10"},
+				},
+				Etcd: NodeConfig{
+					Nodes: []string{"192.168.1.20"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "unsupported mode",
+			config: &RenewalConfig{
+				ClusterName: "test-cluster",
+				OS:          "ubuntu",
+				Mode:        "telnet",
+				ControlPlane: NodeConfig{
+					Nodes: []string{"192.168.1.10"},
+					SSH: SSHConfig{
+						User:    "ec2-user",
+						KeyPath: keyFile,
+					},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
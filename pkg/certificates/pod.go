@@ -0,0 +1,268 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	renewerContainerName = "certificate-renewer"
+
+	defaultPodTimeout      = 10 * time.Minute
+	defaultPodPollInterval = 2 * time.Second
+	podDeleteTimeout       = time.Minute
+)
+
+// hostShims makes the commands built for SSH sessions run on the host from inside the pod:
+// "sudo" enters the host namespaces and "sudo sheltie" opens a root shell on Bottlerocket.
+// Stderr is redirected to stdout so the whole output is returned by the exec session.
+const hostShims = `exec 2>&1
+host() { nsenter --target 1 --mount --uts --ipc --net --pid -- "$@"; }
+sudo() { if [ "$1" = "sheltie" ]; then shift; host /bin/bash "$@"; else host "$@"; fi; }
+`
+
+// execScript reads the whole script from stdin before running it, so the commands run with
+// a closed stdin like they do over SSH instead of consuming the rest of the script.
+const execScript = `script="$(cat)" && exec sh -c "$script" </dev/null`
+
+// PodRunner implements SSHRunner by running each command in a privileged pod scheduled on the
+// node, the same way the in-place upgrader does. It allows renewing certificates on nodes
+// that can't be reached over SSH, but only works for nodes registered in the cluster, so it
+// can't be used for external etcd machines.
+// The pod only sleeps: commands are sent over the stdin of an exec session and their output is
+// read from it, so certificates and keys written by the commands are never stored in the pod
+// spec or the container logs.
+type PodRunner struct {
+	client       kubernetes.Client
+	exec         PodExecutor
+	kubeconfig   string
+	image        string
+	timeout      time.Duration
+	pollInterval time.Duration
+	nodeNames    map[string]string
+	podCount     int
+}
+
+// PodExecutor runs a command in a pod container, passing stdin to it, and returns its output.
+type PodExecutor interface {
+	ExecInPod(ctx context.Context, namespace, podName, containerName, kubeconfig string, stdin []byte, command ...string) (string, error)
+}
+
+// PodRunnerOpt allows to customize a PodRunner.
+type PodRunnerOpt func(*PodRunner)
+
+// WithPodTimeout sets how long to wait for each command to finish.
+func WithPodTimeout(timeout time.Duration) PodRunnerOpt {
+	return func(r *PodRunner) {
+		r.timeout = timeout
+	}
+}
+
+// WithPodPollInterval sets how often the pod status is checked.
+func WithPodPollInterval(interval time.Duration) PodRunnerOpt {
+	return func(r *PodRunner) {
+		r.pollInterval = interval
+	}
+}
+
+// NewPodRunner builds a PodRunner that creates its pods with the given image and runs the
+// commands in them using the given kubeconfig. The image needs to provide sh, cat, sleep and nsenter.
+func NewPodRunner(client kubernetes.Client, exec PodExecutor, kubeconfig, image string, opts ...PodRunnerOpt) *PodRunner {
+	r := &PodRunner{
+		client:       client,
+		exec:         exec,
+		kubeconfig:   kubeconfig,
+		image:        image,
+		timeout:      defaultPodTimeout,
+		pollInterval: defaultPodPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// RunCommand runs a command on the node with the given address and returns its output.
+func (r *PodRunner) RunCommand(ctx context.Context, node string, cmd string, opts ...SSHOption) (string, error) {
+	cfg := defaultSSHConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	nodeName, err := r.nodeName(ctx, node)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	r.podCount++
+	pod := renewerPod(fmt.Sprintf("%s-cert-renewer-%d", nodeName, r.podCount), nodeName, r.image, r.timeout)
+	if err := r.client.Create(ctx, pod); err != nil {
+		return "", fmt.Errorf("creating certificate renewer pod on node %s: %v", nodeName, err)
+	}
+	defer r.deletePod(pod)
+
+	if err := r.waitForPod(ctx, pod); err != nil {
+		return "", err
+	}
+
+	output, err := r.exec.ExecInPod(ctx, pod.Namespace, pod.Name, renewerContainerName, r.kubeconfig, []byte(hostShims+cmd), "sh", "-c", execScript)
+	output = strings.TrimSpace(output)
+	if cfg.displayLogs {
+		logger.V(6).Info(cmd)
+		logger.V(6).Info(output)
+	}
+	if err != nil {
+		return output, fmt.Errorf("executing command: %v, output: %s", err, output)
+	}
+
+	return output, nil
+}
+
+// waitForPod waits until the renewer container is running.
+func (r *PodRunner) waitForPod(ctx context.Context, pod *corev1.Pod) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		if err := r.client.Get(ctx, pod.Name, pod.Namespace, pod); err != nil {
+			// The API server might be briefly unavailable while certificates are renewed,
+			// so keep polling until the timeout.
+			lastErr = err
+			logger.V(4).Info("Failed getting certificate renewer pod, retrying", "pod", pod.Name, "error", err)
+		} else if runningContainer(pod) {
+			return nil
+		} else if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+			return fmt.Errorf("certificate renewer pod %s stopped before running the command: %s", pod.Name, pod.Status.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("timed out waiting for certificate renewer pod %s after %s: %v", pod.Name, r.timeout, lastErr)
+			}
+			return fmt.Errorf("timed out waiting for certificate renewer pod %s after %s", pod.Name, r.timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// deletePod uses its own context so the pod is removed even when the command timed out.
+func (r *PodRunner) deletePod(pod *corev1.Pod) {
+	ctx, cancel := context.WithTimeout(context.Background(), podDeleteTimeout)
+	defer cancel()
+
+	if err := r.client.Delete(ctx, pod); err != nil && !apierrors.IsNotFound(err) {
+		logger.V(4).Info("Failed deleting certificate renewer pod, please delete it manually", "pod", pod.Name, "error", err)
+	}
+}
+
+// nodeName returns the name of the Kubernetes node with the given address. The renewal
+// configuration identifies nodes by IP, but pods need the node name.
+func (r *PodRunner) nodeName(ctx context.Context, address string) (string, error) {
+	if r.nodeNames == nil {
+		nodes := &corev1.NodeList{}
+		if err := r.client.List(ctx, nodes); err != nil {
+			return "", fmt.Errorf("listing nodes: %v", err)
+		}
+
+		r.nodeNames = map[string]string{}
+		for _, n := range nodes.Items {
+			r.nodeNames[n.Name] = n.Name
+			for _, a := range n.Status.Addresses {
+				r.nodeNames[a.Address] = n.Name
+			}
+		}
+	}
+
+	name, ok := r.nodeNames[address]
+	if !ok {
+		return "", fmt.Errorf("node with address %s not found in the cluster", address)
+	}
+
+	return name, nil
+}
+
+func runningContainer(pod *corev1.Pod) bool {
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.Name == renewerContainerName && s.State.Running != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renewerPod returns a pod that keeps a privileged container running on the node for the
+// duration of a command. The container exits on its own after timeout in case the pod can't
+// be deleted.
+func renewerPod(name, nodeName, image string, timeout time.Duration) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"eksa-certificate-renewer": "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			HostPID:  true,
+			Containers: []corev1.Container{
+				{
+					Name:    renewerContainerName,
+					Image:   image,
+					Command: []string{"sleep", strconv.Itoa(int(timeout.Seconds()) + 1)},
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: ptr.Bool(true),
+					},
+				},
+			},
+			Tolerations: []corev1.Toleration{
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
+
+// UpgraderImage returns the image from the in-place upgrader config map that matches the
+// Kubernetes version of the cluster control plane, which can be used with NewPodRunner.
+func UpgraderImage(ctx context.Context, client kubernetes.Client, clusterName string) (string, error) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := client.Get(ctx, clusterName, constants.EksaSystemNamespace, kcp); err != nil {
+		return "", fmt.Errorf("getting kubeadm control plane for cluster %s: %v", clusterName, err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := client.Get(ctx, constants.UpgraderConfigMapName, constants.EksaSystemNamespace, cm); err != nil {
+		return "", fmt.Errorf("getting upgrader config map: %v", err)
+	}
+
+	image, ok := cm.Data[kcp.Spec.Version]
+	if !ok {
+		return "", fmt.Errorf("upgrader image for Kubernetes version %s not found in config map %s", kcp.Spec.Version, constants.UpgraderConfigMapName)
+	}
+
+	return image, nil
+}
@@ -0,0 +1,279 @@
+package certificates_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// runningClient simulates kubelet by marking the renewer container as running, or the pod
+// with the given phase, as soon as the pod is read. The first getErrors reads fail.
+type runningClient struct {
+	kubernetes.Client
+	phase     corev1.PodPhase
+	getErrors int
+	pods      []*corev1.Pod
+}
+
+// fakeExec returns the same output for every command and records the last one.
+type fakeExec struct {
+	output     string
+	err        error
+	kubeconfig string
+	stdin      string
+	command    []string
+}
+
+func (e *fakeExec) ExecInPod(_ context.Context, _, _, _, kubeconfig string, stdin []byte, command ...string) (string, error) {
+	e.kubeconfig = kubeconfig
+	e.stdin = string(stdin)
+	e.command = command
+	return e.output, e.err
+}
+
+func (c *runningClient) Create(ctx context.Context, obj kubernetes.Object) error {
+	if pod, ok := obj.(*corev1.Pod); ok {
+		c.pods = append(c.pods, pod.DeepCopy())
+	}
+	return c.Client.Create(ctx, obj)
+}
+
+func (c *runningClient) Get(ctx context.Context, name, namespace string, obj kubernetes.Object) error {
+	if _, ok := obj.(*corev1.Pod); ok && c.getErrors > 0 {
+		c.getErrors--
+		return errors.New("connection refused")
+	}
+
+	if err := c.Client.Get(ctx, name, namespace, obj); err != nil {
+		return err
+	}
+
+	if pod, ok := obj.(*corev1.Pod); ok {
+		if c.phase != "" {
+			pod.Status.Phase = c.phase
+			return nil
+		}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{
+				Name: "certificate-renewer",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			},
+		}
+	}
+
+	return nil
+}
+
+func controlPlaneNode() *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-cp-1"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "192.168.1.10"},
+			},
+		},
+	}
+}
+
+func TestPodRunnerRunCommandSuccess(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := &runningClient{
+		Client: test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build()),
+	}
+	exec := &fakeExec{output: "certificates renewed\n"}
+	runner := certificates.NewPodRunner(c, exec, "test.kubeconfig", "upgrader:v1", certificates.WithPodPollInterval(time.Millisecond))
+
+	output, err := runner.RunCommand(ctx, "192.168.1.10", "sudo kubeadm certs renew all")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(output).To(Equal("certificates renewed"))
+	g.Expect(exec.kubeconfig).To(Equal("test.kubeconfig"))
+	g.Expect(exec.stdin).To(HaveSuffix("sudo kubeadm certs renew all"))
+
+	g.Expect(c.pods).To(HaveLen(1))
+	pod := c.pods[0]
+	g.Expect(pod.Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(pod.Spec.NodeName).To(Equal("test-cluster-cp-1"))
+	g.Expect(pod.Spec.HostPID).To(BeTrue())
+	g.Expect(pod.Spec.Containers[0].Image).To(Equal("upgrader:v1"))
+	g.Expect(*pod.Spec.Containers[0].SecurityContext.Privileged).To(BeTrue())
+	g.Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"sleep", "601"}))
+
+	// The pod is cleaned up once the command finishes.
+	pods := &corev1.PodList{}
+	g.Expect(c.List(ctx, pods)).To(Succeed())
+	g.Expect(pods.Items).To(BeEmpty())
+}
+
+func TestPodRunnerRunCommandKeepsSecretsOutOfThePod(t *testing.T) {
+	g := NewWithT(t)
+	c := &runningClient{
+		Client: test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build()),
+	}
+	exec := &fakeExec{}
+	runner := certificates.NewPodRunner(c, exec, "test.kubeconfig", "upgrader:v1", certificates.WithPodPollInterval(time.Millisecond))
+	cmd := "sudo tee /tmp/apiserver-etcd-client.key > /dev/null << 'EOF'\nPRIVATE KEY\nEOF"
+
+	_, err := runner.RunCommand(context.Background(), "192.168.1.10", cmd)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exec.stdin).To(ContainSubstring("PRIVATE KEY"))
+	g.Expect(exec.command).ToNot(ContainElement(ContainSubstring("PRIVATE KEY")))
+	g.Expect(c.pods[0].String()).ToNot(ContainSubstring("PRIVATE KEY"))
+}
+
+func TestPodRunnerRunCommandNodeName(t *testing.T) {
+	g := NewWithT(t)
+	c := &runningClient{
+		Client: test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build()),
+	}
+	runner := certificates.NewPodRunner(c, &fakeExec{}, "test.kubeconfig", "upgrader:v1", certificates.WithPodPollInterval(time.Millisecond))
+
+	_, err := runner.RunCommand(context.Background(), "test-cluster-cp-1", "true")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.pods[0].Spec.NodeName).To(Equal("test-cluster-cp-1"))
+}
+
+func TestPodRunnerRunCommandFailure(t *testing.T) {
+	g := NewWithT(t)
+	c := &runningClient{
+		Client: test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build()),
+	}
+	exec := &fakeExec{output: "permission denied", err: errors.New("command terminated with exit code 1")}
+	runner := certificates.NewPodRunner(c, exec, "test.kubeconfig", "upgrader:v1", certificates.WithPodPollInterval(time.Millisecond))
+
+	output, err := runner.RunCommand(context.Background(), "192.168.1.10", "sudo kubeadm certs renew all")
+	g.Expect(err).To(MatchError(ContainSubstring("exit code 1")))
+	g.Expect(output).To(Equal("permission denied"))
+}
+
+func TestPodRunnerRunCommandNodeNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build())
+	runner := certificates.NewPodRunner(c, &fakeExec{}, "test.kubeconfig", "upgrader:v1")
+
+	_, err := runner.RunCommand(context.Background(), "192.168.1.20", "true")
+	g.Expect(err).To(MatchError(ContainSubstring("node with address 192.168.1.20 not found")))
+}
+
+func TestPodRunnerRunCommandTimeout(t *testing.T) {
+	g := NewWithT(t)
+	c := test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build())
+	runner := certificates.NewPodRunner(c, &fakeExec{}, "test.kubeconfig", "upgrader:v1",
+		certificates.WithPodTimeout(10*time.Millisecond),
+		certificates.WithPodPollInterval(time.Millisecond),
+	)
+
+	_, err := runner.RunCommand(context.Background(), "192.168.1.10", "true")
+	g.Expect(err).To(MatchError(ContainSubstring("timed out waiting for certificate renewer pod")))
+}
+
+func TestPodRunnerRunCommandRetriesTransientErrors(t *testing.T) {
+	g := NewWithT(t)
+	c := &runningClient{
+		Client:    test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build()),
+		getErrors: 3,
+	}
+	runner := certificates.NewPodRunner(c, &fakeExec{output: "done"}, "test.kubeconfig", "upgrader:v1", certificates.WithPodPollInterval(time.Millisecond))
+
+	output, err := runner.RunCommand(context.Background(), "192.168.1.10", "true")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(output).To(Equal("done"))
+}
+
+func TestPodRunnerRunCommandTimeoutAfterErrors(t *testing.T) {
+	g := NewWithT(t)
+	c := &runningClient{
+		Client:    test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build()),
+		getErrors: math.MaxInt,
+	}
+	runner := certificates.NewPodRunner(c, &fakeExec{}, "test.kubeconfig", "upgrader:v1",
+		certificates.WithPodTimeout(10*time.Millisecond),
+		certificates.WithPodPollInterval(time.Millisecond),
+	)
+
+	_, err := runner.RunCommand(context.Background(), "192.168.1.10", "true")
+	g.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
+
+func TestPodRunnerRunCommandPodFailed(t *testing.T) {
+	g := NewWithT(t)
+	c := &runningClient{
+		Client: test.NewKubeClient(fake.NewClientBuilder().WithObjects(controlPlaneNode()).Build()),
+		phase:  corev1.PodFailed,
+	}
+	runner := certificates.NewPodRunner(c, &fakeExec{}, "test.kubeconfig", "upgrader:v1", certificates.WithPodPollInterval(time.Millisecond))
+
+	_, err := runner.RunCommand(context.Background(), "192.168.1.10", "true")
+	g.Expect(err).To(MatchError(ContainSubstring("stopped before running the command")))
+}
+
+func upgraderConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.UpgraderConfigMapName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string]string{
+			"v1.29.9-eks-1-29-20": "upgrader:v1.29",
+			"v1.30.5-eks-1-30-15": "upgrader:v1.30",
+		},
+	}
+}
+
+func kubeadmControlPlane(version string) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: version,
+		},
+	}
+}
+
+func TestUpgraderImage(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient(upgraderConfigMap(), kubeadmControlPlane("v1.29.9-eks-1-29-20"))
+
+	image, err := certificates.UpgraderImage(context.Background(), client, "test-cluster")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(image).To(Equal("upgrader:v1.29"))
+}
+
+func TestUpgraderImageVersionNotFound(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient(upgraderConfigMap(), kubeadmControlPlane("v1.31.1-eks-1-31-5"))
+
+	_, err := certificates.UpgraderImage(context.Background(), client, "test-cluster")
+	g.Expect(err).To(MatchError(ContainSubstring("upgrader image for Kubernetes version v1.31.1-eks-1-31-5 not found")))
+}
+
+func TestUpgraderImageMissingControlPlane(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := certificates.UpgraderImage(context.Background(), test.NewFakeKubeClient(upgraderConfigMap()), "test-cluster")
+	g.Expect(err).To(MatchError(ContainSubstring("getting kubeadm control plane for cluster test-cluster")))
+}
+
+func TestUpgraderImageMissingConfigMap(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := certificates.UpgraderImage(context.Background(), test.NewFakeKubeClient(kubeadmControlPlane("v1.29.9-eks-1-29-20")), "test-cluster")
+	g.Expect(err).To(MatchError(ContainSubstring("getting upgrader config map")))
+}
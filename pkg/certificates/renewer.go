@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
@@ -28,10 +29,28 @@ type Renewer struct {
 	SSHEtcd         SSHRunner
 	SSHControlPlane SSHRunner
 	OS              OSRenewer
+	Tracker         *Tracker
+}
+
+// RenewerOpt allows to customize a Renewer.
+type RenewerOpt func(*Renewer)
+
+// WithControlPlaneRunner sets the runner used for the control plane nodes instead of SSH.
+func WithControlPlaneRunner(runner SSHRunner) RenewerOpt {
+	return func(r *Renewer) {
+		r.SSHControlPlane = runner
+	}
+}
+
+// WithTracker records the renewal progress with the given Tracker.
+func WithTracker(tracker *Tracker) RenewerOpt {
+	return func(r *Renewer) {
+		r.Tracker = tracker
+	}
 }
 
 // NewRenewer creates a new certificate renewer instance with a timestamped backup directory.
-func NewRenewer(kubectl kubernetes.Client, osType string, cfg *RenewalConfig) (*Renewer, error) {
+func NewRenewer(kubectl kubernetes.Client, osType string, cfg *RenewalConfig, opts ...RenewerOpt) (*Renewer, error) {
 	ts := time.Now().Format(backupDirTimeFormat)
 	backupDir := backupDirStr + ts
 
@@ -50,22 +69,38 @@ func NewRenewer(kubectl kubernetes.Client, osType string, cfg *RenewalConfig) (*
 		}
 	}
 
-	sshControlPlane, err := NewSSHRunner(cfg.ControlPlane.SSH)
-	if err != nil {
-		return nil, fmt.Errorf("building control plane ssh client: %v", err)
+	r := &Renewer{
+		BackupDir: backupDir,
+		Kubectl:   kubectl,
+		OS:        osRenewer,
+		SSHEtcd:   sshEtcd,
+	}
+
+	for _, opt := range opts {
+		opt(r)
 	}
 
-	return &Renewer{
-		BackupDir:       backupDir,
-		Kubectl:         kubectl,
-		OS:              osRenewer,
-		SSHEtcd:         sshEtcd,
-		SSHControlPlane: sshControlPlane,
-	}, nil
+	if r.SSHControlPlane == nil {
+		sshControlPlane, err := NewSSHRunner(cfg.ControlPlane.SSH)
+		if err != nil {
+			return nil, fmt.Errorf("building control plane ssh client: %v", err)
+		}
+		r.SSHControlPlane = sshControlPlane
+	}
+
+	return r, nil
 }
 
 // RenewCertificates orchestrates the certificate renewal process for the specified component.
 func (r *Renewer) RenewCertificates(ctx context.Context, cfg *RenewalConfig, component string) error {
+	r.Tracker.Start(ctx)
+	err := r.renewCertificates(ctx, cfg, component)
+	r.Tracker.Finish(ctx, err)
+
+	return err
+}
+
+func (r *Renewer) renewCertificates(ctx context.Context, cfg *RenewalConfig, component string) error {
 	processEtcd, processControlPlane, err := r.validateRenewalConfig(cfg, component)
 	if err != nil {
 		return err
@@ -96,7 +131,10 @@ func (r *Renewer) RenewCertificates(ctx context.Context, cfg *RenewalConfig, com
 
 func (r *Renewer) renewEtcdCerts(ctx context.Context, cfg *RenewalConfig) error {
 	for _, node := range cfg.Etcd.Nodes {
-		if err := r.OS.RenewEtcdCerts(ctx, node, r.SSHEtcd); err != nil {
+		r.Tracker.StepStarted(ctx, node, constants.EtcdComponent, v1alpha1.CertificatesRenewed)
+		err := r.OS.RenewEtcdCerts(ctx, node, r.SSHEtcd)
+		r.Tracker.StepFinished(ctx, node, constants.EtcdComponent, v1alpha1.CertificatesRenewed, err)
+		if err != nil {
 			return fmt.Errorf("renewing certificates for etcd node %s: %v", node, err)
 		}
 	}
@@ -107,7 +145,10 @@ func (r *Renewer) renewEtcdCerts(ctx context.Context, cfg *RenewalConfig) error
 
 func (r *Renewer) renewControlPlaneCerts(ctx context.Context, cfg *RenewalConfig, component string) error {
 	for _, node := range cfg.ControlPlane.Nodes {
-		if err := r.OS.RenewControlPlaneCerts(ctx, node, cfg, component, r.SSHControlPlane); err != nil {
+		r.Tracker.StepStarted(ctx, node, constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed)
+		err := r.OS.RenewControlPlaneCerts(ctx, node, cfg, component, r.SSHControlPlane)
+		r.Tracker.StepFinished(ctx, node, constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed, err)
+		if err != nil {
 			return fmt.Errorf("renewing certificates for control-plane node %s: %v", node, err)
 		}
 	}
@@ -186,7 +227,10 @@ func (r *Renewer) processEtcdCertificateTransfer(ctx context.Context, cfg *Renew
 	}
 
 	for _, node := range cfg.ControlPlane.Nodes {
-		if err := r.OS.TransferCertsToControlPlaneFromLocal(ctx, node, r.SSHControlPlane); err != nil {
+		r.Tracker.StepStarted(ctx, node, constants.ControlPlaneComponent, v1alpha1.EtcdClientCertificatesTransferred)
+		err := r.OS.TransferCertsToControlPlaneFromLocal(ctx, node, r.SSHControlPlane)
+		r.Tracker.StepFinished(ctx, node, constants.ControlPlaneComponent, v1alpha1.EtcdClientCertificatesTransferred, err)
+		if err != nil {
 			return fmt.Errorf("transferring certificates to control plane node: %v", err)
 		}
 	}
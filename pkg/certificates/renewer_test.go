@@ -8,13 +8,16 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/certificates"
 	"github.com/aws/eks-anywhere/pkg/certificates/mocks"
 	kubemocks "github.com/aws/eks-anywhere/pkg/clients/kubernetes/mocks"
+	"github.com/aws/eks-anywhere/pkg/constants"
 )
 
 const (
@@ -627,3 +630,66 @@ func TestNewRenewerControlPlaneSSHError(t *testing.T) {
 		t.Fatal("NewRenewer() expected error, got nil")
 	}
 }
+
+func TestNewRenewerWithControlPlaneRunner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	cfg := &certificates.RenewalConfig{
+		ClusterName: "test-cluster",
+		OS:          string(certificates.OSTypeLinux),
+		Mode:        certificates.RenewalModePod,
+		ControlPlane: certificates.NodeConfig{
+			Nodes: []string{"0.0.0.0"},
+		},
+	}
+	runner := mocks.NewMockSSHRunner(ctrl)
+
+	renewer, err := certificates.NewRenewer(kubemocks.NewMockClient(ctrl), cfg.OS, cfg, certificates.WithControlPlaneRunner(runner))
+	if err != nil {
+		t.Fatalf("NewRenewer() expected no error, got: %v", err)
+	}
+	if renewer.SSHControlPlane != runner {
+		t.Fatalf("NewRenewer() expected the control plane runner to be used")
+	}
+	t.Cleanup(func() { os.RemoveAll(renewer.BackupDir) })
+}
+
+func TestRenewControlPlaneCertsTracksFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	cfg := &certificates.RenewalConfig{
+		ClusterName: "test-cluster",
+		OS:          string(certificates.OSTypeLinux),
+		ControlPlane: certificates.NodeConfig{
+			Nodes: []string{"0.0.0.0"},
+		},
+	}
+
+	sshCP := mocks.NewMockSSHRunner(ctrl)
+	sshCP.EXPECT().
+		RunCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return("", fmt.Errorf("renew control plane error")).
+		AnyTimes()
+
+	kubeClient := newTrackerClient(t)
+	renewer := &certificates.Renewer{
+		BackupDir:       t.TempDir(),
+		Kubectl:         kubeClient,
+		OS:              certificates.BuildOSRenewer(cfg.OS, t.TempDir()),
+		SSHControlPlane: sshCP,
+		Tracker:         certificates.NewTracker(kubeClient, cfg.ClusterName, "", certificates.RenewalModeSSH),
+	}
+
+	if err := renewer.RenewCertificates(context.Background(), cfg, constants.ControlPlaneComponent); err == nil {
+		t.Fatalf("RenewCertificates() expected error, got nil")
+	}
+
+	g := NewWithT(t)
+	renewal := getRenewal(g, kubeClient)
+	g.Expect(renewal.Status.Nodes).To(HaveLen(1))
+	g.Expect(renewal.Status.Nodes[0].Conditions[0].Type).To(Equal(v1alpha1.CertificatesRenewed))
+	g.Expect(renewal.Status.Nodes[0].Conditions[0].Reason).To(Equal(v1alpha1.CertificateRenewalNodeFailedReason))
+	g.Expect(renewal.Status.FailureMessage).ToNot(BeNil())
+}
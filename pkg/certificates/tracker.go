@@ -0,0 +1,115 @@
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const trackerNameTimeFormat = "20060102150405"
+
+// Tracker records the progress of a certificate renewal in a CertificateRenewal object,
+// with one entry per node. Failing to write the object never fails the renewal, the errors
+// are only logged. A nil Tracker is valid and does nothing.
+type Tracker struct {
+	client  kubernetes.Client
+	renewal *v1alpha1.CertificateRenewal
+}
+
+// NewTracker builds a Tracker for a renewal of the given cluster.
+func NewTracker(client kubernetes.Client, clusterName, component string, mode RenewalMode) *Tracker {
+	return &Tracker{
+		client: client,
+		renewal: &v1alpha1.CertificateRenewal{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1alpha1.CertificateRenewalKind,
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", clusterName, time.Now().UTC().Format(trackerNameTimeFormat)),
+				Namespace: constants.EksaSystemNamespace,
+			},
+			Spec: v1alpha1.CertificateRenewalSpec{
+				ClusterName: clusterName,
+				Component:   component,
+				Mode:        string(mode),
+			},
+		},
+	}
+}
+
+// Start creates the CertificateRenewal object.
+func (t *Tracker) Start(ctx context.Context) {
+	if t == nil {
+		return
+	}
+
+	if err := t.client.Create(ctx, t.renewal); err != nil {
+		logger.V(4).Info("Failed creating CertificateRenewal object, renewal progress won't be recorded", "error", err)
+		t.client = nil
+		return
+	}
+
+	logger.V(4).Info("Recording renewal progress", "certificaterenewal", t.renewal.Name)
+}
+
+// StepStarted marks the step as in progress for the node.
+func (t *Tracker) StepStarted(ctx context.Context, node, role string, step v1alpha1.ConditionType) {
+	if t == nil {
+		return
+	}
+
+	t.renewal.Node(node, role).SetCondition(
+		conditions.FalseCondition(step, v1alpha1.CertificateRenewalNodeInProgressReason, clusterv1.ConditionSeverityInfo, ""),
+	)
+	t.update(ctx)
+}
+
+// StepFinished marks the step as done for the node, or as failed if err is not nil.
+func (t *Tracker) StepFinished(ctx context.Context, node, role string, step v1alpha1.ConditionType, err error) {
+	if t == nil {
+		return
+	}
+
+	condition := conditions.TrueCondition(step)
+	if err != nil {
+		condition = conditions.FalseCondition(step, v1alpha1.CertificateRenewalNodeFailedReason, clusterv1.ConditionSeverityError, "%s", err.Error())
+	}
+
+	t.renewal.Node(node, role).SetCondition(condition)
+	t.update(ctx)
+}
+
+// Finish marks the renewal as completed, or records the error that stopped it.
+func (t *Tracker) Finish(ctx context.Context, err error) {
+	if t == nil {
+		return
+	}
+
+	if err != nil {
+		msg := err.Error()
+		t.renewal.Status.FailureMessage = &msg
+	} else {
+		t.renewal.Status.Completed = true
+	}
+	t.update(ctx)
+}
+
+func (t *Tracker) update(ctx context.Context) {
+	if t.client == nil {
+		return
+	}
+
+	if err := t.client.Update(ctx, t.renewal); err != nil {
+		logger.V(4).Info("Failed updating CertificateRenewal object", "certificaterenewal", t.renewal.Name, "error", err)
+	}
+}
@@ -0,0 +1,85 @@
+package certificates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func newTrackerClient(t *testing.T) kubernetes.Client {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return test.NewKubeClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+}
+
+func getRenewal(g *WithT, c kubernetes.Client) *v1alpha1.CertificateRenewal {
+	renewals := &v1alpha1.CertificateRenewalList{}
+	g.Expect(c.List(context.Background(), renewals)).To(Succeed())
+	g.Expect(renewals.Items).To(HaveLen(1))
+	return &renewals.Items[0]
+}
+
+func TestTrackerRecordsProgress(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := newTrackerClient(t)
+	tracker := certificates.NewTracker(c, "test-cluster", "", certificates.RenewalModePod)
+
+	tracker.Start(ctx)
+	renewal := getRenewal(g, c)
+	g.Expect(renewal.Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(renewal.Name).To(HavePrefix("test-cluster-"))
+	g.Expect(renewal.Spec.Mode).To(Equal("pod"))
+
+	tracker.StepStarted(ctx, "192.168.1.10", constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed)
+	renewal = getRenewal(g, c)
+	g.Expect(renewal.Status.Nodes).To(HaveLen(1))
+	g.Expect(renewal.Status.Nodes[0].Role).To(Equal(constants.ControlPlaneComponent))
+	g.Expect(renewal.Status.Nodes[0].Conditions[0].Status).To(Equal(corev1.ConditionFalse))
+	g.Expect(renewal.Status.Nodes[0].Conditions[0].Reason).To(Equal(v1alpha1.CertificateRenewalNodeInProgressReason))
+
+	tracker.StepFinished(ctx, "192.168.1.10", constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed, nil)
+	tracker.StepStarted(ctx, "192.168.1.11", constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed)
+	tracker.StepFinished(ctx, "192.168.1.11", constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed, errors.New("exit code 1"))
+	tracker.Finish(ctx, errors.New("renewing certificates for control-plane node 192.168.1.11"))
+
+	renewal = getRenewal(g, c)
+	g.Expect(renewal.Status.Nodes).To(HaveLen(2))
+	g.Expect(renewal.Status.Nodes[0].Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(renewal.Status.Nodes[1].Conditions[0].Reason).To(Equal(v1alpha1.CertificateRenewalNodeFailedReason))
+	g.Expect(renewal.Status.Nodes[1].Conditions[0].Message).To(Equal("exit code 1"))
+	g.Expect(renewal.Status.Completed).To(BeFalse())
+	g.Expect(renewal.Status.FailureMessage).ToNot(BeNil())
+}
+
+func TestTrackerCreateErrorDoesNotFail(t *testing.T) {
+	ctx := context.Background()
+	tracker := certificates.NewTracker(test.NewFakeKubeClientAlwaysError(), "test-cluster", "", certificates.RenewalModeSSH)
+
+	tracker.Start(ctx)
+	tracker.StepStarted(ctx, "192.168.1.10", constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed)
+	tracker.Finish(ctx, nil)
+}
+
+func TestTrackerNil(t *testing.T) {
+	ctx := context.Background()
+	var tracker *certificates.Tracker
+
+	tracker.Start(ctx)
+	tracker.StepStarted(ctx, "192.168.1.10", constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed)
+	tracker.StepFinished(ctx, "192.168.1.10", constants.ControlPlaneComponent, v1alpha1.CertificatesRenewed, nil)
+	tracker.Finish(ctx, nil)
+}
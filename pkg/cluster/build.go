@@ -1,14 +1,14 @@
 package cluster
 
 // NewDefaultConfigClientBuilder returns a ConfigClientBuilder with the
-// default processors to build a Config.
+// default processors to build a Config, including the processors of the
+// providers registered with RegisterProviderEntry.
 func NewDefaultConfigClientBuilder() *ConfigClientBuilder {
-	return NewConfigClientBuilder().Register(
+	b := NewConfigClientBuilder().Register(
 		getCloudStackMachineConfigs,
 		getCloudStackDatacenter,
 		getTinkerbellMachineAndTemplateConfigs,
 		getTinkerbellDatacenter,
-		getDockerDatacenter,
 		getVSphereDatacenter,
 		getVSphereMachineConfigs,
 		getSnowDatacenter,
@@ -21,4 +21,10 @@ func NewDefaultConfigClientBuilder() *ConfigClientBuilder {
 		getGitOps,
 		getFluxConfig,
 	)
+
+	for _, p := range registeredProviderEntries() {
+		b.Register(p.ClientProcessors...)
+	}
+
+	return b
 }
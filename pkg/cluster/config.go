@@ -29,12 +29,34 @@ type Config struct {
 	FluxConfig                *anywherev1.FluxConfig
 	SnowCredentialsSecret     *v1.Secret
 	SnowIPPools               map[string]*anywherev1.SnowIPPool
+	VSphereIPPools            map[string]*anywherev1.VSphereIPPool
+	// ProviderObjects holds the API objects of the providers registered with RegisterProviderEntry
+	// that don't have a dedicated field.
+	ProviderObjects ObjectLookup
+}
+
+// AddProviderObject records an API object of a registered provider in the Config.
+func (c *Config) AddProviderObject(obj APIObject) {
+	if c.ProviderObjects == nil {
+		c.ProviderObjects = ObjectLookup{}
+	}
+	c.ProviderObjects.add(obj)
+}
+
+// ProviderObject returns the API object of a registered provider referenced by ref.
+func (c *Config) ProviderObject(apiVersion string, ref anywherev1.Ref) APIObject {
+	return c.ProviderObjects.GetFromRef(apiVersion, ref)
 }
 
 func (c *Config) VsphereMachineConfig(name string) *anywherev1.VSphereMachineConfig {
 	return c.VSphereMachineConfigs[name]
 }
 
+// VSphereIPPool returns a VSphereIPPool based on a name.
+func (c *Config) VSphereIPPool(name string) *anywherev1.VSphereIPPool {
+	return c.VSphereIPPools[name]
+}
+
 func (c *Config) CloudStackMachineConfig(name string) *anywherev1.CloudStackMachineConfig {
 	return c.CloudStackMachineConfigs[name]
 }
@@ -123,6 +145,13 @@ func (c *Config) DeepCopy() *Config {
 		c2.SnowIPPools[k] = v.DeepCopy()
 	}
 
+	if c.VSphereIPPools != nil {
+		c2.VSphereIPPools = make(map[string]*anywherev1.VSphereIPPool, len(c.VSphereIPPools))
+	}
+	for k, v := range c.VSphereIPPools {
+		c2.VSphereIPPools[k] = v.DeepCopy()
+	}
+
 	if c.TinkerbellMachineConfigs != nil {
 		c2.TinkerbellMachineConfigs = make(map[string]*anywherev1.TinkerbellMachineConfig, len(c.TinkerbellMachineConfigs))
 	}
@@ -137,6 +166,13 @@ func (c *Config) DeepCopy() *Config {
 		c2.TinkerbellTemplateConfigs[k] = v.DeepCopy()
 	}
 
+	if c.ProviderObjects != nil {
+		c2.ProviderObjects = make(ObjectLookup, len(c.ProviderObjects))
+	}
+	for k, v := range c.ProviderObjects {
+		c2.ProviderObjects[k] = v.DeepCopyObject().(APIObject)
+	}
+
 	return c2
 }
 
@@ -164,6 +200,10 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.VSphereIPPools {
+		objs = appendIfNotNil(objs, e)
+	}
+
 	for _, e := range c.CloudStackMachineConfigs {
 		objs = appendIfNotNil(objs, e)
 	}
@@ -196,6 +236,12 @@ func (c *Config) ChildObjects() []kubernetes.Object {
 		objs = appendIfNotNil(objs, e)
 	}
 
+	for _, e := range c.ProviderObjects {
+		if o, ok := e.(kubernetes.Object); ok {
+			objs = appendIfNotNil(objs, o)
+		}
+	}
+
 	return objs
 }
 
@@ -25,6 +25,9 @@ func TestConfigChildObjects(t *testing.T) {
 		SnowIPPools: map[string]*anywherev1.SnowIPPool{
 			"pool1": {}, "pool2": {},
 		},
+		VSphereIPPools: map[string]*anywherev1.VSphereIPPool{
+			"pool1": {}, "pool2": {},
+		},
 		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
 			"machine1": {}, "machine2": {},
 		},
@@ -50,7 +53,7 @@ func TestConfigChildObjects(t *testing.T) {
 	}
 
 	objs := config.ChildObjects()
-	g.Expect(objs).To(HaveLen(22))
+	g.Expect(objs).To(HaveLen(24))
 	for _, o := range objs {
 		g.Expect(reflect.ValueOf(o).IsNil()).To(BeFalse())
 	}
@@ -71,6 +74,9 @@ func TestConfigClusterAndChildren(t *testing.T) {
 		SnowIPPools: map[string]*anywherev1.SnowIPPool{
 			"pool1": {}, "pool2": {},
 		},
+		VSphereIPPools: map[string]*anywherev1.VSphereIPPool{
+			"pool1": {}, "pool2": {},
+		},
 		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
 			"machine1": {}, "machine2": {},
 		},
@@ -96,7 +102,7 @@ func TestConfigClusterAndChildren(t *testing.T) {
 	}
 
 	objs := config.ClusterAndChildren()
-	g.Expect(objs).To(HaveLen(23))
+	g.Expect(objs).To(HaveLen(25))
 	for _, o := range objs {
 		g.Expect(reflect.ValueOf(o).IsNil()).To(BeFalse())
 	}
@@ -119,6 +125,9 @@ func TestConfigDeepCopy(t *testing.T) {
 		SnowIPPools: map[string]*anywherev1.SnowIPPool{
 			"pool1": {}, "pool2": {},
 		},
+		VSphereIPPools: map[string]*anywherev1.VSphereIPPool{
+			"pool1": {}, "pool2": {},
+		},
 		VSphereMachineConfigs: map[string]*anywherev1.VSphereMachineConfig{
 			"machine1": {}, "machine2": {},
 		},
@@ -11,10 +11,18 @@ func init() {
 }
 
 func manager() *ConfigManager {
+	providerEntriesMu.RLock()
+	defer providerEntriesMu.RUnlock()
 	return defaultManager
 }
 
+// NewDefaultConfigManager builds a ConfigManager with the built-in configuration and the
+// configuration of the providers registered with RegisterProviderEntry.
 func NewDefaultConfigManager() (*ConfigManager, error) {
+	return newDefaultConfigManager(registeredProviderEntries())
+}
+
+func newDefaultConfigManager(providers []*ProviderEntry) (*ConfigManager, error) {
 	m := NewConfigManager()
 	err := m.Register(
 		clusterEntry(),
@@ -24,7 +32,6 @@ func NewDefaultConfigManager() (*ConfigManager, error) {
 		fluxEntry(),
 		vsphereEntry(),
 		cloudstackEntry(),
-		dockerEntry(),
 		snowEntry(),
 		tinkerbellEntry(),
 		nutanixEntry(),
@@ -33,5 +40,14 @@ func NewDefaultConfigManager() (*ConfigManager, error) {
 		return nil, err
 	}
 
+	for _, p := range providers {
+		if p.ConfigManagerEntry == nil {
+			continue
+		}
+		if err := m.Register(p.ConfigManagerEntry); err != nil {
+			return nil, err
+		}
+	}
+
 	return m, nil
 }
@@ -8,6 +8,15 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 )
 
+func init() {
+	if err := RegisterProviderEntry(&ProviderEntry{
+		ConfigManagerEntry: dockerEntry(),
+		ClientProcessors:   []ConfigClientProcessor{getDockerDatacenter},
+	}); err != nil {
+		panic(err)
+	}
+}
+
 func dockerEntry() *ConfigManagerEntry {
 	return &ConfigManagerEntry{
 		APIObjectMapping: map[string]APIObjectGenerator{
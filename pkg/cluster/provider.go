@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ProviderEntry declares the cluster Config of a provider: how its API objects are parsed,
+// defaulted and validated, and how they are retrieved from the API server.
+type ProviderEntry struct {
+	// ConfigManagerEntry is registered in the ConfigManager returned by NewDefaultConfigManager.
+	ConfigManagerEntry *ConfigManagerEntry
+	// ClientProcessors are registered in the ConfigClientBuilder returned by NewDefaultConfigClientBuilder.
+	ClientProcessors []ConfigClientProcessor
+}
+
+var (
+	providerEntriesMu sync.RWMutex
+	providerEntries   []*ProviderEntry
+)
+
+// RegisterProviderEntry adds the configuration of a provider to the default ConfigManager and
+// ConfigClientBuilder. It's meant to be called from the init function of the provider package.
+func RegisterProviderEntry(entry *ProviderEntry) error {
+	providerEntriesMu.Lock()
+	defer providerEntriesMu.Unlock()
+
+	entries := append(append([]*ProviderEntry{}, providerEntries...), entry)
+	m, err := newDefaultConfigManager(entries)
+	if err != nil {
+		return fmt.Errorf("registering provider config: %v", err)
+	}
+
+	providerEntries = entries
+	defaultManager = m
+
+	return nil
+}
+
+func registeredProviderEntries() []*ProviderEntry {
+	providerEntriesMu.RLock()
+	defer providerEntriesMu.RUnlock()
+	return providerEntries
+}
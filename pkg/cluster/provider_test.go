@@ -0,0 +1,83 @@
+package cluster_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+const testProviderDatacenterKind = "TestProviderDatacenterConfig"
+
+var testProviderClusterConfig = []byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  datacenterRef:
+    kind: TestProviderDatacenterConfig
+    name: my-datacenter
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: TestProviderDatacenterConfig
+metadata:
+  name: my-datacenter
+`)
+
+func testProviderEntry() *cluster.ProviderEntry {
+	return &cluster.ProviderEntry{
+		ConfigManagerEntry: &cluster.ConfigManagerEntry{
+			APIObjectMapping: map[string]cluster.APIObjectGenerator{
+				testProviderDatacenterKind: func() cluster.APIObject {
+					return &anywherev1.DockerDatacenterConfig{}
+				},
+			},
+			Processors: []cluster.ParsedProcessor{
+				func(c *cluster.Config, o cluster.ObjectLookup) {
+					if c.Cluster.Spec.DatacenterRef.Kind != testProviderDatacenterKind {
+						return
+					}
+					if datacenter := o.GetFromRef(c.Cluster.APIVersion, c.Cluster.Spec.DatacenterRef); datacenter != nil {
+						c.AddProviderObject(datacenter)
+					}
+				},
+			},
+		},
+		ClientProcessors: []cluster.ConfigClientProcessor{
+			func(_ context.Context, _ cluster.Client, c *cluster.Config) error {
+				if c.Cluster.Spec.DatacenterRef.Kind != testProviderDatacenterKind {
+					return nil
+				}
+				datacenter := &anywherev1.DockerDatacenterConfig{}
+				datacenter.SetGroupVersionKind(anywherev1.GroupVersion.WithKind(testProviderDatacenterKind))
+				datacenter.Name = c.Cluster.Spec.DatacenterRef.Name
+				c.AddProviderObject(datacenter)
+				return nil
+			},
+		},
+	}
+}
+
+func TestRegisterProviderEntry(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(cluster.RegisterProviderEntry(testProviderEntry())).To(Succeed())
+
+	config, err := cluster.ParseConfig(testProviderClusterConfig)
+	g.Expect(err).NotTo(HaveOccurred())
+	datacenter := config.ProviderObject(config.Cluster.APIVersion, config.Cluster.Spec.DatacenterRef)
+	g.Expect(datacenter).NotTo(BeNil())
+	g.Expect(datacenter.GetName()).To(Equal("my-datacenter"))
+	g.Expect(config.ChildObjects()).To(ConsistOf(datacenter))
+	g.Expect(config.DeepCopy().ProviderObjects).To(Equal(config.ProviderObjects))
+
+	built, err := cluster.NewDefaultConfigClientBuilder().Build(context.Background(), nil, config.Cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(built.ProviderObject(config.Cluster.APIVersion, config.Cluster.Spec.DatacenterRef)).NotTo(BeNil())
+
+	g.Expect(cluster.RegisterProviderEntry(testProviderEntry())).To(
+		MatchError("registering provider config: mapping for api object TestProviderDatacenterConfig already registered"),
+	)
+}
@@ -17,10 +17,14 @@ func vsphereEntry() *ConfigManagerEntry {
 			anywherev1.VSphereMachineConfigKind: func() APIObject {
 				return &anywherev1.VSphereMachineConfig{}
 			},
+			anywherev1.VSphereIPPoolKind: func() APIObject {
+				return &anywherev1.VSphereIPPool{}
+			},
 		},
 		Processors: []ParsedProcessor{
 			processVSphereDatacenter,
 			machineConfigsProcessor(processVSphereMachineConfig),
+			vsphereIPPoolsProcessor,
 		},
 		Defaulters: []Defaulter{
 			func(c *Config) error {
@@ -76,6 +80,20 @@ func vsphereEntry() *ConfigManagerEntry {
 				}
 				return nil
 			},
+			func(c *Config) error {
+				for _, p := range c.VSphereIPPools {
+					if err := p.Validate(); err != nil {
+						return err
+					}
+					// The IPAddressClaims CAPV creates in eksa-system are resolved to the
+					// pool in the namespace of their cluster by the VSphereIPPool controller.
+					if err := validateSameNamespace(c, p); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			validateVSphereIPPoolRefsExist,
 		},
 	}
 }
@@ -110,6 +128,42 @@ func processVSphereMachineConfig(c *Config, objects ObjectLookup, machineRef *an
 	c.VSphereMachineConfigs[m.GetName()] = m.(*anywherev1.VSphereMachineConfig)
 }
 
+func vsphereIPPoolsProcessor(c *Config, objects ObjectLookup) {
+	for _, m := range c.VSphereMachineConfigs {
+		for _, ref := range m.IPPoolRefs() {
+			if !ref.IsVSphereIPPool() {
+				continue
+			}
+
+			p := objects.GetFromRef(c.Cluster.APIVersion, anywherev1.Ref{Kind: anywherev1.VSphereIPPoolKind, Name: ref.Name})
+			if p == nil {
+				continue
+			}
+
+			if c.VSphereIPPools == nil {
+				c.VSphereIPPools = map[string]*anywherev1.VSphereIPPool{}
+			}
+			c.VSphereIPPools[p.GetName()] = p.(*anywherev1.VSphereIPPool)
+		}
+	}
+}
+
+// validateVSphereIPPoolRefsExist checks the VSphereIPPools referenced by the machine configs
+// are part of the config, other kind of pools are managed outside EKS Anywhere.
+func validateVSphereIPPoolRefsExist(c *Config) error {
+	for _, m := range c.VSphereMachineConfigs {
+		for _, ref := range m.IPPoolRefs() {
+			if !ref.IsVSphereIPPool() {
+				continue
+			}
+			if _, ok := c.VSphereIPPools[ref.Name]; !ok {
+				return fmt.Errorf("VSphereIPPool %s referenced by VSphereMachineConfig %s not found", ref.Name, m.Name)
+			}
+		}
+	}
+	return nil
+}
+
 func getVSphereDatacenter(ctx context.Context, client Client, c *Config) error {
 	if c.Cluster.Spec.DatacenterRef.Kind != anywherev1.VSphereDatacenterKind {
 		return nil
@@ -144,6 +198,34 @@ func getVSphereMachineConfigs(ctx context.Context, client Client, c *Config) err
 		}
 
 		c.VSphereMachineConfigs[machine.Name] = machine
+
+		if err := getVSphereIPPools(ctx, client, c, machine); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getVSphereIPPools(ctx context.Context, client Client, c *Config, machine *anywherev1.VSphereMachineConfig) error {
+	for _, ref := range machine.IPPoolRefs() {
+		if !ref.IsVSphereIPPool() {
+			continue
+		}
+
+		if _, ok := c.VSphereIPPools[ref.Name]; ok {
+			continue
+		}
+
+		pool := &anywherev1.VSphereIPPool{}
+		if err := client.Get(ctx, ref.Name, c.Cluster.Namespace, pool); err != nil {
+			return err
+		}
+
+		if c.VSphereIPPools == nil {
+			c.VSphereIPPools = map[string]*anywherev1.VSphereIPPool{}
+		}
+		c.VSphereIPPools[pool.Name] = pool
 	}
 
 	return nil
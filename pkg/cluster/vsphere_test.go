@@ -43,6 +43,42 @@ func TestValidateVsphereMachineConfigNotFoundError(t *testing.T) {
 	g.Expect(err).To(MatchError(ContainSubstring("VSphereMachineConfig dummy-machine-config not found")))
 }
 
+func TestParseConfigVSphereIPPool(t *testing.T) {
+	g := NewWithT(t)
+	got, err := cluster.ParseConfigFromFile("testdata/cluster_vsphere_ippool.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(got.VSphereIPPools).To(HaveLen(1))
+	pool := got.VSphereIPPool("eksa-unit-test-pool")
+	g.Expect(pool).NotTo(BeNil())
+	g.Expect(pool.Spec.Gateway).To(Equal("10.0.0.1"))
+
+	cm, _ := cluster.NewDefaultConfigManager()
+	g.Expect(cm.SetDefaults(got)).To(Succeed())
+	g.Expect(cm.Validate(got)).To(Succeed())
+}
+
+func TestValidateVSphereIPPoolNotFoundError(t *testing.T) {
+	g := NewWithT(t)
+	got, _ := cluster.ParseConfigFromFile("testdata/cluster_vsphere_ippool.yaml")
+	got.VsphereMachineConfig("eksa-unit-test").Spec.IPPool.Name = "dummy-pool"
+
+	cm, _ := cluster.NewDefaultConfigManager()
+	g.Expect(cm.SetDefaults(got)).To(Succeed())
+	err := cm.Validate(got)
+	g.Expect(err).To(MatchError(ContainSubstring("VSphereIPPool dummy-pool referenced by VSphereMachineConfig eksa-unit-test not found")))
+}
+
+func TestValidateVSphereIPPoolInvalid(t *testing.T) {
+	g := NewWithT(t)
+	got, _ := cluster.ParseConfigFromFile("testdata/cluster_vsphere_ippool.yaml")
+	got.VSphereIPPool("eksa-unit-test-pool").Spec.Gateway = ""
+
+	cm, _ := cluster.NewDefaultConfigManager()
+	err := cm.Validate(got)
+	g.Expect(err).To(MatchError(ContainSubstring("VSphereIPPool eksa-unit-test-pool gateway can not be empty")))
+}
+
 func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -135,3 +171,84 @@ func TestDefaultConfigClientBuilderVSphereCluster(t *testing.T) {
 	g.Expect(config.VSphereMachineConfigs["machine-1"]).To(Equal(machineControlPlane))
 	g.Expect(config.VSphereMachineConfigs["machine-2"]).To(Equal(machineWorker))
 }
+
+func TestDefaultConfigClientBuilderVSphereClusterIPPool(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b := cluster.NewDefaultConfigClientBuilder()
+	ctrl := gomock.NewController(t)
+	client := mocks.NewMockClient(ctrl)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterSpec{
+			DatacenterRef: anywherev1.Ref{
+				Kind: anywherev1.VSphereDatacenterKind,
+				Name: "datacenter",
+			},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				MachineGroupRef: &anywherev1.Ref{
+					Kind: anywherev1.VSphereMachineConfigKind,
+					Name: "machine-1",
+				},
+			},
+		},
+	}
+	machine := &anywherev1.VSphereMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+		},
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			IPPool: &anywherev1.VSphereIPPoolReference{
+				Name: "pool",
+			},
+			AdditionalNetworks: []anywherev1.VSphereMachineNetwork{
+				{
+					Name: "storage",
+					IPPool: &anywherev1.VSphereIPPoolReference{
+						APIGroup: "ipam.cluster.x-k8s.io",
+						Kind:     "InClusterIPPool",
+						Name:     "external-pool", // Should not be fetched
+					},
+				},
+			},
+		},
+	}
+	pool := &anywherev1.VSphereIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool",
+			Namespace: "default",
+		},
+		Spec: anywherev1.VSphereIPPoolSpec{
+			Addresses: []string{"10.0.0.10-10.0.0.20"},
+			Prefix:    24,
+			Gateway:   "10.0.0.1",
+		},
+	}
+
+	client.EXPECT().Get(ctx, "datacenter", "default", &anywherev1.VSphereDatacenterConfig{}).Return(nil)
+	client.EXPECT().Get(ctx, "machine-1", "default", &anywherev1.VSphereMachineConfig{}).Return(nil).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			m := obj.(*anywherev1.VSphereMachineConfig)
+			m.ObjectMeta = machine.ObjectMeta
+			m.Spec = machine.Spec
+			return nil
+		},
+	)
+	client.EXPECT().Get(ctx, "pool", "default", &anywherev1.VSphereIPPool{}).Return(nil).DoAndReturn(
+		func(ctx context.Context, name, namespace string, obj runtime.Object) error {
+			p := obj.(*anywherev1.VSphereIPPool)
+			p.ObjectMeta = pool.ObjectMeta
+			p.Spec = pool.Spec
+			return nil
+		},
+	)
+
+	config, err := b.Build(ctx, client, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.VSphereIPPools).To(HaveLen(1))
+	g.Expect(config.VSphereIPPool("pool")).To(Equal(pool))
+}
@@ -3,6 +3,7 @@ package clusterapi
 import (
 	_ "embed"
 	"fmt"
+	"sort"
 
 	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
@@ -68,10 +69,37 @@ func setRegistryMirrorInEtcdCluster(etcd *etcdv1.EtcdadmCluster, mirrorConfig *v
 }
 
 func registryMirror(mirrorConfig *v1alpha1.RegistryMirrorConfiguration) bootstrapv1.RegistryMirrorConfiguration {
+	registryMirror := registrymirror.FromClusterRegistryMirrorConfiguration(mirrorConfig)
+	if len(registryMirror.Fallbacks) == 0 {
+		return bootstrapv1.RegistryMirrorConfiguration{
+			Endpoint: containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror()),
+			CACert:   mirrorConfig.CACertContent,
+		}
+	}
+
+	// Bottlerocket tries the endpoints of a mirrored registry in order.
 	return bootstrapv1.RegistryMirrorConfiguration{
-		Endpoint: containerd.ToAPIEndpoint(registrymirror.FromClusterRegistryMirrorConfiguration(mirrorConfig).CoreEKSAMirror()),
-		CACert:   mirrorConfig.CACertContent,
+		CACert:  mirrorConfig.CACertContent,
+		Mirrors: bottlerocketMirrors(registryMirror),
+	}
+}
+
+func bottlerocketMirrors(registryMirror *registrymirror.RegistryMirror) []bootstrapv1.Mirror {
+	endpoints := containerd.MirrorEndpoints(registryMirror)
+	upstreams := make([]string, 0, len(endpoints))
+	for upstream := range endpoints {
+		upstreams = append(upstreams, upstream)
 	}
+	sort.Strings(upstreams)
+
+	mirrors := make([]bootstrapv1.Mirror, 0, len(upstreams))
+	for _, upstream := range upstreams {
+		mirrors = append(mirrors, bootstrapv1.Mirror{
+			Registry:  upstream,
+			Endpoints: endpoints[upstream],
+		})
+	}
+	return mirrors
 }
 
 type values map[string]interface{}
@@ -91,8 +119,41 @@ func registryMirrorConfigContent(registryMirror *registrymirror.RegistryMirror)
 	return string(config), nil
 }
 
+// containerdHostsConfig makes containerd read the registry host configurations, which list the
+// registry mirrors to try in order for each mirrored registry.
+var containerdHostsConfig = fmt.Sprintf(`[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "%s"
+`, containerd.HostsDir)
+
+// registryMirrorHostsConfig configures the registry mirror and its fallbacks with containerd host configurations.
+func registryMirrorHostsConfig(registryMirror *registrymirror.RegistryMirror) ([]bootstrapv1.File, error) {
+	credentials, err := containerd.ReadCredentials(registryMirror)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []bootstrapv1.File{
+		{
+			Path:    "/etc/containerd/config_append.toml",
+			Owner:   "root:root",
+			Content: containerdHostsConfig,
+		},
+	}
+	for _, f := range containerd.HostsFiles(registryMirror, credentials) {
+		files = append(files, bootstrapv1.File{
+			Path:    f.Path,
+			Owner:   "root:root",
+			Content: f.Content,
+		})
+	}
+	return files, nil
+}
+
 func registryMirrorConfig(registryMirrorConfig *v1alpha1.RegistryMirrorConfiguration) (files []bootstrapv1.File, err error) {
 	registryMirror := registrymirror.FromClusterRegistryMirrorConfiguration(registryMirrorConfig)
+	if len(registryMirror.Fallbacks) > 0 {
+		return registryMirrorHostsConfig(registryMirror)
+	}
 	registryConfig, err := registryMirrorConfigContent(registryMirror)
 	if err != nil {
 		return nil, err
@@ -126,6 +126,65 @@ var registryMirrorTests = []struct {
 			CACert:   "xyz",
 		},
 	},
+	{
+		name: "with fallback mirrors",
+		registryMirrorConfig: &v1alpha1.RegistryMirrorConfiguration{
+			Endpoint:      "1.2.3.4",
+			Port:          "443",
+			CACertContent: "xyz",
+			OCINamespaces: []v1alpha1.OCINamespace{
+				{
+					Registry:  "public.ecr.aws",
+					Namespace: "eks-anywhere",
+				},
+			},
+			Mirrors: []v1alpha1.RegistryMirrorEndpoint{
+				{
+					Endpoint: "1.2.3.5",
+					Port:     "443",
+				},
+			},
+		},
+		wantFiles: []bootstrapv1.File{
+			{
+				Path:  "/etc/containerd/config_append.toml",
+				Owner: "root:root",
+				Content: `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "/etc/containerd/certs.d"
+`,
+			},
+			{
+				Path:  "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+				Owner: "root:root",
+				Content: `[host."https://1.2.3.4:443/v2/eks-anywhere"]
+  capabilities = ["pull", "resolve"]
+  override_path = true
+  ca = "/etc/containerd/certs.d/1.2.3.4:443/ca.crt"
+
+[host."https://1.2.3.5:443"]
+  capabilities = ["pull", "resolve"]
+`,
+			},
+			{
+				Path:    "/etc/containerd/certs.d/1.2.3.4:443/ca.crt",
+				Owner:   "root:root",
+				Content: "xyz",
+			},
+		},
+		wantRegistryConfig: bootstrapv1.RegistryMirrorConfiguration{
+			CACert: "xyz",
+			Mirrors: []bootstrapv1.Mirror{
+				{
+					Registry:  "public.ecr.aws",
+					Endpoints: []string{"1.2.3.4:443/v2/eks-anywhere", "1.2.3.5:443"},
+				},
+			},
+		},
+		wantRegistryConfigEtcd: &etcdbootstrapv1.RegistryMirrorConfiguration{
+			Endpoint: "1.2.3.4:443/v2/eks-anywhere",
+			CACert:   "xyz",
+		},
+	},
 }
 
 func TestSetRegistryMirrorInKubeadmControlPlaneBottleRocket(t *testing.T) {
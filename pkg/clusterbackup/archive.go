@@ -0,0 +1,74 @@
+package clusterbackup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// FormatVersion is the version of the archive layout written by Backuper. Restorer refuses
+// archives with a newer version, since it can't know how to read them.
+const FormatVersion = 1
+
+// Archive layout, relative to the root of the archive:
+//
+//	metadata.yaml      Metadata describing the backup.
+//	eksa/<Kind>.yaml   EKS Anywhere objects and Tinkerbell Hardware, one file per kind.
+//	capi/              CAPI objects, as written by clusterctl move --to-directory.
+//
+// The archive only holds what Restorer can use to move the workload clusters to a new
+// management cluster. The objects of the management cluster itself are kept as a reference
+// for its cluster config, but they are not restored.
+const (
+	metadataFile   = "metadata.yaml"
+	eksaObjectsDir = "eksa"
+	capiObjectsDir = "capi"
+)
+
+// Metadata describes the content of a backup archive.
+type Metadata struct {
+	// FormatVersion is the version of the archive layout.
+	FormatVersion int `json:"formatVersion"`
+	// ClusterName is the name of the management cluster the backup was taken from.
+	ClusterName string `json:"clusterName"`
+	// EksaVersion is the version of the CLI that took the backup.
+	EksaVersion string `json:"eksaVersion"`
+	// CreatedAt is the time the backup was taken.
+	CreatedAt metav1.Time `json:"createdAt"`
+	// WorkloadClusters are the names of the workload clusters managed by the management cluster.
+	WorkloadClusters []string `json:"workloadClusters,omitempty"`
+}
+
+func writeMetadata(dir string, m *Metadata) error {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshalling backup metadata: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, metadataFile), content, 0o644); err != nil {
+		return fmt.Errorf("writing backup metadata: %v", err)
+	}
+
+	return nil
+}
+
+func readMetadata(dir string) (*Metadata, error) {
+	content, err := os.ReadFile(filepath.Join(dir, metadataFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading backup metadata: %v", err)
+	}
+
+	m := &Metadata{}
+	if err := yaml.Unmarshal(content, m); err != nil {
+		return nil, fmt.Errorf("parsing backup metadata: %v", err)
+	}
+
+	if m.FormatVersion < 1 || m.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d, this version of the CLI supports up to %d", m.FormatVersion, FormatVersion)
+	}
+
+	return m, nil
+}
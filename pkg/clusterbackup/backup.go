@@ -0,0 +1,139 @@
+package clusterbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const stateDirTimeFormat = "2006-01-02T15_04_05"
+
+// CAPIBackuper saves the CAPI objects of a management cluster to a directory.
+type CAPIBackuper interface {
+	BackupManagement(ctx context.Context, cluster *types.Cluster, managementStatePath, clusterName string) error
+}
+
+// Packager builds an archive from a directory and extracts it.
+type Packager interface {
+	Package(sourceFolder, dstFile string) error
+	UnPackage(orgFile, dstFolder string) error
+}
+
+// Backuper takes a backup of a management cluster, including all its workload clusters,
+// and packages it in a compressed archive.
+type Backuper struct {
+	client      kubernetes.Client
+	capi        CAPIBackuper
+	packager    Packager
+	eksaVersion string
+	now         func() time.Time
+}
+
+// NewBackuper builds a Backuper. client must point to the management cluster.
+func NewBackuper(client kubernetes.Client, capi CAPIBackuper, packager Packager, eksaVersion string) *Backuper {
+	return &Backuper{
+		client:      client,
+		capi:        capi,
+		packager:    packager,
+		eksaVersion: eksaVersion,
+		now:         time.Now,
+	}
+}
+
+// Backup writes a backup archive of the management cluster to dst.
+// The backup is staged in a folder under the cluster folder, which is removed once the
+// archive is written.
+func (b *Backuper) Backup(ctx context.Context, cluster *types.Cluster, dst string) error {
+	now := b.now()
+	stateDir := fmt.Sprintf("%s-backup-%s", cluster.Name, now.Format(stateDirTimeFormat))
+	stagingDir := filepath.Join(cluster.Name, stateDir)
+	if err := os.MkdirAll(filepath.Join(stagingDir, eksaObjectsDir), os.ModePerm); err != nil {
+		return fmt.Errorf("creating backup staging folder: %v", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	metadata := &Metadata{
+		FormatVersion: FormatVersion,
+		ClusterName:   cluster.Name,
+		EksaVersion:   b.eksaVersion,
+		CreatedAt:     metav1.NewTime(now.UTC()),
+	}
+
+	logger.Info("Backing up EKS Anywhere objects")
+	workloadClusters, err := b.backupEksaObjects(ctx, cluster, filepath.Join(stagingDir, eksaObjectsDir))
+	if err != nil {
+		return err
+	}
+	metadata.WorkloadClusters = workloadClusters
+
+	logger.Info("Backing up CAPI objects")
+	if err := b.capi.BackupManagement(ctx, cluster, filepath.Join(stateDir, capiObjectsDir), ""); err != nil {
+		return fmt.Errorf("backing up CAPI objects: %v", err)
+	}
+
+	if err := writeMetadata(stagingDir, metadata); err != nil {
+		return err
+	}
+
+	if err := b.packager.Package(stagingDir, dst); err != nil {
+		return fmt.Errorf("packaging backup: %v", err)
+	}
+
+	return nil
+}
+
+// backupEksaObjects writes the EKS Anywhere objects to dir and returns the names of the
+// workload clusters managed by the cluster.
+func (b *Backuper) backupEksaObjects(ctx context.Context, cluster *types.Cluster, dir string) ([]string, error) {
+	var workloadClusters []string
+	tinkerbell := false
+	for _, kind := range eksaKinds {
+		objs, err := listObjects(ctx, b.client, anywherev1.GroupVersion.WithKind(kind))
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == anywherev1.ClusterKind {
+			for _, obj := range objs {
+				c, err := toCluster(obj)
+				if err != nil {
+					return nil, err
+				}
+				if c.Name != cluster.Name && c.ManagedBy() == cluster.Name {
+					workloadClusters = append(workloadClusters, c.Name)
+				}
+				if c.Spec.DatacenterRef.Kind == anywherev1.TinkerbellDatacenterKind {
+					tinkerbell = true
+				}
+			}
+		}
+
+		if err := writeObjects(dir, kind, objs); err != nil {
+			return nil, err
+		}
+	}
+
+	if !tinkerbell {
+		return workloadClusters, nil
+	}
+
+	hardware, err := listObjects(ctx, b.client, hardwareGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeObjects(dir, hardwareGVK.Kind, hardware); err != nil {
+		return nil, err
+	}
+
+	return workloadClusters, nil
+}
@@ -0,0 +1,211 @@
+package clusterbackup_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterbackup"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/tar"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+// fakeCAPI simulates clusterctl move to and from a directory.
+type fakeCAPI struct {
+	backupErr error
+	restored  []string
+}
+
+func (f *fakeCAPI) BackupManagement(_ context.Context, cluster *types.Cluster, managementStatePath, _ string) error {
+	if f.backupErr != nil {
+		return f.backupErr
+	}
+	dir := filepath.Join(cluster.Name, managementStatePath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "Cluster_eksa-system_w01.yaml"), []byte("kind: Cluster"), 0o600)
+}
+
+func (f *fakeCAPI) RestoreManagement(_ context.Context, from string, _ *types.Cluster, clusterName string) error {
+	if _, err := os.Stat(filepath.Join(from, "Cluster_eksa-system_w01.yaml")); err != nil {
+		return err
+	}
+	f.restored = append(f.restored, clusterName)
+	return nil
+}
+
+type failingListClient struct {
+	kubernetes.Client
+}
+
+func (failingListClient) List(context.Context, kubernetes.ObjectList, ...kubernetes.ListOption) error {
+	return errors.New("connection refused")
+}
+
+func newClient(t *testing.T, objs ...client.Object) kubernetes.Client {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, anywherev1.AddToScheme, tinkv1alpha1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return test.NewKubeClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build())
+}
+
+func eksaCluster(name, managementCluster string) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{Kind: anywherev1.ClusterKind, APIVersion: anywherev1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			ResourceVersion: "10",
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		Spec: anywherev1.ClusterSpec{
+			ManagementCluster: anywherev1.ManagementCluster{Name: managementCluster},
+			DatacenterRef:     anywherev1.Ref{Kind: anywherev1.TinkerbellDatacenterKind, Name: name},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				MachineGroupRef: &anywherev1.Ref{Kind: anywherev1.TinkerbellMachineConfigKind, Name: name + "-cp"},
+			},
+		},
+		Status: anywherev1.ClusterStatus{FailureMessage: ptr.String("failed")},
+	}
+}
+
+func clusterObjects() []client.Object {
+	return []client.Object{
+		eksaCluster("mgmt", "mgmt"),
+		eksaCluster("w01", "mgmt"),
+		&anywherev1.TinkerbellDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"},
+		},
+		&anywherev1.TinkerbellDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01", Namespace: "default"},
+		},
+		&anywherev1.TinkerbellMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01-cp", Namespace: "default"},
+			Spec: anywherev1.TinkerbellMachineConfigSpec{
+				TemplateRef: anywherev1.Ref{Kind: anywherev1.TinkerbellTemplateConfigKind, Name: "w01-template"},
+			},
+		},
+		&anywherev1.TinkerbellTemplateConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "w01-template", Namespace: "default"},
+		},
+		&tinkv1alpha1.Hardware{
+			ObjectMeta: metav1.ObjectMeta{Name: "hw-1", Namespace: constants.EksaSystemNamespace},
+		},
+	}
+}
+
+func newBackuper(c kubernetes.Client, capi *fakeCAPI) *clusterbackup.Backuper {
+	return clusterbackup.NewBackuper(c, capi, tar.NewGzipPackager(), "v0.0.0-dev")
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	t.Cleanup(func() { os.RemoveAll("mgmt"); os.RemoveAll("mgmt-new") })
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	capi := &fakeCAPI{}
+	backuper := newBackuper(newClient(t, clusterObjects()...), capi)
+	g.Expect(backuper.Backup(ctx, &types.Cluster{Name: "mgmt"}, archive)).To(Succeed())
+
+	// The staging folder is removed once the archive is written.
+	entries, err := os.ReadDir("mgmt")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(entries).To(BeEmpty())
+
+	target := newClient(t, &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{Name: "hw-1", Namespace: constants.EksaSystemNamespace, Labels: map[string]string{"in-use": "true"}},
+	})
+	restorer := clusterbackup.NewRestorer(target, capi, tar.NewGzipPackager())
+	metadata, err := restorer.RestoreWorkloads(ctx, archive, &types.Cluster{Name: "mgmt-new"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(metadata.ClusterName).To(Equal("mgmt"))
+	g.Expect(metadata.EksaVersion).To(Equal("v0.0.0-dev"))
+	g.Expect(metadata.WorkloadClusters).To(ConsistOf("w01"))
+	g.Expect(capi.restored).To(ConsistOf("w01"))
+
+	cluster := &anywherev1.Cluster{}
+	g.Expect(target.Get(ctx, "w01", "default", cluster)).To(Succeed())
+	g.Expect(cluster.Spec.ManagementCluster.Name).To(Equal("mgmt-new"))
+	g.Expect(cluster.Annotations).ToNot(HaveKey("kubectl.kubernetes.io/last-applied-configuration"))
+	g.Expect(cluster.Status.FailureMessage).To(BeNil())
+
+	g.Expect(target.Get(ctx, "w01", "default", &anywherev1.TinkerbellDatacenterConfig{})).To(Succeed())
+	g.Expect(target.Get(ctx, "w01-cp", "default", &anywherev1.TinkerbellMachineConfig{})).To(Succeed())
+	g.Expect(target.Get(ctx, "w01-template", "default", &anywherev1.TinkerbellTemplateConfig{})).To(Succeed())
+
+	// Objects of the old management cluster are not restored.
+	g.Expect(target.Get(ctx, "mgmt", "default", &anywherev1.Cluster{})).ToNot(Succeed())
+	g.Expect(target.Get(ctx, "mgmt", "default", &anywherev1.TinkerbellDatacenterConfig{})).ToNot(Succeed())
+
+	// Existing hardware is left untouched.
+	hardware := &tinkv1alpha1.Hardware{}
+	g.Expect(target.Get(ctx, "hw-1", constants.EksaSystemNamespace, hardware)).To(Succeed())
+	g.Expect(hardware.Labels).To(HaveKeyWithValue("in-use", "true"))
+}
+
+func TestBackupCAPIError(t *testing.T) {
+	g := NewWithT(t)
+	t.Cleanup(func() { os.RemoveAll("mgmt") })
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	backuper := newBackuper(newClient(t, clusterObjects()...), &fakeCAPI{backupErr: errors.New("move failed")})
+
+	g.Expect(backuper.Backup(context.Background(), &types.Cluster{Name: "mgmt"}, archive)).To(MatchError(ContainSubstring("backing up CAPI objects: move failed")))
+	g.Expect(archive).ToNot(BeAnExistingFile())
+}
+
+func TestBackupListError(t *testing.T) {
+	g := NewWithT(t)
+	t.Cleanup(func() { os.RemoveAll("mgmt") })
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	backuper := newBackuper(failingListClient{Client: newClient(t)}, &fakeCAPI{})
+
+	g.Expect(backuper.Backup(context.Background(), &types.Cluster{Name: "mgmt"}, archive)).To(MatchError(ContainSubstring("listing Cluster")))
+}
+
+func TestRestoreUnsupportedVersion(t *testing.T) {
+	g := NewWithT(t)
+	t.Cleanup(func() { os.RemoveAll("mgmt-new") })
+
+	dir := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte("formatVersion: 99\nclusterName: mgmt\n"), 0o600)).To(Succeed())
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	g.Expect(tar.GzipTarFolder(dir, archive)).To(Succeed())
+
+	restorer := clusterbackup.NewRestorer(newClient(t), &fakeCAPI{}, tar.NewGzipPackager())
+	_, err := restorer.RestoreWorkloads(context.Background(), archive, &types.Cluster{Name: "mgmt-new"})
+	g.Expect(err).To(MatchError(ContainSubstring("unsupported backup format version 99")))
+}
+
+func TestRestoreMissingArchive(t *testing.T) {
+	g := NewWithT(t)
+	t.Cleanup(func() { os.RemoveAll("mgmt-new") })
+
+	restorer := clusterbackup.NewRestorer(newClient(t, &corev1.Namespace{}), &fakeCAPI{}, tar.NewGzipPackager())
+	_, err := restorer.RestoreWorkloads(context.Background(), filepath.Join(t.TempDir(), "missing.tar.gz"), &types.Cluster{Name: "mgmt-new"})
+	g.Expect(err).To(MatchError(ContainSubstring("extracting backup")))
+}
@@ -0,0 +1,138 @@
+package clusterbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
+)
+
+// eksaKinds are the EKS Anywhere kinds that make up a cluster configuration. Objects the
+// controllers create on their own, like upgrade requests, are not backed up.
+var eksaKinds = []string{
+	anywherev1.ClusterKind,
+	anywherev1.AWSIamConfigKind,
+	anywherev1.OIDCConfigKind,
+	anywherev1.GitOpsConfigKind,
+	anywherev1.FluxConfigKind,
+	anywherev1.CloudStackDatacenterKind,
+	anywherev1.CloudStackMachineConfigKind,
+	anywherev1.DockerDatacenterKind,
+	anywherev1.NutanixDatacenterKind,
+	anywherev1.NutanixMachineConfigKind,
+	anywherev1.SnowDatacenterKind,
+	anywherev1.SnowMachineConfigKind,
+	anywherev1.SnowIPPoolKind,
+	anywherev1.TinkerbellDatacenterKind,
+	anywherev1.TinkerbellMachineConfigKind,
+	anywherev1.TinkerbellTemplateConfigKind,
+	anywherev1.VSphereDatacenterKind,
+	anywherev1.VSphereMachineConfigKind,
+}
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// hardwareGVK is the Tinkerbell Hardware kind. Its CRD only exists in clusters with the
+// Tinkerbell stack installed.
+var hardwareGVK = schema.GroupVersionKind{Group: "tinkerbell.org", Version: "v1alpha1", Kind: "Hardware"}
+
+func listObjects(ctx context.Context, client kubernetes.Client, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("listing %s: %v", gvk.Kind, err)
+	}
+
+	sort.Slice(list.Items, func(i, j int) bool {
+		if list.Items[i].GetNamespace() != list.Items[j].GetNamespace() {
+			return list.Items[i].GetNamespace() < list.Items[j].GetNamespace()
+		}
+		return list.Items[i].GetName() < list.Items[j].GetName()
+	})
+
+	for i := range list.Items {
+		sanitize(&list.Items[i])
+	}
+
+	return list.Items, nil
+}
+
+// sanitize removes the fields set by the API server, so the objects can be created again in
+// a different cluster.
+func sanitize(obj *unstructured.Unstructured) {
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetManagedFields(nil)
+	obj.SetOwnerReferences(nil)
+	obj.SetFinalizers(nil)
+	annotations := obj.GetAnnotations()
+	delete(annotations, lastAppliedConfigAnnotation)
+	obj.SetAnnotations(annotations)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
+
+func writeObjects(dir, kind string, objs []unstructured.Unstructured) error {
+	if len(objs) == 0 {
+		return nil
+	}
+
+	content, err := unstructuredutil.UnstructuredToYaml(objs)
+	if err != nil {
+		return fmt.Errorf("marshalling %s objects: %v", kind, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, kind+".yaml"), content, 0o600); err != nil {
+		return fmt.Errorf("writing %s objects: %v", kind, err)
+	}
+
+	return nil
+}
+
+// readObjects reads all the objects in the EKS Anywhere objects directory of an archive.
+func readObjects(dir string) ([]unstructured.Unstructured, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading backup objects: %v", err)
+	}
+
+	var objs []unstructured.Unstructured
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".yaml") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading backup objects: %v", err)
+		}
+
+		fileObjs, err := unstructuredutil.YamlToUnstructured(content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing backup objects in %s: %v", f.Name(), err)
+		}
+		objs = append(objs, fileObjs...)
+	}
+
+	return objs, nil
+}
+
+func toCluster(obj unstructured.Unstructured) (*anywherev1.Cluster, error) {
+	cluster := &anywherev1.Cluster{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, cluster); err != nil {
+		return nil, fmt.Errorf("converting cluster %s: %v", obj.GetName(), err)
+	}
+
+	return cluster, nil
+}
@@ -0,0 +1,232 @@
+package clusterbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// CAPIRestorer restores CAPI objects saved with CAPIBackuper to a management cluster.
+type CAPIRestorer interface {
+	RestoreManagement(ctx context.Context, from string, to *types.Cluster, clusterName string) error
+}
+
+// Restorer makes a new management cluster take over the workload clusters of a backed up
+// management cluster.
+//
+// The new management cluster is created beforehand with the cluster config from the backup,
+// the same way as the original. Their CAPI objects are moved in first, so the EKS Anywhere
+// controller finds the existing machines instead of creating new ones, and then their EKS
+// Anywhere objects are applied pointing to the new management cluster.
+//
+// The management cluster itself is not restored, its own objects in the backup are ignored.
+type Restorer struct {
+	client   kubernetes.Client
+	capi     CAPIRestorer
+	packager Packager
+	now      func() time.Time
+}
+
+// NewRestorer builds a Restorer. client must point to the new management cluster.
+func NewRestorer(client kubernetes.Client, capi CAPIRestorer, packager Packager) *Restorer {
+	return &Restorer{
+		client:   client,
+		capi:     capi,
+		packager: packager,
+		now:      time.Now,
+	}
+}
+
+// RestoreWorkloads moves the workload clusters in the archive to the target management cluster
+// and returns the archive metadata.
+func (r *Restorer) RestoreWorkloads(ctx context.Context, archive string, target *types.Cluster) (*Metadata, error) {
+	stagingDir := filepath.Join(target.Name, fmt.Sprintf("%s-restore-%s", target.Name, r.now().Format(stateDirTimeFormat)))
+	if err := os.MkdirAll(stagingDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("creating restore staging folder: %v", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := r.packager.UnPackage(archive, stagingDir); err != nil {
+		return nil, fmt.Errorf("extracting backup: %v", err)
+	}
+
+	metadata, err := readMetadata(stagingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := readObjects(filepath.Join(stagingDir, eksaObjectsDir))
+	if err != nil {
+		return nil, err
+	}
+	index := newObjectIndex(objs)
+
+	for _, name := range metadata.WorkloadClusters {
+		logger.Info("Restoring workload cluster", "cluster", name)
+		if err := r.restoreWorkloadCluster(ctx, index, filepath.Join(stagingDir, capiObjectsDir), name, target); err != nil {
+			return nil, fmt.Errorf("restoring workload cluster %s: %v", name, err)
+		}
+	}
+
+	if err := r.restoreHardware(ctx, index.kind(hardwareGVK.Kind)); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (r *Restorer) restoreWorkloadCluster(ctx context.Context, index objectIndex, capiDir, name string, target *types.Cluster) error {
+	clusterObj := index.find(anywherev1.ClusterKind, "", name)
+	if clusterObj == nil {
+		return fmt.Errorf("cluster object not found in backup")
+	}
+
+	cluster, err := toCluster(*clusterObj)
+	if err != nil {
+		return err
+	}
+
+	if err := r.capi.RestoreManagement(ctx, capiDir, target, name); err != nil {
+		return err
+	}
+
+	if err := r.ensureNamespace(ctx, cluster.Namespace); err != nil {
+		return err
+	}
+
+	// The objects the cluster references are applied first, so they exist when the
+	// cluster is validated and reconciled.
+	for _, obj := range index.clusterDependencies(cluster) {
+		if err := r.apply(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	clusterObj = clusterObj.DeepCopy()
+	if err := unstructured.SetNestedField(clusterObj.Object, target.Name, "spec", "managementCluster", "name"); err != nil {
+		return fmt.Errorf("updating management cluster: %v", err)
+	}
+
+	return r.apply(ctx, clusterObj)
+}
+
+// restoreHardware creates the Hardware objects missing in the new management cluster.
+// Existing ones are left untouched, since they might be in use by the new management cluster.
+func (r *Restorer) restoreHardware(ctx context.Context, hardware []*unstructured.Unstructured) error {
+	for _, h := range hardware {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(hardwareGVK)
+		err := r.client.Get(ctx, h.GetName(), h.GetNamespace(), existing)
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("getting hardware %s: %v", h.GetName(), err)
+		}
+
+		if err := r.client.Create(ctx, h.DeepCopy()); err != nil {
+			return fmt.Errorf("creating hardware %s: %v", h.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Restorer) ensureNamespace(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := r.client.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating namespace %s: %v", name, err)
+	}
+
+	return nil
+}
+
+func (r *Restorer) apply(ctx context.Context, obj *unstructured.Unstructured) error {
+	if err := r.client.ApplyServerSide(ctx,
+		constants.EKSACLIFieldManager,
+		obj,
+		kubernetes.ApplyServerSideOptions{ForceOwnership: true},
+	); err != nil {
+		return fmt.Errorf("applying %s %s: %v", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
+
+// objectIndex indexes the objects of a backup by kind.
+type objectIndex map[string][]*unstructured.Unstructured
+
+func newObjectIndex(objs []unstructured.Unstructured) objectIndex {
+	index := objectIndex{}
+	for i := range objs {
+		index[objs[i].GetKind()] = append(index[objs[i].GetKind()], &objs[i])
+	}
+
+	return index
+}
+
+func (i objectIndex) kind(kind string) []*unstructured.Unstructured {
+	return i[kind]
+}
+
+// find returns the object with the given kind and name. An empty namespace matches any namespace.
+func (i objectIndex) find(kind, namespace, name string) *unstructured.Unstructured {
+	for _, obj := range i[kind] {
+		if obj.GetName() == name && (namespace == "" || obj.GetNamespace() == namespace) {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+// clusterDependencies returns the objects referenced by the cluster that exist in the backup.
+func (i objectIndex) clusterDependencies(cluster *anywherev1.Cluster) []*unstructured.Unstructured {
+	refs := []anywherev1.Ref{cluster.Spec.DatacenterRef}
+	refs = append(refs, cluster.Spec.IdentityProviderRefs...)
+	if cluster.Spec.GitOpsRef != nil {
+		refs = append(refs, *cluster.Spec.GitOpsRef)
+	}
+	refs = append(refs, cluster.MachineConfigRefs()...)
+
+	var deps []*unstructured.Unstructured
+	seen := map[anywherev1.Ref]bool{}
+	for _, ref := range refs {
+		obj := i.find(ref.Kind, cluster.Namespace, ref.Name)
+		if obj == nil || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+
+		// Tinkerbell machine configs can reference a template, which needs to exist first.
+		if ref.Kind == anywherev1.TinkerbellMachineConfigKind {
+			templateRef := anywherev1.Ref{Kind: anywherev1.TinkerbellTemplateConfigKind}
+			templateRef.Name, _, _ = unstructured.NestedString(obj.Object, "spec", "templateRef", "name")
+			if template := i.find(templateRef.Kind, cluster.Namespace, templateRef.Name); template != nil && !seen[templateRef] {
+				seen[templateRef] = true
+				deps = append(deps, template)
+			}
+		}
+
+		deps = append(deps, obj)
+	}
+
+	return deps
+}
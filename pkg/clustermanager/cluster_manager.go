@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"math"
 	"net/url"
-	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -24,6 +23,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/clustermanager/internal"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/diagnostics"
 	"github.com/aws/eks-anywhere/pkg/executables"
@@ -367,10 +367,7 @@ func (c *ClusterManager) CreateRegistryCredSecret(ctx context.Context, mgmt *typ
 			Namespace: constants.EksaSystemNamespace,
 			Name:      "registry-credentials",
 		},
-		Data: map[string][]byte{
-			"username": []byte(os.Getenv("REGISTRY_USERNAME")),
-			"password": []byte(os.Getenv("REGISTRY_PASSWORD")),
-		},
+		Data: config.RegistryCredentialsSecretData(),
 	}
 
 	return c.clusterClient.Apply(ctx, mgmt.KubeconfigFile, secret)
@@ -1170,6 +1170,36 @@ func TestCreateRegistryCredSecretSuccess(t *testing.T) {
 	tt.Expect(err).To(BeNil())
 }
 
+func TestCreateRegistryCredSecretFallbackMirrors(t *testing.T) {
+	tt := newTest(t)
+	t.Setenv("REGISTRY_USERNAME", "primary")
+	t.Setenv("REGISTRY_PASSWORD", "primarypass")
+	t.Setenv("REGISTRY_USERNAME_1", "fallback")
+	t.Setenv("REGISTRY_PASSWORD_1", "fallbackpass")
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.Version,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: constants.EksaSystemNamespace,
+			Name:      "registry-credentials",
+		},
+		Data: map[string][]byte{
+			"username":   []byte("primary"),
+			"password":   []byte("primarypass"),
+			"username_1": []byte("fallback"),
+			"password_1": []byte("fallbackpass"),
+		},
+	}
+
+	tt.mocks.client.EXPECT().Apply(tt.ctx, tt.cluster.KubeconfigFile, secret).Return(nil)
+
+	err := tt.clusterManager.CreateRegistryCredSecret(tt.ctx, tt.cluster)
+	tt.Expect(err).To(BeNil())
+}
+
 func TestAllowDeleteWhilePaused(t *testing.T) {
 	tests := []struct {
 		name string
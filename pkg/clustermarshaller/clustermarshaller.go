@@ -14,7 +14,7 @@ import (
 )
 
 func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) ([]byte, error) {
-	marshallables := make([]v1alpha1.Marshallable, 0, 5+len(machineConfigs)+len(clusterSpec.TinkerbellTemplateConfigs)+len(clusterSpec.SnowIPPools))
+	marshallables := make([]v1alpha1.Marshallable, 0, 5+len(machineConfigs)+len(clusterSpec.TinkerbellTemplateConfigs)+len(clusterSpec.SnowIPPools)+len(clusterSpec.VSphereIPPools))
 	marshallables = append(marshallables,
 		clusterSpec.Cluster.ConvertConfigToConfigGenerateStruct(),
 		datacenterConfig.Marshallable(),
@@ -50,6 +50,11 @@ func MarshalClusterSpec(clusterSpec *cluster.Spec, datacenterConfig providers.Da
 			marshallables = append(marshallables, t.ConvertConfigToConfigGenerateStruct())
 		}
 	}
+	if clusterSpec.VSphereIPPools != nil {
+		for _, p := range clusterSpec.VSphereIPPools {
+			marshallables = append(marshallables, p.ConvertConfigToConfigGenerateStruct())
+		}
+	}
 
 	resources := make([][]byte, 0, len(marshallables))
 	for _, marshallable := range marshallables {
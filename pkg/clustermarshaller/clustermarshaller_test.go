@@ -421,3 +421,81 @@ func TestWriteClusterConfigSnow(t *testing.T) {
 	g.Expect(clustermarshaller.WriteClusterConfig(clusterSpec, datacenterConfig, machineConfigs, writer)).To(Succeed())
 	test.AssertFilesEquals(t, filepath.Join(folder, "testcluster-eks-a-cluster.yaml"), "testdata/expected_marshalled_snow.yaml")
 }
+
+func TestWriteClusterConfigVSphereIPPool(t *testing.T) {
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster = &v1alpha1.Cluster{
+			TypeMeta: v1.TypeMeta{
+				Kind:       v1alpha1.ClusterKind,
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name: "testcluster",
+			},
+			Spec: v1alpha1.ClusterSpec{
+				DatacenterRef: v1alpha1.Ref{
+					Kind: v1alpha1.VSphereDatacenterKind,
+					Name: "testvsphere",
+				},
+				ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+					MachineGroupRef: &v1alpha1.Ref{
+						Kind: v1alpha1.VSphereMachineConfigKind,
+						Name: "testvsphere",
+					},
+				},
+			},
+		}
+
+		s.VSphereIPPools = map[string]*v1alpha1.VSphereIPPool{
+			"ippool": {
+				TypeMeta: v1.TypeMeta{
+					Kind:       v1alpha1.VSphereIPPoolKind,
+					APIVersion: v1alpha1.GroupVersion.String(),
+				},
+				ObjectMeta: v1.ObjectMeta{
+					Name: "ippool",
+				},
+				Spec: v1alpha1.VSphereIPPoolSpec{
+					Addresses:   []string{"10.0.0.10-10.0.0.20"},
+					Prefix:      24,
+					Gateway:     "10.0.0.1",
+					Nameservers: []string{"10.0.0.2"},
+				},
+			},
+		}
+		s.Cluster.SetSelfManaged()
+	})
+
+	datacenterConfig := &v1alpha1.VSphereDatacenterConfig{
+		TypeMeta: v1.TypeMeta{
+			Kind:       v1alpha1.VSphereDatacenterKind,
+			APIVersion: v1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name: "testvsphere",
+		},
+	}
+
+	machineConfigs := []providers.MachineConfig{
+		&v1alpha1.VSphereMachineConfig{
+			TypeMeta: v1.TypeMeta{
+				Kind:       v1alpha1.VSphereMachineConfigKind,
+				APIVersion: v1alpha1.GroupVersion.String(),
+			},
+			ObjectMeta: v1.ObjectMeta{
+				Name: "testvsphere",
+			},
+			Spec: v1alpha1.VSphereMachineConfigSpec{
+				IPPool: &v1alpha1.VSphereIPPoolReference{
+					APIGroup: v1alpha1.DefaultVSphereIPPoolAPIGroup,
+					Kind:     v1alpha1.DefaultVSphereIPPoolKind,
+					Name:     "ippool",
+				},
+			},
+		},
+	}
+	g := NewWithT(t)
+	folder, writer := test.NewWriter(t)
+	g.Expect(clustermarshaller.WriteClusterConfig(clusterSpec, datacenterConfig, machineConfigs, writer)).To(Succeed())
+	test.AssertFilesEquals(t, filepath.Join(folder, "testcluster-eks-a-cluster.yaml"), "testdata/expected_marshalled_vsphere_ip_pool.yaml")
+}
@@ -24,6 +24,12 @@ type CliConfig struct {
 	GitSshKeyPassphrase string
 	GitPrivateKeyFile   string
 	GitKnownHostsFile   string
+	// GitOpsWaitForMerge makes the CLI wait for GitOps pull requests to be merged.
+	GitOpsWaitForMerge bool
+	// GitOpsMergeTimeout is how long the CLI waits for a GitOps pull request to be merged.
+	GitOpsMergeTimeout time.Duration
+	// GitOpsPackagesFile is a file with curated packages objects committed with the cluster config.
+	GitOpsPackagesFile string
 }
 
 // CreateClusterCLIConfig is the config we use for create cluster specific configurations.
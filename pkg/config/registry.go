@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -29,13 +31,69 @@ func ReadCredentials() (username, password string, err error) {
 	return username, password, nil
 }
 
+// ReadMirrorCredentials reads the credentials of the registry mirror at index in the ordered list of
+// registry mirrors, where 0 is the primary mirror read with ReadCredentials. The credentials of the
+// additional mirrors are read from the REGISTRY_USERNAME_<index> and REGISTRY_PASSWORD_<index> env vars.
+func ReadMirrorCredentials(index int) (username, password string, err error) {
+	if index == 0 {
+		return ReadCredentials()
+	}
+
+	usernameEnv := fmt.Sprintf("%s_%d", constants.RegistryUsername, index)
+	username, ok := os.LookupEnv(usernameEnv)
+	if !ok {
+		return "", "", fmt.Errorf("please set %s env var", usernameEnv)
+	}
+
+	passwordEnv := fmt.Sprintf("%s_%d", constants.RegistryPassword, index)
+	password, ok = os.LookupEnv(passwordEnv)
+	if !ok {
+		return "", "", fmt.Errorf("please set %s env var", passwordEnv)
+	}
+
+	return username, password, nil
+}
+
+// RegistryCredentialsSecretData returns the data of the registry-credentials secret. It holds the
+// credentials of the primary registry mirror and those of every additional mirror set in the
+// REGISTRY_USERNAME_<index> and REGISTRY_PASSWORD_<index> env vars.
+func RegistryCredentialsSecretData() map[string][]byte {
+	data := map[string][]byte{
+		"username": []byte(os.Getenv(constants.RegistryUsername)),
+		"password": []byte(os.Getenv(constants.RegistryPassword)),
+	}
+
+	prefix := constants.RegistryUsername + "_"
+	for _, env := range os.Environ() {
+		name, _, _ := strings.Cut(env, "=")
+		index, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+		if !strings.HasPrefix(name, prefix) || err != nil || index < 1 {
+			continue
+		}
+		username, password, err := ReadMirrorCredentials(index)
+		if err != nil {
+			continue
+		}
+		data[mirrorSecretKey("username", index)] = []byte(username)
+		data[mirrorSecretKey("password", index)] = []byte(password)
+	}
+
+	return data
+}
+
+func mirrorSecretKey(key string, index int) string {
+	if index == 0 {
+		return key
+	}
+	return fmt.Sprintf("%s_%d", key, index)
+}
+
 // ReadCredentialsFromSecret reads from Kubernetes secret registry-credentials.
 // Returns the username and password, or error.
 func ReadCredentialsFromSecret(ctx context.Context, client client.Client) (username, password string, err error) {
-	registryAuthSecret := &corev1.Secret{}
-	key := types.NamespacedName{Name: registryAuthSecretName, Namespace: constants.EksaSystemNamespace}
-	if err := client.Get(ctx, key, registryAuthSecret); err != nil {
-		return "", "", errors.Wrap(err, "fetching registry auth secret")
+	registryAuthSecret, err := getRegistryAuthSecret(ctx, client)
+	if err != nil {
+		return "", "", err
 	}
 
 	rUsername := registryAuthSecret.Data["username"]
@@ -44,6 +102,37 @@ func ReadCredentialsFromSecret(ctx context.Context, client client.Client) (usern
 	return string(rUsername), string(rPassword), nil
 }
 
+// ReadMirrorCredentialsFromSecret reads the credentials of the registry mirror at index in the ordered
+// list of registry mirrors from Kubernetes secret registry-credentials, where 0 is the primary mirror.
+func ReadMirrorCredentialsFromSecret(ctx context.Context, client client.Client, index int) (username, password string, err error) {
+	if index == 0 {
+		return ReadCredentialsFromSecret(ctx, client)
+	}
+
+	registryAuthSecret, err := getRegistryAuthSecret(ctx, client)
+	if err != nil {
+		return "", "", err
+	}
+
+	rUsername, ok := registryAuthSecret.Data[mirrorSecretKey("username", index)]
+	if !ok {
+		return "", "", fmt.Errorf("registry auth secret has no credentials for registry mirror %d", index)
+	}
+	rPassword := registryAuthSecret.Data[mirrorSecretKey("password", index)]
+
+	return string(rUsername), string(rPassword), nil
+}
+
+func getRegistryAuthSecret(ctx context.Context, client client.Client) (*corev1.Secret, error) {
+	registryAuthSecret := &corev1.Secret{}
+	key := types.NamespacedName{Name: registryAuthSecretName, Namespace: constants.EksaSystemNamespace}
+	if err := client.Get(ctx, key, registryAuthSecret); err != nil {
+		return nil, errors.Wrap(err, "fetching registry auth secret")
+	}
+
+	return registryAuthSecret, nil
+}
+
 // SetCredentialsEnv sets the registry username and password env variables.
 func SetCredentialsEnv(username, password string) error {
 	if err := os.Setenv(constants.RegistryUsername, username); err != nil {
@@ -56,3 +145,23 @@ func SetCredentialsEnv(username, password string) error {
 
 	return nil
 }
+
+// SetMirrorCredentialsEnv sets the username and password env variables read by ReadMirrorCredentials
+// for the registry mirror at index.
+func SetMirrorCredentialsEnv(index int, username, password string) error {
+	if index == 0 {
+		return SetCredentialsEnv(username, password)
+	}
+
+	usernameEnv := fmt.Sprintf("%s_%d", constants.RegistryUsername, index)
+	if err := os.Setenv(usernameEnv, username); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", usernameEnv, err)
+	}
+
+	passwordEnv := fmt.Sprintf("%s_%d", constants.RegistryPassword, index)
+	if err := os.Setenv(passwordEnv, password); err != nil {
+		return fmt.Errorf("failed setting env %s: %v", passwordEnv, err)
+	}
+
+	return nil
+}
@@ -31,6 +31,28 @@ func TestReadConfig(t *testing.T) {
 	assert.Equal(t, expectedPassword, password)
 }
 
+func TestReadMirrorCredentials(t *testing.T) {
+	t.Setenv(constants.RegistryUsername, "primary")
+	t.Setenv(constants.RegistryPassword, "primarypass")
+	username, password, err := ReadMirrorCredentials(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", username)
+	assert.Equal(t, "primarypass", password)
+
+	_, _, err = ReadMirrorCredentials(1)
+	assert.EqualError(t, err, "please set REGISTRY_USERNAME_1 env var")
+
+	t.Setenv("REGISTRY_USERNAME_1", "secondary")
+	_, _, err = ReadMirrorCredentials(1)
+	assert.EqualError(t, err, "please set REGISTRY_PASSWORD_1 env var")
+
+	t.Setenv("REGISTRY_PASSWORD_1", "secondarypass")
+	username, password, err = ReadMirrorCredentials(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "secondary", username)
+	assert.Equal(t, "secondarypass", password)
+}
+
 func TestSetCredentialsEnv(t *testing.T) {
 	uName := ""
 	uPass := ""
@@ -71,3 +93,59 @@ func TestReadCredentialsFromSecretNotFound(t *testing.T) {
 	assert.Empty(t, u)
 	assert.Empty(t, p)
 }
+
+func TestRegistryCredentialsSecretData(t *testing.T) {
+	t.Setenv(constants.RegistryUsername, "primary")
+	t.Setenv(constants.RegistryPassword, "primarypass")
+	t.Setenv("REGISTRY_USERNAME_2", "fallback")
+	t.Setenv("REGISTRY_PASSWORD_2", "fallbackpass")
+	t.Setenv("REGISTRY_USERNAME_3", "incomplete")
+
+	assert.Equal(t, map[string][]byte{
+		"username":   []byte("primary"),
+		"password":   []byte("primarypass"),
+		"username_2": []byte("fallback"),
+		"password_2": []byte("fallbackpass"),
+	}, RegistryCredentialsSecretData())
+}
+
+func TestReadMirrorCredentialsFromSecret(t *testing.T) {
+	ctx := context.Background()
+	sec := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      registryAuthSecretName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Data: map[string][]byte{
+			"username":   []byte("primary"),
+			"password":   []byte("primarypass"),
+			"username_1": []byte("fallback"),
+			"password_1": []byte("fallbackpass"),
+		},
+	}
+	cl := fake.NewClientBuilder().WithRuntimeObjects(sec).Build()
+
+	u, p, err := ReadMirrorCredentialsFromSecret(ctx, cl, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "primary", u)
+	assert.Equal(t, "primarypass", p)
+
+	u, p, err = ReadMirrorCredentialsFromSecret(ctx, cl, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", u)
+	assert.Equal(t, "fallbackpass", p)
+
+	_, _, err = ReadMirrorCredentialsFromSecret(ctx, cl, 2)
+	assert.EqualError(t, err, "registry auth secret has no credentials for registry mirror 2")
+}
+
+func TestSetMirrorCredentialsEnv(t *testing.T) {
+	t.Setenv("REGISTRY_USERNAME_1", "")
+	t.Setenv("REGISTRY_PASSWORD_1", "")
+
+	assert.NoError(t, SetMirrorCredentialsEnv(1, "fallback", "fallbackpass"))
+	username, password, err := ReadMirrorCredentials(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", username)
+	assert.Equal(t, "fallbackpass", password)
+}
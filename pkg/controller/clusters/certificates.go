@@ -0,0 +1,251 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+const (
+	// CertificateRenewalStartedReason is the event reason used when an automatic certificate renewal is triggered.
+	CertificateRenewalStartedReason = "CertificateRenewalStarted"
+
+	externalEtcdLabel = "cluster.x-k8s.io/etcd-cluster"
+)
+
+// ReconcileCertificateRotation renews the control plane certificates when the cluster has a
+// CertificateRotation policy and the certificates reported in the cluster status expire within
+// the configured threshold. The renewal is done by rolling out the control plane machines,
+// which only starts inside the policy maintenance window. Certificates for external etcd
+// machines, control planes upgraded in place and Tinkerbell control planes without spare
+// hardware for the rollout can't be renewed this way, so they are only reported through the
+// CertificatesReady condition and events.
+// It relies on the ClusterCertificateInfo reported by UpdateClusterCertificateStatus and runs as
+// a reconcile phase, never from the status update.
+func ReconcileCertificateRotation(ctx context.Context, log logr.Logger, c client.Client, recorder record.EventRecorder, cluster *anywherev1.Cluster, now time.Time) error {
+	policy := cluster.Spec.CertificateRotation
+	if policy == nil {
+		conditions.Delete(cluster, anywherev1.CertificatesReadyCondition)
+		return nil
+	}
+
+	kcp, err := controller.GetKubeadmControlPlane(ctx, c, cluster)
+	if err != nil {
+		return errors.Wrap(err, "getting kubeadmcontrolplane")
+	}
+	if kcp == nil {
+		return nil
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := c.List(ctx, machines,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	); err != nil {
+		return errors.Wrap(err, "listing cluster machines")
+	}
+
+	var controlPlaneMachines []clusterv1.Machine
+	machineKinds := make(map[string]string, len(machines.Items))
+	for _, m := range machines.Items {
+		if _, ok := m.Labels[clusterv1.MachineControlPlaneLabel]; ok {
+			controlPlaneMachines = append(controlPlaneMachines, m)
+			machineKinds[m.Name] = clusterv1.MachineControlPlaneLabel
+		} else if _, ok := m.Labels[externalEtcdLabel]; ok {
+			machineKinds[m.Name] = externalEtcdLabel
+		}
+	}
+
+	if renewalInProgress(kcp, controlPlaneMachines, now) {
+		markCertificatesNotReady(cluster, recorder, anywherev1.CertificateRenewalInProgressReason, clusterv1.ConditionSeverityInfo,
+			"Rolling out control plane machines to renew certificates")
+		return nil
+	}
+
+	// Certificate information is only refreshed for ready clusters.
+	if !conditions.IsTrue(cluster, anywherev1.ReadyCondition) {
+		return nil
+	}
+
+	// Entries for machines that don't exist anymore are stale and ignored.
+	var expiringControlPlane, expiringEtcd []anywherev1.ClusterCertificateInfo
+	for _, info := range cluster.Status.ClusterCertificateInfo {
+		if info.ExpiresInDays > policy.RenewBeforeDays {
+			continue
+		}
+		switch machineKinds[info.Machine] {
+		case clusterv1.MachineControlPlaneLabel:
+			expiringControlPlane = append(expiringControlPlane, info)
+		case externalEtcdLabel:
+			expiringEtcd = append(expiringEtcd, info)
+		}
+	}
+
+	switch {
+	case len(expiringControlPlane) > 0 && renewalAttempted(kcp, policy, now):
+		markCertificatesNotReady(cluster, recorder, anywherev1.CertificateRenewalFailedReason, clusterv1.ConditionSeverityError,
+			fmt.Sprintf("Control plane certificates still expire after renewal (%s), run 'eksctl anywhere renew certificates' to renew them manually", expiringMachines(expiringControlPlane)))
+	case len(expiringControlPlane) > 0 && !policy.MaintenanceWindow.Contains(now):
+		markCertificatesNotReady(cluster, recorder, anywherev1.CertificatesExpiringSoonReason, clusterv1.ConditionSeverityWarning,
+			fmt.Sprintf("Control plane certificates are expiring (%s), renewal will start in the next maintenance window", expiringMachines(expiringControlPlane)))
+	case len(expiringControlPlane) > 0:
+		blocked, err := rolloutBlocked(ctx, c, cluster, kcp)
+		if err != nil {
+			return errors.Wrap(err, "checking control plane rollout for certificate renewal")
+		}
+		if blocked != "" {
+			markCertificatesNotReady(cluster, recorder, anywherev1.CertificatesExpiringSoonReason, clusterv1.ConditionSeverityWarning,
+				fmt.Sprintf("Control plane certificates are expiring (%s) and can't be renewed by a rollout because %s, run 'eksctl anywhere renew certificates' to renew them", expiringMachines(expiringControlPlane), blocked))
+			return nil
+		}
+
+		log.Info("Triggering control plane rollout to renew certificates", "machines", expiringMachines(expiringControlPlane))
+		if err := triggerControlPlaneRollout(ctx, c, kcp, now); err != nil {
+			markCertificatesNotReady(cluster, recorder, anywherev1.CertificateRenewalFailedReason, clusterv1.ConditionSeverityError,
+				fmt.Sprintf("Triggering control plane rollout: %v", err))
+			return errors.Wrap(err, "triggering control plane rollout for certificate renewal")
+		}
+		recorder.Event(cluster, corev1.EventTypeNormal, CertificateRenewalStartedReason, "Rolling out control plane machines to renew certificates")
+		conditions.MarkFalse(cluster, anywherev1.CertificatesReadyCondition, anywherev1.CertificateRenewalInProgressReason, clusterv1.ConditionSeverityInfo,
+			"Rolling out control plane machines to renew certificates")
+	case len(expiringEtcd) > 0:
+		markCertificatesNotReady(cluster, recorder, anywherev1.CertificatesExpiringSoonReason, clusterv1.ConditionSeverityWarning,
+			fmt.Sprintf("External etcd certificates are expiring (%s), run 'eksctl anywhere renew certificates' to renew them", expiringMachines(expiringEtcd)))
+	default:
+		conditions.MarkTrue(cluster, anywherev1.CertificatesReadyCondition)
+	}
+
+	return nil
+}
+
+// markCertificatesNotReady sets the CertificatesReady condition to false and records an event
+// only when the reason changes, so the same problem is not reported on every reconciliation.
+func markCertificatesNotReady(cluster *anywherev1.Cluster, recorder record.EventRecorder, reason string, severity clusterv1.ConditionSeverity, message string) {
+	current := conditions.Get(cluster, anywherev1.CertificatesReadyCondition)
+	if current == nil || current.Reason != reason {
+		eventType := corev1.EventTypeWarning
+		if severity == clusterv1.ConditionSeverityInfo {
+			eventType = corev1.EventTypeNormal
+		}
+		recorder.Event(cluster, eventType, reason, message)
+	}
+
+	conditions.MarkFalse(cluster, anywherev1.CertificatesReadyCondition, reason, severity, "%s", message)
+}
+
+// renewalInProgress returns true if some control plane machines were created before the
+// current rolloutAfter, which means KCP is still replacing them.
+func renewalInProgress(kcp *controlplanev1.KubeadmControlPlane, machines []clusterv1.Machine, now time.Time) bool {
+	if kcp.Spec.RolloutAfter == nil || kcp.Spec.RolloutAfter.Time.After(now) {
+		return false
+	}
+
+	for _, m := range machines {
+		if m.CreationTimestamp.Before(kcp.Spec.RolloutAfter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renewalAttempted returns true if a rollout was already triggered within the renewal period.
+// Certificates that still expire after it are not going to be renewed by another rollout.
+func renewalAttempted(kcp *controlplanev1.KubeadmControlPlane, policy *anywherev1.CertificateRotation, now time.Time) bool {
+	if kcp.Spec.RolloutAfter == nil {
+		return false
+	}
+
+	renewBefore := time.Duration(policy.RenewBeforeDays) * 24 * time.Hour
+	return now.Sub(kcp.Spec.RolloutAfter.Time) < renewBefore
+}
+
+// rolloutBlocked returns why the control plane machines can't be replaced to renew their
+// certificates, or an empty string if they can.
+func rolloutBlocked(ctx context.Context, c client.Client, cluster *anywherev1.Cluster, kcp *controlplanev1.KubeadmControlPlane) (string, error) {
+	if strategy := cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy; strategy != nil && strategy.Type == anywherev1.InPlaceStrategyType {
+		return "the control plane is upgraded in place", nil
+	}
+
+	if cluster.Spec.DatacenterRef.Kind != anywherev1.TinkerbellDatacenterKind {
+		return "", nil
+	}
+
+	maxSurge := 1
+	if kcp.Spec.RolloutStrategy != nil && kcp.Spec.RolloutStrategy.RollingUpdate != nil && kcp.Spec.RolloutStrategy.RollingUpdate.MaxSurge != nil {
+		maxSurge = kcp.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntValue()
+	}
+	if maxSurge == 0 {
+		return "", nil
+	}
+
+	available, err := availableControlPlaneHardware(ctx, c, cluster)
+	if err != nil {
+		return "", err
+	}
+	if available < maxSurge {
+		return fmt.Sprintf("the rollout needs %d spare control plane hardware but %d are available", maxSurge, available), nil
+	}
+
+	return "", nil
+}
+
+// availableControlPlaneHardware counts the Tinkerbell hardware not used by any cluster that
+// matches the control plane hardware selector.
+func availableControlPlaneHardware(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) (int, error) {
+	if cluster.Spec.ControlPlaneConfiguration.MachineGroupRef == nil {
+		return 0, errors.New("control plane machine group reference is not set")
+	}
+
+	machineConfig := &anywherev1.TinkerbellMachineConfig{}
+	key := client.ObjectKey{Name: cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name, Namespace: cluster.Namespace}
+	if err := c.Get(ctx, key, machineConfig); err != nil {
+		return 0, errors.Wrap(err, "getting control plane tinkerbell machine config")
+	}
+
+	reader := hardware.NewKubeReader(c)
+	if err := reader.LoadHardware(ctx); err != nil {
+		return 0, errors.Wrap(err, "loading tinkerbell hardware")
+	}
+
+	return reader.GetCatalogue().TotalHardwareMatching(machineConfig.Spec.HardwareSelector), nil
+}
+
+func triggerControlPlaneRollout(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane, now time.Time) error {
+	original := kcp.DeepCopy()
+	kcp.Spec.RolloutAfter = &metav1.Time{Time: now}
+
+	return c.Patch(ctx, kcp, client.MergeFrom(original))
+}
+
+func expiringMachines(infos []anywherev1.ClusterCertificateInfo) string {
+	sorted := make([]anywherev1.ClusterCertificateInfo, len(infos))
+	copy(sorted, infos)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ExpiresInDays < sorted[j].ExpiresInDays
+	})
+
+	machines := make([]string, 0, len(sorted))
+	for _, info := range sorted {
+		machines = append(machines, fmt.Sprintf("%s expires in %d days", info.Machine, info.ExpiresInDays))
+	}
+
+	return strings.Join(machines, ", ")
+}
@@ -0,0 +1,329 @@
+package clusters_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+type certificateRotationTest struct {
+	*WithT
+	ctx      context.Context
+	cluster  *anywherev1.Cluster
+	kcp      *controlplanev1.KubeadmControlPlane
+	machines []*clusterv1.Machine
+	objs     []runtime.Object
+	recorder *record.FakeRecorder
+	now      time.Time
+}
+
+func newCertificateRotationTest(t *testing.T) *certificateRotationTest {
+	now := time.Date(2024, time.June, 1, 2, 30, 0, 0, time.UTC) // Saturday
+	created := metav1.NewTime(now.Add(-300 * 24 * time.Hour))
+
+	return &certificateRotationTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		cluster: &anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: constants.EksaSystemNamespace,
+			},
+			Spec: anywherev1.ClusterSpec{
+				CertificateRotation: &anywherev1.CertificateRotation{
+					RenewBeforeDays: 30,
+					MaintenanceWindow: &anywherev1.MaintenanceWindow{
+						Days:     []string{"Saturday"},
+						Start:    "02:00",
+						Duration: metav1.Duration{Duration: 2 * time.Hour},
+					},
+				},
+			},
+			Status: anywherev1.ClusterStatus{
+				Conditions: []anywherev1.Condition{
+					{
+						Type:   anywherev1.ReadyCondition,
+						Status: "True",
+					},
+				},
+				ClusterCertificateInfo: []anywherev1.ClusterCertificateInfo{
+					{Machine: "test-cluster-cp-1", ExpiresInDays: 60},
+					{Machine: "test-cluster-etcd-1", ExpiresInDays: 60},
+				},
+			},
+		},
+		kcp: &controlplanev1.KubeadmControlPlane{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "KubeadmControlPlane",
+				APIVersion: controlplanev1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: constants.EksaSystemNamespace,
+			},
+		},
+		machines: []*clusterv1.Machine{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-cluster-cp-1",
+					Namespace:         constants.EksaSystemNamespace,
+					CreationTimestamp: created,
+					Labels: map[string]string{
+						clusterv1.ClusterNameLabel:         "test-cluster",
+						clusterv1.MachineControlPlaneLabel: "",
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-cluster-etcd-1",
+					Namespace:         constants.EksaSystemNamespace,
+					CreationTimestamp: created,
+					Labels: map[string]string{
+						clusterv1.ClusterNameLabel:      "test-cluster",
+						"cluster.x-k8s.io/etcd-cluster": "test-cluster-etcd",
+					},
+				},
+			},
+		},
+		recorder: record.NewFakeRecorder(10),
+		now:      now,
+	}
+}
+
+func (tt *certificateRotationTest) client() client.Client {
+	objs := append([]runtime.Object{tt.cluster, tt.kcp}, tt.objs...)
+	for _, m := range tt.machines {
+		objs = append(objs, m)
+	}
+	return fake.NewClientBuilder().WithRuntimeObjects(objs...).Build()
+}
+
+func (tt *certificateRotationTest) reconcile(c client.Client) error {
+	return clusters.ReconcileCertificateRotation(tt.ctx, test.NewNullLogger(), c, tt.recorder, tt.cluster, tt.now)
+}
+
+func (tt *certificateRotationTest) expectCondition(reason string) {
+	condition := conditions.Get(tt.cluster, anywherev1.CertificatesReadyCondition)
+	tt.Expect(condition).ToNot(BeNil())
+	tt.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+	tt.Expect(condition.Reason).To(Equal(reason))
+}
+
+func (tt *certificateRotationTest) expectRolloutAfter(c client.Client, want *time.Time) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKeyFromObject(tt.kcp), kcp)).To(Succeed())
+	if want == nil {
+		tt.Expect(kcp.Spec.RolloutAfter).To(BeNil())
+		return
+	}
+	tt.Expect(kcp.Spec.RolloutAfter).ToNot(BeNil())
+	tt.Expect(kcp.Spec.RolloutAfter.Time.Equal(*want)).To(BeTrue())
+}
+
+func TestReconcileCertificateRotationNoPolicy(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.cluster.Spec.CertificateRotation = nil
+	conditions.MarkTrue(tt.cluster, anywherev1.CertificatesReadyCondition)
+
+	tt.Expect(tt.reconcile(tt.client())).To(Succeed())
+	tt.Expect(conditions.Get(tt.cluster, anywherev1.CertificatesReadyCondition)).To(BeNil())
+}
+
+func TestReconcileCertificateRotationCertificatesValid(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.CertificatesReadyCondition)).To(BeTrue())
+	tt.expectRolloutAfter(c, nil)
+	tt.Expect(tt.recorder.Events).To(BeEmpty())
+}
+
+func TestReconcileCertificateRotationTriggersRollout(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 10
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificateRenewalInProgressReason)
+	tt.expectRolloutAfter(c, &tt.now)
+	tt.Expect(tt.recorder.Events).To(Receive(ContainSubstring(clusters.CertificateRenewalStartedReason)))
+}
+
+func TestReconcileCertificateRotationOutsideMaintenanceWindow(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.now = tt.now.Add(24 * time.Hour)
+	tt.cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 10
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificatesExpiringSoonReason)
+	tt.expectRolloutAfter(c, nil)
+	tt.Expect(tt.recorder.Events).To(Receive(ContainSubstring("test-cluster-cp-1 expires in 10 days")))
+
+	// The same problem is not reported twice.
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.Expect(tt.recorder.Events).To(BeEmpty())
+}
+
+func TestReconcileCertificateRotationInProgress(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.cluster.Status.Conditions[0].Status = "False"
+	tt.kcp.Spec.RolloutAfter = &metav1.Time{Time: tt.now.Add(-time.Hour)}
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificateRenewalInProgressReason)
+}
+
+func TestReconcileCertificateRotationRenewalFailed(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	rolloutAfter := tt.now.Add(-48 * time.Hour)
+	tt.kcp.Spec.RolloutAfter = &metav1.Time{Time: rolloutAfter}
+	tt.machines[0].CreationTimestamp = metav1.NewTime(tt.now.Add(-24 * time.Hour))
+	tt.cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 10
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificateRenewalFailedReason)
+	tt.expectRolloutAfter(c, &rolloutAfter)
+	tt.Expect(tt.recorder.Events).To(Receive(ContainSubstring("Warning " + anywherev1.CertificateRenewalFailedReason)))
+}
+
+func TestReconcileCertificateRotationExternalEtcdExpiring(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.cluster.Status.ClusterCertificateInfo[1].ExpiresInDays = 5
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificatesExpiringSoonReason)
+	tt.expectRolloutAfter(c, nil)
+	tt.Expect(tt.recorder.Events).To(Receive(ContainSubstring("External etcd certificates are expiring")))
+}
+
+func TestReconcileCertificateRotationIgnoresStaleMachines(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.cluster.Status.ClusterCertificateInfo = append(tt.cluster.Status.ClusterCertificateInfo,
+		anywherev1.ClusterCertificateInfo{Machine: "deleted-machine", ExpiresInDays: 1},
+	)
+
+	tt.Expect(tt.reconcile(tt.client())).To(Succeed())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.CertificatesReadyCondition)).To(BeTrue())
+}
+
+func TestReconcileCertificateRotationListError(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	c := &MockClient{Client: tt.client()}
+
+	tt.Expect(tt.reconcile(c)).To(MatchError(ContainSubstring("listing cluster machines")))
+}
+
+func TestReconcileCertificateRotationInPlace(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &anywherev1.ControlPlaneUpgradeRolloutStrategy{
+		Type: anywherev1.InPlaceStrategyType,
+	}
+	tt.cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 10
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificatesExpiringSoonReason)
+	tt.expectRolloutAfter(c, nil)
+	tt.Expect(tt.recorder.Events).To(Receive(ContainSubstring("the control plane is upgraded in place")))
+}
+
+func (tt *certificateRotationTest) withTinkerbell(hardware ...*tinkv1alpha1.Hardware) {
+	tt.cluster.Spec.DatacenterRef = anywherev1.Ref{Kind: anywherev1.TinkerbellDatacenterKind, Name: "test-cluster"}
+	tt.cluster.Spec.ControlPlaneConfiguration.MachineGroupRef = &anywherev1.Ref{Kind: anywherev1.TinkerbellMachineConfigKind, Name: "test-cluster-cp"}
+	tt.objs = append(tt.objs, &anywherev1.TinkerbellMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster-cp",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: anywherev1.TinkerbellMachineConfigSpec{
+			HardwareSelector: anywherev1.HardwareSelector{"type": "cp"},
+		},
+	})
+	for _, h := range hardware {
+		tt.objs = append(tt.objs, h)
+	}
+}
+
+func tinkerbellHardware(name string, labels map[string]string) *tinkv1alpha1.Hardware {
+	return &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+		Spec: tinkv1alpha1.HardwareSpec{
+			Metadata: &tinkv1alpha1.HardwareMetadata{
+				Instance: &tinkv1alpha1.MetadataInstance{ID: name},
+			},
+		},
+	}
+}
+
+func TestReconcileCertificateRotationTinkerbellWithoutSpareHardware(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.withTinkerbell(
+		tinkerbellHardware("cp-1", map[string]string{"type": "cp", hardware.OwnerNameLabel: "test-cluster-cp-1"}),
+		tinkerbellHardware("worker-1", map[string]string{"type": "worker"}),
+	)
+	tt.cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 10
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificatesExpiringSoonReason)
+	tt.expectRolloutAfter(c, nil)
+	tt.Expect(tt.recorder.Events).To(Receive(ContainSubstring("needs 1 spare control plane hardware but 0 are available")))
+}
+
+func TestReconcileCertificateRotationTinkerbellWithSpareHardware(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.withTinkerbell(
+		tinkerbellHardware("cp-1", map[string]string{"type": "cp", hardware.OwnerNameLabel: "test-cluster-cp-1"}),
+		tinkerbellHardware("cp-2", map[string]string{"type": "cp"}),
+	)
+	tt.cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 10
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificateRenewalInProgressReason)
+	tt.expectRolloutAfter(c, &tt.now)
+}
+
+func TestReconcileCertificateRotationTinkerbellWithoutSurge(t *testing.T) {
+	tt := newCertificateRotationTest(t)
+	tt.withTinkerbell()
+	maxSurge := intstr.FromInt(0)
+	tt.kcp.Spec.RolloutStrategy = &controlplanev1.RolloutStrategy{
+		RollingUpdate: &controlplanev1.RollingUpdate{MaxSurge: &maxSurge},
+	}
+	tt.cluster.Status.ClusterCertificateInfo[0].ExpiresInDays = 10
+	c := tt.client()
+
+	tt.Expect(tt.reconcile(c)).To(Succeed())
+	tt.expectCondition(anywherev1.CertificateRenewalInProgressReason)
+	tt.expectRolloutAfter(c, &tt.now)
+}
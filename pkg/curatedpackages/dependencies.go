@@ -0,0 +1,229 @@
+package curatedpackages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const packageInstallTimeout = "10m"
+
+// InstallPlan is the ordered list of packages to install for a package, dependencies first.
+// The requested package is always the last step.
+type InstallPlan struct {
+	Steps []InstallStep
+}
+
+// InstallStep is a package in an InstallPlan.
+type InstallStep struct {
+	Package      packagesv1.BundlePackage
+	Version      string
+	Dependencies []string
+	// Installed is true when the package is already installed in the cluster and will be skipped.
+	Installed bool
+}
+
+// ResolveDependencies returns the packages needed to install bp in install order, dependencies
+// first and bp last. Dependencies are read from the latest version of each package in the bundle.
+// It fails if a dependency is not in the bundle or if the dependencies form a cycle.
+func (pc *PackageClient) ResolveDependencies(bp *packagesv1.BundlePackage) ([]packagesv1.BundlePackage, error) {
+	r := &dependencyResolver{
+		packages: pc.packageMap(),
+		state:    map[string]visitState{},
+	}
+	if err := r.visit(*bp, nil); err != nil {
+		return nil, err
+	}
+	return r.ordered, nil
+}
+
+// PlanInstall validates the client custom configs against the schema of bp, then resolves the
+// dependencies of bp and marks the ones already installed in the cluster.
+func (pc *PackageClient) PlanInstall(ctx context.Context, bp *packagesv1.BundlePackage, clusterName, kubeConfig string) (*InstallPlan, error) {
+	if _, err := pc.validateInstallConfig(bp); err != nil {
+		return nil, err
+	}
+
+	ordered, err := pc.ResolveDependencies(bp)
+	if err != nil {
+		return nil, err
+	}
+
+	installed, err := pc.installedPackages(ctx, clusterName, kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &InstallPlan{}
+	for i, p := range ordered {
+		version, err := latestVersion(p)
+		if err != nil {
+			return nil, err
+		}
+		// The requested package is always installed, even if another instance of it exists.
+		isDependency := i < len(ordered)-1
+		plan.Steps = append(plan.Steps, InstallStep{
+			Package:      p,
+			Version:      version.Name,
+			Dependencies: version.Dependencies,
+			Installed:    isDependency && installed[strings.ToLower(p.Name)],
+		})
+	}
+	return plan, nil
+}
+
+// DisplayInstallPlan pretty-prints the steps of an install plan.
+func (pc *PackageClient) DisplayInstallPlan(w io.Writer, plan *InstallPlan) error {
+	lines := append([][]string{}, installPlanHeaderLines...)
+	for i, s := range plan.Steps {
+		action := "install"
+		if s.Installed {
+			action = "skip (installed)"
+		}
+		deps := "-"
+		if len(s.Dependencies) > 0 {
+			deps = strings.Join(s.Dependencies, ", ")
+		}
+		lines = append(lines, []string{fmt.Sprint(i + 1), s.Package.Name, s.Version, deps, action})
+	}
+
+	tw := newCPTabwriter(w, nil)
+	defer tw.Flush()
+	return tw.writeTable(lines)
+}
+
+// installPlanHeaderLines pretties-up a table of install plan steps.
+var installPlanHeaderLines = [][]string{
+	{"Step", "Package", "Version", "Dependencies", "Action"},
+	{"----", "-------", "-------", "------------", "------"},
+}
+
+// InstallPackageWithDependencies installs the steps of plan in order. Dependencies are installed
+// with their default configuration and named after the package, and each one must reach the installed
+// state before the next step starts. The last step is the requested package, installed with
+// customName and the client custom configs, which are validated before any dependency is installed.
+func (pc *PackageClient) InstallPackageWithDependencies(ctx context.Context, plan *InstallPlan, customName, clusterName, kubeConfig string) error {
+	if len(plan.Steps) == 0 {
+		return nil
+	}
+
+	// Unknown keys were already reported when planning, only errors matter here.
+	requested := plan.Steps[len(plan.Steps)-1].Package
+	configString, err := pc.getInstallConfigurations()
+	if err != nil {
+		return err
+	}
+	if _, err = ValidatePackageConfig(&requested, "", configString); err != nil {
+		return err
+	}
+
+	dependencies := plan.Steps[:len(plan.Steps)-1]
+	for _, s := range dependencies {
+		if s.Installed {
+			logger.V(3).Info("Dependency already installed, skipping", "package", s.Package.Name)
+			continue
+		}
+
+		name := strings.ToLower(s.Package.Name)
+		logger.Info("Installing package dependency", "package", s.Package.Name)
+		if err := pc.createPackage(ctx, convertBundlePackageToPackage(s.Package, name, clusterName, pc.bundle.APIVersion, ""), kubeConfig); err != nil {
+			return fmt.Errorf("installing dependency %s: %v", s.Package.Name, err)
+		}
+		if err := pc.waitForPackageInstalled(ctx, name, clusterName, kubeConfig); err != nil {
+			return fmt.Errorf("waiting for dependency %s to be installed: %v", s.Package.Name, err)
+		}
+	}
+
+	p := convertBundlePackageToPackage(requested, customName, clusterName, pc.bundle.APIVersion, configString)
+	return pc.createPackage(ctx, p, kubeConfig)
+}
+
+func (pc *PackageClient) installedPackages(ctx context.Context, clusterName, kubeConfig string) (map[string]bool, error) {
+	params := []string{"get", "packages", "--kubeconfig", kubeConfig, "--namespace", constants.EksaPackagesName + "-" + clusterName, "-o", "json"}
+	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("getting installed packages: %v", err)
+	}
+
+	list := &packagesv1.PackageList{}
+	if stdOut.Len() > 0 {
+		if err := json.Unmarshal(stdOut.Bytes(), list); err != nil {
+			return nil, fmt.Errorf("parsing installed packages: %v", err)
+		}
+	}
+
+	installed := make(map[string]bool, len(list.Items))
+	for _, p := range list.Items {
+		installed[strings.ToLower(p.Spec.PackageName)] = true
+	}
+	return installed, nil
+}
+
+func (pc *PackageClient) waitForPackageInstalled(ctx context.Context, name, clusterName, kubeConfig string) error {
+	params := []string{
+		"wait", "--for=jsonpath={.status.state}=" + string(packagesv1.StateInstalled), "packages/" + name,
+		"--timeout", packageInstallTimeout,
+		"--kubeconfig", kubeConfig,
+		"--namespace", constants.EksaPackagesName + "-" + clusterName,
+	}
+	_, err := pc.kubectl.ExecuteCommand(ctx, params...)
+	return err
+}
+
+func latestVersion(p packagesv1.BundlePackage) (packagesv1.SourceVersion, error) {
+	// Versions are ordered in the bundle so the first one is the latest.
+	if len(p.Source.Versions) == 0 {
+		return packagesv1.SourceVersion{}, fmt.Errorf("package %s has no versions in the bundle", p.Name)
+	}
+	return p.Source.Versions[0], nil
+}
+
+type visitState int
+
+const (
+	visiting visitState = iota + 1
+	visited
+)
+
+type dependencyResolver struct {
+	packages map[string]packagesv1.BundlePackage
+	state    map[string]visitState
+	ordered  []packagesv1.BundlePackage
+}
+
+// visit adds the dependencies of p and then p to the ordered list with a depth first traversal.
+// path holds the packages being visited to report cycles.
+func (r *dependencyResolver) visit(p packagesv1.BundlePackage, path []string) error {
+	key := strings.ToLower(p.Name)
+	path = append(path, p.Name)
+	switch r.state[key] {
+	case visited:
+		return nil
+	case visiting:
+		return fmt.Errorf("dependency cycle detected: %s", strings.Join(path, " -> "))
+	}
+
+	r.state[key] = visiting
+	version, err := latestVersion(p)
+	if err != nil {
+		return err
+	}
+	for _, dep := range version.Dependencies {
+		depPackage, ok := r.packages[strings.ToLower(dep)]
+		if !ok {
+			return fmt.Errorf("package %s depends on %s, which is not in the package bundle", p.Name, dep)
+		}
+		if err := r.visit(depPackage, path); err != nil {
+			return err
+		}
+	}
+	r.state[key] = visited
+	r.ordered = append(r.ordered, p)
+	return nil
+}
@@ -0,0 +1,178 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+)
+
+type dependencyTest struct {
+	*WithT
+	ctx     context.Context
+	kubectl *mocks.MockKubectlRunner
+	bundle  *packagesv1.PackageBundle
+	client  *curatedpackages.PackageClient
+}
+
+func newDependencyTest(t *testing.T, packages ...packagesv1.BundlePackage) *dependencyTest {
+	k := mocks.NewMockKubectlRunner(gomock.NewController(t))
+	bundle := &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{Packages: packages},
+	}
+	return &dependencyTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		kubectl: k,
+		bundle:  bundle,
+		client:  curatedpackages.NewPackageClient(k, curatedpackages.WithBundle(bundle)),
+	}
+}
+
+func bundlePackage(name string, dependencies ...string) packagesv1.BundlePackage {
+	return packagesv1.BundlePackage{
+		Name: name,
+		Source: packagesv1.BundlePackageSource{
+			Versions: []packagesv1.SourceVersion{
+				{Name: "1.0.0", Dependencies: dependencies},
+				{Name: "0.9.0"},
+			},
+		},
+	}
+}
+
+func packageNames(packages []packagesv1.BundlePackage) []string {
+	names := make([]string, 0, len(packages))
+	for _, p := range packages {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestResolveDependencies(t *testing.T) {
+	tt := newDependencyTest(t,
+		bundlePackage("harbor", "cert-manager", "redis"),
+		bundlePackage("redis", "storage"),
+		bundlePackage("cert-manager"),
+		bundlePackage("storage"),
+	)
+
+	got, err := tt.client.ResolveDependencies(&tt.bundle.Spec.Packages[0])
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(packageNames(got)).To(Equal([]string{"cert-manager", "storage", "redis", "harbor"}))
+}
+
+func TestResolveDependenciesSharedDependency(t *testing.T) {
+	tt := newDependencyTest(t,
+		bundlePackage("app", "a", "b"),
+		bundlePackage("a", "cert-manager"),
+		bundlePackage("b", "Cert-Manager"),
+		bundlePackage("cert-manager"),
+	)
+
+	got, err := tt.client.ResolveDependencies(&tt.bundle.Spec.Packages[0])
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(packageNames(got)).To(Equal([]string{"cert-manager", "a", "b", "app"}))
+}
+
+func TestResolveDependenciesMissingPackage(t *testing.T) {
+	tt := newDependencyTest(t, bundlePackage("harbor", "cert-manager"))
+
+	_, err := tt.client.ResolveDependencies(&tt.bundle.Spec.Packages[0])
+	tt.Expect(err).To(MatchError("package harbor depends on cert-manager, which is not in the package bundle"))
+}
+
+func TestResolveDependenciesCycle(t *testing.T) {
+	tt := newDependencyTest(t,
+		bundlePackage("a", "b"),
+		bundlePackage("b", "c"),
+		bundlePackage("c", "a"),
+	)
+
+	_, err := tt.client.ResolveDependencies(&tt.bundle.Spec.Packages[0])
+	tt.Expect(err).To(MatchError("dependency cycle detected: a -> b -> c -> a"))
+}
+
+func TestPlanInstallAndDisplay(t *testing.T) {
+	tt := newDependencyTest(t,
+		bundlePackage("harbor", "cert-manager", "redis"),
+		bundlePackage("redis"),
+		bundlePackage("cert-manager"),
+	)
+	installed := bytes.NewBufferString(`{"items":[{"metadata":{"name":"my-cert-manager"},"spec":{"packageName":"cert-manager"}}]}`)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packages", "--kubeconfig", "kubeconfig", "--namespace", "eksa-packages-billy", "-o", "json").
+		Return(*installed, nil)
+
+	plan, err := tt.client.PlanInstall(tt.ctx, &tt.bundle.Spec.Packages[0], "billy", "kubeconfig")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(plan.Steps).To(HaveLen(3))
+	tt.Expect(plan.Steps[0].Installed).To(BeTrue())
+	tt.Expect(plan.Steps[1].Installed).To(BeFalse())
+
+	buf := &bytes.Buffer{}
+	tt.Expect(tt.client.DisplayInstallPlan(buf, plan)).To(Succeed())
+	expected := "Step\t\tPackage\t\tVersion\t\tDependencies\t\tAction\t\t\n" +
+		"----\t\t-------\t\t-------\t\t------------\t\t------\t\t\n" +
+		"1\t\tcert-manager\t1.0.0\t\t-\t\t\tskip (installed)\n" +
+		"2\t\tredis\t\t1.0.0\t\t-\t\t\tinstall\t\t\n" +
+		"3\t\tharbor\t\t1.0.0\t\tcert-manager, redis\tinstall\t\t\n"
+	tt.Expect(buf.String()).To(Equal(expected))
+}
+
+func TestPlanInstallGetPackagesError(t *testing.T) {
+	tt := newDependencyTest(t, bundlePackage("harbor"))
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("connection refused"))
+
+	_, err := tt.client.PlanInstall(tt.ctx, &tt.bundle.Spec.Packages[0], "billy", "kubeconfig")
+	tt.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
+
+func TestInstallPackageWithDependencies(t *testing.T) {
+	tt := newDependencyTest(t)
+	plan := &curatedpackages.InstallPlan{
+		Steps: []curatedpackages.InstallStep{
+			{Package: bundlePackage("cert-manager"), Installed: true},
+			{Package: bundlePackage("Redis")},
+			{Package: bundlePackage("harbor", "cert-manager", "redis")},
+		},
+	}
+
+	gomock.InOrder(
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), "create", "-f", "-", "--kubeconfig", "kubeconfig").
+			DoAndReturn(func(_ context.Context, yaml []byte, _ ...string) (bytes.Buffer, error) {
+				tt.Expect(string(yaml)).To(ContainSubstring("name: redis\n"))
+				return bytes.Buffer{}, nil
+			}),
+		tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "wait", "--for=jsonpath={.status.state}=installed", "packages/redis",
+			"--timeout", "10m", "--kubeconfig", "kubeconfig", "--namespace", "eksa-packages-billy").Return(bytes.Buffer{}, nil),
+		tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), "create", "-f", "-", "--kubeconfig", "kubeconfig").
+			DoAndReturn(func(_ context.Context, yaml []byte, _ ...string) (bytes.Buffer, error) {
+				tt.Expect(string(yaml)).To(ContainSubstring("name: my-harbor\n"))
+				return bytes.Buffer{}, nil
+			}),
+	)
+
+	tt.Expect(tt.client.InstallPackageWithDependencies(tt.ctx, plan, "my-harbor", "billy", "kubeconfig")).To(Succeed())
+}
+
+func TestInstallPackageWithDependenciesWaitError(t *testing.T) {
+	tt := newDependencyTest(t)
+	plan := &curatedpackages.InstallPlan{
+		Steps: []curatedpackages.InstallStep{
+			{Package: bundlePackage("cert-manager")},
+			{Package: bundlePackage("harbor", "cert-manager")},
+		},
+	}
+	tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), gomock.Any()).Return(bytes.Buffer{}, nil)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("timed out"))
+
+	err := tt.client.InstallPackageWithDependencies(tt.ctx, plan, "my-harbor", "billy", "kubeconfig")
+	tt.Expect(err).To(MatchError("waiting for dependency cert-manager to be installed: timed out"))
+}
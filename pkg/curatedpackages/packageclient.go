@@ -12,6 +12,7 @@ import (
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
@@ -80,8 +81,12 @@ func (pc *PackageClient) GeneratePackages(clusterName string) ([]packagesv1.Pack
 		if !found {
 			return nil, fmt.Errorf("unknown package %q", p)
 		}
+		config, err := GenerateConfigSkeleton(&bundlePackage, "")
+		if err != nil {
+			return nil, err
+		}
 		name := CustomName + strings.ToLower(bundlePackage.Name)
-		packages = append(packages, convertBundlePackageToPackage(bundlePackage, name, clusterName, pc.bundle.APIVersion, ""))
+		packages = append(packages, convertBundlePackageToPackage(bundlePackage, name, clusterName, pc.bundle.APIVersion, config))
 	}
 	return packages, nil
 }
@@ -118,19 +123,38 @@ func (pc *PackageClient) packageMap() map[string]packagesv1.BundlePackage {
 }
 
 func (pc *PackageClient) InstallPackage(ctx context.Context, bp *packagesv1.BundlePackage, customName string, clusterName string, kubeConfig string) error {
-	configString, err := pc.getInstallConfigurations()
+	configString, err := pc.validateInstallConfig(bp)
 	if err != nil {
 		return err
 	}
 
 	p := convertBundlePackageToPackage(*bp, customName, clusterName, pc.bundle.APIVersion, configString)
-	displayPackage := NewDisplayablePackage(&p)
-	params := []string{"create", "-f", "-", "--kubeconfig", kubeConfig}
-	packageYaml, err := yaml.Marshal(displayPackage)
+	return pc.createPackage(ctx, p, kubeConfig)
+}
+
+// validateInstallConfig builds the configuration from the client custom configs and validates it
+// against the schema of the latest version of bp, logging the keys the schema doesn't define.
+func (pc *PackageClient) validateInstallConfig(bp *packagesv1.BundlePackage) (string, error) {
+	configString, err := pc.getInstallConfigurations()
+	if err != nil {
+		return "", err
+	}
+	warnings, err := ValidatePackageConfig(bp, "", configString)
+	if err != nil {
+		return "", err
+	}
+	for _, w := range warnings {
+		logger.Info("Warning: configuration key not defined in the package schema", "package", bp.Name, "warning", w)
+	}
+	return configString, nil
+}
+
+func (pc *PackageClient) createPackage(ctx context.Context, p packagesv1.Package, kubeConfig string) error {
+	packageYaml, err := yaml.Marshal(NewDisplayablePackage(&p))
 	if err != nil {
 		return err
 	}
-	stdOut, err := pc.kubectl.ExecuteFromYaml(ctx, packageYaml, params...)
+	stdOut, err := pc.kubectl.ExecuteFromYaml(ctx, packageYaml, "create", "-f", "-", "--kubeconfig", kubeConfig)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,355 @@
+package curatedpackages
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+const configRootPath = "config"
+
+// packageSchema is the subset of JSON schema used by curated packages to describe their configuration.
+type packageSchema struct {
+	Type                 schemaTypes               `json:"type,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Default              interface{}               `json:"default,omitempty"`
+	Enum                 []interface{}             `json:"enum,omitempty"`
+	Properties           map[string]*packageSchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *additionalProperties     `json:"additionalProperties,omitempty"`
+	Items                *packageSchema            `json:"items,omitempty"`
+}
+
+// schemaTypes holds the allowed types of a value, which can be a single type or a list in JSON schema.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings: %v", err)
+	}
+	*t = list
+	return nil
+}
+
+// additionalProperties is either a boolean or the schema of the properties not listed in properties.
+type additionalProperties struct {
+	allowed bool
+	schema  *packageSchema
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	a.schema = &packageSchema{}
+	return json.Unmarshal(data, a.schema)
+}
+
+// ValidatePackageConfig validates the configuration of a package against the JSON schema shipped with
+// version in the bundle package, or with the latest version if version is empty. Unknown keys are reported
+// with the closest valid key: as errors where the schema sets additionalProperties to false, and as returned
+// warnings where it doesn't restrict them. Versions without a schema accept any configuration.
+func ValidatePackageConfig(bp *packagesv1.BundlePackage, version, config string) (warnings []string, err error) {
+	schema, err := getPackageSchema(bp, version)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil || strings.TrimSpace(config) == "" {
+		return nil, nil
+	}
+
+	var values interface{}
+	if err := yaml.Unmarshal([]byte(config), &values); err != nil {
+		return nil, fmt.Errorf("parsing configuration for package %s: %v", bp.Name, err)
+	}
+	if values == nil {
+		return nil, nil
+	}
+
+	r := &schemaValidation{}
+	schema.validate(configRootPath, values, r)
+	if len(r.errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration for package %s: %v", bp.Name, kerrors.NewAggregate(r.errs))
+	}
+	return r.warnings, nil
+}
+
+// GenerateConfigSkeleton returns a YAML configuration for a package where every key of its schema is commented
+// out, with its description and default value. It returns an empty string if the package version has no schema.
+func GenerateConfigSkeleton(bp *packagesv1.BundlePackage, version string) (string, error) {
+	schema, err := getPackageSchema(bp, version)
+	if err != nil || schema == nil {
+		return "", err
+	}
+
+	b := &strings.Builder{}
+	schema.writeSkeleton(b, "")
+	return b.String(), nil
+}
+
+func getPackageSchema(bp *packagesv1.BundlePackage, version string) (*packageSchema, error) {
+	v, err := sourceVersion(bp, version)
+	if err != nil {
+		return nil, err
+	}
+	if v.Schema == "" {
+		return nil, nil
+	}
+
+	raw, err := bp.GetJsonSchema(&v)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema for package %s: %v", bp.Name, err)
+	}
+	schema := &packageSchema{}
+	if err := json.Unmarshal(raw, schema); err != nil {
+		return nil, fmt.Errorf("parsing schema for package %s: %v", bp.Name, err)
+	}
+	return schema, nil
+}
+
+func sourceVersion(bp *packagesv1.BundlePackage, version string) (packagesv1.SourceVersion, error) {
+	if version == "" {
+		return latestVersion(*bp)
+	}
+	for _, v := range bp.Source.Versions {
+		if v.Name == version {
+			return v, nil
+		}
+	}
+	return packagesv1.SourceVersion{}, fmt.Errorf("version %s of package %s not found in the bundle", version, bp.Name)
+}
+
+// schemaValidation collects the errors and warnings found validating a configuration.
+type schemaValidation struct {
+	errs     []error
+	warnings []string
+}
+
+func (s *packageSchema) validate(path string, value interface{}, r *schemaValidation) {
+	if err := s.validateType(path, value); err != nil {
+		r.errs = append(r.errs, err)
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		r.errs = append(r.errs, fmt.Errorf("%s: value %v is not one of %v", path, value, s.Enum))
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateProperties(path, v, r)
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, r)
+			}
+		}
+	}
+}
+
+func (s *packageSchema) validateProperties(path string, values map[string]interface{}, r *schemaValidation) {
+	for _, key := range s.Required {
+		if _, ok := values[key]; !ok {
+			r.errs = append(r.errs, fmt.Errorf("%s: missing required key %q", path, key))
+		}
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := childPath(path, key)
+		if property, ok := s.Properties[key]; ok {
+			property.validate(keyPath, values[key], r)
+			continue
+		}
+
+		switch {
+		case s.AdditionalProperties == nil:
+			// JSON schema allows any other key by default, but a key that isn't listed is most likely a typo.
+			if len(s.Properties) > 0 {
+				r.warnings = append(r.warnings, s.unknownKey(path, key).Error())
+			}
+		case !s.AdditionalProperties.allowed:
+			r.errs = append(r.errs, s.unknownKey(path, key))
+		case s.AdditionalProperties.schema != nil:
+			s.AdditionalProperties.schema.validate(keyPath, values[key], r)
+		}
+	}
+}
+
+func (s *packageSchema) unknownKey(path, key string) error {
+	keyPath := childPath(path, key)
+	if suggestion := closestKey(key, s.propertyNames()); suggestion != "" {
+		return fmt.Errorf("%s: unknown key, did you mean %q?", keyPath, childPath(path, suggestion))
+	}
+	return fmt.Errorf("%s: unknown key", keyPath)
+}
+
+func (s *packageSchema) validateType(path string, value interface{}) error {
+	if len(s.Type) == 0 {
+		return nil
+	}
+	for _, t := range s.Type {
+		if matchesType(t, value) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(s.Type, " or "), valueType(value))
+}
+
+func (s *packageSchema) propertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeSkeleton writes each property as a commented out line with its default value,
+// preceded by its description. Nested objects are written indented under their key.
+func (s *packageSchema) writeSkeleton(b *strings.Builder, indent string) {
+	for _, name := range s.propertyNames() {
+		property := s.Properties[name]
+		if property.Description != "" {
+			fmt.Fprintf(b, "# %s# %s\n", indent, property.Description)
+		}
+		if len(property.Properties) > 0 {
+			fmt.Fprintf(b, "# %s%s:\n", indent, name)
+			property.writeSkeleton(b, indent+"  ")
+			continue
+		}
+		fmt.Fprintf(b, "# %s%s:%s\n", indent, name, skeletonValue(property.Default))
+	}
+}
+
+func skeletonValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	// JSON is valid YAML, and keeps strings quoted so they are not read as other types.
+	v, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return " " + string(v)
+}
+
+func matchesType(t string, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case float64:
+		return t == "number" || (t == "integer" && v == float64(int64(v)))
+	case string:
+		// Values set with --set are strings unless they are booleans, so accept strings
+		// that parse to the expected numeric type.
+		switch t {
+		case "string":
+			return true
+		case "integer":
+			_, err := strconv.ParseInt(v, 10, 64)
+			return err == nil
+		case "number":
+			_, err := strconv.ParseFloat(v, 64)
+			return err == nil
+		}
+		return false
+	case map[string]interface{}:
+		return t == "object"
+	case []interface{}:
+		return t == "array"
+	}
+	return false
+}
+
+func valueType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func childPath(path, key string) string {
+	if path == configRootPath {
+		return key
+	}
+	return path + "." + key
+}
+
+// closestKey returns the candidate with the smallest edit distance to key,
+// or an empty string if none is close enough to be a likely typo.
+func closestKey(key string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(key), strings.ToLower(c))
+		if bestDistance == -1 || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if bestDistance == -1 || (bestDistance > 2 && bestDistance > len(key)/3) {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,338 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+const testPackageSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "sourceRegistry": {
+      "type": "string",
+      "default": "public.ecr.aws/eks-anywhere",
+      "description": "Source registry for package."
+    },
+    "replicas": {
+      "type": "integer",
+      "default": 1
+    },
+    "mode": {
+      "type": "string",
+      "enum": ["standalone", "cluster"]
+    },
+    "server": {
+      "type": "object",
+      "description": "Server settings.",
+      "properties": {
+        "port": {
+          "type": "integer",
+          "default": 8080,
+          "description": "Port of the server."
+        },
+        "tls": {
+          "type": "boolean"
+        }
+      },
+      "required": ["port"],
+      "additionalProperties": false
+    },
+    "labels": {
+      "type": "object",
+      "additionalProperties": {"type": "string"}
+    }
+  },
+  "additionalProperties": false
+}`
+
+func encodeSchema(t *testing.T, schema string) string {
+	b := &bytes.Buffer{}
+	w := gzip.NewWriter(b)
+	if _, err := w.Write([]byte(schema)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes())
+}
+
+func schemaPackage(t *testing.T) *packagesv1.BundlePackage {
+	return &packagesv1.BundlePackage{
+		Name: "hello-eks-anywhere",
+		Source: packagesv1.BundlePackageSource{
+			Versions: []packagesv1.SourceVersion{
+				{Name: "0.2.0", Schema: encodeSchema(t, testPackageSchema)},
+				{Name: "0.1.0"},
+			},
+		},
+	}
+}
+
+func TestValidatePackageConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		config  string
+		wantErr string
+	}{
+		{
+			name:   "empty config",
+			config: "",
+		},
+		{
+			name:   "valid config",
+			config: "sourceRegistry: my-registry\nreplicas: 3\nmode: cluster\nserver:\n  port: 443\n  tls: true\nlabels:\n  team: a\n",
+		},
+		{
+			name:   "numbers set as strings",
+			config: "replicas: \"3\"\n",
+		},
+		{
+			name:    "unknown key with suggestion",
+			config:  "sourceRegistr: my-registry\n",
+			wantErr: `invalid configuration for package hello-eks-anywhere: sourceRegistr: unknown key, did you mean "sourceRegistry"?`,
+		},
+		{
+			name:    "unknown nested key with suggestion",
+			config:  "server:\n  port: 443\n  prot: 80\n",
+			wantErr: `invalid configuration for package hello-eks-anywhere: server.prot: unknown key, did you mean "server.port"?`,
+		},
+		{
+			name:    "unknown key without suggestion",
+			config:  "somethingElse: true\n",
+			wantErr: "invalid configuration for package hello-eks-anywhere: somethingElse: unknown key",
+		},
+		{
+			name:    "wrong type",
+			config:  "replicas: many\n",
+			wantErr: "invalid configuration for package hello-eks-anywhere: replicas: expected integer, got string",
+		},
+		{
+			name:    "wrong additional property type",
+			config:  "labels:\n  team: true\n",
+			wantErr: "invalid configuration for package hello-eks-anywhere: labels.team: expected string, got boolean",
+		},
+		{
+			name:    "value not in enum",
+			config:  "mode: ha\n",
+			wantErr: "invalid configuration for package hello-eks-anywhere: mode: value ha is not one of [standalone cluster]",
+		},
+		{
+			name:    "missing required key",
+			config:  "server:\n  tls: false\n",
+			wantErr: `invalid configuration for package hello-eks-anywhere: server: missing required key "port"`,
+		},
+		{
+			name:    "multiple errors",
+			config:  "replicas: many\nmode: ha\n",
+			wantErr: "invalid configuration for package hello-eks-anywhere: [mode: value ha is not one of [standalone cluster], replicas: expected integer, got string]",
+		},
+		{
+			name:    "version without schema",
+			version: "0.1.0",
+			config:  "anything: goes\n",
+		},
+		{
+			name:    "unknown version",
+			version: "0.3.0",
+			config:  "replicas: 1\n",
+			wantErr: "version 0.3.0 of package hello-eks-anywhere not found in the bundle",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			warnings, err := curatedpackages.ValidatePackageConfig(schemaPackage(t), tc.version, tc.config)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
+const testOpenPackageSchema = `{
+  "type": "object",
+  "properties": {
+    "sourceRegistry": {
+      "type": "string"
+    },
+    "server": {
+      "type": "object",
+      "properties": {
+        "port": {
+          "type": "integer"
+        }
+      }
+    },
+    "extraArgs": {
+      "type": "object"
+    }
+  }
+}`
+
+func TestValidatePackageConfigUnrestrictedKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       string
+		wantWarnings []string
+		wantErr      string
+	}{
+		{
+			name:   "valid config",
+			config: "sourceRegistry: my-registry\nserver:\n  port: 443\n",
+		},
+		{
+			name:         "unknown key with suggestion",
+			config:       "sourceRegistr: my-registry\n",
+			wantWarnings: []string{`sourceRegistr: unknown key, did you mean "sourceRegistry"?`},
+		},
+		{
+			name:         "unknown nested keys",
+			config:       "server:\n  prot: 80\n  somethingElse: true\n",
+			wantWarnings: []string{`server.prot: unknown key, did you mean "server.port"?`, "server.somethingElse: unknown key"},
+		},
+		{
+			name:   "object without properties",
+			config: "extraArgs:\n  anything: goes\n",
+		},
+		{
+			name:    "errors take precedence",
+			config:  "sourceRegistr: my-registry\nserver:\n  port: many\n",
+			wantErr: "invalid configuration for package hello-eks-anywhere: server.port: expected integer, got string",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			bp := &packagesv1.BundlePackage{
+				Name: "hello-eks-anywhere",
+				Source: packagesv1.BundlePackageSource{
+					Versions: []packagesv1.SourceVersion{{Name: "0.1.0", Schema: encodeSchema(t, testOpenPackageSchema)}},
+				},
+			}
+
+			warnings, err := curatedpackages.ValidatePackageConfig(bp, "", tc.config)
+			if tc.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.wantErr))
+			}
+			g.Expect(warnings).To(Equal(tc.wantWarnings))
+		})
+	}
+}
+
+func TestValidatePackageConfigInvalidSchema(t *testing.T) {
+	g := NewWithT(t)
+	bp := &packagesv1.BundlePackage{
+		Name: "hello-eks-anywhere",
+		Source: packagesv1.BundlePackageSource{
+			Versions: []packagesv1.SourceVersion{{Name: "0.1.0", Schema: "not-base64"}},
+		},
+	}
+
+	_, err := curatedpackages.ValidatePackageConfig(bp, "", "replicas: 1\n")
+	g.Expect(err).To(MatchError(ContainSubstring("reading schema for package hello-eks-anywhere")))
+}
+
+func TestGenerateConfigSkeleton(t *testing.T) {
+	g := NewWithT(t)
+
+	got, err := curatedpackages.GenerateConfigSkeleton(schemaPackage(t), "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(`# labels:
+# mode:
+# replicas: 1
+# # Server settings.
+# server:
+#   # Port of the server.
+#   port: 8080
+#   tls:
+# # Source registry for package.
+# sourceRegistry: "public.ecr.aws/eks-anywhere"
+`))
+}
+
+func TestGenerateConfigSkeletonNoSchema(t *testing.T) {
+	g := NewWithT(t)
+
+	got, err := curatedpackages.GenerateConfigSkeleton(schemaPackage(t), "0.1.0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(BeEmpty())
+}
+
+func TestGeneratePackagesConfigSkeleton(t *testing.T) {
+	g := NewWithT(t)
+	bundle := &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{Packages: []packagesv1.BundlePackage{*schemaPackage(t)}},
+	}
+	client := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(bundle), curatedpackages.WithCustomPackages([]string{"hello-eks-anywhere"}))
+
+	packages, err := client.GeneratePackages("billy")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(packages).To(HaveLen(1))
+	g.Expect(packages[0].Spec.Config).To(ContainSubstring("# sourceRegistry: \"public.ecr.aws/eks-anywhere\"\n"))
+
+	// The skeleton is all comments, so the generated package is installed with the default configuration.
+	var values interface{}
+	g.Expect(yaml.Unmarshal([]byte(packages[0].Spec.Config), &values)).To(Succeed())
+	g.Expect(values).To(BeNil())
+}
+
+func TestInstallPackageInvalidConfig(t *testing.T) {
+	g := NewWithT(t)
+	bp := schemaPackage(t)
+	bundle := &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{Packages: []packagesv1.BundlePackage{*bp}},
+	}
+	client := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(bundle), curatedpackages.WithCustomConfigs([]string{"titel=hello"}))
+
+	err := client.InstallPackage(context.Background(), bp, "my-hello", "billy", "kubeconfig")
+	g.Expect(err).To(MatchError("invalid configuration for package hello-eks-anywhere: titel: unknown key"))
+}
+
+func TestPlanInstallInvalidConfig(t *testing.T) {
+	g := NewWithT(t)
+	bp := schemaPackage(t)
+	bundle := &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{Packages: []packagesv1.BundlePackage{*bp}},
+	}
+	client := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(bundle), curatedpackages.WithCustomConfigs([]string{"titel=hello"}))
+
+	_, err := client.PlanInstall(context.Background(), bp, "billy", "kubeconfig")
+	g.Expect(err).To(MatchError("invalid configuration for package hello-eks-anywhere: titel: unknown key"))
+}
+
+func TestInstallPackageWithDependenciesInvalidConfig(t *testing.T) {
+	g := NewWithT(t)
+	bp := schemaPackage(t)
+	bp.Source.Versions[0].Dependencies = []string{"cert-manager"}
+	bundle := &packagesv1.PackageBundle{
+		Spec: packagesv1.PackageBundleSpec{Packages: []packagesv1.BundlePackage{*bp}},
+	}
+	client := curatedpackages.NewPackageClient(nil, curatedpackages.WithBundle(bundle), curatedpackages.WithCustomConfigs([]string{"titel=hello"}))
+	plan := &curatedpackages.InstallPlan{
+		Steps: []curatedpackages.InstallStep{
+			{Package: packagesv1.BundlePackage{Name: "cert-manager"}},
+			{Package: *bp},
+		},
+	}
+
+	// The kubectl runner is nil, so installing the dependency before validating would panic.
+	err := client.InstallPackageWithDependencies(context.Background(), plan, "my-hello", "billy", "kubeconfig")
+	g.Expect(err).To(MatchError("invalid configuration for package hello-eks-anywhere: titel: unknown key"))
+}
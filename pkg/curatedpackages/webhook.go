@@ -0,0 +1,104 @@
+package curatedpackages
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+// PackageConfigValidator validates the configuration of curated packages against the schema of the
+// package version in the active bundle of their cluster, with the same checks as install package.
+type PackageConfigValidator struct {
+	client client.Reader
+}
+
+// NewPackageConfigValidator returns a PackageConfigValidator that reads bundles with client.
+func NewPackageConfigValidator(client client.Reader) *PackageConfigValidator {
+	return &PackageConfigValidator{client: client}
+}
+
+// SetupWebhookWithManager sets up the webhook manager for Packages.
+func (v *PackageConfigValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&packagesv1.Package{}).
+		WithValidator(v).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-packages-eks-amazonaws-com-v1alpha1-package,mutating=false,failurePolicy=fail,sideEffects=None,groups=packages.eks.amazonaws.com,resources=packages,verbs=create;update,versions=v1alpha1,name=validation.package.anywhere.amazonaws.com,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:rbac:groups=packages.eks.amazonaws.com,resources=packagebundlecontrollers;packagebundles,verbs=get
+
+var _ webhook.CustomValidator = &PackageConfigValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *PackageConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	p, ok := obj.(*packagesv1.Package)
+	if !ok {
+		return nil, fmt.Errorf("expected a Package but got %T", obj)
+	}
+	return v.validate(ctx, p)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *PackageConfigValidator) ValidateUpdate(ctx context.Context, _, obj runtime.Object) (admission.Warnings, error) {
+	p, ok := obj.(*packagesv1.Package)
+	if !ok {
+		return nil, fmt.Errorf("expected a Package but got %T", obj)
+	}
+	return v.validate(ctx, p)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (v *PackageConfigValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the configuration of p. Packages outside a cluster namespace or in a cluster without
+// an active bundle are accepted and left to the packages controller webhook, which rejects them.
+func (v *PackageConfigValidator) validate(ctx context.Context, p *packagesv1.Package) (admission.Warnings, error) {
+	clusterName := p.GetClusterName()
+	if clusterName == "" {
+		return nil, nil
+	}
+
+	pbc := &packagesv1.PackageBundleController{}
+	err := v.client.Get(ctx, client.ObjectKey{Namespace: packagesv1.PackageNamespace, Name: clusterName}, pbc)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting package bundle controller for cluster %s: %v", clusterName, err)
+	}
+	if pbc.Spec.ActiveBundle == "" {
+		return nil, nil
+	}
+
+	bundle := &packagesv1.PackageBundle{}
+	if err = v.client.Get(ctx, client.ObjectKey{Namespace: packagesv1.PackageNamespace, Name: pbc.Spec.ActiveBundle}, bundle); err != nil {
+		return nil, fmt.Errorf("getting active package bundle for cluster %s: %v", clusterName, err)
+	}
+
+	bp, err := bundle.FindPackage(p.Spec.PackageName)
+	if err != nil {
+		return nil, err
+	}
+	// The packages controller accepts a version name or digest, the schema is looked up by name.
+	version := ""
+	if p.Spec.PackageVersion != "" && p.Spec.PackageVersion != packagesv1.Latest {
+		sv, err := bundle.FindVersion(bp, p.Spec.PackageVersion)
+		if err != nil {
+			return nil, err
+		}
+		version = sv.Name
+	}
+
+	return ValidatePackageConfig(&bp, version, p.Spec.Config)
+}
@@ -0,0 +1,104 @@
+package curatedpackages_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func newPackageConfigValidator(t *testing.T, objs ...runtime.Object) *curatedpackages.PackageConfigValidator {
+	scheme := runtime.NewScheme()
+	if err := packagesv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+	return curatedpackages.NewPackageConfigValidator(c)
+}
+
+func activeBundleObjects(t *testing.T) []runtime.Object {
+	return []runtime.Object{
+		&packagesv1.PackageBundleController{
+			ObjectMeta: metav1.ObjectMeta{Name: "billy", Namespace: packagesv1.PackageNamespace},
+			Spec:       packagesv1.PackageBundleControllerSpec{ActiveBundle: "v1-28-1"},
+		},
+		&packagesv1.PackageBundle{
+			ObjectMeta: metav1.ObjectMeta{Name: "v1-28-1", Namespace: packagesv1.PackageNamespace},
+			Spec:       packagesv1.PackageBundleSpec{Packages: []packagesv1.BundlePackage{*schemaPackage(t)}},
+		},
+	}
+}
+
+func testPackage(version, config string) *packagesv1.Package {
+	return &packagesv1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-hello", Namespace: "eksa-packages-billy"},
+		Spec: packagesv1.PackageSpec{
+			PackageName:    "hello-eks-anywhere",
+			PackageVersion: version,
+			Config:         config,
+		},
+	}
+}
+
+func TestPackageConfigValidatorValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkg     *packagesv1.Package
+		wantErr string
+	}{
+		{
+			name: "valid config",
+			pkg:  testPackage("", "replicas: 2"),
+		},
+		{
+			name:    "unknown key",
+			pkg:     testPackage(packagesv1.Latest, "titel: hello"),
+			wantErr: "invalid configuration for package hello-eks-anywhere: titel: unknown key",
+		},
+		{
+			name: "version without schema",
+			pkg:  testPackage("0.1.0", "titel: hello"),
+		},
+		{
+			name:    "unknown version",
+			pkg:     testPackage("9.9.9", "replicas: 2"),
+			wantErr: "package version not found in bundle (v1-28-1): hello-eks-anywhere @ 9.9.9",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			v := newPackageConfigValidator(t, activeBundleObjects(t)...)
+
+			_, err := v.ValidateCreate(context.Background(), tt.pkg)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestPackageConfigValidatorValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	v := newPackageConfigValidator(t, activeBundleObjects(t)...)
+
+	_, err := v.ValidateUpdate(context.Background(), testPackage("", ""), testPackage("", "server:\n  prot: 80"))
+	g.Expect(err).To(MatchError(ContainSubstring("server.prot: unknown key")))
+}
+
+func TestPackageConfigValidatorNoActiveBundle(t *testing.T) {
+	g := NewWithT(t)
+	v := newPackageConfigValidator(t)
+
+	warnings, err := v.ValidateCreate(context.Background(), testPackage("", "titel: hello"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+}
@@ -0,0 +1,59 @@
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	cloudstackreconciler "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
+)
+
+// cloudstackProviderRegistration wires the CloudStack provider through the provider registry.
+// Its cluster Config entry is registered by the cluster package itself.
+func cloudstackProviderRegistration() ProviderRegistration {
+	return ProviderRegistration{
+		DatacenterKind: v1alpha1.CloudStackDatacenterKind,
+		WithDependencies: func(f *Factory, config ProviderConfig) {
+			f.WithKubectl().WithCloudStackValidatorRegistry(config.SkipIPCheck).WithWriter()
+		},
+		NewProvider: func(_ context.Context, deps *Dependencies, config ProviderConfig) (providers.Provider, error) {
+			datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(config.ClusterConfigFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", config.ClusterConfigFile, err)
+			}
+
+			execConfig, err := decoder.ParseCloudStackCredsFromEnv()
+			if err != nil {
+				return nil, fmt.Errorf("parsing CloudStack credentials: %v", err)
+			}
+			validator, err := deps.CloudStackValidatorRegistry.Get(execConfig)
+			if err != nil {
+				return nil, fmt.Errorf("building validator from exec config: %v", err)
+			}
+
+			return cloudstack.NewProvider(datacenterConfig, config.Cluster, deps.Kubectl, validator, deps.Writer, time.Now, logger.Get()), nil
+		},
+		CAPIProviderName: constants.CloudStackProviderName,
+		CAPINamespace:    constants.CapcSystemNamespace,
+		WithReconcilerDependencies: func(f *Factory) {
+			// The controller always runs the IP check.
+			f.WithCloudStackValidatorRegistry(false)
+		},
+		NewClusterReconciler: func(_ context.Context, deps ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			return cloudstackreconciler.New(
+				deps.Client,
+				deps.IPValidator,
+				deps.CNIReconciler,
+				deps.Tracker,
+				deps.Dependencies.CloudStackValidatorRegistry,
+			), nil
+		},
+	}
+}
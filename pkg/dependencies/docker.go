@@ -0,0 +1,48 @@
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+	dockerreconciler "github.com/aws/eks-anywhere/pkg/providers/docker/reconciler"
+)
+
+// dockerProviderRegistration wires the Docker provider through the provider registry.
+// Its cluster Config entry is registered by the cluster package itself.
+func dockerProviderRegistration() ProviderRegistration {
+	return ProviderRegistration{
+		DatacenterKind: v1alpha1.DockerDatacenterKind,
+		WithDependencies: func(f *Factory, _ ProviderConfig) {
+			f.WithDocker().WithKubectl()
+		},
+		NewProvider: func(_ context.Context, deps *Dependencies, config ProviderConfig) (providers.Provider, error) {
+			datacenterConfig, err := v1alpha1.GetDockerDatacenterConfig(config.ClusterConfigFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", config.ClusterConfigFile, err)
+			}
+
+			return docker.NewProvider(
+				datacenterConfig,
+				deps.DockerClient,
+				deps.Kubectl,
+				time.Now,
+			), nil
+		},
+		CAPIProviderName: constants.DockerProviderName,
+		CAPINamespace:    constants.CapdSystemNamespace,
+		NewClusterReconciler: func(_ context.Context, deps ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			return dockerreconciler.New(deps.Client, deps.CNIReconciler, deps.Tracker), nil
+		},
+		ControllerWatches: []client.Object{
+			&v1alpha1.DockerDatacenterConfig{},
+		},
+	}
+}
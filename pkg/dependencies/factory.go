@@ -38,14 +38,11 @@ import (
 	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
-	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
-	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/docker"
 	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
 	"github.com/aws/eks-anywhere/pkg/providers/snow"
-	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/validator"
 	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
@@ -79,7 +76,7 @@ type Dependencies struct {
 	AwsIamAuth                  *awsiamauth.Installer
 	ClusterManager              *clustermanager.ClusterManager
 	KubernetesRetrierClient     *clustermanager.KubernetesRetrierClient
-	Bootstrapper                *bootstrapper.Bootstrapper
+	Bootstrapper                interfaces.Bootstrapper
 	GitOpsFlux                  *flux.Flux
 	Git                         *gitfactory.GitTools
 	EksdInstaller               *eksd.Installer
@@ -135,13 +132,19 @@ func (d *Dependencies) Close(ctx context.Context) error {
 	return nil
 }
 
+// registryMirrorDialTimeout is how long to wait for a registry mirror to accept connections
+// before falling back to the next one.
+const registryMirrorDialTimeout = 5 * time.Second
+
 // ForSpec constructs a Factory using the bundle referenced by clusterSpec.
+// The CLI uses the first reachable registry mirror of the cluster, in order of preference.
 func ForSpec(clusterSpec *cluster.Spec) *Factory {
 	managementComponents := cluster.ManagementComponentsFromBundles(clusterSpec.Bundles)
 	eksaToolsImage := managementComponents.Eksa.CliTools
+	registryMirror := registrymirror.FromCluster(clusterSpec.Cluster).Failover(registrymirror.Reachable(registryMirrorDialTimeout))
 	return NewFactory().
 		UseExecutableImage(eksaToolsImage.VersionedImage()).
-		WithRegistryMirror(registrymirror.FromCluster(clusterSpec.Cluster)).
+		WithRegistryMirror(registryMirror).
 		UseProxyConfiguration(clusterSpec.Cluster.ProxyConfiguration()).
 		WithWriterFolder(clusterSpec.Cluster.Name).
 		WithDiagnosticCollectorImage(managementComponents.Eksa.DiagnosticCollector.VersionedImage())
@@ -155,6 +158,7 @@ type Factory struct {
 	proxyConfiguration       map[string]string
 	writerFolder             string
 	diagnosticCollectorImage string
+	providerRegistry         *ProviderRegistry
 	buildSteps               []buildStep
 	dependencies             Dependencies
 }
@@ -168,8 +172,9 @@ type executablesConfig struct {
 }
 
 type config struct {
-	bundlesOverride string
-	noTimeouts      bool
+	bundlesOverride     string
+	noTimeouts          bool
+	bootstrapKubeconfig string
 }
 
 type buildStep func(ctx context.Context) error
@@ -180,7 +185,8 @@ func NewFactory() *Factory {
 		executablesConfig: &executablesConfig{
 			useDockerContainer: executables.ExecutablesInDocker(),
 		},
-		buildSteps: make([]buildStep, 0),
+		providerRegistry: defaultProviderRegistry,
+		buildSteps:       make([]buildStep, 0),
 	}
 }
 
@@ -314,10 +320,10 @@ func (f *Factory) UseExecutablesDockerClient(client executables.DockerClient) *F
 	return f
 }
 
-// dockerLogin performs a docker login with the ENV VARS.
-func dockerLogin(ctx context.Context, registry string, docker executables.DockerClient) error {
-	username, password, _ := cliconfig.ReadCredentials()
-	err := docker.Login(ctx, registry, username, password)
+// dockerLogin performs a docker login to the registry mirror with the credentials from its ENV VARS.
+func dockerLogin(ctx context.Context, registryMirror *registrymirror.RegistryMirror, docker executables.DockerClient) error {
+	username, password, _ := registryMirror.ReadCredentials()
+	err := docker.Login(ctx, registryMirror.BaseRegistry, username, password)
 	if err != nil {
 		return err
 	}
@@ -330,7 +336,7 @@ func (f *Factory) WithDockerLogin() *Factory {
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.registryMirror != nil {
-			err := dockerLogin(ctx, f.registryMirror.BaseRegistry, f.executablesConfig.dockerClient)
+			err := dockerLogin(ctx, f.registryMirror, f.executablesConfig.dockerClient)
 			if err != nil {
 				return err
 			}
@@ -385,7 +391,7 @@ func (f *Factory) WithExecutableBuilder() *Factory {
 		}
 		if f.registryMirror != nil && f.registryMirror.Auth {
 			docker := f.executablesConfig.builder.BuildDockerExecutable()
-			err := dockerLogin(ctx, f.registryMirror.BaseRegistry, docker)
+			err := dockerLogin(ctx, f.registryMirror, docker)
 			if err != nil {
 				return err
 			}
@@ -426,24 +432,23 @@ type TinkerbellOptions struct {
 }
 
 // WithProvider initializes the provider dependency and adds to the build steps.
-func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, skipIPCheck bool, hardwareCSVPath string, force bool, tinkerbellBootstrapIP string, skippedValidations map[string]bool, opts *ProviderOptions) *Factory { // nolint:gocyclo
-	switch clusterConfig.Spec.DatacenterRef.Kind {
-	case v1alpha1.VSphereDatacenterKind:
-		f.WithKubectl().WithGovc().WithWriter().WithIPValidator()
-	case v1alpha1.CloudStackDatacenterKind:
-		f.WithKubectl().WithCloudStackValidatorRegistry(skipIPCheck).WithWriter()
-	case v1alpha1.DockerDatacenterKind:
-		f.WithDocker().WithKubectl()
-	case v1alpha1.TinkerbellDatacenterKind:
-		if clusterConfig.Spec.RegistryMirrorConfiguration != nil {
-			f.WithDocker().WithKubectl().WithWriter().WithHelm(helm.WithInsecure())
-		} else {
-			f.WithDocker().WithKubectl().WithWriter().WithHelm()
-		}
-	case v1alpha1.SnowDatacenterKind:
-		f.WithUnAuthKubeClient().WithSnowConfigManager()
-	case v1alpha1.NutanixDatacenterKind:
-		f.WithKubectl().WithNutanixClientCache().WithNutanixDefaulter().WithNutanixValidator().WithIPValidator()
+func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1.Cluster, skipIPCheck bool, hardwareCSVPath string, force bool, tinkerbellBootstrapIP string, skippedValidations map[string]bool, opts *ProviderOptions) *Factory {
+	config := ProviderConfig{
+		ClusterConfigFile:     clusterConfigFile,
+		Cluster:               clusterConfig,
+		SkipIPCheck:           skipIPCheck,
+		HardwareCSVPath:       hardwareCSVPath,
+		Force:                 force,
+		TinkerbellBootstrapIP: tinkerbellBootstrapIP,
+		SkippedValidations:    skippedValidations,
+		Options:               opts,
+		NoTimeouts:            f.config.noTimeouts,
+	}
+	kind := clusterConfig.Spec.DatacenterRef.Kind
+
+	p, ok := f.providerRegistry.Get(kind)
+	if ok && p.WithDependencies != nil {
+		p.WithDependencies(f, config)
 	}
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -451,145 +456,15 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 			return nil
 		}
 
-		switch clusterConfig.Spec.DatacenterRef.Kind {
-		case v1alpha1.VSphereDatacenterKind:
-			datacenterConfig, err := v1alpha1.GetVSphereDatacenterConfig(clusterConfigFile)
-			if err != nil {
-				return fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFile, err)
-			}
-
-			f.dependencies.Provider = vsphere.NewProvider(
-				datacenterConfig,
-				clusterConfig,
-				f.dependencies.Govc,
-				f.dependencies.Kubectl,
-				f.dependencies.Writer,
-				f.dependencies.IPValidator,
-				time.Now,
-				skipIPCheck,
-				skippedValidations,
-			)
-
-		case v1alpha1.CloudStackDatacenterKind:
-			datacenterConfig, err := v1alpha1.GetCloudStackDatacenterConfig(clusterConfigFile)
-			if err != nil {
-				return fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFile, err)
-			}
-
-			execConfig, err := decoder.ParseCloudStackCredsFromEnv()
-			if err != nil {
-				return fmt.Errorf("parsing CloudStack credentials: %v", err)
-			}
-			validator, err := f.dependencies.CloudStackValidatorRegistry.Get(execConfig)
-			if err != nil {
-				return fmt.Errorf("building validator from exec config: %v", err)
-			}
-			f.dependencies.Provider = cloudstack.NewProvider(datacenterConfig, clusterConfig, f.dependencies.Kubectl, validator, f.dependencies.Writer, time.Now, logger.Get())
-
-		case v1alpha1.SnowDatacenterKind:
-			f.dependencies.Provider = snow.NewProvider(
-				f.dependencies.UnAuthKubeClient,
-				f.dependencies.SnowConfigManager,
-				skipIPCheck,
-			)
-
-		case v1alpha1.TinkerbellDatacenterKind:
-			datacenterConfig, err := v1alpha1.GetTinkerbellDatacenterConfig(clusterConfigFile)
-			if err != nil {
-				return fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFile, err)
-			}
-
-			config, err := cluster.ParseConfigFromFile(clusterConfigFile)
-			if err != nil {
-				return fmt.Errorf("unable to get machine config from file %s: %v", clusterConfigFile, err)
-			}
-
-			machineConfigs := config.TinkerbellMachineConfigs
-			tinkerbellIP := tinkerbellBootstrapIP
-			if tinkerbellIP == "" {
-				logger.V(4).Info("Inferring local Tinkerbell Bootstrap IP from environment")
-				localIp, err := networkutils.GetLocalIP()
-				if err != nil {
-					return err
-				}
-				tinkerbellIP = localIp.String()
-			}
-			logger.V(4).Info("Tinkerbell IP", "tinkerbell-ip", tinkerbellIP)
-
-			// Set BMC timeout based on noTimeouts flag
-			bmcTimeout := 5 * time.Minute // Default 5 minutes
-			if f.config.noTimeouts {
-				bmcTimeout = 168 * time.Hour // 1 week, effectively no timeout
-			}
-
-			provider, err := tinkerbell.NewProvider(
-				datacenterConfig,
-				machineConfigs,
-				clusterConfig,
-				hardwareCSVPath,
-				f.dependencies.Writer,
-				f.dependencies.DockerClient,
-				f.dependencies.Helm,
-				f.dependencies.Kubectl,
-				tinkerbellIP,
-				time.Now,
-				force,
-				skipIPCheck,
-				bmcTimeout,
-			)
-			if err != nil {
-				return err
-			}
-			if opts != nil && opts.Tinkerbell != nil && opts.Tinkerbell.BMCOptions != nil {
-				provider.BMCOptions = opts.Tinkerbell.BMCOptions
-			}
-
-			f.dependencies.Provider = provider
-
-		case v1alpha1.DockerDatacenterKind:
-			datacenterConfig, err := v1alpha1.GetDockerDatacenterConfig(clusterConfigFile)
-			if err != nil {
-				return fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFile, err)
-			}
-
-			f.dependencies.Provider = docker.NewProvider(
-				datacenterConfig,
-				f.dependencies.DockerClient,
-				f.dependencies.Kubectl,
-				time.Now,
-			)
-		case v1alpha1.NutanixDatacenterKind:
-			datacenterConfig, err := v1alpha1.GetNutanixDatacenterConfig(clusterConfigFile)
-			if err != nil {
-				return fmt.Errorf("unable to get datacenter config from file %s: %v", clusterConfigFile, err)
-			}
-
-			config, err := cluster.ParseConfigFromFile(clusterConfigFile)
-			if err != nil {
-				return fmt.Errorf("unable to get machine config from file %s: %v", clusterConfigFile, err)
-			}
+		if !ok {
+			return fmt.Errorf("no provider support for datacenter kind: %s", kind)
+		}
 
-			machineConfigs := config.NutanixMachineConfigs
-			skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
-			skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-			httpClient := &http.Client{Transport: skipVerifyTransport}
-			provider := nutanix.NewProvider(
-				datacenterConfig,
-				machineConfigs,
-				clusterConfig,
-				f.dependencies.Kubectl,
-				f.dependencies.Writer,
-				f.dependencies.NutanixClientCache,
-				f.dependencies.IPValidator,
-				crypto.NewTlsValidator(),
-				httpClient,
-				time.Now,
-				skipIPCheck,
-			)
-			f.dependencies.Provider = provider
-		default:
-			return fmt.Errorf("no provider support for datacenter kind: %s", clusterConfig.Spec.DatacenterRef.Kind)
+		provider, err := p.NewProvider(ctx, &f.dependencies, config)
+		if err != nil {
+			return fmt.Errorf("building provider for datacenter kind %s: %v", kind, err)
 		}
+		f.dependencies.Provider = provider
 
 		return nil
 	})
@@ -798,14 +673,14 @@ func (f *Factory) WithClusterctl() *Factory {
 }
 
 func (f *Factory) WithFlux() *Factory {
-	f.WithExecutableBuilder()
+	f.WithExecutableBuilder().WithWriter()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Flux != nil {
 			return nil
 		}
 
-		f.dependencies.Flux = f.executablesConfig.builder.BuildFluxExecutable()
+		f.dependencies.Flux = f.executablesConfig.builder.BuildFluxExecutable(f.dependencies.Writer)
 		return nil
 	})
 
@@ -930,7 +805,7 @@ func (f *Factory) WithIPValidator() *Factory {
 }
 
 func (f *Factory) WithBootstrapper() *Factory {
-	f.WithKind().WithKubectl()
+	f.WithKind().WithKubectl().WithWriter()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dependencies.Bootstrapper != nil {
@@ -946,6 +821,15 @@ func (f *Factory) WithBootstrapper() *Factory {
 			)
 		}
 
+		if f.config.bootstrapKubeconfig != "" {
+			f.dependencies.Bootstrapper = bootstrapper.NewExistingCluster(
+				f.config.bootstrapKubeconfig,
+				f.dependencies.Kubectl,
+				f.dependencies.Writer,
+			)
+			return nil
+		}
+
 		f.dependencies.Bootstrapper = bootstrapper.New(
 			bootstrapper.NewRetrierClient(
 				f.dependencies.Kind,
@@ -959,6 +843,13 @@ func (f *Factory) WithBootstrapper() *Factory {
 	return f
 }
 
+// UseBootstrapKubeconfig configures the bootstrapper to use the existing cluster the kubeconfig
+// points to as bootstrap cluster, instead of creating a kind cluster.
+func (f *Factory) UseBootstrapKubeconfig(kubeconfig string) *Factory {
+	f.config.bootstrapKubeconfig = kubeconfig
+	return f
+}
+
 type clusterManagerClient struct {
 	*executables.Clusterctl
 	*clustermanager.KubernetesRetrierClient
@@ -12,6 +12,7 @@ import (
 
 	"github.com/aws/eks-anywhere/internal/test"
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
@@ -736,6 +737,18 @@ func TestFactoryBuildWithBootstrapperNoTimeout(t *testing.T) {
 	tt.Expect(deps.Bootstrapper).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithBootstrapperExistingCluster(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		UseBootstrapKubeconfig("bootstrap.kubeconfig").
+		WithBootstrapper().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Bootstrapper).To(BeAssignableToTypeOf(&bootstrapper.ExistingCluster{}))
+}
+
 func TestFactoryBuildWithEksdUpgraderNoTimeout(t *testing.T) {
 	tt := newTest(t, vsphere)
 	deps, err := dependencies.NewFactory().
@@ -0,0 +1,72 @@
+package dependencies
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/crypto"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	nutanixreconciler "github.com/aws/eks-anywhere/pkg/providers/nutanix/reconciler"
+)
+
+// nutanixProviderRegistration wires the Nutanix provider through the provider registry.
+// Its cluster Config entry is registered by the cluster package itself.
+func nutanixProviderRegistration() ProviderRegistration {
+	return ProviderRegistration{
+		DatacenterKind: v1alpha1.NutanixDatacenterKind,
+		WithDependencies: func(f *Factory, _ ProviderConfig) {
+			f.WithKubectl().WithNutanixClientCache().WithNutanixDefaulter().WithNutanixValidator().WithIPValidator()
+		},
+		NewProvider: func(_ context.Context, deps *Dependencies, config ProviderConfig) (providers.Provider, error) {
+			datacenterConfig, err := v1alpha1.GetNutanixDatacenterConfig(config.ClusterConfigFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", config.ClusterConfigFile, err)
+			}
+
+			clusterConfig, err := cluster.ParseConfigFromFile(config.ClusterConfigFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get machine config from file %s: %v", config.ClusterConfigFile, err)
+			}
+
+			skipVerifyTransport := http.DefaultTransport.(*http.Transport).Clone()
+			skipVerifyTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+			httpClient := &http.Client{Transport: skipVerifyTransport}
+
+			return nutanix.NewProvider(
+				datacenterConfig,
+				clusterConfig.NutanixMachineConfigs,
+				config.Cluster,
+				deps.Kubectl,
+				deps.Writer,
+				deps.NutanixClientCache,
+				deps.IPValidator,
+				crypto.NewTlsValidator(),
+				httpClient,
+				time.Now,
+				config.SkipIPCheck,
+			), nil
+		},
+		CAPIProviderName: constants.NutanixProviderName,
+		CAPINamespace:    constants.CapxSystemNamespace,
+		WithReconcilerDependencies: func(f *Factory) {
+			f.WithNutanixDefaulter().WithNutanixValidator()
+		},
+		NewClusterReconciler: func(_ context.Context, deps ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			return nutanixreconciler.New(
+				deps.Client,
+				deps.Dependencies.NutanixValidator,
+				deps.CNIReconciler,
+				deps.Tracker,
+				deps.IPValidator,
+			), nil
+		},
+	}
+}
@@ -0,0 +1,196 @@
+package dependencies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+// ProviderRegistration wires a provider into the CLI and controller factories. Providers are
+// resolved by the kind of the datacenter config referenced by the cluster, so a registered
+// provider, including one that lives outside this repository, doesn't need any change to the factories.
+type ProviderRegistration struct {
+	// DatacenterKind is the kind of the datacenter config handled by the provider.
+	DatacenterKind string
+	// ConfigEntry declares how the provider objects are parsed, defaulted, validated and
+	// retrieved in the cluster Config. It's registered by RegisterProvider. Optional.
+	ConfigEntry *cluster.ProviderEntry
+	// WithDependencies adds to the factory the dependencies the provider needs. Optional.
+	WithDependencies func(f *Factory, config ProviderConfig)
+	// NewProvider builds the CLI provider once the dependencies are built.
+	NewProvider func(ctx context.Context, deps *Dependencies, config ProviderConfig) (providers.Provider, error)
+
+	// CAPIProviderName is the name of the CAPI infrastructure provider the controller
+	// builds the cluster reconciler for. Required with NewClusterReconciler.
+	CAPIProviderName string
+	// CAPINamespace is the namespace the CAPI infrastructure provider runs in.
+	// Required with NewClusterReconciler.
+	CAPINamespace string
+	// WithReconcilerDependencies adds to the factory the dependencies the cluster reconciler
+	// needs, on top of the ones in ProviderReconcilerDependencies. Optional.
+	WithReconcilerDependencies func(f *Factory)
+	// NewClusterReconciler builds the cluster reconciler of the controller. Optional.
+	NewClusterReconciler func(ctx context.Context, deps ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error)
+	// ControllerWatches are the provider objects the cluster controller watches to reconcile
+	// the clusters referencing them. Only used with NewClusterReconciler.
+	ControllerWatches []client.Object
+}
+
+// ProviderConfig holds the CLI inputs a provider is built from.
+type ProviderConfig struct {
+	ClusterConfigFile     string
+	Cluster               *v1alpha1.Cluster
+	SkipIPCheck           bool
+	HardwareCSVPath       string
+	Force                 bool
+	TinkerbellBootstrapIP string
+	SkippedValidations    map[string]bool
+	Options               *ProviderOptions
+	NoTimeouts            bool
+}
+
+// ProviderReconcilerDependencies holds the shared dependencies a provider cluster reconciler is built from.
+type ProviderReconcilerDependencies struct {
+	Client        client.Client
+	CNIReconciler *cnireconciler.Reconciler
+	Tracker       *remote.ClusterCacheTracker
+	IPValidator   *clusters.IPValidator
+	Dependencies  *Dependencies
+}
+
+// registeredBuiltInProviders returns the built-in providers, which every ProviderRegistry starts with.
+func registeredBuiltInProviders() []ProviderRegistration {
+	return []ProviderRegistration{
+		dockerProviderRegistration(),
+		vsphereProviderRegistration(),
+		cloudstackProviderRegistration(),
+		tinkerbellProviderRegistration(),
+		snowProviderRegistration(),
+		nutanixProviderRegistration(),
+	}
+}
+
+// ProviderRegistry holds provider registrations indexed by datacenter kind.
+// It's safe for concurrent use.
+type ProviderRegistry struct {
+	mu             sync.RWMutex
+	byKind         map[string]ProviderRegistration
+	byCAPIProvider map[string]ProviderRegistration
+}
+
+// NewProviderRegistry builds a ProviderRegistry holding the built-in providers.
+func NewProviderRegistry() *ProviderRegistry {
+	r := &ProviderRegistry{
+		byKind:         map[string]ProviderRegistration{},
+		byCAPIProvider: map[string]ProviderRegistration{},
+	}
+	for _, p := range registeredBuiltInProviders() {
+		r.byKind[p.DatacenterKind] = p
+		r.byCAPIProvider[p.CAPIProviderName] = p
+	}
+
+	return r
+}
+
+// Register validates and records a provider registration.
+func (r *ProviderRegistry) Register(p ProviderRegistration) error {
+	if p.DatacenterKind == "" {
+		return errors.New("registering provider: datacenter kind can't be empty")
+	}
+	if p.NewProvider == nil {
+		return fmt.Errorf("registering provider %s: NewProvider can't be nil", p.DatacenterKind)
+	}
+	if p.NewClusterReconciler != nil {
+		if p.CAPIProviderName == "" || p.CAPINamespace == "" {
+			return fmt.Errorf("registering provider %s: CAPI provider name and namespace are required with a cluster reconciler", p.DatacenterKind)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byKind[p.DatacenterKind]; ok {
+		return fmt.Errorf("registering provider %s: datacenter kind is already registered", p.DatacenterKind)
+	}
+	if p.CAPIProviderName != "" {
+		if _, ok := r.byCAPIProvider[p.CAPIProviderName]; ok {
+			return fmt.Errorf("registering provider %s: CAPI provider %s is already registered", p.DatacenterKind, p.CAPIProviderName)
+		}
+		r.byCAPIProvider[p.CAPIProviderName] = p
+	}
+	r.byKind[p.DatacenterKind] = p
+
+	return nil
+}
+
+// Get returns the registration for a datacenter kind.
+func (r *ProviderRegistry) Get(datacenterKind string) (ProviderRegistration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.byKind[datacenterKind]
+	return p, ok
+}
+
+// GetByCAPIProviderName returns the registration for a CAPI infrastructure provider name.
+func (r *ProviderRegistry) GetByCAPIProviderName(name string) (ProviderRegistration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.byCAPIProvider[name]
+	return p, ok
+}
+
+// DatacenterKinds returns the registered datacenter kinds, sorted.
+func (r *ProviderRegistry) DatacenterKinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	kinds := make([]string, 0, len(r.byKind))
+	for kind := range r.byKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+var defaultProviderRegistry = NewProviderRegistry()
+
+// RegisterProvider records a provider in the default registry used by the factories and
+// its ConfigEntry in the default cluster Config manager and client builder.
+// It's meant to be called from the init function of the provider package, which is then
+// imported by the binary entrypoint.
+func RegisterProvider(p ProviderRegistration) error {
+	if err := defaultProviderRegistry.Register(p); err != nil {
+		return err
+	}
+
+	if p.ConfigEntry == nil {
+		return nil
+	}
+
+	return cluster.RegisterProviderEntry(p.ConfigEntry)
+}
+
+// DefaultProviderRegistry returns the registry factories use unless configured with WithProviderRegistry.
+func DefaultProviderRegistry() *ProviderRegistry {
+	return defaultProviderRegistry
+}
+
+// WithProviderRegistry configures the registry the factory resolves registered providers from.
+func (f *Factory) WithProviderRegistry(registry *ProviderRegistry) *Factory {
+	f.providerRegistry = registry
+	return f
+}
+
+// ProviderRegistry returns the registry the factory resolves registered providers from.
+func (f *Factory) ProviderRegistry() *ProviderRegistry {
+	return f.providerRegistry
+}
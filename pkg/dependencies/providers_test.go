@@ -0,0 +1,287 @@
+package dependencies_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	providermocks "github.com/aws/eks-anywhere/pkg/providers/mocks"
+)
+
+func newProviderRegistration(kind string, provider providers.Provider) dependencies.ProviderRegistration {
+	return dependencies.ProviderRegistration{
+		DatacenterKind: kind,
+		NewProvider: func(context.Context, *dependencies.Dependencies, dependencies.ProviderConfig) (providers.Provider, error) {
+			return provider, nil
+		},
+	}
+}
+
+func TestProviderRegistryRegister(t *testing.T) {
+	g := NewWithT(t)
+	r := dependencies.NewProviderRegistry()
+	openstack := newProviderRegistration("OpenStackDatacenterConfig", nil)
+	openstack.CAPIProviderName = "openstack"
+
+	g.Expect(r.Register(openstack)).To(Succeed())
+	g.Expect(r.Register(newProviderRegistration("HetznerDatacenterConfig", nil))).To(Succeed())
+
+	p, ok := r.Get("OpenStackDatacenterConfig")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.CAPIProviderName).To(Equal("openstack"))
+	p, ok = r.GetByCAPIProviderName("openstack")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.DatacenterKind).To(Equal("OpenStackDatacenterConfig"))
+	_, ok = r.Get("GCPDatacenterConfig")
+	g.Expect(ok).To(BeFalse())
+	g.Expect(r.DatacenterKinds()).To(Equal([]string{
+		"CloudStackDatacenterConfig",
+		"DockerDatacenterConfig",
+		"HetznerDatacenterConfig",
+		"NutanixDatacenterConfig",
+		"OpenStackDatacenterConfig",
+		"SnowDatacenterConfig",
+		"TinkerbellDatacenterConfig",
+		"VSphereDatacenterConfig",
+	}))
+}
+
+func TestProviderRegistryDocker(t *testing.T) {
+	g := NewWithT(t)
+	r := dependencies.NewProviderRegistry()
+
+	p, ok := r.Get(anywherev1.DockerDatacenterKind)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.NewProvider).NotTo(BeNil())
+	g.Expect(p.NewClusterReconciler).NotTo(BeNil())
+	g.Expect(p.ControllerWatches).To(ConsistOf(&anywherev1.DockerDatacenterConfig{}))
+	p, ok = r.GetByCAPIProviderName("docker")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(p.DatacenterKind).To(Equal(anywherev1.DockerDatacenterKind))
+	g.Expect(p.CAPINamespace).To(Equal("capd-system"))
+
+	g.Expect(r.Register(newProviderRegistration(anywherev1.DockerDatacenterKind, nil))).To(
+		MatchError("registering provider DockerDatacenterConfig: datacenter kind is already registered"),
+	)
+}
+
+func TestProviderRegistryBuiltIn(t *testing.T) {
+	tests := []struct {
+		kind          string
+		capiProvider  string
+		capiNamespace string
+	}{
+		{kind: anywherev1.VSphereDatacenterKind, capiProvider: "vsphere", capiNamespace: "capv-system"},
+		{kind: anywherev1.CloudStackDatacenterKind, capiProvider: "cloudstack", capiNamespace: "capc-system"},
+		{kind: anywherev1.TinkerbellDatacenterKind, capiProvider: "tinkerbell", capiNamespace: "capt-system"},
+		{kind: anywherev1.SnowDatacenterKind, capiProvider: "snow", capiNamespace: "capas-system"},
+		{kind: anywherev1.NutanixDatacenterKind, capiProvider: "nutanix", capiNamespace: "capx-system"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.kind, func(t *testing.T) {
+			g := NewWithT(t)
+			r := dependencies.NewProviderRegistry()
+
+			p, ok := r.Get(tc.kind)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(p.WithDependencies).NotTo(BeNil())
+			g.Expect(p.NewProvider).NotTo(BeNil())
+			g.Expect(p.NewClusterReconciler).NotTo(BeNil())
+			p, ok = r.GetByCAPIProviderName(tc.capiProvider)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(p.DatacenterKind).To(Equal(tc.kind))
+			g.Expect(p.CAPINamespace).To(Equal(tc.capiNamespace))
+		})
+	}
+}
+
+func TestRegisterProviderConfigEntry(t *testing.T) {
+	g := NewWithT(t)
+	registration := newProviderRegistration("RegisterProviderTestDatacenterConfig", nil)
+	registration.ConfigEntry = &cluster.ProviderEntry{
+		ConfigManagerEntry: &cluster.ConfigManagerEntry{
+			APIObjectMapping: map[string]cluster.APIObjectGenerator{
+				"RegisterProviderTestDatacenterConfig": func() cluster.APIObject {
+					return &anywherev1.DockerDatacenterConfig{}
+				},
+			},
+			Processors: []cluster.ParsedProcessor{
+				func(c *cluster.Config, o cluster.ObjectLookup) {
+					if datacenter := o.GetFromRef(c.Cluster.APIVersion, c.Cluster.Spec.DatacenterRef); datacenter != nil {
+						c.AddProviderObject(datacenter)
+					}
+				},
+			},
+		},
+	}
+
+	g.Expect(dependencies.RegisterProvider(registration)).To(Succeed())
+	_, ok := dependencies.DefaultProviderRegistry().Get("RegisterProviderTestDatacenterConfig")
+	g.Expect(ok).To(BeTrue())
+
+	config, err := cluster.ParseConfig([]byte(`apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: my-cluster
+spec:
+  datacenterRef:
+    kind: RegisterProviderTestDatacenterConfig
+    name: my-datacenter
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: RegisterProviderTestDatacenterConfig
+metadata:
+  name: my-datacenter
+`))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.ProviderObject(config.Cluster.APIVersion, config.Cluster.Spec.DatacenterRef)).NotTo(BeNil())
+}
+
+func TestProviderRegistryRegisterErrors(t *testing.T) {
+	newReconciler := func(context.Context, dependencies.ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+		return nil, nil
+	}
+
+	tests := []struct {
+		name         string
+		registration func() dependencies.ProviderRegistration
+		wantErr      string
+	}{
+		{
+			name: "empty kind",
+			registration: func() dependencies.ProviderRegistration {
+				return newProviderRegistration("", nil)
+			},
+			wantErr: "registering provider: datacenter kind can't be empty",
+		},
+		{
+			name: "no provider constructor",
+			registration: func() dependencies.ProviderRegistration {
+				return dependencies.ProviderRegistration{DatacenterKind: "OpenStackDatacenterConfig"}
+			},
+			wantErr: "registering provider OpenStackDatacenterConfig: NewProvider can't be nil",
+		},
+		{
+			name: "built-in kind",
+			registration: func() dependencies.ProviderRegistration {
+				return newProviderRegistration(anywherev1.VSphereDatacenterKind, nil)
+			},
+			wantErr: "registering provider VSphereDatacenterConfig: datacenter kind is already registered",
+		},
+		{
+			name: "reconciler without CAPI namespace",
+			registration: func() dependencies.ProviderRegistration {
+				p := newProviderRegistration("OpenStackDatacenterConfig", nil)
+				p.CAPIProviderName = "openstack"
+				p.NewClusterReconciler = newReconciler
+				return p
+			},
+			wantErr: "registering provider OpenStackDatacenterConfig: CAPI provider name and namespace are required with a cluster reconciler",
+		},
+		{
+			name: "built-in CAPI provider",
+			registration: func() dependencies.ProviderRegistration {
+				p := newProviderRegistration("OpenStackDatacenterConfig", nil)
+				p.CAPIProviderName = "vsphere"
+				p.CAPINamespace = "capv-system"
+				p.NewClusterReconciler = newReconciler
+				return p
+			},
+			wantErr: "registering provider OpenStackDatacenterConfig: CAPI provider vsphere is already registered",
+		},
+		{
+			name: "duplicated kind",
+			registration: func() dependencies.ProviderRegistration {
+				return newProviderRegistration("GCPDatacenterConfig", nil)
+			},
+			wantErr: "registering provider GCPDatacenterConfig: datacenter kind is already registered",
+		},
+		{
+			name: "duplicated CAPI provider",
+			registration: func() dependencies.ProviderRegistration {
+				p := newProviderRegistration("OpenStackDatacenterConfig", nil)
+				p.CAPIProviderName = "gcp"
+				return p
+			},
+			wantErr: "registering provider OpenStackDatacenterConfig: CAPI provider gcp is already registered",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := dependencies.NewProviderRegistry()
+			gcp := newProviderRegistration("GCPDatacenterConfig", nil)
+			gcp.CAPIProviderName = "gcp"
+			g.Expect(r.Register(gcp)).To(Succeed())
+
+			g.Expect(r.Register(tc.registration())).To(MatchError(tc.wantErr))
+		})
+	}
+}
+
+func TestFactoryBuildWithRegisteredProvider(t *testing.T) {
+	g := NewWithT(t)
+	clusterConfigFile := "testdata/cluster_invalid_provider.yaml"
+	clusterSpec := test.NewFullClusterSpec(t, clusterConfigFile)
+	provider := providermocks.NewMockProvider(gomock.NewController(t))
+
+	var config dependencies.ProviderConfig
+	withDependencies := 0
+	registration := newProviderRegistration("InvalidDatacenterConfig", provider)
+	registration.WithDependencies = func(f *dependencies.Factory, _ dependencies.ProviderConfig) {
+		withDependencies++
+		f.WithKubectl()
+	}
+	registration.NewProvider = func(_ context.Context, deps *dependencies.Dependencies, c dependencies.ProviderConfig) (providers.Provider, error) {
+		g.Expect(deps.Kubectl).NotTo(BeNil())
+		config = c
+		return provider, nil
+	}
+	registry := dependencies.NewProviderRegistry()
+	g.Expect(registry.Register(registration)).To(Succeed())
+
+	deps, err := dependencies.NewFactory().
+		WithProviderRegistry(registry).
+		WithLocalExecutables().
+		WithProvider(clusterConfigFile, clusterSpec.Cluster, true, "", false, "", map[string]bool{}, nil).
+		Build(context.Background())
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(deps.Provider).To(BeIdenticalTo(provider))
+	g.Expect(withDependencies).To(Equal(1))
+	g.Expect(config.ClusterConfigFile).To(Equal(clusterConfigFile))
+	g.Expect(config.Cluster).To(BeIdenticalTo(clusterSpec.Cluster))
+	g.Expect(config.SkipIPCheck).To(BeTrue())
+}
+
+func TestFactoryBuildWithRegisteredProviderError(t *testing.T) {
+	g := NewWithT(t)
+	clusterConfigFile := "testdata/cluster_invalid_provider.yaml"
+	clusterSpec := test.NewFullClusterSpec(t, clusterConfigFile)
+
+	registration := newProviderRegistration("InvalidDatacenterConfig", nil)
+	registration.NewProvider = func(context.Context, *dependencies.Dependencies, dependencies.ProviderConfig) (providers.Provider, error) {
+		return nil, errors.New("missing credentials")
+	}
+	registry := dependencies.NewProviderRegistry()
+	g.Expect(registry.Register(registration)).To(Succeed())
+
+	_, err := dependencies.NewFactory().
+		WithProviderRegistry(registry).
+		WithLocalExecutables().
+		WithProvider(clusterConfigFile, clusterSpec.Cluster, false, "", false, "", map[string]bool{}, nil).
+		Build(context.Background())
+
+	g.Expect(err).To(MatchError("building provider for datacenter kind InvalidDatacenterConfig: missing credentials"))
+}
@@ -0,0 +1,35 @@
+package dependencies
+
+import (
+	"context"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowreconciler "github.com/aws/eks-anywhere/pkg/providers/snow/reconciler"
+)
+
+// snowProviderRegistration wires the Snow provider through the provider registry.
+// Its cluster Config entry is registered by the cluster package itself.
+func snowProviderRegistration() ProviderRegistration {
+	return ProviderRegistration{
+		DatacenterKind: v1alpha1.SnowDatacenterKind,
+		WithDependencies: func(f *Factory, _ ProviderConfig) {
+			f.WithUnAuthKubeClient().WithSnowConfigManager()
+		},
+		NewProvider: func(_ context.Context, deps *Dependencies, config ProviderConfig) (providers.Provider, error) {
+			return snow.NewProvider(
+				deps.UnAuthKubeClient,
+				deps.SnowConfigManager,
+				config.SkipIPCheck,
+			), nil
+		},
+		CAPIProviderName: constants.SnowProviderName,
+		CAPINamespace:    constants.CapasSystemNamespace,
+		NewClusterReconciler: func(_ context.Context, deps ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			return snowreconciler.New(deps.Client, deps.CNIReconciler, deps.Tracker, deps.IPValidator), nil
+		},
+	}
+}
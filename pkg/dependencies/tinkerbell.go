@@ -0,0 +1,90 @@
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/helm"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	tinkerbellreconciler "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
+)
+
+// tinkerbellProviderRegistration wires the Tinkerbell provider through the provider registry.
+// Its cluster Config entry is registered by the cluster package itself.
+func tinkerbellProviderRegistration() ProviderRegistration {
+	return ProviderRegistration{
+		DatacenterKind: v1alpha1.TinkerbellDatacenterKind,
+		WithDependencies: func(f *Factory, config ProviderConfig) {
+			if config.Cluster.Spec.RegistryMirrorConfiguration != nil {
+				f.WithDocker().WithKubectl().WithWriter().WithHelm(helm.WithInsecure())
+			} else {
+				f.WithDocker().WithKubectl().WithWriter().WithHelm()
+			}
+		},
+		NewProvider: func(_ context.Context, deps *Dependencies, config ProviderConfig) (providers.Provider, error) {
+			datacenterConfig, err := v1alpha1.GetTinkerbellDatacenterConfig(config.ClusterConfigFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", config.ClusterConfigFile, err)
+			}
+
+			clusterConfig, err := cluster.ParseConfigFromFile(config.ClusterConfigFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get machine config from file %s: %v", config.ClusterConfigFile, err)
+			}
+
+			tinkerbellIP := config.TinkerbellBootstrapIP
+			if tinkerbellIP == "" {
+				logger.V(4).Info("Inferring local Tinkerbell Bootstrap IP from environment")
+				localIp, err := networkutils.GetLocalIP()
+				if err != nil {
+					return nil, err
+				}
+				tinkerbellIP = localIp.String()
+			}
+			logger.V(4).Info("Tinkerbell IP", "tinkerbell-ip", tinkerbellIP)
+
+			// Set BMC timeout based on noTimeouts flag
+			bmcTimeout := 5 * time.Minute // Default 5 minutes
+			if config.NoTimeouts {
+				bmcTimeout = 168 * time.Hour // 1 week, effectively no timeout
+			}
+
+			provider, err := tinkerbell.NewProvider(
+				datacenterConfig,
+				clusterConfig.TinkerbellMachineConfigs,
+				config.Cluster,
+				config.HardwareCSVPath,
+				deps.Writer,
+				deps.DockerClient,
+				deps.Helm,
+				deps.Kubectl,
+				tinkerbellIP,
+				time.Now,
+				config.Force,
+				config.SkipIPCheck,
+				bmcTimeout,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if config.Options != nil && config.Options.Tinkerbell != nil && config.Options.Tinkerbell.BMCOptions != nil {
+				provider.BMCOptions = config.Options.Tinkerbell.BMCOptions
+			}
+
+			return provider, nil
+		},
+		CAPIProviderName: constants.TinkerbellProviderName,
+		CAPINamespace:    constants.CaptSystemNamespace,
+		NewClusterReconciler: func(_ context.Context, deps ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			return tinkerbellreconciler.New(deps.Client, deps.CNIReconciler, deps.Tracker, deps.IPValidator), nil
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package dependencies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+	vspherereconciler "github.com/aws/eks-anywhere/pkg/providers/vsphere/reconciler"
+)
+
+// vsphereProviderRegistration wires the vSphere provider through the provider registry.
+// Its cluster Config entry is registered by the cluster package itself.
+func vsphereProviderRegistration() ProviderRegistration {
+	return ProviderRegistration{
+		DatacenterKind: v1alpha1.VSphereDatacenterKind,
+		WithDependencies: func(f *Factory, _ ProviderConfig) {
+			f.WithKubectl().WithGovc().WithWriter().WithIPValidator()
+		},
+		NewProvider: func(_ context.Context, deps *Dependencies, config ProviderConfig) (providers.Provider, error) {
+			datacenterConfig, err := v1alpha1.GetVSphereDatacenterConfig(config.ClusterConfigFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get datacenter config from file %s: %v", config.ClusterConfigFile, err)
+			}
+
+			return vsphere.NewProvider(
+				datacenterConfig,
+				config.Cluster,
+				deps.Govc,
+				deps.Kubectl,
+				deps.Writer,
+				deps.IPValidator,
+				time.Now,
+				config.SkipIPCheck,
+				config.SkippedValidations,
+			), nil
+		},
+		CAPIProviderName: constants.VSphereProviderName,
+		CAPINamespace:    constants.CapvSystemNamespace,
+		WithReconcilerDependencies: func(f *Factory) {
+			f.WithVSphereDefaulter().WithVSphereValidator()
+		},
+		NewClusterReconciler: func(_ context.Context, deps ProviderReconcilerDependencies) (clusters.ProviderClusterReconciler, error) {
+			return vspherereconciler.New(
+				deps.Client,
+				deps.Dependencies.VSphereValidator,
+				deps.Dependencies.VSphereDefaulter,
+				deps.CNIReconciler,
+				deps.Tracker,
+				deps.IPValidator,
+			), nil
+		},
+	}
+}
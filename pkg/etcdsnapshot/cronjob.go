@@ -0,0 +1,299 @@
+package etcdsnapshot
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+const (
+	// CronJobName is the name of the CronJob that takes the snapshots in the eksa-system namespace of the cluster.
+	CronJobName = "etcd-snapshot"
+	// CredentialsSecretName is the name of the Secret with the S3 credentials in the eksa-system namespace of the cluster.
+	CredentialsSecretName = "etcd-snapshot-s3-credentials"
+	// Label is added to all the objects created for the snapshots.
+	Label = "anywhere.eks.amazonaws.com/etcd-snapshot"
+
+	snapshotContainerName = "snapshot"
+	storeContainerName    = "store"
+	snapshotDir           = "/snapshot"
+	destinationDir        = "/destination"
+
+	defaultCertificatesDir = "/etc/kubernetes/pki"
+	stackedEtcdEndpoint    = "https://127.0.0.1:2379"
+	controlPlaneNodeLabel  = "node-role.kubernetes.io/control-plane"
+)
+
+// The store scripts copy the snapshot with a timestamped name and then delete the oldest ones,
+// keeping $RETENTION snapshots.
+const (
+	localStoreScript = `set -eu
+name="etcd-snapshot-$(date -u +%Y%m%d%H%M%S).db"
+cp /snapshot/snapshot.db "/destination/${name}.tmp"
+mv "/destination/${name}.tmp" "/destination/${name}"
+ls -1 /destination | grep -E '^etcd-snapshot-[0-9]{14}\.db$' | sort -r | tail -n +$((RETENTION + 1)) | while read -r f; do rm -f "/destination/${f}"; done
+`
+	s3StoreScript = `set -eu
+dest="s3://${BUCKET}/${PREFIX}"
+aws s3 cp ${S3_ARGS} /snapshot/snapshot.db "${dest}etcd-snapshot-$(date -u +%Y%m%d%H%M%S).db"
+aws s3 ls ${S3_ARGS} "${dest}" | awk '{print $4}' | grep -E '^etcd-snapshot-[0-9]{14}\.db$' | sort -r | tail -n +$((RETENTION + 1)) | while read -r f; do aws s3 rm ${S3_ARGS} "${dest}${f}"; done
+`
+)
+
+// Etcd defines how the snapshot job connects to etcd from a control plane node.
+type Etcd struct {
+	Endpoint string
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// Images defines the container images used by the snapshot job.
+type Images struct {
+	// Etcd provides etcdctl.
+	Etcd string
+	// Store provides sh and coreutils. It's used to store the snapshots in local and
+	// PersistentVolume destinations, S3 destinations use their own image.
+	Store string
+}
+
+// EtcdFromKubeadmControlPlane returns the etcd connection used by the kube-apiserver of the
+// control plane. With stacked etcd, the job connects to the etcd member running on the same
+// node. With external etcd, it connects to the first etcd machine, which is only known once
+// the etcd cluster has been provisioned.
+func EtcdFromKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane) (*Etcd, error) {
+	clusterConfig := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if clusterConfig != nil && clusterConfig.Etcd.External != nil {
+		external := clusterConfig.Etcd.External
+		if len(external.Endpoints) == 0 {
+			return nil, errors.New("external etcd endpoints are not available yet")
+		}
+		return &Etcd{
+			Endpoint: external.Endpoints[0],
+			CAFile:   external.CAFile,
+			CertFile: external.CertFile,
+			KeyFile:  external.KeyFile,
+		}, nil
+	}
+
+	certsDir := defaultCertificatesDir
+	if clusterConfig != nil && clusterConfig.CertificatesDir != "" {
+		certsDir = clusterConfig.CertificatesDir
+	}
+
+	return &Etcd{
+		Endpoint: stackedEtcdEndpoint,
+		CAFile:   filepath.Join(certsDir, "etcd", "ca.crt"),
+		CertFile: filepath.Join(certsDir, "apiserver-etcd-client.crt"),
+		KeyFile:  filepath.Join(certsDir, "apiserver-etcd-client.key"),
+	}, nil
+}
+
+// CronJob builds the CronJob that takes the snapshots of the cluster etcd and stores them in the
+// configured destination. It runs on the control plane nodes, reusing the kube-apiserver etcd
+// client certificates.
+func CronJob(config *anywherev1.EtcdSnapshotConfiguration, etcd *Etcd, images Images) *batchv1.CronJob {
+	podSpec := corev1.PodSpec{
+		HostNetwork:   true,
+		RestartPolicy: corev1.RestartPolicyNever,
+		NodeSelector: map[string]string{
+			controlPlaneNodeLabel: "",
+		},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      controlPlaneNodeLabel,
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		},
+		InitContainers: []corev1.Container{snapshotContainer(etcd, images.Etcd)},
+		Containers:     []corev1.Container{storeContainer(config, images.Store)},
+		Volumes: []corev1.Volume{
+			{
+				Name:         "snapshot",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			},
+			{
+				Name: "etcd-certs",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{
+						Path: certsDir(etcd),
+						Type: hostPathType(corev1.HostPathDirectory),
+					},
+				},
+			},
+		},
+	}
+
+	// Local snapshots only live as long as the node: they are deleted with it on every
+	// control plane rollout.
+	switch d := config.Destination; {
+	case d.Local != nil:
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "destination",
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: d.Local.Path,
+					Type: hostPathType(corev1.HostPathDirectoryOrCreate),
+				},
+			},
+		})
+	case d.PersistentVolume != nil:
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "destination",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: d.PersistentVolume.ClaimName,
+				},
+			},
+		})
+	}
+
+	return &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "CronJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CronJobName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{Label: "true"},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   config.Schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptr.Int32(1),
+			FailedJobsHistoryLimit:     ptr.Int32(1),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{Label: "true"},
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptr.Int32(2),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{Label: "true"},
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}
+}
+
+// CredentialsSecret builds the Secret with the S3 credentials used by the snapshot job from
+// the Secret referenced in the S3 destination.
+func CredentialsSecret(source *corev1.Secret) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CredentialsSecretName,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{Label: "true"},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: source.Data,
+	}
+}
+
+func snapshotContainer(etcd *Etcd, image string) corev1.Container {
+	certsPath := certsDir(etcd)
+	return corev1.Container{
+		Name:    snapshotContainerName,
+		Image:   image,
+		Command: []string{"etcdctl", "snapshot", "save", snapshotDir + "/snapshot.db"},
+		Env: []corev1.EnvVar{
+			{Name: "ETCDCTL_API", Value: "3"},
+			{Name: "ETCDCTL_ENDPOINTS", Value: etcd.Endpoint},
+			{Name: "ETCDCTL_CACERT", Value: etcd.CAFile},
+			{Name: "ETCDCTL_CERT", Value: etcd.CertFile},
+			{Name: "ETCDCTL_KEY", Value: etcd.KeyFile},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "snapshot", MountPath: snapshotDir},
+			{Name: "etcd-certs", MountPath: certsPath, ReadOnly: true},
+		},
+	}
+}
+
+func storeContainer(config *anywherev1.EtcdSnapshotConfiguration, storeImage string) corev1.Container {
+	container := corev1.Container{
+		Name: storeContainerName,
+		Env: []corev1.EnvVar{
+			{Name: "RETENTION", Value: strconv.Itoa(config.RetentionOrDefault())},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "snapshot", MountPath: snapshotDir, ReadOnly: true},
+		},
+	}
+
+	s3 := config.Destination.S3
+	if s3 == nil {
+		container.Image = storeImage
+		container.Command = []string{"sh", "-c", localStoreScript}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: "destination", MountPath: destinationDir})
+		return container
+	}
+
+	var args []string
+	if s3.Endpoint != "" {
+		args = append(args, "--endpoint-url", s3.Endpoint)
+	}
+	if s3.InsecureSkipVerify {
+		args = append(args, "--no-verify-ssl")
+	}
+	prefix := s3.Prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	container.Image = s3.Image
+	container.Command = []string{"sh", "-c", s3StoreScript}
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "BUCKET", Value: s3.Bucket},
+		corev1.EnvVar{Name: "PREFIX", Value: prefix},
+		corev1.EnvVar{Name: "S3_ARGS", Value: strings.Join(args, " ")},
+	)
+	if s3.Region != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: s3.Region})
+	}
+	container.EnvFrom = []corev1.EnvFromSource{
+		{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: CredentialsSecretName},
+			},
+		},
+	}
+
+	return container
+}
+
+// certsDir returns the host directory containing all the etcd client certificates.
+func certsDir(etcd *Etcd) string {
+	dir := filepath.Dir(etcd.CAFile)
+	for _, f := range []string{etcd.CertFile, etcd.KeyFile} {
+		for !strings.HasPrefix(f, dir+"/") && dir != "/" {
+			dir = filepath.Dir(dir)
+		}
+	}
+
+	return dir
+}
+
+func hostPathType(t corev1.HostPathType) *corev1.HostPathType {
+	return &t
+}
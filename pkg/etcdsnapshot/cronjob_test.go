@@ -0,0 +1,193 @@
+package etcdsnapshot_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/etcdsnapshot"
+)
+
+func TestEtcdFromKubeadmControlPlane(t *testing.T) {
+	tests := []struct {
+		name          string
+		clusterConfig *bootstrapv1.ClusterConfiguration
+		want          *etcdsnapshot.Etcd
+		wantErr       string
+	}{
+		{
+			name: "stacked etcd default certificates dir",
+			want: &etcdsnapshot.Etcd{
+				Endpoint: "https://127.0.0.1:2379",
+				CAFile:   "/etc/kubernetes/pki/etcd/ca.crt",
+				CertFile: "/etc/kubernetes/pki/apiserver-etcd-client.crt",
+				KeyFile:  "/etc/kubernetes/pki/apiserver-etcd-client.key",
+			},
+		},
+		{
+			name:          "stacked etcd bottlerocket",
+			clusterConfig: &bootstrapv1.ClusterConfiguration{CertificatesDir: "/var/lib/kubeadm/pki"},
+			want: &etcdsnapshot.Etcd{
+				Endpoint: "https://127.0.0.1:2379",
+				CAFile:   "/var/lib/kubeadm/pki/etcd/ca.crt",
+				CertFile: "/var/lib/kubeadm/pki/apiserver-etcd-client.crt",
+				KeyFile:  "/var/lib/kubeadm/pki/apiserver-etcd-client.key",
+			},
+		},
+		{
+			name: "external etcd",
+			clusterConfig: &bootstrapv1.ClusterConfiguration{
+				Etcd: bootstrapv1.Etcd{
+					External: &bootstrapv1.ExternalEtcd{
+						Endpoints: []string{"https://10.0.0.1:2379", "https://10.0.0.2:2379"},
+						CAFile:    "/etc/kubernetes/pki/etcd/ca.crt",
+						CertFile:  "/etc/kubernetes/pki/apiserver-etcd-client.crt",
+						KeyFile:   "/etc/kubernetes/pki/apiserver-etcd-client.key",
+					},
+				},
+			},
+			want: &etcdsnapshot.Etcd{
+				Endpoint: "https://10.0.0.1:2379",
+				CAFile:   "/etc/kubernetes/pki/etcd/ca.crt",
+				CertFile: "/etc/kubernetes/pki/apiserver-etcd-client.crt",
+				KeyFile:  "/etc/kubernetes/pki/apiserver-etcd-client.key",
+			},
+		},
+		{
+			name: "external etcd without endpoints",
+			clusterConfig: &bootstrapv1.ClusterConfiguration{
+				Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+			},
+			wantErr: "external etcd endpoints are not available yet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = tt.clusterConfig
+			})
+
+			got, err := etcdsnapshot.EtcdFromKubeadmControlPlane(kcp)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func stackedEtcd() *etcdsnapshot.Etcd {
+	return &etcdsnapshot.Etcd{
+		Endpoint: "https://127.0.0.1:2379",
+		CAFile:   "/var/lib/kubeadm/pki/etcd/ca.crt",
+		CertFile: "/var/lib/kubeadm/pki/server-etcd-client.crt",
+		KeyFile:  "/var/lib/kubeadm/pki/apiserver-etcd-client.key",
+	}
+}
+
+var images = etcdsnapshot.Images{Etcd: "etcd:v3.5.9", Store: "upgrader:v1.28.3"}
+
+func TestCronJobLocalDestination(t *testing.T) {
+	g := NewWithT(t)
+	config := &anywherev1.EtcdSnapshotConfiguration{
+		Schedule:    "0 */6 * * *",
+		Retention:   3,
+		Destination: anywherev1.EtcdSnapshotDestination{Local: &anywherev1.LocalEtcdSnapshotDestination{Path: "/var/lib/etcd-snapshots"}},
+	}
+
+	cronJob := etcdsnapshot.CronJob(config, stackedEtcd(), images)
+	g.Expect(cronJob.Name).To(Equal(etcdsnapshot.CronJobName))
+	g.Expect(cronJob.Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(cronJob.Spec.Schedule).To(Equal("0 */6 * * *"))
+
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	g.Expect(pod.HostNetwork).To(BeTrue())
+	g.Expect(pod.NodeSelector).To(HaveKey("node-role.kubernetes.io/control-plane"))
+
+	g.Expect(pod.InitContainers).To(HaveLen(1))
+	snapshot := pod.InitContainers[0]
+	g.Expect(snapshot.Image).To(Equal("etcd:v3.5.9"))
+	g.Expect(snapshot.Env).To(ContainElements(
+		corev1.EnvVar{Name: "ETCDCTL_ENDPOINTS", Value: "https://127.0.0.1:2379"},
+		corev1.EnvVar{Name: "ETCDCTL_CERT", Value: "/var/lib/kubeadm/pki/server-etcd-client.crt"},
+	))
+	g.Expect(snapshot.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "etcd-certs", MountPath: "/var/lib/kubeadm/pki", ReadOnly: true}))
+
+	g.Expect(pod.Containers).To(HaveLen(1))
+	store := pod.Containers[0]
+	g.Expect(store.Image).To(Equal("upgrader:v1.28.3"))
+	g.Expect(store.Env).To(ContainElement(corev1.EnvVar{Name: "RETENTION", Value: "3"}))
+	g.Expect(store.Command[2]).To(ContainSubstring("/destination/"))
+
+	g.Expect(volume(pod, "etcd-certs").HostPath.Path).To(Equal("/var/lib/kubeadm/pki"))
+	g.Expect(volume(pod, "destination").HostPath.Path).To(Equal("/var/lib/etcd-snapshots"))
+}
+
+func TestCronJobPersistentVolumeDestination(t *testing.T) {
+	g := NewWithT(t)
+	config := &anywherev1.EtcdSnapshotConfiguration{
+		Schedule:    "@daily",
+		Destination: anywherev1.EtcdSnapshotDestination{PersistentVolume: &anywherev1.PersistentVolumeEtcdSnapshotDestination{ClaimName: "snapshots"}},
+	}
+
+	pod := etcdsnapshot.CronJob(config, stackedEtcd(), images).Spec.JobTemplate.Spec.Template.Spec
+	g.Expect(pod.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "RETENTION", Value: "7"}))
+	g.Expect(volume(pod, "destination").PersistentVolumeClaim.ClaimName).To(Equal("snapshots"))
+}
+
+func TestCronJobS3Destination(t *testing.T) {
+	g := NewWithT(t)
+	config := &anywherev1.EtcdSnapshotConfiguration{
+		Schedule: "@hourly",
+		Destination: anywherev1.EtcdSnapshotDestination{S3: &anywherev1.S3EtcdSnapshotDestination{
+			Endpoint:              "https://minio.local:9000",
+			Bucket:                "backups",
+			Prefix:                "mgmt",
+			Region:                "us-west-2",
+			CredentialsSecretName: "minio",
+			InsecureSkipVerify:    true,
+			Image:                 "aws-cli:2",
+		}},
+	}
+
+	pod := etcdsnapshot.CronJob(config, stackedEtcd(), images).Spec.JobTemplate.Spec.Template.Spec
+	store := pod.Containers[0]
+	g.Expect(store.Image).To(Equal("aws-cli:2"))
+	g.Expect(store.Command[2]).To(ContainSubstring("aws s3 cp"))
+	g.Expect(store.Env).To(ContainElements(
+		corev1.EnvVar{Name: "BUCKET", Value: "backups"},
+		corev1.EnvVar{Name: "PREFIX", Value: "mgmt/"},
+		corev1.EnvVar{Name: "S3_ARGS", Value: "--endpoint-url https://minio.local:9000 --no-verify-ssl"},
+		corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: "us-west-2"},
+	))
+	g.Expect(store.EnvFrom[0].SecretRef.Name).To(Equal(etcdsnapshot.CredentialsSecretName))
+	g.Expect(volume(pod, "destination")).To(BeNil())
+}
+
+func TestCredentialsSecret(t *testing.T) {
+	g := NewWithT(t)
+	source := &corev1.Secret{Data: map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("id")}}
+
+	secret := etcdsnapshot.CredentialsSecret(source)
+	g.Expect(secret.Name).To(Equal(etcdsnapshot.CredentialsSecretName))
+	g.Expect(secret.Namespace).To(Equal(constants.EksaSystemNamespace))
+	g.Expect(secret.Data).To(Equal(source.Data))
+}
+
+func volume(pod corev1.PodSpec, name string) *corev1.Volume {
+	for i := range pod.Volumes {
+		if pod.Volumes[i].Name == name {
+			return &pod.Volumes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	anywhereCluster "github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/etcdsnapshot"
+)
+
+// etcdRequeueTime is how long to wait for external etcd to be provisioned.
+const etcdRequeueTime = 30 * time.Second
+
+// RemoteClientRegistry defines methods for remote cluster controller clients.
+type RemoteClientRegistry interface {
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// Reconciler manages the scheduled etcd snapshots of a cluster.
+type Reconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+	return &Reconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+	}
+}
+
+// Reconcile deploys the etcd snapshot CronJob to the cluster when it has an EtcdSnapshot
+// configuration and removes it otherwise. It reports the state of the last snapshots in the
+// cluster status and the EtcdSnapshotsReady condition.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	if cluster.Spec.EtcdSnapshot == nil {
+		return controller.Result{}, r.reconcileDelete(ctx, log, cluster)
+	}
+
+	result, err := clusters.CheckControlPlaneReady(ctx, r.client, log, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "checking controlplane ready")
+	}
+	if result.Return() {
+		return result, nil
+	}
+
+	kcp, err := controller.GetKubeadmControlPlane(ctx, r.client, cluster)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting kubeadmcontrolplane")
+	}
+
+	etcd, err := etcdsnapshot.EtcdFromKubeadmControlPlane(kcp)
+	if err != nil {
+		log.Info("Etcd is not ready for snapshots yet, requeuing", "reason", err.Error())
+		return controller.ResultWithRequeue(etcdRequeueTime), nil
+	}
+
+	clusterSpec, err := anywhereCluster.BuildSpec(ctx, clientutil.NewKubeClient(r.client), cluster)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	versionsBundle := clusterSpec.RootVersionsBundle()
+	images := etcdsnapshot.Images{
+		Etcd:  versionsBundle.KubeDistro.EtcdImage.VersionedImage(),
+		Store: versionsBundle.Upgrader.Upgrader.VersionedImage(),
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "getting workload cluster's client to reconcile etcd snapshots")
+	}
+
+	if err := r.applySnapshotObjects(ctx, rClient, cluster, etcd, images); err != nil {
+		conditions.MarkFalse(cluster, anywherev1.EtcdSnapshotsReadyCondition, anywherev1.EtcdSnapshotDeploymentFailedReason,
+			clusterv1.ConditionSeverityError, "%s", err.Error())
+		return controller.Result{}, err
+	}
+
+	return controller.Result{}, r.updateStatus(ctx, rClient, cluster)
+}
+
+func (r *Reconciler) applySnapshotObjects(ctx context.Context, rClient client.Client, cluster *anywherev1.Cluster, etcd *etcdsnapshot.Etcd, images etcdsnapshot.Images) error {
+	config := cluster.Spec.EtcdSnapshot
+	objs := []client.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: constants.EksaSystemNamespace},
+		},
+	}
+
+	if s3 := config.Destination.S3; s3 != nil {
+		source := &corev1.Secret{}
+		key := types.NamespacedName{Name: s3.CredentialsSecretName, Namespace: constants.EksaSystemNamespace}
+		if err := r.client.Get(ctx, key, source); err != nil {
+			return errors.Wrapf(err, "getting etcd snapshot S3 credentials secret %s", s3.CredentialsSecretName)
+		}
+		objs = append(objs, etcdsnapshot.CredentialsSecret(source))
+	} else if err := deleteObject(ctx, rClient, credentialsSecret()); err != nil {
+		return err
+	}
+
+	objs = append(objs, etcdsnapshot.CronJob(config, etcd, images))
+
+	if err := serverside.ReconcileObjects(ctx, rClient, objs); err != nil {
+		return errors.Wrap(err, "applying etcd snapshot objects")
+	}
+
+	return nil
+}
+
+// updateStatus reports the snapshot times from the CronJob status and whether the most recent
+// finished snapshot job succeeded.
+func (r *Reconciler) updateStatus(ctx context.Context, rClient client.Client, cluster *anywherev1.Cluster) error {
+	cronJob := &batchv1.CronJob{}
+	if err := rClient.Get(ctx, types.NamespacedName{Name: etcdsnapshot.CronJobName, Namespace: constants.EksaSystemNamespace}, cronJob); err != nil {
+		return errors.Wrap(err, "getting etcd snapshot cronjob")
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := rClient.List(ctx, jobs,
+		client.InNamespace(constants.EksaSystemNamespace),
+		client.MatchingLabels{etcdsnapshot.Label: "true"},
+	); err != nil {
+		return errors.Wrap(err, "listing etcd snapshot jobs")
+	}
+
+	cluster.Status.EtcdSnapshot = &anywherev1.EtcdSnapshotStatus{
+		LastScheduleTime: cronJob.Status.LastScheduleTime,
+		LastSuccessTime:  cronJob.Status.LastSuccessfulTime,
+	}
+
+	job, finished := latestFinishedJob(jobs.Items)
+	switch {
+	case finished != nil && finished.Type == batchv1.JobFailed:
+		conditions.MarkFalse(cluster, anywherev1.EtcdSnapshotsReadyCondition, anywherev1.EtcdSnapshotFailedReason, clusterv1.ConditionSeverityWarning,
+			"Etcd snapshot job %s failed: %s", job.Name, finished.Message)
+	case cronJob.Status.LastSuccessfulTime == nil:
+		conditions.MarkFalse(cluster, anywherev1.EtcdSnapshotsReadyCondition, anywherev1.EtcdSnapshotPendingReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for the first etcd snapshot")
+	default:
+		conditions.MarkTrue(cluster, anywherev1.EtcdSnapshotsReadyCondition)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileDelete(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if cluster.Status.EtcdSnapshot == nil && conditions.Get(cluster, anywherev1.EtcdSnapshotsReadyCondition) == nil {
+		return nil
+	}
+
+	rClient, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(cluster))
+	if err != nil {
+		return errors.Wrap(err, "getting workload cluster's client to remove etcd snapshots")
+	}
+
+	log.Info("Removing etcd snapshot cronjob")
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: etcdsnapshot.CronJobName, Namespace: constants.EksaSystemNamespace},
+	}
+	if err := deleteObject(ctx, rClient, cronJob); err != nil {
+		return err
+	}
+
+	if err := deleteObject(ctx, rClient, credentialsSecret()); err != nil {
+		return err
+	}
+
+	cluster.Status.EtcdSnapshot = nil
+	conditions.Delete(cluster, anywherev1.EtcdSnapshotsReadyCondition)
+
+	return nil
+}
+
+func credentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: etcdsnapshot.CredentialsSecretName, Namespace: constants.EksaSystemNamespace},
+	}
+}
+
+func deleteObject(ctx context.Context, c client.Client, obj client.Object) error {
+	if err := c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting %s", obj.GetName())
+	}
+
+	return nil
+}
+
+// latestFinishedJob returns the job that finished last and its terminal condition.
+func latestFinishedJob(jobs []batchv1.Job) (*batchv1.Job, *batchv1.JobCondition) {
+	var latest *batchv1.Job
+	var latestCondition *batchv1.JobCondition
+	for i := range jobs {
+		for j := range jobs[i].Status.Conditions {
+			c := &jobs[i].Status.Conditions[j]
+			if c.Status != corev1.ConditionTrue || (c.Type != batchv1.JobComplete && c.Type != batchv1.JobFailed) {
+				continue
+			}
+			if latestCondition == nil || latestCondition.LastTransitionTime.Before(&c.LastTransitionTime) {
+				latest, latestCondition = &jobs[i], c
+			}
+		}
+	}
+
+	return latest, latestCondition
+}
@@ -0,0 +1,356 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/etcdsnapshot"
+	"github.com/aws/eks-anywhere/pkg/etcdsnapshot/reconciler"
+	"github.com/aws/eks-anywhere/pkg/etcdsnapshot/reconciler/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type reconcilerTest struct {
+	t *testing.T
+	*WithT
+	ctx            context.Context
+	cluster        *anywherev1.Cluster
+	kcp            *controlplanev1.KubeadmControlPlane
+	managementObjs []client.Object
+	remoteObjs     []client.Object
+	remoteClient   client.Client
+	registry       *mocks.MockRemoteClientRegistry
+}
+
+func newReconcilerTest(t *testing.T) *reconcilerTest {
+	bundle := test.Bundle()
+	version := test.DevEksaVersion()
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "eksa-system",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: "1.22",
+			BundlesRef: &anywherev1.BundlesRef{
+				Name:       bundle.Name,
+				Namespace:  bundle.Namespace,
+				APIVersion: bundle.APIVersion,
+			},
+			EksaVersion: &version,
+			EtcdSnapshot: &anywherev1.EtcdSnapshotConfiguration{
+				Schedule: "@daily",
+				Destination: anywherev1.EtcdSnapshotDestination{
+					Local: &anywherev1.LocalEtcdSnapshotDestination{Path: "/var/lib/etcd-snapshots"},
+				},
+			},
+		},
+	}
+	kcpVersion := "test"
+	kcp := test.KubeadmControlPlane(func(kcp *controlplanev1.KubeadmControlPlane) {
+		kcp.Name = cluster.Name
+		kcp.Spec.Version = kcpVersion
+		kcp.Status = controlplanev1.KubeadmControlPlaneStatus{
+			Conditions: clusterv1.Conditions{
+				{
+					Type:               clusterapi.ReadyCondition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Now()),
+				},
+			},
+			Version: pointer.String(kcpVersion),
+		}
+	})
+
+	return &reconcilerTest{
+		t:              t,
+		WithT:          NewWithT(t),
+		ctx:            context.Background(),
+		cluster:        cluster,
+		kcp:            kcp,
+		managementObjs: []client.Object{bundle, test.EksdRelease("1-22"), test.EKSARelease()},
+		registry:       mocks.NewMockRemoteClientRegistry(gomock.NewController(t)),
+	}
+}
+
+func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
+	scheme := runtime.NewScheme()
+	tt.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(releasev1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(eksdv1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	tt.Expect(controlplanev1.AddToScheme(scheme)).To(Succeed())
+
+	managementClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(tt.managementObjs, tt.kcp)...).
+		Build()
+
+	// The fake client doesn't support server side apply, so it's simulated with create and update.
+	tt.remoteClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tt.remoteObjs...).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyWithCreateOrUpdate}).
+		Build()
+
+	return reconciler.New(managementClient, tt.registry)
+}
+
+func (tt *reconcilerTest) expectRemoteClient() {
+	tt.registry.EXPECT().GetClient(tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: constants.EksaSystemNamespace}).
+		DoAndReturn(func(context.Context, client.ObjectKey) (client.Client, error) {
+			return tt.remoteClient, nil
+		})
+}
+
+func (tt *reconcilerTest) getCronJob() (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{}
+	err := tt.remoteClient.Get(tt.ctx, types.NamespacedName{Name: etcdsnapshot.CronJobName, Namespace: constants.EksaSystemNamespace}, cronJob)
+	return cronJob, err
+}
+
+func applyWithCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch != client.Apply {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing := obj.DeepCopyObject().(client.Object)
+	err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func nullLog() logr.Logger {
+	return logr.New(logf.NullLogSink{})
+}
+
+func snapshotJob(name string, conditionType batchv1.JobConditionType, finished time.Time) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{etcdsnapshot.Label: "true"},
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:               conditionType,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(finished),
+					Message:            "BackoffLimitExceeded",
+				},
+			},
+		},
+	}
+}
+
+func TestReconcileDeploysCronJob(t *testing.T) {
+	tt := newReconcilerTest(t)
+	r := tt.reconciler()
+	tt.expectRemoteClient()
+
+	result, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	cronJob, err := tt.getCronJob()
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(cronJob.Spec.Schedule).To(Equal("@daily"))
+	tt.Expect(cronJob.Spec.JobTemplate.Spec.Template.Spec.InitContainers[0].Env).To(
+		ContainElement(corev1.EnvVar{Name: "ETCDCTL_ENDPOINTS", Value: "https://127.0.0.1:2379"}),
+	)
+
+	tt.Expect(tt.cluster.Status.EtcdSnapshot).ToNot(BeNil())
+	condition := conditions.Get(tt.cluster, anywherev1.EtcdSnapshotsReadyCondition)
+	tt.Expect(condition).ToNot(BeNil())
+	tt.Expect(condition.Reason).To(Equal(anywherev1.EtcdSnapshotPendingReason))
+}
+
+func TestReconcileReportsLastSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	lastSuccess := metav1.NewTime(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+	tt.remoteObjs = []client.Object{
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: etcdsnapshot.CronJobName, Namespace: constants.EksaSystemNamespace},
+			Status: batchv1.CronJobStatus{
+				LastScheduleTime:   &lastSuccess,
+				LastSuccessfulTime: &lastSuccess,
+			},
+		},
+		snapshotJob("etcd-snapshot-1", batchv1.JobFailed, lastSuccess.Add(-24*time.Hour)),
+		snapshotJob("etcd-snapshot-2", batchv1.JobComplete, lastSuccess.Time),
+	}
+	r := tt.reconciler()
+	tt.expectRemoteClient()
+
+	_, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(tt.cluster.Status.EtcdSnapshot.LastSuccessTime.Equal(&lastSuccess)).To(BeTrue())
+	tt.Expect(conditions.IsTrue(tt.cluster, anywherev1.EtcdSnapshotsReadyCondition)).To(BeTrue())
+}
+
+func TestReconcileReportsFailedSnapshot(t *testing.T) {
+	tt := newReconcilerTest(t)
+	lastSuccess := metav1.NewTime(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+	tt.remoteObjs = []client.Object{
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: etcdsnapshot.CronJobName, Namespace: constants.EksaSystemNamespace},
+			Status:     batchv1.CronJobStatus{LastSuccessfulTime: &lastSuccess},
+		},
+		snapshotJob("etcd-snapshot-1", batchv1.JobComplete, lastSuccess.Time),
+		snapshotJob("etcd-snapshot-2", batchv1.JobFailed, lastSuccess.Add(24*time.Hour)),
+	}
+	r := tt.reconciler()
+	tt.expectRemoteClient()
+
+	_, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(tt.cluster.Status.EtcdSnapshot.LastSuccessTime.Equal(&lastSuccess)).To(BeTrue())
+	condition := conditions.Get(tt.cluster, anywherev1.EtcdSnapshotsReadyCondition)
+	tt.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+	tt.Expect(condition.Reason).To(Equal(anywherev1.EtcdSnapshotFailedReason))
+	tt.Expect(condition.Message).To(ContainSubstring("etcd-snapshot-2 failed: BackoffLimitExceeded"))
+}
+
+func TestReconcileS3CopiesCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.EtcdSnapshot.Destination = anywherev1.EtcdSnapshotDestination{
+		S3: &anywherev1.S3EtcdSnapshotDestination{
+			Bucket:                "snapshots",
+			CredentialsSecretName: "minio",
+			Image:                 "aws-cli:2",
+		},
+	}
+	tt.managementObjs = append(tt.managementObjs, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: constants.EksaSystemNamespace},
+		Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("id"), "AWS_SECRET_ACCESS_KEY": []byte("secret")},
+	})
+	r := tt.reconciler()
+	tt.expectRemoteClient()
+
+	_, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+
+	secret := &corev1.Secret{}
+	tt.Expect(tt.remoteClient.Get(tt.ctx, types.NamespacedName{Name: etcdsnapshot.CredentialsSecretName, Namespace: constants.EksaSystemNamespace}, secret)).To(Succeed())
+	tt.Expect(secret.Data).To(HaveKeyWithValue("AWS_SECRET_ACCESS_KEY", []byte("secret")))
+}
+
+func TestReconcileS3MissingCredentials(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.EtcdSnapshot.Destination = anywherev1.EtcdSnapshotDestination{
+		S3: &anywherev1.S3EtcdSnapshotDestination{
+			Bucket:                "snapshots",
+			CredentialsSecretName: "minio",
+			Image:                 "aws-cli:2",
+		},
+	}
+	r := tt.reconciler()
+	tt.expectRemoteClient()
+
+	_, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("getting etcd snapshot S3 credentials secret minio")))
+	condition := conditions.Get(tt.cluster, anywherev1.EtcdSnapshotsReadyCondition)
+	tt.Expect(condition.Reason).To(Equal(anywherev1.EtcdSnapshotDeploymentFailedReason))
+
+	_, err = tt.getCronJob()
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestReconcileExternalEtcdNotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+		Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+	}
+	r := tt.reconciler()
+
+	result, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.ResultWithRequeue(30 * time.Second)))
+}
+
+func TestReconcileControlPlaneNotReady(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.kcp.Status.Conditions = nil
+	r := tt.reconciler()
+
+	result, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+}
+
+func TestReconcileRemoteClientError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	r := tt.reconciler()
+	tt.registry.EXPECT().GetClient(tt.ctx, gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	_, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
+
+func TestReconcileDisabledRemovesCronJob(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.EtcdSnapshot = nil
+	tt.cluster.Status.EtcdSnapshot = &anywherev1.EtcdSnapshotStatus{}
+	conditions.MarkTrue(tt.cluster, anywherev1.EtcdSnapshotsReadyCondition)
+	tt.remoteObjs = []client.Object{
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: etcdsnapshot.CronJobName, Namespace: constants.EksaSystemNamespace},
+		},
+	}
+	r := tt.reconciler()
+	tt.expectRemoteClient()
+
+	_, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+
+	_, err = tt.getCronJob()
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	tt.Expect(tt.cluster.Status.EtcdSnapshot).To(BeNil())
+	tt.Expect(conditions.Get(tt.cluster, anywherev1.EtcdSnapshotsReadyCondition)).To(BeNil())
+}
+
+func TestReconcileDisabledNeverEnabled(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.cluster.Spec.EtcdSnapshot = nil
+	r := tt.reconciler()
+
+	result, err := r.Reconcile(tt.ctx, nullLog(), tt.cluster)
+	tt.Expect(err).ToNot(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
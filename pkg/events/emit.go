@@ -0,0 +1,29 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// Emit sends e to the Sink configured in ctx. The event timestamp is set to the current time
+// if unset. Sink errors are logged and otherwise ignored so event delivery never interrupts
+// cluster operations.
+func Emit(ctx context.Context, e Event) {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+
+	if err := SinkFromContext(ctx).Emit(e); err != nil {
+		logger.V(4).Info("Failed to emit event", "type", e.Type, "task", e.Task, "error", err)
+	}
+}
+
+// ErrorMessage returns err's message or an empty string if err is nil.
+func ErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Type identifies the lifecycle stage an Event describes.
+type Type string
+
+const (
+	// TaskStarted is emitted before a task runs.
+	TaskStarted Type = "TaskStarted"
+
+	// TaskFinished is emitted after a task completes successfully.
+	TaskFinished Type = "TaskFinished"
+
+	// TaskFailed is emitted after a task returns an error.
+	TaskFailed Type = "TaskFailed"
+
+	// TaskRestored is emitted when a task is restored from a checkpoint instead of being run.
+	TaskRestored Type = "TaskRestored"
+
+	// HookRan is emitted after a workflow hook runs.
+	HookRan Type = "HookRan"
+)
+
+// Event is a machine readable record of a task or workflow lifecycle stage.
+type Event struct {
+	// Type is the lifecycle stage the event describes.
+	Type Type `json:"type"`
+
+	// Timestamp is the time the event occurred.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Cluster is the name of the cluster being operated on.
+	Cluster string `json:"cluster,omitempty"`
+
+	// Task is the name of the task the event relates to. For hooks it is the anchor task, if any.
+	Task string `json:"task,omitempty"`
+
+	// Hook describes the kind of hook that ran, for example PreTask or PostWorkflow.
+	Hook string `json:"hook,omitempty"`
+
+	// DurationSeconds is the time taken by the task or hook. It is only set for events emitted
+	// after something has run.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+
+	// Error is the error message for failed tasks and hooks.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink receives events. Implementations must be safe for concurrent use.
+type Sink interface {
+	// Emit records e. Errors are logged by callers and never interrupt the operation emitting
+	// the event.
+	Emit(e Event) error
+}
+
+// sinkKey is used to store a Sink in a context.
+type sinkKey struct{}
+
+// WithSink returns a context based on ctx containing sink.
+func WithSink(ctx context.Context, sink Sink) context.Context {
+	return context.WithValue(ctx, sinkKey{}, sink)
+}
+
+// SinkFromContext retrieves the Sink configured in ctx. If no sink is configured it returns a
+// sink that discards all events.
+func SinkFromContext(ctx context.Context) Sink {
+	if ctx == nil {
+		return NopSink{}
+	}
+
+	if sink, ok := ctx.Value(sinkKey{}).(Sink); ok && sink != nil {
+		return sink
+	}
+	return NopSink{}
+}
+
+// NopSink is a Sink that discards all events.
+type NopSink struct{}
+
+// Emit satisfies Sink.
+func (NopSink) Emit(Event) error { return nil }
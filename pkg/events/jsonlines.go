@@ -0,0 +1,58 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// JSONLinesSink is a Sink that writes each event as a single line of JSON.
+type JSONLinesSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewJSONLinesSink creates a JSONLinesSink that writes events to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{w: w}
+}
+
+// NewFileSink creates a JSONLinesSink that appends events to the file at path. The file is
+// created if it doesn't exist. Callers should call Close when done.
+func NewFileSink(path string) (*JSONLinesSink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening events file: %v", err)
+	}
+
+	return &JSONLinesSink{w: f, closer: f}, nil
+}
+
+// Emit satisfies Sink.
+func (s *JSONLinesSink) Emit(e Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshalling event: %v", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.w.Write(line); err != nil {
+		return fmt.Errorf("writing event: %v", err)
+	}
+
+	return nil
+}
+
+// Close closes the underlying file if the sink was created with NewFileSink.
+func (s *JSONLinesSink) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
@@ -0,0 +1,59 @@
+package events_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/events"
+)
+
+func TestJSONLinesSinkEmit(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	sink := events.NewJSONLinesSink(buf)
+
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	g.Expect(sink.Emit(events.Event{Type: events.TaskStarted, Timestamp: timestamp, Cluster: "c", Task: "t"})).To(Succeed())
+	g.Expect(sink.Emit(events.Event{Type: events.TaskFailed, Timestamp: timestamp, Task: "t", Error: "boom"})).To(Succeed())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	g.Expect(lines).To(HaveLen(2))
+	g.Expect(lines[0]).To(MatchJSON(`{"type":"TaskStarted","timestamp":"2024-01-02T03:04:05Z","cluster":"c","task":"t"}`))
+	g.Expect(lines[1]).To(MatchJSON(`{"type":"TaskFailed","timestamp":"2024-01-02T03:04:05Z","task":"t","error":"boom"}`))
+}
+
+func TestFileSinkAppends(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	for i := 0; i < 2; i++ {
+		sink, err := events.NewFileSink(path)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(sink.Emit(events.Event{Type: events.TaskFinished})).To(Succeed())
+		g.Expect(sink.Close()).To(Succeed())
+	}
+
+	content, err := os.ReadFile(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(strings.Count(string(content), "\n")).To(Equal(2))
+}
+
+func TestEmitUsesContextSink(t *testing.T) {
+	g := NewWithT(t)
+	buf := &bytes.Buffer{}
+	ctx := events.WithSink(context.Background(), events.NewJSONLinesSink(buf))
+
+	events.Emit(ctx, events.Event{Type: events.HookRan})
+	g.Expect(buf.String()).To(ContainSubstring(`"type":"HookRan"`))
+	g.Expect(buf.String()).ToNot(ContainSubstring(`"timestamp":"0001-01-01T00:00:00Z"`))
+
+	// Emitting without a sink is a no-op.
+	events.Emit(context.Background(), events.Event{Type: events.HookRan})
+}
@@ -22,16 +22,22 @@ type ExecutableBuilder interface {
 
 type ExecutablesBuilder struct {
 	executableBuilder ExecutableBuilder
+	// containerRuntime is the container runtime as seen by the executables.
+	containerRuntime ContainerRuntime
+	// kindOpts configure the kind executable on top of containerRuntime.
+	kindOpts []KindOpt
 }
 
 func NewExecutablesBuilder(executableBuilder ExecutableBuilder) *ExecutablesBuilder {
 	return &ExecutablesBuilder{
 		executableBuilder: executableBuilder,
+		containerRuntime:  DockerRuntime,
 	}
 }
 
 func (b *ExecutablesBuilder) BuildKindExecutable(writer filewriter.FileWriter) *Kind {
-	return NewKind(b.executableBuilder.Build(kindPath), writer)
+	opts := append([]KindOpt{WithKindContainerRuntime(b.containerRuntime)}, b.kindOpts...)
+	return NewKind(b.executableBuilder.Build(kindPath), writer, opts...)
 }
 
 func (b *ExecutablesBuilder) BuildClusterAwsAdmExecutable() *Clusterawsadm {
@@ -60,8 +66,8 @@ func (b *ExecutablesBuilder) BuildAwsCli() *AwsCli {
 	return NewAwsCli(b.executableBuilder.Build(awsCliPath))
 }
 
-func (b *ExecutablesBuilder) BuildFluxExecutable() *Flux {
-	return NewFlux(b.executableBuilder.Build(fluxPath))
+func (b *ExecutablesBuilder) BuildFluxExecutable(writer filewriter.FileWriter) *Flux {
+	return NewFlux(b.executableBuilder.Build(fluxPath), writer)
 }
 
 func (b *ExecutablesBuilder) BuildTroubleshootExecutable() *Troubleshoot {
@@ -79,8 +85,12 @@ func (b *ExecutablesBuilder) BuildHelm(opts ...helm.Opt) helm.Client {
 }
 
 // BuildDockerExecutable initializes a docker executable and returns it.
+// It runs the CLI of the container runtime the executables use.
 func (b *ExecutablesBuilder) BuildDockerExecutable() *Docker {
-	return NewDocker(b.executableBuilder.Build(dockerPath))
+	return &Docker{
+		Executable: b.executableBuilder.Build(b.containerRuntime.cli()),
+		runtime:    b.containerRuntime,
+	}
 }
 
 // BuildSSHExecutable initializes a SSH executable and returns it.
@@ -101,10 +111,16 @@ func BuildSonobuoyExecutable() *Sonobuoy {
 	})
 }
 
+// BuildDockerExecutable builds a docker executable running the CLI of the
+// container runtime selected with EKSA_CONTAINER_RUNTIME in the host.
 func BuildDockerExecutable() *Docker {
-	return NewDocker(&executable{
-		cli: dockerPath,
-	})
+	runtime := ContainerRuntimeFromEnv()
+	return &Docker{
+		Executable: &executable{
+			cli: runtime.cli(),
+		},
+		runtime: runtime,
+	}
 }
 
 // RunExecutablesInDocker determines if binary executables should be ran
@@ -135,6 +151,10 @@ func InitInDockerExecutablesBuilder(ctx context.Context, image string, mountDirs
 }
 
 // NewInDockerExecutablesBuilder builds an executables builder for docker.
+// The tools container runs with the container runtime selected with EKSA_CONTAINER_RUNTIME.
+// The executables in it reach the runtime through its Docker compatible socket, so they
+// always see it as Docker, except kind, which creates the node containers with the provider
+// of the runtime through the same socket.
 func NewInDockerExecutablesBuilder(dockerClient DockerClient, image string, mountDirs ...string) (*ExecutablesBuilder, error) {
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -142,14 +162,22 @@ func NewInDockerExecutablesBuilder(dockerClient DockerClient, image string, moun
 	}
 	mountDirs = append(mountDirs, currentDir)
 
-	dockerContainer := newDockerContainer(image, currentDir, mountDirs, dockerClient)
-	dockerExecutableBuilder := NewDockerExecutableBuilder(dockerContainer)
+	runtime := ContainerRuntimeFromEnv()
+	dockerContainer := newDockerContainer(image, currentDir, mountDirs, dockerClient, runtime)
+	dockerExecutableBuilder := newContainerExecutableBuilder(dockerContainer, runtime)
+
+	b := NewExecutablesBuilder(dockerExecutableBuilder)
+	b.kindOpts = []KindOpt{WithKindContainerRuntime(runtime), WithKindContainerHost(dockerSocket)}
 
-	return NewExecutablesBuilder(dockerExecutableBuilder), nil
+	return b, nil
 }
 
+// NewLocalExecutablesBuilder builds an executables builder for the binaries in the host path.
+// They use the container runtime selected with EKSA_CONTAINER_RUNTIME.
 func NewLocalExecutablesBuilder() *ExecutablesBuilder {
-	return NewExecutablesBuilder(newLocalExecutableBuilder())
+	b := NewExecutablesBuilder(newLocalExecutableBuilder())
+	b.containerRuntime = ContainerRuntimeFromEnv()
+	return b
 }
 
 func DefaultEksaImage() string {
@@ -40,7 +40,7 @@ func TestLocalExecutablesBuilderAllExecutables(t *testing.T) {
 	g.Expect(cmk).NotTo(BeNil())
 	aws := b.BuildAwsCli()
 	g.Expect(aws).NotTo(BeNil())
-	flux := b.BuildFluxExecutable()
+	flux := b.BuildFluxExecutable(writer)
 	g.Expect(flux).NotTo(BeNil())
 	trouble := b.BuildTroubleshootExecutable()
 	g.Expect(trouble).NotTo(BeNil())
@@ -174,6 +174,22 @@ func (c *Clusterctl) BackupManagement(ctx context.Context, cluster *types.Cluste
 	return nil
 }
 
+// RestoreManagement restores the CAPI resources saved with BackupManagement in `from` directory to the `to` cluster.
+// If `clusterName` is provided, it filters and restores only the provided cluster.
+func (c *Clusterctl) RestoreManagement(ctx context.Context, from string, to *types.Cluster, clusterName string) error {
+	_, err := c.Execute(
+		ctx, "move",
+		"--from-directory", from,
+		"--to-kubeconfig", to.KubeconfigFile,
+		"--namespace", constants.EksaSystemNamespace,
+		"--filter-cluster", clusterName,
+	)
+	if err != nil {
+		return fmt.Errorf("failed restoring CAPI objects from backup: %v", err)
+	}
+	return nil
+}
+
 // MoveManagement moves management components `from` cluster `to` cluster
 // If `clusterName` is provided, it filters and moves only the provided cluster.
 func (c *Clusterctl) MoveManagement(ctx context.Context, from, to *types.Cluster, clusterName string) error {
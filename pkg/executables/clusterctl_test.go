@@ -274,6 +274,36 @@ func TestClusterctlBackupManagementFailed(t *testing.T) {
 	}
 }
 
+func TestClusterctlRestoreManagement(t *testing.T) {
+	tt := newClusterctlTest(t)
+	to := &types.Cluster{
+		Name:           "cluster",
+		KubeconfigFile: "cluster.kubeconfig",
+	}
+
+	wantMoveArgs := []interface{}{"move", "--from-directory", "mgmt/mgmt-restore/capi", "--to-kubeconfig", "cluster.kubeconfig", "--namespace", constants.EksaSystemNamespace, "--filter-cluster", "w01"}
+
+	tt.e.EXPECT().Execute(tt.ctx, wantMoveArgs...)
+	if err := tt.clusterctl.RestoreManagement(tt.ctx, "mgmt/mgmt-restore/capi", to, "w01"); err != nil {
+		t.Fatalf("Clusterctl.RestoreManagement() error = %v, want nil", err)
+	}
+}
+
+func TestClusterctlRestoreManagementFailed(t *testing.T) {
+	tt := newClusterctlTest(t)
+	to := &types.Cluster{
+		Name:           "cluster",
+		KubeconfigFile: "cluster.kubeconfig",
+	}
+
+	wantMoveArgs := []interface{}{"move", "--from-directory", "mgmt/mgmt-restore/capi", "--to-kubeconfig", "cluster.kubeconfig", "--namespace", constants.EksaSystemNamespace, "--filter-cluster", "w01"}
+
+	tt.e.EXPECT().Execute(tt.ctx, wantMoveArgs...).Return(bytes.Buffer{}, fmt.Errorf("error restoring"))
+	if err := tt.clusterctl.RestoreManagement(tt.ctx, "mgmt/mgmt-restore/capi", to, "w01"); err == nil {
+		t.Fatal("Clusterctl.RestoreManagement() error = nil, want not nil")
+	}
+}
+
 func TestClusterctlMoveManagement(t *testing.T) {
 	tests := []struct {
 		testName     string
@@ -0,0 +1,178 @@
+package executables
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	containerRuntimeEnv = "EKSA_CONTAINER_RUNTIME"
+	podmanPath          = "podman"
+
+	dockerSocket        = "/var/run/docker.sock"
+	podmanRootfulSocket = "/run/podman/podman.sock"
+	kindProviderEnv     = "KIND_EXPERIMENTAL_PROVIDER"
+	containerHostEnv    = "CONTAINER_HOST"
+)
+
+var geteuid = os.Geteuid
+
+// ContainerRuntime is a container engine with a Docker compatible CLI. It holds what differs
+// between the engines, so the clients built on top of it don't branch on the engine.
+type ContainerRuntime interface {
+	// Name returns the name the runtime is selected with in EKSA_CONTAINER_RUNTIME.
+	Name() string
+	// Rootless returns true if the runtime runs containers without root privileges.
+	Rootless() bool
+	// SocketPath returns the path of the API socket of the runtime in the host.
+	SocketPath() string
+
+	// cli returns the name of the runtime CLI.
+	cli() string
+	// memTotalFormat returns the format of the info command that prints the total memory.
+	memTotalFormat() string
+	// saveFlags returns the flags to save several images to the same archive.
+	saveFlags() []string
+	// kindEnv returns the env vars kind needs to create the node containers with the runtime.
+	// containerHost is the API socket the runtime client run by kind connects to when it isn't
+	// the default one, as in the tools container.
+	kindEnv(containerHost string) map[string]string
+	// toolsContainerFlags returns the flags to run the tools container with, so the executables
+	// in it can reach the runtime through a Docker compatible socket.
+	toolsContainerFlags() []string
+	// toolsImageBinaries returns the binaries, besides the executables, the tools image needs
+	// to run with the runtime.
+	toolsImageBinaries() []string
+}
+
+var (
+	// DockerRuntime is the Docker engine.
+	DockerRuntime ContainerRuntime = dockerRuntime{}
+	// PodmanRuntime is Podman, either rootful or rootless.
+	PodmanRuntime ContainerRuntime = podmanRuntime{}
+)
+
+// ContainerRuntimeFromEnv returns the container runtime selected with the EKSA_CONTAINER_RUNTIME
+// env var. It defaults to Docker.
+func ContainerRuntimeFromEnv() ContainerRuntime {
+	env := strings.ToLower(strings.TrimSpace(os.Getenv(containerRuntimeEnv)))
+	switch env {
+	case "", DockerRuntime.Name():
+		return DockerRuntime
+	case PodmanRuntime.Name():
+		return PodmanRuntime
+	default:
+		logger.Info("Warning: unsupported container runtime, using docker", "runtime", env)
+		return DockerRuntime
+	}
+}
+
+type dockerRuntime struct{}
+
+func (dockerRuntime) Name() string {
+	return "docker"
+}
+
+func (dockerRuntime) Rootless() bool {
+	return false
+}
+
+func (dockerRuntime) SocketPath() string {
+	return dockerSocket
+}
+
+func (dockerRuntime) cli() string {
+	return dockerPath
+}
+
+func (dockerRuntime) memTotalFormat() string {
+	return "'{{json .MemTotal}}'"
+}
+
+func (dockerRuntime) saveFlags() []string {
+	return nil
+}
+
+func (dockerRuntime) kindEnv(string) map[string]string {
+	return nil
+}
+
+func (r dockerRuntime) toolsContainerFlags() []string {
+	return []string{"-v", fmt.Sprintf("%s:%s", r.SocketPath(), dockerSocket)}
+}
+
+func (dockerRuntime) toolsImageBinaries() []string {
+	return nil
+}
+
+type podmanRuntime struct{}
+
+func (podmanRuntime) Name() string {
+	return "podman"
+}
+
+func (podmanRuntime) Rootless() bool {
+	return geteuid() != 0
+}
+
+// SocketPath returns the path of the API socket of Podman in the host.
+// It serves a Docker compatible API on it when the podman.socket unit is enabled.
+func (r podmanRuntime) SocketPath() string {
+	if host, ok := strings.CutPrefix(os.Getenv(containerHostEnv), "unix://"); ok {
+		return host
+	}
+
+	if !r.Rootless() {
+		return podmanRootfulSocket
+	}
+
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+	return runtimeDir + "/podman/podman.sock"
+}
+
+func (podmanRuntime) cli() string {
+	return podmanPath
+}
+
+func (podmanRuntime) memTotalFormat() string {
+	return "'{{json .Host.MemTotal}}'"
+}
+
+func (podmanRuntime) saveFlags() []string {
+	// Podman only saves several images to the same archive when asked to.
+	return []string{"--multi-image-archive"}
+}
+
+func (r podmanRuntime) kindEnv(containerHost string) map[string]string {
+	env := map[string]string{kindProviderEnv: r.Name()}
+	if containerHost != "" {
+		env[containerHostEnv] = "unix://" + containerHost
+	}
+	return env
+}
+
+func (r podmanRuntime) toolsContainerFlags() []string {
+	flags := []string{
+		"-v", fmt.Sprintf("%s:%s", r.SocketPath(), dockerSocket),
+		// SELinux, enabled by default in RHEL, prevents containers from connecting to the host socket.
+		"--security-opt", "label=disable",
+	}
+	if r.Rootless() {
+		// Keep the host user in the container so files written to the mounted directories
+		// stay owned by it.
+		flags = append(flags, "--userns", "keep-id")
+	}
+	return flags
+}
+
+// toolsImageBinaries returns the Podman client, which the kind Podman provider runs
+// to create the bootstrap cluster from the tools container.
+func (podmanRuntime) toolsImageBinaries() []string {
+	return []string{podmanPath}
+}
@@ -0,0 +1,166 @@
+package executables_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/executables/mocks"
+)
+
+func TestContainerRuntimeFromEnv(t *testing.T) {
+	tests := []struct {
+		name   string
+		envVar string
+		want   executables.ContainerRuntime
+	}{
+		{
+			name:   "default",
+			envVar: "",
+			want:   executables.DockerRuntime,
+		},
+		{
+			name:   "docker",
+			envVar: "docker",
+			want:   executables.DockerRuntime,
+		},
+		{
+			name:   "podman",
+			envVar: "Podman",
+			want:   executables.PodmanRuntime,
+		},
+		{
+			name:   "unsupported",
+			envVar: "containerd",
+			want:   executables.DockerRuntime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("EKSA_CONTAINER_RUNTIME", tt.envVar)
+			g := NewWithT(t)
+			g.Expect(executables.ContainerRuntimeFromEnv()).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestContainerRuntimeSocketPath(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(executables.DockerRuntime.SocketPath()).To(Equal("/var/run/docker.sock"))
+
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/podman.sock")
+	g.Expect(executables.PodmanRuntime.SocketPath()).To(Equal("/tmp/podman.sock"))
+
+	t.Setenv("CONTAINER_HOST", "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if os.Geteuid() == 0 {
+		g.Expect(executables.PodmanRuntime.Rootless()).To(BeFalse())
+		g.Expect(executables.PodmanRuntime.SocketPath()).To(Equal("/run/podman/podman.sock"))
+	} else {
+		g.Expect(executables.PodmanRuntime.Rootless()).To(BeTrue())
+		g.Expect(executables.PodmanRuntime.SocketPath()).To(Equal("/run/user/1000/podman/podman.sock"))
+	}
+}
+
+func TestInPodmanExecutablesBuilder(t *testing.T) {
+	t.Setenv("EKSA_CONTAINER_RUNTIME", "podman")
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/podman.sock")
+	g := NewWithT(t)
+	ctx := context.Background()
+	image := "image"
+	ctrl := gomock.NewController(t)
+	c := mocks.NewMockDockerClient(ctrl)
+	c.EXPECT().PullImage(ctx, image)
+	c.EXPECT().Execute(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, args ...string) (bytes.Buffer, error) {
+		g.Expect(args).To(ContainElements("/tmp/podman.sock:/var/run/docker.sock", "label=disable"))
+		return bytes.Buffer{}, nil
+	})
+	c.EXPECT().Execute(ctx, "exec", gomock.Any(), "podman", "--version")
+	c.EXPECT().Execute(ctx, gomock.Any()) // Remove container
+
+	b, err := executables.NewInDockerExecutablesBuilder(c, image)
+	g.Expect(err).NotTo(HaveOccurred())
+	closer, err := b.Init(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(b.BuildDockerExecutable().Runtime()).To(Equal(executables.DockerRuntime), "executables in the tools container see a Docker compatible socket")
+	g.Expect(executables.KindEnv(b.BuildKindExecutable(nil))).To(Equal(map[string]string{
+		"KIND_EXPERIMENTAL_PROVIDER": "podman",
+		"CONTAINER_HOST":             "unix:///var/run/docker.sock",
+	}))
+	g.Expect(closer(ctx)).To(Succeed())
+}
+
+func TestInRootlessPodmanExecutablesBuilder(t *testing.T) {
+	executables.FakeRootless(t)
+	t.Setenv("EKSA_CONTAINER_RUNTIME", "podman")
+	t.Setenv("CONTAINER_HOST", "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	g := NewWithT(t)
+	ctx := context.Background()
+	image := "image"
+	ctrl := gomock.NewController(t)
+	c := mocks.NewMockDockerClient(ctrl)
+	c.EXPECT().PullImage(ctx, image)
+	c.EXPECT().Execute(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, args ...string) (bytes.Buffer, error) {
+		g.Expect(args).To(ContainElements("/run/user/1000/podman/podman.sock:/var/run/docker.sock", "label=disable", "--userns", "keep-id"))
+		return bytes.Buffer{}, nil
+	})
+	c.EXPECT().Execute(ctx, "exec", gomock.Any(), "podman", "--version")
+	c.EXPECT().Execute(ctx, gomock.Any()) // Remove container
+
+	b, err := executables.NewInDockerExecutablesBuilder(c, image)
+	g.Expect(err).NotTo(HaveOccurred())
+	closer, err := b.Init(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(executables.PodmanRuntime.Rootless()).To(BeTrue())
+	g.Expect(b.BuildDockerExecutable().Runtime()).To(Equal(executables.DockerRuntime))
+	g.Expect(executables.KindEnv(b.BuildKindExecutable(nil))).To(Equal(map[string]string{
+		"KIND_EXPERIMENTAL_PROVIDER": "podman",
+		"CONTAINER_HOST":             "unix:///var/run/docker.sock",
+	}), "kind creates the node containers with the Podman provider through the mounted socket")
+	g.Expect(closer(ctx)).To(Succeed())
+}
+
+func TestInPodmanExecutablesBuilderMissingPodmanBinary(t *testing.T) {
+	t.Setenv("EKSA_CONTAINER_RUNTIME", "podman")
+	t.Setenv("CONTAINER_HOST", "unix:///tmp/podman.sock")
+	g := NewWithT(t)
+	ctx := context.Background()
+	image := "image"
+	ctrl := gomock.NewController(t)
+	c := mocks.NewMockDockerClient(ctrl)
+	c.EXPECT().PullImage(ctx, image)
+	c.EXPECT().Execute(ctx, gomock.Any()) // Run container
+	c.EXPECT().Execute(ctx, "exec", gomock.Any(), "podman", "--version").Return(bytes.Buffer{}, errors.New("executable file not found in $PATH"))
+	c.EXPECT().Execute(ctx, "rm", "-f", "-v", gomock.Any())
+
+	b, err := executables.NewInDockerExecutablesBuilder(c, image)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = b.Init(ctx)
+	g.Expect(err).To(MatchError("tools image image doesn't have the podman binary required to run with podman: executable file not found in $PATH"))
+}
+
+func TestInDockerExecutablesBuilderKind(t *testing.T) {
+	t.Setenv("EKSA_CONTAINER_RUNTIME", "")
+	g := NewWithT(t)
+
+	b, err := executables.NewInDockerExecutablesBuilder(mocks.NewMockDockerClient(gomock.NewController(t)), "image")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(executables.KindEnv(b.BuildKindExecutable(nil))).To(BeEmpty())
+}
+
+func TestLocalPodmanExecutablesBuilder(t *testing.T) {
+	t.Setenv("EKSA_CONTAINER_RUNTIME", "podman")
+	g := NewWithT(t)
+
+	b := executables.NewLocalExecutablesBuilder()
+	g.Expect(b.BuildDockerExecutable().Runtime()).To(Equal(executables.PodmanRuntime))
+	g.Expect(executables.KindEnv(b.BuildKindExecutable(nil))).To(Equal(map[string]string{"KIND_EXPERIMENTAL_PROVIDER": "podman"}))
+	g.Expect(executables.BuildDockerExecutable().Runtime()).To(Equal(executables.PodmanRuntime))
+}
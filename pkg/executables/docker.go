@@ -18,12 +18,24 @@ const (
 	packageDevDomain  = "067575901363.dkr.ecr.us-west-2.amazonaws.com"
 )
 
+// Docker is the client of a container runtime with a Docker compatible CLI, Docker by default.
 type Docker struct {
 	Executable
+	runtime ContainerRuntime
 }
 
 func NewDocker(executable Executable) *Docker {
-	return &Docker{Executable: executable}
+	return &Docker{Executable: executable, runtime: DockerRuntime}
+}
+
+// NewPodman builds a Docker client that runs the Podman CLI.
+func NewPodman(executable Executable) *Docker {
+	return &Docker{Executable: executable, runtime: PodmanRuntime}
+}
+
+// Runtime returns the container runtime of the client.
+func (d *Docker) Runtime() ContainerRuntime {
+	return d.runtime
 }
 
 func (d *Docker) GetDockerLBPort(ctx context.Context, clusterName string) (port string, err error) {
@@ -60,7 +72,7 @@ func (d *Docker) Version(ctx context.Context) (int, error) {
 }
 
 func (d *Docker) AllocatedMemory(ctx context.Context) (uint64, error) {
-	cmdOutput, err := d.Execute(ctx, "info", "--format", "'{{json .MemTotal}}'")
+	cmdOutput, err := d.Execute(ctx, "info", "--format", d.runtime.memTotalFormat())
 	if err != nil {
 		return 0, fmt.Errorf("please check if docker is installed and running %v", err)
 	}
@@ -105,8 +117,9 @@ func (d *Docker) LoadFromFile(ctx context.Context, filepath string) error {
 }
 
 func (d *Docker) SaveToFile(ctx context.Context, filepath string, images ...string) error {
-	params := make([]string, 0, 3+len(images))
+	params := make([]string, 0, 4+len(images))
 	params = append(params, "save", "-o", filepath)
+	params = append(params, d.runtime.saveFlags()...)
 	params = append(params, images...)
 
 	if _, err := d.Execute(ctx, params...); err != nil {
@@ -145,7 +158,7 @@ func (d *Docker) CheckContainerExistence(ctx context.Context, name string) (bool
 	_, err := d.Execute(ctx, params...)
 	if err == nil {
 		return true, nil
-	} else if strings.Contains(err.Error(), "No such container") {
+	} else if strings.Contains(strings.ToLower(err.Error()), "no such container") {
 		return false, nil
 	}
 
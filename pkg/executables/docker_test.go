@@ -281,3 +281,40 @@ func TestDockerCheckContainerExistenceOtherError(t *testing.T) {
 	assert.False(t, exists)
 	assert.EqualError(t, err, expectedError, "Error should be: %v, got: %v", expectedError, err)
 }
+
+func TestPodmanAllocatedMemory(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "info", "--format", "'{{json .Host.MemTotal}}'").Return(*bytes.NewBufferString("'12345'\n"), nil)
+	d := executables.NewPodman(executable)
+
+	g.Expect(d.Runtime()).To(Equal(executables.PodmanRuntime))
+	g.Expect(d.AllocatedMemory(ctx)).To(Equal(uint64(12345)))
+}
+
+func TestPodmanSaveToFileMultipleImages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "save", "-o", "file", "--multi-image-archive", "image1:tag1", "image2:tag2").Return(bytes.Buffer{}, nil)
+	d := executables.NewPodman(executable)
+
+	g.Expect(d.SaveToFile(ctx, "file", "image1:tag1", "image2:tag2")).To(Succeed())
+}
+
+func TestPodmanCheckContainerExistenceDoesNotExists(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	mockCtrl := gomock.NewController(t)
+
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().Execute(ctx, "container", "inspect", "basic_test").Return(bytes.Buffer{}, errors.New("Error: no such container basic_test"))
+	d := executables.NewPodman(executable)
+
+	g.Expect(d.CheckContainerExistence(ctx, "basic_test")).To(BeFalse())
+}
@@ -9,17 +9,23 @@ type DockerContainer interface {
 }
 
 func NewDockerExecutableBuilder(dockerContainer DockerContainer) *dockerExecutableBuilder {
+	return newContainerExecutableBuilder(dockerContainer, DockerRuntime)
+}
+
+func newContainerExecutableBuilder(container DockerContainer, runtime ContainerRuntime) *dockerExecutableBuilder {
 	return &dockerExecutableBuilder{
-		container: dockerContainer,
+		container: container,
+		runtime:   runtime,
 	}
 }
 
 type dockerExecutableBuilder struct {
 	container DockerContainer
+	runtime   ContainerRuntime
 }
 
 func (d *dockerExecutableBuilder) Build(binaryName string) Executable {
-	return NewDockerExecutable(binaryName, d.container.ContainerName())
+	return newContainerExecutable(d.runtime, binaryName, d.container.ContainerName())
 }
 
 func (b *dockerExecutableBuilder) Init(ctx context.Context) (Closer, error) {
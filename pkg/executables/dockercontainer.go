@@ -25,17 +25,19 @@ type dockerContainer struct {
 	mountDirs           []string
 	containerName       string
 	dockerClient        DockerClient
+	runtime             ContainerRuntime
 	initOnce, closeOnce sync.Once
 	*retrier.Retrier
 }
 
-func newDockerContainer(image, workingDir string, mountDirs []string, dockerClient DockerClient) *dockerContainer {
+func newDockerContainer(image, workingDir string, mountDirs []string, dockerClient DockerClient, runtime ContainerRuntime) *dockerContainer {
 	return &dockerContainer{
 		image:         image,
 		workingDir:    workingDir,
 		mountDirs:     mountDirs,
 		containerName: containerNamePrefix + strconv.FormatInt(time.Now().UnixNano(), 10),
 		dockerClient:  dockerClient,
+		runtime:       runtime,
 		Retrier:       retrier.NewWithMaxRetries(maxRetries, backOffPeriod),
 	}
 }
@@ -43,6 +45,7 @@ func newDockerContainer(image, workingDir string, mountDirs []string, dockerClie
 func NewDockerContainerCustomBinary(docker DockerClient) *dockerContainer {
 	return &dockerContainer{
 		dockerClient: docker,
+		runtime:      DockerRuntime,
 	}
 }
 
@@ -63,7 +66,8 @@ func (d *dockerContainer) Init(ctx context.Context) error {
 			return
 		}
 
-		params := []string{"run", "-d", "--name", d.containerName, "--network", "host", "-w", absWorkingDir, "-v", "/var/run/docker.sock:/var/run/docker.sock"}
+		params := []string{"run", "-d", "--name", d.containerName, "--network", "host", "-w", absWorkingDir}
+		params = append(params, d.runtime.toolsContainerFlags()...)
 
 		for _, m := range d.mountDirs {
 			var absMountDir string
@@ -78,12 +82,31 @@ func (d *dockerContainer) Init(ctx context.Context) error {
 		// start container and keep it running in the background
 		logger.V(3).Info("Initializing long running container", "name", d.containerName, "image", d.image)
 		params = append(params, "--entrypoint", "sleep", d.image, "infinity")
-		_, err = d.dockerClient.Execute(ctx, params...)
+		if _, err = d.dockerClient.Execute(ctx, params...); err != nil {
+			return
+		}
+
+		err = d.checkToolsImageBinaries(ctx)
 	})
 
 	return err
 }
 
+// checkToolsImageBinaries checks the container has the binaries it needs to run with the
+// container runtime. It removes the container if it doesn't, since it can't be used.
+func (d *dockerContainer) checkToolsImageBinaries(ctx context.Context) error {
+	for _, binary := range d.runtime.toolsImageBinaries() {
+		if _, err := d.dockerClient.Execute(ctx, "exec", d.containerName, binary, "--version"); err != nil {
+			if closeErr := d.Close(ctx); closeErr != nil {
+				logger.Error(closeErr, "Failed removing tools container", "name", d.containerName)
+			}
+			return fmt.Errorf("tools image %s doesn't have the %s binary required to run with %s: %v", d.image, binary, d.runtime.Name(), err)
+		}
+	}
+
+	return nil
+}
+
 func (d *dockerContainer) ContainerName() string {
 	return d.containerName
 }
@@ -11,14 +11,21 @@ const containerNamePrefix = "eksa_"
 type linuxDockerExecutable struct {
 	cli           string
 	containerName string
+	runtimeCli    string
 }
 
 // This currently returns a linuxDockerExecutable, but if we support other types of docker executables we can change
 // the name of this constructor.
 func NewDockerExecutable(cli string, containerName string) Executable {
+	return newContainerExecutable(DockerRuntime, cli, containerName)
+}
+
+// newContainerExecutable builds an Executable that runs cli in the container with the runtime.
+func newContainerExecutable(runtime ContainerRuntime, cli string, containerName string) Executable {
 	return &linuxDockerExecutable{
 		cli:           cli,
 		containerName: containerName,
+		runtimeCli:    runtime.cli(),
 	}
 }
 
@@ -39,7 +46,7 @@ func (e *linuxDockerExecutable) Command(ctx context.Context, args ...string) *Co
 }
 
 func (e *linuxDockerExecutable) Run(cmd *Command) (stdout bytes.Buffer, err error) {
-	return execute(cmd.ctx, "docker", cmd.stdIn, cmd.envVars, e.buildCommand(cmd.envVars, e.cli, cmd.args...)...)
+	return execute(cmd.ctx, e.runtimeCli, cmd.stdIn, cmd.envVars, e.buildCommand(cmd.envVars, e.cli, cmd.args...)...)
 }
 
 func (e *linuxDockerExecutable) buildCommand(envs map[string]string, cli string, args ...string) []string {
@@ -2,6 +2,7 @@ package executables
 
 import (
 	"context"
+	"testing"
 	"time"
 )
 
@@ -12,3 +13,15 @@ func KubectlWaitRetryPolicy(k *Kubectl, totalRetries int, err error) (retry bool
 func CallKubectlPrivateWait(k *Kubectl, ctx context.Context, kubeconfig string, timeoutTime time.Time, forCondition string, property string, namespace string) error {
 	return k.wait(ctx, kubeconfig, timeoutTime, forCondition, property, namespace)
 }
+
+// FakeRootless makes the container runtimes see a non root user for the duration of the test.
+func FakeRootless(t *testing.T) {
+	original := geteuid
+	geteuid = func() int { return 1000 }
+	t.Cleanup(func() { geteuid = original })
+}
+
+// KindEnv returns the env vars kind runs with to create the node containers.
+func KindEnv(k *Kind) map[string]string {
+	return k.containerRuntime.kindEnv(k.containerHost)
+}
@@ -6,7 +6,10 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
 
@@ -15,17 +18,22 @@ const (
 	eksaGithubTokenEnv         = "EKSA_GITHUB_TOKEN"
 	githubTokenEnv             = "GITHUB_TOKEN"
 	githubProvider             = "github"
+	gitlabProvider             = "gitlab"
+	giteaProvider              = "gitea"
 	gitProvider                = "git"
 	defaultPrivateKeyAlgorithm = "ecdsa"
+	fluxCAFile                 = "flux-git-ca.pem"
 )
 
 type Flux struct {
 	Executable
+	writer filewriter.FileWriter
 }
 
-func NewFlux(executable Executable) *Flux {
+func NewFlux(executable Executable, writer filewriter.FileWriter) *Flux {
 	return &Flux{
 		Executable: executable,
+		writer:     writer,
 	}
 }
 
@@ -64,6 +72,109 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, flux
 	return err
 }
 
+// BootstrapGitlab creates the GitLab project if it doesn’t exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	hostname, err := gitlab.Hostname(c.Gitlab)
+	if err != nil {
+		return err
+	}
+
+	params := []string{
+		"bootstrap",
+		gitlabProvider,
+		"--repository", c.Gitlab.Repository,
+		"--owner", c.Gitlab.Owner,
+		"--hostname", hostname,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.Gitlab.Personal {
+		params = append(params, "--personal")
+	}
+
+	params, err = f.appendCAFile(params, c.Gitlab.CACertContent)
+	if err != nil {
+		return err
+	}
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := map[string]string{gitlab.GitlabTokenEnv: token}
+	if _, err = f.ExecuteWithEnv(ctx, env, params...); err != nil {
+		return fmt.Errorf("executing flux bootstrap gitlab: %v", err)
+	}
+
+	return nil
+}
+
+// BootstrapGitea creates the Gitea repository if it doesn’t exist, and commits the toolkit
+// components manifests to the main branch. Then it configures the target cluster to synchronize with the repository.
+// If the toolkit components are present on the cluster, the bootstrap command will perform an upgrade if needed.
+func (f *Flux) BootstrapGitea(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error {
+	c := fluxConfig.Spec
+	hostname, err := gitea.Hostname(c.Gitea)
+	if err != nil {
+		return err
+	}
+
+	params := []string{
+		"bootstrap",
+		giteaProvider,
+		"--repository", c.Gitea.Repository,
+		"--owner", c.Gitea.Owner,
+		"--hostname", hostname,
+		"--path", c.ClusterConfigPath,
+		"--ssh-key-algorithm", defaultPrivateKeyAlgorithm,
+	}
+	params = setUpCommonParamsBootstrap(cluster, fluxConfig, params)
+
+	if c.Gitea.Personal {
+		params = append(params, "--personal")
+	}
+
+	params, err = f.appendCAFile(params, c.Gitea.CACertContent)
+	if err != nil {
+		return err
+	}
+
+	token, err := gitea.GetGiteaAccessTokenFromEnv()
+	if err != nil {
+		return fmt.Errorf("setting token env: %v", err)
+	}
+
+	env := map[string]string{gitea.GiteaTokenEnv: token}
+	if _, err = f.ExecuteWithEnv(ctx, env, params...); err != nil {
+		return fmt.Errorf("executing flux bootstrap gitea: %v", err)
+	}
+
+	return nil
+}
+
+// appendCAFile writes the CA bundle to disk so flux can read it and adds the --ca-file flag to params.
+func (f *Flux) appendCAFile(params []string, caCertContent string) ([]string, error) {
+	if caCertContent == "" {
+		return params, nil
+	}
+	if f.writer == nil {
+		return nil, fmt.Errorf("writing flux ca file: no file writer configured")
+	}
+
+	path, err := f.writer.Write(fluxCAFile, []byte(caCertContent))
+	if err != nil {
+		return nil, fmt.Errorf("writing flux ca file: %v", err)
+	}
+
+	return append(params, "--ca-file", path), nil
+}
+
 // BootstrapGit commits the toolkit components manifests to the branch of a Git repository.
 // It then configures the target cluster to synchronize with the repository. If the toolkit components are present on the cluster, the
 // bootstrap command will perform an upgrade if needed.
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/executables"
@@ -141,7 +143,7 @@ func TestFluxInstallGithubToolkitsSuccess(t *testing.T) {
 				tt.wantExecArgs...,
 			).Return(bytes.Buffer{}, nil)
 
-			f := executables.NewFlux(executable)
+			f := executables.NewFlux(executable, nil)
 			if err := f.BootstrapGithub(ctx, tt.cluster, tt.fluxConfig); err != nil {
 				t.Errorf("flux.BootstrapGithub() error = %v, want nil", err)
 			}
@@ -149,6 +151,111 @@ func TestFluxInstallGithubToolkitsSuccess(t *testing.T) {
 	}
 }
 
+func TestFluxInstallGitlabToolkitsSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Setenv("EKSA_GITLAB_TOKEN", validPATValue)
+
+	owner := "group/subgroup"
+	repo := "gitops-fleet"
+	path := "clusters/cluster-name"
+
+	tests := []struct {
+		testName     string
+		cluster      *types.Cluster
+		fluxConfig   *v1alpha1.FluxConfig
+		wantExecArgs []interface{}
+	}{
+		{
+			testName: "gitlab.com",
+			cluster:  &types.Cluster{KubeconfigFile: "f.kubeconfig"},
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					ClusterConfigPath: path,
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Owner:      owner,
+						Repository: repo,
+					},
+				},
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "gitlab", "--repository", repo, "--owner", owner, "--hostname", "gitlab.com", "--path", path, "--ssh-key-algorithm", "ecdsa", "--kubeconfig", "f.kubeconfig",
+			},
+		},
+		{
+			testName: "self-managed personal with ca",
+			cluster:  &types.Cluster{},
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					ClusterConfigPath: path,
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Owner:         "janedoe",
+						Repository:    repo,
+						Personal:      true,
+						BaseURL:       "https://gitlab.example.com:8443/",
+						CACertContent: "ca",
+					},
+				},
+			},
+			wantExecArgs: []interface{}{
+				"bootstrap", "gitlab", "--repository", repo, "--owner", "janedoe", "--hostname", "gitlab.example.com:8443", "--path", path, "--ssh-key-algorithm", "ecdsa", "--personal", "--ca-file", "generated/flux-git-ca.pem",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			ctx := context.Background()
+			dir, writer := test.NewWriter(t)
+			for i, arg := range tt.wantExecArgs {
+				if arg == "generated/flux-git-ca.pem" {
+					tt.wantExecArgs[i] = filepath.Join(dir, "generated", "flux-git-ca.pem")
+				}
+			}
+			executable := mockexecutables.NewMockExecutable(mockCtrl)
+			env := map[string]string{"GITLAB_TOKEN": validPATValue}
+			executable.EXPECT().ExecuteWithEnv(
+				ctx,
+				env,
+				tt.wantExecArgs...,
+			).Return(bytes.Buffer{}, nil)
+
+			f := executables.NewFlux(executable, writer)
+			if err := f.BootstrapGitlab(ctx, tt.cluster, tt.fluxConfig); err != nil {
+				t.Errorf("flux.BootstrapGitlab() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestFluxInstallGiteaToolkitsSuccess(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Setenv("EKSA_GITEA_TOKEN", validPATValue)
+
+	ctx := context.Background()
+	fluxConfig := &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			ClusterConfigPath: "clusters/cluster-name",
+			Branch:            "main",
+			Gitea: &v1alpha1.GiteaProviderConfig{
+				Owner:      "org",
+				Repository: "gitops-fleet",
+				BaseURL:    "https://gitea.example.com",
+			},
+		},
+	}
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().ExecuteWithEnv(
+		ctx,
+		map[string]string{"GITEA_TOKEN": validPATValue},
+		"bootstrap", "gitea", "--repository", "gitops-fleet", "--owner", "org", "--hostname", "gitea.example.com", "--path", "clusters/cluster-name", "--ssh-key-algorithm", "ecdsa", "--branch", "main",
+	).Return(bytes.Buffer{}, nil)
+
+	f := executables.NewFlux(executable, nil)
+	if err := f.BootstrapGitea(ctx, &types.Cluster{}, fluxConfig); err != nil {
+		t.Errorf("flux.BootstrapGitea() error = %v, want nil", err)
+	}
+}
+
 func TestFluxUninstallGitOpsToolkitsComponents(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
@@ -202,7 +309,7 @@ func TestFluxUninstallGitOpsToolkitsComponents(t *testing.T) {
 				tt.wantExecArgs...,
 			).Return(bytes.Buffer{}, nil)
 
-			f := executables.NewFlux(executable)
+			f := executables.NewFlux(executable, nil)
 			if err := f.Uninstall(ctx, tt.cluster, tt.fluxConfig); err != nil {
 				t.Errorf("flux.Uninstall() error = %v, want nil", err)
 			}
@@ -273,7 +380,7 @@ func TestFluxPauseKustomization(t *testing.T) {
 				tt.wantExecArgs...,
 			).Return(bytes.Buffer{}, nil)
 
-			f := executables.NewFlux(executable)
+			f := executables.NewFlux(executable, nil)
 			if err := f.SuspendKustomization(ctx, tt.cluster, tt.fluxConfig); err != nil {
 				t.Errorf("flux.SuspendKustomization() error = %v, want nil", err)
 			}
@@ -345,7 +452,7 @@ func TestFluxResumeKustomization(t *testing.T) {
 				tt.wantExecArgs...,
 			).Return(bytes.Buffer{}, nil)
 
-			f := executables.NewFlux(executable)
+			f := executables.NewFlux(executable, nil)
 			if err := f.ResumeKustomization(ctx, tt.cluster, tt.fluxConfig); err != nil {
 				t.Errorf("flux.ResumeKustomization() error = %v, want nil", err)
 			}
@@ -407,7 +514,7 @@ func TestFluxReconcile(t *testing.T) {
 				tt.wantExecArgs...,
 			).Return(bytes.Buffer{}, nil)
 
-			f := executables.NewFlux(executable)
+			f := executables.NewFlux(executable, nil)
 			if err := f.Reconcile(ctx, tt.cluster, tt.fluxConfig); err != nil {
 				t.Errorf("flux.Reconcile() error = %v, want nil", err)
 			}
@@ -550,7 +657,7 @@ func TestFluxInstallGitToolkitsSuccess(t *testing.T) {
 				tt.wantExecArgs...,
 			).Return(bytes.Buffer{}, nil)
 
-			f := executables.NewFlux(executable)
+			f := executables.NewFlux(executable, nil)
 			if err := f.BootstrapGit(ctx, tt.cluster, tt.fluxConfig, tt.cliConfig); err != nil {
 				t.Errorf("flux.BootstrapGit() error = %v, want nil", err)
 			}
@@ -277,6 +277,16 @@ func (g *Govc) GetVMDiskSizeInGB(ctx context.Context, vm, datacenter string) (in
 	return int(devicesInfo[0].CapacityInKB / 1024 / 1024), nil
 }
 
+// GetVMDiskCount returns the number of disks on the VM.
+func (g *Govc) GetVMDiskCount(ctx context.Context, vm, datacenter string) (int, error) {
+	devicesInfo, err := g.DevicesInfo(ctx, datacenter, vm, "disk-*")
+	if err != nil {
+		return 0, fmt.Errorf("getting disks for vm %s: %v", vm, err)
+	}
+
+	return len(devicesInfo), nil
+}
+
 // GetHardDiskSize returns the size of all the hard disks for given VM.
 func (g *Govc) GetHardDiskSize(ctx context.Context, vm, datacenter string) (map[string]float64, error) {
 	devicesInfo, err := g.DevicesInfo(ctx, datacenter, vm, "disk-*")
@@ -916,6 +926,137 @@ func (g *Govc) GetComputeClusterPath(ctx context.Context, datacenter string, com
 	return foundCluster, nil
 }
 
+// PCIPassthroughDevice is a PCI device an ESXi host can pass through to its VMs.
+type PCIPassthroughDevice struct {
+	ID       string
+	VendorID int32
+	DeviceID int32
+}
+
+type hostPCIDevice struct {
+	ID string `json:"id"`
+	// vSphere reports the IDs as signed 16 bit integers.
+	VendorID int16 `json:"vendorId"`
+	DeviceID int16 `json:"deviceId"`
+}
+
+type hostPCIPassthruInfo struct {
+	ID             string `json:"id"`
+	PassthruActive bool   `json:"passthruActive"`
+}
+
+type hostPCIProperty struct {
+	Name string `json:"name"`
+	Val  struct {
+		HostPciDevice       []hostPCIDevice       `json:"hostPciDevice"`
+		HostPciPassthruInfo []hostPCIPassthruInfo `json:"hostPciPassthruInfo"`
+	} `json:"val"`
+}
+
+// GetResourcePoolComputeResourcePath returns the path of the compute resource, cluster or standalone
+// host, a resource pool belongs to. The resource pool is resolved in the datacenter first, so it can
+// be a name or a path like the resource pool of the machine configs.
+func (g *Govc) GetResourcePoolComputeResourcePath(ctx context.Context, datacenter, resourcePool string) (string, error) {
+	envMap, err := g.validateAndSetupCreds()
+	if err != nil {
+		return "", fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	poolPath, err := g.GetResourcePoolPath(ctx, datacenter, resourcePool, envMap)
+	if err != nil {
+		return "", err
+	}
+
+	// Resource pools are under the root pool of their compute resource, /<datacenter>/host/<compute resource>/Resources.
+	elems := strings.Split(poolPath, "/")
+	for i, elem := range elems {
+		if elem == "Resources" && i > 0 {
+			return strings.Join(elems[:i], "/"), nil
+		}
+	}
+	return "", fmt.Errorf("resource pool %s doesn't belong to a compute resource", poolPath)
+}
+
+// GetHostsPCIPassthroughDevices returns the PCI devices with passthrough active of each ESXi host
+// in a compute resource, indexed by host path. Compute resources not given by their full path
+// are looked up as compute clusters in the datacenter.
+func (g *Govc) GetHostsPCIPassthroughDevices(ctx context.Context, datacenter, computeResource string) (map[string][]PCIPassthroughDevice, error) {
+	envMap, err := g.validateAndSetupCreds()
+	if err != nil {
+		return nil, fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	if !strings.HasPrefix(computeResource, "/"+datacenter+"/") {
+		if computeResource, err = g.GetComputeClusterPath(ctx, datacenter, computeResource, envMap); err != nil {
+			return nil, err
+		}
+	}
+
+	var hostsResponse bytes.Buffer
+	err = g.Retry(func() error {
+		hostsResponse, err = g.ExecuteWithEnv(ctx, envMap, "find", "-json", computeResource, "-type", "h")
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting hosts in %s: %v", computeResource, err)
+	}
+
+	hosts := make([]string, 0)
+	hostsJSON := strings.TrimSuffix(hostsResponse.String(), "\n")
+	if hostsJSON != "null" && hostsJSON != "" {
+		if err = json.Unmarshal([]byte(hostsJSON), &hosts); err != nil {
+			return nil, fmt.Errorf("failed unmarshalling govc response: %v", err)
+		}
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no hosts found in %s", computeResource)
+	}
+
+	devices := make(map[string][]PCIPassthroughDevice, len(hosts))
+	for _, host := range hosts {
+		var response bytes.Buffer
+		err = g.Retry(func() error {
+			response, err = g.ExecuteWithEnv(ctx, envMap, "object.collect", "-json", host, "hardware.pciDevice", "config.pciPassthruInfo")
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting PCI devices of host %s: %v", host, err)
+		}
+
+		properties := make([]hostPCIProperty, 0)
+		if err = json.Unmarshal(response.Bytes(), &properties); err != nil {
+			return nil, fmt.Errorf("failed unmarshalling govc response: %v", err)
+		}
+
+		active := map[string]bool{}
+		var hostDevices []hostPCIDevice
+		for _, property := range properties {
+			switch property.Name {
+			case "hardware.pciDevice":
+				hostDevices = property.Val.HostPciDevice
+			case "config.pciPassthruInfo":
+				for _, info := range property.Val.HostPciPassthruInfo {
+					active[info.ID] = info.PassthruActive
+				}
+			}
+		}
+
+		devices[host] = []PCIPassthroughDevice{}
+		for _, d := range hostDevices {
+			if !active[d.ID] {
+				continue
+			}
+			devices[host] = append(devices[host], PCIPassthroughDevice{
+				ID:       d.ID,
+				VendorID: int32(uint16(d.VendorID)),
+				DeviceID: int32(uint16(d.DeviceID)),
+			})
+		}
+	}
+
+	return devices, nil
+}
+
 // ValidateVCenterSetupMachineConfig validates that all resources specified in a
 // VSphereMachineConfig exist and are accessible.
 func (g *Govc) ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, _ *bool) error {
@@ -1228,6 +1228,162 @@ func TestGetComputeClusterPathErrorNullOrEmptyResponse(t *testing.T) {
 	}
 }
 
+func TestGovcGetResourcePoolComputeResourcePath(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourcePool string
+		poolName     string
+		found        string
+		want         string
+	}{
+		{
+			name:         "root pool with wildcard",
+			resourcePool: "*/Resources",
+			poolName:     "Resources",
+			found:        `["/SDDC-Datacenter/host/Cluster-1/Resources"]`,
+			want:         "/SDDC-Datacenter/host/Cluster-1",
+		},
+		{
+			name:         "pool name",
+			resourcePool: "gpu-pool",
+			poolName:     "gpu-pool",
+			found:        `["/SDDC-Datacenter/host/folder/Cluster-1/Resources/parent/gpu-pool"]`,
+			want:         "/SDDC-Datacenter/host/folder/Cluster-1",
+		},
+		{
+			name:         "standalone host",
+			resourcePool: "/SDDC-Datacenter/host/esxi-1/Resources/gpu-pool",
+			poolName:     "gpu-pool",
+			found:        `["/SDDC-Datacenter/host/esxi-1/Resources/gpu-pool"]`,
+			want:         "/SDDC-Datacenter/host/esxi-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			datacenter := "SDDC-Datacenter"
+			_, g, executable, env := setup(t)
+			gt := NewWithT(t)
+
+			executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", "/"+datacenter, "-type", "p", "-name", tt.poolName).
+				Return(*bytes.NewBufferString(tt.found), nil)
+
+			path, err := g.GetResourcePoolComputeResourcePath(ctx, datacenter, tt.resourcePool)
+			gt.Expect(err).NotTo(HaveOccurred())
+			gt.Expect(path).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGovcGetResourcePoolComputeResourcePathMultipleComputeResources(t *testing.T) {
+	ctx := context.Background()
+	datacenter := "SDDC-Datacenter"
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", "/"+datacenter, "-type", "p", "-name", "Resources").
+		Return(*bytes.NewBufferString(`["/SDDC-Datacenter/host/Cluster-1/Resources", "/SDDC-Datacenter/host/Cluster-2/Resources"]`), nil)
+
+	_, err := g.GetResourcePoolComputeResourcePath(ctx, datacenter, "*/Resources")
+	gt.Expect(err).To(MatchError("specified resource pool 'Resources' maps to multiple paths within the datacenter 'SDDC-Datacenter'"))
+}
+
+func TestGovcGetHostsPCIPassthroughDevicesSuccess(t *testing.T) {
+	ctx := context.Background()
+	datacenter := "SDDC-Datacenter"
+	computeCluster := "/SDDC-Datacenter/host/Cluster-1"
+	host := "/SDDC-Datacenter/host/Cluster-1/esxi-1"
+	pciResponse := `[
+  {"name": "hardware.pciDevice", "op": "assign", "val": {"_typeName": "ArrayOfHostPciDevice", "HostPciDevice": [
+    {"_typeName": "HostPciDevice", "id": "0000:00:00.0", "vendorId": -32634, "deviceId": 8193},
+    {"_typeName": "HostPciDevice", "id": "0000:3b:00.0", "vendorId": 4318, "deviceId": 8373},
+    {"_typeName": "HostPciDevice", "id": "0000:5e:00.0", "vendorId": -32634, "deviceId": -30712}
+  ]}},
+  {"name": "config.pciPassthruInfo", "op": "assign", "val": {"_typeName": "ArrayOfHostPciPassthruInfo", "HostPciPassthruInfo": [
+    {"_typeName": "HostPciPassthruInfo", "id": "0000:00:00.0", "passthruEnabled": false, "passthruActive": false},
+    {"_typeName": "HostPciPassthruInfo", "id": "0000:3b:00.0", "passthruEnabled": true, "passthruActive": true},
+    {"_typeName": "HostPciPassthruInfo", "id": "0000:5e:00.0", "passthruEnabled": true, "passthruActive": true}
+  ]}}
+]`
+
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "find", "-json", computeCluster, "-type", "h").
+		Return(*bytes.NewBufferString(`["` + host + `"]`), nil)
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "object.collect", "-json", host, "hardware.pciDevice", "config.pciPassthruInfo").
+		Return(*bytes.NewBufferString(pciResponse), nil)
+
+	devices, err := g.GetHostsPCIPassthroughDevices(ctx, datacenter, computeCluster)
+	gt.Expect(err).To(BeNil())
+	gt.Expect(devices).To(Equal(map[string][]executables.PCIPassthroughDevice{
+		host: {
+			{ID: "0000:3b:00.0", VendorID: 4318, DeviceID: 8373},
+			{ID: "0000:5e:00.0", VendorID: 32902, DeviceID: 34824},
+		},
+	}))
+}
+
+func TestGovcGetHostsPCIPassthroughDevicesRelativeComputeCluster(t *testing.T) {
+	ctx := context.Background()
+	datacenter := "SDDC-Datacenter"
+	host := "/SDDC-Datacenter/host/Cluster-1/esxi-1"
+
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "find", "-json", "/"+datacenter, "-type", "c", "-name", "Cluster-1").
+		Return(*bytes.NewBufferString(`["/SDDC-Datacenter/host/Cluster-1"]`), nil)
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "find", "-json", "/SDDC-Datacenter/host/Cluster-1", "-type", "h").
+		Return(*bytes.NewBufferString(`["` + host + `"]`), nil)
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "object.collect", "-json", host, "hardware.pciDevice", "config.pciPassthruInfo").
+		Return(*bytes.NewBufferString(`[]`), nil)
+
+	devices, err := g.GetHostsPCIPassthroughDevices(ctx, datacenter, "Cluster-1")
+	gt.Expect(err).To(BeNil())
+	gt.Expect(devices).To(Equal(map[string][]executables.PCIPassthroughDevice{host: {}}))
+}
+
+func TestGovcGetHostsPCIPassthroughDevicesNoHosts(t *testing.T) {
+	ctx := context.Background()
+	computeCluster := "/SDDC-Datacenter/host/Cluster-1"
+
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "find", "-json", computeCluster, "-type", "h").
+		Return(*bytes.NewBufferString("null\n"), nil)
+
+	_, err := g.GetHostsPCIPassthroughDevices(ctx, "SDDC-Datacenter", computeCluster)
+	gt.Expect(err).To(MatchError("no hosts found in /SDDC-Datacenter/host/Cluster-1"))
+}
+
+func TestGovcGetHostsPCIPassthroughDevicesCollectError(t *testing.T) {
+	ctx := context.Background()
+	computeCluster := "/SDDC-Datacenter/host/Cluster-1"
+	host := "/SDDC-Datacenter/host/Cluster-1/esxi-1"
+
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	g.Retrier = retrier.NewWithMaxRetries(1, 0)
+
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "find", "-json", computeCluster, "-type", "h").
+		Return(*bytes.NewBufferString(`["` + host + `"]`), nil)
+	executable.EXPECT().
+		ExecuteWithEnv(ctx, env, "object.collect", "-json", host, "hardware.pciDevice", "config.pciPassthruInfo").
+		Return(bytes.Buffer{}, errors.New("permission denied"))
+
+	_, err := g.GetHostsPCIPassthroughDevices(ctx, "SDDC-Datacenter", computeCluster)
+	gt.Expect(err).To(MatchError(ContainSubstring("getting PCI devices of host /SDDC-Datacenter/host/Cluster-1/esxi-1: permission denied")))
+}
 func TestGovcValidateVCenterSetupMachineConfig(t *testing.T) {
 	ctx := context.Background()
 	ts := newHTTPSServer(t)
@@ -2277,6 +2433,48 @@ func TestGovcGetVMDiskSizeInGB(t *testing.T) {
 	gt.Expect(size).To(Equal(25))
 }
 
+func TestGovcGetVMDiskCount(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	template := "ubuntu-2204-kube-v1.30"
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	response := map[string][]interface{}{
+		"Devices": {
+			map[string]interface{}{
+				"Name":       "disk-1000-0",
+				"DeviceInfo": map[string]string{"Label": "Hard disk 1"},
+			},
+			map[string]interface{}{
+				"Name":       "disk-1000-1",
+				"DeviceInfo": map[string]string{"Label": "Hard disk 2"},
+			},
+		},
+	}
+	marshaledResponse, err := json.Marshal(response)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "device.info", "-dc", datacenter, "-vm", template, "-json", "disk-*").Return(*bytes.NewBuffer(marshaledResponse), nil)
+
+	count, err := g.GetVMDiskCount(ctx, template, datacenter)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(count).To(Equal(2))
+}
+
+func TestGovcGetVMDiskCountError(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	template := "ubuntu-2204-kube-v1.30"
+	ctx := context.Background()
+	_, g, executable, env := setup(t)
+	gt := NewWithT(t)
+
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "device.info", "-dc", datacenter, "-vm", template, "-json", "disk-*").Return(bytes.Buffer{}, errors.New("not found"))
+
+	_, err := g.GetVMDiskCount(ctx, template, datacenter)
+	gt.Expect(err).To(MatchError("getting disks for vm ubuntu-2204-kube-v1.30: getting template device information: not found"))
+}
+
 func TestGovcGetVMDiskSizeInGBError(t *testing.T) {
 	datacenter := "SDDC-Datacenter"
 	template := "bottlerocket-kube-v1.24.6"
@@ -2,6 +2,7 @@ package executables
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	_ "embed"
 	"errors"
@@ -31,7 +32,9 @@ const configFileName = "kind_tmp.yaml"
 type Kind struct {
 	writer filewriter.FileWriter
 	Executable
-	execConfig *kindExecConfig
+	execConfig       *kindExecConfig
+	containerRuntime ContainerRuntime
+	containerHost    string
 }
 
 // kindExecConfig contains transient information for the execution of kind commands
@@ -61,13 +64,36 @@ type kindExecConfig struct {
 	AuditPolicyPath      string
 }
 
-func NewKind(executable Executable, writer filewriter.FileWriter) *Kind {
-	return &Kind{
-		writer:     writer,
-		Executable: executable,
+// KindOpt configures a Kind executable.
+type KindOpt func(*Kind)
+
+// WithKindContainerRuntime configures kind to create the node containers with the container runtime.
+func WithKindContainerRuntime(runtime ContainerRuntime) KindOpt {
+	return func(k *Kind) {
+		k.containerRuntime = runtime
+	}
+}
+
+// WithKindContainerHost configures the API socket of the container runtime kind connects to,
+// when it isn't the default one of the runtime.
+func WithKindContainerHost(socket string) KindOpt {
+	return func(k *Kind) {
+		k.containerHost = socket
 	}
 }
 
+func NewKind(executable Executable, writer filewriter.FileWriter, opts ...KindOpt) *Kind {
+	k := &Kind{
+		writer:           writer,
+		Executable:       executable,
+		containerRuntime: DockerRuntime,
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
+}
+
 // CreateAuditPolicy creates an audit policy file to be used by the bootstrap cluster's api server.
 func (k *Kind) CreateAuditPolicy(clusterSpec *cluster.Spec) error {
 	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
@@ -131,7 +157,7 @@ func (k *Kind) CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.
 
 func (k *Kind) ClusterExists(ctx context.Context, clusterName string) (bool, error) {
 	internalName := getInternalName(clusterName)
-	stdOut, err := k.Execute(ctx, "get", "clusters")
+	stdOut, err := k.execute(ctx, "get", "clusters")
 	if err != nil {
 		return false, fmt.Errorf("executing get clusters: %v", err)
 	}
@@ -154,7 +180,7 @@ func (k *Kind) ClusterExists(ctx context.Context, clusterName string) (bool, err
 
 func (k *Kind) GetKubeconfig(ctx context.Context, clusterName string) (string, error) {
 	internalName := getInternalName(clusterName)
-	stdOut, err := k.Execute(ctx, "get", "kubeconfig", "--name", internalName)
+	stdOut, err := k.execute(ctx, "get", "kubeconfig", "--name", internalName)
 	if err != nil {
 		return "", fmt.Errorf("executing get kubeconfig: %v", err)
 	}
@@ -205,7 +231,7 @@ func (k *Kind) WithEnv(env map[string]string) bootstrapper.BootstrapClusterClien
 func (k *Kind) DeleteBootstrapCluster(ctx context.Context, cluster *types.Cluster) error {
 	internalName := getInternalName(cluster.Name)
 	logger.V(4).Info("Deleting kind cluster", "name", internalName)
-	_, err := k.Execute(ctx, "delete", "cluster", "--name", internalName)
+	_, err := k.execute(ctx, "delete", "cluster", "--name", internalName)
 	if err != nil {
 		return fmt.Errorf("executing delete cluster: %v", err)
 	}
@@ -225,6 +251,9 @@ func (k *Kind) setupExecConfig(clusterSpec *cluster.Spec) error {
 		CorednsVersion:       versionsBundle.KubeDistro.CoreDNS.Tag,
 		env:                  make(map[string]string),
 	}
+	for name, value := range k.containerRuntime.kindEnv(k.containerHost) {
+		k.execConfig.env[name] = value
+	}
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
 		k.execConfig.MirrorBase = registryMirror.BaseRegistry
 		k.execConfig.RegistryMirrorMap = containerd.ToAPIEndpoints(registryMirror.NamespacedRegistryMap)
@@ -254,6 +283,14 @@ func (k *Kind) setupExecConfig(clusterSpec *cluster.Spec) error {
 	return nil
 }
 
+// execute runs a kind command, with the provider for the container runtime when it isn't Docker.
+func (k *Kind) execute(ctx context.Context, args ...string) (bytes.Buffer, error) {
+	if env := k.containerRuntime.kindEnv(k.containerHost); len(env) > 0 {
+		return k.ExecuteWithEnv(ctx, env, args...)
+	}
+	return k.Execute(ctx, args...)
+}
+
 func (k *Kind) cleanExecConfig() {
 	k.execConfig = nil
 }
@@ -621,3 +621,37 @@ func TestKindCreateBootstrapClusterAuditPolicyError(t *testing.T) {
 		t.Fatal("Expected an error when CreateAuditPolicy fails, but got nil")
 	}
 }
+
+func TestKindPodmanProvider(t *testing.T) {
+	clusterName := "cluster-name"
+	internalName := fmt.Sprintf("%s-eks-a-cluster", clusterName)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+	env := map[string]string{"KIND_EXPERIMENTAL_PROVIDER": "podman"}
+
+	mockCtrl := gomock.NewController(t)
+	executable := mockexecutables.NewMockExecutable(mockCtrl)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "get", "clusters").Return(*bytes.NewBufferString(internalName), nil)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "delete", "cluster", "--name", internalName).Return(bytes.Buffer{}, nil)
+	executable.EXPECT().ExecuteWithEnv(
+		ctx,
+		env,
+		"create", "cluster", "--name", internalName, "--kubeconfig", test.OfType("string"), "--image", test.OfType("string"), "--config", test.OfType("string"),
+	).Return(bytes.Buffer{}, nil)
+
+	k := executables.NewKind(executable, writer, executables.WithKindContainerRuntime(executables.PodmanRuntime))
+	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = clusterName
+		s.VersionsBundles["1.19"] = versionBundle
+	})
+	if _, err := k.CreateBootstrapCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("Kind.CreateBootstrapCluster() error = %v, want nil", err)
+	}
+	exists, err := k.ClusterExists(ctx, clusterName)
+	if err != nil || !exists {
+		t.Fatalf("Kind.ClusterExists() = %v, %v, want true, nil", exists, err)
+	}
+	if err = k.DeleteBootstrapCluster(ctx, &types.Cluster{Name: clusterName}); err != nil {
+		t.Fatalf("Kind.DeleteBootstrapCluster() error = %v, want nil", err)
+	}
+}
@@ -1039,6 +1039,18 @@ func (k *Kubectl) GetPodLogs(ctx context.Context, namespace, podName, containerN
 	return k.getPodLogs(ctx, namespace, podName, containerName, kubeconfig, nil, nil)
 }
 
+// ExecInPod runs a command in the specified container (namespace/pod/container) with stdin attached
+// and returns its stdout.
+func (k *Kubectl) ExecInPod(ctx context.Context, namespace, podName, containerName, kubeconfig string, stdin []byte, command ...string) (string, error) {
+	params := []string{"exec", "-i", podName, "--container", containerName, "--kubeconfig", kubeconfig, "--namespace", namespace, "--"}
+	params = append(params, command...)
+	stdOut, err := k.ExecuteWithStdin(ctx, stdin, params...)
+	if err != nil {
+		return stdOut.String(), fmt.Errorf("executing command in pod %s: %v", podName, err)
+	}
+	return stdOut.String(), nil
+}
+
 // GetPodLogsSince returns the logs of the specified container (namespace/pod/container) since a timestamp.
 func (k *Kubectl) GetPodLogsSince(ctx context.Context, namespace, podName, containerName, kubeconfig string, since time.Time) (string, error) {
 	sinceTime := metav1.NewTime(since)
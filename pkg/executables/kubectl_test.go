@@ -3744,6 +3744,29 @@ func TestGetPodLogs(t *testing.T) {
 	}
 }
 
+func TestExecInPod(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	stdin := []byte("cat /etc/hostname")
+	expectedParam := []string{"exec", "-i", "testpod", "--container", "testcontainer", "--kubeconfig", "c.kubeconfig", "--namespace", "eksa-system", "--", "sh", "-s"}
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, stdin, expectedParam).Return(*bytes.NewBufferString("node-1\n"), nil)
+
+	output, err := tt.k.ExecInPod(tt.ctx, "eksa-system", "testpod", "testcontainer", tt.cluster.KubeconfigFile, stdin, "sh", "-s")
+	tt.Expect(err).To(Succeed())
+	tt.Expect(output).To(Equal("node-1\n"))
+}
+
+func TestExecInPodError(t *testing.T) {
+	t.Parallel()
+	tt := newKubectlTest(t)
+	stdin := []byte("false")
+	expectedParam := []string{"exec", "-i", "testpod", "--container", "testcontainer", "--kubeconfig", "c.kubeconfig", "--namespace", "eksa-system", "--", "sh", "-s"}
+	tt.e.EXPECT().ExecuteWithStdin(tt.ctx, stdin, expectedParam).Return(bytes.Buffer{}, errors.New("command terminated with exit code 1"))
+
+	_, err := tt.k.ExecInPod(tt.ctx, "eksa-system", "testpod", "testcontainer", tt.cluster.KubeconfigFile, stdin, "sh", "-s")
+	tt.Expect(err).To(MatchError(ContainSubstring("executing command in pod testpod: command terminated with exit code 1")))
+}
+
 func TestGetPodLogsSince(t *testing.T) {
 	t.Parallel()
 	tt := newKubectlTest(t)
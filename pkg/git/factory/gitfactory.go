@@ -20,7 +20,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/git/gitclient"
 	"github.com/aws/eks-anywhere/pkg/git/gogithub"
 	"github.com/aws/eks-anywhere/pkg/git/providers/codecommit"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 type GitTools struct {
@@ -36,6 +38,7 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	var repo string
 	var repoUrl string
 	var gitAuth transport.AuthMethod
+	var caBundle []byte
 	var err error
 	var tools GitTools
 
@@ -54,6 +57,36 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 		gitAuth = &http.BasicAuth{Password: githubToken, Username: fluxConfig.Spec.Github.Owner}
 		repo = fluxConfig.Spec.Github.Repository
 		repoUrl = github.RepoUrl(fluxConfig.Spec.Github.Owner, repo)
+	case fluxConfig.Spec.Gitlab != nil:
+		gitlabToken, err := gitlab.GetGitlabAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		tools.Provider, err = buildGitlabProvider(gitlabToken, fluxConfig.Spec.Gitlab)
+		if err != nil {
+			return nil, fmt.Errorf("building gitlab provider: %v", err)
+		}
+
+		gitAuth = &http.BasicAuth{Password: gitlabToken, Username: gitlab.TokenAuthUsername}
+		repo = fluxConfig.Spec.Gitlab.Repository
+		repoUrl = gitlab.RepoUrl(fluxConfig.Spec.Gitlab)
+		caBundle = []byte(fluxConfig.Spec.Gitlab.CACertContent)
+	case fluxConfig.Spec.Gitea != nil:
+		giteaToken, err := gitea.GetGiteaAccessTokenFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		tools.Provider, err = buildGiteaProvider(giteaToken, fluxConfig.Spec.Gitea)
+		if err != nil {
+			return nil, fmt.Errorf("building gitea provider: %v", err)
+		}
+
+		gitAuth = &http.BasicAuth{Password: gitea.TokenAuthPassword, Username: giteaToken}
+		repo = fluxConfig.Spec.Gitea.Repository
+		repoUrl = gitea.RepoUrl(fluxConfig.Spec.Gitea)
+		caBundle = []byte(fluxConfig.Spec.Gitea.CACertContent)
 	case fluxConfig.Spec.Git != nil:
 		privateKeyFile := os.Getenv(config.EksaGitPrivateKeyTokenEnv)
 		privateKeyPassphrase := os.Getenv(config.EksaGitPassphraseTokenEnv)
@@ -85,7 +118,7 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 			opt(&tools)
 		}
 	}
-	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, tools.RepositoryDirectory)
+	tools.Client = buildGitClient(ctx, gitAuth, repoUrl, tools.RepositoryDirectory, caBundle)
 
 	tools.Writer, err = newRepositoryWriter(writer, repo)
 	if err != nil {
@@ -95,12 +128,15 @@ func Build(ctx context.Context, cluster *v1alpha1.Cluster, fluxConfig *v1alpha1.
 	return &tools, nil
 }
 
-func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, repo string) *gitclient.GitClient {
+func buildGitClient(ctx context.Context, auth transport.AuthMethod, repoUrl string, repo string, caBundle []byte) *gitclient.GitClient {
 	opts := []gitclient.Opt{
 		gitclient.WithRepositoryUrl(repoUrl),
 		gitclient.WithRepositoryDirectory(repo),
 		gitclient.WithAuth(auth),
 	}
+	if len(caBundle) > 0 {
+		opts = append(opts, gitclient.WithCABundle(caBundle))
+	}
 
 	return gitclient.New(opts...)
 }
@@ -117,6 +153,24 @@ func buildGithubProvider(ctx context.Context, githubToken string, config *v1alph
 	return provider, nil
 }
 
+func buildGitlabProvider(gitlabToken string, config *v1alpha1.GitlabProviderConfig) (git.ProviderClient, error) {
+	client, err := gitlab.NewClient(gitlab.BaseURL(config), gitlabToken, config.CACertContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitlab.New(client, config)
+}
+
+func buildGiteaProvider(giteaToken string, config *v1alpha1.GiteaProviderConfig) (git.ProviderClient, error) {
+	client, err := gitea.NewClient(gitea.BaseURL(config), giteaToken, config.CACertContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitea.New(client, config)
+}
+
 func newRepositoryWriter(writer filewriter.FileWriter, repository string) (filewriter.FileWriter, error) {
 	localGitWriterPath := filepath.Join("git", repository)
 	gitwriter, err := writer.WithDir(localGitWriterPath)
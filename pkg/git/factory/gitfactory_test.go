@@ -2,6 +2,7 @@ package gitfactory_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -9,7 +10,9 @@ import (
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	gitFactory "github.com/aws/eks-anywhere/pkg/git/factory"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
 	"github.com/aws/eks-anywhere/pkg/git/providers/github"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
 )
 
 const (
@@ -69,3 +72,71 @@ func setupContext(t *testing.T) {
 	t.Setenv(github.EksaGithubTokenEnv, validPATValue)
 	t.Setenv(github.GithubTokenEnv, validPATValue)
 }
+
+func TestGitFactoryGitlabAndGitea(t *testing.T) {
+	tests := []struct {
+		testName   string
+		fluxConfig *v1alpha1.FluxConfig
+		wantErr    string
+	}{
+		{
+			testName: "gitlab default instance",
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{Owner: "group/subgroup", Repository: "testRepo"},
+				},
+			},
+		},
+		{
+			testName: "gitlab invalid ca",
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						Owner:         "jeff",
+						Repository:    "testRepo",
+						BaseURL:       "https://gitlab.example.com",
+						CACertContent: "not a cert",
+					},
+				},
+			},
+			wantErr: "building gitlab provider",
+		},
+		{
+			testName: "gitea",
+			fluxConfig: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitea: &v1alpha1.GiteaProviderConfig{Owner: "org", Repository: "testRepo", BaseURL: "https://gitea.example.com"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Setenv(gitlab.EksaGitlabTokenEnv, validPATValue)
+			t.Setenv(gitlab.GitlabTokenEnv, "")
+			t.Setenv(gitea.EksaGiteaTokenEnv, validPATValue)
+			t.Setenv(gitea.GiteaTokenEnv, "")
+			cluster := &v1alpha1.Cluster{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "testCluster",
+				},
+			}
+			_, w := test.NewWriter(t)
+
+			tools, err := gitFactory.Build(context.Background(), cluster, tt.fluxConfig, w)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("gitfactory.Build() err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("gitfactory.Build() returned err, wanted nil. err: %v", err)
+			}
+			if tools.Provider == nil || tools.Client == nil {
+				t.Fatalf("gitfactory.Build() returned incomplete tools: %+v", tools)
+			}
+		})
+	}
+}
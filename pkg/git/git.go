@@ -14,6 +14,7 @@ type Client interface {
 	Pull(ctx context.Context, branch string) error
 	Init() error
 	Branch(name string) error
+	HeadCommit() (string, error)
 	ValidateRemoteExists(ctx context.Context) error
 }
 
@@ -24,6 +25,8 @@ type ProviderClient interface {
 	AddDeployKeyToRepo(ctx context.Context, opts AddDeployKeyOpts) error
 	Validate(ctx context.Context) error
 	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+	CreatePullRequest(ctx context.Context, opts CreatePullRequestOpts) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, opts GetPullRequestOpts) (*PullRequest, error)
 }
 
 type CreateRepoOpts struct {
@@ -53,6 +56,30 @@ type AddDeployKeyOpts struct {
 	ReadOnly   bool
 }
 
+// CreatePullRequestOpts defines a pull request to merge the Head branch into the Base branch.
+type CreatePullRequestOpts struct {
+	Owner      string
+	Repository string
+	Title      string
+	Body       string
+	Head       string
+	Base       string
+}
+
+type GetPullRequestOpts struct {
+	Owner      string
+	Repository string
+	Number     int
+}
+
+// PullRequest describes a pull request in a remote repository.
+type PullRequest struct {
+	Number int
+	URL    string
+	Merged bool
+	Closed bool
+}
+
 type Repository struct {
 	Name         string
 	Owner        string
@@ -66,6 +66,16 @@ func WithRepositoryDirectory(repoDir string) Opt {
 	}
 }
 
+// WithCABundle configures the default go-git client to trust the PEM encoded CA bundle when
+// talking to the remote over HTTPS. It has no effect if a custom GoGit client is used.
+func WithCABundle(caBundle []byte) Opt {
+	return func(c *GitClient) {
+		if gg, ok := c.Client.(*goGit); ok {
+			gg.caBundle = caBundle
+		}
+	}
+}
+
 func (g *GitClient) Clone(ctx context.Context) error {
 	_, err := g.Client.Clone(ctx, g.RepoDirectory, g.RepoUrl, g.Auth)
 	if err != nil && strings.Contains(err.Error(), emptyRepoError) {
@@ -266,6 +276,21 @@ func (g *GitClient) Branch(name string) error {
 	return nil
 }
 
+// HeadCommit returns the hash of the commit HEAD points to in the local repository.
+func (g *GitClient) HeadCommit() (string, error) {
+	r, err := g.Client.OpenDir(g.RepoDirectory)
+	if err != nil {
+		return "", fmt.Errorf("getting head commit: %v", err)
+	}
+
+	ref, err := g.Client.Head(r)
+	if err != nil {
+		return "", fmt.Errorf("getting head commit: %v", err)
+	}
+
+	return ref.Hash().String(), nil
+}
+
 func (g *GitClient) ValidateRemoteExists(ctx context.Context) error {
 	logger.V(3).Info("Validating git setup", "repoUrl", g.RepoUrl)
 	remote := g.Client.NewRemote(g.RepoUrl, gogit.DefaultRemoteName)
@@ -333,7 +358,9 @@ type GoGit interface {
 	SetRepositoryReference(r *gogit.Repository, p *plumbing.Reference) error
 }
 
-type goGit struct{}
+type goGit struct {
+	caBundle []byte
+}
 
 func (gg *goGit) Clone(ctx context.Context, dir string, repourl string, auth transport.AuthMethod) (*gogit.Repository, error) {
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
@@ -343,6 +370,7 @@ func (gg *goGit) Clone(ctx context.Context, dir string, repourl string, auth tra
 		Auth:     auth,
 		URL:      repourl,
 		Progress: os.Stdout,
+		CABundle: gg.caBundle,
 	})
 }
 
@@ -374,7 +402,8 @@ func (gg *goGit) PushWithContext(ctx context.Context, r *gogit.Repository, auth
 	defer cancel()
 
 	return r.PushContext(ctx, &gogit.PushOptions{
-		Auth: auth,
+		Auth:     auth,
+		CABundle: gg.caBundle,
 	})
 }
 
@@ -382,7 +411,7 @@ func (gg *goGit) PullWithContext(ctx context.Context, w *gogit.Worktree, auth tr
 	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
 	defer cancel()
 
-	return w.PullContext(ctx, &gogit.PullOptions{RemoteName: gogit.DefaultRemoteName, Auth: auth, ReferenceName: ref})
+	return w.PullContext(ctx, &gogit.PullOptions{RemoteName: gogit.DefaultRemoteName, Auth: auth, ReferenceName: ref, CABundle: gg.caBundle})
 }
 
 func (gg *goGit) Head(r *gogit.Repository) (*plumbing.Reference, error) {
@@ -423,7 +452,7 @@ func (gg *goGit) ListRemotes(r *gogit.Repository, auth transport.AuthMethod) ([]
 		}
 		return nil, err
 	}
-	refList, err := remote.List(&gogit.ListOptions{Auth: auth})
+	refList, err := remote.List(&gogit.ListOptions{Auth: auth, CABundle: gg.caBundle})
 	if err != nil {
 		return nil, err
 	}
@@ -435,7 +464,7 @@ func (gg *goGit) Remove(f string, w *gogit.Worktree) (plumbing.Hash, error) {
 }
 
 func (ggc *goGit) ListWithContext(ctx context.Context, r *gogit.Remote, auth transport.AuthMethod) ([]*plumbing.Reference, error) {
-	refList, err := r.ListContext(ctx, &gogit.ListOptions{Auth: auth})
+	refList, err := r.ListContext(ctx, &gogit.ListOptions{Auth: auth, CABundle: ggc.caBundle})
 	if err != nil {
 		return nil, err
 	}
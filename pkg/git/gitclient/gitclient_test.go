@@ -151,6 +151,40 @@ func TestGoGitPush(t *testing.T) {
 	}
 }
 
+func TestGoGitHeadCommit(t *testing.T) {
+	_, client := newGoGitMock(t)
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+	hash := plumbing.NewHash("0123456789abcdef0123456789abcdef01234567")
+
+	client.EXPECT().OpenDir(repoDir).Return(&goGit.Repository{}, nil)
+	client.EXPECT().Head(gomock.Any()).Return(plumbing.NewHashReference(plumbing.HEAD, hash), nil)
+
+	commit, err := g.HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit() error = %v, want nil", err)
+	}
+	if commit != hash.String() {
+		t.Errorf("HeadCommit() = %s, want %s", commit, hash)
+	}
+}
+
+func TestGoGitHeadCommitError(t *testing.T) {
+	_, client := newGoGitMock(t)
+	g := &gitclient.GitClient{
+		RepoDirectory: repoDir,
+		Client:        client,
+	}
+
+	client.EXPECT().OpenDir(repoDir).Return(nil, fmt.Errorf("not a repository"))
+
+	if _, err := g.HeadCommit(); err == nil {
+		t.Error("HeadCommit() error = nil, want error")
+	}
+}
+
 func TestGoGitPull(t *testing.T) {
 	tests := []struct {
 		name       string
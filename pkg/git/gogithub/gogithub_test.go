@@ -445,6 +445,58 @@ func TestPathExistsItDoesNot(t *testing.T) {
 	tt.Expect(tt.g.PathExists(tt.ctx, owner, repo, branch, path)).To(BeTrue())
 }
 
+func TestCreatePullRequest(t *testing.T) {
+	tt := newTest(t)
+	opts := git.CreatePullRequestOpts{
+		Owner:      "aws",
+		Repository: "eksa-gitops",
+		Title:      "Update cluster",
+		Body:       "body",
+		Head:       "eksa/my-cluster",
+		Base:       "main",
+	}
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "aws", "eksa-gitops", &github.NewPullRequest{
+		Title: &opts.Title,
+		Body:  &opts.Body,
+		Head:  &opts.Head,
+		Base:  &opts.Base,
+	}).Return(&github.PullRequest{
+		Number:  github.Int(7),
+		HTMLURL: github.String("https://github.com/aws/eksa-gitops/pull/7"),
+		State:   github.String("open"),
+	}, nil, nil)
+
+	tt.Expect(tt.g.CreatePullRequest(tt.ctx, opts)).To(Equal(&git.PullRequest{
+		Number: 7,
+		URL:    "https://github.com/aws/eksa-gitops/pull/7",
+	}))
+}
+
+func TestCreatePullRequestError(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().CreatePullRequest(tt.ctx, "aws", "eksa-gitops", gomock.Any()).Return(nil, nil, errors.New("validation failed"))
+
+	_, err := tt.g.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{Owner: "aws", Repository: "eksa-gitops"})
+	tt.Expect(err).To(MatchError(ContainSubstring("validation failed")))
+}
+
+func TestGetPullRequest(t *testing.T) {
+	tt := newTest(t)
+	tt.client.EXPECT().GetPullRequest(tt.ctx, "aws", "eksa-gitops", 7).Return(&github.PullRequest{
+		Number:  github.Int(7),
+		HTMLURL: github.String("https://github.com/aws/eksa-gitops/pull/7"),
+		State:   github.String("closed"),
+		Merged:  github.Bool(true),
+	}, nil, nil)
+
+	tt.Expect(tt.g.GetPullRequest(tt.ctx, git.GetPullRequestOpts{Owner: "aws", Repository: "eksa-gitops", Number: 7})).To(Equal(&git.PullRequest{
+		Number: 7,
+		URL:    "https://github.com/aws/eksa-gitops/pull/7",
+		Merged: true,
+		Closed: true,
+	}))
+}
+
 type gogithubTest struct {
 	*WithT
 	g      *gogithub.GoGithub
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const providerHTTPTimeout = 30 * time.Second
+
+// NewHTTPClient returns an http.Client for the API of a self-hosted git provider. When caCertContent is
+// not empty, the PEM encoded certificates are trusted in addition to the system ones.
+func NewHTTPClient(caCertContent string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if caCertContent != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCertContent)) {
+			return nil, errors.New("no valid certificates found in CA cert content")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return &http.Client{Transport: transport, Timeout: providerHTTPTimeout}, nil
+}
@@ -0,0 +1,225 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const apiPath = "/api/v1"
+
+// Client is a minimal client of the Gitea REST API v1 that satisfies GiteaClient.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the Gitea instance at baseURL authenticated with an access token.
+// caCertContent is an optional PEM encoded CA bundle for instances using a private CA.
+func NewClient(baseURL, token, caCertContent string) (*Client, error) {
+	httpClient, err := git.NewHTTPClient(caCertContent)
+	if err != nil {
+		return nil, fmt.Errorf("building gitea http client: %v", err)
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + apiPath,
+		token:      token,
+		httpClient: httpClient,
+	}, nil
+}
+
+// APIError is returned when the Gitea API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitea api returned %d: %s", e.StatusCode, e.Message)
+}
+
+func isNotFound(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type repository struct {
+	Name     string `json:"name"`
+	CloneURL string `json:"clone_url"`
+	Owner    user   `json:"owner"`
+}
+
+func (r *repository) toRepository(organization string) *git.Repository {
+	return &git.Repository{
+		Name:         r.Name,
+		Owner:        r.Owner.Login,
+		Organization: organization,
+		CloneUrl:     r.CloneURL,
+	}
+}
+
+// AuthenticatedUser returns the login of the token owner.
+func (c *Client) AuthenticatedUser(ctx context.Context) (string, error) {
+	u := &user{}
+	if err := c.do(ctx, http.MethodGet, "/user", nil, u); err != nil {
+		return "", fmt.Errorf("getting authenticated gitea user: %v", err)
+	}
+	return u.Login, nil
+}
+
+// OrganizationExists checks if the organization exists and is visible to the token owner.
+func (c *Client) OrganizationExists(ctx context.Context, org string) (bool, error) {
+	err := c.do(ctx, http.MethodGet, "/orgs/"+url.PathEscape(org), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting gitea organization %s: %v", org, err)
+	}
+	return true, nil
+}
+
+// GetRepo describes a Gitea repository. It returns a *git.RepositoryDoesNotExistError if it doesn't exist.
+func (c *Client) GetRepo(ctx context.Context, opts git.GetRepoOpts) (*git.Repository, error) {
+	r := &repository{}
+	err := c.do(ctx, http.MethodGet, repoPath(opts.Owner, opts.Repository), nil, r)
+	if isNotFound(err) {
+		return nil, &git.RepositoryDoesNotExistError{Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toRepository(""), nil
+}
+
+// CreateRepo creates an empty Gitea repository for the authenticated user or in the owner organization.
+func (c *Client) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new Gitea repo", "repo", opts.Name, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"name":        opts.Name,
+		"description": opts.Description,
+		"private":     opts.Privacy,
+		"auto_init":   opts.AutoInit,
+	}
+
+	path := "/user/repos"
+	org := ""
+	if !opts.Personal {
+		path = "/orgs/" + url.PathEscape(opts.Owner) + "/repos"
+		org = opts.Owner
+	}
+
+	r := &repository{}
+	if err := c.do(ctx, http.MethodPost, path, body, r); err != nil {
+		return nil, fmt.Errorf("failed to create new Gitea repo %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new Gitea repo", "repo", r.Name, "owner", r.Owner.Login)
+
+	return r.toRepository(org), nil
+}
+
+// DeleteRepo deletes a Gitea repository.
+func (c *Client) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	logger.V(3).Info("Deleting Gitea repository", "name", opts.Repository, "owner", opts.Owner)
+	if err := c.do(ctx, http.MethodDelete, repoPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// AddDeployKeyToRepo registers a deploy key in a Gitea repository.
+func (c *Client) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"title":     opts.Title,
+		"key":       opts.Key,
+		"read_only": opts.ReadOnly,
+	}
+	if err := c.do(ctx, http.MethodPost, repoPath(opts.Owner, opts.Repository)+"/keys", body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to repo: %v", err)
+	}
+	return nil
+}
+
+// PathExists checks if a file or directory exists in a branch of a Gitea repository.
+func (c *Client) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	ref := url.Values{"ref": []string{branch}}
+	p := repoPath(owner, repo) + "/contents/" + escapePath(path) + "?" + ref.Encode()
+	err := c.do(ctx, http.MethodGet, p, nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitea repository: %v", path, err)
+	}
+	return true, nil
+}
+
+func repoPath(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,147 @@
+package gitea_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *gitea.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := gitea.NewClient(server.URL, "token", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClientAuthenticatedUser(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Path).To(Equal("/api/v1/user"))
+		g.Expect(r.Header.Get("Authorization")).To(Equal("token token"))
+		_, _ = w.Write([]byte(`{"login":"jeff"}`))
+	})
+
+	user, err := c.AuthenticatedUser(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(user).To(Equal("jeff"))
+}
+
+func TestClientOrganizationExists(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/orgs/org" {
+			_, _ = w.Write([]byte(`{"username":"org"}`))
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	exists, err := c.OrganizationExists(context.Background(), "org")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	exists, err = c.OrganizationExists(context.Background(), "other")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestClientGetRepoNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, http.NotFound)
+
+	_, err := c.GetRepo(context.Background(), git.GetRepoOpts{Owner: "org", Repository: "repo"})
+	var notFound *git.RepositoryDoesNotExistError
+	g.Expect(errors.As(err, &notFound)).To(BeTrue())
+}
+
+func TestClientCreateRepo(t *testing.T) {
+	tests := []struct {
+		name     string
+		personal bool
+		wantPath string
+		wantOrg  string
+	}{
+		{name: "personal", personal: true, wantPath: "/api/v1/user/repos"},
+		{name: "organization", wantPath: "/api/v1/orgs/org/repos", wantOrg: "org"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.Method).To(Equal(http.MethodPost))
+				g.Expect(r.URL.Path).To(Equal(tt.wantPath))
+				body := map[string]interface{}{}
+				g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				g.Expect(body).To(HaveKeyWithValue("private", true))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"name":"repo","clone_url":"https://gitea.example.com/org/repo.git","owner":{"login":"org"}}`))
+			})
+
+			repo, err := c.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "repo", Owner: "org", Personal: tt.personal, Privacy: true})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(repo).To(Equal(&git.Repository{
+				Name:         "repo",
+				Owner:        "org",
+				Organization: tt.wantOrg,
+				CloneUrl:     "https://gitea.example.com/org/repo.git",
+			}))
+		})
+	}
+}
+
+func TestClientAddDeployKeyToRepo(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Path).To(Equal("/api/v1/repos/org/repo/keys"))
+		body := map[string]interface{}{}
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		g.Expect(body).To(HaveKeyWithValue("read_only", false))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := c.AddDeployKeyToRepo(context.Background(), git.AddDeployKeyOpts{Owner: "org", Repository: "repo", Key: "ssh-rsa AAA", Title: "flux"})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestClientPathExists(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Query().Get("ref")).To(Equal("main"))
+		if r.URL.Path == "/api/v1/repos/org/repo/contents/clusters/mgmt" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		http.NotFound(w, r)
+	})
+
+	exists, err := c.PathExists(context.Background(), "org", "repo", "main", "clusters/mgmt")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+
+	exists, err = c.PathExists(context.Background(), "org", "repo", "main", "clusters/other")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestClientAPIError(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+
+	_, err := c.AuthenticatedUser(context.Background())
+	g.Expect(err).To(MatchError(ContainSubstring("gitea api returned 403: forbidden")))
+}
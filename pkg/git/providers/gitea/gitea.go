@@ -0,0 +1,149 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName   = "gitea"
+	EksaGiteaTokenEnv = "EKSA_GITEA_TOKEN"
+	GiteaTokenEnv     = "GITEA_TOKEN"
+	// TokenAuthPassword is the password Gitea accepts in git basic auth when the username is an access token.
+	TokenAuthPassword = "x-oauth-basic"
+)
+
+type giteaProvider struct {
+	giteaProviderClient GiteaClient
+	config              *v1alpha1.GiteaProviderConfig
+}
+
+// GiteaClient represents the attributes that the Gitea provider requires of a client to interact with the Gitea API.
+type GiteaClient interface {
+	GetRepo(ctx context.Context, opts git.GetRepoOpts) (repo *git.Repository, err error)
+	CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (repo *git.Repository, err error)
+	DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error
+	AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error
+	AuthenticatedUser(ctx context.Context) (string, error)
+	OrganizationExists(ctx context.Context, org string) (bool, error)
+	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+}
+
+// New returns a git.ProviderClient for the Gitea repository in config.
+func New(giteaProviderClient GiteaClient, config *v1alpha1.GiteaProviderConfig) (git.ProviderClient, error) {
+	return &giteaProvider{
+		giteaProviderClient: giteaProviderClient,
+		config:              config,
+	}, nil
+}
+
+// CreateRepo creates an empty Gitea repository. The repository must be initialized locally or
+// file must be added to it before it can be successfully cloned.
+func (g *giteaProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	return g.giteaProviderClient.CreateRepo(ctx, opts)
+}
+
+// GetRepo describes the remote repository. If the repo does not exist, a nil repo is returned.
+func (g *giteaProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.config.Repository
+	o := g.config.Owner
+	logger.V(3).Info("Describing Gitea repository", "name", r, "owner", o)
+	repo, err := g.giteaProviderClient.GetRepo(ctx, git.GetRepoOpts{Owner: o, Repository: r})
+	if err != nil {
+		var e *git.RepositoryDoesNotExistError
+		if errors.As(err, &e) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo, nil
+}
+
+// DeleteRepo deletes a Gitea repository.
+func (g *giteaProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	return g.giteaProviderClient.DeleteRepo(ctx, opts)
+}
+
+// AddDeployKeyToRepo registers a deploy key in a Gitea repository.
+func (g *giteaProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	return g.giteaProviderClient.AddDeployKeyToRepo(ctx, opts)
+}
+
+// CreatePullRequest is not supported for Gitea repositories yet.
+func (g *giteaProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("pull requests are not supported for the %s provider", GitProviderName)
+}
+
+// GetPullRequest is not supported for Gitea repositories yet.
+func (g *giteaProvider) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("pull requests are not supported for the %s provider", GitProviderName)
+}
+
+// PathExists checks if a path exists in a branch of a Gitea repository.
+func (g *giteaProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	return g.giteaProviderClient.PathExists(ctx, owner, repo, branch, path)
+}
+
+// Validate checks the access token is valid and has access to the owner user or organization.
+func (g *giteaProvider) Validate(ctx context.Context) error {
+	login, err := g.giteaProviderClient.AuthenticatedUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, login) {
+			return fmt.Errorf("the authenticated Gitea user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+
+	exists, err := g.giteaProviderClient.OrganizationExists(ctx, g.config.Owner)
+	if err != nil {
+		return fmt.Errorf("the authenticated Gitea user doesn't have proper access to Gitea organization %s, %v", g.config.Owner, err)
+	}
+	if !exists {
+		return fmt.Errorf("the authenticated Gitea user doesn't have proper access to Gitea organization %s", g.config.Owner)
+	}
+	return nil
+}
+
+// GetGiteaAccessTokenFromEnv returns the Gitea access token from the EKSA_GITEA_TOKEN env var
+// and exports it as GITEA_TOKEN for Flux.
+func GetGiteaAccessTokenFromEnv() (string, error) {
+	token, ok := os.LookupEnv(EksaGiteaTokenEnv)
+	if !ok || token == "" {
+		return "", fmt.Errorf("gitea access token environment variable %s is invalid; could not get var from environment", EksaGiteaTokenEnv)
+	}
+	if err := os.Setenv(GiteaTokenEnv, token); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GiteaTokenEnv, err)
+	}
+	return token, nil
+}
+
+// BaseURL returns the base URL of the Gitea instance in config.
+func BaseURL(config *v1alpha1.GiteaProviderConfig) string {
+	return strings.TrimSuffix(config.BaseURL, "/")
+}
+
+// Hostname returns the host of the Gitea instance in config.
+func Hostname(config *v1alpha1.GiteaProviderConfig) (string, error) {
+	u, err := url.Parse(BaseURL(config))
+	if err != nil {
+		return "", fmt.Errorf("parsing gitea base url: %v", err)
+	}
+	return u.Host, nil
+}
+
+// RepoUrl returns the HTTPS clone URL of the repository in config.
+func RepoUrl(config *v1alpha1.GiteaProviderConfig) string {
+	return fmt.Sprintf("%s/%s/%s.git", BaseURL(config), config.Owner, config.Repository)
+}
@@ -0,0 +1,99 @@
+package gitea_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitea/mocks"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		testName  string
+		config    *v1alpha1.GiteaProviderConfig
+		orgExists bool
+		wantErr   string
+	}{
+		{
+			testName: "good personal repo",
+			config:   &v1alpha1.GiteaProviderConfig{Owner: "Jeff", Repository: "repo", Personal: true},
+		},
+		{
+			testName: "wrong owner for personal repo",
+			config:   &v1alpha1.GiteaProviderConfig{Owner: "nobody", Repository: "repo", Personal: true},
+			wantErr:  "the authenticated Gitea user and owner nobody specified in the EKS-A gitops spec don't match",
+		},
+		{
+			testName:  "good organization repo",
+			config:    &v1alpha1.GiteaProviderConfig{Owner: "org", Repository: "repo"},
+			orgExists: true,
+		},
+		{
+			testName: "organization not visible",
+			config:   &v1alpha1.GiteaProviderConfig{Owner: "hidden", Repository: "repo"},
+			wantErr:  "the authenticated Gitea user doesn't have proper access to Gitea organization hidden",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			client := mocks.NewMockGiteaClient(gomock.NewController(t))
+			client.EXPECT().AuthenticatedUser(ctx).Return("jeff", nil)
+			if !tt.config.Personal {
+				client.EXPECT().OrganizationExists(ctx, tt.config.Owner).Return(tt.orgExists, nil)
+			}
+
+			p, err := gitea.New(client, tt.config)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			err = p.Validate(ctx)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateAuthenticationError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockGiteaClient(gomock.NewController(t))
+	client.EXPECT().AuthenticatedUser(ctx).Return("", errors.New("unauthorized"))
+
+	p, err := gitea.New(client, &v1alpha1.GiteaProviderConfig{Owner: "org", Repository: "repo"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(p.Validate(ctx)).To(MatchError("unauthorized"))
+}
+
+func TestGetRepoDoesNotExist(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := mocks.NewMockGiteaClient(gomock.NewController(t))
+	client.EXPECT().GetRepo(ctx, git.GetRepoOpts{Owner: "org", Repository: "repo"}).Return(nil, &git.RepositoryDoesNotExistError{})
+
+	p, err := gitea.New(client, &v1alpha1.GiteaProviderConfig{Owner: "org", Repository: "repo"})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	repo, err := p.GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestRepoUrlAndHostname(t *testing.T) {
+	g := NewWithT(t)
+	config := &v1alpha1.GiteaProviderConfig{Owner: "org", Repository: "repo", BaseURL: "https://gitea.example.com/"}
+	g.Expect(gitea.RepoUrl(config)).To(Equal("https://gitea.example.com/org/repo.git"))
+	host, err := gitea.Hostname(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(host).To(Equal("gitea.example.com"))
+}
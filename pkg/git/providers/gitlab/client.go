@@ -0,0 +1,244 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const apiPath = "/api/v4"
+
+// Client is a minimal client of the GitLab REST API v4 that satisfies GitlabClient.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the GitLab instance at baseURL authenticated with a personal, group or
+// project access token. caCertContent is an optional PEM encoded CA bundle for self-managed instances.
+func NewClient(baseURL, token, caCertContent string) (*Client, error) {
+	httpClient, err := git.NewHTTPClient(caCertContent)
+	if err != nil {
+		return nil, fmt.Errorf("building gitlab http client: %v", err)
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + apiPath,
+		token:      token,
+		httpClient: httpClient,
+	}, nil
+}
+
+// APIError is returned when the GitLab API responds with an error status code.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitlab api returned %d: %s", e.StatusCode, e.Message)
+}
+
+func isNotFound(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type namespace struct {
+	ID       int    `json:"id"`
+	Kind     string `json:"kind"`
+	FullPath string `json:"full_path"`
+}
+
+type project struct {
+	Path          string    `json:"path"`
+	HTTPURLToRepo string    `json:"http_url_to_repo"`
+	Namespace     namespace `json:"namespace"`
+}
+
+func (p *project) toRepository() *git.Repository {
+	r := &git.Repository{
+		Name:     p.Path,
+		Owner:    p.Namespace.FullPath,
+		CloneUrl: p.HTTPURLToRepo,
+	}
+	if p.Namespace.Kind == "group" {
+		r.Organization = p.Namespace.FullPath
+	}
+	return r
+}
+
+// AuthenticatedUser returns the username of the token owner.
+func (c *Client) AuthenticatedUser(ctx context.Context) (string, error) {
+	u := &user{}
+	if err := c.do(ctx, http.MethodGet, "/user", nil, u); err != nil {
+		return "", fmt.Errorf("getting authenticated gitlab user: %v", err)
+	}
+	return u.Username, nil
+}
+
+// GroupExists checks if the group at path exists and is visible to the token owner.
+func (c *Client) GroupExists(ctx context.Context, path string) (bool, error) {
+	err := c.do(ctx, http.MethodGet, "/groups/"+url.PathEscape(path), nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting gitlab group %s: %v", path, err)
+	}
+	return true, nil
+}
+
+// GetRepo describes a GitLab project. It returns a *git.RepositoryDoesNotExistError if it doesn't exist.
+func (c *Client) GetRepo(ctx context.Context, opts git.GetRepoOpts) (*git.Repository, error) {
+	p := &project{}
+	err := c.do(ctx, http.MethodGet, projectPath(opts.Owner, opts.Repository), nil, p)
+	if isNotFound(err) {
+		return nil, &git.RepositoryDoesNotExistError{Err: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.toRepository(), nil
+}
+
+// CreateRepo creates an empty GitLab project in the user namespace or in the owner group.
+func (c *Client) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	logger.V(3).Info("Attempting to create new GitLab project", "repo", opts.Name, "owner", opts.Owner)
+	visibility := "public"
+	if opts.Privacy {
+		visibility = "private"
+	}
+	body := map[string]interface{}{
+		"name":                   opts.Name,
+		"path":                   opts.Name,
+		"description":            opts.Description,
+		"visibility":             visibility,
+		"initialize_with_readme": opts.AutoInit,
+	}
+
+	if !opts.Personal {
+		ns := &namespace{}
+		if err := c.do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(opts.Owner), nil, ns); err != nil {
+			return nil, fmt.Errorf("getting gitlab namespace %s: %v", opts.Owner, err)
+		}
+		body["namespace_id"] = ns.ID
+	}
+
+	p := &project{}
+	if err := c.do(ctx, http.MethodPost, "/projects", body, p); err != nil {
+		return nil, fmt.Errorf("failed to create new GitLab project %s: %v", opts.Name, err)
+	}
+	logger.V(3).Info("Successfully created new GitLab project", "repo", p.Path, "owner", p.Namespace.FullPath)
+
+	return p.toRepository(), nil
+}
+
+// DeleteRepo deletes a GitLab project.
+func (c *Client) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	logger.V(3).Info("Deleting GitLab project", "name", opts.Repository, "owner", opts.Owner)
+	if err := c.do(ctx, http.MethodDelete, projectPath(opts.Owner, opts.Repository), nil, nil); err != nil {
+		return fmt.Errorf("deleting repository %s: %v", opts.Repository, err)
+	}
+	return nil
+}
+
+// AddDeployKeyToRepo registers a deploy key in a GitLab project.
+func (c *Client) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	logger.V(3).Info("Adding deploy key to repository", "repository", opts.Repository, "owner", opts.Owner)
+	body := map[string]interface{}{
+		"title":    opts.Title,
+		"key":      opts.Key,
+		"can_push": !opts.ReadOnly,
+	}
+	if err := c.do(ctx, http.MethodPost, projectPath(opts.Owner, opts.Repository)+"/deploy_keys", body, nil); err != nil {
+		return fmt.Errorf("adding deploy key to repo: %v", err)
+	}
+	return nil
+}
+
+// PathExists checks if a file or a non empty directory exists in a branch of a GitLab project.
+func (c *Client) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	p := projectPath(owner, repo)
+	ref := url.Values{"ref": []string{branch}}
+
+	err := c.do(ctx, http.MethodGet, p+"/repository/files/"+url.PathEscape(path)+"?"+ref.Encode(), nil, nil)
+	if err == nil {
+		return true, nil
+	}
+	if !isNotFound(err) {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", path, err)
+	}
+
+	query := url.Values{"ref": []string{branch}, "path": []string{path}, "per_page": []string{"1"}}
+	var tree []json.RawMessage
+	err = c.do(ctx, http.MethodGet, p+"/repository/tree?"+query.Encode(), nil, &tree)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed checking if path %s exists in remote gitlab repository: %v", path, err)
+	}
+
+	return len(tree) > 0, nil
+}
+
+func projectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, out)
+}
@@ -0,0 +1,136 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+)
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *gitlab.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := gitlab.NewClient(server.URL, "token", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClientAuthenticatedUser(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Path).To(Equal("/api/v4/user"))
+		g.Expect(r.Header.Get("PRIVATE-TOKEN")).To(Equal("token"))
+		_, _ = w.Write([]byte(`{"username":"jeff"}`))
+	})
+
+	user, err := c.AuthenticatedUser(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(user).To(Equal("jeff"))
+}
+
+func TestClientGetRepoNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.EscapedPath()).To(Equal("/api/v4/projects/group%2Fsub%2Frepo"))
+		http.NotFound(w, r)
+	})
+
+	_, err := c.GetRepo(context.Background(), git.GetRepoOpts{Owner: "group/sub", Repository: "repo"})
+	var notFound *git.RepositoryDoesNotExistError
+	g.Expect(errors.As(err, &notFound)).To(BeTrue())
+}
+
+func TestClientCreateRepoInGroup(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/v4/namespaces/group%2Fsub":
+			_, _ = w.Write([]byte(`{"id":42,"kind":"group","full_path":"group/sub"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v4/projects":
+			body := map[string]interface{}{}
+			g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			g.Expect(body).To(HaveKeyWithValue("namespace_id", BeEquivalentTo(42)))
+			g.Expect(body).To(HaveKeyWithValue("visibility", "private"))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"path":"repo","http_url_to_repo":"https://gitlab.example.com/group/sub/repo.git","namespace":{"kind":"group","full_path":"group/sub"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	repo, err := c.CreateRepo(context.Background(), git.CreateRepoOpts{Name: "repo", Owner: "group/sub", Privacy: true})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(Equal(&git.Repository{
+		Name:         "repo",
+		Owner:        "group/sub",
+		Organization: "group/sub",
+		CloneUrl:     "https://gitlab.example.com/group/sub/repo.git",
+	}))
+}
+
+func TestClientAddDeployKeyToRepo(t *testing.T) {
+	g := NewWithT(t)
+	c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(r.URL.EscapedPath()).To(Equal("/api/v4/projects/me%2Frepo/deploy_keys"))
+		body := map[string]interface{}{}
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		g.Expect(body).To(HaveKeyWithValue("can_push", true))
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	err := c.AddDeployKeyToRepo(context.Background(), git.AddDeployKeyOpts{Owner: "me", Repository: "repo", Key: "ssh-rsa AAA", Title: "flux"})
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestClientPathExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileCode int
+		tree     string
+		want     bool
+	}{
+		{name: "file", fileCode: http.StatusOK, want: true},
+		{name: "directory", fileCode: http.StatusNotFound, tree: `[{"name":"a"}]`, want: true},
+		{name: "missing", fileCode: http.StatusNotFound, tree: `[]`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.URL.Query().Get("ref")).To(Equal("main"))
+				switch r.URL.EscapedPath() {
+				case "/api/v4/projects/me%2Frepo/repository/files/clusters%2Fmgmt":
+					w.WriteHeader(tt.fileCode)
+					_, _ = w.Write([]byte(`{}`))
+				case "/api/v4/projects/me%2Frepo/repository/tree":
+					g.Expect(r.URL.Query().Get("path")).To(Equal("clusters/mgmt"))
+					_, _ = w.Write([]byte(tt.tree))
+				default:
+					t.Errorf("unexpected request %s", r.URL)
+				}
+			})
+
+			exists, err := c.PathExists(context.Background(), "me", "repo", "main", "clusters/mgmt")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(exists).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNewClientInvalidCA(t *testing.T) {
+	g := NewWithT(t)
+	_, err := gitlab.NewClient("https://gitlab.example.com", "token", "not a cert")
+	g.Expect(err).To(MatchError(ContainSubstring("no valid certificates found")))
+}
@@ -0,0 +1,153 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	GitProviderName    = "gitlab"
+	EksaGitlabTokenEnv = "EKSA_GITLAB_TOKEN"
+	GitlabTokenEnv     = "GITLAB_TOKEN"
+	DefaultBaseURL     = "https://gitlab.com"
+	// TokenAuthUsername is the username GitLab expects in git basic auth with an access token.
+	TokenAuthUsername = "oauth2"
+)
+
+type gitlabProvider struct {
+	gitlabProviderClient GitlabClient
+	config               *v1alpha1.GitlabProviderConfig
+}
+
+// GitlabClient represents the attributes that the GitLab provider requires of a client to interact with the GitLab API.
+type GitlabClient interface {
+	GetRepo(ctx context.Context, opts git.GetRepoOpts) (repo *git.Repository, err error)
+	CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (repo *git.Repository, err error)
+	DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error
+	AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error
+	AuthenticatedUser(ctx context.Context) (string, error)
+	GroupExists(ctx context.Context, path string) (bool, error)
+	PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error)
+}
+
+// New returns a git.ProviderClient for the GitLab repository in config.
+func New(gitlabProviderClient GitlabClient, config *v1alpha1.GitlabProviderConfig) (git.ProviderClient, error) {
+	return &gitlabProvider{
+		gitlabProviderClient: gitlabProviderClient,
+		config:               config,
+	}, nil
+}
+
+// CreateRepo creates an empty GitLab project. The repository must be initialized locally or
+// file must be added to it before it can be successfully cloned.
+func (g *gitlabProvider) CreateRepo(ctx context.Context, opts git.CreateRepoOpts) (*git.Repository, error) {
+	return g.gitlabProviderClient.CreateRepo(ctx, opts)
+}
+
+// GetRepo describes the remote repository. If the repo does not exist, a nil repo is returned.
+func (g *gitlabProvider) GetRepo(ctx context.Context) (*git.Repository, error) {
+	r := g.config.Repository
+	o := g.config.Owner
+	logger.V(3).Info("Describing GitLab repository", "name", r, "owner", o)
+	repo, err := g.gitlabProviderClient.GetRepo(ctx, git.GetRepoOpts{Owner: o, Repository: r})
+	if err != nil {
+		var e *git.RepositoryDoesNotExistError
+		if errors.As(err, &e) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected error when describing repository %s: %w", r, err)
+	}
+	return repo, nil
+}
+
+// DeleteRepo deletes a GitLab project.
+func (g *gitlabProvider) DeleteRepo(ctx context.Context, opts git.DeleteRepoOpts) error {
+	return g.gitlabProviderClient.DeleteRepo(ctx, opts)
+}
+
+// AddDeployKeyToRepo registers a deploy key in a GitLab project.
+func (g *gitlabProvider) AddDeployKeyToRepo(ctx context.Context, opts git.AddDeployKeyOpts) error {
+	return g.gitlabProviderClient.AddDeployKeyToRepo(ctx, opts)
+}
+
+// CreatePullRequest is not supported for GitLab repositories yet.
+func (g *gitlabProvider) CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("pull requests are not supported for the %s provider", GitProviderName)
+}
+
+// GetPullRequest is not supported for GitLab repositories yet.
+func (g *gitlabProvider) GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error) {
+	return nil, fmt.Errorf("pull requests are not supported for the %s provider", GitProviderName)
+}
+
+// PathExists checks if a path exists in a branch of a GitLab project.
+func (g *gitlabProvider) PathExists(ctx context.Context, owner, repo, branch, path string) (bool, error) {
+	return g.gitlabProviderClient.PathExists(ctx, owner, repo, branch, path)
+}
+
+// Validate checks the access token is valid and has access to the owner namespace.
+func (g *gitlabProvider) Validate(ctx context.Context) error {
+	username, err := g.gitlabProviderClient.AuthenticatedUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	if g.config.Personal {
+		if !strings.EqualFold(g.config.Owner, username) {
+			return fmt.Errorf("the authenticated GitLab user and owner %s specified in the EKS-A gitops spec don't match; confirm access token owner is %s", g.config.Owner, g.config.Owner)
+		}
+		return nil
+	}
+
+	exists, err := g.gitlabProviderClient.GroupExists(ctx, g.config.Owner)
+	if err != nil {
+		return fmt.Errorf("the authenticated GitLab user doesn't have proper access to GitLab group %s, %v", g.config.Owner, err)
+	}
+	if !exists {
+		return fmt.Errorf("the authenticated GitLab user doesn't have proper access to GitLab group %s", g.config.Owner)
+	}
+	return nil
+}
+
+// GetGitlabAccessTokenFromEnv returns the GitLab access token from the EKSA_GITLAB_TOKEN env var
+// and exports it as GITLAB_TOKEN for Flux.
+func GetGitlabAccessTokenFromEnv() (string, error) {
+	token, ok := os.LookupEnv(EksaGitlabTokenEnv)
+	if !ok || token == "" {
+		return "", fmt.Errorf("gitlab access token environment variable %s is invalid; could not get var from environment", EksaGitlabTokenEnv)
+	}
+	if err := os.Setenv(GitlabTokenEnv, token); err != nil {
+		return "", fmt.Errorf("unable to set %s: %v", GitlabTokenEnv, err)
+	}
+	return token, nil
+}
+
+// BaseURL returns the base URL of the GitLab instance in config.
+func BaseURL(config *v1alpha1.GitlabProviderConfig) string {
+	if config.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimSuffix(config.BaseURL, "/")
+}
+
+// Hostname returns the host of the GitLab instance in config.
+func Hostname(config *v1alpha1.GitlabProviderConfig) (string, error) {
+	u, err := url.Parse(BaseURL(config))
+	if err != nil {
+		return "", fmt.Errorf("parsing gitlab base url: %v", err)
+	}
+	return u.Host, nil
+}
+
+// RepoUrl returns the HTTPS clone URL of the repository in config.
+func RepoUrl(config *v1alpha1.GitlabProviderConfig) string {
+	return fmt.Sprintf("%s/%s/%s.git", BaseURL(config), config.Owner, config.Repository)
+}
@@ -0,0 +1,130 @@
+package gitlab_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab"
+	"github.com/aws/eks-anywhere/pkg/git/providers/gitlab/mocks"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		testName    string
+		config      *v1alpha1.GitlabProviderConfig
+		groupExists bool
+		groupErr    error
+		wantErr     string
+	}{
+		{
+			testName: "good personal repo",
+			config:   &v1alpha1.GitlabProviderConfig{Owner: "Jeff", Repository: "repo", Personal: true},
+		},
+		{
+			testName: "wrong owner for personal repo",
+			config:   &v1alpha1.GitlabProviderConfig{Owner: "nobody", Repository: "repo", Personal: true},
+			wantErr:  "the authenticated GitLab user and owner nobody specified in the EKS-A gitops spec don't match",
+		},
+		{
+			testName:    "good group repo",
+			config:      &v1alpha1.GitlabProviderConfig{Owner: "group/subgroup", Repository: "repo"},
+			groupExists: true,
+		},
+		{
+			testName: "group not visible",
+			config:   &v1alpha1.GitlabProviderConfig{Owner: "hidden", Repository: "repo"},
+			wantErr:  "the authenticated GitLab user doesn't have proper access to GitLab group hidden",
+		},
+		{
+			testName: "group lookup fails",
+			config:   &v1alpha1.GitlabProviderConfig{Owner: "group", Repository: "repo"},
+			groupErr: errors.New("boom"),
+			wantErr:  "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			client := mocks.NewMockGitlabClient(gomock.NewController(t))
+			client.EXPECT().AuthenticatedUser(ctx).Return("jeff", nil)
+			if !tt.config.Personal {
+				client.EXPECT().GroupExists(ctx, tt.config.Owner).Return(tt.groupExists, tt.groupErr)
+			}
+
+			p, err := gitlab.New(client, tt.config)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			err = p.Validate(ctx)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGetRepoDoesNotExist(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config := &v1alpha1.GitlabProviderConfig{Owner: "group", Repository: "repo"}
+	client := mocks.NewMockGitlabClient(gomock.NewController(t))
+	client.EXPECT().GetRepo(ctx, git.GetRepoOpts{Owner: "group", Repository: "repo"}).Return(nil, &git.RepositoryDoesNotExistError{})
+
+	p, err := gitlab.New(client, config)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	repo, err := p.GetRepo(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(repo).To(BeNil())
+}
+
+func TestGetRepoError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	config := &v1alpha1.GitlabProviderConfig{Owner: "group", Repository: "repo"}
+	client := mocks.NewMockGitlabClient(gomock.NewController(t))
+	client.EXPECT().GetRepo(ctx, git.GetRepoOpts{Owner: "group", Repository: "repo"}).Return(nil, errors.New("boom"))
+
+	p, err := gitlab.New(client, config)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	_, err = p.GetRepo(ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("unexpected error when describing repository repo: boom")))
+}
+
+func TestRepoUrlAndHostname(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(gitlab.RepoUrl(&v1alpha1.GitlabProviderConfig{Owner: "group/sub", Repository: "repo"})).To(Equal("https://gitlab.com/group/sub/repo.git"))
+
+	config := &v1alpha1.GitlabProviderConfig{Owner: "me", Repository: "repo", BaseURL: "https://gitlab.example.com:8443/"}
+	g.Expect(gitlab.RepoUrl(config)).To(Equal("https://gitlab.example.com:8443/me/repo.git"))
+	host, err := gitlab.Hostname(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(host).To(Equal("gitlab.example.com:8443"))
+}
+
+func TestGetGitlabAccessTokenFromEnv(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "glpat-token")
+	t.Setenv(gitlab.GitlabTokenEnv, "")
+
+	token, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(token).To(Equal("glpat-token"))
+}
+
+func TestGetGitlabAccessTokenFromEnvMissing(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(gitlab.EksaGitlabTokenEnv, "")
+
+	_, err := gitlab.GetGitlabAccessTokenFromEnv()
+	g.Expect(err).To(MatchError(ContainSubstring("EKSA_GITLAB_TOKEN")))
+}
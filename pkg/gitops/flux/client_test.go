@@ -50,6 +50,22 @@ func TestFluxClientBootstrapGithubSuccess(t *testing.T) {
 	tt.Expect(tt.c.BootstrapGithub(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapGithub() should succeed with 5 tries")
 }
 
+func TestFluxClientBootstrapGitlabSuccess(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap gitlab")).Times(4)
+	tt.f.EXPECT().BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).Times(1)
+
+	tt.Expect(tt.c.BootstrapGitlab(tt.ctx, tt.cluster, tt.fluxConfig)).To(Succeed(), "fluxClient.BootstrapGitlab() should succeed with 5 tries")
+}
+
+func TestFluxClientBootstrapGiteaError(t *testing.T) {
+	tt := newFluxClientTest(t)
+	tt.f.EXPECT().BootstrapGitea(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap gitea")).Times(5)
+	tt.f.EXPECT().BootstrapGitea(tt.ctx, tt.cluster, tt.fluxConfig).Return(nil).AnyTimes()
+
+	tt.Expect(tt.c.BootstrapGitea(tt.ctx, tt.cluster, tt.fluxConfig)).To(MatchError(ContainSubstring("error in bootstrap gitea")), "fluxClient.BootstrapGitea() should fail after 5 tries")
+}
+
 func TestFluxClientBootstrapGithubError(t *testing.T) {
 	tt := newFluxClientTest(t)
 	tt.f.EXPECT().BootstrapGithub(tt.ctx, tt.cluster, tt.fluxConfig).Return(errors.New("error in bootstrap github")).Times(5)
@@ -151,7 +151,7 @@ func (fc *fluxForCluster) clone(ctx context.Context) error {
 
 // createRemoteRepository will create a repository in the remote git provider with the user-provided configuration.
 func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
-	logger.V(3).Info("Remote Github repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
+	logger.V(3).Info("Remote git repo does not exist; will create and initialize", "repo", fc.repository(), "owner", fc.owner())
 
 	opts := git.CreateRepoOpts{
 		Name:        fc.repository(),
@@ -161,7 +161,7 @@ func (fc *fluxForCluster) createRemoteRepository(ctx context.Context) error {
 		Privacy:     true,
 	}
 
-	logger.V(4).Info("Creating remote Github repo", "options", opts)
+	logger.V(4).Info("Creating remote git repo", "options", opts)
 	if err := fc.gitClient.CreateRepo(ctx, opts); err != nil {
 		return fmt.Errorf("creating repo: %v", err)
 	}
@@ -224,6 +224,12 @@ func (fc *fluxForCluster) repository() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Repository
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Repository
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Repository
+	}
 	if fc.clusterSpec.FluxConfig.Spec.Git != nil {
 		r := fc.clusterSpec.FluxConfig.Spec.Git.RepositoryUrl
 		return path.Base(strings.TrimSuffix(r, filepath.Ext(r)))
@@ -235,6 +241,12 @@ func (fc *fluxForCluster) owner() string {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Owner
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Owner
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Owner
+	}
 	return ""
 }
 
@@ -246,6 +258,12 @@ func (fc *fluxForCluster) personal() bool {
 	if fc.clusterSpec.FluxConfig.Spec.Github != nil {
 		return fc.clusterSpec.FluxConfig.Spec.Github.Personal
 	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitlab != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitlab.Personal
+	}
+	if fc.clusterSpec.FluxConfig.Spec.Gitea != nil {
+		return fc.clusterSpec.FluxConfig.Spec.Gitea.Personal
+	}
 	return false
 }
 
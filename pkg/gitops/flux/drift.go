@@ -0,0 +1,274 @@
+package flux
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const defaultObjectNamespace = "default"
+
+// DetectDrift compares the EKS Anywhere objects committed to the Git repository for the cluster
+// with the live objects in the cluster. The local repository is synced with the remote first,
+// so the comparison is made against the HEAD of the configured branch.
+// Only fields set in Git are compared, so values defaulted by the EKS Anywhere webhooks
+// are not reported as drift.
+func (f *Flux) DetectDrift(ctx context.Context, c client.Reader, clusterSpec *cluster.Spec) (*v1alpha1.GitOpsDriftStatus, error) {
+	if f.shouldSkipFlux() {
+		return nil, errors.New("GitOps is not configured for the cluster")
+	}
+
+	fc := newFluxForCluster(f, clusterSpec, nil, nil)
+
+	if err := fc.syncGitRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	commit, err := f.gitClient.HeadCommit()
+	if err != nil {
+		return nil, err
+	}
+
+	configFile := path.Join(f.writer.Dir(), fc.eksaSystemDir(), clusterConfigFileName)
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading cluster config from git: %v", err)
+	}
+
+	gitObjects, err := parseObjects(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster config from git: %v", err)
+	}
+
+	drift := &v1alpha1.GitOpsDriftStatus{
+		LastCheckTime: metav1.Now(),
+		Commit:        commit,
+	}
+
+	for _, obj := range gitObjects {
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = clusterSpec.Cluster.Namespace
+		}
+		if namespace == "" {
+			namespace = defaultObjectNamespace
+		}
+
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		err := c.Get(ctx, client.ObjectKey{Name: obj.GetName(), Namespace: namespace}, live)
+		if apierrors.IsNotFound(err) {
+			drift.Fields = append(drift.Fields, v1alpha1.FieldDrift{
+				Kind: obj.GetKind(),
+				Name: obj.GetName(),
+				Type: v1alpha1.DriftTypeMissing,
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s %s from cluster: %v", obj.GetKind(), obj.GetName(), err)
+		}
+
+		fields, err := DiffObjectSpec(obj, live)
+		if err != nil {
+			return nil, err
+		}
+		drift.Fields = append(drift.Fields, fields...)
+	}
+
+	drift.Drifted = len(drift.Fields) > 0
+	logger.V(3).Info("Compared cluster config in git with the cluster", "commit", commit, "differences", len(drift.Fields))
+
+	return drift, nil
+}
+
+// UpdateDriftStatus records drift in the status of the FluxConfig object in the cluster.
+func UpdateDriftStatus(ctx context.Context, c client.Client, fluxConfig *v1alpha1.FluxConfig, drift *v1alpha1.GitOpsDriftStatus) error {
+	namespace := fluxConfig.Namespace
+	if namespace == "" {
+		namespace = defaultObjectNamespace
+	}
+
+	live := &v1alpha1.FluxConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Name: fluxConfig.Name, Namespace: namespace}, live); err != nil {
+		return fmt.Errorf("reading FluxConfig %s: %v", fluxConfig.Name, err)
+	}
+
+	live.Status.Drift = drift
+	if err := c.Status().Update(ctx, live); err != nil {
+		return fmt.Errorf("updating FluxConfig %s drift status: %v", fluxConfig.Name, err)
+	}
+
+	return nil
+}
+
+// RecordDrift compares the cluster config in Git with the cluster and records the result in the
+// status of the cluster FluxConfig. It's a no-op when GitOps is not configured.
+func (f *Flux) RecordDrift(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if f.shouldSkipFlux() {
+		return nil
+	}
+
+	c, err := kubernetes.NewRuntimeClientFromFileName(cluster.KubeconfigFile)
+	if err != nil {
+		return fmt.Errorf("building client to record drift: %v", err)
+	}
+
+	drift, err := f.DetectDrift(ctx, c, clusterSpec)
+	if err != nil {
+		return fmt.Errorf("detecting drift: %v", err)
+	}
+
+	return UpdateDriftStatus(ctx, c, clusterSpec.FluxConfig, drift)
+}
+
+// DiffObjectSpec returns the differences between the spec of an object in Git and the spec of
+// the same object in the cluster. Map keys only present in the cluster are ignored.
+func DiffObjectSpec(gitObj, liveObj *unstructured.Unstructured) ([]v1alpha1.FieldDrift, error) {
+	gitSpec, err := normalize(gitObj.Object["spec"])
+	if err != nil {
+		return nil, fmt.Errorf("reading %s %s spec from git: %v", gitObj.GetKind(), gitObj.GetName(), err)
+	}
+	if gitSpec == nil {
+		return nil, nil
+	}
+	liveSpec, err := normalize(liveObj.Object["spec"])
+	if err != nil {
+		return nil, fmt.Errorf("reading %s %s spec from cluster: %v", gitObj.GetKind(), gitObj.GetName(), err)
+	}
+
+	d := &differ{kind: gitObj.GetKind(), name: gitObj.GetName()}
+	d.diff("spec", gitSpec, liveSpec, true)
+	return d.fields, nil
+}
+
+type differ struct {
+	kind, name string
+	fields     []v1alpha1.FieldDrift
+}
+
+func (d *differ) diff(fieldPath string, git, live interface{}, liveExists bool) {
+	if !liveExists {
+		d.add(fieldPath, v1alpha1.DriftTypeMissing, git, nil)
+		return
+	}
+
+	switch g := git.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			d.add(fieldPath, v1alpha1.DriftTypeModified, git, live)
+			return
+		}
+		keys := make([]string, 0, len(g))
+		for k := range g {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			lv, exists := l[k]
+			d.diff(fieldPath+"."+k, g[k], lv, exists)
+		}
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(g) {
+			d.add(fieldPath, v1alpha1.DriftTypeModified, git, live)
+			return
+		}
+		for i := range g {
+			d.diff(fieldPath+"["+strconv.Itoa(i)+"]", g[i], l[i], true)
+		}
+	default:
+		if !equalScalars(git, live) {
+			d.add(fieldPath, v1alpha1.DriftTypeModified, git, live)
+		}
+	}
+}
+
+func (d *differ) add(fieldPath string, t v1alpha1.DriftType, git, live interface{}) {
+	f := v1alpha1.FieldDrift{
+		Kind: d.kind,
+		Name: d.name,
+		Path: fieldPath,
+		Type: t,
+		Git:  encode(git),
+	}
+	if t != v1alpha1.DriftTypeMissing {
+		f.Live = encode(live)
+	}
+	d.fields = append(d.fields, f)
+}
+
+func equalScalars(a, b interface{}) bool {
+	return encode(a) == encode(b)
+}
+
+func encode(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// normalize round trips v through JSON so values read from YAML and from the API server
+// have the same types, for example float64 for all numbers.
+func normalize(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func parseObjects(content []byte) ([]*unstructured.Unstructured, error) {
+	reader := apiyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	var objs []*unstructured.Unstructured
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, err
+		}
+		if len(obj.Object) == 0 || obj.GetKind() == "" {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
@@ -0,0 +1,179 @@
+package flux_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+)
+
+const gitClusterConfig = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Cluster
+metadata:
+  name: management-cluster
+  namespace: default
+spec:
+  kubernetesVersion: "1.28"
+  controlPlaneConfiguration:
+    count: 3
+  workerNodeGroupConfigurations:
+  - name: md-0
+    count: 2
+---
+apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: VSphereDatacenterConfig
+metadata:
+  name: management-cluster
+  namespace: default
+spec:
+  server: vcenter.example.com
+`
+
+func TestDiffObjectSpec(t *testing.T) {
+	g := NewWithT(t)
+	gitObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Cluster",
+		"metadata": map[string]interface{}{"name": "c"},
+		"spec": map[string]interface{}{
+			"controlPlaneConfiguration": map[string]interface{}{"count": 3},
+			"workerNodeGroupConfigurations": []interface{}{
+				map[string]interface{}{"name": "md-0", "count": 2},
+			},
+			"clusterNetwork":    map[string]interface{}{"cniConfig": map[string]interface{}{"cilium": map[string]interface{}{}}},
+			"kubernetesVersion": "1.28",
+		},
+	}}
+	liveObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"controlPlaneConfiguration": map[string]interface{}{"count": int64(3), "endpoint": map[string]interface{}{"host": "1.2.3.4"}},
+			"workerNodeGroupConfigurations": []interface{}{
+				map[string]interface{}{"name": "md-0", "count": int64(5)},
+			},
+			"kubernetesVersion": "1.28",
+		},
+	}}
+
+	fields, err := flux.DiffObjectSpec(gitObj, liveObj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fields).To(Equal([]v1alpha1.FieldDrift{
+		{Kind: "Cluster", Name: "c", Path: "spec.clusterNetwork", Type: v1alpha1.DriftTypeMissing, Git: `{"cniConfig":{"cilium":{}}}`},
+		{Kind: "Cluster", Name: "c", Path: "spec.workerNodeGroupConfigurations[0].count", Type: v1alpha1.DriftTypeModified, Git: "2", Live: "5"},
+	}))
+}
+
+func TestDiffObjectSpecListLengthChanged(t *testing.T) {
+	g := NewWithT(t)
+	gitObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Cluster",
+		"metadata": map[string]interface{}{"name": "c"},
+		"spec":     map[string]interface{}{"list": []interface{}{"a"}},
+	}}
+	liveObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"list": []interface{}{"a", "b"}},
+	}}
+
+	fields, err := flux.DiffObjectSpec(gitObj, liveObj)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fields).To(ConsistOf(v1alpha1.FieldDrift{
+		Kind: "Cluster", Name: "c", Path: "spec.list", Type: v1alpha1.DriftTypeModified, Git: `["a"]`, Live: `["a","b"]`,
+	}))
+}
+
+func TestDetectDrift(t *testing.T) {
+	tt := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, NewCluster("management-cluster"), "")
+
+	repoDir := tt.writer.Dir()
+	eksaDir := filepath.Join(repoDir, "clusters", "management-cluster", "management-cluster", "eksa-system")
+	tt.Expect(os.MkdirAll(filepath.Join(repoDir, ".git"), 0o755)).To(Succeed())
+	tt.Expect(os.MkdirAll(eksaDir, 0o755)).To(Succeed())
+	tt.Expect(os.WriteFile(filepath.Join(eksaDir, "eksa-cluster.yaml"), []byte(gitClusterConfig), 0o644)).To(Succeed())
+
+	tt.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	tt.git.EXPECT().HeadCommit().Return("abc123", nil)
+
+	live := &v1alpha1.Cluster{
+		TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.ClusterKind, APIVersion: v1alpha1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "management-cluster", Namespace: "default"},
+		Spec: v1alpha1.ClusterSpec{
+			KubernetesVersion:         "1.28",
+			ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{Count: 5},
+			WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptr(2)},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(driftScheme(t)).WithObjects(live).Build()
+
+	drift, err := tt.gitOpsFlux.DetectDrift(tt.ctx, c, clusterSpec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(drift.Commit).To(Equal("abc123"))
+	tt.Expect(drift.Drifted).To(BeTrue())
+	tt.Expect(drift.Fields).To(Equal([]v1alpha1.FieldDrift{
+		{Kind: "Cluster", Name: "management-cluster", Path: "spec.controlPlaneConfiguration.count", Type: v1alpha1.DriftTypeModified, Git: "3", Live: "5"},
+		{Kind: "VSphereDatacenterConfig", Name: "management-cluster", Type: v1alpha1.DriftTypeMissing},
+	}))
+}
+
+func TestDetectDriftGitOpsNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	f := flux.NewFluxFromGitOpsFluxClient(nil, nil, nil, nil)
+
+	_, err := f.DetectDrift(context.Background(), nil, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("GitOps is not configured")))
+}
+
+func TestRecordDriftGitOpsNotConfigured(t *testing.T) {
+	g := NewWithT(t)
+	f := flux.NewFluxFromGitOpsFluxClient(nil, nil, nil, nil)
+
+	g.Expect(f.RecordDrift(context.Background(), nil, nil)).To(Succeed())
+}
+
+func TestUpdateDriftStatus(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	fluxConfig := &v1alpha1.FluxConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "gitops", Namespace: "default"},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(driftScheme(t)).
+		WithObjects(fluxConfig.DeepCopy()).
+		WithStatusSubresource(&v1alpha1.FluxConfig{}).
+		Build()
+	drift := &v1alpha1.GitOpsDriftStatus{
+		Commit:  "abc123",
+		Drifted: true,
+		Fields:  []v1alpha1.FieldDrift{{Kind: "Cluster", Name: "c", Path: "spec.a", Type: v1alpha1.DriftTypeModified, Git: "1", Live: "2"}},
+	}
+
+	g.Expect(flux.UpdateDriftStatus(ctx, c, fluxConfig, drift)).To(Succeed())
+
+	got := &v1alpha1.FluxConfig{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(fluxConfig), got)).To(Succeed())
+	g.Expect(got.Status.Drift.Commit).To(Equal("abc123"))
+	g.Expect(got.Status.Drift.Fields).To(Equal(drift.Fields))
+}
+
+func driftScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
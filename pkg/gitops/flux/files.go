@@ -15,6 +15,7 @@ const (
 	eksaSystemDirName     = "eksa-system"
 	kustomizeFileName     = "kustomization.yaml"
 	clusterConfigFileName = "eksa-cluster.yaml"
+	packagesFileName      = "curated-packages.yaml"
 	fluxSyncFileName      = "gotk-sync.yaml"
 	fluxPatchFileName     = "gotk-patches.yaml"
 )
@@ -35,6 +36,8 @@ type Templater interface {
 type FileGenerator struct {
 	fluxWriter, eksaWriter       filewriter.FileWriter
 	fluxTemplater, eksaTemplater Templater
+	// packages is true when the eks-a system directory has a curated packages file.
+	packages bool
 }
 
 func NewFileGenerator() *FileGenerator {
@@ -114,10 +117,24 @@ func (g *FileGenerator) WriteClusterConfig(clusterSpec *cluster.Spec, datacenter
 	return nil
 }
 
+// WritePackages writes the curated packages objects into the eks-a system git directory, next to the
+// cluster config. They are included in the eks-a kustomization written afterwards.
+func (g *FileGenerator) WritePackages(content []byte) error {
+	if filePath, err := g.eksaWriter.Write(packagesFileName, content, filewriter.PersistentFile); err != nil {
+		return fmt.Errorf("writing curated packages file into %s: %v", filePath, err)
+	}
+	g.packages = true
+
+	return nil
+}
+
 func (g *FileGenerator) WriteEksaKustomization() error {
 	values := map[string]string{
 		"ConfigFileName": clusterConfigFileName,
 	}
+	if g.packages {
+		values["PackagesFileName"] = packagesFileName
+	}
 
 	if path, err := g.eksaTemplater.WriteToFile(eksaKustomizeContent, values, kustomizeFileName, filewriter.PersistentFile); err != nil {
 		return fmt.Errorf("writing eks-a kustomization manifest file into %s: %v", path, err)
@@ -83,7 +83,10 @@ spec:
 var wantEksaKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
 kind: Kustomization
 resources:
-- {{.ConfigFileName}}`
+- {{.ConfigFileName}}
+{{- if .PackagesFileName}}
+- {{.PackagesFileName}}
+{{- end}}`
 
 var wantFluxKustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
 kind: Kustomization
@@ -2,7 +2,10 @@ package flux
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"path"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -27,6 +30,8 @@ const (
 
 type GitOpsFluxClient interface {
 	BootstrapGithub(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitlab(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
+	BootstrapGitea(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	BootstrapGit(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig, cliConfig *config.CliConfig) error
 	Uninstall(ctx context.Context, cluster *types.Cluster, fluxConfig *v1alpha1.FluxConfig) error
 	GetCluster(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) (eksaCluster *v1alpha1.Cluster, err error)
@@ -49,6 +54,9 @@ type GitClient interface {
 	Commit(message string) error
 	Branch(name string) error
 	Init() error
+	HeadCommit() (string, error)
+	CreatePullRequest(ctx context.Context, opts git.CreatePullRequestOpts) (*git.PullRequest, error)
+	GetPullRequest(ctx context.Context, opts git.GetPullRequestOpts) (*git.PullRequest, error)
 }
 
 type Flux struct {
@@ -56,6 +64,8 @@ type Flux struct {
 	gitClient  GitClient
 	writer     filewriter.FileWriter
 	cliConfig  *config.CliConfig
+	// pullRequest is the pull request opened with the last cluster config change, if any.
+	pullRequest *git.PullRequest
 }
 
 func NewFlux(fluxClient FluxClient, kubeClient KubeClient, gitTools *gitFactory.GitTools, cliConfig *config.CliConfig) *Flux {
@@ -118,6 +128,16 @@ func (f *Flux) Bootstrap(ctx context.Context, cluster *types.Cluster, clusterSpe
 		return fmt.Errorf("installing GitHub gitops: %v", err)
 	}
 
+	if err := f.BootstrapGitlab(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing GitLab gitops: %v", err)
+	}
+
+	if err := f.BootstrapGitea(ctx, cluster, clusterSpec); err != nil {
+		_ = f.Uninstall(ctx, cluster, clusterSpec)
+		return fmt.Errorf("installing Gitea gitops: %v", err)
+	}
+
 	if err := f.BootstrapGit(ctx, cluster, clusterSpec); err != nil {
 		_ = f.Uninstall(ctx, cluster, clusterSpec)
 		return fmt.Errorf("installing generic git gitops: %v", err)
@@ -134,6 +154,24 @@ func (f *Flux) BootstrapGithub(ctx context.Context, cluster *types.Cluster, clus
 	return f.fluxClient.BootstrapGithub(ctx, cluster, clusterSpec.FluxConfig)
 }
 
+// BootstrapGitlab bootstraps flux with the GitLab provider if the cluster is a management cluster configured with it.
+func (f *Flux) BootstrapGitlab(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.Gitlab == nil {
+		return nil
+	}
+
+	return f.fluxClient.BootstrapGitlab(ctx, cluster, clusterSpec.FluxConfig)
+}
+
+// BootstrapGitea bootstraps flux with the Gitea provider if the cluster is a management cluster configured with it.
+func (f *Flux) BootstrapGitea(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
+	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.Gitea == nil {
+		return nil
+	}
+
+	return f.fluxClient.BootstrapGitea(ctx, cluster, clusterSpec.FluxConfig)
+}
+
 func (f *Flux) BootstrapGit(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error {
 	if clusterSpec.Cluster.IsManaged() || clusterSpec.FluxConfig.Spec.Git == nil {
 		return nil
@@ -210,6 +248,11 @@ func (f *Flux) ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster
 		return nil
 	}
 
+	if f.pullRequestMerged() {
+		// Wait for Flux to fetch the merged changes before reporting success.
+		return f.fluxClient.Reconcile(ctx, cluster, clusterSpec.FluxConfig)
+	}
+
 	return f.fluxClient.ForceReconcile(ctx, cluster, clusterSpec.FluxConfig.Spec.SystemNamespace)
 }
 
@@ -225,11 +268,23 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return err
 	}
 
+	var prBranch string
+	if fc.usePullRequest() {
+		prBranch = fc.pullRequestBranch()
+		if err := f.gitClient.Branch(prBranch); err != nil {
+			return fmt.Errorf("creating pull request branch: %v", err)
+		}
+	}
+
 	g := NewFileGenerator()
 	if err := g.Init(f.writer, fc.eksaSystemDir(), fc.fluxSystemDir()); err != nil {
 		return err
 	}
 
+	if err := f.writePackages(g, fc); err != nil {
+		return err
+	}
+
 	if err := g.WriteEksaFiles(clusterSpec, datacenterConfig, machineConfigs); err != nil {
 		return err
 	}
@@ -243,9 +298,35 @@ func (f *Flux) UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec,
 		return err
 	}
 	logger.V(3).Info("Finished pushing updated cluster config file to git", "repository", fc.repository())
+
+	if fc.usePullRequest() {
+		return f.openPullRequest(ctx, fc, prBranch)
+	}
 	return nil
 }
 
+// writePackages writes the curated packages file from the CLI config next to the cluster config. Without one,
+// the packages committed by previous changes are kept in the kustomization so Flux doesn't prune them.
+func (f *Flux) writePackages(g *FileGenerator, fc *fluxForCluster) error {
+	if f.cliConfig != nil && f.cliConfig.GitOpsPackagesFile != "" {
+		content, err := os.ReadFile(f.cliConfig.GitOpsPackagesFile)
+		if err != nil {
+			return fmt.Errorf("reading curated packages file: %v", err)
+		}
+		return g.WritePackages(content)
+	}
+
+	content, err := os.ReadFile(path.Join(f.writer.Dir(), fc.eksaSystemDir(), packagesFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading curated packages file from git: %v", err)
+	}
+
+	return g.WritePackages(content)
+}
+
 func (f *Flux) Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation {
 	if f.shouldSkipFlux() {
 		return nil
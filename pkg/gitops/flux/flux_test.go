@@ -443,6 +443,59 @@ func TestInstallGitOpsGitProviderSuccess(t *testing.T) {
 	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, managementComponents, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
 }
 
+func TestInstallGitOpsGitlabProviderSuccess(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := NewCluster(clusterName)
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	clusterSpec.FluxConfig.Spec.Gitlab = &v1alpha1.GitlabProviderConfig{Owner: "group/sub", Repository: "fleet"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+
+	managementComponents := cluster.ManagementComponentsFromBundles(clusterSpec.Bundles)
+
+	cluster := &types.Cluster{}
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "fleet"}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGitlab(g.ctx, cluster, clusterSpec.FluxConfig)
+	g.git.EXPECT().Pull(g.ctx, clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+
+	datacenterConfig := datacenterConfig(clusterName)
+	machineConfig := machineConfig(clusterName)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, managementComponents, clusterSpec, datacenterConfig, []providers.MachineConfig{machineConfig})).To(Succeed())
+}
+
+func TestInstallGitOpsGiteaBootstrapError(t *testing.T) {
+	clusterName := "management-cluster"
+	clusterConfig := NewCluster(clusterName)
+	g := newFluxTest(t)
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+
+	clusterSpec.FluxConfig.Spec.Gitea = &v1alpha1.GiteaProviderConfig{Owner: "org", Repository: "fleet", BaseURL: "https://gitea.example.com"}
+	clusterSpec.FluxConfig.Spec.Github = nil
+
+	managementComponents := cluster.ManagementComponentsFromBundles(clusterSpec.Bundles)
+
+	cluster := &types.Cluster{}
+
+	g.git.EXPECT().GetRepo(g.ctx).Return(&git.Repository{Name: "fleet"}, nil)
+	g.git.EXPECT().Clone(g.ctx).Return(nil)
+	g.git.EXPECT().Branch(clusterSpec.FluxConfig.Spec.Branch).Return(nil)
+	g.git.EXPECT().Add(path.Dir("clusters/management-cluster")).Return(nil)
+	g.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	g.git.EXPECT().Push(g.ctx).Return(nil)
+	g.flux.EXPECT().BootstrapGitea(g.ctx, cluster, clusterSpec.FluxConfig).Return(errors.New("error in bootstrap"))
+	g.flux.EXPECT().Uninstall(g.ctx, cluster, clusterSpec.FluxConfig).Return(nil)
+
+	g.Expect(g.gitOpsFlux.InstallGitOps(g.ctx, cluster, managementComponents, clusterSpec, nil, nil)).To(MatchError(ContainSubstring("installing Gitea gitops: error in bootstrap")))
+}
+
 func TestInstallGitOpsCommitFilesError(t *testing.T) {
 	clusterName := "test-cluster"
 	clusterConfig := NewCluster(clusterName)
@@ -239,3 +239,31 @@ func TestGitClientInitError(t *testing.T) {
 
 	tt.Expect(tt.c.Init()).To(MatchError(ContainSubstring("error in init")), "gitClient.Init() should fail after 1 try")
 }
+
+func TestGitClientCreatePullRequestSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.CreatePullRequestOpts{Head: "eksa/test", Base: "main"}
+	want := &git.PullRequest{Number: 1}
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(nil, errors.New("error in create pull request")).Times(4)
+	tt.p.EXPECT().CreatePullRequest(tt.ctx, opts).Return(want, nil).Times(1)
+
+	tt.Expect(tt.c.CreatePullRequest(tt.ctx, opts)).To(Equal(want), "gitClient.CreatePullRequest() should succeed with 5 tries")
+}
+
+func TestGitClientCreatePullRequestNoProvider(t *testing.T) {
+	tt := newGitClientTest(t)
+
+	c := newGitClient(&gitFactory.GitTools{Provider: nil, Client: tt.g})
+	_, err := c.CreatePullRequest(tt.ctx, git.CreatePullRequestOpts{})
+	tt.Expect(err).To(MatchError(ContainSubstring("pull requests require a git provider")))
+}
+
+func TestGitClientGetPullRequestSuccess(t *testing.T) {
+	tt := newGitClientTest(t)
+	opts := git.GetPullRequestOpts{Number: 1}
+	want := &git.PullRequest{Number: 1, Merged: true}
+	tt.p.EXPECT().GetPullRequest(tt.ctx, opts).Return(nil, errors.New("error in get pull request")).Times(4)
+	tt.p.EXPECT().GetPullRequest(tt.ctx, opts).Return(want, nil).Times(1)
+
+	tt.Expect(tt.c.GetPullRequest(tt.ctx, opts)).To(Equal(want), "gitClient.GetPullRequest() should succeed with 5 tries")
+}
@@ -0,0 +1,105 @@
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+)
+
+const (
+	pullRequestBranchTimeFormat    = "20060102150405"
+	pullRequestPollPeriod          = 15 * time.Second
+	defaultPullRequestMergeTimeout = time.Hour
+)
+
+var errPullRequestClosed = errors.New("pull request was closed without being merged")
+
+func (fc *fluxForCluster) usePullRequest() bool {
+	return fc.clusterSpec.FluxConfig.Spec.PullRequest != nil
+}
+
+// pullRequestBranch returns a new branch name for a pull request with the cluster config changes.
+func (fc *fluxForCluster) pullRequestBranch() string {
+	prefix := fc.clusterSpec.FluxConfig.Spec.PullRequest.BranchPrefix
+	if prefix == "" {
+		prefix = v1alpha1.FluxDefaultPullRequestBranchPrefix
+	}
+	return prefix + fc.clusterSpec.Cluster.Name + "-" + time.Now().UTC().Format(pullRequestBranchTimeFormat)
+}
+
+// openPullRequest opens a pull request to merge head into the configured branch and, when requested in the
+// CLI config, waits for it to be merged. Waiting fails if the pull request is closed or not merged in time.
+func (f *Flux) openPullRequest(ctx context.Context, fc *fluxForCluster, head string) error {
+	pr, err := f.gitClient.CreatePullRequest(ctx, git.CreatePullRequestOpts{
+		Owner:      fc.owner(),
+		Repository: fc.repository(),
+		Title:      fmt.Sprintf("Update EKS Anywhere cluster %s", fc.clusterSpec.Cluster.Name),
+		Body:       updateClusterconfigCommitMessage,
+		Head:       head,
+		Base:       fc.branch(),
+	})
+	if err != nil {
+		return fmt.Errorf("opening pull request for branch %s: %v", head, err)
+	}
+	f.pullRequest = pr
+	logger.Info("Opened pull request with the cluster config changes", "url", pr.URL)
+
+	if f.cliConfig == nil || !f.cliConfig.GitOpsWaitForMerge {
+		return nil
+	}
+
+	return f.waitForPullRequestMerge(ctx, fc)
+}
+
+func (f *Flux) waitForPullRequestMerge(ctx context.Context, fc *fluxForCluster) error {
+	timeout := defaultPullRequestMergeTimeout
+	if f.cliConfig != nil && f.cliConfig.GitOpsMergeTimeout != 0 {
+		timeout = f.cliConfig.GitOpsMergeTimeout
+	}
+	logger.Info("Waiting for pull request to be merged", "url", f.pullRequest.URL, "timeout", timeout)
+
+	r := retrier.New(timeout, retrier.WithRetryPolicy(func(_ int, err error) (bool, time.Duration) {
+		return !errors.Is(err, errPullRequestClosed), pullRequestPollPeriod
+	}))
+	err := r.Retry(func() error {
+		pr, err := f.gitClient.GetPullRequest(ctx, git.GetPullRequestOpts{
+			Owner:      fc.owner(),
+			Repository: fc.repository(),
+			Number:     f.pullRequest.Number,
+		})
+		if err != nil {
+			return err
+		}
+		if pr.Merged {
+			return nil
+		}
+		if pr.Closed {
+			return errPullRequestClosed
+		}
+		return fmt.Errorf("pull request %d is not merged yet", f.pullRequest.Number)
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for pull request %s to be merged: %v", f.pullRequest.URL, err)
+	}
+
+	f.pullRequest.Merged = true
+	logger.Info("Pull request merged", "url", f.pullRequest.URL)
+	return nil
+}
+
+// PullRequestPending returns true if the last cluster config change was proposed
+// in a pull request that hasn't been merged yet.
+func (f *Flux) PullRequestPending() bool {
+	return f.pullRequest != nil && !f.pullRequest.Merged
+}
+
+// pullRequestMerged returns true if the cluster config changes were already merged through a pull request.
+func (f *Flux) pullRequestMerged() bool {
+	return f.pullRequest != nil && f.pullRequest.Merged
+}
@@ -0,0 +1,151 @@
+package flux_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/git"
+	"github.com/aws/eks-anywhere/pkg/gitops/flux"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+type pullRequestTest struct {
+	fluxTest
+	spec    *cluster.Spec
+	eksaDir string
+	pr      *git.PullRequest
+}
+
+func newPullRequestTest(t *testing.T, cliConfig *config.CliConfig) *pullRequestTest {
+	g := newFluxTest(t)
+	clusterConfig := NewCluster("management-cluster")
+	clusterConfig.Spec.DatacenterRef = v1alpha1.Ref{Name: "datacenter"}
+	clusterSpec := newClusterSpec(t, clusterConfig, "")
+	clusterSpec.FluxConfig.Spec.PullRequest = &v1alpha1.PullRequestConfig{BranchPrefix: "eksa/"}
+	g.gitOpsFlux = flux.NewFluxFromGitOpsFluxClient(g.flux, g.git, g.writer, cliConfig)
+
+	return &pullRequestTest{
+		fluxTest: g,
+		spec:     clusterSpec,
+		eksaDir:  "clusters/management-cluster/management-cluster/eksa-system",
+		pr:       &git.PullRequest{Number: 3, URL: "https://github.com/mFolwer/testRepo/pull/3"},
+	}
+}
+
+func (tt *pullRequestTest) expectPushToBranch() {
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch(tt.spec.FluxConfig.Spec.Branch).Return(nil)
+	tt.git.EXPECT().Branch(test.OfType("string")).Do(func(branch string) {
+		tt.Expect(branch).To(HavePrefix("eksa/management-cluster-"))
+	}).Return(nil)
+	tt.git.EXPECT().Add(tt.eksaDir).Return(nil)
+	tt.git.EXPECT().Commit(test.OfType("string")).Return(nil)
+	tt.git.EXPECT().Push(tt.ctx).Return(nil)
+}
+
+func (tt *pullRequestTest) updateGitEksaSpec() error {
+	return tt.gitOpsFlux.UpdateGitEksaSpec(tt.ctx, tt.spec, datacenterConfig("management-cluster"), []providers.MachineConfig{machineConfig("management-cluster")})
+}
+
+func TestUpdateGitEksaSpecPullRequest(t *testing.T) {
+	tt := newPullRequestTest(t, &config.CliConfig{GitOpsWaitForMerge: true, GitOpsMergeTimeout: time.Minute})
+	cluster := &types.Cluster{}
+	tt.expectPushToBranch()
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).DoAndReturn(
+		func(_ interface{}, opts git.CreatePullRequestOpts) (*git.PullRequest, error) {
+			tt.Expect(opts.Owner).To(Equal("mFolwer"))
+			tt.Expect(opts.Repository).To(Equal("testRepo"))
+			tt.Expect(opts.Base).To(Equal("testBranch"))
+			tt.Expect(opts.Head).To(HavePrefix("eksa/management-cluster-"))
+			return tt.pr, nil
+		},
+	)
+	tt.git.EXPECT().GetPullRequest(tt.ctx, git.GetPullRequestOpts{Owner: "mFolwer", Repository: "testRepo", Number: 3}).
+		Return(&git.PullRequest{Number: 3, Merged: true, Closed: true}, nil)
+	tt.flux.EXPECT().Reconcile(tt.ctx, cluster, tt.spec.FluxConfig).Return(nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.PullRequestPending()).To(BeFalse())
+	tt.Expect(tt.gitOpsFlux.ForceReconcileGitRepo(tt.ctx, cluster, tt.spec)).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecPullRequestNoWait(t *testing.T) {
+	tt := newPullRequestTest(t, nil)
+	tt.expectPushToBranch()
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(tt.pr, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+	tt.Expect(tt.gitOpsFlux.PullRequestPending()).To(BeTrue())
+}
+
+func TestUpdateGitEksaSpecPullRequestDefaultTimeout(t *testing.T) {
+	tt := newPullRequestTest(t, &config.CliConfig{GitOpsWaitForMerge: true})
+	tt.expectPushToBranch()
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(tt.pr, nil)
+	tt.git.EXPECT().GetPullRequest(tt.ctx, gomock.Any()).Return(&git.PullRequest{Number: 3, Merged: true}, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+}
+
+func TestUpdateGitEksaSpecPullRequestError(t *testing.T) {
+	tt := newPullRequestTest(t, nil)
+	tt.expectPushToBranch()
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(nil, errors.New("pull requests are not supported"))
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("opening pull request")))
+}
+
+func TestUpdateGitEksaSpecPullRequestClosed(t *testing.T) {
+	tt := newPullRequestTest(t, &config.CliConfig{GitOpsWaitForMerge: true, GitOpsMergeTimeout: time.Minute})
+	tt.expectPushToBranch()
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(tt.pr, nil)
+	tt.git.EXPECT().GetPullRequest(tt.ctx, gomock.Any()).Return(&git.PullRequest{Number: 3, Closed: true}, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("pull request was closed without being merged")))
+}
+
+func TestUpdateGitEksaSpecPullRequestPackages(t *testing.T) {
+	packages := filepath.Join(t.TempDir(), "packages.yaml")
+	tt := newPullRequestTest(t, &config.CliConfig{GitOpsPackagesFile: packages})
+	tt.Expect(os.WriteFile(packages, []byte("kind: Package\n"), 0o644)).To(Succeed())
+	tt.expectPushToBranch()
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(tt.pr, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+	test.AssertContentToFile(t, "kind: Package\n", filepath.Join(tt.writer.Dir(), tt.eksaDir, "curated-packages.yaml"))
+	kustomization, err := os.ReadFile(filepath.Join(tt.writer.Dir(), tt.eksaDir, "kustomization.yaml"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(kustomization)).To(HaveSuffix("- eksa-cluster.yaml\n- curated-packages.yaml"))
+}
+
+func TestUpdateGitEksaSpecPullRequestPackagesReadError(t *testing.T) {
+	tt := newPullRequestTest(t, &config.CliConfig{GitOpsPackagesFile: filepath.Join(t.TempDir(), "missing.yaml")})
+	tt.git.EXPECT().Clone(tt.ctx).Return(nil)
+	tt.git.EXPECT().Branch(gomock.Any()).Return(nil).Times(2)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(MatchError(ContainSubstring("reading curated packages file")))
+}
+
+func TestUpdateGitEksaSpecPullRequestKeepsPackages(t *testing.T) {
+	tt := newPullRequestTest(t, nil)
+	tt.Expect(os.MkdirAll(filepath.Join(tt.writer.Dir(), tt.eksaDir), 0o755)).To(Succeed())
+	tt.Expect(os.WriteFile(filepath.Join(tt.writer.Dir(), tt.eksaDir, "curated-packages.yaml"), []byte("kind: Package\n"), 0o644)).To(Succeed())
+	tt.expectPushToBranch()
+	tt.git.EXPECT().CreatePullRequest(tt.ctx, gomock.Any()).Return(tt.pr, nil)
+
+	tt.Expect(tt.updateGitEksaSpec()).To(Succeed())
+	kustomization, err := os.ReadFile(filepath.Join(tt.writer.Dir(), tt.eksaDir, "kustomization.yaml"))
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(kustomization)).To(HaveSuffix("- curated-packages.yaml"))
+}
@@ -31,6 +31,12 @@ func (f *Flux) Upgrade(ctx context.Context, managementCluster *types.Cluster, cu
 	if err := f.BootstrapGithub(ctx, managementCluster, newSpec); err != nil {
 		return nil, fmt.Errorf("upgrading Flux components with github provider: %v", err)
 	}
+	if err := f.BootstrapGitlab(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with gitlab provider: %v", err)
+	}
+	if err := f.BootstrapGitea(ctx, managementCluster, newSpec); err != nil {
+		return nil, fmt.Errorf("upgrading Flux components with gitea provider: %v", err)
+	}
 	if err := f.BootstrapGit(ctx, managementCluster, newSpec); err != nil {
 		return nil, fmt.Errorf("upgrading Flux components with git provider: %v", err)
 	}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	configcli "github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 )
 
@@ -53,7 +52,7 @@ func (f *EnvClientFactory) Init(ctx context.Context, r *registrymirror.RegistryM
 	// TODO (cxbrowne): The registry credentials should be injected on construction through environment variables REGISTRY_USERNAME
 	// and REGISTRY_PASSWORD, or passed to this method as arguments.
 	// Issue: https://github.com/aws/eks-anywhere-internal/issues/2115
-	rUsername, rPassword, err := configcli.ReadCredentials()
+	rUsername, rPassword, err := r.ReadCredentials()
 	if err != nil {
 		return err
 	}
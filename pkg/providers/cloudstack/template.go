@@ -219,6 +219,10 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
 		}
+
+		if err := containerd.SetFallbackTemplateValues(values, registryMirror); err != nil {
+			return nil, err
+		}
 	}
 
 	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
@@ -401,6 +405,10 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 		if len(registryMirror.CACertContent) > 0 {
 			values["registryCACert"] = registryMirror.CACertContent
 		}
+
+		if err := containerd.SetFallbackTemplateValues(values, registryMirror); err != nil {
+			return nil, err
+		}
 	}
 
 	if clusterSpec.Cluster.Spec.ProxyConfiguration != nil {
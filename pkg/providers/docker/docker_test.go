@@ -1044,6 +1044,30 @@ func TestDockerGenerateDeploymentFileWithMirrorAndAuthConfig(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_mirror_with_auth_config_md.yaml")
 }
 
+func TestDockerGenerateDeploymentFileWithMirrorFallbacks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Setenv("REGISTRY_USERNAME_1", "username")
+	t.Setenv("REGISTRY_PASSWORD_1", "password")
+	ctx := context.Background()
+	client := dockerMocks.NewMockProviderClient(mockCtrl)
+	kubectl := dockerMocks.NewMockProviderKubectlClient(mockCtrl)
+	provider := docker.NewProvider(&v1alpha1.DockerDatacenterConfig{}, client, kubectl, test.FakeNow)
+	clusterObj := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, "cluster_mirror_with_fallbacks_config.yaml")
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), clusterObj, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_mirror_with_fallbacks_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_mirror_with_fallbacks_config_md.yaml")
+}
+
 func TestTemplateBuilder_CertSANs(t *testing.T) {
 	for _, tc := range []struct {
 		Input  string
@@ -253,6 +253,10 @@ func buildTemplateMapCP(
 			values["registryCACert"] = registryMirror.CACertContent
 		}
 
+		if err := containerd.SetFallbackTemplateValues(values, registryMirror); err != nil {
+			return values, err
+		}
+
 		if registryMirror.Auth {
 			values["registryAuth"] = registryMirror.Auth
 			username, password, err := config.ReadCredentials()
@@ -443,6 +447,10 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 			values["registryCACert"] = registryMirror.CACertContent
 		}
 
+		if err := containerd.SetFallbackTemplateValues(values, registryMirror); err != nil {
+			return values, err
+		}
+
 		if registryMirror.Auth {
 			values["registryAuth"] = registryMirror.Auth
 			username, password, err := config.ReadCredentials()
@@ -301,6 +301,36 @@ func TestNewNutanixTemplateBuilderRegistryMirrorConfig(t *testing.T) {
 	assert.Equal(t, expectedWorkersSpec, workerSpec)
 }
 
+func TestNewNutanixTemplateBuilderRegistryMirrorConfigWithFallbacks(t *testing.T) {
+	t.Setenv(constants.RegistryUsername, "username")
+	t.Setenv(constants.RegistryPassword, "password")
+	dcConf, machineConf, workerConfs := minimalNutanixConfigSpec(t)
+
+	t.Setenv(constants.EksaNutanixUsernameKey, "admin")
+	t.Setenv(constants.EksaNutanixPasswordKey, "password")
+	creds := GetCredsFromEnv()
+	builder := NewNutanixTemplateBuilder(&dcConf.Spec, &machineConf.Spec, &machineConf.Spec, workerConfs, creds, time.Now)
+	assert.NotNil(t, builder)
+
+	buildSpec := test.NewFullClusterSpec(t, "testdata/eksa-cluster-registry-mirror-with-fallbacks.yaml")
+
+	cpSpec, err := builder.GenerateCAPISpecControlPlane(buildSpec)
+	assert.NoError(t, err)
+	assert.NotNil(t, cpSpec)
+	test.AssertContentToFile(t, string(cpSpec), "testdata/expected_results_registry_mirror_with_fallbacks.yaml")
+
+	workloadTemplateNames := map[string]string{
+		"eksa-unit-test": "eksa-unit-test",
+	}
+	kubeadmconfigTemplateNames := map[string]string{
+		"eksa-unit-test": "eksa-unit-test",
+	}
+	workerSpec, err := builder.GenerateCAPISpecWorkers(buildSpec, workloadTemplateNames, kubeadmconfigTemplateNames)
+	assert.NoError(t, err)
+	assert.NotNil(t, workerSpec)
+	test.AssertContentToFile(t, string(workerSpec), "testdata/expected_results_registry_mirror_with_fallbacks_md.yaml")
+}
+
 func TestNewNutanixTemplateBuilderRegistryMirrorConfigNoRegistryCredsSet(t *testing.T) {
 	dcConf, machineConf, workerConfs := minimalNutanixConfigSpec(t)
 
@@ -1,15 +1,22 @@
 package tinkerbell
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
@@ -227,47 +234,7 @@ func selectorsFromClusterSpec(spec *ClusterSpec) (selectorSet, error) {
 // It does not protect against intersections or subsets so consumers should ensure a 1-2-1
 // mapping between catalogue hardware and selectors.
 func MinimumHardwareAvailableAssertionForCreate(catalogue *hardware.Catalogue) ClusterSpecAssertion {
-	return func(spec *ClusterSpec) error {
-		// Without Hardware selectors we get undesirable behavior so ensure we have them for
-		// all MachineConfigs.
-		if err := ensureHardwareSelectorsSpecified(spec); err != nil {
-			return err
-		}
-
-		// Build a set of required hardware counts per machine group. minimumHardwareRequirements
-		// will account for the same selector being specified on different groups.
-		requirements := MinimumHardwareRequirements{}
-
-		err := requirements.Add(
-			spec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-			spec.ControlPlaneConfiguration().Count,
-		)
-		if err != nil {
-			return err
-		}
-
-		for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
-			err := requirements.Add(
-				spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector,
-				*nodeGroup.Count,
-			)
-			if err != nil {
-				return err
-			}
-		}
-
-		if spec.HasExternalEtcd() {
-			err := requirements.Add(
-				spec.ExternalEtcdMachineConfig().Spec.HardwareSelector,
-				spec.ExternalEtcdConfiguration().Count,
-			)
-			if err != nil {
-				return err
-			}
-		}
-
-		return validateMinimumHardwareRequirements(requirements, catalogue)
-	}
+	return HardwareCapacityAssertion(catalogue, nil, nil)
 }
 
 // WorkerNodeHardware holds machine deployment name, replica count and hardware selector for a Tinkerbell worker node.
@@ -369,37 +336,52 @@ func (v *ValidatableTinkerbellCAPI) toK8sVersion(k8sversion string) v1alpha1.Kub
 	return kubeVersion
 }
 
-// AssertionsForScaleUpDown asserts that catalogue has sufficient hardware to
-// support the scaling up/down from current ClusterSpec to desired ValidatableCluster.
-// nolint:gocyclo // TODO: Reduce cyclomatic complexity https://github.com/aws/eks-anywhere-internal/issues/1186
-func AssertionsForScaleUpDown(catalogue *hardware.Catalogue, current ValidatableCluster, rollingUpgrade bool) ClusterSpecAssertion {
-	return func(spec *ClusterSpec) error {
-		// Without Hardware selectors we get undesirable behavior so ensure we have them for
-		// all MachineConfigs.
-		if err := ensureHardwareSelectorsSpecified(spec); err != nil {
-			return err
+// GetValidatableCAPI reads the KubeadmControlPlane and the existing MachineDeployments of cluster
+// as a ValidatableTinkerbellCAPI. The KubeadmControlPlane is nil if it doesn't exist.
+func GetValidatableCAPI(ctx context.Context, c client.Client, cluster *v1alpha1.Cluster) (*ValidatableTinkerbellCAPI, error) {
+	currentKCP, err := controller.GetKubeadmControlPlane(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+	var wgs []*clusterapi.WorkerGroup[*tinkerbellv1.TinkerbellMachineTemplate]
+	for _, wnc := range cluster.Spec.WorkerNodeGroupConfigurations {
+		md := &clusterv1.MachineDeployment{}
+		mdName := clusterapi.MachineDeploymentName(cluster, wnc)
+		key := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: mdName}
+		err := c.Get(ctx, key, md)
+		if err == nil {
+			wgs = append(wgs, &clusterapi.WorkerGroup[*tinkerbellv1.TinkerbellMachineTemplate]{
+				MachineDeployment: md,
+			})
+		} else if !apierrors.IsNotFound(err) {
+			return nil, err
 		}
+	}
+	validatableCAPI := &ValidatableTinkerbellCAPI{
+		KubeadmControlPlane: currentKCP,
+		WorkerGroups:        wgs,
+	}
+	return validatableCAPI, nil
+}
 
+// UpgradeOperationAssertion asserts the upgrade from current to the desired ClusterSpec is supported:
+// clusters with external etcd can't be scaled or upgraded and machines can't be added or removed during
+// a rolling upgrade. The hardware needed by the upgrade is checked by HardwareCapacityAssertion.
+func UpgradeOperationAssertion(current ValidatableCluster, rollingUpgrade bool) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
 		if spec.HasExternalEtcd() {
+			if rollingUpgrade {
+				return fmt.Errorf("external etcd upgrade is not supported")
+			}
 			return fmt.Errorf("scale up/down not supported for external etcd")
 		}
-		// Build a set of required hardware counts per machine group. minimumHardwareRequirements
-		// will account for the same selector being specified on different groups.
-		requirements := MinimumHardwareRequirements{}
+
+		if !rollingUpgrade {
+			return nil
+		}
 
 		if current.ControlPlaneReplicaCount() != spec.Cluster.Spec.ControlPlaneConfiguration.Count {
-			if rollingUpgrade {
-				return fmt.Errorf("cannot perform scale up or down during rolling upgrades")
-			}
-			if current.ControlPlaneReplicaCount() < spec.Cluster.Spec.ControlPlaneConfiguration.Count {
-				err := requirements.Add(
-					spec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-					spec.Cluster.Spec.ControlPlaneConfiguration.Count-current.ControlPlaneReplicaCount(),
-				)
-				if err != nil {
-					return fmt.Errorf("error during scale up: %v", err)
-				}
-			}
+			return fmt.Errorf("cannot perform scale up or down during rolling upgrades")
 		}
 
 		workerNodeHardwareMap := make(map[string]WorkerNodeHardware)
@@ -408,118 +390,17 @@ func AssertionsForScaleUpDown(catalogue *hardware.Catalogue, current Validatable
 		}
 
 		for _, nodeGroupNewSpec := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
-			nodeGroupMachineDeploymentNameNewSpec := machineDeploymentName(spec.Cluster.Name, nodeGroupNewSpec.Name)
-			if workerNodeGroupOldSpec, ok := workerNodeHardwareMap[nodeGroupMachineDeploymentNameNewSpec]; ok {
-				if *nodeGroupNewSpec.Count != workerNodeGroupOldSpec.Replicas {
-					if rollingUpgrade {
-						return fmt.Errorf("cannot perform scale up or down during rolling upgrades")
-					}
-					if *nodeGroupNewSpec.Count > workerNodeGroupOldSpec.Replicas {
-						err := requirements.Add(
-							spec.WorkerNodeGroupMachineConfig(nodeGroupNewSpec).Spec.HardwareSelector,
-							*nodeGroupNewSpec.Count-workerNodeGroupOldSpec.Replicas,
-						)
-						if err != nil {
-							return fmt.Errorf("error during scale up: %v", err)
-						}
-					}
-				}
-			} else { // worker node group was newly added
-				if rollingUpgrade {
-					return fmt.Errorf("cannot perform scale up or down during rolling upgrades")
-				}
-				err := requirements.Add(
-					spec.WorkerNodeGroupMachineConfig(nodeGroupNewSpec).Spec.HardwareSelector,
-					*nodeGroupNewSpec.Count,
-				)
-				if err != nil {
-					return fmt.Errorf("error during scale up: %v", err)
-				}
-			}
-		}
-
-		if err := validateMinimumHardwareRequirements(requirements, catalogue); err != nil {
-			return fmt.Errorf("for scale up, %v", err)
-		}
-		return nil
-	}
-}
-
-// ExtraHardwareAvailableAssertionForRollingUpgrade asserts that catalogue has sufficient hardware to
-// support the ClusterSpec during an rolling upgrade workflow.
-func ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue *hardware.Catalogue, current ValidatableCluster, eksaVersionUpgrade bool) ClusterSpecAssertion {
-	return func(spec *ClusterSpec) error {
-		// Without Hardware selectors we get undesirable behavior so ensure we have them for
-		// all MachineConfigs.
-		if err := ensureHardwareSelectorsSpecified(spec); err != nil {
-			return err
-		}
-
-		// Build a set of required hardware counts per machine group. minimumHardwareRequirements
-		// will account for the same selector being specified on different groups.
-		requirements := MinimumHardwareRequirements{}
-
-		if spec.Cluster.Spec.KubernetesVersion != current.ClusterK8sVersion() || eksaVersionUpgrade {
-			if err := ensureCPHardwareAvailability(spec, requirements); err != nil {
-				return err
+			workerNodeGroupOldSpec, ok := workerNodeHardwareMap[machineDeploymentName(spec.Cluster.Name, nodeGroupNewSpec.Name)]
+			// A newly added worker node group is a scale up.
+			if !ok || *nodeGroupNewSpec.Count != workerNodeGroupOldSpec.Replicas {
+				return fmt.Errorf("cannot perform scale up or down during rolling upgrades")
 			}
 		}
 
-		if err := ensureWorkerHardwareAvailability(spec, current, requirements, eksaVersionUpgrade); err != nil {
-			return err
-		}
-
-		if spec.HasExternalEtcd() {
-			return fmt.Errorf("external etcd upgrade is not supported")
-		}
-
-		if err := validateMinimumHardwareRequirements(requirements, catalogue); err != nil {
-			return fmt.Errorf("for rolling upgrade, %v", err)
-		}
 		return nil
 	}
 }
 
-func ensureCPHardwareAvailability(spec *ClusterSpec, hwReq MinimumHardwareRequirements) error {
-	maxSurge := 1
-
-	rolloutStrategy := spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy
-	if rolloutStrategy != nil && rolloutStrategy.Type == "RollingUpdate" {
-		maxSurge = spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
-	}
-	err := hwReq.Add(
-		spec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-		maxSurge,
-	)
-	if err != nil {
-		return fmt.Errorf("for rolling upgrade, %v", err)
-	}
-	return nil
-}
-
-func ensureWorkerHardwareAvailability(spec *ClusterSpec, current ValidatableCluster, hwReq MinimumHardwareRequirements, eksaVersionUpgrade bool) error {
-	currentWngK8sversion := current.WorkerNodeGroupK8sVersion()
-	desiredWngK8sVersion := WorkerNodeGroupWithK8sVersion(spec.Spec)
-	for _, nodeGroup := range spec.WorkerNodeGroupConfigurations() {
-		maxSurge := 1
-		// As rolling upgrades and scale up/down is not permitted in a single operation, its safe to access directly using the md name.
-		mdName := fmt.Sprintf("%s-%s", spec.Cluster.Name, nodeGroup.Name)
-		if currentWngK8sversion[mdName] != desiredWngK8sVersion[mdName] || eksaVersionUpgrade {
-			if nodeGroup.UpgradeRolloutStrategy != nil && nodeGroup.UpgradeRolloutStrategy.Type == "RollingUpdate" {
-				maxSurge = nodeGroup.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
-			}
-			err := hwReq.Add(
-				spec.WorkerNodeGroupMachineConfig(nodeGroup).Spec.HardwareSelector,
-				maxSurge,
-			)
-			if err != nil {
-				return fmt.Errorf("for rolling upgrade, %v", err)
-			}
-		}
-	}
-	return nil
-}
-
 // ensureHardwareSelectorsSpecified ensures each machine config present in spec has a hardware
 // selector.
 func ensureHardwareSelectorsSpecified(spec *ClusterSpec) error {
@@ -548,17 +429,6 @@ func ensureHardwareSelectorsSpecified(spec *ClusterSpec) error {
 	return nil
 }
 
-// ExtraHardwareAvailableAssertionForNodeRollOut asserts catalogue has sufficient hardware to meet minimum requirement
-// and is component agnostic between Control Plane and worker nodes.
-func ExtraHardwareAvailableAssertionForNodeRollOut(catalogue *hardware.Catalogue, hwReq MinimumHardwareRequirements) ClusterSpecAssertion {
-	return func(_ *ClusterSpec) error {
-		if err := validateMinimumHardwareRequirements(hwReq, catalogue); err != nil {
-			return fmt.Errorf("for node rollout, %v", err)
-		}
-		return nil
-	}
-}
-
 type missingHardwareSelectorErr struct {
 	Name string
 }
@@ -802,38 +802,30 @@ func TestValidateTinkerbellCAPIWorkerNodeK8sVersion(t *testing.T) {
 	g.Expect(wngK8sVersion[mdName]).To(gomega.Equal(kube121))
 }
 
-func TestAssertionsForScaleUpDown_Success(t *testing.T) {
+func TestUpgradeOperationAssertion_Success(t *testing.T) {
 	g := gomega.NewWithT(t)
 
-	catalogue := hardware.NewCatalogue()
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 
-	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, true)
+	assertion := tinkerbell.UpgradeOperationAssertion(&tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, true)
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForScaleUpDown_CAPISuccess(t *testing.T) {
+func TestUpgradeOperationAssertion_CAPISuccess(t *testing.T) {
 	g := gomega.NewWithT(t)
 
-	catalogue := hardware.NewCatalogue()
-	tinkerbellCAPI := validatableTinkerbellCAPI()
-
-	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, tinkerbellCAPI, false)
+	assertion := tinkerbell.UpgradeOperationAssertion(validatableTinkerbellCAPI(), false)
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 
-	check := &tinkerbell.ValidatableTinkerbellClusterSpec{newClusterSpec}
-	t.Log(tinkerbellCAPI.WorkerNodeHardwareGroups()[0].MachineDeploymentName)
-	t.Log(check.WorkerNodeHardwareGroups()[0].MachineDeploymentName)
-
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForScaleUpDown_ScaleUpControlPlaneSuccess(t *testing.T) {
+func TestHardwareCapacityAssertion_ScaleUpControlPlaneSuccess(t *testing.T) {
 	g := gomega.NewWithT(t)
 
 	catalogue := hardware.NewCatalogue()
@@ -843,7 +835,7 @@ func TestAssertionsForScaleUpDown_ScaleUpControlPlaneSuccess(t *testing.T) {
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 
-	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, nil)
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 2
@@ -851,7 +843,22 @@ func TestAssertionsForScaleUpDown_ScaleUpControlPlaneSuccess(t *testing.T) {
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForScaleUpDown_ScaleUpWorkerSuccess(t *testing.T) {
+func TestHardwareCapacityAssertion_ScaleUpControlPlaneError(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, nil)
+	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 2
+
+	g.Expect(assertion(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("minimum hardware count not met for selector '{\"type\":\"cp\"}'")))
+}
+
+func TestHardwareCapacityAssertion_ScaleUpWorkerSuccess(t *testing.T) {
 	g := gomega.NewWithT(t)
 
 	catalogue := hardware.NewCatalogue()
@@ -861,7 +868,7 @@ func TestAssertionsForScaleUpDown_ScaleUpWorkerSuccess(t *testing.T) {
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 
-	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, nil)
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
@@ -869,7 +876,7 @@ func TestAssertionsForScaleUpDown_ScaleUpWorkerSuccess(t *testing.T) {
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForScaleUpDown_AddWorkerSuccess(t *testing.T) {
+func TestHardwareCapacityAssertion_AddWorkerSuccess(t *testing.T) {
 	g := gomega.NewWithT(t)
 
 	catalogue := hardware.NewCatalogue()
@@ -880,14 +887,14 @@ func TestAssertionsForScaleUpDown_AddWorkerSuccess(t *testing.T) {
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	clusterSpec.Spec.Cluster.Spec.WorkerNodeGroupConfigurations = []eksav1alpha1.WorkerNodeGroupConfiguration{}
 
-	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, nil)
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForRollingUpgrade_CPOnly(t *testing.T) {
+func TestHardwareCapacityAssertion_RollingUpgradeCPOnly(t *testing.T) {
 	g := gomega.NewWithT(t)
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
@@ -899,15 +906,16 @@ func TestAssertionsForRollingUpgrade_CPOnly(t *testing.T) {
 
 	kube124 := eksav1alpha1.Kube124
 	clusterSpec.WorkerNodeGroupConfigurations()[0].KubernetesVersion = &kube124
-	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	current := &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.WorkerNodeGroupConfigurations()[0].KubernetesVersion = &kube124
 	newClusterSpec.Cluster.Spec.KubernetesVersion = eksav1alpha1.Kube125
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, current, tinkerbell.KubernetesVersionRolledOutGroups(newClusterSpec.Spec, current))
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForRollingUpgrade_WorkerOnly(t *testing.T) {
+func TestHardwareCapacityAssertion_RollingUpgradeWorkerOnly(t *testing.T) {
 	g := gomega.NewWithT(t)
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
@@ -920,15 +928,16 @@ func TestAssertionsForRollingUpgrade_WorkerOnly(t *testing.T) {
 
 	kube125 := eksav1alpha1.Kube125
 	clusterSpec.WorkerNodeGroupConfigurations()[0].KubernetesVersion = &kube124
-	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	current := &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.Cluster.Spec.KubernetesVersion = kube124
 	newClusterSpec.WorkerNodeGroupConfigurations()[0].KubernetesVersion = &kube125
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, current, tinkerbell.KubernetesVersionRolledOutGroups(newClusterSpec.Spec, current))
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForRollingUpgrade_BothCPWorker(t *testing.T) {
+func TestHardwareCapacityAssertion_RollingUpgradeBothCPWorker(t *testing.T) {
 	g := gomega.NewWithT(t)
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
@@ -942,15 +951,16 @@ func TestAssertionsForRollingUpgrade_BothCPWorker(t *testing.T) {
 		Labels: map[string]string{"type": "worker"},
 	}})
 
-	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	current := &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	kube125 := eksav1alpha1.Kube125
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.Cluster.Spec.KubernetesVersion = kube125
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, current, tinkerbell.KubernetesVersionRolledOutGroups(newClusterSpec.Spec, current))
 	g.Expect(assertion(newClusterSpec)).To(gomega.Succeed())
 }
 
-func TestAssertionsForRollingUpgrade_CPError(t *testing.T) {
+func TestHardwareCapacityAssertion_RollingUpgradeCPError(t *testing.T) {
 	g := gomega.NewWithT(t)
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
@@ -958,15 +968,16 @@ func TestAssertionsForRollingUpgrade_CPError(t *testing.T) {
 	clusterSpec.Cluster.Spec.KubernetesVersion = kube124
 	catalogue := hardware.NewCatalogue()
 
-	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	current := &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.WorkerNodeGroupConfigurations()[0].KubernetesVersion = &kube124
 	newClusterSpec.Cluster.Spec.KubernetesVersion = eksav1alpha1.Kube125
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, current, tinkerbell.KubernetesVersionRolledOutGroups(newClusterSpec.Spec, current))
 	g.Expect(assertion(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("minimum hardware count not met for selector '{\"type\":\"cp\"}'")))
 }
 
-func TestAssertionsForRollingUpgrade_WorkerError(t *testing.T) {
+func TestHardwareCapacityAssertion_RollingUpgradeWorkerError(t *testing.T) {
 	g := gomega.NewWithT(t)
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
@@ -976,38 +987,50 @@ func TestAssertionsForRollingUpgrade_WorkerError(t *testing.T) {
 	clusterSpec.WorkerNodeGroupConfigurations()[0].KubernetesVersion = &kube124
 	catalogue := hardware.NewCatalogue()
 
-	assertion := tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, false)
+	current := &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.WorkerNodeGroupConfigurations()[0].KubernetesVersion = &kube125
 	newClusterSpec.Cluster.Spec.KubernetesVersion = kube125
+	assertion := tinkerbell.HardwareCapacityAssertion(catalogue, current, tinkerbell.KubernetesVersionRolledOutGroups(newClusterSpec.Spec, current))
 	g.Expect(assertion(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("minimum hardware count not met for selector '{\"type\":\"worker\"}'")))
 }
 
-func TestAssertionsForScaleUpDown_ExternalEtcdErrorFails(t *testing.T) {
+func TestUpgradeOperationAssertion_ExternalEtcdErrorFails(t *testing.T) {
 	g := gomega.NewWithT(t)
 
-	catalogue := hardware.NewCatalogue()
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
-
-	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, true)
+	current := &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 
-	g.Expect(assertion(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("scale up/down not supported for external etcd")))
+	g.Expect(tinkerbell.UpgradeOperationAssertion(current, false)(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("scale up/down not supported for external etcd")))
+	g.Expect(tinkerbell.UpgradeOperationAssertion(current, true)(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("external etcd upgrade is not supported")))
 }
 
-func TestAssertionsForScaleUpDown_FailsScaleUpAndRollingError(t *testing.T) {
+func TestUpgradeOperationAssertion_FailsScaleUpAndRollingError(t *testing.T) {
 	g := gomega.NewWithT(t)
 
-	catalogue := hardware.NewCatalogue()
 	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 
-	assertion := tinkerbell.AssertionsForScaleUpDown(catalogue, &tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, true)
+	assertion := tinkerbell.UpgradeOperationAssertion(&tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, true)
 	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
 	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
 	newClusterSpec.WorkerNodeGroupConfigurations()[0].Count = ptr.Int(2)
-	g.Expect(assertion(newClusterSpec)).NotTo(gomega.Succeed())
+	g.Expect(assertion(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("cannot perform scale up or down during rolling upgrades")))
+}
+
+func TestUpgradeOperationAssertion_FailsAddWorkerAndRollingError(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	clusterSpec.Spec.Cluster.Spec.WorkerNodeGroupConfigurations = []eksav1alpha1.WorkerNodeGroupConfiguration{}
+
+	assertion := tinkerbell.UpgradeOperationAssertion(&tinkerbell.ValidatableTinkerbellClusterSpec{clusterSpec}, true)
+	newClusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	newClusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	g.Expect(assertion(newClusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("cannot perform scale up or down during rolling upgrades")))
 }
 
 func TestHardwareSatisfiesOnlyOneSelectorAssertion_MeetsOnlyOneSelector(t *testing.T) {
@@ -1127,48 +1150,6 @@ func TestAssertAutoScalerDisabledForInPlace(t *testing.T) {
 	g.Expect(tinkerbell.AssertAutoScalerDisabledForInPlace(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("austoscaler configuration not supported with InPlace")))
 }
 
-func TestAssertExtraHardwareAvailableAssertionForNodeRollOutSuccess(t *testing.T) {
-	g := gomega.NewWithT(t)
-	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
-	req := tinkerbell.MinimumHardwareRequirements{}
-	err := req.Add(map[string]string{"type": "cp"}, 1)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	catalogue := hardware.NewCatalogue()
-	// Add something for the control plane.
-	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
-		ObjectMeta: v1.ObjectMeta{
-			Labels: clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-		},
-	})).To(gomega.Succeed())
-
-	assertion := tinkerbell.ExtraHardwareAvailableAssertionForNodeRollOut(catalogue, req)
-	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
-}
-
-func TestAssertExtraHardwareAvailableAssertionForNodeRollOutError(t *testing.T) {
-	g := gomega.NewWithT(t)
-	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
-	req := tinkerbell.MinimumHardwareRequirements{}
-	err := req.Add(map[string]string{"type": "cp"}, 2)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	catalogue := hardware.NewCatalogue()
-	// Add something for the control plane.
-	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
-		ObjectMeta: v1.ObjectMeta{
-			Labels: clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector,
-		},
-	})).To(gomega.Succeed())
-
-	assertion := tinkerbell.ExtraHardwareAvailableAssertionForNodeRollOut(catalogue, req)
-	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("minimum hardware count not met for selector '{\"type\":\"cp\"}'")))
-}
-
 // mergeHardwareSelectors merges m1 with m2. Values already in m1 will be overwritten by m2.
 func mergeHardwareSelectors(m1, m2 map[string]string) map[string]string {
 	for name, value := range m2 {
@@ -0,0 +1,234 @@
+package tinkerbell
+
+import (
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// HardwareCapacity compares the free hardware matching a hardware selector with the hardware needed by the
+// machine groups using it.
+type HardwareCapacity struct {
+	// Selector is the hardware selector of the TinkerbellMachineConfigs.
+	Selector v1alpha1.HardwareSelector `json:"selector"`
+	// MachineConfigs are the names of the TinkerbellMachineConfigs with the selector.
+	MachineConfigs []string `json:"machineConfigs"`
+	// Groups are the control plane, etcd and worker node groups using the machine configs.
+	Groups []string `json:"groups"`
+	// Free is the number of unprovisioned hardware matching the selector.
+	Free int `json:"free"`
+	// Required is the number of hardware needed for new machines when creating or scaling up the cluster.
+	Required int `json:"required"`
+	// Surge is the number of extra hardware needed to replace machines during a rolling upgrade.
+	Surge int `json:"surge"`
+}
+
+// Shortfall returns the number of hardware missing to create the new machines and roll out all the groups.
+func (c HardwareCapacity) Shortfall() int {
+	if missing := c.Required + c.Surge - c.Free; missing > 0 {
+		return missing
+	}
+	return 0
+}
+
+// RolledOutGroups reports whether the machines of a control plane, etcd or worker node group, named as
+// in HardwareCapacity.Groups, are replaced by an operation.
+type RolledOutGroups func(group string) bool
+
+// AllGroupsRolledOut is the RolledOutGroups of an operation that replaces the machines of all the groups.
+func AllGroupsRolledOut(string) bool {
+	return true
+}
+
+// KubernetesVersionRolledOutGroups returns the groups rolled out when upgrading the current cluster to the
+// Kubernetes versions in spec.
+func KubernetesVersionRolledOutGroups(spec *cluster.Spec, current ValidatableCluster) RolledOutGroups {
+	groups := map[string]bool{
+		hardware.ControlPlaneMachineGroup: spec.Cluster.Spec.KubernetesVersion != current.ClusterK8sVersion(),
+	}
+
+	currentVersions := current.WorkerNodeGroupK8sVersion()
+	desiredVersions := WorkerNodeGroupWithK8sVersion(spec)
+	for _, group := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		name := machineDeploymentName(spec.Cluster.Name, group.Name)
+		if version, ok := currentVersions[name]; ok && version != desiredVersions[name] {
+			groups[group.Name] = true
+		}
+	}
+
+	return func(group string) bool {
+		return groups[group]
+	}
+}
+
+// CalculateHardwareCapacity calculates the HardwareCapacity of each hardware selector in spec using the
+// hardware in catalogue as the free hardware. current describes the machines of an existing cluster and
+// must be nil for new clusters. For existing clusters, the surge of every group with a rolling upgrade
+// strategy is included as any rolling upgrade can replace all the group machines.
+func CalculateHardwareCapacity(spec *ClusterSpec, catalogue *hardware.Catalogue, current ValidatableCluster) ([]HardwareCapacity, error) {
+	return calculateHardwareCapacity(spec, catalogue, current, AllGroupsRolledOut)
+}
+
+func calculateHardwareCapacity(spec *ClusterSpec, catalogue *hardware.Catalogue, current ValidatableCluster, rolledOut RolledOutGroups) ([]HardwareCapacity, error) {
+	var capacity []HardwareCapacity
+	index := map[string]int{}
+
+	add := func(group string, machineConfig *v1alpha1.TinkerbellMachineConfig, required, surge int) error {
+		key, err := machineConfig.Spec.HardwareSelector.ToString()
+		if err != nil {
+			return err
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(capacity)
+			index[key] = i
+			capacity = append(capacity, HardwareCapacity{Selector: machineConfig.Spec.HardwareSelector})
+		}
+
+		c := &capacity[i]
+		if !contains(c.MachineConfigs, machineConfig.Name) {
+			c.MachineConfigs = append(c.MachineConfigs, machineConfig.Name)
+		}
+		c.Groups = append(c.Groups, group)
+		c.Required += required
+		if rolledOut != nil && rolledOut(group) {
+			c.Surge += surge
+		}
+
+		return nil
+	}
+
+	cpCount := spec.ControlPlaneConfiguration().Count
+	cpRequired, cpSurge := cpCount, 0
+	if current != nil {
+		cpRequired = scaleUp(current.ControlPlaneReplicaCount(), cpCount)
+		cpSurge = controlPlaneSurge(spec.ControlPlaneConfiguration().UpgradeRolloutStrategy)
+	}
+	if err := add(hardware.ControlPlaneMachineGroup, spec.ControlPlaneMachineConfig(), cpRequired, cpSurge); err != nil {
+		return nil, err
+	}
+
+	// External etcd can't be scaled or upgraded, so existing clusters don't need more etcd hardware.
+	if spec.HasExternalEtcd() {
+		etcdRequired := 0
+		if current == nil {
+			etcdRequired = spec.ExternalEtcdConfiguration().Count
+		}
+		if err := add(hardware.EtcdMachineGroup, spec.ExternalEtcdMachineConfig(), etcdRequired, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	currentWorkers := map[string]int{}
+	if current != nil {
+		for _, w := range current.WorkerNodeHardwareGroups() {
+			currentWorkers[w.MachineDeploymentName] = w.Replicas
+		}
+	}
+
+	for _, group := range spec.WorkerNodeGroupConfigurations() {
+		count := 0
+		if group.Count != nil {
+			count = *group.Count
+		}
+
+		required, surge := count, 0
+		if replicas, ok := currentWorkers[machineDeploymentName(spec.Cluster.Name, group.Name)]; ok {
+			required = scaleUp(replicas, count)
+			surge = workerSurge(group.UpgradeRolloutStrategy)
+		}
+
+		if err := add(group.Name, spec.WorkerNodeGroupMachineConfig(group), required, surge); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range capacity {
+		capacity[i].Free = catalogue.TotalHardwareMatching(capacity[i].Selector)
+	}
+
+	return capacity, nil
+}
+
+// HardwareCapacityAssertion asserts catalogue has enough hardware for the new machines of the cluster and
+// to roll out the groups selected by rolledOut, which can be nil when no machine is replaced. Groups
+// sharing a hardware selector are checked together. current must be nil for new clusters.
+func HardwareCapacityAssertion(catalogue *hardware.Catalogue, current ValidatableCluster, rolledOut RolledOutGroups) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		// Without Hardware selectors we get undesirable behavior so ensure we have them for
+		// all MachineConfigs.
+		if err := ensureHardwareSelectorsSpecified(spec); err != nil {
+			return err
+		}
+
+		capacity, err := calculateHardwareCapacity(spec, catalogue, current, rolledOut)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range capacity {
+			if required := c.Required + c.Surge; c.Free < required {
+				selector, err := c.Selector.ToString()
+				if err != nil {
+					return err
+				}
+				return fmt.Errorf("minimum hardware count not met for selector '%v': have %v, require %v", selector, c.Free, required)
+			}
+		}
+
+		return nil
+	}
+}
+
+func scaleUp(current, desired int) int {
+	if desired > current {
+		return desired - current
+	}
+	return 0
+}
+
+func controlPlaneSurge(strategy *v1alpha1.ControlPlaneUpgradeRolloutStrategy) int {
+	if strategy == nil {
+		return 1
+	}
+
+	switch strategy.Type {
+	case v1alpha1.InPlaceStrategyType:
+		return 0
+	case v1alpha1.RollingUpdateStrategyType:
+		if strategy.RollingUpdate != nil {
+			return strategy.RollingUpdate.MaxSurge
+		}
+	}
+
+	return 1
+}
+
+func workerSurge(strategy *v1alpha1.WorkerNodesUpgradeRolloutStrategy) int {
+	if strategy == nil {
+		return 1
+	}
+
+	switch strategy.Type {
+	case v1alpha1.InPlaceStrategyType:
+		return 0
+	case v1alpha1.RollingUpdateStrategyType:
+		if strategy.RollingUpdate != nil {
+			return strategy.RollingUpdate.MaxSurge
+		}
+	}
+
+	return 1
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package tinkerbell_test
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eksav1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestCalculateHardwareCapacityForCreate(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(2)
+
+	catalogue := hardware.NewCatalogue()
+	insertHardwareWithLabels(g, catalogue, "cp-1", map[string]string{"type": "cp"})
+	insertHardwareWithLabels(g, catalogue, "cp-2", map[string]string{"type": "cp"})
+	insertHardwareWithLabels(g, catalogue, "etcd-1", map[string]string{"type": "etcd"})
+	insertHardwareWithLabels(g, catalogue, "worker-1", map[string]string{"type": "worker"})
+	insertHardwareWithLabels(g, catalogue, "worker-2", map[string]string{"type": "worker"})
+	insertHardwareWithLabels(g, catalogue, "worker-3", map[string]string{"type": "worker"})
+
+	capacity, err := tinkerbell.CalculateHardwareCapacity(clusterSpec, catalogue, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(capacity).To(gomega.Equal([]tinkerbell.HardwareCapacity{
+		{
+			Selector:       eksav1alpha1.HardwareSelector{"type": "cp"},
+			MachineConfigs: []string{"control-plane"},
+			Groups:         []string{hardware.ControlPlaneMachineGroup},
+			Free:           2,
+			Required:       3,
+		},
+		{
+			Selector:       eksav1alpha1.HardwareSelector{"type": "etcd"},
+			MachineConfigs: []string{"external-etcd"},
+			Groups:         []string{hardware.EtcdMachineGroup},
+			Free:           1,
+			Required:       1,
+		},
+		{
+			Selector:       eksav1alpha1.HardwareSelector{"type": "worker"},
+			MachineConfigs: []string{"worker-node-group"},
+			Groups:         []string{"worker-node-group-0"},
+			Free:           3,
+			Required:       2,
+		},
+	}))
+	g.Expect(capacity[0].Shortfall()).To(gomega.Equal(1))
+	g.Expect(capacity[2].Shortfall()).To(gomega.Equal(0))
+}
+
+func TestCalculateHardwareCapacitySharedSelector(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	clusterSpec.MachineConfigs["worker-node-group"].Spec.HardwareSelector = eksav1alpha1.HardwareSelector{"type": "cp"}
+
+	catalogue := hardware.NewCatalogue()
+	insertHardwareWithLabels(g, catalogue, "node-1", map[string]string{"type": "cp"})
+
+	capacity, err := tinkerbell.CalculateHardwareCapacity(clusterSpec, catalogue, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(capacity).To(gomega.HaveLen(1))
+	g.Expect(capacity[0].MachineConfigs).To(gomega.Equal([]string{"control-plane", "worker-node-group"}))
+	g.Expect(capacity[0].Groups).To(gomega.Equal([]string{hardware.ControlPlaneMachineGroup, "worker-node-group-0"}))
+	g.Expect(capacity[0].Required).To(gomega.Equal(2))
+	g.Expect(capacity[0].Shortfall()).To(gomega.Equal(1))
+}
+
+func TestCalculateHardwareCapacityForExistingCluster(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &eksav1alpha1.ControlPlaneUpgradeRolloutStrategy{
+		Type:          eksav1alpha1.RollingUpdateStrategyType,
+		RollingUpdate: &eksav1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 2},
+	}
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations = append(clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations,
+		eksav1alpha1.WorkerNodeGroupConfiguration{
+			Name:  "worker-node-group-1",
+			Count: ptr.Int(2),
+			MachineGroupRef: &eksav1alpha1.Ref{
+				Kind: eksav1alpha1.TinkerbellMachineConfigKind,
+				Name: "worker-node-group",
+			},
+		},
+	)
+
+	catalogue := hardware.NewCatalogue()
+	insertHardwareWithLabels(g, catalogue, "cp-1", map[string]string{"type": "cp"})
+	insertHardwareWithLabels(g, catalogue, "worker-1", map[string]string{"type": "worker"})
+
+	capacity, err := tinkerbell.CalculateHardwareCapacity(clusterSpec, catalogue, validatableTinkerbellCAPI())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(capacity).To(gomega.HaveLen(3))
+
+	// Scale up from 1 to 3 replicas plus a surge of 2.
+	g.Expect(capacity[0].Required).To(gomega.Equal(2))
+	g.Expect(capacity[0].Surge).To(gomega.Equal(2))
+	g.Expect(capacity[0].Shortfall()).To(gomega.Equal(3))
+
+	// External etcd isn't scaled or upgraded.
+	g.Expect(capacity[1].Required).To(gomega.Equal(0))
+	g.Expect(capacity[1].Surge).To(gomega.Equal(0))
+
+	// The existing worker node group only needs the default surge, the new one all its replicas.
+	g.Expect(capacity[2].Groups).To(gomega.Equal([]string{"worker-node-group-0", "worker-node-group-1"}))
+	g.Expect(capacity[2].Required).To(gomega.Equal(2))
+	g.Expect(capacity[2].Surge).To(gomega.Equal(1))
+	g.Expect(capacity[2].Shortfall()).To(gomega.Equal(2))
+}
+
+func TestCalculateHardwareCapacityInPlaceHasNoSurge(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &eksav1alpha1.ControlPlaneUpgradeRolloutStrategy{
+		Type: eksav1alpha1.InPlaceStrategyType,
+	}
+	clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &eksav1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type: eksav1alpha1.InPlaceStrategyType,
+	}
+
+	capacity, err := tinkerbell.CalculateHardwareCapacity(clusterSpec, hardware.NewCatalogue(), validatableTinkerbellCAPI())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	for _, c := range capacity {
+		g.Expect(c.Shortfall()).To(gomega.Equal(0))
+	}
+}
+
+func TestHardwareCapacityAssertionIncludeSurge(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	insertHardwareWithLabels(g, catalogue, "worker-1", map[string]string{"type": "worker"})
+
+	current := validatableTinkerbellCAPI()
+	g.Expect(tinkerbell.HardwareCapacityAssertion(catalogue, current, nil)(clusterSpec)).To(gomega.Succeed())
+	g.Expect(tinkerbell.HardwareCapacityAssertion(catalogue, current, tinkerbell.AllGroupsRolledOut)(clusterSpec)).To(
+		gomega.MatchError(gomega.ContainSubstring("minimum hardware count not met for selector '{\"type\":\"cp\"}': have 0, require 1")),
+	)
+}
+
+func TestHardwareCapacityAssertionOnlyRolledOutGroupsSurge(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+
+	catalogue := hardware.NewCatalogue()
+	insertHardwareWithLabels(g, catalogue, "worker-1", map[string]string{"type": "worker"})
+
+	workersOnly := func(group string) bool { return group == "worker-node-group-0" }
+	g.Expect(tinkerbell.HardwareCapacityAssertion(catalogue, validatableTinkerbellCAPI(), workersOnly)(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestHardwareCapacityAssertionScaleUpAndRollOutSharedHardware(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// Scaling up the control plane and rolling it out need 2 machines, each one alone only needs 1.
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count = 2
+
+	catalogue := hardware.NewCatalogue()
+	insertHardwareWithLabels(g, catalogue, "cp-1", map[string]string{"type": "cp"})
+
+	controlPlaneOnly := func(group string) bool { return group == hardware.ControlPlaneMachineGroup }
+	g.Expect(tinkerbell.HardwareCapacityAssertion(catalogue, validatableTinkerbellCAPI(), controlPlaneOnly)(clusterSpec)).To(
+		gomega.MatchError(gomega.ContainSubstring("minimum hardware count not met for selector '{\"type\":\"cp\"}': have 1, require 2")),
+	)
+}
+
+func TestKubernetesVersionRolledOutGroups(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	kube128 := eksav1alpha1.Kube128
+	current := NewDefaultValidClusterSpecBuilder().Build()
+	current.Cluster.Spec.KubernetesVersion = kube128
+	current.Cluster.Spec.WorkerNodeGroupConfigurations = append(current.Cluster.Spec.WorkerNodeGroupConfigurations,
+		eksav1alpha1.WorkerNodeGroupConfiguration{
+			Name:              "worker-node-group-1",
+			Count:             ptr.Int(1),
+			KubernetesVersion: &kube128,
+			MachineGroupRef: &eksav1alpha1.Ref{
+				Kind: eksav1alpha1.TinkerbellMachineConfigKind,
+				Name: "worker-node-group",
+			},
+		},
+	)
+
+	desired := current.Spec.DeepCopy()
+	desired.Cluster.Spec.KubernetesVersion = eksav1alpha1.Kube129
+
+	rolledOut := tinkerbell.KubernetesVersionRolledOutGroups(desired, &tinkerbell.ValidatableTinkerbellClusterSpec{ClusterSpec: current})
+	g.Expect(rolledOut(hardware.ControlPlaneMachineGroup)).To(gomega.BeTrue())
+	g.Expect(rolledOut("worker-node-group-0")).To(gomega.BeTrue())
+	// The group with its own Kubernetes version isn't upgraded.
+	g.Expect(rolledOut("worker-node-group-1")).To(gomega.BeFalse())
+}
+
+func TestHardwareCapacityAssertionMissingSelector(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	builder := NewDefaultValidClusterSpecBuilder()
+	builder.WithoutHardwareSelectors()
+	clusterSpec := builder.Build()
+
+	g.Expect(tinkerbell.HardwareCapacityAssertion(hardware.NewCatalogue(), nil, nil)(clusterSpec)).ToNot(gomega.Succeed())
+}
+
+func insertHardwareWithLabels(g *gomega.WithT, catalogue *hardware.Catalogue, name string, labels map[string]string) {
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	})).To(gomega.Succeed())
+}
@@ -103,6 +103,17 @@ func (c *Catalogue) TotalHardware() int {
 	return len(c.hardware)
 }
 
+// TotalHardwareMatching returns the number of catalogued hardware with labels matching selector.
+func (c *Catalogue) TotalHardwareMatching(selector eksav1alpha1.HardwareSelector) int {
+	total := 0
+	for _, h := range c.hardware {
+		if LabelsMatchSelector(selector, h.Labels) {
+			total++
+		}
+	}
+	return total
+}
+
 const HardwareIDIndex = ".Spec.Metadata.Instance.ID"
 
 // WithHardwareIDIndex creates a Hardware index using HardwareIDIndex on .Spec.Metadata.Instance.ID
@@ -280,3 +280,23 @@ func TestHardwareCatalogueWriter_Write(t *testing.T) {
 	g.Expect(hardware).To(gomega.HaveLen(1))
 	g.Expect(hardware[0].Name).To(gomega.Equal(machine.Hostname))
 }
+
+func TestCatalogue_Hardware_TotalHardwareMatching(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	catalogue := hardware.NewCatalogue()
+	for name, labels := range map[string]map[string]string{
+		"cp-1":     {"type": "cp"},
+		"cp-2":     {"type": "cp", "rack": "1"},
+		"worker-1": {"type": "worker"},
+	} {
+		err := catalogue.InsertHardware(&v1alpha1.Hardware{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels},
+		})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}
+
+	g.Expect(catalogue.TotalHardwareMatching(map[string]string{"type": "cp"})).To(gomega.Equal(2))
+	g.Expect(catalogue.TotalHardwareMatching(map[string]string{"type": "cp", "rack": "1"})).To(gomega.Equal(1))
+	g.Expect(catalogue.TotalHardwareMatching(map[string]string{"type": "etcd"})).To(gomega.Equal(0))
+}
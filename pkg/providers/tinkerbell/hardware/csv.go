@@ -98,20 +98,24 @@ func ensureRequiredColumnsInCSV(unmatched []string) error {
 	return nil
 }
 
-// BuildHardwareYAML builds a hardware yaml from the csv at the provided path.
+// BuildHardwareYAML builds a hardware yaml from the csv or inventory at the provided path.
 func BuildHardwareYAML(path string, opts *BMCOptions) ([]byte, error) {
-	reader, err := NewNormalizedCSVReaderFromFile(path, opts)
+	reader, err := NewNormalizedReaderFromFile(path, opts)
 	if err != nil {
-		return nil, fmt.Errorf("reading csv: %v", err)
+		return nil, fmt.Errorf("reading hardware: %v", err)
 	}
 
+	return BuildHardwareYAMLFromReader(reader)
+}
+
+// BuildHardwareYAMLFromReader builds a hardware yaml from the machines read from reader.
+func BuildHardwareYAMLFromReader(reader MachineReader) ([]byte, error) {
 	var b bytes.Buffer
 	writer := NewTinkerbellManifestYAML(&b)
 
 	validator := NewDefaultMachineValidator()
 
-	err = TranslateAll(reader, writer, validator)
-	if err != nil {
+	if err := TranslateAll(reader, writer, validator); err != nil {
 		return nil, fmt.Errorf("generating hardware yaml: %v", err)
 	}
 
@@ -0,0 +1,129 @@
+package hardware
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Inventory is a JSON or YAML description of machines. Unlike the CSV format, it can express
+// BMCOptions for each machine.
+type Inventory struct {
+	Machines []InventoryMachine `json:"machines"`
+}
+
+// InventoryMachine is a single machine in an Inventory.
+type InventoryMachine struct {
+	Hostname    string            `json:"hostname,omitempty"`
+	IPAddress   string            `json:"ipAddress,omitempty"`
+	Netmask     string            `json:"netmask,omitempty"`
+	Gateway     string            `json:"gateway,omitempty"`
+	Nameservers []string          `json:"nameservers,omitempty"`
+	MACAddress  string            `json:"mac,omitempty"`
+	Disk        string            `json:"disk,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	VLANID      string            `json:"vlanID,omitempty"`
+	BMC         *InventoryBMC     `json:"bmc,omitempty"`
+}
+
+// InventoryBMC is the BMC configuration of an InventoryMachine.
+type InventoryBMC struct {
+	IPAddress string `json:"ipAddress"`
+	Username  string `json:"username,omitempty"`
+	Password  string `json:"password,omitempty"`
+	// Options for the Rufio providers. When unset, the InventoryReader options are used.
+	Options *BMCOptions `json:"options,omitempty"`
+}
+
+// InventoryReader reads an Inventory and provides Machine instances. It satisfies the MachineReader interface.
+type InventoryReader struct {
+	machines []InventoryMachine
+	next     int
+	// BMCOptions used in machines that don't specify their own.
+	BMCOptions *BMCOptions
+}
+
+// NewInventoryReader returns a new InventoryReader instance that consumes JSON or YAML inventory data from r.
+func NewInventoryReader(r io.Reader, opts *BMCOptions) (*InventoryReader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var inventory Inventory
+	if err := yaml.UnmarshalStrict(data, &inventory); err != nil {
+		return nil, fmt.Errorf("parsing inventory: %v", err)
+	}
+
+	return &InventoryReader{machines: inventory.Machines, BMCOptions: opts}, nil
+}
+
+// Read returns the next Machine in the inventory. It returns io.EOF when all machines have been read.
+func (ir *InventoryReader) Read() (Machine, error) {
+	if ir.next >= len(ir.machines) {
+		return Machine{}, io.EOF
+	}
+
+	im := ir.machines[ir.next]
+	ir.next++
+
+	m := Machine{
+		Hostname:    im.Hostname,
+		IPAddress:   im.IPAddress,
+		Netmask:     im.Netmask,
+		Gateway:     im.Gateway,
+		Nameservers: im.Nameservers,
+		MACAddress:  im.MACAddress,
+		Disk:        im.Disk,
+		Labels:      make(Labels, len(im.Labels)),
+		VLANID:      im.VLANID,
+	}
+	for k, v := range im.Labels {
+		m.Labels[k] = v
+	}
+
+	if im.BMC != nil {
+		m.BMCIPAddress = im.BMC.IPAddress
+		m.BMCUsername = im.BMC.Username
+		m.BMCPassword = im.BMC.Password
+		m.BMCOptions = im.BMC.Options
+	}
+	if m.BMCOptions == nil {
+		m.BMCOptions = ir.BMCOptions
+	}
+
+	return m, nil
+}
+
+// NewNormalizedInventoryReaderFromFile creates a MachineReader instance backed by an InventoryReader reading
+// from path that applies default normalizations to machines.
+func NewNormalizedInventoryReaderFromFile(path string, opts *BMCOptions) (MachineReader, error) {
+	fh, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	reader, err := NewInventoryReader(bufio.NewReader(fh), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNormalizer(reader), nil
+}
+
+// NewNormalizedReaderFromFile creates a normalized MachineReader for the hardware file at path. Files with a
+// .json, .yaml or .yml extension are read as an Inventory, any other file is read as CSV.
+func NewNormalizedReaderFromFile(path string, opts *BMCOptions) (MachineReader, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return NewNormalizedInventoryReaderFromFile(path, opts)
+	default:
+		return NewNormalizedCSVReaderFromFile(path, opts)
+	}
+}
@@ -0,0 +1,82 @@
+package hardware_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func TestInventoryReaderFromFile(t *testing.T) {
+	g := gomega.NewWithT(t)
+	defaultOpts := &hardware.BMCOptions{RPC: &hardware.RPCOpts{ConsumerURL: "https://default.example.com"}}
+
+	reader, err := hardware.NewNormalizedReaderFromFile("./testdata/hardware.yaml", defaultOpts)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine).To(gomega.Equal(hardware.Machine{
+		Hostname:     "worker1",
+		IPAddress:    "10.10.10.10",
+		Netmask:      "255.255.255.0",
+		Gateway:      "10.10.10.1",
+		Nameservers:  hardware.Nameservers{"1.1.1.1"},
+		MACAddress:   "00:00:00:00:00:0a",
+		Disk:         "/dev/sda",
+		Labels:       hardware.Labels{"type": "cp"},
+		BMCIPAddress: "192.168.0.10",
+		BMCUsername:  "Admin",
+		BMCPassword:  "admin",
+		BMCOptions: &hardware.BMCOptions{RPC: &hardware.RPCOpts{
+			ConsumerURL: "https://rpc.example.com",
+			HMAC:        hardware.HMACOpts{Secrets: []string{"superSecret"}},
+		}},
+	}))
+
+	machine, err = reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine.Hostname).To(gomega.Equal("worker2"))
+	g.Expect(machine.VLANID).To(gomega.Equal("200"))
+	g.Expect(machine.BMCOptions).To(gomega.Equal(defaultOpts))
+
+	_, err = reader.Read()
+	g.Expect(err).To(gomega.Equal(io.EOF))
+}
+
+func TestInventoryReaderJSON(t *testing.T) {
+	g := gomega.NewWithT(t)
+	data := `{"machines": [{"hostname": "cp1", "mac": "00:00:00:00:00:01", "labels": {"type": "cp"}}]}`
+
+	reader, err := hardware.NewInventoryReader(strings.NewReader(data), nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine.Hostname).To(gomega.Equal("cp1"))
+	g.Expect(machine.Labels).To(gomega.Equal(hardware.Labels{"type": "cp"}))
+	g.Expect(machine.HasBMC()).To(gomega.BeFalse())
+	g.Expect(machine.BMCOptions).To(gomega.BeNil())
+}
+
+func TestInventoryReaderUnknownField(t *testing.T) {
+	g := gomega.NewWithT(t)
+	data := "machines:\n- hostname: cp1\n  ip: 10.10.10.10\n"
+
+	_, err := hardware.NewInventoryReader(strings.NewReader(data), nil)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("parsing inventory")))
+}
+
+func TestNewNormalizedReaderFromFileCSV(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	reader, err := hardware.NewNormalizedReaderFromFile("./testdata/hardware.csv", nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	machine, err := reader.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(machine.Hostname).To(gomega.Equal("worker1"))
+}
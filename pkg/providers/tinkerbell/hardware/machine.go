@@ -37,65 +37,65 @@ type Machine struct {
 // Right now we only support the RPC provider.
 type BMCOptions struct {
 	// RPC are the options for the Rufio RPC provider.
-	RPC *RPCOpts `csv:"-"`
+	RPC *RPCOpts `csv:"-" json:"rpc,omitempty"`
 }
 
 // RPCOpts are the options used for the Rufio RPC provider.
 type RPCOpts struct {
 	// ConsumerURL is the URL where an rpc consumer/listener is running
 	// and to which we will send and receive all notifications.
-	ConsumerURL string `csv:"-"`
+	ConsumerURL string `csv:"-" json:"consumerURL"`
 	// Request is the options used to create the rpc HTTP request.
-	Request RequestOpts `csv:"-"`
+	Request RequestOpts `csv:"-" json:"request,omitempty"`
 	// Signature is the options used for adding an HMAC signature to an HTTP request.
-	Signature SignatureOpts `csv:"-"`
+	Signature SignatureOpts `csv:"-" json:"signature,omitempty"`
 	// HMAC is the options used to create a HMAC signature.
-	HMAC HMACOpts `csv:"-"`
+	HMAC HMACOpts `csv:"-" json:"hmac,omitempty"`
 	// Experimental options.
-	Experimental ExperimentalOpts `csv:"-"`
+	Experimental ExperimentalOpts `csv:"-" json:"experimental,omitempty"`
 }
 
 // ExperimentalOpts are the experimental options used in the Rufio RPC provider.
 type ExperimentalOpts struct {
 	// CustomRequestPayload must be in json.
-	CustomRequestPayload string `csv:"-"`
+	CustomRequestPayload string `csv:"-" json:"customRequestPayload,omitempty"`
 	// DotPath is the path to the json object where the bmclib RequestPayload{} struct will be embedded. For example: object.data.body
-	DotPath string `csv:"-"`
+	DotPath string `csv:"-" json:"dotPath,omitempty"`
 }
 
 // SignatureOpts are the options used for adding an HMAC signature to an HTTP request.
 type SignatureOpts struct {
 	// HeaderName is the header name that should contain the signature(s). Example: X-BMCLIB-Signature
-	HeaderName string `csv:"-"`
+	HeaderName string `csv:"-" json:"headerName,omitempty"`
 	// AppendAlgoToHeaderDisabled decides whether to append the algorithm to the signature header or not.
 	// Example: X-BMCLIB-Signature becomes X-BMCLIB-Signature-256
 	// When set to true, a header will be added for each algorithm. Example: X-BMCLIB-Signature-256 and X-BMCLIB-Signature-512
-	AppendAlgoToHeaderDisabled bool `csv:"-"`
+	AppendAlgoToHeaderDisabled bool `csv:"-" json:"appendAlgoToHeaderDisabled,omitempty"`
 	// IncludedPayloadHeaders are headers whose values will be included in the signature payload. Example: X-BMCLIB-My-Custom-Header
 	// All headers will be deduplicated.
-	IncludedPayloadHeaders []string `csv:"-"`
+	IncludedPayloadHeaders []string `csv:"-" json:"includedPayloadHeaders,omitempty"`
 }
 
 // RequestOpts are the options used to create the rpc HTTP request.
 type RequestOpts struct {
 	// HTTPContentType is the content type to use for the rpc request notification.
-	HTTPContentType string `csv:"-"`
+	HTTPContentType string `csv:"-" json:"httpContentType,omitempty"`
 	// HTTPMethod is the HTTP method to use for the rpc request notification.
-	HTTPMethod string `csv:"-"`
+	HTTPMethod string `csv:"-" json:"httpMethod,omitempty"`
 	// StaticHeaders are predefined headers that will be added to every request.
-	StaticHeaders http.Header `csv:"-"`
+	StaticHeaders http.Header `csv:"-" json:"staticHeaders,omitempty"`
 	// TimestampFormat is the time format for the timestamp header.
-	TimestampFormat string `csv:"-"`
+	TimestampFormat string `csv:"-" json:"timestampFormat,omitempty"`
 	// TimestampHeader is the header name that should contain the timestamp. Example: X-BMCLIB-Timestamp
-	TimestampHeader string `csv:"-"`
+	TimestampHeader string `csv:"-" json:"timestampHeader,omitempty"`
 }
 
 // HMACOpts are the options used to create a HMAC signature.
 type HMACOpts struct {
 	// PrefixSigDisabled determines whether the algorithm will be prefixed to the signature. Example: sha256=abc123
-	PrefixSigDisabled bool `csv:"-"`
+	PrefixSigDisabled bool `csv:"-" json:"prefixSigDisabled,omitempty"`
 	// Secrets used for signing.
-	Secrets []string `csv:"-"`
+	Secrets []string `csv:"-" json:"secrets,omitempty"`
 }
 
 // HasBMC determines if m has a BMC configuration. A BMC configuration is present if any of the BMC fields
@@ -0,0 +1,105 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// ControlPlaneMachineGroup is the machine group of hardware provisioned for control plane machines.
+	ControlPlaneMachineGroup = "control-plane"
+	// EtcdMachineGroup is the machine group of hardware provisioned for external etcd machines.
+	EtcdMachineGroup = "etcd"
+
+	etcdClusterLabel = "cluster.x-k8s.io/etcd-cluster"
+)
+
+// HardwareState is the state of a Hardware in the pool.
+type HardwareState string
+
+const (
+	// HardwareStateFree is the state of hardware that can be selected for new machines.
+	HardwareStateFree HardwareState = "Free"
+	// HardwareStateProvisioned is the state of hardware owned by a machine.
+	HardwareStateProvisioned HardwareState = "Provisioned"
+)
+
+// HardwareStatus describes the state of a Hardware and who owns it.
+type HardwareStatus struct {
+	Hardware *tinkv1alpha1.Hardware
+	State    HardwareState
+	// Machine is the TinkerbellMachine that owns provisioned hardware.
+	Machine string
+	// Cluster is the cluster of the owner machine.
+	Cluster string
+	// MachineGroup is the group of the owner machine: control-plane, etcd or the worker node group name.
+	MachineGroup string
+}
+
+// ListHardwareStatus lists the Hardware in the eksa-system namespace with their state. Provisioned hardware
+// is attributed to a cluster and machine group using the labels of the TinkerbellMachine that owns it.
+func ListHardwareStatus(ctx context.Context, c client.Client) ([]HardwareStatus, error) {
+	hardwareList := &tinkv1alpha1.HardwareList{}
+	if err := c.List(ctx, hardwareList, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("listing hardware: %v", err)
+	}
+
+	machineList := &tinkerbellv1.TinkerbellMachineList{}
+	if err := c.List(ctx, machineList, client.InNamespace(constants.EksaSystemNamespace)); err != nil {
+		return nil, fmt.Errorf("listing tinkerbell machines: %v", err)
+	}
+	machines := make(map[string]*tinkerbellv1.TinkerbellMachine, len(machineList.Items))
+	for i := range machineList.Items {
+		machines[machineList.Items[i].Name] = &machineList.Items[i]
+	}
+
+	statuses := make([]HardwareStatus, 0, len(hardwareList.Items))
+	for i := range hardwareList.Items {
+		h := &hardwareList.Items[i]
+		status := HardwareStatus{Hardware: h, State: HardwareStateFree}
+
+		if owner, ok := h.Labels[OwnerNameLabel]; ok {
+			status.State = HardwareStateProvisioned
+			status.Machine = owner
+			if m, ok := machines[owner]; ok {
+				status.Cluster = m.Labels[clusterv1.ClusterNameLabel]
+				status.MachineGroup = machineGroup(m.Labels, status.Cluster)
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].Hardware.Name < statuses[j].Hardware.Name
+	})
+
+	return statuses, nil
+}
+
+// machineGroup returns the machine group from the labels CAPI propagates to infrastructure machines.
+func machineGroup(labels map[string]string, cluster string) string {
+	if _, ok := labels[clusterv1.MachineControlPlaneLabel]; ok {
+		return ControlPlaneMachineGroup
+	}
+
+	if _, ok := labels[etcdClusterLabel]; ok {
+		return EtcdMachineGroup
+	}
+
+	if md, ok := labels[clusterv1.MachineDeploymentNameLabel]; ok {
+		// EKS-A names MachineDeployments with the cluster name prefix followed by the worker node group name.
+		return strings.TrimPrefix(md, cluster+"-")
+	}
+
+	return ""
+}
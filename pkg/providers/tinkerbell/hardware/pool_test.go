@@ -0,0 +1,107 @@
+package hardware_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/pkg/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func TestListHardwareStatus(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{
+		poolHardware("hw-free", nil),
+		poolHardware("hw-cp", map[string]string{hardware.OwnerNameLabel: "cp-machine"}),
+		poolHardware("hw-etcd", map[string]string{hardware.OwnerNameLabel: "etcd-machine"}),
+		poolHardware("hw-md", map[string]string{hardware.OwnerNameLabel: "md-machine"}),
+		poolHardware("hw-orphan", map[string]string{hardware.OwnerNameLabel: "deleted-machine"}),
+		poolMachine("cp-machine", map[string]string{
+			clusterv1.ClusterNameLabel:         "mgmt",
+			clusterv1.MachineControlPlaneLabel: "",
+		}),
+		poolMachine("etcd-machine", map[string]string{
+			clusterv1.ClusterNameLabel:      "mgmt",
+			"cluster.x-k8s.io/etcd-cluster": "mgmt-etcd",
+		}),
+		poolMachine("md-machine", map[string]string{
+			clusterv1.ClusterNameLabel:           "mgmt",
+			clusterv1.MachineDeploymentNameLabel: "mgmt-md-0",
+		}),
+	}
+
+	scheme := runtime.NewScheme()
+	_ = tinkv1alpha1.AddToScheme(scheme)
+	_ = tinkerbellv1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+	statuses, err := hardware.ListHardwareStatus(ctx, cl)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(statuses).To(HaveLen(5))
+
+	type summary struct {
+		Name, Machine, Cluster, Group string
+		State                         hardware.HardwareState
+	}
+	var got []summary
+	for _, s := range statuses {
+		got = append(got, summary{
+			Name:    s.Hardware.Name,
+			Machine: s.Machine,
+			Cluster: s.Cluster,
+			Group:   s.MachineGroup,
+			State:   s.State,
+		})
+	}
+
+	g.Expect(got).To(Equal([]summary{
+		{Name: "hw-cp", Machine: "cp-machine", Cluster: "mgmt", Group: hardware.ControlPlaneMachineGroup, State: hardware.HardwareStateProvisioned},
+		{Name: "hw-etcd", Machine: "etcd-machine", Cluster: "mgmt", Group: hardware.EtcdMachineGroup, State: hardware.HardwareStateProvisioned},
+		{Name: "hw-free", State: hardware.HardwareStateFree},
+		{Name: "hw-md", Machine: "md-machine", Cluster: "mgmt", Group: "md-0", State: hardware.HardwareStateProvisioned},
+		{Name: "hw-orphan", Machine: "deleted-machine", State: hardware.HardwareStateProvisioned},
+	}))
+}
+
+func TestListHardwareStatusListError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	// The scheme is missing the TinkerbellMachine types.
+	scheme := runtime.NewScheme()
+	_ = tinkv1alpha1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	_, err := hardware.ListHardwareStatus(ctx, cl)
+	g.Expect(err).To(MatchError(ContainSubstring("listing tinkerbell machines")))
+}
+
+func poolHardware(name string, labels map[string]string) *tinkv1alpha1.Hardware {
+	return &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+	}
+}
+
+func poolMachine(name string, labels map[string]string) *tinkerbellv1.TinkerbellMachine {
+	return &tinkerbellv1.TinkerbellMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    labels,
+		},
+	}
+}
@@ -0,0 +1,195 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const defaultRedfishTimeout = 30 * time.Second
+
+// RedfishReader is a MachineReader that discovers the MAC address, disk and hostname of machines through the
+// Redfish API of their BMC. Machines are read from a decorated MachineReader, typically an InventoryReader that
+// lists the BMC endpoints and the network configuration of each machine. Only empty fields are discovered, so
+// values set in the decorated reader take precedence.
+type RedfishReader struct {
+	ctx    context.Context
+	reader MachineReader
+
+	// Insecure skips the verification of the BMC TLS certificates, commonly self-signed.
+	Insecure bool
+	// Timeout for the discovery of a single machine.
+	Timeout time.Duration
+}
+
+// NewRedfishReader creates a RedfishReader instance that decorates r's Read().
+func NewRedfishReader(ctx context.Context, r MachineReader) *RedfishReader {
+	return &RedfishReader{
+		ctx:     ctx,
+		reader:  r,
+		Timeout: defaultRedfishTimeout,
+	}
+}
+
+// Read reads a Machine from the decorated MachineReader and discovers its missing hardware details. If the
+// decorated MachineReader errors, it is returned.
+func (rr *RedfishReader) Read() (Machine, error) {
+	m, err := rr.reader.Read()
+	if err != nil {
+		return Machine{}, err
+	}
+
+	if m.MACAddress != "" && m.Disk != "" && m.Hostname != "" {
+		return m, nil
+	}
+
+	if m.BMCIPAddress == "" {
+		return Machine{}, newMachineError("BMCIPAddress is required to discover hardware through redfish")
+	}
+
+	if err := rr.discover(&m); err != nil {
+		return Machine{}, fmt.Errorf("discovering hardware through redfish (bmc=%v): %v", m.BMCIPAddress, err)
+	}
+
+	return m, nil
+}
+
+func (rr *RedfishReader) discover(m *Machine) error {
+	ctx, cancel := context.WithTimeout(rr.ctx, rr.Timeout)
+	defer cancel()
+
+	client, err := gofish.ConnectContext(ctx, gofish.ClientConfig{
+		Endpoint:  redfishEndpoint(m.BMCIPAddress),
+		Username:  m.BMCUsername,
+		Password:  m.BMCPassword,
+		Insecure:  rr.Insecure,
+		BasicAuth: true,
+	})
+	if err != nil {
+		return fmt.Errorf("connecting: %v", err)
+	}
+	defer client.Logout()
+
+	systems, err := client.Service.Systems()
+	if err != nil {
+		return fmt.Errorf("listing systems: %v", err)
+	}
+	if len(systems) == 0 {
+		return fmt.Errorf("no systems found")
+	}
+	system := systems[0]
+
+	if m.MACAddress == "" {
+		if m.MACAddress, err = discoverMACAddress(system); err != nil {
+			return err
+		}
+	}
+
+	if m.Disk == "" {
+		if m.Disk, err = discoverDisk(system); err != nil {
+			return err
+		}
+	}
+
+	if m.Hostname == "" {
+		if m.Hostname = suggestHostname(system); m.Hostname == "" {
+			return fmt.Errorf("system %v has no hostname or serial number to suggest a hostname", system.ID)
+		}
+	}
+
+	return nil
+}
+
+// discoverMACAddress returns the MAC address of the first enabled interface with an active link, falling back
+// to the first interface with a MAC address.
+func discoverMACAddress(system *redfish.ComputerSystem) (string, error) {
+	interfaces, err := system.EthernetInterfaces()
+	if err != nil {
+		return "", fmt.Errorf("listing ethernet interfaces: %v", err)
+	}
+	sort.SliceStable(interfaces, func(i, j int) bool { return interfaces[i].ID < interfaces[j].ID })
+
+	var fallback string
+	for _, i := range interfaces {
+		mac := i.MACAddress
+		if mac == "" {
+			mac = i.PermanentMACAddress
+		}
+		if mac == "" {
+			continue
+		}
+		if i.InterfaceEnabled && i.LinkStatus == redfish.LinkUpLinkStatus {
+			return mac, nil
+		}
+		if fallback == "" {
+			fallback = mac
+		}
+	}
+
+	if fallback == "" {
+		return "", fmt.Errorf("no ethernet interface with a MAC address found")
+	}
+
+	return fallback, nil
+}
+
+// discoverDisk suggests the device of the first drive in the system. Redfish doesn't expose OS device names,
+// so the name is derived from the drive protocol.
+func discoverDisk(system *redfish.ComputerSystem) (string, error) {
+	storage, err := system.Storage()
+	if err != nil {
+		return "", fmt.Errorf("listing storage: %v", err)
+	}
+	sort.SliceStable(storage, func(i, j int) bool { return storage[i].ID < storage[j].ID })
+
+	for _, s := range storage {
+		drives, err := s.Drives()
+		if err != nil {
+			return "", fmt.Errorf("listing drives of storage %v: %v", s.ID, err)
+		}
+		if len(drives) == 0 {
+			continue
+		}
+		if drives[0].Protocol == common.NVMeProtocol {
+			return "/dev/nvme0n1", nil
+		}
+		return "/dev/sda", nil
+	}
+
+	return "", fmt.Errorf("no drives found")
+}
+
+var invalidHostnameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// suggestHostname returns the hostname reported by the system or, when it's not available, one derived from
+// its serial number.
+func suggestHostname(system *redfish.ComputerSystem) string {
+	if name := sanitizeHostname(system.HostName); name != "" {
+		return name
+	}
+
+	if serial := sanitizeHostname(system.SerialNumber); serial != "" {
+		return "node-" + serial
+	}
+
+	return ""
+}
+
+func sanitizeHostname(s string) string {
+	s = invalidHostnameChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(s)), "-")
+	return strings.Trim(s, "-")
+}
+
+func redfishEndpoint(bmcIPAddress string) string {
+	if strings.HasPrefix(bmcIPAddress, "http://") || strings.HasPrefix(bmcIPAddress, "https://") {
+		return bmcIPAddress
+	}
+	return "https://" + bmcIPAddress
+}
@@ -0,0 +1,163 @@
+package hardware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware/mocks"
+)
+
+func redfishSystem(system, ethernetInterfaces, drive string) map[string]string {
+	return map[string]string{
+		"/redfish/v1/":        `{"Id": "RootService", "Systems": {"@odata.id": "/redfish/v1/Systems"}}`,
+		"/redfish/v1/Systems": `{"Members": [{"@odata.id": "/redfish/v1/Systems/1"}]}`,
+		"/redfish/v1/Systems/1": `{"Id": "1", ` + system + `
+			"EthernetInterfaces": {"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces"},
+			"Storage": {"@odata.id": "/redfish/v1/Systems/1/Storage"}}`,
+		"/redfish/v1/Systems/1/EthernetInterfaces": ethernetInterfaces,
+		"/redfish/v1/Systems/1/EthernetInterfaces/1": `{"Id": "1", "MACAddress": "AA:BB:CC:DD:EE:01",
+			"InterfaceEnabled": true, "LinkStatus": "LinkDown"}`,
+		"/redfish/v1/Systems/1/EthernetInterfaces/2": `{"Id": "2", "MACAddress": "AA:BB:CC:DD:EE:02",
+			"InterfaceEnabled": true, "LinkStatus": "LinkUp"}`,
+		"/redfish/v1/Systems/1/Storage":            `{"Members": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1"}]}`,
+		"/redfish/v1/Systems/1/Storage/1":          `{"Id": "1", "Drives": [{"@odata.id": "/redfish/v1/Systems/1/Storage/1/Drives/1"}]}`,
+		"/redfish/v1/Systems/1/Storage/1/Drives/1": drive,
+	}
+}
+
+func newRedfishServer(t *testing.T, resources map[string]string) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The service root is public, as in real BMCs.
+		user, pass, ok := r.BasicAuth()
+		if r.URL.Path != "/redfish/v1/" && (!ok || user != "Admin" || pass != "admin") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := resources[strings.TrimSuffix(r.URL.Path, "/")]
+		if !ok {
+			body, ok = resources[r.URL.Path]
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newRedfishReader(t *testing.T, machine hardware.Machine) *hardware.RedfishReader {
+	ctrl := gomock.NewController(t)
+	source := mocks.NewMockMachineReader(ctrl)
+	source.EXPECT().Read().Return(machine, nil)
+
+	reader := hardware.NewRedfishReader(context.Background(), source)
+	reader.Insecure = true
+	return reader
+}
+
+func TestRedfishReaderDiscoversHardware(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishServer(t, redfishSystem(
+		`"HostName": "", "SerialNumber": "SRV 0042",`,
+		`{"Members": [{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"}, {"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/2"}]}`,
+		`{"Id": "1", "Protocol": "NVMe"}`,
+	))
+
+	machine := NewValidMachine()
+	machine.Hostname = ""
+	machine.MACAddress = ""
+	machine.Disk = ""
+	machine.BMCIPAddress = server.URL
+	machine.BMCUsername = "Admin"
+	machine.BMCPassword = "admin"
+
+	got, err := newRedfishReader(t, machine).Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(got.MACAddress).To(gomega.Equal("AA:BB:CC:DD:EE:02"))
+	g.Expect(got.Disk).To(gomega.Equal("/dev/nvme0n1"))
+	g.Expect(got.Hostname).To(gomega.Equal("node-srv-0042"))
+	g.Expect(got.IPAddress).To(gomega.Equal(machine.IPAddress))
+}
+
+func TestRedfishReaderKeepsSourceValues(t *testing.T) {
+	g := gomega.NewWithT(t)
+	server := newRedfishServer(t, redfishSystem(
+		`"HostName": "CP-01.example.com",`,
+		`{"Members": [{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"}]}`,
+		`{"Id": "1", "Protocol": "SATA"}`,
+	))
+
+	machine := NewValidMachine()
+	machine.Hostname = ""
+	machine.BMCIPAddress = server.URL
+	machine.BMCUsername = "Admin"
+	machine.BMCPassword = "admin"
+
+	got, err := newRedfishReader(t, machine).Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(got.MACAddress).To(gomega.Equal(machine.MACAddress))
+	g.Expect(got.Disk).To(gomega.Equal(machine.Disk))
+	g.Expect(got.Hostname).To(gomega.Equal("cp-01-example-com"))
+}
+
+func TestRedfishReaderSkipsCompleteMachines(t *testing.T) {
+	g := gomega.NewWithT(t)
+	machine := NewValidMachine()
+	machine.BMCIPAddress = "https://127.0.0.1:1"
+
+	got, err := newRedfishReader(t, machine).Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(got).To(gomega.Equal(machine))
+}
+
+func TestRedfishReaderNoDrives(t *testing.T) {
+	g := gomega.NewWithT(t)
+	resources := redfishSystem(
+		`"HostName": "cp1",`,
+		`{"Members": [{"@odata.id": "/redfish/v1/Systems/1/EthernetInterfaces/1"}]}`,
+		"",
+	)
+	resources["/redfish/v1/Systems/1/Storage/1"] = `{"Id": "1", "Drives": []}`
+	server := newRedfishServer(t, resources)
+
+	machine := NewValidMachine()
+	machine.Disk = ""
+	machine.BMCIPAddress = server.URL
+	machine.BMCUsername = "Admin"
+	machine.BMCPassword = "admin"
+
+	_, err := newRedfishReader(t, machine).Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("no drives found")))
+}
+
+func TestRedfishReaderNoBMC(t *testing.T) {
+	g := gomega.NewWithT(t)
+	machine := NewValidMachine()
+	machine.MACAddress = ""
+	machine.BMCIPAddress = ""
+
+	_, err := newRedfishReader(t, machine).Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("BMCIPAddress is required")))
+}
+
+func TestRedfishReaderPropagatesReadErrors(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	source := mocks.NewMockMachineReader(ctrl)
+	source.EXPECT().Read().Return(hardware.Machine{}, io.EOF)
+
+	_, err := hardware.NewRedfishReader(context.Background(), source).Read()
+	g.Expect(err).To(gomega.Equal(io.EOF))
+}
@@ -7,17 +7,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
-	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
-	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	rufiov1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/rufio"
 	c "github.com/aws/eks-anywhere/pkg/cluster"
-	"github.com/aws/eks-anywhere/pkg/clusterapi"
-	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
@@ -311,7 +305,7 @@ func (r *Reconciler) ValidateHardware(ctx context.Context, log logr.Logger, tink
 
 	switch o {
 	case K8sVersionUpgradeOperation:
-		validatableCAPI, err := r.getValidatableCAPI(ctx, tinkerbellScope.ClusterSpec.Cluster)
+		validatableCAPI, err := tinkerbell.GetValidatableCAPI(ctx, r.client, tinkerbellScope.ClusterSpec.Cluster)
 		if err != nil {
 			return controller.Result{}, err
 		}
@@ -319,34 +313,31 @@ func (r *Reconciler) ValidateHardware(ctx context.Context, log logr.Logger, tink
 		// skip extra hardware validation for InPlace upgrades
 		if upgradeStrategy == nil || upgradeStrategy.Type != anywherev1.InPlaceStrategyType {
 			// eksa version upgrade cannot be triggered from controller, so set it to false.
-			v.Register(tinkerbell.ExtraHardwareAvailableAssertionForRollingUpgrade(kubeReader.GetCatalogue(), validatableCAPI, false))
+			v.Register(tinkerbell.UpgradeOperationAssertion(validatableCAPI, true))
+			rolledOut := tinkerbell.KubernetesVersionRolledOutGroups(clusterSpec, validatableCAPI)
+			v.Register(tinkerbell.HardwareCapacityAssertion(kubeReader.GetCatalogue(), validatableCAPI, rolledOut))
 		}
 	case NewClusterOperation:
 		v.Register(tinkerbell.MinimumHardwareAvailableAssertionForCreate(kubeReader.GetCatalogue()))
 	case NoChange:
-		validatableCAPI, err := r.getValidatableCAPI(ctx, tinkerbellScope.ClusterSpec.Cluster)
+		validatableCAPI, err := tinkerbell.GetValidatableCAPI(ctx, r.client, tinkerbellScope.ClusterSpec.Cluster)
 		if err != nil {
 			return controller.Result{}, err
 		}
-		v.Register(tinkerbell.AssertionsForScaleUpDown(kubeReader.GetCatalogue(), validatableCAPI, false))
+		v.Register(tinkerbell.UpgradeOperationAssertion(validatableCAPI, false))
 
-		hardwareReq, err := r.validateHardwareReqForKCP(validatableCAPI, tinkerbellScope)
+		controlPlaneRolledOut := r.kcpRolledOut(validatableCAPI, tinkerbellScope)
+		rolledOutWorkers, err := r.rolledOutMachineDeployments(ctx, tinkerbellScope)
 		if err != nil {
 			return controller.Result{}, err
 		}
 
-		workerHardwareReq, err := r.validateHardwareReqForMachineDeployments(ctx, tinkerbellScope)
-		if err != nil {
-			return controller.Result{}, err
-		}
-
-		// Hardware selectors for controlPlane and worker nodes are mutually exclusive, so its safe to copy
-		// as no keys are going to be overwritten
-		for k, v := range workerHardwareReq {
-			hardwareReq[k] = v
-		}
-		v.Register(tinkerbell.ExtraHardwareAvailableAssertionForNodeRollOut(kubeReader.GetCatalogue(), hardwareReq))
-
+		v.Register(tinkerbell.HardwareCapacityAssertion(kubeReader.GetCatalogue(), validatableCAPI, func(group string) bool {
+			if group == hardware.ControlPlaneMachineGroup {
+				return controlPlaneRolledOut
+			}
+			return rolledOutWorkers[group]
+		}))
 	}
 
 	tinkClusterSpec := tinkerbell.NewClusterSpec(
@@ -366,92 +357,36 @@ func (r *Reconciler) ValidateHardware(ctx context.Context, log logr.Logger, tink
 	return controller.Result{}, nil
 }
 
-func (r *Reconciler) getValidatableCAPI(ctx context.Context, cluster *anywherev1.Cluster) (*tinkerbell.ValidatableTinkerbellCAPI, error) {
-	currentKCP, err := controller.GetKubeadmControlPlane(ctx, r.client, cluster)
-	if err != nil {
-		return nil, err
-	}
-	var wgs []*clusterapi.WorkerGroup[*tinkerbellv1.TinkerbellMachineTemplate]
-	for _, wnc := range cluster.Spec.WorkerNodeGroupConfigurations {
-		md := &clusterv1.MachineDeployment{}
-		mdName := clusterapi.MachineDeploymentName(cluster, wnc)
-		key := types.NamespacedName{Namespace: constants.EksaSystemNamespace, Name: mdName}
-		err := r.client.Get(ctx, key, md)
-		if err == nil {
-			wgs = append(wgs, &clusterapi.WorkerGroup[*tinkerbellv1.TinkerbellMachineTemplate]{
-				MachineDeployment: md,
-			})
-		} else if !apierrors.IsNotFound(err) {
-			return nil, err
-		}
-	}
-	validatableCAPI := &tinkerbell.ValidatableTinkerbellCAPI{
-		KubeadmControlPlane: currentKCP,
-		WorkerGroups:        wgs,
-	}
-	return validatableCAPI, nil
-}
-
-// validateHardwareReqForKCP returns minium hardware requirements for the KCP to rollout new control plane nodes
+// kcpRolledOut reports whether the KCP rolls out new control plane nodes.
 // CAPI rolls out a new control-plane node whenever the associated MachineTemplate changes in the kcp object
 // There will be no rollout if the template stays the same.
-func (r *Reconciler) validateHardwareReqForKCP(validatableCAPI *tinkerbell.ValidatableTinkerbellCAPI, tinkerbellScope *Scope) (tinkerbell.MinimumHardwareRequirements, error) {
+func (r *Reconciler) kcpRolledOut(validatableCAPI *tinkerbell.ValidatableTinkerbellCAPI, tinkerbellScope *Scope) bool {
 	currentKCP := validatableCAPI.KubeadmControlPlane
 	newKCP := tinkerbellScope.ControlPlane.KubeadmControlPlane
-	tinkerbellClusterSpec := tinkerbell.NewClusterSpec(tinkerbellScope.ClusterSpec, tinkerbellScope.ClusterSpec.TinkerbellMachineConfigs, tinkerbellScope.ClusterSpec.TinkerbellDatacenter)
-	maxSurge := 1
-	requirements := tinkerbell.MinimumHardwareRequirements{}
-	if currentKCP.Spec.MachineTemplate.InfrastructureRef.Name != newKCP.Spec.MachineTemplate.InfrastructureRef.Name {
-		upgradeStrategy := tinkerbellScope.ClusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy
-		if upgradeStrategy != nil && upgradeStrategy.Type == anywherev1.RollingUpdateStrategyType {
-			maxSurge = upgradeStrategy.RollingUpdate.MaxSurge
-		}
-		if err := requirements.Add(tinkerbellClusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector, maxSurge); err != nil {
-			return nil, err
-		}
-	}
-	return requirements, nil
+	return currentKCP.Spec.MachineTemplate.InfrastructureRef.Name != newKCP.Spec.MachineTemplate.InfrastructureRef.Name
 }
 
-// validateHardwareReqForMachineDeployments returns minium hardware requirements for the md's to rollout new worker nodes
+// rolledOutMachineDeployments returns the names of the worker node groups whose md's roll out new worker nodes.
 // CAPI rolls out a new worker node only whenever the associated MachineTemplate changes in the md object
 // A single cluster can have multiple MachineDeployment objects and in case of modular upgrades
 // only few of those worker groups might need a rollout.
-func (r *Reconciler) validateHardwareReqForMachineDeployments(ctx context.Context, tinkerbellScope *Scope) (requirements tinkerbell.MinimumHardwareRequirements, err error) {
-	newWorkers := tinkerbellScope.Workers
-
-	tinkerbellClusterSpec := tinkerbell.NewClusterSpec(tinkerbellScope.ClusterSpec, tinkerbellScope.ClusterSpec.TinkerbellMachineConfigs, tinkerbellScope.ClusterSpec.TinkerbellDatacenter)
-	requirements = tinkerbell.MinimumHardwareRequirements{}
-	for _, wg := range newWorkers.Groups {
-		maxSurge := 1
+func (r *Reconciler) rolledOutMachineDeployments(ctx context.Context, tinkerbellScope *Scope) (map[string]bool, error) {
+	clusterName := tinkerbellScope.ClusterSpec.Cluster.Name
+	rolledOut := map[string]bool{}
+	for _, wg := range tinkerbellScope.Workers.Groups {
 		currentMachineDeployment, err := controller.GetMachineDeployment(ctx, r.client, wg.MachineDeployment.GetName())
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get workernode group machinedeployment")
 		}
-		clusterName := tinkerbellClusterSpec.Cluster.Name
-
-		// EKS-A names MachineDeployment with the clusterName prefix followed by the WorkerNodeGroup name provider concatenated by '-'
-		// We just need the workerNodeGroup name to fetch the corresponding workerNodeGroup config from the spec
-		workerNodeGroupName := strings.ReplaceAll(wg.MachineDeployment.GetName(), clusterName, "")[1:]
-		var workerNodeGroup anywherev1.WorkerNodeGroupConfiguration
-		for _, wng := range tinkerbellClusterSpec.WorkerNodeGroupConfigurations() {
-			if wng.Name == workerNodeGroupName {
-				workerNodeGroup = wng
-				break
-			}
-		}
+
 		if currentMachineDeployment != nil && currentMachineDeployment.Spec.Template.Spec.InfrastructureRef.Name != wg.MachineDeployment.Spec.Template.Spec.InfrastructureRef.Name {
-			upgradeStrategy := wg.MachineDeployment.Spec.Strategy
-			if upgradeStrategy != nil && upgradeStrategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType {
-				maxSurge = int(upgradeStrategy.RollingUpdate.MaxSurge.IntVal)
-			}
-			if err := requirements.Add(tinkerbellClusterSpec.WorkerNodeGroupMachineConfig(workerNodeGroup).Spec.HardwareSelector, maxSurge); err != nil {
-				return nil, err
-			}
+			// EKS-A names MachineDeployment with the clusterName prefix followed by the WorkerNodeGroup name provider concatenated by '-'
+			workerNodeGroupName := strings.ReplaceAll(wg.MachineDeployment.GetName(), clusterName, "")[1:]
+			rolledOut[workerNodeGroupName] = true
 		}
 	}
 
-	return requirements, nil
+	return rolledOut, nil
 }
 
 // ValidateRufioMachines checks to ensure all the Rufio machines condition contactable is True.
@@ -597,6 +597,39 @@ func TestReconcilerValidateHardwareControlPlaneOSImageChangeSuccess(t *testing.T
 	tt.cleanup()
 }
 
+func TestReconcilerValidateHardwareScaleUpAndControlPlaneOSImageChangeError(t *testing.T) {
+	tt := newReconcilerTest(t)
+	worker := tinkWorker(tt.cluster.Name, func(w *tinkerbell.Workers) {
+		w.Groups[0].MachineDeployment.Name = "workload-cluster-md-0"
+	})
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tinkHardware("hw1", "cp"), worker.Groups[0].MachineDeployment, tinkHardware("hw2", "worker"))
+	tt.createAllObjs()
+
+	logger := test.NewNullLogger()
+	scope := tt.buildScope()
+	cpRef := scope.ClusterSpec.Config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	scope.ClusterSpec.Config.Cluster.Spec.ControlPlaneConfiguration.Count++
+	scope.ClusterSpec.Config.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &anywherev1.ControlPlaneUpgradeRolloutStrategy{
+		Type: anywherev1.RollingUpdateStrategyType,
+		RollingUpdate: &anywherev1.ControlPlaneRollingUpdateParams{
+			MaxSurge: 1,
+		},
+	}
+	scope.ClusterSpec.Config.TinkerbellMachineConfigs[cpRef].Spec.OSImageURL = "new-os-image"
+
+	_, err := tt.reconciler().GenerateSpec(tt.ctx, logger, scope)
+	tt.Expect(err).NotTo(HaveOccurred())
+	_, err = tt.reconciler().DetectOperation(tt.ctx, logger, scope)
+	tt.Expect(err).NotTo(HaveOccurred())
+	result, err := tt.reconciler().ValidateHardware(tt.ctx, logger, scope)
+
+	tt.Expect(err).ToNot(BeNil())
+	tt.Expect(result).To(Equal(controller.Result{}), "result should not stop reconciliation")
+	tt.Expect(*tt.cluster.Status.FailureMessage).To(ContainSubstring("minimum hardware count not met for selector '{\"type\":\"cp\"}': have 1, require 2"))
+	tt.Expect(tt.cluster.Status.FailureReason).To(HaveValue(Equal(anywherev1.HardwareInvalidReason)))
+	tt.cleanup()
+}
+
 func TestReconcilerValidateHardwareWorkerNodeGroupOSImageChangeError(t *testing.T) {
 	tt := newReconcilerTest(t)
 
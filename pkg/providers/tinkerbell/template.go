@@ -719,6 +719,10 @@ func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]i
 		values["registryCACert"] = registryMirror.CACertContent
 	}
 
+	if err := containerd.SetFallbackTemplateValues(values, registryMirror); err != nil {
+		return values, err
+	}
+
 	if registryMirror.Auth {
 		values["registryAuth"] = registryMirror.Auth
 		username, password, err := config.ReadCredentials()
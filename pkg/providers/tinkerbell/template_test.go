@@ -296,6 +296,41 @@ func TestTemplateBuilderWNKubeletConfig(t *testing.T) {
 	}
 }
 
+func TestTemplateBuilderRegistryMirrorFallbacks(t *testing.T) {
+	for _, tc := range []struct {
+		Input    string
+		OutputCP string
+		OutputMD string
+	}{
+		{
+			Input:    "testdata/cluster_tinkerbell_registry_mirror_with_fallbacks.yaml",
+			OutputCP: "testdata/expected_results_cluster_tinkerbell_cp_registry_mirror_with_fallbacks.yaml",
+			OutputMD: "testdata/expected_results_cluster_tinkerbell_md_registry_mirror_with_fallbacks.yaml",
+		},
+		{
+			Input:    "testdata/cluster_tinkerbell_bottlerocket_registry_mirror_with_fallbacks.yaml",
+			OutputCP: "testdata/expected_results_cluster_tinkerbell_bottlerocket_cp_registry_mirror_with_fallbacks.yaml",
+			OutputMD: "testdata/expected_results_cluster_tinkerbell_bottlerocket_md_registry_mirror_with_fallbacks.yaml",
+		},
+	} {
+		g := NewWithT(t)
+		clusterSpec := test.NewFullClusterSpec(t, tc.Input)
+		cpMachineCfg, _ := getControlPlaneMachineSpec(clusterSpec)
+		wngMachineCfgs, _ := getWorkerNodeGroupMachineSpec(clusterSpec)
+		tinkIPBefore := "0.0.0.0"
+		bldr := NewTemplateBuilder(&clusterSpec.TinkerbellDatacenter.Spec, cpMachineCfg, nil, wngMachineCfgs, tinkIPBefore, time.Now)
+
+		data, err := bldr.GenerateCAPISpecControlPlane(clusterSpec)
+		g.Expect(err).ToNot(HaveOccurred())
+		test.AssertContentToFile(t, string(data), tc.OutputCP)
+
+		workerTemplateNames, kubeadmTemplateNames := clusterapi.InitialTemplateNamesForWorkers(clusterSpec)
+		data, err = bldr.GenerateCAPISpecWorkers(clusterSpec, workerTemplateNames, kubeadmTemplateNames)
+		g.Expect(err).ToNot(HaveOccurred())
+		test.AssertContentToFile(t, string(data), tc.OutputMD)
+	}
+}
+
 func TestTemplateBuilderCPHookIso(t *testing.T) {
 	for _, tc := range []struct {
 		Input  string
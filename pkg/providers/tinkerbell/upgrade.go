@@ -70,7 +70,7 @@ func (p *Provider) SetupAndValidateUpgradeCluster(ctx context.Context, cluster *
 	if p.hardwareCSVIsProvided() {
 		machineCatalogueWriter := hardware.NewMachineCatalogueWriter(p.catalogue)
 
-		machines, err := hardware.NewNormalizedCSVReaderFromFile(p.hardwareCSVFile, p.BMCOptions)
+		machines, err := hardware.NewNormalizedReaderFromFile(p.hardwareCSVFile, p.BMCOptions)
 		if err != nil {
 			return err
 		}
@@ -165,27 +165,10 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 	currentTinkerbellSpec := NewClusterSpec(currentSpec, currentSpec.TinkerbellMachineConfigs, currentSpec.TinkerbellDatacenter)
 	rollingUpgrade := p.isRollingUpgrade(currentSpec, newClusterSpec)
 	currentCluster := &ValidatableTinkerbellClusterSpec{currentTinkerbellSpec}
-	if rollingUpgrade || eksaVersionUpgrade {
-		clusterSpecValidator.Register(ExtraHardwareAvailableAssertionForRollingUpgrade(p.catalogue, currentCluster, eksaVersionUpgrade))
-	}
-	requirements, err := p.validateHardwareReqForControlPlaneRollOut(currentSpec, newClusterSpec)
-	if err != nil {
-		return err
-	}
-
-	workerRequirements, err := p.validateHardwareReqForWorkerNodeGroupsRollOut(currentSpec, newClusterSpec)
-	if err != nil {
-		return err
-	}
-
-	// Hardware selectors for controlPlane and worker nodes are mutually exclusive, so its safe to copy
-	// as no keys are going to be overwritten
-	for k, v := range workerRequirements {
-		requirements[k] = v
-	}
-	clusterSpecValidator.Register(ExtraHardwareAvailableAssertionForNodeRollOut(p.catalogue, requirements))
-	// ScaleUpDown should not be supported in case of either rolling upgrade or eksa version upgrade.
-	clusterSpecValidator.Register(AssertionsForScaleUpDown(p.catalogue, currentCluster, rollingUpgrade || eksaVersionUpgrade))
+	// Scaling up or down should not be supported in case of either rolling upgrade or eksa version upgrade.
+	clusterSpecValidator.Register(UpgradeOperationAssertion(currentCluster, rollingUpgrade || eksaVersionUpgrade))
+	// Groups sharing the same hardware selector are checked together for their new machines and rollout surge.
+	clusterSpecValidator.Register(HardwareCapacityAssertion(p.catalogue, currentCluster, rolledOutGroups(currentSpec, newClusterSpec, eksaVersionUpgrade)))
 
 	tinkerbellClusterSpec := NewClusterSpec(newClusterSpec, p.machineConfigs, p.datacenterConfig)
 
@@ -196,50 +179,34 @@ func (p *Provider) validateAvailableHardwareForUpgrade(ctx context.Context, curr
 	return nil
 }
 
-// validateHardwareReqForControlPlaneRollOut checks if new ControlPlane nodes need to be rolled out
-// and returns the HardwareRequirements for ControlPlane HardwareSelector
-// as we open up more feature gates in our spec this function can be used to
-// validate against the features that affect control plane only ex. API server extra args, etc.
-func (p *Provider) validateHardwareReqForControlPlaneRollOut(currentSpec, newClusterSpec *cluster.Spec) (MinimumHardwareRequirements, error) {
-	oldCP := currentSpec.TinkerbellMachineConfigs[currentSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
-	newCP := newClusterSpec.TinkerbellMachineConfigs[currentSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
-
-	maxSurge := 1
-	requirements := MinimumHardwareRequirements{}
-	rolloutStrategy := newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy
-	if rolloutStrategy != nil && rolloutStrategy.Type == "RollingUpdate" {
-		maxSurge = newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
-	}
-	if oldCP.Spec.OSImageURL != newCP.Spec.OSImageURL {
-		if err := requirements.Add(newCP.Spec.HardwareSelector, maxSurge); err != nil {
-			return nil, fmt.Errorf("validating hardware requirements for control-plane nodes roll out: %v", err)
-		}
+// rolledOutGroups returns the groups whose machines are replaced when upgrading from currentSpec to
+// newClusterSpec: all of them for EKS Anywhere version and datacenter OS image upgrades, and otherwise the
+// ones with a new Kubernetes version or machine config OS image.
+func rolledOutGroups(currentSpec, newClusterSpec *cluster.Spec, eksaVersionUpgrade bool) RolledOutGroups {
+	if eksaVersionUpgrade || currentSpec.TinkerbellDatacenter.Spec.OSImageURL != newClusterSpec.TinkerbellDatacenter.Spec.OSImageURL {
+		return AllGroupsRolledOut
 	}
-	return requirements, nil
-}
 
-// validateHardwareReqForWorkerNodeGroupsRollOut checks if new Worker nodes need to be rolled out for a given worker node group configuration
-// and returns the HardwareRequirements for Worker node group HardwareSelector
-// as we open up more feature gates in our spec this function can be used to
-// validate against the features that affect worker groups only.
-func (p *Provider) validateHardwareReqForWorkerNodeGroupsRollOut(currentSpec, newClusterSpec *cluster.Spec) (MinimumHardwareRequirements, error) {
-	requirements := MinimumHardwareRequirements{}
-	for _, newWngConfig := range newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
-		oldWng := currentSpec.TinkerbellMachineConfigs[newWngConfig.MachineGroupRef.Name]
-		newWng := newClusterSpec.TinkerbellMachineConfigs[newWngConfig.MachineGroupRef.Name]
-		if oldWng != nil && oldWng.Spec.OSImageURL != newWng.Spec.OSImageURL {
-			maxSurge := 1
-			rolloutStrategy := newWngConfig.UpgradeRolloutStrategy
-			if rolloutStrategy != nil && rolloutStrategy.Type == "RollingUpdate" {
-				maxSurge = rolloutStrategy.RollingUpdate.MaxSurge
-			}
-			if err := requirements.Add(newWng.Spec.HardwareSelector, maxSurge); err != nil {
-				return nil, fmt.Errorf("validating hardware requirements for worker node groups roll out: %v", err)
-			}
-		}
+	osImageChanged := func(machineConfig string) bool {
+		current := currentSpec.TinkerbellMachineConfigs[machineConfig]
+		desired := newClusterSpec.TinkerbellMachineConfigs[machineConfig]
+		return current != nil && desired != nil && current.Spec.OSImageURL != desired.Spec.OSImageURL
 	}
 
-	return requirements, nil
+	versionRollout := KubernetesVersionRolledOutGroups(newClusterSpec, &ValidatableTinkerbellClusterSpec{
+		NewClusterSpec(currentSpec, currentSpec.TinkerbellMachineConfigs, currentSpec.TinkerbellDatacenter),
+	})
+	groups := map[string]bool{
+		hardware.ControlPlaneMachineGroup: versionRollout(hardware.ControlPlaneMachineGroup) ||
+			osImageChanged(newClusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name),
+	}
+	for _, group := range newClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		groups[group.Name] = versionRollout(group.Name) || osImageChanged(group.MachineGroupRef.Name)
+	}
+
+	return func(group string) bool {
+		return groups[group]
+	}
 }
 
 // ApplyHardwareToCluster adds all the hardwares to the cluster.
@@ -1273,7 +1273,7 @@ func TestProviderValidateAvailableHardwareOnlyCPUpgradeError(t *testing.T) {
 	machineConfigs[cpRef].Spec.OSImageURL = "https://ubuntu-1-22.gz"
 	provider.catalogue = catalogue
 	err := provider.validateAvailableHardwareForUpgrade(ctx, clusterSpec, newCluster)
-	if err == nil || !strings.Contains(err.Error(), "for rolling upgrade, minimum hardware count not met for selector '{\"type\":\"cp\"}'") {
+	if err == nil || !strings.Contains(err.Error(), "minimum hardware count not met for selector '{\"type\":\"cp\"}'") {
 		t.Fatal(err)
 	}
 }
@@ -1339,7 +1339,7 @@ func TestProviderValidateAvailableHardwareOnlyCPOSImageURLUpgradeError(t *testin
 	newCluster.TinkerbellMachineConfigs = newMachineConfigs
 	provider.catalogue = catalogue
 	err := provider.validateAvailableHardwareForUpgrade(ctx, clusterSpec, newCluster)
-	if err == nil || !strings.Contains(err.Error(), "for node rollout, minimum hardware count not met for selector '{\"type\":\"cp\"}'") {
+	if err == nil || !strings.Contains(err.Error(), "minimum hardware count not met for selector '{\"type\":\"cp\"}'") {
 		fmt.Println(err)
 		t.Fatal(err)
 	}
@@ -1403,7 +1403,7 @@ func TestProviderValidateAvailableHardwareOnlyWorkerUpgradeError(t *testing.T) {
 	machineConfigs[wngRef].Spec.OSImageURL = "https://ubuntu-1-22.gz"
 	provider.catalogue = catalogue
 	err := provider.validateAvailableHardwareForUpgrade(ctx, clusterSpec, newCluster)
-	if err == nil || !strings.Contains(err.Error(), "for rolling upgrade, minimum hardware count not met for selector '{\"type\":\"worker\"}'") {
+	if err == nil || !strings.Contains(err.Error(), "minimum hardware count not met for selector '{\"type\":\"worker\"}'") {
 		t.Fatal(err)
 	}
 }
@@ -1445,6 +1445,45 @@ func TestProviderValidateAvailableHardwareOnlyWorkerOSImageURLUpgradeSuccess(t *
 	}
 }
 
+func TestProviderValidateAvailableHardwareScaleUpAndOSImageURLUpgradeError(t *testing.T) {
+	clusterSpecManifest := "cluster_osimage_machine_config.yaml"
+	mockCtrl := gomock.NewController(t)
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	machineConfigs := givenMachineConfigs(t, clusterSpecManifest)
+	docker := stackmocks.NewMockDocker(mockCtrl)
+	helm := stackmocks.NewMockHelm(mockCtrl)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	stackInstaller := stackmocks.NewMockStackInstaller(mockCtrl)
+	writer := filewritermocks.NewMockFileWriter(mockCtrl)
+	ctx := context.Background()
+	provider := newTinkerbellProvider(datacenterConfig, machineConfigs, clusterSpec.Cluster, writer, docker, helm, kubectl)
+	provider.stackInstaller = stackInstaller
+
+	clusterSpec.ManagementCluster = &types.Cluster{Name: "test", KubeconfigFile: "kubeconfig-file"}
+	clusterSpec.Cluster.Spec.ManagementCluster = v1alpha1.ManagementCluster{Name: "test-mgmt"}
+	catalogue := hardware.NewCatalogue()
+	newCluster := clusterSpec.DeepCopy()
+	newMachineConfigs := make(map[string]*v1alpha1.TinkerbellMachineConfig, len(machineConfigs))
+	for k, v := range machineConfigs {
+		newMachineConfigs[k] = v
+	}
+
+	// The scale up and the rollout of the control plane each need one of the hardware, together they need two.
+	_ = catalogue.InsertHardware(&tinkv1.Hardware{ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{"type": "cp"},
+	}})
+	newCluster.Cluster.Spec.ControlPlaneConfiguration.Count = 2
+	cpRef := newCluster.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	newMachineConfigs[cpRef].Spec.OSImageURL = "https://ubuntu-1-21-patch.gz"
+	provider.catalogue = catalogue
+	newCluster.TinkerbellMachineConfigs = newMachineConfigs
+	err := provider.validateAvailableHardwareForUpgrade(ctx, clusterSpec, newCluster)
+	if err == nil || !strings.Contains(err.Error(), "minimum hardware count not met for selector '{\"type\":\"cp\"}': have 1, require 2") {
+		t.Fatal(err)
+	}
+}
+
 func TestProviderValidateAvailableHardwareOnlyWorkerOSImageURLUpgradeError(t *testing.T) {
 	clusterSpecManifest := "cluster_osimage_machine_config.yaml"
 	mockCtrl := gomock.NewController(t)
@@ -1482,7 +1521,7 @@ func TestProviderValidateAvailableHardwareOnlyWorkerOSImageURLUpgradeError(t *te
 	provider.catalogue = catalogue
 	newCluster.TinkerbellMachineConfigs = newMachineConfigs
 	err := provider.validateAvailableHardwareForUpgrade(ctx, clusterSpec, newCluster)
-	if err == nil || !strings.Contains(err.Error(), "for node rollout, minimum hardware count not met for selector '{\"type\":\"worker\"}'") {
+	if err == nil || !strings.Contains(err.Error(), "minimum hardware count not met for selector '{\"type\":\"worker\"}'") {
 		t.Fatal(err)
 	}
 }
@@ -286,6 +286,21 @@ func (mr *MockProviderGovcClientMockRecorder) GetHardDiskSize(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHardDiskSize", reflect.TypeOf((*MockProviderGovcClient)(nil).GetHardDiskSize), arg0, arg1, arg2)
 }
 
+// GetHostsPCIPassthroughDevices mocks base method.
+func (m *MockProviderGovcClient) GetHostsPCIPassthroughDevices(arg0 context.Context, arg1, arg2 string) (map[string][]executables.PCIPassthroughDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostsPCIPassthroughDevices", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string][]executables.PCIPassthroughDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostsPCIPassthroughDevices indicates an expected call of GetHostsPCIPassthroughDevices.
+func (mr *MockProviderGovcClientMockRecorder) GetHostsPCIPassthroughDevices(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostsPCIPassthroughDevices", reflect.TypeOf((*MockProviderGovcClient)(nil).GetHostsPCIPassthroughDevices), arg0, arg1, arg2)
+}
+
 // GetLibraryElementContentVersion mocks base method.
 func (m *MockProviderGovcClient) GetLibraryElementContentVersion(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -301,6 +316,21 @@ func (mr *MockProviderGovcClientMockRecorder) GetLibraryElementContentVersion(ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibraryElementContentVersion", reflect.TypeOf((*MockProviderGovcClient)(nil).GetLibraryElementContentVersion), arg0, arg1)
 }
 
+// GetResourcePoolComputeResourcePath mocks base method.
+func (m *MockProviderGovcClient) GetResourcePoolComputeResourcePath(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourcePoolComputeResourcePath", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourcePoolComputeResourcePath indicates an expected call of GetResourcePoolComputeResourcePath.
+func (mr *MockProviderGovcClientMockRecorder) GetResourcePoolComputeResourcePath(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourcePoolComputeResourcePath", reflect.TypeOf((*MockProviderGovcClient)(nil).GetResourcePoolComputeResourcePath), arg0, arg1, arg2)
+}
+
 // GetResourcePoolInfo mocks base method.
 func (m *MockProviderGovcClient) GetResourcePoolInfo(arg0 context.Context, arg1, arg2 string, arg3 ...string) (map[string]int, error) {
 	m.ctrl.T.Helper()
@@ -351,6 +381,21 @@ func (mr *MockProviderGovcClientMockRecorder) GetTags(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockProviderGovcClient)(nil).GetTags), arg0, arg1)
 }
 
+// GetVMDiskCount mocks base method.
+func (m *MockProviderGovcClient) GetVMDiskCount(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVMDiskCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVMDiskCount indicates an expected call of GetVMDiskCount.
+func (mr *MockProviderGovcClientMockRecorder) GetVMDiskCount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVMDiskCount", reflect.TypeOf((*MockProviderGovcClient)(nil).GetVMDiskCount), arg0, arg1, arg2)
+}
+
 // GetVMDiskSizeInGB mocks base method.
 func (m *MockProviderGovcClient) GetVMDiskSizeInGB(arg0 context.Context, arg1, arg2 string) (int, error) {
 	m.ctrl.T.Helper()
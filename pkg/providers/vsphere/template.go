@@ -238,6 +238,14 @@ func buildTemplateMapCP(
 		"eksaCloudProviderPassword":            vuc.EksaVsphereCPPassword,
 		"controlPlaneCloneMode":                controlPlaneMachineSpec.CloneMode,
 		"etcdCloneMode":                        etcdMachineSpec.CloneMode,
+		"controlPlaneAdditionalNetworks":       controlPlaneMachineSpec.AdditionalNetworks,
+		"controlPlaneAdditionalDisksGiB":       additionalDisksGiB(controlPlaneMachineSpec),
+		"controlPlaneDiskMounts":               additionalDiskMounts(controlPlaneMachineSpec),
+		"diskMountScript":                      diskMountScript,
+		"diskMountScriptPath":                  diskMountScriptPath,
+		"controlPlanePCIDevices":               controlPlaneMachineSpec.PCIDevices,
+		"controlPlaneIPPool":                   controlPlaneMachineSpec.IPPool,
+		"controlPlaneNameservers":              ipPoolNameservers(clusterSpec, controlPlaneMachineSpec.IPPool),
 	}
 
 	auditPolicy, err := common.GetAuditPolicy(clusterSpec.Cluster.Spec.KubernetesVersion)
@@ -258,10 +266,15 @@ func buildTemplateMapCP(
 
 		if controlPlaneMachineSpec.OSFamily == anywherev1.Bottlerocket &&
 			len(registryMirror.NamespacedRegistryMap) == 1 &&
-			registryMirror.CoreEKSAMirror() != "" {
+			registryMirror.CoreEKSAMirror() != "" &&
+			len(registryMirror.Fallbacks) == 0 {
 			values["publicECRMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 		}
 
+		if err := containerd.SetFallbackTemplateValues(values, registryMirror); err != nil {
+			return values, err
+		}
+
 		if registryMirror.Auth {
 			values["registryAuth"] = registryMirror.Auth
 			username, password, err := config.ReadCredentials()
@@ -311,6 +324,11 @@ func buildTemplateMapCP(
 		values["etcdVMsNumCPUs"] = etcdMachineSpec.NumCPUs
 		values["etcdVsphereResourcePool"] = etcdMachineSpec.ResourcePool
 		values["etcdVsphereStoragePolicyName"] = etcdMachineSpec.StoragePolicyName
+		values["etcdAdditionalNetworks"] = etcdMachineSpec.AdditionalNetworks
+		values["etcdAdditionalDisksGiB"] = additionalDisksGiB(etcdMachineSpec)
+		values["etcdPCIDevices"] = etcdMachineSpec.PCIDevices
+		values["etcdIPPool"] = etcdMachineSpec.IPPool
+		values["etcdNameservers"] = ipPoolNameservers(clusterSpec, etcdMachineSpec.IPPool)
 		values["etcdSshUsername"] = firstEtcdMachinesUser.Name
 		values["vsphereEtcdSshAuthorizedKey"] = etcdSSHKey
 
@@ -480,6 +498,14 @@ func buildTemplateMapMD(
 		"workerNodeGroupTaints":          workerNodeGroupConfiguration.Taints,
 		"autoscalingConfig":              workerNodeGroupConfiguration.AutoScalingConfiguration,
 		"workerCloneMode":                workerNodeGroupMachineSpec.CloneMode,
+		"workerAdditionalNetworks":       workerNodeGroupMachineSpec.AdditionalNetworks,
+		"workerAdditionalDisksGiB":       additionalDisksGiB(workerNodeGroupMachineSpec),
+		"workerDiskMounts":               additionalDiskMounts(workerNodeGroupMachineSpec),
+		"diskMountScript":                diskMountScript,
+		"diskMountScriptPath":            diskMountScriptPath,
+		"workerPCIDevices":               workerNodeGroupMachineSpec.PCIDevices,
+		"workerIPPool":                   workerNodeGroupMachineSpec.IPPool,
+		"workerNameservers":              ipPoolNameservers(clusterSpec, workerNodeGroupMachineSpec.IPPool),
 	}
 
 	if clusterSpec.Cluster.Spec.RegistryMirrorConfiguration != nil {
@@ -494,10 +520,15 @@ func buildTemplateMapMD(
 
 		if workerNodeGroupMachineSpec.OSFamily == anywherev1.Bottlerocket &&
 			len(registryMirror.NamespacedRegistryMap) == 1 &&
-			registryMirror.CoreEKSAMirror() != "" {
+			registryMirror.CoreEKSAMirror() != "" &&
+			len(registryMirror.Fallbacks) == 0 {
 			values["publicECRMirror"] = containerd.ToAPIEndpoint(registryMirror.CoreEKSAMirror())
 		}
 
+		if err := containerd.SetFallbackTemplateValues(values, registryMirror); err != nil {
+			return values, err
+		}
+
 		if registryMirror.Auth {
 			values["registryAuth"] = registryMirror.Auth
 			username, password, err := config.ReadCredentials()
@@ -602,6 +633,85 @@ func buildTemplateMapMD(
 	return values, nil
 }
 
+// diskMount is an additional disk formatted and mounted by the diskMountScript.
+type diskMount struct {
+	Position  int
+	Label     string
+	MountPath string
+}
+
+// diskMountScriptPath is where the diskMountScript is written in the machines.
+const diskMountScriptPath = "/usr/local/bin/eksa-mount-disk.sh"
+
+// diskMountScript mounts an additional disk by its filesystem label. The first time, when no
+// filesystem has the label yet, it formats the disk at the given position among the data disks,
+// ordered by SCSI address like CAPV orders the template disks it resizes. Kernel device names,
+// like /dev/sdb, aren't used since they depend on the order the disks are probed at boot.
+const diskMountScript = `#!/bin/bash
+set -euo pipefail
+position=$1
+label=$2
+mount_path=$3
+if ! device=$(blkid -L "$label"); then
+  root_disk=$(lsblk -nrso NAME,TYPE "$(findmnt -no SOURCE /)" | awk '$2 == "disk" {print $1}')
+  device=$(lsblk -dnro NAME,HCTL,TYPE | awk -v root="$root_disk" '$3 == "disk" && $1 != root && $2 != "" {print $2, $1}' |
+    sort -t: -k1,1n -k2,2n -k3,3n -k4,4n | awk -v n="$position" 'NR == n {print "/dev/" $2}')
+  if [ -z "$device" ]; then
+    echo "additional disk $position not found" >&2
+    exit 1
+  fi
+  if [ -n "$(blkid -o value -s TYPE "$device")" ]; then
+    echo "additional disk $position ($device) already has a filesystem, not formatting it" >&2
+    exit 1
+  fi
+  mkfs.ext4 -L "$label" "$device"
+fi
+mkdir -p "$mount_path"
+if ! grep -q "^LABEL=$label " /etc/fstab; then
+  echo "LABEL=$label $mount_path ext4 defaults,nofail 0 2" >>/etc/fstab
+fi
+if ! mountpoint -q "$mount_path"; then
+  mount "$mount_path"
+fi`
+
+func additionalDisksGiB(machineSpec anywherev1.VSphereMachineConfigSpec) []int {
+	sizes := make([]int, 0, len(machineSpec.AdditionalDisks))
+	for _, disk := range machineSpec.AdditionalDisks {
+		sizes = append(sizes, disk.SizeGiB)
+	}
+	return sizes
+}
+
+// additionalDiskMounts returns the additional disks with a mount path. Their position is the
+// one of the template disk CAPV resizes for them, after the boot disk.
+func additionalDiskMounts(machineSpec anywherev1.VSphereMachineConfigSpec) []diskMount {
+	var mounts []diskMount
+	for i, disk := range machineSpec.AdditionalDisks {
+		if disk.MountPath == "" {
+			continue
+		}
+		mounts = append(mounts, diskMount{
+			Position:  i + 1,
+			Label:     fmt.Sprintf("eksa-disk-%d", i),
+			MountPath: disk.MountPath,
+		})
+	}
+	return mounts
+}
+
+// ipPoolNameservers returns the nameservers of the VSphereIPPool the primary network device
+// gets its address from. Pools of other kinds don't carry nameservers.
+func ipPoolNameservers(clusterSpec *cluster.Spec, ref *anywherev1.VSphereIPPoolReference) []string {
+	if ref == nil || !ref.IsVSphereIPPool() {
+		return nil
+	}
+	pool := clusterSpec.VSphereIPPool(ref.Name)
+	if pool == nil {
+		return nil
+	}
+	return pool.Spec.Nameservers
+}
+
 func buildTemplateMapFailureDomain(
 	clusterSpec *cluster.Spec,
 	failureDomain anywherev1.FailureDomain,
@@ -1,11 +1,19 @@
 package vsphere_test
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiyaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/aws/eks-anywhere/internal/test"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -217,3 +225,153 @@ func TestVsphereTemplateBuilderGenerateCAPISpecVCenterTags(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	test.AssertContentToFile(t, string(wData), "testdata/expected_kct_vcenter_tags.yaml")
 }
+
+func TestVsphereTemplateBuilderGenerateCAPISpecAdditionalNetworksAndDisks(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	additionalNetworks := []v1alpha1.VSphereMachineNetwork{
+		{
+			Name: "/SDDC-Datacenter/network/storage-vlan",
+		},
+		{
+			Name: "/SDDC-Datacenter/network/database-vlan",
+			IPPool: &v1alpha1.VSphereIPPoolReference{
+				APIGroup: v1alpha1.DefaultVSphereIPPoolAPIGroup,
+				Kind:     v1alpha1.DefaultVSphereIPPoolKind,
+				Name:     "database-pool",
+			},
+		},
+	}
+	additionalDisks := []v1alpha1.VSphereMachineDisk{
+		{SizeGiB: 100, MountPath: "/var/lib/data"},
+		{SizeGiB: 50},
+		{SizeGiB: 200, MountPath: "/var/lib/logs"},
+	}
+	for _, machineConfig := range spec.VSphereMachineConfigs {
+		machineConfig.Spec.AdditionalNetworks = additionalNetworks
+	}
+	controlPlaneMachineConfigName := spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name
+	spec.VSphereMachineConfigs[controlPlaneMachineConfigName].Spec.AdditionalDisks = additionalDisks
+	etcdMachineConfigName := spec.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef.Name
+	spec.VSphereMachineConfigs[etcdMachineConfigName].Spec.AdditionalDisks = []v1alpha1.VSphereMachineDisk{{SizeGiB: 20}}
+	workerMachineConfigName := spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
+	spec.VSphereMachineConfigs[workerMachineConfigName].Spec.AdditionalDisks = additionalDisks
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	cpData, err := builder.GenerateCAPISpecControlPlane(spec, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(cpData), "testdata/expected_kcp_additional_networks_disks.yaml")
+	wData, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(wData), "testdata/expected_kct_additional_networks_disks.yaml")
+}
+
+func TestVsphereTemplateBuilderDiskMountScriptSyntax(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not found")
+	}
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	for _, machineConfig := range spec.VSphereMachineConfigs {
+		machineConfig.Spec.AdditionalDisks = []v1alpha1.VSphereMachineDisk{{SizeGiB: 100, MountPath: "/var/lib/data"}}
+	}
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	cpData, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).ToNot(HaveOccurred())
+	wData, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	scripts := []string{
+		fileContent(t, cpData, "KubeadmControlPlane", "/usr/local/bin/eksa-mount-disk.sh", "spec", "kubeadmConfigSpec", "files"),
+		fileContent(t, wData, "KubeadmConfigTemplate", "/usr/local/bin/eksa-mount-disk.sh", "spec", "template", "spec", "files"),
+	}
+	for _, script := range scripts {
+		g.Expect(script).To(HaveSuffix("\nfi\n"))
+		path := filepath.Join(t.TempDir(), "eksa-mount-disk.sh")
+		g.Expect(os.WriteFile(path, []byte(script), 0o600)).To(Succeed())
+		out, err := exec.Command(bash, "-n", path).CombinedOutput()
+		g.Expect(err).NotTo(HaveOccurred(), string(out))
+	}
+}
+
+// fileContent returns the content of the file with path in the files field of the first object of kind in data.
+func fileContent(t *testing.T, data []byte, kind, path string, filesField ...string) string {
+	t.Helper()
+	decoder := apiyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		o := &unstructured.Unstructured{}
+		if err := decoder.Decode(&o.Object); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		if o.GetKind() != kind {
+			continue
+		}
+		files, _, err := unstructured.NestedSlice(o.Object, filesField...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			if file := f.(map[string]interface{}); file["path"] == path {
+				return file["content"].(string)
+			}
+		}
+	}
+	t.Fatalf("file %s not found in %s", path, kind)
+	return ""
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecPCIDevices(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	workerMachineConfigName := spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef.Name
+	spec.VSphereMachineConfigs[workerMachineConfigName].Spec.PCIDevices = []v1alpha1.VSpherePCIDevice{
+		{DeviceID: 8373, VendorID: 4318},
+		{DeviceID: 8373, VendorID: 4318},
+	}
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	wData, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(wData), "testdata/expected_results_main_pci_devices_md.yaml")
+}
+
+func TestVsphereTemplateBuilderGenerateCAPISpecIPPool(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.VSphereIPPools = map[string]*v1alpha1.VSphereIPPool{
+		"node-pool": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-pool",
+			},
+			Spec: v1alpha1.VSphereIPPoolSpec{
+				Addresses:   []string{"10.0.0.10-10.0.0.50"},
+				Prefix:      24,
+				Gateway:     "10.0.0.1",
+				Nameservers: []string{"10.0.0.2", "10.0.0.3"},
+			},
+		},
+	}
+	for _, machineConfig := range spec.VSphereMachineConfigs {
+		machineConfig.Spec.IPPool = &v1alpha1.VSphereIPPoolReference{
+			APIGroup: v1alpha1.DefaultVSphereIPPoolAPIGroup,
+			Kind:     v1alpha1.DefaultVSphereIPPoolKind,
+			Name:     "node-pool",
+		}
+	}
+
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+	cpData, err := builder.GenerateCAPISpecControlPlane(spec, func(values map[string]interface{}) {
+		values["controlPlaneTemplateName"] = clusterapi.ControlPlaneMachineTemplateName(spec.Cluster)
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(cpData), "testdata/expected_kcp_ip_pool.yaml")
+	wData, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(wData), "testdata/expected_kct_ip_pool.yaml")
+}
@@ -8,10 +8,12 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"sort"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/collection"
 	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/types"
@@ -222,10 +224,34 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 		}
 	}
 
+	if err := v.validateAdditionalNetworks(ctx, vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if err := v.validatePCIDevices(ctx, vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if err := v.validateIPPools(vsphereClusterSpec); err != nil {
+		return err
+	}
+
+	if etcdMachineConfig != nil {
+		for _, disk := range etcdMachineConfig.Spec.AdditionalDisks {
+			if disk.MountPath != "" {
+				return fmt.Errorf("VSphereMachineConfig %s: mounting additional disks is not supported for etcd machines", etcdMachineConfig.Name)
+			}
+		}
+	}
+
 	if err := v.validateTemplates(ctx, vsphereClusterSpec); err != nil {
 		return err
 	}
 
+	if err := v.validateAdditionalDisks(ctx, vsphereClusterSpec); err != nil {
+		return err
+	}
+
 	if err := v.validateMachineConfigTagsExist(ctx, vsphereClusterSpec.machineConfigs()); err != nil {
 		return err
 	}
@@ -243,6 +269,249 @@ func (v *Validator) ValidateClusterMachineConfigs(ctx context.Context, vsphereCl
 	return nil
 }
 
+// validateAdditionalNetworks validates the additional networks of the machine configs exist,
+// setting their full path.
+func (v *Validator) validateAdditionalNetworks(ctx context.Context, vsphereClusterSpec *Spec) error {
+	for _, config := range vsphereClusterSpec.VSphereMachineConfigs {
+		for i := range config.Spec.AdditionalNetworks {
+			network := &config.Spec.AdditionalNetworks[i]
+			network.Name = vsphereClusterSpec.VSphereDatacenter.NetworkPath(network.Name)
+			if err := v.validateNetwork(ctx, network.Name); err != nil {
+				return fmt.Errorf("validating additional networks for VSphereMachineConfig %s: %v", config.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAdditionalDisks validates the templates of the machine configs with additional disks
+// have a disk after the boot disk for each of them. CAPV resizes those template disks to the
+// additional disk sizes, it doesn't create new disks.
+func (v *Validator) validateAdditionalDisks(ctx context.Context, vsphereClusterSpec *Spec) error {
+	datacenter := vsphereClusterSpec.VSphereDatacenter.Spec.Datacenter
+	for _, config := range vsphereClusterSpec.VSphereMachineConfigs {
+		if len(config.Spec.AdditionalDisks) == 0 {
+			continue
+		}
+		disks, err := v.govc.GetVMDiskCount(ctx, config.Spec.Template, datacenter)
+		if err != nil {
+			return fmt.Errorf("validating additional disks for VSphereMachineConfig %s: %v", config.Name, err)
+		}
+		if disks-1 < len(config.Spec.AdditionalDisks) {
+			return fmt.Errorf("VSphereMachineConfig %s has %d additional disks but template %s has %d disks after the boot disk",
+				config.Name, len(config.Spec.AdditionalDisks), config.Spec.Template, disks-1)
+		}
+	}
+	return nil
+}
+
+// validatePCIDevices validates that, for each machine config requesting PCI devices, every compute
+// resource its machines can be placed in has at least one ESXi host with passthrough active for
+// all the devices. Machines are placed in the compute resource of the failure domain of their
+// worker node group or, otherwise, in the one of their resource pool.
+//
+// TODO: validate vGPU profiles against the hosts once CAPV renders them and they can be configured
+// in VSphereMachineConfig. Only whole PCI devices are passed through for now.
+func (v *Validator) validatePCIDevices(ctx context.Context, vsphereClusterSpec *Spec) error {
+	requested := false
+	for _, mc := range vsphereClusterSpec.VSphereMachineConfigs {
+		requested = requested || len(mc.Spec.PCIDevices) > 0
+	}
+	if !requested {
+		return nil
+	}
+
+	datacenter := vsphereClusterSpec.VSphereDatacenter.Spec.Datacenter
+	failureDomains := map[string]anywherev1.FailureDomain{}
+	for _, fd := range vsphereClusterSpec.VSphereDatacenter.Spec.FailureDomains {
+		failureDomains[fd.Name] = fd
+	}
+
+	// Machines are placed in the compute resource of a failure domain, or of a resource pool,
+	// which is only resolved for the machine configs requesting PCI devices.
+	type placement struct {
+		resourcePool   string
+		computeCluster string
+	}
+	placements := map[*anywherev1.VSphereMachineConfig][]placement{}
+	if cp := vsphereClusterSpec.controlPlaneMachineConfig(); cp != nil {
+		placements[cp] = []placement{{resourcePool: cp.Spec.ResourcePool}}
+	}
+	if etcd := vsphereClusterSpec.etcdMachineConfig(); vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration != nil && etcd != nil {
+		placements[etcd] = append(placements[etcd], placement{resourcePool: etcd.Spec.ResourcePool})
+	}
+	for _, wng := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		mc := vsphereClusterSpec.workerMachineConfig(wng)
+		if mc == nil {
+			continue
+		}
+		if len(wng.FailureDomains) == 0 {
+			placements[mc] = append(placements[mc], placement{resourcePool: mc.Spec.ResourcePool})
+		}
+		for _, name := range wng.FailureDomains {
+			if fd, ok := failureDomains[name]; ok {
+				placements[mc] = append(placements[mc], placement{computeCluster: fd.ComputeCluster})
+			}
+		}
+	}
+
+	machineConfigs := make([]*anywherev1.VSphereMachineConfig, 0, len(placements))
+	for mc := range placements {
+		if len(mc.Spec.PCIDevices) > 0 {
+			machineConfigs = append(machineConfigs, mc)
+		}
+	}
+	sort.Slice(machineConfigs, func(i, j int) bool {
+		return machineConfigs[i].Name < machineConfigs[j].Name
+	})
+
+	poolComputeResources := map[string]string{}
+	hostDevices := map[string]map[string][]executables.PCIPassthroughDevice{}
+	for _, mc := range machineConfigs {
+		for _, p := range placements[mc] {
+			computeResource := p.computeCluster
+			if p.resourcePool != "" {
+				var ok bool
+				if computeResource, ok = poolComputeResources[p.resourcePool]; !ok {
+					var err error
+					if computeResource, err = v.govc.GetResourcePoolComputeResourcePath(ctx, datacenter, p.resourcePool); err != nil {
+						return fmt.Errorf("validating PCI devices for VSphereMachineConfig %s: %v", mc.Name, err)
+					}
+					poolComputeResources[p.resourcePool] = computeResource
+				}
+			}
+
+			hosts, ok := hostDevices[computeResource]
+			if !ok {
+				var err error
+				if hosts, err = v.govc.GetHostsPCIPassthroughDevices(ctx, datacenter, computeResource); err != nil {
+					return fmt.Errorf("validating PCI devices for VSphereMachineConfig %s: %v", mc.Name, err)
+				}
+				hostDevices[computeResource] = hosts
+			}
+
+			if !anyHostHasPCIDevices(hosts, mc.Spec.PCIDevices) {
+				return fmt.Errorf("validating PCI devices for VSphereMachineConfig %s: no ESXi host in %s has passthrough active for all the requested PCI devices", mc.Name, computeResource)
+			}
+		}
+	}
+
+	logger.MarkPass("Machine config PCI devices validated")
+	return nil
+}
+
+// anyHostHasPCIDevices returns true if a host has at least as many passthrough devices of each
+// vendor and device ID as requested.
+func anyHostHasPCIDevices(hosts map[string][]executables.PCIPassthroughDevice, requested []anywherev1.VSpherePCIDevice) bool {
+	for _, devices := range hosts {
+		available := map[anywherev1.VSpherePCIDevice]int{}
+		for _, d := range devices {
+			available[anywherev1.VSpherePCIDevice{DeviceID: d.DeviceID, VendorID: d.VendorID}]++
+		}
+
+		found := true
+		for _, r := range requested {
+			if available[r] == 0 {
+				found = false
+				break
+			}
+			available[r]--
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// validateIPPools validates every VSphereIPPool has enough addresses for all the machines
+// using it, counting the extra machines created during rolling upgrades, and that the
+// control plane endpoint can't be allocated to a machine.
+func (v *Validator) validateIPPools(vsphereClusterSpec *Spec) error {
+	needed := map[string]int{}
+	addMachines := func(machineConfig *anywherev1.VSphereMachineConfig, machines int) {
+		for _, ref := range machineConfig.IPPoolRefs() {
+			if ref.IsVSphereIPPool() {
+				needed[ref.Name] += machines
+			}
+		}
+	}
+
+	cp := vsphereClusterSpec.Cluster.Spec.ControlPlaneConfiguration
+	addMachines(vsphereClusterSpec.controlPlaneMachineConfig(), cp.Count+controlPlaneMaxSurge(cp))
+	if etcd := vsphereClusterSpec.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		// etcdadm clusters are upgraded creating one new machine at a time.
+		addMachines(vsphereClusterSpec.etcdMachineConfig(), etcd.Count+1)
+	}
+	for _, wn := range vsphereClusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		addMachines(vsphereClusterSpec.workerMachineConfig(wn), workerMaxCount(wn)+workerMaxSurge(wn))
+	}
+
+	if len(needed) == 0 && len(vsphereClusterSpec.VSphereIPPools) == 0 {
+		return nil
+	}
+
+	referenced := make([]string, 0, len(needed))
+	for name := range needed {
+		referenced = append(referenced, name)
+	}
+	sort.Strings(referenced)
+	for _, name := range referenced {
+		if _, ok := vsphereClusterSpec.VSphereIPPools[name]; !ok {
+			return fmt.Errorf("VSphereIPPool %s is referenced by machine configs but not found in the cluster spec", name)
+		}
+	}
+
+	names := make([]string, 0, len(vsphereClusterSpec.VSphereIPPools))
+	for name := range vsphereClusterSpec.VSphereIPPools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	endpoint := cp.Endpoint.Host
+	for _, name := range names {
+		pool := vsphereClusterSpec.VSphereIPPools[name]
+		size, err := pool.Size()
+		if err != nil {
+			return err
+		}
+		if size < needed[name] {
+			return fmt.Errorf("VSphereIPPool %s has %d addresses but %d are needed for the machines using it, including rolling upgrades", name, size, needed[name])
+		}
+		if contains, _ := pool.Contains(endpoint); contains {
+			return fmt.Errorf("VSphereIPPool %s contains the control plane endpoint %s", name, endpoint)
+		}
+	}
+
+	logger.MarkPass("VSphereIPPools validated")
+	return nil
+}
+
+func controlPlaneMaxSurge(cp anywherev1.ControlPlaneConfiguration) int {
+	if cp.UpgradeRolloutStrategy != nil && cp.UpgradeRolloutStrategy.RollingUpdate != nil {
+		return cp.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+	return 1
+}
+
+func workerMaxSurge(wn anywherev1.WorkerNodeGroupConfiguration) int {
+	if wn.UpgradeRolloutStrategy != nil && wn.UpgradeRolloutStrategy.RollingUpdate != nil {
+		return wn.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
+	}
+	return 1
+}
+
+func workerMaxCount(wn anywherev1.WorkerNodeGroupConfiguration) int {
+	count := 0
+	if wn.Count != nil {
+		count = *wn.Count
+	}
+	if wn.AutoScalingConfiguration != nil && wn.AutoScalingConfiguration.MaxCount > count {
+		count = wn.AutoScalingConfiguration.MaxCount
+	}
+	return count
+}
+
 func (v *Validator) validateControlPlaneIp(ip string) error {
 	// check if controlPlaneEndpointIp is valid
 	parsedIp := net.ParseIP(ip)
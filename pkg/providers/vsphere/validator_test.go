@@ -10,6 +10,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/govmomi"
 	"github.com/aws/eks-anywhere/pkg/govmomi/mocks"
 	govcmocks "github.com/aws/eks-anywhere/pkg/providers/vsphere/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
@@ -1066,3 +1068,458 @@ func TestValidator_validateTemplates(t *testing.T) {
 		})
 	}
 }
+
+func additionalNetworksSpec() *Spec {
+	return &Spec{
+		Spec: &cluster.Spec{
+			Config: &cluster.Config{
+				VSphereDatacenter: &v1alpha1.VSphereDatacenterConfig{
+					Spec: v1alpha1.VSphereDatacenterConfigSpec{
+						Datacenter: "myDatacenter",
+					},
+				},
+				VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{
+					"workers": {
+						Spec: v1alpha1.VSphereMachineConfigSpec{
+							AdditionalNetworks: []v1alpha1.VSphereMachineNetwork{
+								{Name: "storage"},
+								{Name: "/myDatacenter/network/folder/database"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidatorValidateAdditionalNetworksSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := additionalNetworksSpec()
+
+	govc.EXPECT().NetworkExists(ctx, "/myDatacenter/network/storage").Return(true, nil)
+	govc.EXPECT().NetworkExists(ctx, "/myDatacenter/network/folder/database").Return(true, nil)
+
+	g.Expect(v.validateAdditionalNetworks(ctx, spec)).To(Succeed())
+	g.Expect(spec.VSphereMachineConfigs["workers"].Spec.AdditionalNetworks).To(Equal([]v1alpha1.VSphereMachineNetwork{
+		{Name: "/myDatacenter/network/storage"},
+		{Name: "/myDatacenter/network/folder/database"},
+	}))
+}
+
+func TestValidatorValidateAdditionalNetworksNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := additionalNetworksSpec()
+
+	govc.EXPECT().NetworkExists(ctx, "/myDatacenter/network/storage").Return(false, nil)
+
+	g.Expect(v.validateAdditionalNetworks(ctx, spec)).To(MatchError(ContainSubstring("network /myDatacenter/network/storage not found")))
+}
+
+func additionalDisksSpec() *Spec {
+	return &Spec{
+		Spec: &cluster.Spec{
+			Config: &cluster.Config{
+				VSphereDatacenter: &v1alpha1.VSphereDatacenterConfig{
+					Spec: v1alpha1.VSphereDatacenterConfigSpec{
+						Datacenter: "myDatacenter",
+					},
+				},
+				VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{
+					"cp": {
+						ObjectMeta: metav1.ObjectMeta{Name: "cp"},
+						Spec: v1alpha1.VSphereMachineConfigSpec{
+							Template: "/myDatacenter/vm/ubuntu",
+						},
+					},
+					"workers": {
+						ObjectMeta: metav1.ObjectMeta{Name: "workers"},
+						Spec: v1alpha1.VSphereMachineConfigSpec{
+							Template: "/myDatacenter/vm/ubuntu-data",
+							AdditionalDisks: []v1alpha1.VSphereMachineDisk{
+								{SizeGiB: 100, MountPath: "/var/lib/data"},
+								{SizeGiB: 50},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidatorValidateAdditionalDisksSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+
+	govc.EXPECT().GetVMDiskCount(ctx, "/myDatacenter/vm/ubuntu-data", "myDatacenter").Return(3, nil)
+
+	g.Expect(v.validateAdditionalDisks(ctx, additionalDisksSpec())).To(Succeed())
+}
+
+func TestValidatorValidateAdditionalDisksNotInTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+
+	govc.EXPECT().GetVMDiskCount(ctx, "/myDatacenter/vm/ubuntu-data", "myDatacenter").Return(2, nil)
+
+	g.Expect(v.validateAdditionalDisks(ctx, additionalDisksSpec())).To(MatchError(
+		"VSphereMachineConfig workers has 2 additional disks but template /myDatacenter/vm/ubuntu-data has 1 disks after the boot disk",
+	))
+}
+
+func TestValidatorValidateAdditionalDisksGovcError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+
+	govc.EXPECT().GetVMDiskCount(ctx, "/myDatacenter/vm/ubuntu-data", "myDatacenter").Return(0, errors.New("not found"))
+
+	g.Expect(v.validateAdditionalDisks(ctx, additionalDisksSpec())).To(MatchError(
+		"validating additional disks for VSphereMachineConfig workers: not found",
+	))
+}
+
+func pciDevicesSpec() *Spec {
+	return &Spec{
+		Spec: &cluster.Spec{
+			Config: &cluster.Config{
+				Cluster: &v1alpha1.Cluster{
+					Spec: v1alpha1.ClusterSpec{
+						ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+							MachineGroupRef: &v1alpha1.Ref{Name: "cp"},
+						},
+						WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+							{
+								Name:            "gpu",
+								MachineGroupRef: &v1alpha1.Ref{Name: "gpu"},
+								FailureDomains:  []string{"fd-1"},
+							},
+						},
+					},
+				},
+				VSphereDatacenter: &v1alpha1.VSphereDatacenterConfig{
+					Spec: v1alpha1.VSphereDatacenterConfigSpec{
+						Datacenter: "myDatacenter",
+						FailureDomains: []v1alpha1.FailureDomain{
+							{Name: "fd-1", ComputeCluster: "gpu-cluster"},
+						},
+					},
+				},
+				VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{
+					"cp": {
+						ObjectMeta: metav1.ObjectMeta{Name: "cp"},
+						Spec: v1alpha1.VSphereMachineConfigSpec{
+							ResourcePool: "pool",
+							PCIDevices:   []v1alpha1.VSpherePCIDevice{{DeviceID: 8373, VendorID: 4318}},
+						},
+					},
+					"gpu": {
+						ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+						Spec: v1alpha1.VSphereMachineConfigSpec{
+							ResourcePool: "*/Resources",
+							PCIDevices: []v1alpha1.VSpherePCIDevice{
+								{DeviceID: 8373, VendorID: 4318},
+								{DeviceID: 8373, VendorID: 4318},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidatorValidatePCIDevicesSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := pciDevicesSpec()
+
+	govc.EXPECT().GetResourcePoolComputeResourcePath(ctx, "myDatacenter", "pool").Return("/myDatacenter/host/cluster", nil)
+	govc.EXPECT().GetHostsPCIPassthroughDevices(ctx, "myDatacenter", "/myDatacenter/host/cluster").Return(map[string][]executables.PCIPassthroughDevice{
+		"/myDatacenter/host/cluster/host-1": {},
+		"/myDatacenter/host/cluster/host-2": {{ID: "0000:3b:00.0", DeviceID: 8373, VendorID: 4318}},
+	}, nil)
+	govc.EXPECT().GetHostsPCIPassthroughDevices(ctx, "myDatacenter", "gpu-cluster").Return(map[string][]executables.PCIPassthroughDevice{
+		"/myDatacenter/host/gpu-cluster/host-1": {
+			{ID: "0000:3b:00.0", DeviceID: 8373, VendorID: 4318},
+			{ID: "0000:d8:00.0", DeviceID: 8373, VendorID: 4318},
+		},
+	}, nil)
+
+	g.Expect(v.validatePCIDevices(ctx, spec)).To(Succeed())
+}
+
+func TestValidatorValidatePCIDevicesNotEnoughDevicesInHost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := pciDevicesSpec()
+	spec.VSphereMachineConfigs["cp"].Spec.PCIDevices = nil
+
+	govc.EXPECT().GetHostsPCIPassthroughDevices(ctx, "myDatacenter", "gpu-cluster").Return(map[string][]executables.PCIPassthroughDevice{
+		"/myDatacenter/host/gpu-cluster/host-1": {{ID: "0000:3b:00.0", DeviceID: 8373, VendorID: 4318}},
+		"/myDatacenter/host/gpu-cluster/host-2": {{ID: "0000:3b:00.0", DeviceID: 8373, VendorID: 4318}},
+	}, nil)
+
+	g.Expect(v.validatePCIDevices(ctx, spec)).To(MatchError(ContainSubstring("VSphereMachineConfig gpu: no ESXi host in gpu-cluster has passthrough active for all the requested PCI devices")))
+}
+
+func TestValidatorValidatePCIDevicesMachineConfigsInNameOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := pciDevicesSpec()
+
+	// Both machine configs fail, the first one by name is always reported.
+	govc.EXPECT().GetResourcePoolComputeResourcePath(ctx, "myDatacenter", "pool").Return("/myDatacenter/host/cluster", nil)
+	govc.EXPECT().GetHostsPCIPassthroughDevices(ctx, "myDatacenter", "/myDatacenter/host/cluster").Return(map[string][]executables.PCIPassthroughDevice{
+		"/myDatacenter/host/cluster/host-1": {},
+	}, nil)
+
+	g.Expect(v.validatePCIDevices(ctx, spec)).To(MatchError(ContainSubstring("VSphereMachineConfig cp: no ESXi host in /myDatacenter/host/cluster has passthrough active for all the requested PCI devices")))
+}
+
+func TestValidatorValidatePCIDevicesGovcError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := pciDevicesSpec()
+	spec.VSphereMachineConfigs["gpu"].Spec.PCIDevices = nil
+
+	govc.EXPECT().GetResourcePoolComputeResourcePath(ctx, "myDatacenter", "pool").Return("/myDatacenter/host/cluster", nil)
+	govc.EXPECT().GetHostsPCIPassthroughDevices(ctx, "myDatacenter", "/myDatacenter/host/cluster").Return(nil, errors.New("no hosts found"))
+
+	g.Expect(v.validatePCIDevices(ctx, spec)).To(MatchError(ContainSubstring("validating PCI devices for VSphereMachineConfig cp: no hosts found")))
+}
+
+func TestValidatorValidatePCIDevicesResourcePoolError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	ctx := context.Background()
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := pciDevicesSpec()
+	spec.VSphereMachineConfigs["cp"].Spec.PCIDevices = nil
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].FailureDomains = nil
+
+	govc.EXPECT().GetResourcePoolComputeResourcePath(ctx, "myDatacenter", "*/Resources").Return("", errors.New("specified resource pool 'Resources' maps to multiple paths within the datacenter 'myDatacenter'"))
+
+	g.Expect(v.validatePCIDevices(ctx, spec)).To(MatchError(
+		"validating PCI devices for VSphereMachineConfig gpu: specified resource pool 'Resources' maps to multiple paths within the datacenter 'myDatacenter'",
+	))
+}
+
+func TestValidatorValidatePCIDevicesNoneRequested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	govc := govcmocks.NewMockProviderGovcClient(ctrl)
+	g := NewWithT(t)
+	v := Validator{
+		govc: govc,
+	}
+	spec := pciDevicesSpec()
+	for _, mc := range spec.VSphereMachineConfigs {
+		mc.Spec.PCIDevices = nil
+	}
+
+	g.Expect(v.validatePCIDevices(context.Background(), spec)).To(Succeed())
+}
+
+func ipPoolsSpec() *Spec {
+	nodesPool := &v1alpha1.VSphereIPPoolReference{Name: "nodes"}
+	return &Spec{
+		Spec: &cluster.Spec{
+			Config: &cluster.Config{
+				Cluster: &v1alpha1.Cluster{
+					Spec: v1alpha1.ClusterSpec{
+						ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+							Count:           3,
+							Endpoint:        &v1alpha1.Endpoint{Host: "10.0.0.5"},
+							MachineGroupRef: &v1alpha1.Ref{Name: "cp"},
+						},
+						ExternalEtcdConfiguration: &v1alpha1.ExternalEtcdConfiguration{
+							Count:           3,
+							MachineGroupRef: &v1alpha1.Ref{Name: "etcd"},
+						},
+						WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+							{
+								Name:            "workers",
+								Count:           ptr.Int(2),
+								MachineGroupRef: &v1alpha1.Ref{Name: "workers"},
+								AutoScalingConfiguration: &v1alpha1.AutoScalingConfiguration{
+									MinCount: 2,
+									MaxCount: 5,
+								},
+								UpgradeRolloutStrategy: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+									RollingUpdate: &v1alpha1.WorkerNodesRollingUpdateParams{MaxSurge: 2},
+								},
+							},
+						},
+					},
+				},
+				VSphereMachineConfigs: map[string]*v1alpha1.VSphereMachineConfig{
+					"cp": {
+						ObjectMeta: metav1.ObjectMeta{Name: "cp"},
+						Spec:       v1alpha1.VSphereMachineConfigSpec{IPPool: nodesPool},
+					},
+					"etcd": {
+						ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+						Spec:       v1alpha1.VSphereMachineConfigSpec{IPPool: nodesPool},
+					},
+					"workers": {
+						ObjectMeta: metav1.ObjectMeta{Name: "workers"},
+						Spec: v1alpha1.VSphereMachineConfigSpec{
+							IPPool: nodesPool,
+							AdditionalNetworks: []v1alpha1.VSphereMachineNetwork{
+								{Name: "storage", IPPool: &v1alpha1.VSphereIPPoolReference{Name: "storage"}},
+							},
+						},
+					},
+				},
+				VSphereIPPools: map[string]*v1alpha1.VSphereIPPool{
+					"nodes": {
+						ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+						Spec: v1alpha1.VSphereIPPoolSpec{
+							Addresses: []string{"10.0.0.10-10.0.0.24"},
+							Prefix:    24,
+							Gateway:   "10.0.0.1",
+						},
+					},
+					"storage": {
+						ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+						Spec: v1alpha1.VSphereIPPoolSpec{
+							Addresses: []string{"10.1.0.10-10.1.0.16"},
+							Prefix:    24,
+							Gateway:   "10.1.0.1",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidatorValidateIPPoolsSuccess(t *testing.T) {
+	g := NewWithT(t)
+	v := Validator{}
+
+	g.Expect(v.validateIPPools(ipPoolsSpec())).To(Succeed())
+}
+
+func TestValidatorValidateIPPoolsNoPools(t *testing.T) {
+	g := NewWithT(t)
+	v := Validator{}
+	spec := ipPoolsSpec()
+	spec.VSphereIPPools = nil
+	for _, machineConfig := range spec.VSphereMachineConfigs {
+		machineConfig.Spec.IPPool = nil
+		machineConfig.Spec.AdditionalNetworks = nil
+	}
+
+	g.Expect(v.validateIPPools(spec)).To(Succeed())
+}
+
+func TestValidatorValidateIPPoolsNotFound(t *testing.T) {
+	g := NewWithT(t)
+	v := Validator{}
+	spec := ipPoolsSpec()
+	delete(spec.VSphereIPPools, "storage")
+
+	g.Expect(v.validateIPPools(spec)).To(MatchError("VSphereIPPool storage is referenced by machine configs but not found in the cluster spec"))
+}
+
+func TestValidatorValidateIPPoolsTooSmall(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(spec *Spec)
+		wantErr string
+	}{
+		{
+			name: "control plane surge",
+			modify: func(spec *Spec) {
+				spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+					RollingUpdate: &v1alpha1.ControlPlaneRollingUpdateParams{MaxSurge: 2},
+				}
+			},
+			wantErr: "VSphereIPPool nodes has 15 addresses but 16 are needed",
+		},
+		{
+			name: "worker autoscaling",
+			modify: func(spec *Spec) {
+				spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration.MaxCount = 6
+			},
+			wantErr: "has 15 addresses but 16 are needed",
+		},
+		{
+			name: "additional network pool",
+			modify: func(spec *Spec) {
+				spec.VSphereIPPools["storage"].Spec.Addresses = []string{"10.1.0.10-10.1.0.15"}
+			},
+			wantErr: "VSphereIPPool storage has 6 addresses but 7 are needed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			v := Validator{}
+			spec := ipPoolsSpec()
+			tt.modify(spec)
+
+			g.Expect(v.validateIPPools(spec)).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestValidatorValidateIPPoolsControlPlaneEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	v := Validator{}
+	spec := ipPoolsSpec()
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "10.0.0.20"
+
+	g.Expect(v.validateIPPools(spec)).To(MatchError("VSphereIPPool nodes contains the control plane endpoint 10.0.0.20"))
+}
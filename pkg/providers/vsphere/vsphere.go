@@ -113,6 +113,7 @@ type ProviderGovcClient interface {
 	DeployTemplateFromLibrary(ctx context.Context, templateDir, templateName, library, datacenter, datastore, network, resourcePool string, resizeDisk2 bool) error
 	ImportTemplate(ctx context.Context, library, ovaURL, name string) error
 	GetVMDiskSizeInGB(ctx context.Context, vm, datacenter string) (int, error)
+	GetVMDiskCount(ctx context.Context, vm, datacenter string) (int, error)
 	GetTags(ctx context.Context, path string) (tags []string, err error)
 	ListTags(ctx context.Context) ([]executables.Tag, error)
 	CreateTag(ctx context.Context, tag, category string) error
@@ -129,6 +130,8 @@ type ProviderGovcClient interface {
 	SetGroupRoleOnObject(ctx context.Context, principal, role, object, domain string) error
 	GetHardDiskSize(ctx context.Context, vm, datacenter string) (map[string]float64, error)
 	GetResourcePoolInfo(ctx context.Context, datacenter, resourcepool string, args ...string) (map[string]int, error)
+	GetHostsPCIPassthroughDevices(ctx context.Context, datacenter, computeResource string) (map[string][]executables.PCIPassthroughDevice, error)
+	GetResourcePoolComputeResourcePath(ctx context.Context, datacenter, resourcePool string) (string, error)
 }
 
 type ProviderKubectlClient interface {
@@ -746,6 +749,9 @@ func NeedsNewControlPlaneTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if ipPoolNameserversChanged(oldSpec, newSpec, oldVmc, newVmc) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
@@ -759,12 +765,16 @@ func NeedsNewWorkloadTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1
 		!v1alpha1.WorkerNodeGroupConfigurationKubeVersionUnchanged(&oldWorker, &newWorker, oldSpec.Cluster, newSpec.Cluster) {
 		return true
 	}
+	if ipPoolNameserversChanged(oldSpec, newSpec, oldVmc, newVmc) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
 func NeedsNewKubeadmConfigTemplate(newWorkerNodeGroup, oldWorkerNodeGroup *v1alpha1.WorkerNodeGroupConfiguration, oldWorkerNodeVmc, newWorkerNodeVmc *v1alpha1.VSphereMachineConfig) bool {
 	return !v1alpha1.TaintsSliceEqual(newWorkerNodeGroup.Taints, oldWorkerNodeGroup.Taints) || !v1alpha1.MapEqual(newWorkerNodeGroup.Labels, oldWorkerNodeGroup.Labels) ||
-		!v1alpha1.UsersSliceEqual(oldWorkerNodeVmc.Spec.Users, newWorkerNodeVmc.Spec.Users)
+		!v1alpha1.UsersSliceEqual(oldWorkerNodeVmc.Spec.Users, newWorkerNodeVmc.Spec.Users) ||
+		!reflect.DeepEqual(additionalDiskMounts(oldWorkerNodeVmc.Spec), additionalDiskMounts(newWorkerNodeVmc.Spec))
 }
 
 func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
@@ -774,6 +784,9 @@ func NeedsNewEtcdTemplate(oldSpec, newSpec *cluster.Spec, oldVdc, newVdc *v1alph
 	if oldSpec.Bundles.Spec.Number != newSpec.Bundles.Spec.Number {
 		return true
 	}
+	if ipPoolNameserversChanged(oldSpec, newSpec, oldVmc, newVmc) {
+		return true
+	}
 	return AnyImmutableFieldChanged(oldVdc, newVdc, oldVmc, newVmc)
 }
 
@@ -805,9 +818,27 @@ func AnyImmutableFieldChanged(oldVdc, newVdc *v1alpha1.VSphereDatacenterConfig,
 	if oldVmc.Spec.Template != newVmc.Spec.Template {
 		return true
 	}
+	if !reflect.DeepEqual(oldVmc.Spec.AdditionalNetworks, newVmc.Spec.AdditionalNetworks) {
+		return true
+	}
+	if !reflect.DeepEqual(oldVmc.Spec.AdditionalDisks, newVmc.Spec.AdditionalDisks) {
+		return true
+	}
+	if !reflect.DeepEqual(oldVmc.Spec.PCIDevices, newVmc.Spec.PCIDevices) {
+		return true
+	}
+	if !reflect.DeepEqual(oldVmc.Spec.IPPool, newVmc.Spec.IPPool) {
+		return true
+	}
 	return false
 }
 
+// ipPoolNameserversChanged returns true if the nameservers the machines get from their
+// VSphereIPPool changed, since they are rendered in the machine template.
+func ipPoolNameserversChanged(oldSpec, newSpec *cluster.Spec, oldVmc, newVmc *v1alpha1.VSphereMachineConfig) bool {
+	return !reflect.DeepEqual(ipPoolNameservers(oldSpec, oldVmc.Spec.IPPool), ipPoolNameservers(newSpec, newVmc.Spec.IPPool))
+}
+
 func (p *vsphereProvider) generateCAPISpecForUpgrade(ctx context.Context, bootstrapCluster, workloadCluster *types.Cluster, currentSpec, newClusterSpec *cluster.Spec) (controlPlaneSpec, workersSpec []byte, err error) {
 	clusterName := newClusterSpec.Cluster.Name
 	var controlPlaneTemplateName, workloadTemplateName, kubeadmconfigTemplateName, etcdTemplateName string
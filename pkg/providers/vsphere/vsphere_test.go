@@ -177,6 +177,10 @@ func (pc *DummyProviderGovcClient) GetVMDiskSizeInGB(ctx context.Context, vm, da
 	return 25, nil
 }
 
+func (pc *DummyProviderGovcClient) GetVMDiskCount(ctx context.Context, vm, datacenter string) (int, error) {
+	return 1, nil
+}
+
 func (pc *DummyProviderGovcClient) GetHardDiskSize(ctx context.Context, vm, datacenter string) (map[string]float64, error) {
 	return map[string]float64{"Hard disk 1": 23068672}, nil
 }
@@ -185,6 +189,14 @@ func (pc *DummyProviderGovcClient) GetResourcePoolInfo(ctx context.Context, data
 	return map[string]int{"Memory_Available": -1}, nil
 }
 
+func (pc *DummyProviderGovcClient) GetResourcePoolComputeResourcePath(ctx context.Context, datacenter, resourcePool string) (string, error) {
+	return "/" + datacenter + "/host/cluster", nil
+}
+
+func (pc *DummyProviderGovcClient) GetHostsPCIPassthroughDevices(ctx context.Context, datacenter, computeResource string) (map[string][]executables.PCIPassthroughDevice, error) {
+	return map[string][]executables.PCIPassthroughDevice{}, nil
+}
+
 func (pc *DummyProviderGovcClient) GetTags(ctx context.Context, path string) (tags []string, err error) {
 	return []string{eksd119ReleaseTag, eksd121ReleaseTag, eksd129ReleaseTag, pc.osTag}, nil
 }
@@ -1439,6 +1451,73 @@ func TestProviderGenerateDeploymentFileWithMirrorAuth(t *testing.T) {
 	test.AssertContentToFile(t, string(md), "testdata/expected_results_mirror_with_auth_config_md.yaml")
 }
 
+func TestProviderGenerateDeploymentFileWithMirrorFallbacks(t *testing.T) {
+	clusterSpecManifest := "cluster_mirror_with_fallbacks_config.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	t.Setenv("REGISTRY_USERNAME_1", "username")
+	t.Setenv("REGISTRY_PASSWORD_1", "password")
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	ctx := context.Background()
+	govc := NewDummyProviderGovcClient()
+	govc.osTag = ubuntuOSTag
+	ipValidator := mocks.NewMockIPValidator(mockCtrl)
+	ipValidator.EXPECT().ValidateControlPlaneIPUniqueness(clusterSpec.Cluster).Return(nil)
+	provider := newProviderWithKubectl(t, datacenterConfig, clusterSpec.Cluster, kubectl, ipValidator)
+
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_mirror_with_fallbacks_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_mirror_with_fallbacks_config_md.yaml")
+}
+
+func TestProviderGenerateDeploymentFileForBottleRocketWithMirrorFallbacks(t *testing.T) {
+	clusterSpecManifest := "cluster_bottlerocket_mirror_with_fallbacks_config.yaml"
+	mockCtrl := gomock.NewController(t)
+	setupContext(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	cluster := &types.Cluster{Name: "test"}
+	clusterSpec := givenClusterSpec(t, clusterSpecManifest)
+	datacenterConfig := givenDatacenterConfig(t, clusterSpecManifest)
+	ctx := context.Background()
+	govc := NewDummyProviderGovcClient()
+	vscb, _ := newMockVSphereClientBuilder(mockCtrl)
+	ipValidator := mocks.NewMockIPValidator(mockCtrl)
+	ipValidator.EXPECT().ValidateControlPlaneIPUniqueness(clusterSpec.Cluster).Return(nil)
+	v := NewValidator(govc, vscb)
+	govc.osTag = bottlerocketOSTag
+	provider := newProvider(
+		t,
+		datacenterConfig,
+		clusterSpec.Cluster,
+		govc,
+		kubectl,
+		v,
+		ipValidator,
+	)
+	if err := provider.SetupAndValidateCreateCluster(ctx, clusterSpec); err != nil {
+		t.Fatalf("failed to setup and validate: %v", err)
+	}
+
+	cp, md, err := provider.GenerateCAPISpecForCreate(context.Background(), cluster, clusterSpec)
+	if err != nil {
+		t.Fatalf("failed to generate cluster api spec contents: %v", err)
+	}
+
+	test.AssertContentToFile(t, string(cp), "testdata/expected_results_bottlerocket_mirror_with_fallbacks_config_cp.yaml")
+	test.AssertContentToFile(t, string(md), "testdata/expected_results_bottlerocket_mirror_with_fallbacks_config_md.yaml")
+}
+
 func TestProviderGenerateDeploymentFileForBottleRocketWithMultipleOciNamespaces(t *testing.T) {
 	clusterSpecManifest := "cluster_bottlerocket_mirror_config_multiple_ocinamespaces.yaml"
 	mockCtrl := gomock.NewController(t)
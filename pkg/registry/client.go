@@ -41,6 +41,7 @@ func (or *OCIRegistryClient) Init() error {
 			err = fmt.Errorf("error with registry <%s>: %v", or.host, err)
 			return
 		}
+		or.registry.PlainHTTP = or.plainHTTP
 
 		transport := http.DefaultTransport.(*http.Transport).Clone()
 		{ // #nosec G402
@@ -111,6 +112,11 @@ func (or *OCIRegistryClient) CopyGraph(ctx context.Context, srcStorage orasregis
 	return oras.Copy(ctx, srcStorage, srcRef, dstStorage, dstRef, oras.CopyOptions{})
 }
 
+// CopyGraphWithOptions copy manifest and all blobs to destination with the given copy options.
+func (or *OCIRegistryClient) CopyGraphWithOptions(ctx context.Context, srcStorage orasregistry.Repository, srcRef string, dstStorage orasregistry.Repository, dstRef string, opts oras.CopyGraphOptions) (ocispec.Descriptor, error) {
+	return oras.Copy(ctx, srcStorage, srcRef, dstStorage, dstRef, oras.CopyOptions{CopyGraphOptions: opts})
+}
+
 // Tag an image.
 func (or *OCIRegistryClient) Tag(ctx context.Context, dstStorage orasregistry.Repository, desc ocispec.Descriptor, tag string) error {
 	return dstStorage.Tag(ctx, desc, tag)
@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	// CosignSignatureTagSuffix is the suffix of the tag cosign stores the signatures of an image under.
+	CosignSignatureTagSuffix = ".sig"
+	// CosignAttestationTagSuffix is the suffix of the tag cosign stores the attestations of an image under.
+	CosignAttestationTagSuffix = ".att"
+)
+
+// CosignTag returns the tag cosign stores the artifacts with suffix of the manifest with digest under.
+func CosignTag(digest, suffix string) string {
+	return strings.Replace(digest, ":", "-", 1) + suffix
+}
+
+// cosignArtifacts returns the cosign signatures and attestations of image found in the source.
+func cosignArtifacts(ctx context.Context, src StorageClient, image Artifact) ([]Artifact, error) {
+	srcStorage, err := src.GetStorage(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := src.Resolve(ctx, srcStorage, image.VersionedImage())
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []Artifact
+	for _, suffix := range []string{CosignSignatureTagSuffix, CosignAttestationTagSuffix} {
+		artifact := NewArtifact(image.Registry, image.Repository, CosignTag(string(desc.Digest), suffix), "")
+		if _, err := src.Resolve(ctx, srcStorage, artifact.VersionedImage()); err != nil {
+			logger.V(6).Info("No cosign artifact found", "artifact", artifact.VersionedImage(), "error", err)
+			continue
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
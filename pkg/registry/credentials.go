@@ -9,8 +9,9 @@ import (
 
 // CredentialStore for registry credentials such as ~/.docker/config.json.
 type CredentialStore struct {
-	directory  string
-	configFile *configfile.ConfigFile
+	directory   string
+	configFile  *configfile.ConfigFile
+	credentials map[string]auth.Credential
 }
 
 // NewCredentialStore create a credential store.
@@ -20,6 +21,14 @@ func NewCredentialStore() *CredentialStore {
 	}
 }
 
+// NewStaticCredentialStore creates a credential store with the username and password for a single
+// registry. It doesn't read the docker config, so it needs no Init and has no credentials for other registries.
+func NewStaticCredentialStore(registry, username, password string) *CredentialStore {
+	cs := &CredentialStore{}
+	cs.SetCredential(registry, username, password)
+	return cs
+}
+
 // SetDirectory override default directory.
 func (cs *CredentialStore) SetDirectory(directory string) {
 	cs.directory = directory
@@ -37,8 +46,22 @@ func (cs *CredentialStore) Init() (err error) {
 	return nil
 }
 
+// SetCredential sets the username and password for a registry, taking precedence over the config file.
+func (cs *CredentialStore) SetCredential(registry, username, password string) {
+	if cs.credentials == nil {
+		cs.credentials = map[string]auth.Credential{}
+	}
+	cs.credentials[registry] = auth.Credential{Username: username, Password: password}
+}
+
 // Credential get an authentication credential for a given registry.
 func (cs *CredentialStore) Credential(registry string) (auth.Credential, error) {
+	if cred, ok := cs.credentials[registry]; ok {
+		return cred, nil
+	}
+	if cs.configFile == nil {
+		return auth.EmptyCredential, nil
+	}
 	authConf, err := cs.configFile.GetCredentialsStore(registry).Get(registry)
 	if err != nil {
 		return auth.EmptyCredential, err
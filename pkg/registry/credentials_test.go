@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2/registry/remote/auth"
 
 	"github.com/aws/eks-anywhere/pkg/registry"
 )
@@ -53,3 +54,16 @@ func TestCredentialStore_InitEmpty(t *testing.T) {
 	err := credentialStore.Init()
 	assert.NoError(t, err)
 }
+
+func TestStaticCredentialStore(t *testing.T) {
+	credentialStore := registry.NewStaticCredentialStore("localhost:5000", "user", "pass")
+
+	result, err := credentialStore.Credential("localhost:5000")
+	assert.NoError(t, err)
+	assert.Equal(t, "user", result.Username)
+	assert.Equal(t, "pass", result.Password)
+
+	result, err = credentialStore.Credential("public.ecr.aws")
+	assert.NoError(t, err)
+	assert.Equal(t, auth.EmptyCredential, result)
+}
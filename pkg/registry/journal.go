@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// Journal records the content already copied to destination repositories so an interrupted
+// copy can be resumed without transferring it again. Entries are appended to a file, one JSON
+// document per line, as soon as they are copied.
+type Journal struct {
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]map[string]int64
+}
+
+type journalEntry struct {
+	Repository string `json:"repository"`
+	Digest     string `json:"digest"`
+	Size       int64  `json:"size"`
+}
+
+// NewJournal loads the journal from path and opens it to record new entries.
+// An empty path creates a journal that is only kept in memory.
+func NewJournal(path string) (*Journal, error) {
+	j := &Journal{entries: map[string]map[string]int64{}}
+	if path == "" {
+		return j, nil
+	}
+
+	if err := j.load(path); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening journal %s: %v", path, err)
+	}
+	j.file = f
+	return j, nil
+}
+
+func (j *Journal) load(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("opening journal %s: %v", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		entry := journalEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// The last line can be incomplete if the previous copy was interrupted while writing it.
+			logger.V(4).Info("Ignoring invalid journal entry", "journal", path, "error", err)
+			continue
+		}
+		j.add(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading journal %s: %v", path, err)
+	}
+	return nil
+}
+
+// Lookup returns the size recorded for digest in repository and whether it was recorded.
+func (j *Journal) Lookup(repository, digest string) (int64, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	size, ok := j.entries[repository][digest]
+	return size, ok
+}
+
+// Repositories returns the repositories where digest has been copied.
+func (j *Journal) Repositories(digest string) []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var repositories []string
+	for repository, digests := range j.entries {
+		if _, ok := digests[digest]; ok {
+			repositories = append(repositories, repository)
+		}
+	}
+	return repositories
+}
+
+// Record adds digest to repository in the journal. Recording a digest again replaces its size.
+func (j *Journal) Record(repository, digest string, size int64) error {
+	entry := journalEntry{Repository: repository, Digest: digest, Size: size}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.add(entry)
+	if j.file == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing journal: %v", err)
+	}
+	return nil
+}
+
+// Close the journal file.
+func (j *Journal) Close() error {
+	if j.file == nil {
+		return nil
+	}
+	return j.file.Close()
+}
+
+func (j *Journal) add(entry journalEntry) {
+	if _, ok := j.entries[entry.Repository]; !ok {
+		j.entries[entry.Repository] = map[string]int64{}
+	}
+	j.entries[entry.Repository][entry.Digest] = entry.Size
+}
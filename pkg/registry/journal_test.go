@@ -0,0 +1,62 @@
+package registry_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+func TestJournalResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "copy.journal")
+
+	journal, err := registry.NewJournal(path)
+	assert.NoError(t, err)
+	assert.NoError(t, journal.Record("localhost/a", "sha256:1", 10))
+	assert.NoError(t, journal.Record("localhost/b", "sha256:1", 10))
+	assert.NoError(t, journal.Record("localhost/a", "sha256:2", 20))
+	assert.NoError(t, journal.Record("localhost/a", "sha256:2", 30))
+	assert.NoError(t, journal.Close())
+
+	resumed, err := registry.NewJournal(path)
+	assert.NoError(t, err)
+	defer resumed.Close()
+
+	size, ok := resumed.Lookup("localhost/a", "sha256:2")
+	assert.True(t, ok)
+	assert.Equal(t, int64(30), size)
+	_, ok = resumed.Lookup("localhost/b", "sha256:2")
+	assert.False(t, ok)
+	assert.ElementsMatch(t, []string{"localhost/a", "localhost/b"}, resumed.Repositories("sha256:1"))
+}
+
+func TestJournalIgnoresIncompleteEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "copy.journal")
+	content := `{"repository":"localhost/a","digest":"sha256:1","size":10}` + "\n" + `{"repository":"localhost/a","dig`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	journal, err := registry.NewJournal(path)
+	assert.NoError(t, err)
+	defer journal.Close()
+
+	_, ok := journal.Lookup("localhost/a", "sha256:1")
+	assert.True(t, ok)
+}
+
+func TestJournalInMemory(t *testing.T) {
+	journal, err := registry.NewJournal("")
+	assert.NoError(t, err)
+
+	assert.NoError(t, journal.Record("localhost/a", "sha256:1", 10))
+	_, ok := journal.Lookup("localhost/a", "sha256:1")
+	assert.True(t, ok)
+	assert.NoError(t, journal.Close())
+}
+
+func TestJournalOpenError(t *testing.T) {
+	_, err := registry.NewJournal(t.TempDir())
+	assert.ErrorContains(t, err, "journal")
+}
@@ -0,0 +1,255 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+)
+
+// OCILayoutPrefix marks a storage location as an OCI image layout instead of a registry.
+const OCILayoutPrefix = "oci:"
+
+// OCILayoutClient storage client for an OCI image layout directory, or a tar archive of one.
+// The images of all the repositories are stored in the same layout, named after their
+// repository and tag or digest. Archives are read-only.
+type OCILayoutClient struct {
+	path        string
+	project     string
+	initialized sync.Once
+	store       layoutStore
+}
+
+var _ StorageClient = (*OCILayoutClient)(nil)
+
+// layoutStore is implemented by both the oci.Store of layout directories and the oci.ReadOnlyStore of archives.
+type layoutStore interface {
+	content.ReadOnlyStorage
+	content.Resolver
+	orasregistry.TagLister
+}
+
+type writableLayoutStore interface {
+	layoutStore
+	content.Pusher
+	content.Tagger
+	content.Deleter
+}
+
+// NewOCILayout create an OCI image layout client for the directory or tar archive at path.
+func NewOCILayout(path string) *OCILayoutClient {
+	return &OCILayoutClient{
+		path: path,
+	}
+}
+
+// Init opens the layout. A layout directory is created if it doesn't exist.
+func (lc *OCILayoutClient) Init() error {
+	var err error
+	lc.initialized.Do(func() {
+		info, statErr := os.Stat(lc.path)
+		if statErr == nil && !info.IsDir() {
+			lc.store, err = oci.NewFromTar(context.Background(), lc.path)
+		} else {
+			lc.store, err = oci.New(lc.path)
+		}
+		if err != nil {
+			err = fmt.Errorf("error with OCI layout <%s>: %v", lc.path, err)
+		}
+	})
+	return err
+}
+
+// SetProject for layout destination.
+func (lc *OCILayoutClient) SetProject(project string) {
+	lc.project = project
+}
+
+// Destination of this storage layout.
+func (lc *OCILayoutClient) Destination(image Artifact) string {
+	return path.Join(lc.project, image.Repository) + image.Version()
+}
+
+// GetStorage object based on repository.
+func (lc *OCILayoutClient) GetStorage(_ context.Context, artifact Artifact) (orasregistry.Repository, error) {
+	if lc.store == nil {
+		return nil, fmt.Errorf("OCI layout <%s> is not initialized", lc.path)
+	}
+	return &layoutRepository{
+		store:      lc.store,
+		repository: path.Join(lc.project, artifact.Repository),
+	}, nil
+}
+
+// Resolve the location of the source repository given the image.
+func (lc *OCILayoutClient) Resolve(ctx context.Context, srcStorage orasregistry.Repository, versionedImage string) (ocispec.Descriptor, error) {
+	return srcStorage.Resolve(ctx, versionedImage)
+}
+
+// FetchBytes a resource from the layout.
+func (lc *OCILayoutClient) FetchBytes(ctx context.Context, srcStorage orasregistry.Repository, artifact Artifact) (ocispec.Descriptor, []byte, error) {
+	return oras.FetchBytes(ctx, srcStorage, artifact.VersionedImage(), oras.DefaultFetchBytesOptions)
+}
+
+// FetchBlob get named blob.
+func (lc *OCILayoutClient) FetchBlob(ctx context.Context, srcStorage orasregistry.Repository, descriptor ocispec.Descriptor) ([]byte, error) {
+	return content.FetchAll(ctx, srcStorage, descriptor)
+}
+
+// CopyGraph copy manifest and all blobs to destination.
+func (lc *OCILayoutClient) CopyGraph(ctx context.Context, srcStorage orasregistry.Repository, srcRef string, dstStorage orasregistry.Repository, dstRef string) (ocispec.Descriptor, error) {
+	return oras.Copy(ctx, srcStorage, srcRef, dstStorage, dstRef, oras.CopyOptions{})
+}
+
+// CopyGraphWithOptions copy manifest and all blobs to destination with the given copy options.
+func (lc *OCILayoutClient) CopyGraphWithOptions(ctx context.Context, srcStorage orasregistry.Repository, srcRef string, dstStorage orasregistry.Repository, dstRef string, opts oras.CopyGraphOptions) (ocispec.Descriptor, error) {
+	return oras.Copy(ctx, srcStorage, srcRef, dstStorage, dstRef, oras.CopyOptions{CopyGraphOptions: opts})
+}
+
+// Tag an image.
+func (lc *OCILayoutClient) Tag(ctx context.Context, dstStorage orasregistry.Repository, desc ocispec.Descriptor, tag string) error {
+	return dstStorage.Tag(ctx, desc, tag)
+}
+
+// layoutRepository is the view of one repository in an OCI layout. The content is shared by all
+// the repositories, and references are stored as <repository>:<tag> or <repository>@<digest>.
+type layoutRepository struct {
+	store      layoutStore
+	repository string
+}
+
+var _ orasregistry.Repository = (*layoutRepository)(nil)
+
+// reference returns the name in the layout for a tag, a digest or a full image reference.
+func (r *layoutRepository) reference(ref string) string {
+	if _, dgst, ok := strings.Cut(ref, "@"); ok {
+		return r.repository + "@" + dgst
+	}
+	if strings.HasPrefix(ref, "sha256:") {
+		return r.repository + "@" + ref
+	}
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+	return r.repository + ":" + name
+}
+
+func (r *layoutRepository) writable() (writableLayoutStore, error) {
+	store, ok := r.store.(writableLayoutStore)
+	if !ok {
+		return nil, errors.New("OCI layout archive is read-only")
+	}
+	return store, nil
+}
+
+func (r *layoutRepository) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	return r.store.Fetch(ctx, target)
+}
+
+func (r *layoutRepository) Exists(ctx context.Context, target ocispec.Descriptor) (bool, error) {
+	return r.store.Exists(ctx, target)
+}
+
+func (r *layoutRepository) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	store, err := r.writable()
+	if err != nil {
+		return err
+	}
+	if err = store.Push(ctx, expected, content); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+func (r *layoutRepository) Delete(ctx context.Context, target ocispec.Descriptor) error {
+	store, err := r.writable()
+	if err != nil {
+		return err
+	}
+	return store.Delete(ctx, target)
+}
+
+// Resolve a reference in the repository. Digests that weren't copied with a digest
+// reference to this repository are resolved from the content shared by the layout.
+func (r *layoutRepository) Resolve(ctx context.Context, reference string) (ocispec.Descriptor, error) {
+	ref := r.reference(reference)
+	desc, err := r.store.Resolve(ctx, ref)
+	if errors.Is(err, errdef.ErrNotFound) {
+		if _, dgst, ok := strings.Cut(ref, "@"); ok {
+			return r.store.Resolve(ctx, dgst)
+		}
+	}
+	return desc, err
+}
+
+func (r *layoutRepository) Tag(ctx context.Context, desc ocispec.Descriptor, reference string) error {
+	store, err := r.writable()
+	if err != nil {
+		return err
+	}
+	return store.Tag(ctx, desc, r.reference(reference))
+}
+
+func (r *layoutRepository) FetchReference(ctx context.Context, reference string) (ocispec.Descriptor, io.ReadCloser, error) {
+	desc, err := r.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	rc, err := r.store.Fetch(ctx, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+	return desc, rc, nil
+}
+
+func (r *layoutRepository) PushReference(ctx context.Context, expected ocispec.Descriptor, content io.Reader, reference string) error {
+	if err := r.Push(ctx, expected, content); err != nil {
+		return err
+	}
+	return r.Tag(ctx, expected, reference)
+}
+
+// Referrers is not supported by OCI layouts.
+func (r *layoutRepository) Referrers(_ context.Context, _ ocispec.Descriptor, _ string, _ func(referrers []ocispec.Descriptor) error) error {
+	return errdef.ErrUnsupported
+}
+
+// Tags lists the tags of the repository.
+func (r *layoutRepository) Tags(ctx context.Context, last string, fn func(tags []string) error) error {
+	prefix := r.repository + ":"
+	if last != "" {
+		last = prefix + last
+	}
+	return r.store.Tags(ctx, last, func(tags []string) error {
+		var repositoryTags []string
+		for _, t := range tags {
+			if tag, ok := strings.CutPrefix(t, prefix); ok {
+				repositoryTags = append(repositoryTags, tag)
+			}
+		}
+		if len(repositoryTags) == 0 {
+			return nil
+		}
+		return fn(repositoryTags)
+	})
+}
+
+func (r *layoutRepository) Blobs() orasregistry.BlobStore {
+	return r
+}
+
+func (r *layoutRepository) Manifests() orasregistry.ManifestStore {
+	return r
+}
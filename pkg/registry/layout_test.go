@@ -0,0 +1,199 @@
+package registry_test
+
+import (
+	"archive/tar"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+var layoutImage = registry.Artifact{
+	Registry:   "public.ecr.aws",
+	Repository: "eks-anywhere/kube-vip",
+	Tag:        "v0.5.5",
+}
+
+// newSourceLayout creates a layout with an image tagged as layoutImage.
+func newSourceLayout(t *testing.T) (*registry.OCILayoutClient, ocispec.Descriptor) {
+	client := registry.NewOCILayout(filepath.Join(t.TempDir(), "src"))
+	require.NoError(t, client.Init())
+	repo, err := client.GetStorage(ctx, layoutImage)
+	require.NoError(t, err)
+
+	layer, err := oras.PushBytes(ctx, repo, ocispec.MediaTypeImageLayer, []byte("layer"))
+	require.NoError(t, err)
+	manifest, err := oras.PackManifest(ctx, repo, oras.PackManifestVersion1_1_RC4, "application/vnd.eksa.test", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.Tag(ctx, manifest, layoutImage.Tag))
+	return client, manifest
+}
+
+func TestOCILayoutCopyBetweenLayouts(t *testing.T) {
+	src, manifest := newSourceLayout(t)
+	dst := registry.NewOCILayout(filepath.Join(t.TempDir(), "dst"))
+	require.NoError(t, dst.Init())
+	dst.SetProject("mirror")
+
+	mirror := registry.NewMirror(newMemoryJournal(t), 2)
+	assert.NoError(t, mirror.Copy(ctx, src, dst, layoutImage))
+
+	dstRepo, err := dst.GetStorage(ctx, layoutImage)
+	require.NoError(t, err)
+	desc, err := dst.Resolve(ctx, dstRepo, "mirror/eks-anywhere/kube-vip:v0.5.5")
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Digest, desc.Digest)
+
+	var tags []string
+	assert.NoError(t, dstRepo.Tags(ctx, "", func(t []string) error {
+		tags = append(tags, t...)
+		return nil
+	}))
+	assert.Equal(t, []string{"v0.5.5"}, tags)
+
+	// The other repositories of the layout don't see the image tags.
+	otherRepo, err := dst.GetStorage(ctx, registry.Artifact{Repository: "other"})
+	require.NoError(t, err)
+	_, err = otherRepo.Resolve(ctx, "v0.5.5")
+	assert.Error(t, err)
+}
+
+func TestOCILayoutResolveByDigest(t *testing.T) {
+	src, manifest := newSourceLayout(t)
+	image := layoutImage
+	image.Tag = ""
+	image.Digest = manifest.Digest.String()
+
+	srcRepo, err := src.GetStorage(ctx, image)
+	require.NoError(t, err)
+	desc, err := src.Resolve(ctx, srcRepo, image.VersionedImage())
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Digest, desc.Digest)
+
+	_, data, err := src.FetchBytes(ctx, srcRepo, image)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "application/vnd.eksa.test")
+}
+
+func TestOCILayoutArchive(t *testing.T) {
+	src, manifest := newSourceLayout(t)
+	dir := filepath.Join(t.TempDir(), "dst")
+	dst := registry.NewOCILayout(dir)
+	require.NoError(t, dst.Init())
+	assert.NoError(t, registry.NewMirror(newMemoryJournal(t), 2).Copy(ctx, src, dst, layoutImage))
+
+	archive := filepath.Join(t.TempDir(), "images.tar")
+	writeTar(t, dir, archive)
+
+	archived := registry.NewOCILayout(archive)
+	require.NoError(t, archived.Init())
+	repo, err := archived.GetStorage(ctx, layoutImage)
+	require.NoError(t, err)
+	desc, err := archived.Resolve(ctx, repo, layoutImage.VersionedImage())
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Digest, desc.Digest)
+
+	assert.EqualError(t, repo.Tag(ctx, desc, "latest"), "OCI layout archive is read-only")
+}
+
+func TestOCILayoutGetStorageNotInitialized(t *testing.T) {
+	_, err := registry.NewOCILayout(t.TempDir()).GetStorage(ctx, layoutImage)
+	assert.ErrorContains(t, err, "is not initialized")
+}
+
+func TestNewStorageClient(t *testing.T) {
+	dir := t.TempDir()
+	client, err := registry.NewStorageClient(registry.OCILayoutPrefix+dir, credentialStore, false, false)
+	assert.NoError(t, err)
+	assert.IsType(t, &registry.OCILayoutClient{}, client)
+	assert.Equal(t, "eks-anywhere/kube-vip:v0.5.5", client.Destination(layoutImage))
+	assert.FileExists(t, filepath.Join(dir, ocispec.ImageLayoutFile))
+
+	client, err = registry.NewStorageClient("localhost:5000/mirror", credentialStore, true, true)
+	assert.NoError(t, err)
+	assert.IsType(t, &registry.OCIRegistryClient{}, client)
+	assert.Equal(t, "localhost:5000/mirror/eks-anywhere/kube-vip:v0.5.5", client.Destination(layoutImage))
+}
+
+func TestImageMoverBetweenLayouts(t *testing.T) {
+	src, manifest := newSourceLayout(t)
+	dst := registry.NewOCILayout(filepath.Join(t.TempDir(), "dst"))
+	mover := registry.NewImageMover(registry.StorageSource(src), dst)
+
+	image := layoutImage.VersionedImage()
+	assert.NoError(t, mover.Move(ctx, image, image))
+
+	repo, err := dst.GetStorage(ctx, layoutImage)
+	require.NoError(t, err)
+	desc, err := repo.Resolve(ctx, layoutImage.Tag)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Digest, desc.Digest)
+}
+
+func TestImageMoverWithSignatures(t *testing.T) {
+	src, manifest := newSourceLayout(t)
+	srcRepo, err := src.GetStorage(ctx, layoutImage)
+	require.NoError(t, err)
+	sigTag := registry.CosignTag(string(manifest.Digest), registry.CosignSignatureTagSuffix)
+	sigLayer, err := oras.PushBytes(ctx, srcRepo, "application/vnd.dev.cosign.simplesigning.v1+json", []byte("payload"))
+	require.NoError(t, err)
+	sig, err := oras.PackManifest(ctx, srcRepo, oras.PackManifestVersion1_0, "", oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{sigLayer},
+	})
+	require.NoError(t, err)
+	require.NoError(t, srcRepo.Tag(ctx, sig, sigTag))
+
+	dst := registry.NewOCILayout(filepath.Join(t.TempDir(), "dst"))
+	image := layoutImage.VersionedImage()
+	assert.NoError(t, registry.NewImageMover(registry.StorageSource(src), dst).WithSignatures().Move(ctx, image))
+
+	repo, err := dst.GetStorage(ctx, layoutImage)
+	require.NoError(t, err)
+	desc, err := repo.Resolve(ctx, sigTag)
+	assert.NoError(t, err)
+	assert.Equal(t, sig.Digest, desc.Digest)
+}
+
+func newMemoryJournal(t *testing.T) *registry.Journal {
+	t.Helper()
+	journal, err := registry.NewJournal("")
+	require.NoError(t, err)
+	return journal
+}
+
+func writeTar(t *testing.T, dir, file string) {
+	t.Helper()
+	f, err := os.Create(file)
+	require.NoError(t, err)
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	defer tw.Close()
+
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: rel, Mode: 0o644, Size: int64(len(data))}); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}))
+}
@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	orasregistry "oras.land/oras-go/v2/registry"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+// Mirror copies artifacts to a destination registry skipping the content that is already there.
+// Artifacts resolving to a manifest already copied to the same repository, and still there, are only tagged,
+// blobs copied to another repository of the destination registry are mounted instead of
+// transferred, and blobs are transferred concurrently. Progress is recorded in a Journal so
+// a new Mirror with the same journal resumes an interrupted copy.
+type Mirror struct {
+	journal     *Journal
+	concurrency int
+	stats       mirrorCounters
+}
+
+// MirrorStats summarizes the content copied by a Mirror.
+type MirrorStats struct {
+	ArtifactsCopied  int64
+	ArtifactsSkipped int64
+	BlobsTransferred int64
+	BlobsSkipped     int64
+	BytesTransferred int64
+	BytesSkipped     int64
+}
+
+type mirrorCounters struct {
+	artifactsCopied  atomic.Int64
+	artifactsSkipped atomic.Int64
+	blobsTransferred atomic.Int64
+	blobsSkipped     atomic.Int64
+	bytesTransferred atomic.Int64
+	bytesSkipped     atomic.Int64
+}
+
+// NewMirror creates a Mirror that records its progress in journal and transfers up to
+// concurrency blobs at the same time.
+func NewMirror(journal *Journal, concurrency int) *Mirror {
+	return &Mirror{
+		journal:     journal,
+		concurrency: concurrency,
+	}
+}
+
+// Copy an image from a source to a destination.
+func (m *Mirror) Copy(ctx context.Context, srcClient StorageClient, dstClient StorageClient, image Artifact) error {
+	srcStorage, err := srcClient.GetStorage(ctx, image)
+	if err != nil {
+		return fmt.Errorf("repository source: %v", err)
+	}
+
+	dstStorage, err := dstClient.GetStorage(ctx, image)
+	if err != nil {
+		return fmt.Errorf("repository destination: %v", err)
+	}
+
+	desc, err := srcClient.Resolve(ctx, srcStorage, image.VersionedImage())
+	if err != nil {
+		return fmt.Errorf("resolving source: %v", err)
+	}
+
+	dstRef := dstClient.Destination(image)
+	dstRepository := strings.TrimSuffix(dstRef, image.Version())
+	if size, ok := m.journal.Lookup(dstRepository, string(desc.Digest)); ok && m.inDestination(ctx, dstStorage, dstRepository, desc) {
+		logger.V(6).Info("Artifact already copied", "repository", dstRepository, "digest", desc.Digest)
+		m.stats.artifactsSkipped.Add(1)
+		m.stats.bytesSkipped.Add(size)
+	} else {
+		var graphSize atomic.Int64
+		desc, err = srcClient.CopyGraphWithOptions(ctx, srcStorage, image.VersionedImage(), dstStorage, dstRef, m.copyOptions(dstRepository, &graphSize))
+		if err != nil {
+			return fmt.Errorf("registry copy: %v", err)
+		}
+		// The manifest is recorded with the size of the whole artifact to report it
+		// as skipped when the artifact is copied again.
+		if err = m.journal.Record(dstRepository, string(desc.Digest), graphSize.Load()); err != nil {
+			return err
+		}
+		m.stats.artifactsCopied.Add(1)
+	}
+
+	if len(image.Tag) > 0 {
+		logger.V(6).Info("Tagging image", "repository", image.Repository, "tag", image.Tag)
+		err = dstClient.Tag(ctx, dstStorage, desc, image.Tag)
+		if err != nil {
+			return fmt.Errorf("image tag: %v", err)
+		}
+	}
+	return nil
+}
+
+// inDestination checks the manifest recorded in the journal is still in the destination repository,
+// since its content could have been removed after it was recorded.
+func (m *Mirror) inDestination(ctx context.Context, dstStorage orasregistry.Repository, dstRepository string, desc ocispec.Descriptor) bool {
+	if _, err := dstStorage.Resolve(ctx, string(desc.Digest)); err != nil {
+		logger.V(4).Info("Artifact in journal not found in destination, copying it again", "repository", dstRepository, "digest", desc.Digest, "error", err)
+		return false
+	}
+	return true
+}
+
+// Stats returns the content copied and skipped so far.
+func (m *Mirror) Stats() MirrorStats {
+	return MirrorStats{
+		ArtifactsCopied:  m.stats.artifactsCopied.Load(),
+		ArtifactsSkipped: m.stats.artifactsSkipped.Load(),
+		BlobsTransferred: m.stats.blobsTransferred.Load(),
+		BlobsSkipped:     m.stats.blobsSkipped.Load(),
+		BytesTransferred: m.stats.bytesTransferred.Load(),
+		BytesSkipped:     m.stats.bytesSkipped.Load(),
+	}
+}
+
+func (m *Mirror) copyOptions(dstRepository string, graphSize *atomic.Int64) oras.CopyGraphOptions {
+	host, _, _ := strings.Cut(dstRepository, "/")
+	skipped := func(_ context.Context, desc ocispec.Descriptor) error {
+		m.stats.blobsSkipped.Add(1)
+		m.stats.bytesSkipped.Add(desc.Size)
+		graphSize.Add(desc.Size)
+		return m.journal.Record(dstRepository, string(desc.Digest), desc.Size)
+	}
+
+	return oras.CopyGraphOptions{
+		Concurrency: m.concurrency,
+		PostCopy: func(_ context.Context, desc ocispec.Descriptor) error {
+			m.stats.blobsTransferred.Add(1)
+			m.stats.bytesTransferred.Add(desc.Size)
+			graphSize.Add(desc.Size)
+			return m.journal.Record(dstRepository, string(desc.Digest), desc.Size)
+		},
+		OnCopySkipped: skipped,
+		OnMounted:     skipped,
+		MountFrom: func(_ context.Context, desc ocispec.Descriptor) ([]string, error) {
+			var candidates []string
+			for _, repository := range m.journal.Repositories(string(desc.Digest)) {
+				repositoryHost, path, _ := strings.Cut(repository, "/")
+				if repositoryHost == host && repository != dstRepository {
+					candidates = append(candidates, path)
+				}
+			}
+			return candidates, nil
+		},
+	}
+}
+
+// String summarizes the stats.
+func (s MirrorStats) String() string {
+	return fmt.Sprintf("%d artifacts copied, %d already mirrored; %s transferred in %d blobs, %s skipped in %d blobs",
+		s.ArtifactsCopied, s.ArtifactsSkipped,
+		formatBytes(s.BytesTransferred), s.BlobsTransferred,
+		formatBytes(s.BytesSkipped), s.BlobsSkipped,
+	)
+}
+
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,177 @@
+package registry_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2"
+	orasregistry "oras.land/oras-go/v2/registry"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registry/mocks"
+)
+
+type mirrorTest struct {
+	srcClient *mocks.MockStorageClient
+	dstClient *mocks.MockStorageClient
+	srcRepo   *mocks.MockRepository
+	dstRepo   *mocks.MockRepository
+	journal   *registry.Journal
+	mirror    *registry.Mirror
+	manifest  ocispec.Descriptor
+	layer     ocispec.Descriptor
+}
+
+func newMirrorTest(t *testing.T) *mirrorTest {
+	ctrl := gomock.NewController(t)
+	journal, err := registry.NewJournal("")
+	assert.NoError(t, err)
+	return &mirrorTest{
+		srcClient: mocks.NewMockStorageClient(ctrl),
+		dstClient: mocks.NewMockStorageClient(ctrl),
+		srcRepo:   mocks.NewMockRepository(ctrl),
+		dstRepo:   mocks.NewMockRepository(ctrl),
+		journal:   journal,
+		mirror:    registry.NewMirror(journal, 4),
+		manifest:  ocispec.Descriptor{MediaType: ocispec.MediaTypeImageManifest, Digest: "sha256:6efe21500abbfbb6b3e37b80dd5dea0b11a0d1b145e84298fee5d7784a77e967", Size: 1000},
+		layer:     ocispec.Descriptor{MediaType: ocispec.MediaTypeImageLayer, Digest: "sha256:layer", Size: 5000},
+	}
+}
+
+func (tt *mirrorTest) expectStorage(image registry.Artifact, dstRef string) {
+	tt.srcClient.EXPECT().GetStorage(ctx, image).Return(tt.srcRepo, nil)
+	tt.dstClient.EXPECT().GetStorage(ctx, image).Return(tt.dstRepo, nil)
+	tt.srcClient.EXPECT().Resolve(ctx, tt.srcRepo, image.VersionedImage()).Return(tt.manifest, nil)
+	tt.dstClient.EXPECT().Destination(image).Return(dstRef)
+}
+
+// expectCopyGraph simulates oras copying the layer with copyLayer and then the manifest.
+func (tt *mirrorTest) expectCopyGraph(image registry.Artifact, dstRef string, copyLayer func(oras.CopyGraphOptions) error) {
+	tt.srcClient.EXPECT().CopyGraphWithOptions(ctx, tt.srcRepo, image.VersionedImage(), tt.dstRepo, dstRef, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ orasregistry.Repository, _ string, _ orasregistry.Repository, _ string, opts oras.CopyGraphOptions) (ocispec.Descriptor, error) {
+			if err := copyLayer(opts); err != nil {
+				return ocispec.Descriptor{}, err
+			}
+			return tt.manifest, opts.PostCopy(ctx, tt.manifest)
+		},
+	)
+}
+
+func TestMirrorCopy(t *testing.T) {
+	tt := newMirrorTest(t)
+	dstRef := "localhost/l0g8r8j6/kube-vip/kube-vip" + srcArtifact.Version()
+	tt.expectStorage(srcArtifact, dstRef)
+	tt.expectCopyGraph(srcArtifact, dstRef, func(opts oras.CopyGraphOptions) error {
+		assert.Equal(t, 4, opts.Concurrency)
+		return opts.PostCopy(ctx, tt.layer)
+	})
+	tt.dstClient.EXPECT().Tag(ctx, tt.dstRepo, tt.manifest, srcArtifact.Tag).Return(nil)
+
+	assert.NoError(t, tt.mirror.Copy(ctx, tt.srcClient, tt.dstClient, srcArtifact))
+	assert.Equal(t, registry.MirrorStats{ArtifactsCopied: 1, BlobsTransferred: 2, BytesTransferred: 6000}, tt.mirror.Stats())
+
+	size, ok := tt.journal.Lookup("localhost/l0g8r8j6/kube-vip/kube-vip", string(tt.manifest.Digest))
+	assert.True(t, ok)
+	assert.Equal(t, int64(6000), size)
+}
+
+func TestMirrorCopySkipsCopiedArtifact(t *testing.T) {
+	tt := newMirrorTest(t)
+	dstRef := "localhost/l0g8r8j6/kube-vip/kube-vip" + srcArtifact.Version()
+	assert.NoError(t, tt.journal.Record("localhost/l0g8r8j6/kube-vip/kube-vip", string(tt.manifest.Digest), 6000))
+	tt.expectStorage(srcArtifact, dstRef)
+	tt.dstRepo.EXPECT().Resolve(ctx, string(tt.manifest.Digest)).Return(tt.manifest, nil)
+	tt.dstClient.EXPECT().Tag(ctx, tt.dstRepo, tt.manifest, srcArtifact.Tag).Return(nil)
+
+	assert.NoError(t, tt.mirror.Copy(ctx, tt.srcClient, tt.dstClient, srcArtifact))
+	assert.Equal(t, registry.MirrorStats{ArtifactsSkipped: 1, BytesSkipped: 6000}, tt.mirror.Stats())
+}
+
+func TestMirrorCopyArtifactInJournalMissingFromDestination(t *testing.T) {
+	tt := newMirrorTest(t)
+	dstRef := "localhost/l0g8r8j6/kube-vip/kube-vip" + srcArtifact.Version()
+	assert.NoError(t, tt.journal.Record("localhost/l0g8r8j6/kube-vip/kube-vip", string(tt.manifest.Digest), 6000))
+	tt.expectStorage(srcArtifact, dstRef)
+	tt.dstRepo.EXPECT().Resolve(ctx, string(tt.manifest.Digest)).Return(ocispec.Descriptor{}, fmt.Errorf("not found"))
+	tt.expectCopyGraph(srcArtifact, dstRef, func(opts oras.CopyGraphOptions) error {
+		return opts.PostCopy(ctx, tt.layer)
+	})
+	tt.dstClient.EXPECT().Tag(ctx, tt.dstRepo, tt.manifest, srcArtifact.Tag).Return(nil)
+
+	assert.NoError(t, tt.mirror.Copy(ctx, tt.srcClient, tt.dstClient, srcArtifact))
+	assert.Equal(t, registry.MirrorStats{ArtifactsCopied: 1, BlobsTransferred: 2, BytesTransferred: 6000}, tt.mirror.Stats())
+}
+
+func TestMirrorCopyMountsBlobsFromOtherRepositories(t *testing.T) {
+	tt := newMirrorTest(t)
+	image := srcArtifact
+	image.Tag = ""
+	dstRef := "localhost/other" + image.Version()
+	assert.NoError(t, tt.journal.Record("localhost/l0g8r8j6/kube-vip/kube-vip", string(tt.layer.Digest), tt.layer.Size))
+	assert.NoError(t, tt.journal.Record("remote/l0g8r8j6/kube-vip/kube-vip", string(tt.layer.Digest), tt.layer.Size))
+	tt.expectStorage(image, dstRef)
+	tt.expectCopyGraph(image, dstRef, func(opts oras.CopyGraphOptions) error {
+		candidates, err := opts.MountFrom(ctx, tt.layer)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"l0g8r8j6/kube-vip/kube-vip"}, candidates)
+		return opts.OnMounted(ctx, tt.layer)
+	})
+
+	assert.NoError(t, tt.mirror.Copy(ctx, tt.srcClient, tt.dstClient, image))
+	assert.Equal(t, registry.MirrorStats{ArtifactsCopied: 1, BlobsTransferred: 1, BytesTransferred: 1000, BlobsSkipped: 1, BytesSkipped: 5000}, tt.mirror.Stats())
+	_, ok := tt.journal.Lookup("localhost/other", string(tt.layer.Digest))
+	assert.True(t, ok)
+}
+
+func TestMirrorCopyExistingBlob(t *testing.T) {
+	tt := newMirrorTest(t)
+	dstRef := "localhost/l0g8r8j6/kube-vip/kube-vip" + srcArtifact.Version()
+	tt.expectStorage(srcArtifact, dstRef)
+	tt.expectCopyGraph(srcArtifact, dstRef, func(opts oras.CopyGraphOptions) error {
+		return opts.OnCopySkipped(ctx, tt.layer)
+	})
+	tt.dstClient.EXPECT().Tag(ctx, tt.dstRepo, tt.manifest, srcArtifact.Tag).Return(nil)
+
+	assert.NoError(t, tt.mirror.Copy(ctx, tt.srcClient, tt.dstClient, srcArtifact))
+	assert.Equal(t, int64(5000), tt.mirror.Stats().BytesSkipped)
+}
+
+func TestMirrorCopyError(t *testing.T) {
+	tt := newMirrorTest(t)
+	dstRef := "localhost/l0g8r8j6/kube-vip/kube-vip" + srcArtifact.Version()
+	tt.expectStorage(srcArtifact, dstRef)
+	tt.expectCopyGraph(srcArtifact, dstRef, func(opts oras.CopyGraphOptions) error {
+		return fmt.Errorf("oops")
+	})
+
+	err := tt.mirror.Copy(ctx, tt.srcClient, tt.dstClient, srcArtifact)
+	assert.EqualError(t, err, "registry copy: oops")
+	_, ok := tt.journal.Lookup("localhost/l0g8r8j6/kube-vip/kube-vip", string(tt.manifest.Digest))
+	assert.False(t, ok)
+}
+
+func TestMirrorCopyResolveError(t *testing.T) {
+	tt := newMirrorTest(t)
+	tt.srcClient.EXPECT().GetStorage(ctx, srcArtifact).Return(tt.srcRepo, nil)
+	tt.dstClient.EXPECT().GetStorage(ctx, srcArtifact).Return(tt.dstRepo, nil)
+	tt.srcClient.EXPECT().Resolve(ctx, tt.srcRepo, srcArtifact.VersionedImage()).Return(ocispec.Descriptor{}, fmt.Errorf("not found"))
+
+	err := tt.mirror.Copy(ctx, tt.srcClient, tt.dstClient, srcArtifact)
+	assert.EqualError(t, err, "resolving source: not found")
+}
+
+func TestMirrorStatsString(t *testing.T) {
+	stats := registry.MirrorStats{
+		ArtifactsCopied:  3,
+		ArtifactsSkipped: 2,
+		BlobsTransferred: 10,
+		BlobsSkipped:     4,
+		BytesTransferred: 3 * 1024 * 1024 * 1024 / 2,
+		BytesSkipped:     512,
+	}
+	assert.Equal(t, "3 artifacts copied, 2 already mirrored; 1.5 GiB transferred in 10 blobs, 512 B skipped in 4 blobs", stats.String())
+}
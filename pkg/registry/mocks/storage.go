@@ -11,6 +11,7 @@ import (
 	registry "github.com/aws/eks-anywhere/pkg/registry"
 	gomock "github.com/golang/mock/gomock"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	oras "oras.land/oras-go/v2"
 	registry0 "oras.land/oras-go/v2/registry"
 )
 
@@ -52,6 +53,21 @@ func (mr *MockStorageClientMockRecorder) CopyGraph(ctx, srcStorage, srcRef, dstS
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyGraph", reflect.TypeOf((*MockStorageClient)(nil).CopyGraph), ctx, srcStorage, srcRef, dstStorage, dstRef)
 }
 
+// CopyGraphWithOptions mocks base method.
+func (m *MockStorageClient) CopyGraphWithOptions(ctx context.Context, srcStorage registry0.Repository, srcRef string, dstStorage registry0.Repository, dstRef string, opts oras.CopyGraphOptions) (v1.Descriptor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyGraphWithOptions", ctx, srcStorage, srcRef, dstStorage, dstRef, opts)
+	ret0, _ := ret[0].(v1.Descriptor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyGraphWithOptions indicates an expected call of CopyGraphWithOptions.
+func (mr *MockStorageClientMockRecorder) CopyGraphWithOptions(ctx, srcStorage, srcRef, dstStorage, dstRef, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyGraphWithOptions", reflect.TypeOf((*MockStorageClient)(nil).CopyGraphWithOptions), ctx, srcStorage, srcRef, dstStorage, dstRef, opts)
+}
+
 // Destination mocks base method.
 func (m *MockStorageClient) Destination(image registry.Artifact) string {
 	m.ctrl.T.Helper()
@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const defaultMoverConcurrency = 5
+
+// SourceSelector returns the storage client to copy an image from.
+type SourceSelector func(image Artifact) (StorageClient, error)
+
+// RegistrySources selects the registry in the image reference as the source of each image.
+func RegistrySources(cache *Cache, credentialStore *CredentialStore, insecure bool) SourceSelector {
+	return func(image Artifact) (StorageClient, error) {
+		return cache.Get(NewStorageContext(image.Registry, credentialStore, nil, insecure))
+	}
+}
+
+// StorageSource selects client as the source of all the images.
+func StorageSource(client StorageClient) SourceSelector {
+	return func(_ Artifact) (StorageClient, error) {
+		return client, client.Init()
+	}
+}
+
+// ImageMover copies images from their source to a destination storage, with no container runtime.
+// Sources and destinations can be registries or OCI layouts.
+type ImageMover struct {
+	sources     SourceSelector
+	destination StorageClient
+	signatures  bool
+}
+
+// NewImageMover creates an ImageMover.
+func NewImageMover(sources SourceSelector, destination StorageClient) *ImageMover {
+	return &ImageMover{
+		sources:     sources,
+		destination: destination,
+	}
+}
+
+// WithSignatures makes the mover also copy the cosign signatures and attestations of the images,
+// so they can be verified from the destination.
+func (m *ImageMover) WithSignatures() *ImageMover {
+	m.signatures = true
+	return m
+}
+
+// Move copies images to the destination.
+func (m *ImageMover) Move(ctx context.Context, images ...string) error {
+	if err := m.destination.Init(); err != nil {
+		return err
+	}
+
+	journal, err := NewJournal("")
+	if err != nil {
+		return err
+	}
+	mirror := NewMirror(journal, defaultMoverConcurrency)
+
+	for _, image := range uniqueImages(images) {
+		artifact := NewArtifactFromURI(image)
+		src, err := m.sources(artifact)
+		if err != nil {
+			return fmt.Errorf("getting source for image %s: %v", image, err)
+		}
+
+		logger.V(3).Info("Copying image", "image", image, "destination", m.destination.Destination(artifact))
+		if err = mirror.Copy(ctx, src, m.destination, artifact); err != nil {
+			return fmt.Errorf("copying image %s: %v", image, err)
+		}
+
+		if m.signatures {
+			if err = m.copySignatures(ctx, mirror, src, artifact); err != nil {
+				return fmt.Errorf("copying signatures of image %s: %v", image, err)
+			}
+		}
+	}
+
+	logger.V(1).Info("Images copied", "summary", mirror.Stats().String())
+	return nil
+}
+
+func (m *ImageMover) copySignatures(ctx context.Context, mirror *Mirror, src StorageClient, image Artifact) error {
+	artifacts, err := cosignArtifacts(ctx, src, image)
+	if err != nil {
+		return err
+	}
+	for _, artifact := range artifacts {
+		if err = mirror.Copy(ctx, src, m.destination, artifact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uniqueImages(images []string) []string {
+	seen := make(map[string]struct{}, len(images))
+	unique := make([]string, 0, len(images))
+	for _, i := range images {
+		if _, ok := seen[i]; ok {
+			continue
+		}
+		seen[i] = struct{}{}
+		unique = append(unique, i)
+	}
+	sort.Strings(unique)
+	return unique
+}
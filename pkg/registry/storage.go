@@ -3,8 +3,10 @@ package registry
 import (
 	"context"
 	"crypto/x509"
+	"strings"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
 	orasregistry "oras.land/oras-go/v2/registry"
 )
 
@@ -15,6 +17,7 @@ type StorageContext struct {
 	credentialStore *CredentialStore
 	certificates    *x509.CertPool
 	insecure        bool
+	plainHTTP       bool
 }
 
 // NewStorageContext create registry context.
@@ -27,6 +30,11 @@ func NewStorageContext(host string, credentialStore *CredentialStore, certificat
 	}
 }
 
+// SetPlainHTTP makes clients for this context use plain HTTP instead of HTTPS.
+func (sc *StorageContext) SetPlainHTTP(plainHTTP bool) {
+	sc.plainHTTP = plainHTTP
+}
+
 // StorageClient interface for general image storage client.
 type StorageClient interface {
 	Init() error
@@ -37,5 +45,25 @@ type StorageClient interface {
 	FetchBytes(ctx context.Context, srcStorage orasregistry.Repository, artifact Artifact) (ocispec.Descriptor, []byte, error)
 	FetchBlob(ctx context.Context, srcStorage orasregistry.Repository, descriptor ocispec.Descriptor) ([]byte, error)
 	CopyGraph(ctx context.Context, srcStorage orasregistry.Repository, srcRef string, dstStorage orasregistry.Repository, dstRef string) (ocispec.Descriptor, error)
+	CopyGraphWithOptions(ctx context.Context, srcStorage orasregistry.Repository, srcRef string, dstStorage orasregistry.Repository, dstRef string, opts oras.CopyGraphOptions) (ocispec.Descriptor, error)
 	Tag(ctx context.Context, dstStorage orasregistry.Repository, desc ocispec.Descriptor, tag string) error
 }
+
+// NewStorageClient creates an initialized storage client for location, which is either an OCI layout
+// directory or archive prefixed with OCILayoutPrefix, or a registry host with an optional project path.
+func NewStorageClient(location string, credentialStore *CredentialStore, insecure, plainHTTP bool) (StorageClient, error) {
+	var client StorageClient
+	if layoutPath, ok := strings.CutPrefix(location, OCILayoutPrefix); ok {
+		client = NewOCILayout(layoutPath)
+	} else {
+		host, project, _ := strings.Cut(location, "/")
+		sc := NewStorageContext(host, credentialStore, nil, insecure)
+		sc.SetPlainHTTP(plainHTTP)
+		client = NewOCIRegistry(sc)
+		client.SetProject(project)
+	}
+	if err := client.Init(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,148 @@
+package containerd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+)
+
+// HostsDir is the directory containerd reads the registry host configurations from.
+const HostsDir = "/etc/containerd/certs.d"
+
+// Credentials to authenticate with a registry mirror.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// File is a containerd configuration file.
+type File struct {
+	Path    string
+	Content string
+}
+
+// ReadCredentials reads the credentials of the registry mirror and its fallbacks requiring authentication,
+// indexed by their base registry.
+func ReadCredentials(mirror *registrymirror.RegistryMirror) (map[string]Credentials, error) {
+	credentials := map[string]Credentials{}
+	for _, m := range mirror.Mirrors() {
+		if !m.Auth {
+			continue
+		}
+		username, password, err := m.ReadCredentials()
+		if err != nil {
+			return nil, err
+		}
+		credentials[m.BaseRegistry] = Credentials{Username: username, Password: password}
+	}
+	return credentials, nil
+}
+
+// CACertPath returns the path of the CA certificate file of a registry mirror.
+func CACertPath(mirror *registrymirror.RegistryMirror) string {
+	return filepath.Join(HostsDir, mirror.BaseRegistry, "ca.crt")
+}
+
+// MirrorEndpoints returns the API endpoints of the registry mirror and its fallbacks for each
+// mirrored registry, in order of preference.
+func MirrorEndpoints(mirror *registrymirror.RegistryMirror) map[string][]string {
+	endpoints := map[string][]string{}
+	for _, m := range mirror.Mirrors() {
+		for upstream, endpoint := range m.NamespacedRegistryMap {
+			endpoints[upstream] = append(endpoints[upstream], ToAPIEndpoint(endpoint))
+		}
+	}
+	return endpoints
+}
+
+// HostsFiles returns the hosts.toml files configuring containerd to pull the images of each
+// mirrored registry from the registry mirror and its fallbacks, tried in order of preference,
+// followed by the CA certificate files of the mirrors. credentials are indexed by the base
+// registry of the mirrors requiring authentication.
+func HostsFiles(mirror *registrymirror.RegistryMirror, credentials map[string]Credentials) []File {
+	mirrors := mirror.Mirrors()
+	upstreams := map[string]struct{}{}
+	for _, m := range mirrors {
+		for upstream := range m.NamespacedRegistryMap {
+			upstreams[upstream] = struct{}{}
+		}
+	}
+	sortedUpstreams := make([]string, 0, len(upstreams))
+	for upstream := range upstreams {
+		sortedUpstreams = append(sortedUpstreams, upstream)
+	}
+	sort.Strings(sortedUpstreams)
+
+	files := make([]File, 0, len(sortedUpstreams)+len(mirrors))
+	for _, upstream := range sortedUpstreams {
+		var content strings.Builder
+		for _, m := range mirrors {
+			endpoint, ok := m.NamespacedRegistryMap[upstream]
+			if !ok {
+				continue
+			}
+			writeHost(&content, m, endpoint, credentials)
+		}
+		files = append(files, File{
+			Path:    filepath.Join(HostsDir, upstream, "hosts.toml"),
+			Content: content.String(),
+		})
+	}
+
+	for _, m := range mirrors {
+		if m.CACertContent != "" {
+			files = append(files, File{
+				Path:    CACertPath(m),
+				Content: m.CACertContent,
+			})
+		}
+	}
+
+	return files
+}
+
+// SetFallbackTemplateValues sets the provider template values configuring the fallbacks of the registry mirror,
+// which replace the containerd mirrors config with the host configurations listing the mirrors in order for each
+// mirrored registry. It doesn't set any value when the registry mirror has no fallbacks.
+func SetFallbackTemplateValues(values map[string]interface{}, mirror *registrymirror.RegistryMirror) error {
+	if len(mirror.Fallbacks) == 0 {
+		return nil
+	}
+	credentials, err := ReadCredentials(mirror)
+	if err != nil {
+		return err
+	}
+	values["registryMirrorHosts"] = HostsFiles(mirror, credentials)
+	values["registryMirrorHostsDir"] = HostsDir
+	values["registryMirrorEndpoints"] = MirrorEndpoints(mirror)
+	return nil
+}
+
+func writeHost(b *strings.Builder, mirror *registrymirror.RegistryMirror, endpoint string, credentials map[string]Credentials) {
+	apiEndpoint := ToAPIEndpoint(endpoint)
+	host := fmt.Sprintf("[host.%q]", "https://"+apiEndpoint)
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString(host + "\n")
+	b.WriteString("  capabilities = [\"pull\", \"resolve\"]\n")
+	// Namespaced endpoints already include the API version in their path.
+	if apiEndpoint != endpoint {
+		b.WriteString("  override_path = true\n")
+	}
+	if mirror.CACertContent != "" {
+		fmt.Fprintf(b, "  ca = %q\n", CACertPath(mirror))
+	}
+	if mirror.InsecureSkipVerify {
+		b.WriteString("  skip_verify = true\n")
+	}
+	if c, ok := credentials[mirror.BaseRegistry]; ok && mirror.Auth {
+		auth := base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+		fmt.Fprintf(b, "  [%s.header]\n", strings.Trim(host, "[]"))
+		fmt.Fprintf(b, "    authorization = %q\n", "Basic "+auth)
+	}
+}
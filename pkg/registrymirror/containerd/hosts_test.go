@@ -0,0 +1,143 @@
+package containerd_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
+)
+
+func TestHostsFiles(t *testing.T) {
+	g := NewWithT(t)
+	mirror := &registrymirror.RegistryMirror{
+		BaseRegistry: "1.2.3.4:443",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry: "1.2.3.4:443/eks-anywhere",
+			"docker.io":                       "1.2.3.4:443/docker",
+		},
+		CACertContent: "ca1",
+		Fallbacks: []*registrymirror.RegistryMirror{
+			{
+				BaseRegistry: "1.2.3.5:8443",
+				NamespacedRegistryMap: map[string]string{
+					constants.DefaultCoreEKSARegistry: "1.2.3.5:8443",
+				},
+				Auth:               true,
+				InsecureSkipVerify: true,
+			},
+		},
+	}
+	credentials := map[string]containerd.Credentials{
+		"1.2.3.5:8443": {Username: "user", Password: "pass"},
+	}
+
+	g.Expect(containerd.HostsFiles(mirror, credentials)).To(Equal([]containerd.File{
+		{
+			Path: "/etc/containerd/certs.d/docker.io/hosts.toml",
+			Content: `[host."https://1.2.3.4:443/v2/docker"]
+  capabilities = ["pull", "resolve"]
+  override_path = true
+  ca = "/etc/containerd/certs.d/1.2.3.4:443/ca.crt"
+`,
+		},
+		{
+			Path: "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+			Content: `[host."https://1.2.3.4:443/v2/eks-anywhere"]
+  capabilities = ["pull", "resolve"]
+  override_path = true
+  ca = "/etc/containerd/certs.d/1.2.3.4:443/ca.crt"
+
+[host."https://1.2.3.5:8443"]
+  capabilities = ["pull", "resolve"]
+  skip_verify = true
+  [host."https://1.2.3.5:8443".header]
+    authorization = "Basic dXNlcjpwYXNz"
+`,
+		},
+		{
+			Path:    "/etc/containerd/certs.d/1.2.3.4:443/ca.crt",
+			Content: "ca1",
+		},
+	}))
+}
+
+func TestHostsFilesWithoutCredentials(t *testing.T) {
+	g := NewWithT(t)
+	mirror := &registrymirror.RegistryMirror{
+		BaseRegistry: "1.2.3.4:443",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry: "1.2.3.4:443",
+		},
+		Auth: true,
+	}
+
+	g.Expect(containerd.HostsFiles(mirror, nil)).To(Equal([]containerd.File{
+		{
+			Path: "/etc/containerd/certs.d/public.ecr.aws/hosts.toml",
+			Content: `[host."https://1.2.3.4:443"]
+  capabilities = ["pull", "resolve"]
+`,
+		},
+	}))
+}
+
+func TestReadCredentials(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(constants.RegistryUsername, "user")
+	t.Setenv(constants.RegistryPassword, "pass")
+	t.Setenv("REGISTRY_USERNAME_2", "user2")
+	t.Setenv("REGISTRY_PASSWORD_2", "pass2")
+	mirror := &registrymirror.RegistryMirror{
+		BaseRegistry: "1.2.3.4:443",
+		Auth:         true,
+		Fallbacks: []*registrymirror.RegistryMirror{
+			{BaseRegistry: "1.2.3.5:443", CredentialsIndex: 1},
+			{BaseRegistry: "1.2.3.6:443", Auth: true, CredentialsIndex: 2},
+		},
+	}
+
+	g.Expect(containerd.ReadCredentials(mirror)).To(Equal(map[string]containerd.Credentials{
+		"1.2.3.4:443": {Username: "user", Password: "pass"},
+		"1.2.3.6:443": {Username: "user2", Password: "pass2"},
+	}))
+}
+
+func TestReadCredentialsMissing(t *testing.T) {
+	g := NewWithT(t)
+	mirror := &registrymirror.RegistryMirror{
+		BaseRegistry: "1.2.3.4:443",
+		Fallbacks: []*registrymirror.RegistryMirror{
+			{BaseRegistry: "1.2.3.5:443", Auth: true, CredentialsIndex: 1},
+		},
+	}
+
+	_, err := containerd.ReadCredentials(mirror)
+	g.Expect(err).To(MatchError("please set REGISTRY_USERNAME_1 env var"))
+}
+
+func TestMirrorEndpoints(t *testing.T) {
+	g := NewWithT(t)
+	mirror := &registrymirror.RegistryMirror{
+		BaseRegistry: "1.2.3.4:443",
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry: "1.2.3.4:443/eks-anywhere",
+			"docker.io":                       "1.2.3.4:443/docker",
+		},
+		Fallbacks: []*registrymirror.RegistryMirror{
+			{
+				BaseRegistry: "1.2.3.5:443",
+				NamespacedRegistryMap: map[string]string{
+					constants.DefaultCoreEKSARegistry: "1.2.3.5:443",
+				},
+			},
+		},
+	}
+
+	g.Expect(containerd.MirrorEndpoints(mirror)).To(Equal(map[string][]string{
+		constants.DefaultCoreEKSARegistry: {"1.2.3.4:443/v2/eks-anywhere", "1.2.3.5:443"},
+		"docker.io":                       {"1.2.3.4:443/v2/docker"},
+	}))
+}
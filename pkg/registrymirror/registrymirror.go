@@ -6,9 +6,12 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/config"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 // RegistryMirror configures mirror mappings for artifact registries.
@@ -24,6 +27,11 @@ type RegistryMirror struct {
 	// InsecureSkipVerify skips the registry certificate verification.
 	// Only use this solution for isolated testing or in a tightly controlled, air-gapped environment.
 	InsecureSkipVerify bool
+	// Fallbacks are the registry mirrors, in order of preference, used when this one is unreachable.
+	Fallbacks []*RegistryMirror
+	// CredentialsIndex is the position of the registry mirror in the cluster configuration,
+	// 0 being the primary mirror. It selects the env vars holding its credentials.
+	CredentialsIndex int
 }
 
 var re = regexp.MustCompile(constants.DefaultCuratedPackagesRegistryRegex)
@@ -38,6 +46,23 @@ func FromClusterRegistryMirrorConfiguration(config *v1alpha1.RegistryMirrorConfi
 	if config == nil {
 		return nil
 	}
+	registryMirror := fromMirrorEndpoint(v1alpha1.RegistryMirrorEndpoint{
+		Endpoint:           config.Endpoint,
+		Port:               config.Port,
+		OCINamespaces:      config.OCINamespaces,
+		CACertContent:      config.CACertContent,
+		Authenticate:       config.Authenticate,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	})
+	for i, mirror := range config.Mirrors {
+		fallback := fromMirrorEndpoint(mirror)
+		fallback.CredentialsIndex = i + 1
+		registryMirror.Fallbacks = append(registryMirror.Fallbacks, fallback)
+	}
+	return registryMirror
+}
+
+func fromMirrorEndpoint(config v1alpha1.RegistryMirrorEndpoint) *RegistryMirror {
 	registryMap := make(map[string]string)
 	base := net.JoinHostPort(config.Endpoint, config.Port)
 	// add registry mirror base address
@@ -66,6 +91,53 @@ func FromClusterRegistryMirrorConfiguration(config *v1alpha1.RegistryMirrorConfi
 	}
 }
 
+// Mirrors returns this registry mirror followed by its fallbacks, in order of preference.
+func (r *RegistryMirror) Mirrors() []*RegistryMirror {
+	if r == nil {
+		return nil
+	}
+	return append([]*RegistryMirror{r}, r.Fallbacks...)
+}
+
+// Failover returns the first reachable registry mirror in order of preference, with the other
+// mirrors as its fallbacks. The registry mirror is returned unchanged when it has no fallbacks
+// or none of the mirrors is reachable.
+func (r *RegistryMirror) Failover(reachable func(mirror *RegistryMirror) error) *RegistryMirror {
+	if r == nil || len(r.Fallbacks) == 0 {
+		return r
+	}
+	mirrors := r.Mirrors()
+	for i, mirror := range mirrors {
+		err := reachable(mirror)
+		if err == nil {
+			if i == 0 {
+				return r
+			}
+			selected := *mirror
+			selected.Fallbacks = append(append([]*RegistryMirror{}, mirrors[:i]...), mirrors[i+1:]...)
+			return &selected
+		}
+		logger.V(1).Info("Warning: registry mirror is unreachable", "mirror", mirror.BaseRegistry, "error", err)
+	}
+	return r
+}
+
+// Reachable returns a function checking that a registry mirror accepts connections within timeout.
+func Reachable(timeout time.Duration) func(mirror *RegistryMirror) error {
+	return func(mirror *RegistryMirror) error {
+		conn, err := net.DialTimeout("tcp", mirror.BaseRegistry, timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// ReadCredentials reads the credentials of the registry mirror from the env vars matching its CredentialsIndex.
+func (r *RegistryMirror) ReadCredentials() (username, password string, err error) {
+	return config.ReadMirrorCredentials(r.CredentialsIndex)
+}
+
 // CoreEKSAMirror returns the configured mirror for public.ecr.aws.
 func (r *RegistryMirror) CoreEKSAMirror() string {
 	return r.NamespacedRegistryMap[constants.DefaultCoreEKSARegistry]
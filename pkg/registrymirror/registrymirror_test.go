@@ -1,7 +1,10 @@
 package registrymirror_test
 
 import (
+	"errors"
+	"net"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -385,3 +388,168 @@ func TestReplaceRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestFromClusterWithMirrors(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			RegistryMirrorConfiguration: &v1alpha1.RegistryMirrorConfiguration{
+				Endpoint: "1.2.3.4",
+				Port:     "443",
+				Mirrors: []v1alpha1.RegistryMirrorEndpoint{
+					{
+						Endpoint:      "1.2.3.5",
+						Port:          "443",
+						CACertContent: "xyz",
+						Authenticate:  true,
+						OCINamespaces: []v1alpha1.OCINamespace{
+							{
+								Registry:  "public.ecr.aws",
+								Namespace: "eks-anywhere",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := registrymirror.FromCluster(cluster)
+	g.Expect(got.Fallbacks).To(Equal([]*registrymirror.RegistryMirror{
+		{
+			BaseRegistry: "1.2.3.5:443",
+			NamespacedRegistryMap: map[string]string{
+				constants.DefaultCoreEKSARegistry: "1.2.3.5:443/eks-anywhere",
+			},
+			Auth:             true,
+			CACertContent:    "xyz",
+			CredentialsIndex: 1,
+		},
+	}))
+	g.Expect(got.Mirrors()).To(Equal([]*registrymirror.RegistryMirror{got, got.Fallbacks[0]}))
+	g.Expect(got.ReplaceRegistry("public.ecr.aws/eks-anywhere/kube-vip:v0.5.5")).To(Equal("1.2.3.4:443/eks-anywhere/kube-vip:v0.5.5"))
+}
+
+func TestRegistryMirrorReadCredentials(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(constants.RegistryUsername, "user")
+	t.Setenv(constants.RegistryPassword, "pass")
+	t.Setenv("REGISTRY_USERNAME_1", "user1")
+	t.Setenv("REGISTRY_PASSWORD_1", "pass1")
+	mirror := registrymirror.FromClusterRegistryMirrorConfiguration(&v1alpha1.RegistryMirrorConfiguration{
+		Endpoint: "1.2.3.4",
+		Port:     "443",
+		Mirrors: []v1alpha1.RegistryMirrorEndpoint{
+			{Endpoint: "1.2.3.5", Port: "443", Authenticate: true},
+		},
+	})
+
+	username, password, err := mirror.ReadCredentials()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect([]string{username, password}).To(Equal([]string{"user", "pass"}))
+
+	// The credentials follow the mirror when it is selected on failover.
+	selected := mirror.Failover(func(m *registrymirror.RegistryMirror) error {
+		if m.BaseRegistry == "1.2.3.4:443" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	username, password, err = selected.ReadCredentials()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect([]string{username, password}).To(Equal([]string{"user1", "pass1"}))
+}
+
+func TestFailover(t *testing.T) {
+	primary := &registrymirror.RegistryMirror{BaseRegistry: "1.2.3.4:443"}
+	secondary := &registrymirror.RegistryMirror{BaseRegistry: "1.2.3.5:443"}
+	tertiary := &registrymirror.RegistryMirror{BaseRegistry: "1.2.3.6:443"}
+	primary.Fallbacks = []*registrymirror.RegistryMirror{secondary, tertiary}
+
+	unreachable := func(hosts ...string) func(*registrymirror.RegistryMirror) error {
+		return func(mirror *registrymirror.RegistryMirror) error {
+			for _, h := range hosts {
+				if mirror.BaseRegistry == h {
+					return errors.New("connection refused")
+				}
+			}
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mirror        *registrymirror.RegistryMirror
+		reachable     func(*registrymirror.RegistryMirror) error
+		wantBase      string
+		wantFallbacks []*registrymirror.RegistryMirror
+	}{
+		{
+			name:          "primary reachable",
+			mirror:        primary,
+			reachable:     unreachable(),
+			wantBase:      "1.2.3.4:443",
+			wantFallbacks: []*registrymirror.RegistryMirror{secondary, tertiary},
+		},
+		{
+			name:          "primary unreachable",
+			mirror:        primary,
+			reachable:     unreachable("1.2.3.4:443"),
+			wantBase:      "1.2.3.5:443",
+			wantFallbacks: []*registrymirror.RegistryMirror{primary, tertiary},
+		},
+		{
+			name:          "only last mirror reachable",
+			mirror:        primary,
+			reachable:     unreachable("1.2.3.4:443", "1.2.3.5:443"),
+			wantBase:      "1.2.3.6:443",
+			wantFallbacks: []*registrymirror.RegistryMirror{primary, secondary},
+		},
+		{
+			name:          "no mirror reachable",
+			mirror:        primary,
+			reachable:     unreachable("1.2.3.4:443", "1.2.3.5:443", "1.2.3.6:443"),
+			wantBase:      "1.2.3.4:443",
+			wantFallbacks: []*registrymirror.RegistryMirror{secondary, tertiary},
+		},
+		{
+			name: "no fallbacks",
+			mirror: &registrymirror.RegistryMirror{
+				BaseRegistry: "1.2.3.4:443",
+			},
+			reachable: func(*registrymirror.RegistryMirror) error {
+				t.Fatal("reachability should not be checked without fallbacks")
+				return nil
+			},
+			wantBase: "1.2.3.4:443",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := tt.mirror.Failover(tt.reachable)
+			g.Expect(got.BaseRegistry).To(Equal(tt.wantBase))
+			g.Expect(got.Fallbacks).To(Equal(tt.wantFallbacks))
+		})
+	}
+}
+
+func TestFailoverNil(t *testing.T) {
+	g := NewWithT(t)
+	var r *registrymirror.RegistryMirror
+	g.Expect(r.Failover(registrymirror.Reachable(time.Second))).To(BeNil())
+	g.Expect(r.Mirrors()).To(BeNil())
+}
+
+func TestReachable(t *testing.T) {
+	g := NewWithT(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(HaveOccurred())
+	addr := listener.Addr().String()
+
+	reachable := registrymirror.Reachable(time.Second)
+	g.Expect(reachable(&registrymirror.RegistryMirror{BaseRegistry: addr})).To(Succeed())
+
+	g.Expect(listener.Close()).To(Succeed())
+	g.Expect(reachable(&registrymirror.RegistryMirror{BaseRegistry: addr})).NotTo(Succeed())
+}
@@ -0,0 +1,289 @@
+package signature
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+const (
+	cosignSimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation    = "dev.cosignproject.cosign/signature"
+	dsseEnvelopeMediaType        = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType            = "application/vnd.in-toto+json"
+)
+
+// ImageVerificationType selects the cosign artifacts verified for each image.
+type ImageVerificationType string
+
+const (
+	// CosignSignature verifies the cosign signatures of the images.
+	CosignSignature ImageVerificationType = "signature"
+	// CosignAttestation verifies the cosign attestations of the images.
+	CosignAttestation ImageVerificationType = "attestation"
+)
+
+// ImageVerifier verifies the cosign signatures or attestations of container images with a public key.
+// It neither reaches a transparency log nor a certificate authority, so it works offline as long as
+// the images and their cosign artifacts are available from the sources.
+type ImageVerifier struct {
+	publicKey        *ecdsa.PublicKey
+	verificationType ImageVerificationType
+	sources          registry.SourceSelector
+}
+
+// NewImageVerifier creates an ImageVerifier checking the images from sources with the PEM encoded ECDSA publicKey.
+func NewImageVerifier(publicKey []byte, verificationType ImageVerificationType, sources registry.SourceSelector) (*ImageVerifier, error) {
+	if verificationType != CosignSignature && verificationType != CosignAttestation {
+		return nil, fmt.Errorf("invalid image verification type %q, must be one of %s, %s", verificationType, CosignSignature, CosignAttestation)
+	}
+
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return nil, errors.New("decoding the image verification public key: no PEM data found")
+	}
+	key, err := parsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageVerifier{
+		publicKey:        key,
+		verificationType: verificationType,
+		sources:          sources,
+	}, nil
+}
+
+// ImageVerificationResult is the outcome of the verification of an image.
+type ImageVerificationResult struct {
+	Image    string `json:"image"`
+	Digest   string `json:"digest,omitempty"`
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImageVerificationReport records the verification of a set of images.
+type ImageVerificationReport struct {
+	Type   ImageVerificationType     `json:"type"`
+	Images []ImageVerificationResult `json:"images"`
+}
+
+// Failed returns the results of the images that could not be verified.
+func (r *ImageVerificationReport) Failed() []ImageVerificationResult {
+	var failed []ImageVerificationResult
+	for _, result := range r.Images {
+		if !result.Verified {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns an error summarizing the images that could not be verified, or nil if all of them were.
+func (r *ImageVerificationReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	images := make([]string, 0, len(failed))
+	for _, result := range failed {
+		images = append(images, result.Image)
+	}
+	return fmt.Errorf("%s verification failed for %d of %d images: %s", r.Type, len(failed), len(r.Images), strings.Join(images, ", "))
+}
+
+// Write writes the report to w in YAML.
+func (r *ImageVerificationReport) Write(w io.Writer) error {
+	content, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("marshalling image verification report: %v", err)
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+// Verify verifies each image and reports the results, sorted by image.
+func (v *ImageVerifier) Verify(ctx context.Context, images ...string) *ImageVerificationReport {
+	unique := map[string]struct{}{}
+	for _, image := range images {
+		unique[image] = struct{}{}
+	}
+	report := &ImageVerificationReport{
+		Type:   v.verificationType,
+		Images: make([]ImageVerificationResult, 0, len(unique)),
+	}
+	for image := range unique {
+		result := ImageVerificationResult{Image: image}
+		digest, err := v.verify(ctx, registry.NewArtifactFromURI(image))
+		result.Digest = digest
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Verified = true
+		}
+		report.Images = append(report.Images, result)
+	}
+	sort.Slice(report.Images, func(i, j int) bool {
+		return report.Images[i].Image < report.Images[j].Image
+	})
+	return report
+}
+
+// verify checks that one of the cosign artifacts of image is signed with the public key and
+// refers to the image manifest. It returns the digest of the image manifest.
+func (v *ImageVerifier) verify(ctx context.Context, image registry.Artifact) (digest string, err error) {
+	client, err := v.sources(image)
+	if err != nil {
+		return "", fmt.Errorf("getting source: %v", err)
+	}
+	repo, err := client.GetStorage(ctx, image)
+	if err != nil {
+		return "", err
+	}
+	desc, err := client.Resolve(ctx, repo, image.VersionedImage())
+	if err != nil {
+		return "", fmt.Errorf("resolving image: %v", err)
+	}
+	digest = string(desc.Digest)
+
+	suffix, mediaType, verify := registry.CosignSignatureTagSuffix, cosignSimpleSigningMediaType, v.verifySimpleSigning
+	if v.verificationType == CosignAttestation {
+		suffix, mediaType, verify = registry.CosignAttestationTagSuffix, dsseEnvelopeMediaType, v.verifyAttestation
+	}
+
+	artifact := registry.NewArtifact(image.Registry, image.Repository, registry.CosignTag(digest, suffix), "")
+	_, content, err := client.FetchBytes(ctx, repo, artifact)
+	if err != nil {
+		return digest, fmt.Errorf("no cosign %s found: %v", v.verificationType, err)
+	}
+	manifest := ocispec.Manifest{}
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		return digest, fmt.Errorf("parsing cosign %s manifest: %v", v.verificationType, err)
+	}
+
+	errs := []string{}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaType {
+			continue
+		}
+		blob, err := client.FetchBlob(ctx, repo, layer)
+		if err != nil {
+			return digest, fmt.Errorf("fetching cosign %s: %v", v.verificationType, err)
+		}
+		if err = verify(layer, blob, digest); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		return digest, nil
+	}
+
+	if len(errs) == 0 {
+		return digest, fmt.Errorf("no cosign %s found", v.verificationType)
+	}
+	return digest, fmt.Errorf("no valid cosign %s: %s", v.verificationType, strings.Join(errs, "; "))
+}
+
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySimpleSigning verifies a cosign signature, whose payload is signed in the layer annotations.
+func (v *ImageVerifier) verifySimpleSigning(layer ocispec.Descriptor, payload []byte, digest string) error {
+	sig, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+	if err != nil {
+		return fmt.Errorf("signature isn't base64 encoded: %v", err)
+	}
+	if !v.verifyPayload(payload, sig) {
+		return errors.New("invalid signature")
+	}
+
+	p := simpleSigningPayload{}
+	if err = json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("parsing signature payload: %v", err)
+	}
+	if p.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for digest %s", p.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+	Signatures  []struct {
+		Sig string `json:"sig"`
+	} `json:"signatures"`
+}
+
+type inTotoStatement struct {
+	Subject []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// verifyAttestation verifies a cosign attestation, an in-toto statement signed in a DSSE envelope.
+func (v *ImageVerifier) verifyAttestation(_ ocispec.Descriptor, content []byte, digest string) error {
+	envelope := dsseEnvelope{}
+	if err := json.Unmarshal(content, &envelope); err != nil {
+		return fmt.Errorf("parsing attestation envelope: %v", err)
+	}
+	if envelope.PayloadType != inTotoPayloadType {
+		return fmt.Errorf("unsupported attestation payload type %s", envelope.PayloadType)
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return fmt.Errorf("attestation payload isn't base64 encoded: %v", err)
+	}
+
+	signed := false
+	pae := dssePreAuthEncoding(envelope.PayloadType, payload)
+	for _, s := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err == nil && v.verifyPayload(pae, sig) {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return errors.New("invalid attestation signature")
+	}
+
+	statement := inTotoStatement{}
+	if err = json.Unmarshal(payload, &statement); err != nil {
+		return fmt.Errorf("parsing attestation statement: %v", err)
+	}
+	algorithm, hex, _ := strings.Cut(digest, ":")
+	for _, subject := range statement.Subject {
+		if subject.Digest[algorithm] == hex {
+			return nil
+		}
+	}
+	return fmt.Errorf("attestation subject doesn't match digest %s", digest)
+}
+
+func (v *ImageVerifier) verifyPayload(payload, sig []byte) bool {
+	sum := sha256.Sum256(payload)
+	return ecdsa.VerifyASN1(v.publicKey, sum[:], sig)
+}
+
+// dssePreAuthEncoding returns the DSSE v1 pre-authentication encoding of a payload, the content actually signed.
+func dssePreAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
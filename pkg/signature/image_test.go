@@ -0,0 +1,201 @@
+package signature
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+
+	"github.com/aws/eks-anywhere/pkg/registry"
+)
+
+const testImage = "public.ecr.aws/eks-anywhere/kube-vip:v0.5.5"
+
+type imageVerifierTest struct {
+	*gomega.WithT
+	ctx    context.Context
+	key    *ecdsa.PrivateKey
+	pubKey []byte
+	layout *registry.OCILayoutClient
+	digest string
+}
+
+func newImageVerifierTest(t *testing.T) *imageVerifierTest {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	layout := registry.NewOCILayout(filepath.Join(t.TempDir(), "layout"))
+	g.Expect(layout.Init()).To(gomega.Succeed())
+
+	tt := &imageVerifierTest{
+		WithT:  g,
+		ctx:    ctx,
+		key:    key,
+		pubKey: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		layout: layout,
+	}
+	tt.digest = string(tt.push(registry.NewArtifactFromURI(testImage), "application/vnd.eksa.test", ocispec.MediaTypeImageLayer, []byte("layer"), nil).Digest)
+	return tt
+}
+
+func (tt *imageVerifierTest) push(image registry.Artifact, artifactType, mediaType string, content []byte, annotations map[string]string) ocispec.Descriptor {
+	repo, err := tt.layout.GetStorage(tt.ctx, image)
+	tt.Expect(err).NotTo(gomega.HaveOccurred())
+	layer, err := oras.PushBytes(tt.ctx, repo, mediaType, content)
+	tt.Expect(err).NotTo(gomega.HaveOccurred())
+	layer.Annotations = annotations
+	manifest, err := oras.PackManifest(tt.ctx, repo, oras.PackManifestVersion1_0, artifactType, oras.PackManifestOptions{
+		Layers: []ocispec.Descriptor{layer},
+	})
+	tt.Expect(err).NotTo(gomega.HaveOccurred())
+	tt.Expect(repo.Tag(tt.ctx, manifest, image.Tag)).To(gomega.Succeed())
+	return manifest
+}
+
+func (tt *imageVerifierTest) sign(content []byte) string {
+	sum := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, tt.key, sum[:])
+	tt.Expect(err).NotTo(gomega.HaveOccurred())
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func (tt *imageVerifierTest) cosignArtifact(suffix string) registry.Artifact {
+	image := registry.NewArtifactFromURI(testImage)
+	return registry.NewArtifact(image.Registry, image.Repository, registry.CosignTag(tt.digest, suffix), "")
+}
+
+func (tt *imageVerifierTest) pushSignature(digest, sig string) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"public.ecr.aws/eks-anywhere/kube-vip"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+	if sig == "" {
+		sig = tt.sign(payload)
+	}
+	tt.push(tt.cosignArtifact(registry.CosignSignatureTagSuffix), "", cosignSimpleSigningMediaType, payload, map[string]string{
+		cosignSignatureAnnotation: sig,
+	})
+}
+
+func (tt *imageVerifierTest) pushAttestation(digest string) {
+	_, hex, _ := strings.Cut(digest, ":")
+	statement := []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2","subject":[{"name":"public.ecr.aws/eks-anywhere/kube-vip","digest":{"sha256":%q}}],"predicate":{}}`, hex))
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payloadType": inTotoPayloadType,
+		"payload":     base64.StdEncoding.EncodeToString(statement),
+		"signatures":  []map[string]string{{"sig": tt.sign(dssePreAuthEncoding(inTotoPayloadType, statement))}},
+	})
+	tt.Expect(err).NotTo(gomega.HaveOccurred())
+	tt.push(tt.cosignArtifact(registry.CosignAttestationTagSuffix), "", dsseEnvelopeMediaType, envelope, nil)
+}
+
+func (tt *imageVerifierTest) verify(verificationType ImageVerificationType) *ImageVerificationReport {
+	verifier, err := NewImageVerifier(tt.pubKey, verificationType, registry.StorageSource(tt.layout))
+	tt.Expect(err).NotTo(gomega.HaveOccurred())
+	return verifier.Verify(tt.ctx, testImage, testImage)
+}
+
+func TestImageVerifierSignature(t *testing.T) {
+	tt := newImageVerifierTest(t)
+	tt.pushSignature(tt.digest, "")
+
+	report := tt.verify(CosignSignature)
+	tt.Expect(report.Images).To(gomega.Equal([]ImageVerificationResult{
+		{Image: testImage, Digest: tt.digest, Verified: true},
+	}))
+	tt.Expect(report.Err()).To(gomega.Succeed())
+}
+
+func TestImageVerifierSignatureInvalid(t *testing.T) {
+	tt := newImageVerifierTest(t)
+	tt.pushSignature(tt.digest, base64.StdEncoding.EncodeToString([]byte("invalid")))
+
+	report := tt.verify(CosignSignature)
+	tt.Expect(report.Failed()).To(gomega.Equal([]ImageVerificationResult{
+		{Image: testImage, Digest: tt.digest, Error: "no valid cosign signature: invalid signature"},
+	}))
+	tt.Expect(report.Err()).To(gomega.MatchError("signature verification failed for 1 of 1 images: " + testImage))
+}
+
+func TestImageVerifierSignatureOtherDigest(t *testing.T) {
+	tt := newImageVerifierTest(t)
+	tt.pushSignature("sha256:0000", "")
+
+	report := tt.verify(CosignSignature)
+	tt.Expect(report.Failed()).To(gomega.HaveLen(1))
+	tt.Expect(report.Images[0].Error).To(gomega.Equal("no valid cosign signature: signature is for digest sha256:0000"))
+}
+
+func TestImageVerifierSignatureMissing(t *testing.T) {
+	tt := newImageVerifierTest(t)
+
+	report := tt.verify(CosignSignature)
+	tt.Expect(report.Failed()).To(gomega.HaveLen(1))
+	tt.Expect(report.Images[0].Error).To(gomega.HavePrefix("no cosign signature found"))
+}
+
+func TestImageVerifierAttestation(t *testing.T) {
+	tt := newImageVerifierTest(t)
+	tt.pushAttestation(tt.digest)
+
+	tt.Expect(tt.verify(CosignAttestation).Err()).To(gomega.Succeed())
+	// Attestations don't count as signatures.
+	tt.Expect(tt.verify(CosignSignature).Err()).To(gomega.HaveOccurred())
+}
+
+func TestImageVerifierAttestationOtherSubject(t *testing.T) {
+	tt := newImageVerifierTest(t)
+	tt.pushAttestation("sha256:0000")
+
+	report := tt.verify(CosignAttestation)
+	tt.Expect(report.Images[0].Error).To(gomega.Equal(
+		fmt.Sprintf("no valid cosign attestation: attestation subject doesn't match digest %s", tt.digest),
+	))
+}
+
+func TestImageVerificationReportWrite(t *testing.T) {
+	g := gomega.NewWithT(t)
+	report := &ImageVerificationReport{
+		Type: CosignSignature,
+		Images: []ImageVerificationResult{
+			{Image: "a", Digest: "sha256:1", Verified: true},
+			{Image: "b", Error: "resolving image: not found"},
+		},
+	}
+
+	b := &bytes.Buffer{}
+	g.Expect(report.Write(b)).To(gomega.Succeed())
+	g.Expect(b.String()).To(gomega.Equal(`images:
+- digest: sha256:1
+  image: a
+  verified: true
+- error: 'resolving image: not found'
+  image: b
+  verified: false
+type: signature
+`))
+}
+
+func TestNewImageVerifierErrors(t *testing.T) {
+	g := gomega.NewWithT(t)
+	_, err := NewImageVerifier([]byte("key"), "other", nil)
+	g.Expect(err).To(gomega.MatchError(`invalid image verification type "other", must be one of signature, attestation`))
+
+	_, err = NewImageVerifier([]byte("key"), CosignSignature, nil)
+	g.Expect(err).To(gomega.MatchError("decoding the image verification public key: no PEM data found"))
+}
@@ -216,7 +216,11 @@ func parsePublicKey(key string) (*ecdsa.PublicKey, error) {
 		return nil, fmt.Errorf("decoding the public key as string: %w", err)
 	}
 
-	pubparsed, err := x509.ParsePKIXPublicKey(pubdecoded)
+	return parsePKIXPublicKey(pubdecoded)
+}
+
+func parsePKIXPublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	pubparsed, err := x509.ParsePKIXPublicKey(der)
 	if err != nil {
 		return nil, fmt.Errorf("parsing the public key (not PKIX): %w", err)
 	}
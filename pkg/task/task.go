@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -150,21 +151,29 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 		return err
 	}
 
+	clusterName := commandContext.ClusterSpec.Cluster.Name
 	for task != nil {
 		if completedTask, ok := checkpointInfo.CompletedTasks[task.Name()]; ok {
-			logger.V(4).Info("Restoring task", "task_name", task.Name())
+			taskName := task.Name()
+			logger.V(4).Info("Restoring task", "task_name", taskName)
 			nextTask, err := task.Restore(ctx, commandContext, completedTask)
 			if err != nil {
+				events.Emit(ctx, events.Event{Type: events.TaskFailed, Cluster: clusterName, Task: taskName, Error: err.Error()})
 				return fmt.Errorf("restoring checkpoint info: %v", err)
 			}
+			events.Emit(ctx, events.Event{Type: events.TaskRestored, Cluster: clusterName, Task: taskName})
 			task = nextTask
 			continue
 		}
-		logger.V(4).Info("Task start", "task_name", task.Name())
+		taskName := task.Name()
+		logger.V(4).Info("Task start", "task_name", taskName)
+		events.Emit(ctx, events.Event{Type: events.TaskStarted, Cluster: clusterName, Task: taskName})
+		previousError := commandContext.OriginalError
 		commandContext.Profiler.SetStartTask(task.Name())
 		nextTask := task.Run(ctx, commandContext)
 		commandContext.Profiler.MarkDoneTask(task.Name())
 		commandContext.Profiler.logProfileSummary(task.Name())
+		emitTaskCompleted(ctx, clusterName, taskName, commandContext, previousError)
 		if commandContext.OriginalError == nil {
 			checkpointInfo.taskCompleted(task.Name(), task.Checkpoint())
 		}
@@ -178,6 +187,24 @@ func (tr *taskRunner) RunTask(ctx context.Context, commandContext *CommandContex
 	return commandContext.OriginalError
 }
 
+// emitTaskCompleted emits a TaskFailed event if the task set the command context error, otherwise
+// it emits a TaskFinished event.
+func emitTaskCompleted(ctx context.Context, clusterName, taskName string, commandContext *CommandContext, previousError error) {
+	event := events.Event{
+		Type:            events.TaskFinished,
+		Cluster:         clusterName,
+		Task:            taskName,
+		DurationSeconds: commandContext.Profiler.Metrics()[taskName][taskName].Seconds(),
+	}
+
+	if commandContext.OriginalError != nil && previousError == nil {
+		event.Type = events.TaskFailed
+		event.Error = commandContext.OriginalError.Error()
+	}
+
+	events.Emit(ctx, event)
+}
+
 func taskRunnerFinalBlock(startTime time.Time) {
 	logger.V(4).Info("Tasks completed", "duration", time.Since(startTime))
 }
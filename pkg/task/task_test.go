@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/features"
 	writermocks "github.com/aws/eks-anywhere/pkg/filewriter/mocks"
 	"github.com/aws/eks-anywhere/pkg/task"
@@ -59,6 +61,53 @@ func TestTaskRunnerRunTask(t *testing.T) {
 	}
 }
 
+type recordingSink struct {
+	events []events.Event
+}
+
+func (s *recordingSink) Emit(e events.Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestTaskRunnerRunTaskEmitsEvents(t *testing.T) {
+	tr := newTaskRunnerTest(t)
+	tr.cmdContext.ClusterSpec.Cluster.Name = "my-cluster"
+	sink := &recordingSink{}
+	ctx := events.WithSink(tr.ctx, sink)
+
+	tr.taskA.EXPECT().Run(ctx, tr.cmdContext).DoAndReturn(func(_ context.Context, c *task.CommandContext) task.Task {
+		c.SetError(fmt.Errorf("task failed"))
+		return tr.taskB
+	})
+	tr.taskA.EXPECT().Name().Return("taskA").AnyTimes()
+	tr.taskB.EXPECT().Run(ctx, tr.cmdContext).Return(nil)
+	tr.taskB.EXPECT().Name().Return("taskB").AnyTimes()
+	tr.writer.EXPECT().Write(gomock.Any(), gomock.Any())
+
+	runner := task.NewTaskRunner(tr.taskA, tr.writer)
+	if err := runner.RunTask(ctx, tr.cmdContext); err == nil {
+		t.Fatal("Task.RunTask want err, got nil")
+	}
+
+	var got []string
+	for _, e := range sink.events {
+		if e.Cluster != "my-cluster" {
+			t.Fatalf("event cluster = %v, want my-cluster", e.Cluster)
+		}
+		got = append(got, fmt.Sprintf("%s/%s", e.Type, e.Task))
+	}
+
+	want := []string{"TaskStarted/taskA", "TaskFailed/taskA", "TaskStarted/taskB", "TaskFinished/taskB"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+
+	if sink.events[1].Error != "task failed" {
+		t.Fatalf("failed event error = %v, want task failed", sink.events[1].Error)
+	}
+}
+
 func TestTaskRunnerRunTaskWithCheckpointSecondRunSuccess(t *testing.T) {
 	tt := newTaskRunnerTest(t)
 
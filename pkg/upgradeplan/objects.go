@@ -0,0 +1,116 @@
+package upgradeplan
+
+import (
+	"fmt"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	unstructuredutil "github.com/aws/eks-anywhere/pkg/utils/unstructured"
+)
+
+// Objects are the CAPI objects that define the machines of each machine group of a cluster.
+type Objects struct {
+	KubeadmControlPlane *controlplanev1.KubeadmControlPlane
+	EtcdadmCluster      *etcdv1.EtcdadmCluster
+
+	// MachineDeployments are indexed by name.
+	MachineDeployments map[string]*clusterv1.MachineDeployment
+}
+
+// ParseObjects reads the KubeadmControlPlane, EtcdadmCluster and MachineDeployments from CAPI
+// manifests, such as the ones generated by a provider. Other objects are ignored.
+func ParseObjects(manifests ...[]byte) (*Objects, error) {
+	objs := &Objects{MachineDeployments: map[string]*clusterv1.MachineDeployment{}}
+	for _, manifest := range manifests {
+		parsed, err := unstructuredutil.YamlToUnstructured(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CAPI manifest: %v", err)
+		}
+
+		for _, u := range parsed {
+			var obj interface{}
+			switch u.GetKind() {
+			case "KubeadmControlPlane":
+				objs.KubeadmControlPlane = &controlplanev1.KubeadmControlPlane{}
+				obj = objs.KubeadmControlPlane
+			case "EtcdadmCluster":
+				objs.EtcdadmCluster = &etcdv1.EtcdadmCluster{}
+				obj = objs.EtcdadmCluster
+			case "MachineDeployment":
+				md := &clusterv1.MachineDeployment{}
+				objs.MachineDeployments[u.GetName()] = md
+				obj = md
+			default:
+				continue
+			}
+
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+				return nil, fmt.Errorf("converting %s %s: %v", u.GetKind(), u.GetName(), err)
+			}
+		}
+	}
+
+	return objs, nil
+}
+
+func (o *Objects) kubeadmControlPlane() *controlplanev1.KubeadmControlPlane {
+	if o == nil {
+		return nil
+	}
+	return o.KubeadmControlPlane
+}
+
+func (o *Objects) etcdadmCluster() *etcdv1.EtcdadmCluster {
+	if o == nil {
+		return nil
+	}
+	return o.EtcdadmCluster
+}
+
+func (o *Objects) machineDeployment(name string) *clusterv1.MachineDeployment {
+	if o == nil {
+		return nil
+	}
+	return o.MachineDeployments[name]
+}
+
+// The desired objects are generated and lack the defaults set by the API server, so bootstrap
+// configs are compared with DeepDerivative, which ignores fields unset in the desired config.
+
+func controlPlaneTemplatesChanged(current, desired *controlplanev1.KubeadmControlPlane) bool {
+	if current == nil || desired == nil {
+		return false
+	}
+
+	return current.Spec.MachineTemplate.InfrastructureRef.Name != desired.Spec.MachineTemplate.InfrastructureRef.Name ||
+		!equality.Semantic.DeepDerivative(desired.Spec.KubeadmConfigSpec, current.Spec.KubeadmConfigSpec)
+}
+
+func etcdTemplatesChanged(current, desired *etcdv1.EtcdadmCluster) bool {
+	if current == nil || desired == nil {
+		return false
+	}
+
+	return current.Spec.InfrastructureTemplate.Name != desired.Spec.InfrastructureTemplate.Name ||
+		!equality.Semantic.DeepDerivative(desired.Spec.EtcdadmConfigSpec, current.Spec.EtcdadmConfigSpec)
+}
+
+func machineDeploymentTemplatesChanged(current, desired *clusterv1.MachineDeployment) bool {
+	if current == nil || desired == nil {
+		return false
+	}
+
+	return current.Spec.Template.Spec.InfrastructureRef.Name != desired.Spec.Template.Spec.InfrastructureRef.Name ||
+		configRefName(current) != configRefName(desired)
+}
+
+func configRefName(md *clusterv1.MachineDeployment) string {
+	if md.Spec.Template.Spec.Bootstrap.ConfigRef == nil {
+		return ""
+	}
+	return md.Spec.Template.Spec.Bootstrap.ConfigRef.Name
+}
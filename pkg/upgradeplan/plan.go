@@ -0,0 +1,267 @@
+package upgradeplan
+
+import (
+	"sort"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+const (
+	controlPlaneLabel = "cluster.x-k8s.io/control-plane"
+	externalEtcdLabel = "cluster.x-k8s.io/etcd-cluster"
+)
+
+// GroupKind identifies the type of machine group a GroupPlan describes.
+type GroupKind string
+
+const (
+	// ControlPlaneGroup is the group of control plane machines.
+	ControlPlaneGroup GroupKind = "ControlPlane"
+
+	// EtcdGroup is the group of external etcd machines.
+	EtcdGroup GroupKind = "Etcd"
+
+	// WorkerNodeGroup is a group of worker machines.
+	WorkerNodeGroup GroupKind = "WorkerNodeGroup"
+)
+
+// Plan describes how the machines of a cluster would be rolled out during an upgrade.
+type Plan struct {
+	// Groups contains a plan for each machine group in the order they are upgraded.
+	Groups []GroupPlan `json:"groups"`
+
+	// MaxAdditionalMachines is the peak number of machines that exist in addition to the
+	// cluster's desired machine count at any point during the upgrade. It represents the spare
+	// capacity, such as hardware or hypervisor resources, the upgrade consumes.
+	MaxAdditionalMachines int `json:"maxAdditionalMachines"`
+}
+
+// GroupPlan describes the rollout of a single machine group.
+type GroupPlan struct {
+	// Name is the name of the control plane, etcd cluster or worker node group.
+	Name string `json:"name"`
+
+	// Kind is the kind of machine group.
+	Kind GroupKind `json:"kind"`
+
+	// Strategy is the upgrade rollout strategy used for the group.
+	Strategy anywherev1.UpgradeRolloutStrategyType `json:"strategy"`
+
+	// MaxSurge is the maximum number of machines created above the desired count.
+	MaxSurge int `json:"maxSurge"`
+
+	// MaxUnavailable is the maximum number of machines that may be unavailable.
+	MaxUnavailable int `json:"maxUnavailable"`
+
+	// TargetVersion is the Kubernetes version the group is upgraded to.
+	TargetVersion string `json:"targetVersion,omitempty"`
+
+	// Machines is the number of machines currently in the group.
+	Machines int `json:"machines"`
+
+	// Steps are the ordered rollout steps. Empty if the group is up to date.
+	Steps []Step `json:"steps,omitempty"`
+
+	// AdditionalMachines is the peak number of machines the group creates above its current
+	// machine count.
+	AdditionalMachines int `json:"additionalMachines"`
+}
+
+// Step is a single rollout step. Machines are created before old machines are removed.
+type Step struct {
+	// NewMachines is the number of replacement machines created in the step.
+	NewMachines int `json:"newMachines,omitempty"`
+
+	// ReplacedMachines are the names of machines replaced in the step. They're deleted in the
+	// step except for etcd machines, which are kept until the control plane is upgraded.
+	ReplacedMachines []string `json:"replacedMachines,omitempty"`
+
+	// InPlaceMachines are the names of machines upgraded in place in the step.
+	InPlaceMachines []string `json:"inPlaceMachines,omitempty"`
+
+	// Unavailable is the number of machines unavailable during the step.
+	Unavailable int `json:"unavailable"`
+}
+
+// Build computes the rollout plan for upgrading machines to spec. Machines are the cluster's
+// current CAPI Machines. Current are the CAPI objects in the cluster and desired the ones spec
+// generates; either may be nil when unknown.
+//
+// Machines are considered out of date when their Kubernetes version differs from the version in
+// spec or when the desired objects of their group reference a different infrastructure template
+// or bootstrap config than the current ones. External etcd machines are replaced whenever the
+// control plane Kubernetes version changes. Replacement order is oldest machine first which
+// approximates the CAPI controllers' behavior.
+func Build(spec *cluster.Spec, machines []clusterv1.Machine, current, desired *Objects) *Plan {
+	sorted := append([]clusterv1.Machine{}, machines...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+	})
+
+	plan := &Plan{}
+
+	controlPlaneMachines := filterMachines(sorted, func(m clusterv1.Machine) bool {
+		_, ok := m.Labels[controlPlaneLabel]
+		return ok
+	})
+	controlPlane := controlPlanePlan(spec, controlPlaneMachines, controlPlaneTemplatesChanged(current.kubeadmControlPlane(), desired.kubeadmControlPlane()))
+
+	if spec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+		etcdName := clusterapi.EtcdClusterName(spec.Cluster.Name)
+		etcd := filterMachines(sorted, func(m clusterv1.Machine) bool {
+			return m.Labels[externalEtcdLabel] == etcdName
+		})
+
+		// The etcdadm controller replaces machines one at a time but keeps the replaced machines
+		// until the control plane is upgraded, as the old control plane machines still use them.
+		// The whole etcd cluster is surged for the duration of the control plane upgrade.
+		group := GroupPlan{
+			Name:     etcdName,
+			Kind:     EtcdGroup,
+			Strategy: anywherev1.RollingUpdateStrategyType,
+			MaxSurge: len(etcd),
+			Machines: len(etcd),
+		}
+		versionChanged := len(outdatedMachines(controlPlaneMachines, controlPlane.TargetVersion, false)) > 0
+		if versionChanged || etcdTemplatesChanged(current.etcdadmCluster(), desired.etcdadmCluster()) {
+			group.Steps, group.AdditionalMachines = retainedRollout(machineNames(etcd))
+		}
+		plan.Groups = append(plan.Groups, group)
+		plan.MaxAdditionalMachines = group.AdditionalMachines
+	}
+
+	plan.Groups = append(plan.Groups, controlPlane)
+	plan.MaxAdditionalMachines += controlPlane.AdditionalMachines
+
+	// Machine deployments are rolled out concurrently once the control plane is upgraded so
+	// their additional machines accumulate.
+	workersAdditional := 0
+	for _, wng := range spec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		name := clusterapi.MachineDeploymentName(spec.Cluster, wng)
+		group := workerNodeGroupPlan(spec, wng, filterMachines(sorted, func(m clusterv1.Machine) bool {
+			return m.Labels[clusterv1.MachineDeploymentNameLabel] == name
+		}), machineDeploymentTemplatesChanged(current.machineDeployment(name), desired.machineDeployment(name)))
+		plan.Groups = append(plan.Groups, group)
+		workersAdditional += group.AdditionalMachines
+	}
+	plan.MaxAdditionalMachines = max(plan.MaxAdditionalMachines, workersAdditional)
+
+	return plan
+}
+
+// Changed returns true if any machine group requires an upgrade.
+func (p *Plan) Changed() bool {
+	for _, g := range p.Groups {
+		if len(g.Steps) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func controlPlanePlan(spec *cluster.Spec, machines []clusterv1.Machine, templatesChanged bool) GroupPlan {
+	group := GroupPlan{
+		Name:          clusterapi.KubeadmControlPlaneName(spec.Cluster),
+		Kind:          ControlPlaneGroup,
+		Strategy:      anywherev1.RollingUpdateStrategyType,
+		MaxSurge:      1,
+		TargetVersion: spec.RootVersionsBundle().KubeDistro.Kubernetes.Tag,
+		Machines:      len(machines),
+	}
+
+	if strategy := spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy; strategy != nil {
+		if strategy.Type != "" {
+			group.Strategy = strategy.Type
+		}
+		if strategy.RollingUpdate != nil {
+			group.MaxSurge = strategy.RollingUpdate.MaxSurge
+		}
+	}
+
+	// KCP never has more than one machine unavailable. Without surge the old machine is removed
+	// before its replacement is created.
+	if group.MaxSurge == 0 {
+		group.MaxUnavailable = 1
+	}
+
+	group.plan(machines, spec.Cluster.Spec.ControlPlaneConfiguration.Count, templatesChanged)
+	return group
+}
+
+func workerNodeGroupPlan(spec *cluster.Spec, wng anywherev1.WorkerNodeGroupConfiguration, machines []clusterv1.Machine, templatesChanged bool) GroupPlan {
+	group := GroupPlan{
+		Name:          wng.Name,
+		Kind:          WorkerNodeGroup,
+		Strategy:      anywherev1.RollingUpdateStrategyType,
+		MaxSurge:      1,
+		TargetVersion: spec.WorkerNodeGroupVersionsBundle(wng).KubeDistro.Kubernetes.Tag,
+		Machines:      len(machines),
+	}
+
+	if strategy := wng.UpgradeRolloutStrategy; strategy != nil {
+		if strategy.Type != "" {
+			group.Strategy = strategy.Type
+		}
+		if strategy.RollingUpdate != nil {
+			group.MaxSurge = strategy.RollingUpdate.MaxSurge
+			group.MaxUnavailable = strategy.RollingUpdate.MaxUnavailable
+		}
+	}
+
+	desired := group.Machines
+	if wng.Count != nil {
+		desired = *wng.Count
+	}
+
+	group.plan(machines, desired, templatesChanged)
+	return group
+}
+
+// plan populates the group steps for upgrading the machines that don't run the group's target
+// version, or all machines if the group's templates changed.
+func (g *GroupPlan) plan(machines []clusterv1.Machine, desired int, templatesChanged bool) {
+	outdated := outdatedMachines(machines, g.TargetVersion, templatesChanged)
+	if len(outdated) == 0 {
+		return
+	}
+
+	if g.Strategy == anywherev1.InPlaceStrategyType {
+		g.MaxSurge, g.MaxUnavailable = 0, 1
+		g.Steps = inPlace(outdated)
+		return
+	}
+
+	g.Steps, g.AdditionalMachines = rollingUpdate(outdated, len(machines)-len(outdated), desired, g.MaxSurge, g.MaxUnavailable)
+}
+
+func outdatedMachines(machines []clusterv1.Machine, version string, templatesChanged bool) []string {
+	var outdated []string
+	for _, m := range machines {
+		if templatesChanged || m.Spec.Version == nil || *m.Spec.Version != version {
+			outdated = append(outdated, m.Name)
+		}
+	}
+	return outdated
+}
+
+func filterMachines(machines []clusterv1.Machine, keep func(clusterv1.Machine) bool) []clusterv1.Machine {
+	var filtered []clusterv1.Machine
+	for _, m := range machines {
+		if keep(m) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
+
+func machineNames(machines []clusterv1.Machine) []string {
+	names := make([]string, 0, len(machines))
+	for _, m := range machines {
+		names = append(names, m.Name)
+	}
+	return names
+}
@@ -0,0 +1,438 @@
+package upgradeplan_test
+
+import (
+	"testing"
+	"time"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/upgradeplan"
+)
+
+const (
+	oldVersion = "v1.18.0-eks-1-18-1"
+	newVersion = "v1.19.8-eks-1-19-4"
+)
+
+// singleMachineSpec returns a spec with a single control plane and worker machine.
+func singleMachineSpec() *cluster.Spec {
+	return newSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.To(1)
+	})
+}
+
+func newSpec(opts ...func(*cluster.Spec)) *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test"
+		s.Cluster.Spec.ControlPlaneConfiguration.Count = 3
+		s.Cluster.Spec.WorkerNodeGroupConfigurations = []anywherev1.WorkerNodeGroupConfiguration{
+			{Name: "md-0", Count: ptr.To(3)},
+		}
+		s.VersionsBundles[anywherev1.Kube119].KubeDistro.Kubernetes.Tag = newVersion
+		for _, opt := range opts {
+			opt(s)
+		}
+	})
+}
+
+func machine(name, version string, age int, labels map[string]string) clusterv1.Machine {
+	return clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(time.Unix(int64(age), 0)),
+		},
+		Spec: clusterv1.MachineSpec{Version: ptr.To(version)},
+	}
+}
+
+func controlPlaneMachine(name, version string, age int) clusterv1.Machine {
+	return machine(name, version, age, map[string]string{"cluster.x-k8s.io/control-plane": ""})
+}
+
+func workerMachine(name, version string, age int) clusterv1.Machine {
+	return machine(name, version, age, map[string]string{clusterv1.MachineDeploymentNameLabel: "test-md-0"})
+}
+
+func TestBuildRollingUpdateDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	machines := []clusterv1.Machine{
+		controlPlaneMachine("cp-2", oldVersion, 2),
+		controlPlaneMachine("cp-1", oldVersion, 1),
+		controlPlaneMachine("cp-3", oldVersion, 3),
+		workerMachine("md-1", oldVersion, 1),
+		workerMachine("md-2", oldVersion, 2),
+		workerMachine("md-3", newVersion, 3),
+	}
+
+	plan := upgradeplan.Build(newSpec(), machines, nil, nil)
+	g.Expect(plan.Changed()).To(BeTrue())
+	g.Expect(plan.Groups).To(HaveLen(2))
+
+	cp := plan.Groups[0]
+	g.Expect(cp.Kind).To(Equal(upgradeplan.ControlPlaneGroup))
+	g.Expect(cp.Strategy).To(Equal(anywherev1.RollingUpdateStrategyType))
+	g.Expect(cp.MaxSurge).To(Equal(1))
+	g.Expect(cp.AdditionalMachines).To(Equal(1))
+	g.Expect(cp.Steps).To(Equal([]upgradeplan.Step{
+		{NewMachines: 1, ReplacedMachines: []string{"cp-1"}},
+		{NewMachines: 1, ReplacedMachines: []string{"cp-2"}},
+		{NewMachines: 1, ReplacedMachines: []string{"cp-3"}},
+	}))
+
+	md := plan.Groups[1]
+	g.Expect(md.Name).To(Equal("md-0"))
+	g.Expect(md.Steps).To(Equal([]upgradeplan.Step{
+		{NewMachines: 1, ReplacedMachines: []string{"md-1"}},
+		{NewMachines: 1, ReplacedMachines: []string{"md-2"}},
+	}))
+
+	g.Expect(plan.MaxAdditionalMachines).To(Equal(1))
+}
+
+func TestBuildRollingUpdateSurgeAndUnavailable(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := newSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.To(4)
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &anywherev1.WorkerNodesUpgradeRolloutStrategy{
+			Type: anywherev1.RollingUpdateStrategyType,
+			RollingUpdate: &anywherev1.WorkerNodesRollingUpdateParams{
+				MaxSurge:       2,
+				MaxUnavailable: 1,
+			},
+		}
+	})
+
+	machines := []clusterv1.Machine{
+		workerMachine("md-1", oldVersion, 1),
+		workerMachine("md-2", oldVersion, 2),
+		workerMachine("md-3", oldVersion, 3),
+		workerMachine("md-4", oldVersion, 4),
+	}
+
+	plan := upgradeplan.Build(spec, machines, nil, nil)
+	md := plan.Groups[1]
+	g.Expect(md.AdditionalMachines).To(Equal(2))
+	g.Expect(md.Steps).To(Equal([]upgradeplan.Step{
+		{NewMachines: 2, ReplacedMachines: []string{"md-1", "md-2", "md-3"}, Unavailable: 1},
+		{NewMachines: 2, ReplacedMachines: []string{"md-4"}},
+	}))
+	g.Expect(plan.MaxAdditionalMachines).To(Equal(2))
+}
+
+func TestBuildControlPlaneWithoutSurge(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := newSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &anywherev1.ControlPlaneUpgradeRolloutStrategy{
+			Type:          anywherev1.RollingUpdateStrategyType,
+			RollingUpdate: &anywherev1.ControlPlaneRollingUpdateParams{MaxSurge: 0},
+		}
+	})
+
+	machines := []clusterv1.Machine{
+		controlPlaneMachine("cp-1", oldVersion, 1),
+		controlPlaneMachine("cp-2", oldVersion, 2),
+		controlPlaneMachine("cp-3", oldVersion, 3),
+	}
+
+	cp := upgradeplan.Build(spec, machines, nil, nil).Groups[0]
+	g.Expect(cp.MaxUnavailable).To(Equal(1))
+	g.Expect(cp.AdditionalMachines).To(Equal(0))
+	g.Expect(cp.Steps).To(Equal([]upgradeplan.Step{
+		{ReplacedMachines: []string{"cp-1"}, Unavailable: 1},
+		{NewMachines: 1, ReplacedMachines: []string{"cp-2"}, Unavailable: 1},
+		{NewMachines: 1, ReplacedMachines: []string{"cp-3"}, Unavailable: 1},
+		{NewMachines: 1},
+	}))
+}
+
+func TestBuildInPlace(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := newSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &anywherev1.ControlPlaneUpgradeRolloutStrategy{
+			Type: anywherev1.InPlaceStrategyType,
+		}
+		s.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &anywherev1.WorkerNodesUpgradeRolloutStrategy{
+			Type: anywherev1.InPlaceStrategyType,
+		}
+	})
+
+	machines := []clusterv1.Machine{
+		controlPlaneMachine("cp-1", oldVersion, 1),
+		workerMachine("md-1", oldVersion, 1),
+	}
+
+	plan := upgradeplan.Build(spec, machines, nil, nil)
+	g.Expect(plan.MaxAdditionalMachines).To(Equal(0))
+	g.Expect(plan.Groups[0].Steps).To(Equal([]upgradeplan.Step{{InPlaceMachines: []string{"cp-1"}, Unavailable: 1}}))
+	g.Expect(plan.Groups[1].Steps).To(Equal([]upgradeplan.Step{{InPlaceMachines: []string{"md-1"}, Unavailable: 1}}))
+}
+
+func TestBuildExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := newSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 1}
+	})
+
+	machines := []clusterv1.Machine{
+		machine("etcd-1", "", 1, map[string]string{"cluster.x-k8s.io/etcd-cluster": "test-etcd"}),
+		controlPlaneMachine("cp-1", oldVersion, 1),
+	}
+
+	plan := upgradeplan.Build(spec, machines, nil, nil)
+	g.Expect(plan.Groups[0].Kind).To(Equal(upgradeplan.EtcdGroup))
+	g.Expect(plan.Groups[0].Steps).To(Equal([]upgradeplan.Step{{NewMachines: 1, ReplacedMachines: []string{"etcd-1"}}}))
+}
+
+func TestBuildExternalEtcdRetainsReplacedMachines(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := newSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+	})
+
+	etcdLabels := map[string]string{"cluster.x-k8s.io/etcd-cluster": "test-etcd"}
+	machines := []clusterv1.Machine{
+		machine("etcd-1", "", 1, etcdLabels),
+		machine("etcd-2", "", 2, etcdLabels),
+		machine("etcd-3", "", 3, etcdLabels),
+		controlPlaneMachine("cp-1", oldVersion, 1),
+		controlPlaneMachine("cp-2", oldVersion, 2),
+		controlPlaneMachine("cp-3", oldVersion, 3),
+	}
+
+	plan := upgradeplan.Build(spec, machines, nil, nil)
+	etcd := plan.Groups[0]
+	g.Expect(etcd.MaxSurge).To(Equal(3))
+	g.Expect(etcd.AdditionalMachines).To(Equal(3))
+	g.Expect(etcd.Steps).To(Equal([]upgradeplan.Step{
+		{NewMachines: 1, ReplacedMachines: []string{"etcd-1"}},
+		{NewMachines: 1, ReplacedMachines: []string{"etcd-2"}},
+		{NewMachines: 1, ReplacedMachines: []string{"etcd-3"}},
+	}))
+
+	// The replaced etcd machines are kept while the control plane is upgraded.
+	g.Expect(plan.MaxAdditionalMachines).To(Equal(4))
+}
+
+func TestBuildExternalEtcdTemplateChanged(t *testing.T) {
+	g := NewWithT(t)
+
+	spec := newSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 1}
+	})
+
+	machines := []clusterv1.Machine{
+		machine("etcd-1", "", 1, map[string]string{"cluster.x-k8s.io/etcd-cluster": "test-etcd"}),
+		controlPlaneMachine("cp-1", newVersion, 1),
+	}
+
+	current := &upgradeplan.Objects{EtcdadmCluster: etcdadmCluster("etcd-template-1")}
+	desired := &upgradeplan.Objects{EtcdadmCluster: etcdadmCluster("etcd-template-2")}
+
+	plan := upgradeplan.Build(spec, machines, current, desired)
+	g.Expect(plan.Groups[0].Steps).To(Equal([]upgradeplan.Step{{NewMachines: 1, ReplacedMachines: []string{"etcd-1"}}}))
+	g.Expect(plan.Groups[1].Steps).To(BeEmpty())
+}
+
+func TestBuildControlPlaneTemplateChanged(t *testing.T) {
+	g := NewWithT(t)
+
+	machines := []clusterv1.Machine{
+		controlPlaneMachine("cp-1", newVersion, 1),
+		workerMachine("md-1", newVersion, 1),
+	}
+
+	current := &upgradeplan.Objects{KubeadmControlPlane: kubeadmControlPlane("cp-template-1", nil)}
+	desired := &upgradeplan.Objects{KubeadmControlPlane: kubeadmControlPlane("cp-template-2", nil)}
+
+	plan := upgradeplan.Build(singleMachineSpec(), machines, current, desired)
+	g.Expect(plan.Groups[0].Steps).To(Equal([]upgradeplan.Step{{NewMachines: 1, ReplacedMachines: []string{"cp-1"}}}))
+	g.Expect(plan.Groups[1].Steps).To(BeEmpty())
+}
+
+func TestBuildControlPlaneBootstrapConfigChanged(t *testing.T) {
+	g := NewWithT(t)
+
+	machines := []clusterv1.Machine{
+		controlPlaneMachine("cp-1", newVersion, 1),
+	}
+
+	current := &upgradeplan.Objects{KubeadmControlPlane: kubeadmControlPlane("cp-template", []string{"echo old"})}
+	desired := &upgradeplan.Objects{KubeadmControlPlane: kubeadmControlPlane("cp-template", []string{"echo new"})}
+
+	plan := upgradeplan.Build(singleMachineSpec(), machines, current, desired)
+	g.Expect(plan.Groups[0].Steps).To(Equal([]upgradeplan.Step{{NewMachines: 1, ReplacedMachines: []string{"cp-1"}}}))
+}
+
+func TestBuildControlPlaneBootstrapConfigDefaulted(t *testing.T) {
+	g := NewWithT(t)
+
+	machines := []clusterv1.Machine{
+		controlPlaneMachine("cp-1", newVersion, 1),
+	}
+
+	// Fields defaulted by the API server in the current object don't count as changes.
+	current := &upgradeplan.Objects{KubeadmControlPlane: kubeadmControlPlane("cp-template", []string{"echo"})}
+	current.KubeadmControlPlane.Spec.KubeadmConfigSpec.Format = bootstrapv1.CloudConfig
+	desired := &upgradeplan.Objects{KubeadmControlPlane: kubeadmControlPlane("cp-template", []string{"echo"})}
+
+	plan := upgradeplan.Build(singleMachineSpec(), machines, current, desired)
+	g.Expect(plan.Changed()).To(BeFalse())
+}
+
+func TestBuildWorkerNodeGroupTemplateChanged(t *testing.T) {
+	tests := []struct {
+		name                       string
+		currentInfra, desiredInfra string
+		currentConfig, desiredConf string
+	}{
+		{
+			name:          "infrastructure template",
+			currentInfra:  "md-template-1",
+			desiredInfra:  "md-template-2",
+			currentConfig: "md-config",
+			desiredConf:   "md-config",
+		},
+		{
+			name:          "bootstrap config template",
+			currentInfra:  "md-template",
+			desiredInfra:  "md-template",
+			currentConfig: "md-config-1",
+			desiredConf:   "md-config-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machines := []clusterv1.Machine{
+				controlPlaneMachine("cp-1", newVersion, 1),
+				workerMachine("md-1", newVersion, 1),
+			}
+
+			current := &upgradeplan.Objects{MachineDeployments: map[string]*clusterv1.MachineDeployment{
+				"test-md-0": machineDeployment(tt.currentInfra, tt.currentConfig),
+			}}
+			desired := &upgradeplan.Objects{MachineDeployments: map[string]*clusterv1.MachineDeployment{
+				"test-md-0": machineDeployment(tt.desiredInfra, tt.desiredConf),
+			}}
+
+			plan := upgradeplan.Build(singleMachineSpec(), machines, current, desired)
+			g.Expect(plan.Groups[0].Steps).To(BeEmpty())
+			g.Expect(plan.Groups[1].Steps).To(Equal([]upgradeplan.Step{{NewMachines: 1, ReplacedMachines: []string{"md-1"}}}))
+		})
+	}
+}
+
+func TestParseObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	controlPlane := []byte(`apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlane
+metadata:
+  name: test
+spec:
+  machineTemplate:
+    infrastructureRef:
+      name: cp-template
+---
+apiVersion: etcdcluster.cluster.x-k8s.io/v1beta1
+kind: EtcdadmCluster
+metadata:
+  name: test-etcd
+spec:
+  infrastructureTemplate:
+    name: etcd-template
+`)
+	workers := []byte(`apiVersion: cluster.x-k8s.io/v1beta1
+kind: MachineDeployment
+metadata:
+  name: test-md-0
+spec:
+  template:
+    spec:
+      bootstrap:
+        configRef:
+          name: md-config
+      infrastructureRef:
+        name: md-template
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: md-config
+`)
+
+	objs, err := upgradeplan.ParseObjects(controlPlane, workers)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(objs.KubeadmControlPlane.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("cp-template"))
+	g.Expect(objs.EtcdadmCluster.Spec.InfrastructureTemplate.Name).To(Equal("etcd-template"))
+	g.Expect(objs.MachineDeployments).To(HaveLen(1))
+	g.Expect(objs.MachineDeployments["test-md-0"].Spec.Template.Spec.InfrastructureRef.Name).To(Equal("md-template"))
+}
+
+func kubeadmControlPlane(infraTemplate string, preKubeadmCommands []string) *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{Name: infraTemplate},
+			},
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				PreKubeadmCommands: preKubeadmCommands,
+			},
+		},
+	}
+}
+
+func etcdadmCluster(infraTemplate string) *etcdv1.EtcdadmCluster {
+	return &etcdv1.EtcdadmCluster{
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: corev1.ObjectReference{Name: infraTemplate},
+		},
+	}
+}
+
+func machineDeployment(infraTemplate, configTemplate string) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		Spec: clusterv1.MachineDeploymentSpec{
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{Name: infraTemplate},
+					Bootstrap: clusterv1.Bootstrap{
+						ConfigRef: &corev1.ObjectReference{Name: configTemplate},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildUpToDate(t *testing.T) {
+	g := NewWithT(t)
+
+	machines := []clusterv1.Machine{
+		controlPlaneMachine("cp-1", newVersion, 1),
+		workerMachine("md-1", newVersion, 1),
+	}
+
+	plan := upgradeplan.Build(newSpec(), machines, nil, nil)
+	g.Expect(plan.Changed()).To(BeFalse())
+	g.Expect(plan.MaxAdditionalMachines).To(Equal(0))
+}
@@ -0,0 +1,71 @@
+package upgradeplan
+
+// rollingUpdate simulates a rolling update that replaces outdated machines so the group has
+// desired up to date machines. upToDate is the number of machines already up to date. In each
+// step new machines are created up to desired+maxSurge, then outdated machines are removed while
+// at least desired-maxUnavailable machines remain. It returns the steps and the peak number of
+// machines that exist above the current machine count.
+func rollingUpdate(outdated []string, upToDate, desired, maxSurge, maxUnavailable int) ([]Step, int) {
+	// A rollout without surge or unavailability can never progress. The API validates against
+	// this but defaulting here keeps the simulation from stalling.
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxSurge = 1
+	}
+
+	current := len(outdated) + upToDate
+	peak := 0
+
+	var steps []Step
+	for len(outdated) > 0 || upToDate < desired {
+		step := Step{}
+		total := len(outdated) + upToDate
+
+		if create := min(desired+maxSurge-total, desired-upToDate); create > 0 {
+			step.NewMachines = create
+			upToDate += create
+			total += create
+		}
+		peak = max(peak, total-current)
+
+		remove := min(len(outdated), total-(desired-maxUnavailable))
+		if remove > 0 {
+			step.ReplacedMachines = append([]string{}, outdated[:remove]...)
+			outdated = outdated[remove:]
+			total -= remove
+		}
+		step.Unavailable = max(0, desired-total)
+
+		if step.NewMachines == 0 && len(step.ReplacedMachines) == 0 {
+			break
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, peak
+}
+
+// inPlace upgrades each outdated machine one at a time without creating new machines.
+func inPlace(outdated []string) []Step {
+	steps := make([]Step, 0, len(outdated))
+	for _, name := range outdated {
+		steps = append(steps, Step{
+			InPlaceMachines: []string{name},
+			Unavailable:     1,
+		})
+	}
+	return steps
+}
+
+// retainedRollout replaces each outdated machine one at a time while keeping the replaced machines
+// running until the rollout of another group completes. It returns the steps and the peak number
+// of machines that exist above the current machine count.
+func retainedRollout(outdated []string) ([]Step, int) {
+	steps := make([]Step, 0, len(outdated))
+	for _, name := range outdated {
+		steps = append(steps, Step{
+			NewMachines:      1,
+			ReplacedMachines: []string{name},
+		})
+	}
+	return steps, len(outdated)
+}
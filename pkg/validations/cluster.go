@@ -36,7 +36,8 @@ const (
 // ValidateOSForRegistryMirror checks if the OS is valid for the provided registry mirror configuration.
 func ValidateOSForRegistryMirror(clusterSpec *cluster.Spec, provider providers.Provider) error {
 	cluster := clusterSpec.Cluster
-	if cluster.Spec.RegistryMirrorConfiguration == nil {
+	mirrorConfig := cluster.Spec.RegistryMirrorConfiguration
+	if mirrorConfig == nil {
 		return nil
 	}
 
@@ -46,12 +47,21 @@ func ValidateOSForRegistryMirror(clusterSpec *cluster.Spec, provider providers.P
 	}
 
 	for _, mc := range machineConfigs {
-		if mc.OSFamily() == v1alpha1.Bottlerocket && cluster.Spec.RegistryMirrorConfiguration.InsecureSkipVerify {
-			return errors.New("InsecureSkipVerify is not supported for bottlerocket")
+		if mc.OSFamily() != v1alpha1.Bottlerocket {
+			continue
+		}
+		for _, endpoint := range registryMirrorEndpoints(mirrorConfig) {
+			if endpoint.InsecureSkipVerify {
+				return errors.New("InsecureSkipVerify is not supported for bottlerocket")
+			}
+			// Bottlerocket trusts a single registry mirror CA certificate.
+			if endpoint.CACertContent != "" && endpoint.CACertContent != mirrorConfig.CACertContent {
+				return fmt.Errorf("registry mirror %s: a CA certificate different from the primary registry mirror is not supported for bottlerocket", endpoint.Endpoint)
+			}
 		}
 	}
 
-	ociNamespaces := cluster.Spec.RegistryMirrorConfiguration.OCINamespaces
+	ociNamespaces := mirrorConfig.OCINamespaces
 	if len(ociNamespaces) == 0 {
 		return nil
 	}
@@ -59,33 +69,71 @@ func ValidateOSForRegistryMirror(clusterSpec *cluster.Spec, provider providers.P
 	return nil
 }
 
+// registryMirrorEndpoints returns the primary registry mirror followed by its fallback mirrors.
+func registryMirrorEndpoints(mirrorConfig *v1alpha1.RegistryMirrorConfiguration) []v1alpha1.RegistryMirrorEndpoint {
+	primary := v1alpha1.RegistryMirrorEndpoint{
+		Endpoint:           mirrorConfig.Endpoint,
+		Port:               mirrorConfig.Port,
+		OCINamespaces:      mirrorConfig.OCINamespaces,
+		CACertContent:      mirrorConfig.CACertContent,
+		Authenticate:       mirrorConfig.Authenticate,
+		InsecureSkipVerify: mirrorConfig.InsecureSkipVerify,
+	}
+	return append([]v1alpha1.RegistryMirrorEndpoint{primary}, mirrorConfig.Mirrors...)
+}
+
+// ValidateCertForRegistryMirror checks if the certificate of each registry mirror is valid.
+// Unreachable fallback mirrors are only reported as long as one of the mirrors is reachable.
 func ValidateCertForRegistryMirror(clusterSpec *cluster.Spec, tlsValidator TlsValidator) error {
 	cluster := clusterSpec.Cluster
 	if cluster.Spec.RegistryMirrorConfiguration == nil {
 		return nil
 	}
 
-	if cluster.Spec.RegistryMirrorConfiguration.InsecureSkipVerify {
-		logger.V(1).Info("Warning: skip registry certificate verification is enabled", "registryMirrorConfiguration.insecureSkipVerify", true)
-		return nil
+	endpoints := registryMirrorEndpoints(cluster.Spec.RegistryMirrorConfiguration)
+	unreachable := 0
+	var reachErr error
+	for _, endpoint := range endpoints {
+		if endpoint.InsecureSkipVerify {
+			logger.V(1).Info("Warning: skip registry certificate verification is enabled", "registryMirror", endpoint.Endpoint, "insecureSkipVerify", true)
+			continue
+		}
+
+		authorityUnknown, err := tlsValidator.IsSignedByUnknownAuthority(endpoint.Endpoint, endpoint.Port)
+		if err != nil {
+			if len(endpoints) == 1 {
+				return fmt.Errorf("validating registry mirror endpoint: %v", err)
+			}
+			logger.Info(fmt.Sprintf("Warning: registry mirror endpoint %s is unreachable, falling back to the next registry mirror", endpoint.Endpoint), "error", err)
+			unreachable++
+			reachErr = err
+			continue
+		}
+
+		if err := validateRegistryMirrorCert(endpoint, authorityUnknown, tlsValidator); err != nil {
+			return err
+		}
 	}
 
-	host, port := cluster.Spec.RegistryMirrorConfiguration.Endpoint, cluster.Spec.RegistryMirrorConfiguration.Port
-	authorityUnknown, err := tlsValidator.IsSignedByUnknownAuthority(host, port)
-	if err != nil {
-		return fmt.Errorf("validating registry mirror endpoint: %v", err)
+	if unreachable == len(endpoints) {
+		return fmt.Errorf("validating registry mirror endpoints: none of the registry mirrors is reachable: %v", reachErr)
 	}
+
+	return nil
+}
+
+func validateRegistryMirrorCert(endpoint v1alpha1.RegistryMirrorEndpoint, authorityUnknown bool, tlsValidator TlsValidator) error {
 	if authorityUnknown {
-		logger.V(1).Info(fmt.Sprintf("Warning: registry mirror endpoint %s is using self-signed certs", cluster.Spec.RegistryMirrorConfiguration.Endpoint))
+		logger.V(1).Info(fmt.Sprintf("Warning: registry mirror endpoint %s is using self-signed certs", endpoint.Endpoint))
 	}
 
-	certContent := cluster.Spec.RegistryMirrorConfiguration.CACertContent
+	certContent := endpoint.CACertContent
 	if certContent == "" && authorityUnknown {
-		return fmt.Errorf("registry %s is using self-signed certs, please provide the certificate using caCertContent field. Or use insecureSkipVerify field to skip registry certificate verification", cluster.Spec.RegistryMirrorConfiguration.Endpoint)
+		return fmt.Errorf("registry %s is using self-signed certs, please provide the certificate using caCertContent field. Or use insecureSkipVerify field to skip registry certificate verification", endpoint.Endpoint)
 	}
 
 	if certContent != "" {
-		if err = tlsValidator.ValidateCert(host, port, certContent); err != nil {
+		if err := tlsValidator.ValidateCert(endpoint.Endpoint, endpoint.Port, certContent); err != nil {
 			return fmt.Errorf("invalid registry certificate: %v", err)
 		}
 	}
@@ -94,11 +142,17 @@ func ValidateCertForRegistryMirror(clusterSpec *cluster.Spec, tlsValidator TlsVa
 }
 
 // ValidateAuthenticationForRegistryMirror checks if REGISTRY_USERNAME and REGISTRY_PASSWORD is set if authenticated registry mirrors are used.
+// The credentials of the n-th fallback mirror are read from REGISTRY_USERNAME_<n> and REGISTRY_PASSWORD_<n>.
 func ValidateAuthenticationForRegistryMirror(clusterSpec *cluster.Spec) error {
 	cluster := clusterSpec.Cluster
-	if cluster.Spec.RegistryMirrorConfiguration != nil && cluster.Spec.RegistryMirrorConfiguration.Authenticate {
-		_, _, err := config.ReadCredentials()
-		if err != nil {
+	if cluster.Spec.RegistryMirrorConfiguration == nil {
+		return nil
+	}
+	for i, endpoint := range registryMirrorEndpoints(cluster.Spec.RegistryMirrorConfiguration) {
+		if !endpoint.Authenticate {
+			continue
+		}
+		if _, _, err := config.ReadMirrorCredentials(i); err != nil {
 			return err
 		}
 	}
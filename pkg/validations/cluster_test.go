@@ -144,6 +144,45 @@ func TestValidateCertForRegistryMirrorInsecureSkip(t *testing.T) {
 	tt.Expect(validations.ValidateCertForRegistryMirror(tt.clusterSpec, tt.tlsValidator)).To(Succeed())
 }
 
+func TestValidateCertForRegistryMirrorFallbacks(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.RegistryMirrorEndpoint{
+		{Endpoint: "1.2.3.5", Port: "443", CACertContent: tt.certContent},
+		{Endpoint: "1.2.3.6", Port: "443", InsecureSkipVerify: true},
+	}
+	tt.tlsValidator.EXPECT().IsSignedByUnknownAuthority(tt.host, tt.port).Return(false, errors.New("connection refused"))
+	tt.tlsValidator.EXPECT().IsSignedByUnknownAuthority("1.2.3.5", "443").Return(true, nil)
+	tt.tlsValidator.EXPECT().ValidateCert("1.2.3.5", "443", tt.certContent).Return(nil)
+
+	tt.Expect(validations.ValidateCertForRegistryMirror(tt.clusterSpec, tt.tlsValidator)).To(Succeed())
+}
+
+func TestValidateCertForRegistryMirrorFallbackCertInvalid(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.RegistryMirrorEndpoint{
+		{Endpoint: "1.2.3.5", Port: "443"},
+	}
+	tt.tlsValidator.EXPECT().IsSignedByUnknownAuthority(tt.host, tt.port).Return(false, nil)
+	tt.tlsValidator.EXPECT().IsSignedByUnknownAuthority("1.2.3.5", "443").Return(true, nil)
+
+	tt.Expect(validations.ValidateCertForRegistryMirror(tt.clusterSpec, tt.tlsValidator)).To(
+		MatchError(ContainSubstring("registry 1.2.3.5 is using self-signed certs")),
+	)
+}
+
+func TestValidateCertForRegistryMirrorAllUnreachable(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.RegistryMirrorEndpoint{
+		{Endpoint: "1.2.3.5", Port: "443"},
+	}
+	tt.tlsValidator.EXPECT().IsSignedByUnknownAuthority(tt.host, tt.port).Return(false, errors.New("connection refused"))
+	tt.tlsValidator.EXPECT().IsSignedByUnknownAuthority("1.2.3.5", "443").Return(false, errors.New("connection refused"))
+
+	tt.Expect(validations.ValidateCertForRegistryMirror(tt.clusterSpec, tt.tlsValidator)).To(
+		MatchError("validating registry mirror endpoints: none of the registry mirrors is reachable: connection refused"),
+	)
+}
+
 func TestValidateAuthenticationForRegistryMirrorNoRegistryMirror(t *testing.T) {
 	tt := newTest(t, withTLS())
 	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration = nil
@@ -181,6 +220,21 @@ func TestValidateAuthenticationForRegistryMirrorAuthValid(t *testing.T) {
 	tt.Expect(validations.ValidateAuthenticationForRegistryMirror(tt.clusterSpec)).To(Succeed())
 }
 
+func TestValidateAuthenticationForRegistryMirrorFallbacks(t *testing.T) {
+	tt := newTest(t, withTLS())
+	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = []anywherev1.RegistryMirrorEndpoint{
+		{Endpoint: "1.2.3.5"},
+		{Endpoint: "1.2.3.6", Authenticate: true},
+	}
+	t.Setenv("REGISTRY_USERNAME_2", "username")
+
+	tt.Expect(validations.ValidateAuthenticationForRegistryMirror(tt.clusterSpec)).To(
+		MatchError("please set REGISTRY_PASSWORD_2 env var"))
+
+	t.Setenv("REGISTRY_PASSWORD_2", "password")
+	tt.Expect(validations.ValidateAuthenticationForRegistryMirror(tt.clusterSpec)).To(Succeed())
+}
+
 func TestValidateOSForRegistryMirrorNoRegistryMirror(t *testing.T) {
 	tt := newTest(t, withTLS())
 	tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration = nil
@@ -269,6 +323,54 @@ func TestValidateOSForRegistryMirrorInsecureSkipVerifyEnabled(t *testing.T) {
 	}
 }
 
+func TestValidateOSForRegistryMirrorFallbacksBottlerocket(t *testing.T) {
+	tests := []struct {
+		name    string
+		mirrors []anywherev1.RegistryMirrorEndpoint
+		wantErr string
+	}{
+		{
+			name:    "same ca cert",
+			mirrors: []anywherev1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5", CACertContent: "ca"}},
+		},
+		{
+			name:    "no ca cert",
+			mirrors: []anywherev1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5"}},
+		},
+		{
+			name:    "different ca cert",
+			mirrors: []anywherev1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5", CACertContent: "other"}},
+			wantErr: "registry mirror 1.2.3.5: a CA certificate different from the primary registry mirror is not supported for bottlerocket",
+		},
+		{
+			name:    "insecureSkipVerify",
+			mirrors: []anywherev1.RegistryMirrorEndpoint{{Endpoint: "1.2.3.5", InsecureSkipVerify: true}},
+			wantErr: "InsecureSkipVerify is not supported for bottlerocket",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tt := newTest(t, withTLS())
+			tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.CACertContent = "ca"
+			tt.clusterSpec.Cluster.Spec.RegistryMirrorConfiguration.Mirrors = test.mirrors
+			tt.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return([]providers.MachineConfig{
+				&anywherev1.VSphereMachineConfig{
+					Spec: anywherev1.VSphereMachineConfigSpec{
+						OSFamily: anywherev1.Bottlerocket,
+					},
+				},
+			})
+			err := validations.ValidateOSForRegistryMirror(tt.clusterSpec, tt.provider)
+			if test.wantErr != "" {
+				tt.Expect(err).To(MatchError(test.wantErr))
+			} else {
+				tt.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestValidateOSForRegistryMirrorNoPublicEcrRegistry(t *testing.T) {
 	tt := newTest(t, withTLS())
 	tests := []struct {
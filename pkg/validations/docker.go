@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
 
 const (
 	recommendedTotalMemory = 6200000000
 	requiredMajorVersion   = 20
+	requiredPodmanVersion  = 4
 )
 
 type DockerExecutable interface {
@@ -28,6 +30,22 @@ func CheckMinimumDockerVersion(ctx context.Context, dockerExecutable DockerExecu
 	return nil
 }
 
+// CheckMinimumContainerRuntimeVersion checks the version of the container runtime dockerExecutable runs.
+func CheckMinimumContainerRuntimeVersion(ctx context.Context, runtime executables.ContainerRuntime, dockerExecutable DockerExecutable) error {
+	if runtime != executables.PodmanRuntime {
+		return CheckMinimumDockerVersion(ctx, dockerExecutable)
+	}
+
+	installedMajorVersionInt, err := dockerExecutable.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if installedMajorVersionInt < requiredPodmanVersion {
+		return fmt.Errorf("minimum requirements for podman version have not been met. Install Podman version %d.x.x or above", requiredPodmanVersion)
+	}
+	return nil
+}
+
 func CheckDockerAllocatedMemory(ctx context.Context, dockerExecutable DockerExecutable) {
 	totalMemoryAllocated, err := dockerExecutable.AllocatedMemory(ctx)
 	if err != nil {
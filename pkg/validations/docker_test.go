@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/validations"
 	"github.com/aws/eks-anywhere/pkg/validations/mocks"
 )
@@ -87,3 +88,47 @@ func TestValidateDockerExecutable(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateContainerRuntimeVersion(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		runtime executables.ContainerRuntime
+		version int
+		wantErr string
+	}{
+		{
+			name:    "docker too old",
+			runtime: executables.DockerRuntime,
+			version: 19,
+			wantErr: "minimum requirements for docker version have not been met. Install Docker version 20.x.x or above",
+		},
+		{
+			name:    "podman too old",
+			runtime: executables.PodmanRuntime,
+			version: 3,
+			wantErr: "minimum requirements for podman version have not been met. Install Podman version 4.x.x or above",
+		},
+		{
+			name:    "podman",
+			runtime: executables.PodmanRuntime,
+			version: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			dockerExecutableMock := mocks.NewMockDockerExecutable(mockCtrl)
+			dockerExecutableMock.EXPECT().Version(ctx).Return(tc.version, nil)
+			err := validations.CheckMinimumContainerRuntimeVersion(ctx, tc.runtime, dockerExecutableMock)
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("CheckMinimumContainerRuntimeVersion() error = %v, want nil", err)
+			}
+			if tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Errorf("CheckMinimumContainerRuntimeVersion() error = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+}
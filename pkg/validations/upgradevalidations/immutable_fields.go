@@ -173,6 +173,20 @@ func ValidateGitOpsImmutableFields(ctx context.Context, k validations.KubectlCli
 			}
 		}
 
+		if prevGitOps.Spec.Gitlab != nil {
+			prev, cur := prevGitOps.Spec.Gitlab, clusterSpec.FluxConfig.Spec.Gitlab
+			if cur == nil || prev.Repository != cur.Repository || prev.Owner != cur.Owner || prev.Personal != cur.Personal || prev.BaseURL != cur.BaseURL {
+				return errors.New("fluxConfig spec.gitlab repository, owner, personal and baseURL are immutable")
+			}
+		}
+
+		if prevGitOps.Spec.Gitea != nil {
+			prev, cur := prevGitOps.Spec.Gitea, clusterSpec.FluxConfig.Spec.Gitea
+			if cur == nil || prev.Repository != cur.Repository || prev.Owner != cur.Owner || prev.Personal != cur.Personal || prev.BaseURL != cur.BaseURL {
+				return errors.New("fluxConfig spec.gitea repository, owner, personal and baseURL are immutable")
+			}
+		}
+
 		if prevGitOps.Spec.Branch != clusterSpec.FluxConfig.Spec.Branch {
 			return errors.New("fluxConfig spec.branch is immutable")
 		}
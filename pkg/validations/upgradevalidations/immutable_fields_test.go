@@ -147,6 +147,42 @@ func TestValidateGitOpsImmutableFieldsFluxConfig(t *testing.T) {
 			},
 			wantErr: "fluxConfig spec.github.repository is immutable",
 		},
+		{
+			name: "gitlab base url diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						BaseURL: "https://a.example.com",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitlab: &v1alpha1.GitlabProviderConfig{
+						BaseURL: "https://b.example.com",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitlab repository, owner, personal and baseURL are immutable",
+		},
+		{
+			name: "gitea owner diff",
+			new: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitea: &v1alpha1.GiteaProviderConfig{
+						Owner: "a",
+					},
+				},
+			},
+			old: &v1alpha1.FluxConfig{
+				Spec: v1alpha1.FluxConfigSpec{
+					Gitea: &v1alpha1.GiteaProviderConfig{
+						Owner: "b",
+					},
+				},
+			},
+			wantErr: "fluxConfig spec.gitea repository, owner, personal and baseURL are immutable",
+		},
 		{
 			name: "github owner diff",
 			new: &v1alpha1.FluxConfig{
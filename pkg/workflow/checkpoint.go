@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Checkpointer is implemented by tasks that can record their outputs so a resumed workflow can
+// skip them. Tasks that don't implement Checkpointer are always run.
+type Checkpointer interface {
+	// Checkpoint serializes the outputs of the task. It is called with the context returned by
+	// the task and its post task hooks.
+	Checkpoint(context.Context) (json.RawMessage, error)
+
+	// Restore populates ctx with the outputs recorded by Checkpoint instead of running the task.
+	Restore(context.Context, json.RawMessage) (context.Context, error)
+}
+
+// Checkpoints records the outputs of completed tasks.
+type Checkpoints struct {
+	// CompletedTasks maps task names to the data returned by the task's Checkpoint.
+	CompletedTasks map[TaskName]json.RawMessage `json:"completedTasks"`
+}
+
+// NewCheckpoints returns an empty Checkpoints instance.
+func NewCheckpoints() Checkpoints {
+	return Checkpoints{
+		CompletedTasks: make(map[TaskName]json.RawMessage),
+	}
+}
+
+// CheckpointStore persists Checkpoints between workflow executions.
+type CheckpointStore interface {
+	// Load retrieves previously saved checkpoints. If nothing has been saved it returns empty
+	// Checkpoints.
+	Load(context.Context) (Checkpoints, error)
+
+	// Save persists c overwriting any previously saved checkpoints.
+	Save(_ context.Context, c Checkpoints) error
+
+	// Delete removes any saved checkpoints. It is not an error if nothing has been saved.
+	Delete(context.Context) error
+}
@@ -0,0 +1,5 @@
+/*
+Package checkpoint contains workflow.CheckpointStore implementations used to persist the outputs
+of completed workflow tasks so a failed workflow can be resumed.
+*/
+package checkpoint
@@ -0,0 +1,68 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+// FileStore is a workflow.CheckpointStore that persists checkpoints to a YAML file.
+type FileStore struct {
+	// Path is the path of the checkpoint file.
+	Path string
+}
+
+// NewFileStore creates a FileStore that persists checkpoints to path.
+func NewFileStore(path string) FileStore {
+	return FileStore{Path: path}
+}
+
+// Load satisfies workflow.CheckpointStore.
+func (s FileStore) Load(context.Context) (workflow.Checkpoints, error) {
+	content, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return workflow.NewCheckpoints(), nil
+	}
+	if err != nil {
+		return workflow.Checkpoints{}, fmt.Errorf("reading checkpoint file: %v", err)
+	}
+
+	checkpoints := workflow.NewCheckpoints()
+	if err := yaml.Unmarshal(content, &checkpoints); err != nil {
+		return workflow.Checkpoints{}, fmt.Errorf("unmarshalling checkpoint file: %v", err)
+	}
+
+	return checkpoints, nil
+}
+
+// Save satisfies workflow.CheckpointStore.
+func (s FileStore) Save(_ context.Context, c workflow.Checkpoints) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshalling checkpoints: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), os.ModePerm); err != nil {
+		return fmt.Errorf("creating checkpoint directory: %v", err)
+	}
+
+	if err := os.WriteFile(s.Path, content, 0o600); err != nil {
+		return fmt.Errorf("writing checkpoint file: %v", err)
+	}
+
+	return nil
+}
+
+// Delete satisfies workflow.CheckpointStore.
+func (s FileStore) Delete(context.Context) error {
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing checkpoint file: %v", err)
+	}
+	return nil
+}
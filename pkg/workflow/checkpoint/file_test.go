@@ -0,0 +1,46 @@
+package checkpoint_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/workflow"
+	"github.com/aws/eks-anywhere/pkg/workflow/checkpoint"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	store := checkpoint.NewFileStore(filepath.Join(t.TempDir(), "nested", "checkpoint.yaml"))
+
+	loaded, err := store.Load(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(loaded.CompletedTasks).To(BeEmpty())
+
+	checkpoints := workflow.NewCheckpoints()
+	checkpoints.CompletedTasks["task1"] = json.RawMessage(`{"name":"bootstrap"}`)
+	g.Expect(store.Save(ctx, checkpoints)).To(Succeed())
+
+	loaded, err = store.Load(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(loaded.CompletedTasks).To(HaveKey(workflow.TaskName("task1")))
+	g.Expect(loaded.CompletedTasks["task1"]).To(MatchJSON(`{"name":"bootstrap"}`))
+
+	g.Expect(store.Delete(ctx)).To(Succeed())
+	g.Expect(store.Path).ToNot(BeAnExistingFile())
+	g.Expect(store.Delete(ctx)).To(Succeed())
+}
+
+func TestFileStoreLoadInvalidFile(t *testing.T) {
+	g := NewWithT(t)
+	path := filepath.Join(t.TempDir(), "checkpoint.yaml")
+	g.Expect(os.WriteFile(path, []byte("completedTasks: [invalid"), 0o600)).To(Succeed())
+
+	_, err := checkpoint.NewFileStore(path).Load(context.Background())
+	g.Expect(err).To(MatchError(ContainSubstring("unmarshalling checkpoint file")))
+}
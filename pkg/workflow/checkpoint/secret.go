@@ -0,0 +1,110 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+)
+
+// secretDataKey is the Secret data key checkpoints are stored under.
+const secretDataKey = "checkpoints"
+
+// SecretStore is a workflow.CheckpointStore that persists checkpoints to a Kubernetes Secret.
+// Secrets are used instead of ConfigMaps because task outputs may contain sensitive data such as
+// kubeconfig paths and credentials.
+type SecretStore struct {
+	client    kubernetes.Client
+	name      string
+	namespace string
+}
+
+// NewSecretStore creates a SecretStore that persists checkpoints to the Secret name in namespace.
+func NewSecretStore(client kubernetes.Client, name, namespace string) SecretStore {
+	return SecretStore{
+		client:    client,
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+// Load satisfies workflow.CheckpointStore.
+func (s SecretStore) Load(ctx context.Context) (workflow.Checkpoints, error) {
+	secret := &corev1.Secret{}
+	err := s.client.Get(ctx, s.name, s.namespace, secret)
+	if apierrors.IsNotFound(err) {
+		return workflow.NewCheckpoints(), nil
+	}
+	if err != nil {
+		return workflow.Checkpoints{}, fmt.Errorf("reading checkpoint secret: %v", err)
+	}
+
+	checkpoints := workflow.NewCheckpoints()
+	if data, ok := secret.Data[secretDataKey]; ok {
+		if err := json.Unmarshal(data, &checkpoints); err != nil {
+			return workflow.Checkpoints{}, fmt.Errorf("unmarshalling checkpoint secret: %v", err)
+		}
+	}
+
+	return checkpoints, nil
+}
+
+// Save satisfies workflow.CheckpointStore.
+func (s SecretStore) Save(ctx context.Context, c workflow.Checkpoints) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshalling checkpoints: %v", err)
+	}
+
+	secret := &corev1.Secret{}
+	err = s.client.Get(ctx, s.name, s.namespace, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.name,
+				Namespace: s.namespace,
+			},
+			Data: map[string][]byte{secretDataKey: data},
+		}
+		if err := s.client.Create(ctx, secret); err != nil {
+			return fmt.Errorf("creating checkpoint secret: %v", err)
+		}
+	case err != nil:
+		return fmt.Errorf("reading checkpoint secret: %v", err)
+	default:
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[secretDataKey] = data
+		if err := s.client.Update(ctx, secret); err != nil {
+			return fmt.Errorf("updating checkpoint secret: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Delete satisfies workflow.CheckpointStore.
+func (s SecretStore) Delete(ctx context.Context) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.name,
+			Namespace: s.namespace,
+		},
+	}
+	if err := s.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting checkpoint secret: %v", err)
+	}
+	return nil
+}
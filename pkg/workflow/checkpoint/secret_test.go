@@ -0,0 +1,52 @@
+package checkpoint_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+	"github.com/aws/eks-anywhere/pkg/workflow/checkpoint"
+)
+
+func TestSecretStoreRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	store := checkpoint.NewSecretStore(client, "my-cluster-checkpoint", constants.EksaSystemNamespace)
+
+	loaded, err := store.Load(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(loaded.CompletedTasks).To(BeEmpty())
+
+	checkpoints := workflow.NewCheckpoints()
+	checkpoints.CompletedTasks["task1"] = json.RawMessage(`"value1"`)
+	g.Expect(store.Save(ctx, checkpoints)).To(Succeed())
+
+	checkpoints.CompletedTasks["task2"] = json.RawMessage(`"value2"`)
+	g.Expect(store.Save(ctx, checkpoints)).To(Succeed())
+
+	loaded, err = store.Load(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(loaded.CompletedTasks).To(HaveLen(2))
+	g.Expect(loaded.CompletedTasks["task2"]).To(MatchJSON(`"value2"`))
+
+	g.Expect(store.Delete(ctx)).To(Succeed())
+	err = client.Get(ctx, "my-cluster-checkpoint", constants.EksaSystemNamespace, &corev1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(store.Delete(ctx)).To(Succeed())
+}
+
+func TestSecretStoreLoadError(t *testing.T) {
+	g := NewWithT(t)
+	store := checkpoint.NewSecretStore(test.NewFakeKubeClientAlwaysError(), "checkpoint", constants.EksaSystemNamespace)
+
+	_, err := store.Load(context.Background())
+	g.Expect(err).To(MatchError(ContainSubstring("reading checkpoint secret")))
+}
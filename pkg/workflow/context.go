@@ -0,0 +1,33 @@
+package workflow
+
+import "context"
+
+// mergedContext combines the contexts returned by multiple tasks so a task that depends on
+// several others can observe all of their context values. Deadlines and cancellation are
+// inherited from the first context.
+type mergedContext struct {
+	context.Context
+	others []context.Context
+}
+
+// mergeContexts returns a context whose values are looked up in each of ctxs in order. The first
+// context a key is found in wins. When a single context is provided it is returned as is.
+func mergeContexts(ctxs ...context.Context) context.Context {
+	if len(ctxs) == 1 {
+		return ctxs[0]
+	}
+	return mergedContext{Context: ctxs[0], others: ctxs[1:]}
+}
+
+// Value satisfies context.Context.
+func (c mergedContext) Value(key any) any {
+	if v := c.Context.Value(key); v != nil {
+		return v
+	}
+	for _, ctx := range c.others {
+		if v := ctx.Value(key); v != nil {
+			return v
+		}
+	}
+	return nil
+}
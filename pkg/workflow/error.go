@@ -19,3 +19,14 @@ type ErrDuplicateTaskName struct {
 func (e ErrDuplicateTaskName) Error() string {
 	return fmt.Sprintf("duplicate task name: %v", e.Name)
 }
+
+// ErrUnknownDependency indicates a task declared a dependency on a task that hasn't been added to
+// the workflow.
+type ErrUnknownDependency struct {
+	Name       TaskName
+	Dependency TaskName
+}
+
+func (e ErrUnknownDependency) Error() string {
+	return fmt.Sprintf("task %v depends on unknown task: %v", e.Name, e.Dependency)
+}
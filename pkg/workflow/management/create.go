@@ -7,17 +7,31 @@ import (
 	"github.com/aws/eks-anywhere/pkg/filewriter"
 	"github.com/aws/eks-anywhere/pkg/workflow"
 	"github.com/aws/eks-anywhere/pkg/workflow/task/bootstrap"
+	"github.com/aws/eks-anywhere/pkg/workflow/task/preflight"
 	"github.com/aws/eks-anywhere/pkg/workflow/task/workload"
 )
 
 // Define tasks names for each task run as part of the create cluster workflow. To aid readability
 // the order of task names should be representative of the order of execution.
 const (
+	SetupAndValidate       workflow.TaskName = "SetupAndValidate"
 	CreateBootstrapCluster workflow.TaskName = "CreateBootstrapCluster"
 	CreateWorkloadCluster  workflow.TaskName = "CreateWorkloadCluster"
+	WriteClusterConfig     workflow.TaskName = "WriteClusterConfig"
+	InstallCuratedPackages workflow.TaskName = "InstallCuratedPackages"
 	DeleteBootstrapCluster workflow.TaskName = "DeleteBootstrapCluster"
 )
 
+// createClusterMaxConcurrency is the number of independent tasks run at the same time once the
+// workload cluster is created.
+const createClusterMaxConcurrency = 2
+
+// Provider is the infrastructure provider of the cluster being created.
+type Provider interface {
+	preflight.Provider
+	workload.ClusterConfigProvider
+}
+
 // CreateClusterHookRegistrar is a Hook registrar that binds hooks to a create management cluster
 // workflow.
 type CreateClusterHookRegistrar interface {
@@ -26,8 +40,14 @@ type CreateClusterHookRegistrar interface {
 
 // CreateCluster defines the configuration for a managment cluster creation workflow.
 // It executes tasks in the following order:
-//  1. CreateBootstrapCluster
-//  2. DeleteBootstrapCluster
+//  1. SetupAndValidate
+//  2. CreateBootstrapCluster
+//  3. CreateWorkloadCluster
+//  4. WriteClusterConfig and InstallCuratedPackages concurrently
+//  5. DeleteBootstrapCluster
+//
+// InstallCuratedPackages is only run when a PackageInstaller is configured. Every task is
+// checkpointed so a resumed workflow only runs the tasks that didn't complete.
 type CreateCluster struct {
 	// The spec used to construcft all other dependencies.
 	Spec *cluster.Spec
@@ -44,6 +64,26 @@ type CreateCluster struct {
 	// FS is a file system abstraction used to write files.
 	FS filewriter.FileWriter
 
+	// Provider sets up the infrastructure provider and supplies the datacenter and machine configs
+	// written to the cluster config file.
+	Provider Provider
+
+	// Validator supplies the preflight validations run before creating the cluster.
+	// Optional.
+	Validator preflight.Validator
+
+	// PackageInstaller installs curated packages in the workload cluster.
+	// Optional.
+	PackageInstaller workload.PackageInstaller
+
+	// CheckpointStore persists the outputs of completed tasks so the workflow can be resumed.
+	// Optional.
+	CheckpointStore workflow.CheckpointStore
+
+	// Resume restores tasks completed by a previous run from CheckpointStore instead of
+	// running them again.
+	Resume bool
+
 	// hookRegistrars are data structures that wish to bind runtime hooks to the workflow.
 	// They should be added via the WithHookRegistrar method.
 	hookRegistrars []CreateClusterHookRegistrar
@@ -66,13 +106,27 @@ func (c CreateCluster) Run(ctx context.Context) error {
 }
 
 func (c CreateCluster) build() (*workflow.Workflow, error) {
-	wflw := workflow.New(workflow.Config{})
+	wflw := workflow.New(workflow.Config{
+		CheckpointStore: c.CheckpointStore,
+		Resume:          c.Resume,
+		ClusterName:     c.Spec.Cluster.Name,
+		MaxConcurrency:  createClusterMaxConcurrency,
+	})
 
 	for _, r := range c.hookRegistrars {
 		r.RegisterCreateManagementClusterHooks(wflw)
 	}
 
-	err := wflw.AppendTask(CreateBootstrapCluster, bootstrap.CreateCluster{
+	err := wflw.AppendTask(SetupAndValidate, preflight.SetupAndValidateCreate{
+		Spec:      c.Spec,
+		Provider:  c.Provider,
+		Validator: c.Validator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = wflw.AppendTask(CreateBootstrapCluster, bootstrap.CreateCluster{
 		Spec:         c.Spec,
 		Options:      c.CreateBootstrapClusterOptions,
 		Bootstrapper: c.Bootstrapper,
@@ -89,9 +143,33 @@ func (c CreateCluster) build() (*workflow.Workflow, error) {
 		return nil, err
 	}
 
+	// Once the workload cluster is created, writing its config and installing packages into it
+	// don't depend on one another.
+	err = wflw.AppendTask(WriteClusterConfig, workload.WriteClusterConfig{
+		Spec:     c.Spec,
+		Provider: c.Provider,
+		FS:       c.FS,
+	}, workflow.WithDependencies(CreateWorkloadCluster))
+	if err != nil {
+		return nil, err
+	}
+
+	deleteDependencies := []workflow.TaskName{WriteClusterConfig}
+	if c.PackageInstaller != nil {
+		err = wflw.AppendTask(InstallCuratedPackages, workload.InstallCuratedPackages{
+			Installer: c.PackageInstaller,
+		}, workflow.WithDependencies(CreateWorkloadCluster))
+		if err != nil {
+			return nil, err
+		}
+		deleteDependencies = append(deleteDependencies, InstallCuratedPackages)
+	}
+
+	// The bootstrap cluster is deleted last so a resumed workflow never restores a bootstrap
+	// cluster that no longer exists.
 	err = wflw.AppendTask(DeleteBootstrapCluster, bootstrap.DeleteCluster{
 		Bootstrapper: c.Bootstrapper,
-	})
+	}, workflow.WithDependencies(deleteDependencies...))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,286 @@
+package management_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/types"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/workflow"
+	"github.com/aws/eks-anywhere/pkg/workflow/management"
+)
+
+func TestCreateClusterRunsIndependentTasksConcurrently(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	// Resume from a checkpoint so the bootstrap and workload clusters aren't created.
+	store := &memoryCheckpointStore{checkpoints: createdClustersCheckpoints(t)}
+
+	// The package installer and writing the cluster config wait for one another so the workflow
+	// only completes if they run at the same time.
+	installing := make(chan struct{})
+	written := make(chan struct{})
+	installer := packageInstallerFunc(func(context.Context) {
+		close(installing)
+		select {
+		case <-written:
+		case <-time.After(5 * time.Second):
+		}
+	})
+	registrar := hookRegistrarFunc(func(b workflow.HookBinder) {
+		b.BindPostTaskHook(management.WriteClusterConfig, taskFunc(func(ctx context.Context) (context.Context, error) {
+			select {
+			case <-installing:
+				close(written)
+				return ctx, nil
+			case <-time.After(5 * time.Second):
+				return ctx, errors.New("curated packages weren't installed concurrently")
+			}
+		}))
+	})
+	bootstrapper := &fakeBootstrapper{exists: true, delete: func() error {
+		select {
+		case <-written:
+			return nil
+		default:
+			return errors.New("bootstrap cluster deleted before the cluster config was written")
+		}
+	}}
+
+	wflw := &management.CreateCluster{
+		Spec:             newSpec(),
+		Bootstrapper:     bootstrapper,
+		FS:               writer,
+		Provider:         &fakeProvider{},
+		PackageInstaller: installer,
+		CheckpointStore:  store,
+		Resume:           true,
+	}
+	wflw.WithHookRegistrar(registrar)
+
+	g.Expect(wflw.Run(ctx)).To(Succeed())
+	g.Expect(bootstrapper.deleted).To(BeTrue())
+	g.Expect(store.deleted).To(BeTrue())
+}
+
+func TestCreateClusterResumeSkipsPreflightValidations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	store := &memoryCheckpointStore{checkpoints: createdClustersCheckpoints(t)}
+	provider := &fakeProvider{}
+
+	wflw := management.CreateCluster{
+		Spec:            newSpec(),
+		Bootstrapper:    &fakeBootstrapper{exists: true, delete: func() error { return nil }},
+		FS:              writer,
+		Provider:        provider,
+		Validator:       failingValidator{},
+		CheckpointStore: store,
+		Resume:          true,
+	}
+
+	g.Expect(wflw.Run(ctx)).To(Succeed())
+	g.Expect(provider.setup).To(BeTrue())
+}
+
+func TestCreateClusterRunsPreflightValidations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	store := &memoryCheckpointStore{}
+	provider := &fakeProvider{}
+
+	wflw := management.CreateCluster{
+		Spec:            newSpec(),
+		Bootstrapper:    &fakeBootstrapper{},
+		FS:              writer,
+		Provider:        provider,
+		Validator:       failingValidator{},
+		CheckpointStore: store,
+	}
+
+	g.Expect(wflw.Run(ctx)).To(MatchError(ContainSubstring("validations failed")))
+	g.Expect(provider.setup).To(BeTrue())
+	g.Expect(store.checkpoints.CompletedTasks).To(BeEmpty())
+}
+
+func TestCreateClusterResumeBootstrapClusterNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	store := &memoryCheckpointStore{checkpoints: createdClustersCheckpoints(t)}
+
+	wflw := management.CreateCluster{
+		Spec:            newSpec(),
+		Bootstrapper:    &fakeBootstrapper{exists: false},
+		FS:              writer,
+		Provider:        &fakeProvider{},
+		CheckpointStore: store,
+		Resume:          true,
+	}
+
+	g.Expect(wflw.Run(ctx)).To(MatchError(ContainSubstring("bootstrap cluster bootstrap recorded in the checkpoint no longer exists")))
+	g.Expect(store.deleted).To(BeFalse())
+}
+
+func TestCreateClusterCheckpointsEveryTask(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, writer := test.NewWriter(t)
+
+	store := &memoryCheckpointStore{checkpoints: createdClustersCheckpoints(t)}
+
+	wflw := management.CreateCluster{
+		Spec:         newSpec(),
+		Bootstrapper: &fakeBootstrapper{exists: true, delete: func() error { return errors.New("delete failed") }},
+		FS:           writer,
+		Provider:     &fakeProvider{},
+		PackageInstaller: packageInstallerFunc(func(context.Context) {
+		}),
+		CheckpointStore: store,
+		Resume:          true,
+	}
+
+	g.Expect(wflw.Run(ctx)).To(MatchError(ContainSubstring("delete failed")))
+	g.Expect(store.checkpoints.CompletedTasks).To(HaveKey(management.SetupAndValidate))
+	g.Expect(store.checkpoints.CompletedTasks).To(HaveKey(management.CreateBootstrapCluster))
+	g.Expect(store.checkpoints.CompletedTasks).To(HaveKey(management.CreateWorkloadCluster))
+	g.Expect(store.checkpoints.CompletedTasks).To(HaveKey(management.WriteClusterConfig))
+	g.Expect(store.checkpoints.CompletedTasks).To(HaveKey(management.InstallCuratedPackages))
+	g.Expect(store.checkpoints.CompletedTasks).ToNot(HaveKey(management.DeleteBootstrapCluster))
+}
+
+func newSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Name = "test-cluster"
+	})
+}
+
+func createdClustersCheckpoints(t *testing.T) workflow.Checkpoints {
+	return workflow.Checkpoints{
+		CompletedTasks: map[workflow.TaskName]json.RawMessage{
+			management.SetupAndValidate:       json.RawMessage("{}"),
+			management.CreateBootstrapCluster: mustMarshal(t, &types.Cluster{Name: "bootstrap"}),
+			management.CreateWorkloadCluster:  mustMarshal(t, &types.Cluster{Name: "test-cluster"}),
+		},
+	}
+}
+
+type memoryCheckpointStore struct {
+	checkpoints workflow.Checkpoints
+	deleted     bool
+}
+
+func (s *memoryCheckpointStore) Load(context.Context) (workflow.Checkpoints, error) {
+	return s.checkpoints, nil
+}
+
+func (s *memoryCheckpointStore) Save(_ context.Context, c workflow.Checkpoints) error {
+	s.checkpoints = c
+	return nil
+}
+
+func (s *memoryCheckpointStore) Delete(context.Context) error {
+	s.deleted = true
+	return nil
+}
+
+type packageInstallerFunc func(context.Context)
+
+func (f packageInstallerFunc) InstallCuratedPackages(ctx context.Context) {
+	f(ctx)
+}
+
+type hookRegistrarFunc func(workflow.HookBinder)
+
+func (f hookRegistrarFunc) RegisterCreateManagementClusterHooks(b workflow.HookBinder) {
+	f(b)
+}
+
+type taskFunc func(context.Context) (context.Context, error)
+
+func (f taskFunc) RunTask(ctx context.Context) (context.Context, error) {
+	return f(ctx)
+}
+
+type fakeBootstrapper struct {
+	exists  bool
+	deleted bool
+	delete  func() error
+}
+
+func (b *fakeBootstrapper) BootstrapClusterExists(context.Context, *types.Cluster) (bool, error) {
+	return b.exists, nil
+}
+
+func (b *fakeBootstrapper) CreateBootstrapCluster(context.Context, *cluster.Spec, ...bootstrapper.BootstrapClusterOption) (*types.Cluster, error) {
+	return nil, errors.New("bootstrap cluster should be restored")
+}
+
+func (b *fakeBootstrapper) DeleteBootstrapCluster(context.Context, *types.Cluster, constants.Operation, bool) error {
+	if err := b.delete(); err != nil {
+		return err
+	}
+	b.deleted = true
+	return nil
+}
+
+type fakeProvider struct {
+	setup bool
+}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) SetupAndValidateCreateCluster(context.Context, *cluster.Spec) error {
+	p.setup = true
+	return nil
+}
+
+func (fakeProvider) DatacenterConfig(*cluster.Spec) providers.DatacenterConfig {
+	return &v1alpha1.VSphereDatacenterConfig{}
+}
+
+func (fakeProvider) MachineConfigs(*cluster.Spec) []providers.MachineConfig {
+	return nil
+}
+
+type failingValidator struct{}
+
+func (failingValidator) PreflightValidations(context.Context) []validations.Validation {
+	return []validations.Validation{
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name: "cluster name is unique",
+				Err:  errors.New("cluster already exists"),
+			}
+		},
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) json.RawMessage {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -27,8 +27,30 @@ func (fn TaskFunc) RunTask(ctx context.Context) (context.Context, error) {
 	return fn(ctx)
 }
 
+// TaskOption configures how a task is scheduled when it is appended to a workflow.
+type TaskOption func(*namedTask)
+
+// WithDependencies declares the tasks that must complete before the task can run. Dependencies
+// must be appended to the workflow before the dependent task. Calling WithDependencies without
+// any names marks the task as having no dependencies so it can run as soon as the workflow starts.
+//
+// Tasks appended without WithDependencies depend on the task appended immediately before them,
+// which preserves serial execution for workflows that don't declare dependencies.
+func WithDependencies(names ...TaskName) TaskOption {
+	return func(t *namedTask) {
+		t.DependsOn = append([]TaskName{}, names...)
+		t.explicitDependencies = true
+	}
+}
+
 // namedTask associates a name with a Task in the context of a Workflow to enable hook lookup.
 type namedTask struct {
 	Task
 	Name TaskName
+
+	// DependsOn are the tasks that must complete successfully before this task can run.
+	DependsOn []TaskName
+
+	// explicitDependencies indicates DependsOn was configured with WithDependencies.
+	explicitDependencies bool
 }
@@ -2,7 +2,9 @@ package bootstrap
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/aws/eks-anywhere/pkg/bootstrapper"
 	"github.com/aws/eks-anywhere/pkg/cluster"
@@ -20,6 +22,9 @@ type OptionsRetriever interface {
 // Bootstrapper creates and destroys bootstrap clusters. It is satisfied by the bootstrap package
 // and exists predominently for testability.
 type Bootstrapper interface {
+	// BootstrapClusterExists checks if a cluster created with CreateBootstrapCluster still exists.
+	BootstrapClusterExists(context.Context, *types.Cluster) (bool, error)
+
 	// CreateCluster creates a new local cluster. It does not contain any EKS-A components.
 	CreateBootstrapCluster(
 		context.Context,
@@ -65,6 +70,36 @@ func (t CreateCluster) RunTask(ctx context.Context) (context.Context, error) {
 	return workflowcontext.WithBootstrapAsManagementCluster(ctx, cluster), nil
 }
 
+// Checkpoint satisfies workflow.Checkpointer. It records the bootstrap cluster so it can be
+// reused when the workflow is resumed.
+func (t CreateCluster) Checkpoint(ctx context.Context) (json.RawMessage, error) {
+	cluster := workflowcontext.BootstrapCluster(ctx)
+	if cluster == nil {
+		return nil, errors.New("bootstrap cluster not found in context")
+	}
+
+	return json.Marshal(cluster)
+}
+
+// Restore satisfies workflow.Checkpointer. It fails if the recorded bootstrap cluster no longer
+// exists as subsequent tasks can't run against it.
+func (t CreateCluster) Restore(ctx context.Context, checkpoint json.RawMessage) (context.Context, error) {
+	cluster := &types.Cluster{}
+	if err := json.Unmarshal(checkpoint, cluster); err != nil {
+		return ctx, err
+	}
+
+	exists, err := t.Bootstrapper.BootstrapClusterExists(ctx, cluster)
+	if err != nil {
+		return ctx, fmt.Errorf("checking bootstrap cluster %s exists: %v", cluster.Name, err)
+	}
+	if !exists {
+		return ctx, fmt.Errorf("bootstrap cluster %s recorded in the checkpoint no longer exists", cluster.Name)
+	}
+
+	return workflowcontext.WithBootstrapAsManagementCluster(ctx, cluster), nil
+}
+
 // DeleteCluster deletes a bootstrap cluster. It expects the bootstrap cluster to be
 // populated in the context using workflow.WithBootstrapCluster.
 type DeleteCluster struct {
@@ -85,3 +120,13 @@ func (t DeleteCluster) RunTask(ctx context.Context) (context.Context, error) {
 
 	return ctx, nil
 }
+
+// Checkpoint satisfies workflow.Checkpointer. The task has no outputs.
+func (t DeleteCluster) Checkpoint(context.Context) (json.RawMessage, error) {
+	return json.RawMessage("{}"), nil
+}
+
+// Restore satisfies workflow.Checkpointer.
+func (t DeleteCluster) Restore(ctx context.Context, _ json.RawMessage) (context.Context, error) {
+	return ctx, nil
+}
@@ -0,0 +1,83 @@
+// Package preflight contains workflow tasks that set up and validate the environment before a
+// cluster operation starts.
+package preflight
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+// Provider sets up and validates the infrastructure provider of a cluster being created. This is
+// typically satisfied by a provider.
+type Provider interface {
+	Name() string
+	SetupAndValidateCreateCluster(context.Context, *cluster.Spec) error
+}
+
+// Validator supplies the preflight validations run before creating a cluster.
+type Validator interface {
+	PreflightValidations(context.Context) []validations.Validation
+}
+
+// SetupAndValidateCreate sets up the provider and runs the preflight validations for a cluster
+// creation.
+//
+// When the workflow is resumed the cluster is partially created, so preflights such as
+// checking the cluster name is unique would fail. Restoring the task only sets up the provider,
+// which the rest of the workflow depends on.
+type SetupAndValidateCreate struct {
+	// Spec is the spec of the cluster to create.
+	Spec *cluster.Spec
+
+	// Provider is the infrastructure provider of the cluster.
+	Provider Provider
+
+	// Validator supplies the preflight validations.
+	// Optional.
+	Validator Validator
+}
+
+// RunTask satisfies workflow.Task.
+func (t SetupAndValidateCreate) RunTask(ctx context.Context) (context.Context, error) {
+	logger.Info("Performing setup and validations")
+	runner := validations.NewRunner()
+	runner.Register(t.providerValidation(ctx))
+	if t.Validator != nil {
+		runner.Register(t.Validator.PreflightValidations(ctx)...)
+	}
+
+	if err := runner.Run(); err != nil {
+		return ctx, err
+	}
+
+	return ctx, nil
+}
+
+// Checkpoint satisfies workflow.Checkpointer. The task has no outputs.
+func (t SetupAndValidateCreate) Checkpoint(context.Context) (json.RawMessage, error) {
+	return json.RawMessage("{}"), nil
+}
+
+// Restore satisfies workflow.Checkpointer.
+func (t SetupAndValidateCreate) Restore(ctx context.Context, _ json.RawMessage) (context.Context, error) {
+	logger.Info("Skipping preflight validations for resumed cluster creation")
+	if err := t.Provider.SetupAndValidateCreateCluster(ctx, t.Spec); err != nil {
+		return ctx, fmt.Errorf("setting up %s provider: %v", t.Provider.Name(), err)
+	}
+
+	return ctx, nil
+}
+
+func (t SetupAndValidateCreate) providerValidation(ctx context.Context) validations.Validation {
+	return func() *validations.ValidationResult {
+		return &validations.ValidationResult{
+			Name: fmt.Sprintf("%s Provider setup is valid", t.Provider.Name()),
+			Err:  t.Provider.SetupAndValidateCreateCluster(ctx, t.Spec),
+		}
+	}
+}
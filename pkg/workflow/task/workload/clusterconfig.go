@@ -0,0 +1,84 @@
+package workload
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clustermarshaller"
+	"github.com/aws/eks-anywhere/pkg/filewriter"
+	"github.com/aws/eks-anywhere/pkg/providers"
+)
+
+// ClusterConfigProvider supplies the provider specific objects of a cluster config. This is
+// typically satisfied by a provider.
+type ClusterConfigProvider interface {
+	DatacenterConfig(*cluster.Spec) providers.DatacenterConfig
+	MachineConfigs(*cluster.Spec) []providers.MachineConfig
+}
+
+// WriteClusterConfig writes the cluster config of the created cluster to disk. It doesn't
+// interact with any cluster so it can run concurrently with other tasks.
+type WriteClusterConfig struct {
+	// Spec is the spec of the cluster to write.
+	Spec *cluster.Spec
+
+	// Provider supplies the datacenter and machine configs of the cluster.
+	Provider ClusterConfigProvider
+
+	// FS is a file system abstraction providing file creation and write capabilities.
+	FS filewriter.FileWriter
+}
+
+// RunTask satisfies workflow.Task.
+func (t WriteClusterConfig) RunTask(ctx context.Context) (context.Context, error) {
+	err := clustermarshaller.WriteClusterConfig(
+		t.Spec,
+		t.Provider.DatacenterConfig(t.Spec),
+		t.Provider.MachineConfigs(t.Spec),
+		t.FS,
+	)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, nil
+}
+
+// Checkpoint satisfies workflow.Checkpointer. The task has no outputs.
+func (t WriteClusterConfig) Checkpoint(context.Context) (json.RawMessage, error) {
+	return json.RawMessage("{}"), nil
+}
+
+// Restore satisfies workflow.Checkpointer.
+func (t WriteClusterConfig) Restore(ctx context.Context, _ json.RawMessage) (context.Context, error) {
+	return ctx, nil
+}
+
+// PackageInstaller installs curated packages in a cluster.
+type PackageInstaller interface {
+	InstallCuratedPackages(ctx context.Context)
+}
+
+// InstallCuratedPackages installs the curated packages requested for the cluster. Failing to
+// install packages doesn't fail the cluster creation so the task never returns an error.
+type InstallCuratedPackages struct {
+	// Installer installs the packages.
+	Installer PackageInstaller
+}
+
+// RunTask satisfies workflow.Task.
+func (t InstallCuratedPackages) RunTask(ctx context.Context) (context.Context, error) {
+	t.Installer.InstallCuratedPackages(ctx)
+	return ctx, nil
+}
+
+// Checkpoint satisfies workflow.Checkpointer. The task has no outputs.
+func (t InstallCuratedPackages) Checkpoint(context.Context) (json.RawMessage, error) {
+	return json.RawMessage("{}"), nil
+}
+
+// Restore satisfies workflow.Checkpointer.
+func (t InstallCuratedPackages) Restore(ctx context.Context, _ json.RawMessage) (context.Context, error) {
+	return ctx, nil
+}
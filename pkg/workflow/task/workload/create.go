@@ -2,6 +2,8 @@ package workload
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -105,3 +107,24 @@ func (t Create) RunTask(ctx context.Context) (context.Context, error) {
 
 	return ctx, nil
 }
+
+// Checkpoint satisfies workflow.Checkpointer. It records the workload cluster so the cluster
+// isn't recreated when the workflow is resumed.
+func (t Create) Checkpoint(ctx context.Context) (json.RawMessage, error) {
+	cluster := workflowcontext.WorkloadCluster(ctx)
+	if cluster == nil {
+		return nil, errors.New("workload cluster not found in context")
+	}
+
+	return json.Marshal(cluster)
+}
+
+// Restore satisfies workflow.Checkpointer.
+func (t Create) Restore(ctx context.Context, checkpoint json.RawMessage) (context.Context, error) {
+	cluster := &types.Cluster{}
+	if err := json.Unmarshal(checkpoint, cluster); err != nil {
+		return ctx, err
+	}
+
+	return workflowcontext.WithWorkloadCluster(ctx, cluster), nil
+}
@@ -2,6 +2,11 @@ package workflow
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/events"
 )
 
 // Config is the configuration for constructing a Workflow instance.
@@ -10,9 +15,29 @@ type Config struct {
 	// from hook or from a task. The original error is alwasy returned from the workflow's Execute.
 	// Optional. Defaults to a no-op handler.
 	ErrorHandler ErrorHandler
+
+	// MaxConcurrency is the maximum number of tasks that may run at the same time. Tasks only run
+	// concurrently when they don't depend on one another.
+	// Optional. Defaults to 1.
+	MaxConcurrency int
+
+	// CheckpointStore persists the outputs of tasks implementing Checkpointer as they complete.
+	// Checkpoints are deleted once the workflow completes successfully.
+	// Optional. Defaults to no checkpointing.
+	CheckpointStore CheckpointStore
+
+	// Resume restores tasks recorded in CheckpointStore instead of running them.
+	// Optional. Ignored if CheckpointStore is nil.
+	Resume bool
+
+	// ClusterName is the name of the cluster the workflow operates on. It is included in the
+	// lifecycle events emitted to the events.Sink configured in the Execute context.
+	// Optional.
+	ClusterName string
 }
 
-// Workflow defines an abstract workflow that can execute a serialized set of tasks.
+// Workflow defines an abstract workflow that executes a set of tasks as a directed acyclic graph.
+// Tasks run once all the tasks they depend on have completed.
 type Workflow struct {
 	Config
 
@@ -35,6 +60,10 @@ func New(cfg Config) *Workflow {
 		cfg.ErrorHandler = nopErrorHandler
 	}
 
+	if cfg.MaxConcurrency < 1 {
+		cfg.MaxConcurrency = 1
+	}
+
 	wflw := &Workflow{
 		Config:        cfg,
 		taskNames:     make(map[TaskName]struct{}),
@@ -46,43 +75,284 @@ func New(cfg Config) *Workflow {
 }
 
 // AppendTask appends t to the list of workflow tasks. Task names must be unique within a workflow.
-// Duplicate names will receive an ErrDuplicateTaskName.
-func (w *Workflow) AppendTask(name TaskName, t Task) error {
+// Duplicate names will receive an ErrDuplicateTaskName. Dependencies declared with
+// WithDependencies must already be part of the workflow or an ErrUnknownDependency is returned.
+func (w *Workflow) AppendTask(name TaskName, t Task, opts ...TaskOption) error {
 	if _, found := w.taskNames[name]; found {
 		return ErrDuplicateTaskName{name}
 	}
-	w.tasks = append(w.tasks, namedTask{Task: t, Name: name})
+
+	task := namedTask{Task: t, Name: name}
+	for _, opt := range opts {
+		opt(&task)
+	}
+
+	if !task.explicitDependencies && len(w.tasks) > 0 {
+		task.DependsOn = []TaskName{w.tasks[len(w.tasks)-1].Name}
+	}
+
+	for _, dep := range task.DependsOn {
+		if _, found := w.taskNames[dep]; !found {
+			return ErrUnknownDependency{Name: name, Dependency: dep}
+		}
+	}
+
+	w.tasks = append(w.tasks, task)
 	w.taskNames[name] = struct{}{}
 	return nil
 }
 
 // Execute executes the workflow running any pre and post hooks registered for each task.
+// Pre and post task hooks run serially with their anchor task. If a task fails no further tasks
+// are started, tasks already running are allowed to complete and the first error is returned.
 func (w *Workflow) Execute(ctx context.Context) error {
 	var err error
 
-	if ctx, err = runHooks(ctx, w.preWorkflowHooks); err != nil {
+	if ctx, err = w.runHooks(ctx, preWorkflowHook, "", w.preWorkflowHooks); err != nil {
 		return w.handleError(ctx, err)
 	}
 
-	for _, task := range w.tasks {
-		if ctx, err = w.runPreTaskHooks(ctx, task.Name); err != nil {
-			return w.handleError(ctx, err)
+	if ctx, err = w.runTasks(ctx); err != nil {
+		return err
+	}
+
+	if ctx, err = w.runHooks(ctx, postWorkflowHook, "", w.postWorkflowHooks); err != nil {
+		return w.handleError(ctx, err)
+	}
+
+	if w.CheckpointStore != nil {
+		if err := w.CheckpointStore.Delete(ctx); err != nil {
+			return w.handleError(ctx, fmt.Errorf("deleting workflow checkpoints: %v", err))
 		}
+	}
+
+	return nil
+}
 
-		if ctx, err = task.RunTask(ctx); err != nil {
-			return w.handleError(ctx, err)
+// taskResult is the outcome of running a task and its hooks.
+type taskResult struct {
+	name TaskName
+	ctx  context.Context
+	err  error
+
+	// checkpoint is the data returned by a Checkpointer task. It is nil if the task doesn't
+	// implement Checkpointer or was restored.
+	checkpoint json.RawMessage
+}
+
+// runTasks runs the workflow tasks respecting their dependencies and w.MaxConcurrency. The
+// returned context merges the contexts of all tasks that no other task depends on.
+func (w *Workflow) runTasks(ctx context.Context) (context.Context, error) {
+	if len(w.tasks) == 0 {
+		return ctx, nil
+	}
+
+	checkpoints, err := w.loadCheckpoints(ctx)
+	if err != nil {
+		return ctx, w.handleError(ctx, err)
+	}
+
+	results := make(chan taskResult)
+	completed := make(map[TaskName]context.Context, len(w.tasks))
+	started := make(map[TaskName]struct{}, len(w.tasks))
+	running := 0
+
+	var firstErr error
+	for {
+		if firstErr == nil {
+			for _, task := range w.tasks {
+				if running >= w.MaxConcurrency {
+					break
+				}
+
+				if _, ok := started[task.Name]; ok {
+					continue
+				}
+
+				taskCtx, ready := dependencyContext(ctx, task, completed)
+				if !ready {
+					continue
+				}
+
+				started[task.Name] = struct{}{}
+				running++
+				checkpoint, restore := checkpoints.CompletedTasks[task.Name]
+				go func(task namedTask) {
+					if restore {
+						results <- w.restoreTask(taskCtx, task, checkpoint)
+						return
+					}
+					results <- w.runTask(taskCtx, task)
+				}(task)
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+
+		if result.err != nil {
+			w.handleError(result.ctx, result.err)
+			if firstErr == nil {
+				firstErr = result.err
+			}
+			continue
 		}
 
-		if ctx, err = w.runPostTaskHooks(ctx, task.Name); err != nil {
-			return w.handleError(ctx, err)
+		completed[result.name] = result.ctx
+
+		if w.CheckpointStore != nil && result.checkpoint != nil {
+			checkpoints.CompletedTasks[result.name] = result.checkpoint
+			if err := w.CheckpointStore.Save(ctx, checkpoints); err != nil {
+				err = fmt.Errorf("saving workflow checkpoints: %v", err)
+				w.handleError(result.ctx, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+			}
 		}
 	}
 
-	if ctx, err = runHooks(ctx, w.postWorkflowHooks); err != nil {
-		return w.handleError(ctx, err)
+	if firstErr != nil {
+		return ctx, firstErr
 	}
 
-	return nil
+	return mergeContexts(w.leafContexts(completed)...), nil
+}
+
+// loadCheckpoints retrieves the checkpoints to resume from. It returns empty Checkpoints when the
+// workflow isn't being resumed.
+func (w *Workflow) loadCheckpoints(ctx context.Context) (Checkpoints, error) {
+	if w.CheckpointStore == nil || !w.Resume {
+		return NewCheckpoints(), nil
+	}
+
+	checkpoints, err := w.CheckpointStore.Load(ctx)
+	if err != nil {
+		return Checkpoints{}, fmt.Errorf("loading workflow checkpoints: %v", err)
+	}
+
+	if checkpoints.CompletedTasks == nil {
+		checkpoints.CompletedTasks = make(map[TaskName]json.RawMessage)
+	}
+
+	return checkpoints, nil
+}
+
+// restoreTask restores task from a checkpoint if it implements Checkpointer, otherwise it runs
+// the task.
+func (w *Workflow) restoreTask(ctx context.Context, task namedTask, checkpoint json.RawMessage) taskResult {
+	checkpointer, ok := task.Task.(Checkpointer)
+	if !ok {
+		return w.runTask(ctx, task)
+	}
+
+	result := taskResult{name: task.Name}
+	result.ctx, result.err = checkpointer.Restore(ctx, checkpoint)
+	if result.err != nil {
+		result.err = fmt.Errorf("restoring task %v: %v", task.Name, result.err)
+		w.emit(ctx, events.Event{Type: events.TaskFailed, Task: string(task.Name), Error: result.err.Error()})
+		return result
+	}
+
+	w.emit(ctx, events.Event{Type: events.TaskRestored, Task: string(task.Name)})
+	return result
+}
+
+// runTask runs task and the pre and post hooks bound to it. If the task implements Checkpointer
+// and the workflow has a CheckpointStore the task's checkpoint is included in the result.
+func (w *Workflow) runTask(ctx context.Context, task namedTask) taskResult {
+	w.emit(ctx, events.Event{Type: events.TaskStarted, Task: string(task.Name)})
+	start := time.Now()
+
+	result := taskResult{name: task.Name}
+	result.ctx, result.err = w.runTaskWithHooks(ctx, task)
+	if result.err != nil {
+		w.emit(ctx, events.Event{
+			Type:            events.TaskFailed,
+			Task:            string(task.Name),
+			DurationSeconds: time.Since(start).Seconds(),
+			Error:           result.err.Error(),
+		})
+		return result
+	}
+
+	w.emit(ctx, events.Event{
+		Type:            events.TaskFinished,
+		Task:            string(task.Name),
+		DurationSeconds: time.Since(start).Seconds(),
+	})
+
+	if w.CheckpointStore == nil {
+		return result
+	}
+
+	checkpointer, ok := task.Task.(Checkpointer)
+	if !ok {
+		return result
+	}
+
+	result.checkpoint, result.err = checkpointer.Checkpoint(result.ctx)
+	if result.err != nil {
+		result.err = fmt.Errorf("checkpointing task %v: %v", task.Name, result.err)
+	}
+	return result
+}
+
+// runTaskWithHooks runs task and the pre and post hooks bound to it.
+func (w *Workflow) runTaskWithHooks(ctx context.Context, task namedTask) (context.Context, error) {
+	var err error
+
+	if ctx, err = w.runPreTaskHooks(ctx, task.Name); err != nil {
+		return ctx, err
+	}
+
+	if ctx, err = task.RunTask(ctx); err != nil {
+		return ctx, err
+	}
+
+	return w.runPostTaskHooks(ctx, task.Name)
+}
+
+// dependencyContext returns the context task should be run with and whether all of its
+// dependencies have completed. Tasks without dependencies receive ctx.
+func dependencyContext(ctx context.Context, task namedTask, completed map[TaskName]context.Context) (context.Context, bool) {
+	if len(task.DependsOn) == 0 {
+		return ctx, true
+	}
+
+	ctxs := make([]context.Context, 0, len(task.DependsOn))
+	for _, dep := range task.DependsOn {
+		depCtx, ok := completed[dep]
+		if !ok {
+			return nil, false
+		}
+		ctxs = append(ctxs, depCtx)
+	}
+
+	return mergeContexts(ctxs...), true
+}
+
+// leafContexts returns the contexts of completed tasks that no other task depends on in the order
+// the tasks were appended.
+func (w *Workflow) leafContexts(completed map[TaskName]context.Context) []context.Context {
+	dependedOn := make(map[TaskName]struct{}, len(w.tasks))
+	for _, task := range w.tasks {
+		for _, dep := range task.DependsOn {
+			dependedOn[dep] = struct{}{}
+		}
+	}
+
+	var ctxs []context.Context
+	for _, task := range w.tasks {
+		if _, ok := dependedOn[task.Name]; !ok {
+			ctxs = append(ctxs, completed[task.Name])
+		}
+	}
+	return ctxs
 }
 
 // BindPreWorkflowHook implements the HookBinder interface.
@@ -105,7 +375,7 @@ func (w *Workflow) BindPreTaskHook(id TaskName, t Task) {
 // RunpreTaskHooks executes all pre hooks registered against TaskName in the order they were registered.
 func (w *Workflow) runPreTaskHooks(ctx context.Context, id TaskName) (context.Context, error) {
 	if hooks, ok := w.preTaskHooks[id]; ok {
-		return runHooks(ctx, hooks)
+		return w.runHooks(ctx, preTaskHook, id, hooks)
 	}
 	return ctx, nil
 }
@@ -120,21 +390,46 @@ func (w *Workflow) BindPostTaskHook(id TaskName, t Task) {
 // RunpreTaskHooks executes all post hooks registered against TaskName in the order they were registered.
 func (w *Workflow) runPostTaskHooks(ctx context.Context, id TaskName) (context.Context, error) {
 	if hooks, ok := w.postTaskHooks[id]; ok {
-		return runHooks(ctx, hooks)
+		return w.runHooks(ctx, postTaskHook, id, hooks)
 	}
 	return ctx, nil
 }
 
-func runHooks(ctx context.Context, hooks []Task) (context.Context, error) {
-	var err error
+// Hook kinds reported in HookRan events.
+const (
+	preWorkflowHook  = "PreWorkflow"
+	postWorkflowHook = "PostWorkflow"
+	preTaskHook      = "PreTask"
+	postTaskHook     = "PostTask"
+)
+
+// runHooks runs hooks in order emitting a HookRan event for each. anchor is the task the hooks
+// are bound to and is empty for workflow hooks.
+func (w *Workflow) runHooks(ctx context.Context, kind string, anchor TaskName, hooks []Task) (context.Context, error) {
 	for _, hook := range hooks {
-		if ctx, err = hook.RunTask(ctx); err != nil {
-			return ctx, err
+		start := time.Now()
+		hookCtx, err := hook.RunTask(ctx)
+		w.emit(ctx, events.Event{
+			Type:            events.HookRan,
+			Task:            string(anchor),
+			Hook:            kind,
+			DurationSeconds: time.Since(start).Seconds(),
+			Error:           events.ErrorMessage(err),
+		})
+		if err != nil {
+			return hookCtx, err
 		}
+		ctx = hookCtx
 	}
 	return ctx, nil
 }
 
+// emit sends e to the events.Sink configured in ctx.
+func (w *Workflow) emit(ctx context.Context, e events.Event) {
+	e.Cluster = w.ClusterName
+	events.Emit(ctx, e)
+}
+
 func (w *Workflow) handleError(ctx context.Context, err error) error {
 	w.ErrorHandler(ctx, err)
 	return err
@@ -2,12 +2,16 @@ package workflow_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/onsi/gomega"
 
+	"github.com/aws/eks-anywhere/pkg/events"
 	"github.com/aws/eks-anywhere/pkg/workflow"
 )
 
@@ -348,3 +352,279 @@ func TestDuplicateTaskNames(t *testing.T) {
 	err = wflw.AppendTask(taskName, task2)
 	g.Expect(err).To(gomega.HaveOccurred())
 }
+
+func TestUnknownTaskDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	wflw := workflow.New(workflow.Config{})
+
+	err := wflw.AppendTask("task1", NewMockTask(ctrl), workflow.WithDependencies("missing"))
+	g.Expect(err).To(gomega.MatchError(workflow.ErrUnknownDependency{Name: "task1", Dependency: "missing"}))
+}
+
+func TestWorkflowExecuteIndependentTasksConcurrently(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	// Both tasks block until the other has started so the workflow can only complete if they run
+	// concurrently.
+	task1Started := make(chan struct{})
+	task2Started := make(chan struct{})
+
+	wflw := workflow.New(workflow.Config{MaxConcurrency: 2})
+
+	err := wflw.AppendTask("task1", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		close(task1Started)
+		<-task2Started
+		return ctx, nil
+	}), workflow.WithDependencies())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.AppendTask("task2", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		close(task2Started)
+		<-task1Started
+		return ctx, nil
+	}), workflow.WithDependencies())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.Execute(context.Background())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+}
+
+func TestWorkflowExecuteMergesDependencyContexts(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	type key string
+
+	wflw := workflow.New(workflow.Config{MaxConcurrency: 2})
+
+	err := wflw.AppendTask("task1", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return context.WithValue(ctx, key("task1"), "value1"), nil
+	}), workflow.WithDependencies())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.AppendTask("task2", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return context.WithValue(ctx, key("task2"), "value2"), nil
+	}), workflow.WithDependencies())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.AppendTask("task3", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		g.Expect(ctx.Value(key("task1"))).To(gomega.Equal("value1"))
+		g.Expect(ctx.Value(key("task2"))).To(gomega.Equal("value2"))
+		return ctx, nil
+	}), workflow.WithDependencies("task1", "task2"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	postWorkflowHookRan := false
+	wflw.BindPostWorkflowHook(workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		g.Expect(ctx.Value(key("task1"))).To(gomega.Equal("value1"))
+		g.Expect(ctx.Value(key("task2"))).To(gomega.Equal("value2"))
+		postWorkflowHookRan = true
+		return ctx, nil
+	}))
+
+	err = wflw.Execute(context.Background())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(postWorkflowHookRan).To(gomega.BeTrue())
+}
+
+func TestWorkflowExecuteErroneousTaskSkipsDependents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	g := gomega.NewWithT(t)
+
+	expect := errors.New("expected error")
+
+	task1 := NewMockTask(ctrl)
+	task1.EXPECT().
+		RunTask(gomock.Any()).
+		Return(context.Background(), expect)
+
+	// Depends on task1 so shouldn't run.
+	task2 := NewMockTask(ctrl)
+
+	var handled []error
+	wflw := workflow.New(workflow.Config{
+		MaxConcurrency: 2,
+		ErrorHandler: func(_ context.Context, err error) {
+			handled = append(handled, err)
+		},
+	})
+
+	err := wflw.AppendTask("task1", task1)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.AppendTask("task2", task2, workflow.WithDependencies("task1"))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError(expect))
+	g.Expect(handled).To(gomega.ConsistOf(expect))
+}
+
+type memoryCheckpointStore struct {
+	checkpoints *workflow.Checkpoints
+	deleted     bool
+}
+
+func (s *memoryCheckpointStore) Load(context.Context) (workflow.Checkpoints, error) {
+	if s.checkpoints == nil {
+		return workflow.NewCheckpoints(), nil
+	}
+	return *s.checkpoints, nil
+}
+
+func (s *memoryCheckpointStore) Save(_ context.Context, c workflow.Checkpoints) error {
+	saved := workflow.NewCheckpoints()
+	for k, v := range c.CompletedTasks {
+		saved.CompletedTasks[k] = v
+	}
+	s.checkpoints = &saved
+	return nil
+}
+
+func (s *memoryCheckpointStore) Delete(context.Context) error {
+	s.checkpoints = nil
+	s.deleted = true
+	return nil
+}
+
+type checkpointKey struct{}
+
+// checkpointTask records the value it places in the context so it can be restored.
+type checkpointTask struct {
+	value string
+	err   error
+	ran   *bool
+}
+
+func (t checkpointTask) RunTask(ctx context.Context) (context.Context, error) {
+	*t.ran = true
+	if t.err != nil {
+		return ctx, t.err
+	}
+	return context.WithValue(ctx, checkpointKey{}, t.value), nil
+}
+
+func (t checkpointTask) Checkpoint(ctx context.Context) (json.RawMessage, error) {
+	return json.Marshal(ctx.Value(checkpointKey{}))
+}
+
+func (t checkpointTask) Restore(ctx context.Context, data json.RawMessage) (context.Context, error) {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, checkpointKey{}, value), nil
+}
+
+func TestWorkflowExecuteSavesCheckpointsOnFailure(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	expect := errors.New("expected error")
+	store := &memoryCheckpointStore{}
+
+	var task1Ran, task2Ran bool
+
+	wflw := workflow.New(workflow.Config{CheckpointStore: store})
+
+	err := wflw.AppendTask("task1", checkpointTask{value: "value1", ran: &task1Ran})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.AppendTask("task2", checkpointTask{err: expect, ran: &task2Ran})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.Execute(context.Background())
+	g.Expect(err).To(gomega.MatchError(expect))
+	g.Expect(task1Ran).To(gomega.BeTrue())
+	g.Expect(task2Ran).To(gomega.BeTrue())
+	g.Expect(store.checkpoints).ToNot(gomega.BeNil())
+	g.Expect(store.checkpoints.CompletedTasks).To(gomega.HaveKeyWithValue(
+		workflow.TaskName("task1"), json.RawMessage(`"value1"`),
+	))
+	g.Expect(store.checkpoints.CompletedTasks).ToNot(gomega.HaveKey(workflow.TaskName("task2")))
+}
+
+func TestWorkflowExecuteResumesFromCheckpoints(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	checkpoints := workflow.NewCheckpoints()
+	checkpoints.CompletedTasks["task1"] = json.RawMessage(`"restored"`)
+	store := &memoryCheckpointStore{checkpoints: &checkpoints}
+
+	var task1Ran, task2Ran bool
+
+	wflw := workflow.New(workflow.Config{CheckpointStore: store, Resume: true})
+
+	err := wflw.AppendTask("task1", checkpointTask{value: "value1", ran: &task1Ran})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.AppendTask("task2", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		task2Ran = true
+		g.Expect(ctx.Value(checkpointKey{})).To(gomega.Equal("restored"))
+		return ctx, nil
+	}))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.Execute(context.Background())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(task1Ran).To(gomega.BeFalse())
+	g.Expect(task2Ran).To(gomega.BeTrue())
+	g.Expect(store.deleted).To(gomega.BeTrue())
+}
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (s *recordingSink) Emit(e events.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestWorkflowExecuteEmitsEvents(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	expect := errors.New("expected error")
+	sink := &recordingSink{}
+	ctx := events.WithSink(context.Background(), sink)
+
+	nop := workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return ctx, nil
+	})
+
+	wflw := workflow.New(workflow.Config{ClusterName: "my-cluster"})
+
+	err := wflw.AppendTask("task1", nop)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	err = wflw.AppendTask("task2", workflow.TaskFunc(func(ctx context.Context) (context.Context, error) {
+		return ctx, expect
+	}))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	wflw.BindPreWorkflowHook(nop)
+	wflw.BindPostTaskHook("task1", nop)
+
+	err = wflw.Execute(ctx)
+	g.Expect(err).To(gomega.MatchError(expect))
+
+	var got []string
+	for _, e := range sink.events {
+		g.Expect(e.Cluster).To(gomega.Equal("my-cluster"))
+		g.Expect(e.Timestamp).ToNot(gomega.BeZero())
+		got = append(got, fmt.Sprintf("%s/%s/%s", e.Type, e.Task, e.Hook))
+	}
+
+	g.Expect(got).To(gomega.Equal([]string{
+		"HookRan//PreWorkflow",
+		"TaskStarted/task1/",
+		"HookRan/task1/PostTask",
+		"TaskFinished/task1/",
+		"TaskStarted/task2/",
+		"TaskFailed/task2/",
+	}))
+	g.Expect(sink.events[len(sink.events)-1].Error).To(gomega.Equal(expect.Error()))
+}
@@ -21,6 +21,7 @@ type ClientFactory interface {
 }
 
 type Bootstrapper interface {
+	BootstrapClusterExists(ctx context.Context, cluster *types.Cluster) (bool, error)
 	CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.Spec, opts ...bootstrapper.BootstrapClusterOption) (*types.Cluster, error)
 	DeleteBootstrapCluster(context.Context, *types.Cluster, constants.Operation, bool) error
 }
@@ -51,6 +52,8 @@ type GitOpsManager interface {
 	ResumeClusterResourcesReconcile(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec, provider providers.Provider) error
 	UpdateGitEksaSpec(ctx context.Context, clusterSpec *cluster.Spec, datacenterConfig providers.DatacenterConfig, machineConfigs []providers.MachineConfig) error
 	ForceReconcileGitRepo(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	RecordDrift(ctx context.Context, cluster *types.Cluster, clusterSpec *cluster.Spec) error
+	PullRequestPending() bool
 	Validations(ctx context.Context, clusterSpec *cluster.Spec) []validations.Validation
 	CleanupGitRepo(ctx context.Context, clusterSpec *cluster.Spec) error
 	Install(ctx context.Context, cluster *types.Cluster, managementComponents *cluster.ManagementComponents, oldSpec, newSpec *cluster.Spec) error
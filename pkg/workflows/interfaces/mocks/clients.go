@@ -42,6 +42,21 @@ func (m *MockBootstrapper) EXPECT() *MockBootstrapperMockRecorder {
 	return m.recorder
 }
 
+// BootstrapClusterExists mocks base method.
+func (m *MockBootstrapper) BootstrapClusterExists(arg0 context.Context, arg1 *types.Cluster) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BootstrapClusterExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BootstrapClusterExists indicates an expected call of BootstrapClusterExists.
+func (mr *MockBootstrapperMockRecorder) BootstrapClusterExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrapClusterExists", reflect.TypeOf((*MockBootstrapper)(nil).BootstrapClusterExists), arg0, arg1)
+}
+
 // CreateBootstrapCluster mocks base method.
 func (m *MockBootstrapper) CreateBootstrapCluster(arg0 context.Context, arg1 *cluster.Spec, arg2 ...bootstrapper.BootstrapClusterOption) (*types.Cluster, error) {
 	m.ctrl.T.Helper()
@@ -437,6 +452,34 @@ func (mr *MockGitOpsManagerMockRecorder) PauseClusterResourcesReconcile(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseClusterResourcesReconcile", reflect.TypeOf((*MockGitOpsManager)(nil).PauseClusterResourcesReconcile), arg0, arg1, arg2, arg3)
 }
 
+// PullRequestPending mocks base method.
+func (m *MockGitOpsManager) PullRequestPending() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullRequestPending")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// PullRequestPending indicates an expected call of PullRequestPending.
+func (mr *MockGitOpsManagerMockRecorder) PullRequestPending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullRequestPending", reflect.TypeOf((*MockGitOpsManager)(nil).PullRequestPending))
+}
+
+// RecordDrift mocks base method.
+func (m *MockGitOpsManager) RecordDrift(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordDrift", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordDrift indicates an expected call of RecordDrift.
+func (mr *MockGitOpsManagerMockRecorder) RecordDrift(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDrift", reflect.TypeOf((*MockGitOpsManager)(nil).RecordDrift), arg0, arg1, arg2)
+}
+
 // ResumeClusterResourcesReconcile mocks base method.
 func (m *MockGitOpsManager) ResumeClusterResourcesReconcile(arg0 context.Context, arg1 *types.Cluster, arg2 *cluster.Spec, arg3 providers.Provider) error {
 	m.ctrl.T.Helper()
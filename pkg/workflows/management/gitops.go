@@ -3,11 +3,76 @@ package management
 import (
 	"context"
 
+	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/task"
 	"github.com/aws/eks-anywhere/pkg/workflows"
 )
 
+// proposeGitOpsChanges opens a pull request with the new cluster config when the GitOps config asks for changes
+// to go through pull requests. GitOps reconcile is paused first so Flux doesn't apply the merged changes before
+// the upgrade does. The upgrade only continues once the pull request is merged.
+type proposeGitOpsChanges struct{}
+
+// Run proposeGitOpsChanges stops the upgrade if the pull request is closed, not merged in time or, when the CLI
+// doesn't wait for it, not merged yet. In those cases GitOps reconcile is resumed and the cluster is left unchanged.
+func (s *proposeGitOpsChanges) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
+	if !gitOpsPullRequestEnabled(commandContext.ClusterSpec) {
+		return &updateSecrets{}
+	}
+
+	logger.Info("Pausing GitOps cluster resources reconcile")
+	err := commandContext.GitOpsManager.PauseClusterResourcesReconcile(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.Provider)
+	if err != nil {
+		commandContext.SetError(err)
+		return nil
+	}
+
+	logger.Info("Opening pull request with the new EKS-A cluster spec")
+	datacenterConfig := commandContext.Provider.DatacenterConfig(commandContext.ClusterSpec)
+	machineConfigs := commandContext.Provider.MachineConfigs(commandContext.ClusterSpec)
+	err = commandContext.GitOpsManager.UpdateGitEksaSpec(ctx, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
+	if err != nil {
+		commandContext.SetError(err)
+		resumeGitOpsReconcile(ctx, commandContext)
+		return nil
+	}
+
+	if commandContext.GitOpsManager.PullRequestPending() {
+		logger.Info("The cluster config changes are applied by Flux once the pull request is merged, skipping the upgrade")
+		resumeGitOpsReconcile(ctx, commandContext)
+		return nil
+	}
+
+	return &updateSecrets{}
+}
+
+// resumeGitOpsReconcile resumes GitOps reconcile when the upgrade stops before changing the cluster.
+func resumeGitOpsReconcile(ctx context.Context, commandContext *task.CommandContext) {
+	logger.Info("Resuming GitOps cluster resources kustomization")
+	if err := commandContext.GitOpsManager.ResumeClusterResourcesReconcile(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec, commandContext.Provider); err != nil {
+		commandContext.SetError(err)
+	}
+}
+
+func (s *proposeGitOpsChanges) Name() string {
+	return "propose-gitops-changes"
+}
+
+func (s *proposeGitOpsChanges) Checkpoint() *task.CompletedTask {
+	return &task.CompletedTask{
+		Checkpoint: nil,
+	}
+}
+
+func (s *proposeGitOpsChanges) Restore(ctx context.Context, commandContext *task.CommandContext, completedTask *task.CompletedTask) (task.Task, error) {
+	return &updateSecrets{}, nil
+}
+
+func gitOpsPullRequestEnabled(spec *cluster.Spec) bool {
+	return spec.FluxConfig != nil && spec.FluxConfig.Spec.PullRequest != nil
+}
+
 type pauseGitOpsReconcile struct{}
 
 // Run pauseGitOpsReconcile pause GitOps reconciler before management cluster upgrade.
@@ -37,22 +102,26 @@ func (s *pauseGitOpsReconcile) Restore(ctx context.Context, commandContext *task
 }
 
 // reconcileGitOps updates all the places that have a cluster definition to follow the cluster config provided to this workflow:
-// the cluster config in the git repo if GitOps is enabled. It also resumes the GitOps reconciliations.
+// the cluster config in the git repo if GitOps is enabled. It also resumes the GitOps reconciliations and records
+// the drift between the git repo and the cluster in the FluxConfig status.
 type reconcileGitOps struct{}
 
 // Run reconcileGitOps resumes GitOps reconciler and performs other GitOps related tasks after management cluster upgrade.
 func (s *reconcileGitOps) Run(ctx context.Context, commandContext *task.CommandContext) task.Task {
-	logger.Info("Updating Git Repo with new EKS-A cluster spec")
-	datacenterConfig := commandContext.Provider.DatacenterConfig(commandContext.ClusterSpec)
-	machineConfigs := commandContext.Provider.MachineConfigs(commandContext.ClusterSpec)
-	err := commandContext.GitOpsManager.UpdateGitEksaSpec(ctx, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
-	if err != nil {
-		commandContext.SetError(err)
-		return &workflows.CollectMgmtClusterDiagnosticsTask{}
+	// With pull requests the new cluster spec was merged before the upgrade.
+	if !gitOpsPullRequestEnabled(commandContext.ClusterSpec) {
+		logger.Info("Updating Git Repo with new EKS-A cluster spec")
+		datacenterConfig := commandContext.Provider.DatacenterConfig(commandContext.ClusterSpec)
+		machineConfigs := commandContext.Provider.MachineConfigs(commandContext.ClusterSpec)
+		err := commandContext.GitOpsManager.UpdateGitEksaSpec(ctx, commandContext.ClusterSpec, datacenterConfig, machineConfigs)
+		if err != nil {
+			commandContext.SetError(err)
+			return &workflows.CollectMgmtClusterDiagnosticsTask{}
+		}
 	}
 
 	logger.Info("Forcing reconcile Git repo with latest commit")
-	err = commandContext.GitOpsManager.ForceReconcileGitRepo(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec)
+	err := commandContext.GitOpsManager.ForceReconcileGitRepo(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec)
 	if err != nil {
 		commandContext.SetError(err)
 		return &workflows.CollectMgmtClusterDiagnosticsTask{}
@@ -65,6 +134,12 @@ func (s *reconcileGitOps) Run(ctx context.Context, commandContext *task.CommandC
 		return &writeUpgradeClusterConfig{}
 	}
 
+	// The drift is informational, so failing to record it doesn't fail the upgrade.
+	logger.Info("Recording GitOps drift in the FluxConfig status")
+	if err := commandContext.GitOpsManager.RecordDrift(ctx, commandContext.ManagementCluster, commandContext.ClusterSpec); err != nil {
+		logger.Info("Warning: failed to record GitOps drift", "error", err)
+	}
+
 	return &writeUpgradeClusterConfig{}
 }
 
@@ -290,6 +290,9 @@ func (c *upgradeManagementTestSetup) expectResumeGitOpsReconcile(err error) {
 			c.ctx, c.managementCluster, c.newClusterSpec, c.provider,
 		).Return(err),
 	)
+	if err == nil {
+		c.gitOpsManager.EXPECT().RecordDrift(c.ctx, c.managementCluster, c.newClusterSpec).Return(nil)
+	}
 }
 
 func (c *upgradeManagementTestSetup) expectWriteManagementClusterConfig(err error) {
@@ -813,6 +816,146 @@ func TestUpgradeManagementRunSuccess(t *testing.T) {
 	}
 }
 
+func TestUpgradeManagementRunRecordDriftFailed(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	features.ClearCache()
+	test := newUpgradeManagementClusterTest(t)
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectUpdateSecrets(nil)
+	test.expectEnsureManagementEtcdCAPIComponentsExist(nil)
+	test.expectUpgradeCoreComponents()
+	test.expectPauseGitOpsReconcile(nil)
+	test.expectBackupManagementFromCluster(nil)
+	test.expectPauseCAPIWorkloadClusters(nil)
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	test.expectInstallEksdManifest(nil)
+	test.expectApplyBundles(nil)
+	test.expectApplyReleases(nil)
+	test.expectUpgradeManagementCluster()
+	test.expectResumeCAPIWorkloadClustersAPI(nil)
+	test.expectUpdateGitEksaSpec(nil)
+	test.expectForceReconcileGitRepo(nil)
+	test.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(
+		test.ctx, test.managementCluster, test.newClusterSpec, test.provider,
+	).Return(nil)
+	test.gitOpsManager.EXPECT().RecordDrift(test.ctx, test.managementCluster, test.newClusterSpec).Return(errors.New("reading git repo"))
+	test.expectWriteManagementClusterConfig(nil)
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("UpgradeManagement.Run() err = %v, want err = nil", err)
+	}
+}
+
+func (c *upgradeManagementTestSetup) withGitOpsPullRequest() {
+	c.newClusterSpec.FluxConfig = &v1alpha1.FluxConfig{
+		Spec: v1alpha1.FluxConfigSpec{
+			PullRequest: &v1alpha1.PullRequestConfig{},
+		},
+	}
+}
+
+func TestUpgradeManagementRunGitOpsPullRequestNotMerged(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	features.ClearCache()
+	test := newUpgradeManagementClusterTest(t)
+	test.withGitOpsPullRequest()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	gomock.InOrder(
+		test.gitOpsManager.EXPECT().PauseClusterResourcesReconcile(test.ctx, test.managementCluster, test.newClusterSpec, test.provider).Return(nil),
+		test.gitOpsManager.EXPECT().UpdateGitEksaSpec(test.ctx, test.newClusterSpec, test.datacenterConfig, test.machineConfigs).
+			Return(errors.New("pull request was closed without being merged")),
+		test.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(test.ctx, test.managementCluster, test.newClusterSpec, test.provider).Return(nil),
+	)
+	test.expectWriteCheckpointFile()
+
+	err := test.run()
+	if err == nil {
+		t.Fatal("UpgradeManagement.Run() err = nil, want err not nil")
+	}
+}
+
+func TestUpgradeManagementRunGitOpsPullRequestPending(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	features.ClearCache()
+	test := newUpgradeManagementClusterTest(t)
+	test.withGitOpsPullRequest()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	// Without waiting for the merge the upgrade stops after opening the pull request.
+	gomock.InOrder(
+		test.gitOpsManager.EXPECT().PauseClusterResourcesReconcile(test.ctx, test.managementCluster, test.newClusterSpec, test.provider).Return(nil),
+		test.gitOpsManager.EXPECT().UpdateGitEksaSpec(test.ctx, test.newClusterSpec, test.datacenterConfig, test.machineConfigs).Return(nil),
+		test.gitOpsManager.EXPECT().PullRequestPending().Return(true),
+		test.gitOpsManager.EXPECT().ResumeClusterResourcesReconcile(test.ctx, test.managementCluster, test.newClusterSpec, test.provider).Return(nil),
+	)
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("UpgradeManagement.Run() err = %v, want err = nil", err)
+	}
+}
+
+func TestUpgradeManagementRunGitOpsPullRequestPauseFailed(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	features.ClearCache()
+	test := newUpgradeManagementClusterTest(t)
+	test.withGitOpsPullRequest()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectPauseGitOpsReconcile(errors.New("pausing"))
+	test.expectWriteCheckpointFile()
+
+	err := test.run()
+	if err == nil {
+		t.Fatal("UpgradeManagement.Run() err = nil, want err not nil")
+	}
+}
+
+func TestUpgradeManagementRunGitOpsPullRequestSuccess(t *testing.T) {
+	os.Unsetenv(features.CheckpointEnabledEnvVar)
+	features.ClearCache()
+	test := newUpgradeManagementClusterTest(t)
+	test.withGitOpsPullRequest()
+	test.expectSetup()
+	test.expectPreflightValidationsToPass()
+	test.expectDatacenterConfig()
+	test.expectMachineConfigs()
+	// GitOps reconcile is paused and the pull request is merged before the cluster is changed,
+	// and git isn't updated again afterwards.
+	gomock.InOrder(
+		test.gitOpsManager.EXPECT().PauseClusterResourcesReconcile(test.ctx, test.managementCluster, test.newClusterSpec, test.provider).Return(nil),
+		test.gitOpsManager.EXPECT().UpdateGitEksaSpec(test.ctx, test.newClusterSpec, test.datacenterConfig, test.machineConfigs).Return(nil),
+		test.gitOpsManager.EXPECT().PullRequestPending().Return(false),
+		test.provider.EXPECT().UpdateSecrets(test.ctx, test.managementCluster, test.newClusterSpec).Return(nil),
+	)
+	test.expectEnsureManagementEtcdCAPIComponentsExist(nil)
+	test.expectUpgradeCoreComponents()
+	test.expectPauseGitOpsReconcile(nil)
+	test.expectBackupManagementFromCluster(nil)
+	test.expectPauseCAPIWorkloadClusters(nil)
+	test.expectInstallEksdManifest(nil)
+	test.expectApplyBundles(nil)
+	test.expectApplyReleases(nil)
+	test.expectUpgradeManagementCluster()
+	test.expectResumeCAPIWorkloadClustersAPI(nil)
+	test.expectForceReconcileGitRepo(nil)
+	test.expectResumeGitOpsReconcile(nil)
+	test.expectWriteManagementClusterConfig(nil)
+
+	err := test.run()
+	if err != nil {
+		t.Fatalf("UpgradeManagement.Run() err = %v, want err = nil", err)
+	}
+}
+
 func TestTinkerbellUpgradeManagementRunSuccess(t *testing.T) {
 	os.Unsetenv(features.CheckpointEnabledEnvVar)
 	features.ClearCache()
@@ -73,7 +73,7 @@ func (s *setupAndValidateUpgrade) Run(ctx context.Context, commandContext *task.
 		return nil
 	}
 
-	return &updateSecrets{}
+	return &proposeGitOpsChanges{}
 }
 
 func (s *setupAndValidateUpgrade) providerValidation(ctx context.Context, commandContext *task.CommandContext) []validations.Validation {
@@ -103,7 +103,7 @@ func (s *setupAndValidateUpgrade) Restore(ctx context.Context, commandContext *t
 		return nil, err
 	}
 	commandContext.CurrentClusterSpec = currentSpec
-	return &updateSecrets{}, nil
+	return &proposeGitOpsChanges{}, nil
 }
 
 func (s *setupAndValidateUpgrade) Checkpoint() *task.CompletedTask {